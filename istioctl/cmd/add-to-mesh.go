@@ -40,6 +40,7 @@ import (
 	"istio.io/pkg/log"
 
 	"istio.io/istio/istioctl/pkg/util/handlers"
+	operatormesh "istio.io/istio/operator/cmd/mesh"
 	"istio.io/istio/pilot/pkg/model"
 	kube_registry "istio.io/istio/pilot/pkg/serviceregistry/kube"
 	"istio.io/istio/pkg/config/mesh"
@@ -139,6 +140,7 @@ THIS COMMAND IS STILL UNDER ACTIVE DEVELOPMENT AND NOT READY FOR PRODUCTION USE.
 
 	cmd.PersistentFlags().StringVar(&revision, "revision", "",
 		"control plane revision")
+	operatormesh.MarkRevisionFlagCompletion(cmd)
 
 	return cmd
 }
@@ -187,6 +189,7 @@ THIS COMMAND IS STILL UNDER ACTIVE DEVELOPMENT AND NOT READY FOR PRODUCTION USE.
 
 	cmd.PersistentFlags().StringVar(&revision, "revision", "",
 		"control plane revision")
+	operatormesh.MarkRevisionFlagCompletion(cmd)
 
 	return cmd
 }
@@ -30,6 +30,7 @@ import (
 	"istio.io/pkg/log"
 	"istio.io/pkg/version"
 
+	operatormesh "istio.io/istio/operator/cmd/mesh"
 	"istio.io/istio/pkg/config/mesh"
 	"istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/inject"
@@ -340,6 +341,7 @@ istioctl kube-inject -f samples/bookinfo/platform/kube/bookinfo.yaml \
 
 	injectCmd.PersistentFlags().StringVar(&revision, "revision", "",
 		"control plane revision")
+	operatormesh.MarkRevisionFlagCompletion(injectCmd)
 
 	return injectCmd
 }
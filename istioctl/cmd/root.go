@@ -23,10 +23,13 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"istio.io/istio/galley/pkg/config/analysis/analyzers"
 	"istio.io/istio/istioctl/pkg/install"
 	"istio.io/istio/istioctl/pkg/multicluster"
 	"istio.io/istio/istioctl/pkg/validate"
 	"istio.io/istio/operator/cmd/mesh"
+	"istio.io/istio/operator/pkg/injection"
+	"istio.io/istio/operator/pkg/preflight"
 	"istio.io/istio/pilot/pkg/serviceregistry/kube/controller"
 	"istio.io/istio/pkg/cmd"
 	"istio.io/pkg/collateral"
@@ -97,6 +100,7 @@ debug and diagnose their Istio mesh.
 
 	rootCmd.PersistentFlags().StringVar(&configContext, "context", "",
 		"The name of the kubeconfig context to use")
+	mesh.MarkContextFlagCompletion(rootCmd)
 
 	rootCmd.PersistentFlags().StringVarP(&istioNamespace, "istioNamespace", "i", controller.IstioNamespace,
 		"Istio system namespace")
@@ -154,6 +158,8 @@ debug and diagnose their Istio mesh.
 	postInstallCmd.AddCommand(Webhook())
 	experimentalCmd.AddCommand(postInstallCmd)
 
+	mesh.ValidateInjectionTemplates = injection.ValidateTemplates
+	preflight.Analyzers = analyzers.AllCombined
 	manifestCmd := mesh.ManifestCmd(loggingOptions)
 	hideInheritedFlags(manifestCmd, "namespace", "istioNamespace")
 	rootCmd.AddCommand(manifestCmd)
@@ -169,6 +175,22 @@ debug and diagnose their Istio mesh.
 
 	experimentalCmd.AddCommand(softGraduatedCmd(mesh.UpgradeCmd()))
 	rootCmd.AddCommand(mesh.UpgradeCmd())
+	rootCmd.AddCommand(mesh.RestoreCmd())
+
+	revisionCmd := mesh.RevisionCmd()
+	hideInheritedFlags(revisionCmd, "namespace")
+	rootCmd.AddCommand(revisionCmd)
+
+	effectiveConfigCmd := mesh.EffectiveConfigCmd()
+	hideInheritedFlags(effectiveConfigCmd, "namespace", "istioNamespace")
+	experimentalCmd.AddCommand(effectiveConfigCmd)
+
+	lastAppliedCmd := mesh.LastAppliedCmd()
+	hideInheritedFlags(lastAppliedCmd, "namespace")
+	experimentalCmd.AddCommand(lastAppliedCmd)
+
+	rootCmd.AddCommand(mesh.CompletionCmd())
+	rootCmd.BashCompletionFunction = mesh.BashCompletionFunc
 
 	experimentalCmd.AddCommand(multicluster.NewCreateRemoteSecretCommand())
 	experimentalCmd.AddCommand(multicluster.NewMulticlusterCommand())
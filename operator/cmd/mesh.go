@@ -17,10 +17,15 @@ package main
 import (
 	"os"
 
+	"istio.io/istio/galley/pkg/config/analysis/analyzers"
 	"istio.io/istio/operator/cmd/mesh"
+	"istio.io/istio/operator/pkg/injection"
+	"istio.io/istio/operator/pkg/preflight"
 )
 
 func main() {
+	mesh.ValidateInjectionTemplates = injection.ValidateTemplates
+	preflight.Analyzers = analyzers.AllCombined
 	rootCmd := mesh.GetRootCmd(os.Args[1:])
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
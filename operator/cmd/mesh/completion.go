@@ -0,0 +1,185 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/schema"
+)
+
+const (
+	// Kinds of values completed by the hidden __complete command.
+	completeSet      = "set"
+	completeContext  = "context"
+	completeRevision = "revision"
+	completeProfile  = "profile"
+
+	// istiodSelector selects the istiod deployments of all revisions.
+	istiodSelector = "app=istiod"
+	// revisionLabel is the label holding the revision of an istiod deployment.
+	revisionLabel = "istio.io/rev"
+
+	// BashCompletionFunc defines the bash functions which complete the values of --set, --context and --revision
+	// flags by calling the hidden __complete command. It must be added to the BashCompletionFunction of the root
+	// command.
+	BashCompletionFunc = `
+__istio_complete()
+{
+    local out
+    if out=$(${COMP_WORDS[0]} __complete "$1" "${cur}" 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
+__istio_complete_set()
+{
+    __istio_complete set
+}
+
+__istio_complete_context()
+{
+    __istio_complete context
+}
+
+__istio_complete_revision()
+{
+    __istio_complete revision
+}
+`
+)
+
+// CompletionCmd is a hidden command which prints the completions for a partial value of the given kind, one per line.
+// It is called by the functions in BashCompletionFunc.
+func CompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    "__complete <set|context|revision|profile> [partial value]",
+		Short:  "Prints completions for a partial flag value",
+		Hidden: true,
+		Args:   cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			toComplete := ""
+			if len(args) == 2 {
+				toComplete = args[1]
+			}
+			completions, err := complete(args[0], toComplete)
+			if err != nil {
+				return err
+			}
+			for _, c := range completions {
+				cmd.Println(c)
+			}
+			return nil
+		},
+	}
+}
+
+// complete returns the completions of kind for toComplete.
+func complete(kind, toComplete string) ([]string, error) {
+	var all []string
+	switch kind {
+	case completeSet:
+		if strings.HasPrefix(toComplete, "profile=") {
+			for _, p := range builtinProfiles() {
+				all = append(all, "profile="+p)
+			}
+			break
+		}
+		return schema.CompleteSetPath(toComplete), nil
+	case completeContext:
+		cfg, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+		if err != nil {
+			return nil, err
+		}
+		for c := range cfg.Contexts {
+			all = append(all, c)
+		}
+	case completeRevision:
+		revs, err := clusterRevisions()
+		if err != nil {
+			return nil, err
+		}
+		all = revs
+	case completeProfile:
+		all = builtinProfiles()
+	default:
+		return nil, fmt.Errorf("unknown completion kind %s", kind)
+	}
+	var out []string
+	for _, c := range all {
+		if strings.HasPrefix(c, toComplete) {
+			out = append(out, c)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// clusterRevisions returns the revisions of the istiod deployments in the cluster of the current kubeconfig context.
+func clusterRevisions() ([]string, error) {
+	_, clientSet, err := manifest.InitK8SRestClient("", "")
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := clientSet.AppsV1().Deployments(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{LabelSelector: istiodSelector})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var out []string
+	for _, d := range deployments.Items {
+		if rev := d.Labels[revisionLabel]; rev != "" && !seen[rev] {
+			seen[rev] = true
+			out = append(out, rev)
+		}
+	}
+	return out, nil
+}
+
+func builtinProfiles() []string {
+	profiles := helm.ListBuiltinProfiles()
+	sort.Strings(profiles)
+	return profiles
+}
+
+// markSetFlagCompletion sets up completion of IstioOperator paths for the --set flag of cmd.
+func markSetFlagCompletion(cmd *cobra.Command) {
+	_ = cmd.PersistentFlags().SetAnnotation("set", cobra.BashCompCustom, []string{"__istio_complete_set"})
+}
+
+// markFilenameFlagCompletion sets up completion of YAML file names for the --filename flag of cmd.
+func markFilenameFlagCompletion(cmd *cobra.Command) {
+	_ = cmd.MarkPersistentFlagFilename("filename", "yaml", "yml")
+}
+
+// MarkContextFlagCompletion sets up completion of kubeconfig contexts for the --context flag of cmd.
+func MarkContextFlagCompletion(cmd *cobra.Command) {
+	_ = cmd.PersistentFlags().SetAnnotation("context", cobra.BashCompCustom, []string{"__istio_complete_context"})
+}
+
+// MarkRevisionFlagCompletion sets up completion of the control plane revisions in the cluster for the --revision
+// flag of cmd.
+func MarkRevisionFlagCompletion(cmd *cobra.Command) {
+	_ = cmd.PersistentFlags().SetAnnotation("revision", cobra.BashCompCustom, []string{"__istio_complete_revision"})
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: cluster1
+  cluster:
+    server: https://cluster1.example.com
+users:
+- name: user1
+contexts:
+- name: prod-east
+  context:
+    cluster: cluster1
+    user: user1
+- name: prod-west
+  context:
+    cluster: cluster1
+    user: user1
+- name: staging
+  context:
+    cluster: cluster1
+    user: user1
+`
+
+func TestComplete(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "completion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	kubeconfig := filepath.Join(tmp, "config")
+	if err := ioutil.WriteFile(kubeconfig, []byte(testKubeconfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("KUBECONFIG", os.Getenv("KUBECONFIG"))
+	os.Setenv("KUBECONFIG", kubeconfig)
+
+	tests := []struct {
+		kind       string
+		toComplete string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			kind:       completeSet,
+			toComplete: "components.pil",
+			want:       []string{"components.pilot"},
+		},
+		{
+			kind:       completeSet,
+			toComplete: "profile=mi",
+			want:       []string{"profile=minimal"},
+		},
+		{
+			kind:       completeProfile,
+			toComplete: "de",
+			want:       []string{"default", "demo"},
+		},
+		{
+			kind:       completeContext,
+			toComplete: "prod",
+			want:       []string{"prod-east", "prod-west"},
+		},
+		{
+			kind:    "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind+":"+tt.toComplete, func(t *testing.T) {
+			got, err := complete(tt.kind, tt.toComplete)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+type effectiveConfigArgs struct {
+	// inFilenames is an array of paths to the input IstioOperator CR files.
+	inFilenames []string
+	// valuesFiles is an array of paths to helm values files which are applied to spec.values.
+	valuesFiles []string
+	// set is a string with element format "path=value" where path is an IstioOperator path and the value is a
+	// value to set the node at that path to.
+	set []string
+	// components is a list of components to enable, as an alias for --set enablement paths.
+	components []string
+	// disableComponents is a list of components to disable, as an alias for --set enablement paths.
+	disableComponents []string
+	// force proceeds even if there are validation errors
+	force bool
+	// charts is a path to a charts and profiles directory in the local filesystem, or URL with a release tgz.
+	charts string
+	// configPath sets the root node for the subtree to display the config for.
+	configPath string
+	// outputFormat controls the format of the output.
+	outputFormat string
+}
+
+func addEffectiveConfigFlags(cmd *cobra.Command, args *effectiveConfigArgs) {
+	cmd.PersistentFlags().StringSliceVarP(&args.inFilenames, "filename", "f", nil, filenameFlagHelpStr)
+	markFilenameFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.valuesFiles, "values", nil, valuesFlagHelpStr)
+	cmd.PersistentFlags().StringArrayVarP(&args.set, "set", "s", nil, SetFlagHelpStr)
+	markSetFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.components, "components", nil, componentsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.disableComponents, "disable-components", nil, disableComponentsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
+	cmd.PersistentFlags().StringVarP(&args.charts, "charts", "d", "", chartsFlagHelpStr)
+	cmd.PersistentFlags().StringVarP(&args.configPath, "config-path", "p", "",
+		"The path the root of the configuration subtree to print e.g. components.pilot. By default, print whole tree")
+	cmd.PersistentFlags().StringVarP(&args.outputFormat, "output", "o", yamlOutput,
+		"Output format: one of json|yaml")
+}
+
+// EffectiveConfigCmd is a command that prints the fully merged IstioOperator configuration.
+func EffectiveConfigCmd() *cobra.Command {
+	rootArgs := &rootArgs{}
+	ecArgs := &effectiveConfigArgs{}
+	cmd := &cobra.Command{
+		Use:   "effective-config",
+		Short: "Prints the effective IstioOperator configuration",
+		Long: "The effective-config command prints the configuration that results from merging the selected profile, " +
+			"any files given with -f and any --set flags, without rendering manifests.",
+		Example: `  # Print the effective configuration for the demo profile
+  istioctl x effective-config --set profile=demo
+
+  # Print only the pilot component settings of a custom configuration as JSON
+  istioctl x effective-config -f my-iop.yaml --config-path components.pilot -o json
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("effective-config accepts no positional arguments, got %#v", args)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return effectiveConfig(rootArgs, ecArgs, l)
+		},
+	}
+	addFlags(cmd, rootArgs)
+	addEffectiveConfigFlags(cmd, ecArgs)
+	return cmd
+}
+
+func effectiveConfig(rootArgs *rootArgs, ecArgs *effectiveConfigArgs, l clog.Logger) error {
+	initLogsOrExit(rootArgs)
+
+	switch ecArgs.outputFormat {
+	case jsonOutput, yamlOutput:
+	default:
+		return fmt.Errorf("unknown output format: %v", ecArgs.outputFormat)
+	}
+
+	setFlags, err := applyComponentFlagAliases(applyInstallFlagAlias(ecArgs.set, ecArgs.charts), ecArgs.components, ecArgs.disableComponents)
+	if err != nil {
+		return err
+	}
+	ysf, unsetPaths, err := yamlFromSetFlags(setFlags, ecArgs.force, l)
+	if err != nil {
+		return err
+	}
+	if ysf, err = overlayValuesFiles(ysf, ecArgs.valuesFiles, ecArgs.force, l); err != nil {
+		return err
+	}
+
+	y, _, err := GenerateConfig(ecArgs.inFilenames, ysf, unsetPaths, ecArgs.force, nil, l)
+	if err != nil {
+		return err
+	}
+
+	return printConfig(y, ecArgs.configPath, ecArgs.outputFormat, l)
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/util"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	scanAddonComponents(t)
+	testDataDir = filepath.Join(operatorRootDir, "cmd/mesh/testdata/profile-dump")
+	tests := []struct {
+		desc       string
+		configPath string
+	}{
+		{
+			desc: "all_off",
+		},
+		{
+			desc:       "config_path",
+			configPath: "components",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inPath := filepath.Join(testDataDir, "input", tt.desc+".yaml")
+			outPath := filepath.Join(testDataDir, "output", tt.desc+".yaml")
+
+			cmd := "effective-config --force -f " + inPath
+			if tt.configPath != "" {
+				cmd += " --config-path " + tt.configPath
+			}
+			got, err := runCommand(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Without any --set flags, the effective config is the same as the profile dump of the input file.
+			want, err := readFile(outPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !util.IsYAMLEqual(got, want) {
+				t.Errorf("effective-config command(%s): got:\n%s\n\nwant:\n%s\nDiff:\n%s\n", tt.desc, got, want, util.YAMLDiff(got, want))
+			}
+		})
+	}
+}
+
+func TestEffectiveConfigSetFlag(t *testing.T) {
+	scanAddonComponents(t)
+	inPath := filepath.Join(operatorRootDir, "cmd/mesh/testdata/profile-dump/input/config_path.yaml")
+	got, err := runCommand("effective-config --force -f " + inPath +
+		" --set components.pilot.enabled=true --config-path components.pilot -o json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `"enabled": true`) {
+		t.Errorf("effective-config --set: expect pilot to be enabled, got:\n%s", got)
+	}
+}
+
+// scanAddonComponents scans the addon components of the live charts, which is otherwise done by the first command
+// generating a config and fails for commands that do not set installPackagePath.
+func scanAddonComponents(t *testing.T) {
+	if err := name.ScanBundledAddonComponents(liveInstallPackageDir); err != nil {
+		t.Fatal(err)
+	}
+}
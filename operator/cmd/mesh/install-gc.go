@@ -0,0 +1,113 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/apis"
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+type installGCArgs struct {
+	// kubeConfigPath is the path to kube config file.
+	kubeConfigPath string
+	// context is the cluster context in the kube config
+	context string
+	// istioNamespace is the namespace holding the installed-state IstioOperator CRs.
+	istioNamespace string
+}
+
+func addInstallGCFlags(cmd *cobra.Command, args *installGCArgs) {
+	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
+	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+	MarkContextFlagCompletion(cmd)
+	cmd.PersistentFlags().StringVar(&args.istioNamespace, "istioNamespace", "istio-system",
+		"The namespace of the installed-state IstioOperator CRs.")
+}
+
+// installGCCmd is a command that removes the installed-state CRs of control planes which no longer exist.
+func installGCCmd() *cobra.Command {
+	rootArgs := &rootArgs{}
+	gcArgs := &installGCArgs{}
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Removes the installed state of control planes which no longer exist",
+		Long: "The gc subcommand deletes the installed-state IstioOperator CRs, and the manifests saved with them, of " +
+			"control plane revisions which have no istiod Deployment left in the cluster. Stale CRs otherwise show up " +
+			"in later diff, prune and upgrade operations as if their control plane was still installed.",
+		Example: `  # List the stale installed-state CRs without deleting them
+  istioctl install gc --dry-run
+
+  # Delete the stale installed-state CRs
+  istioctl install gc
+`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return installGC(rootArgs, gcArgs, l)
+		},
+	}
+	addFlags(cmd, rootArgs)
+	addInstallGCFlags(cmd, gcArgs)
+	return cmd
+}
+
+func installGC(rootArgs *rootArgs, gcArgs *installGCArgs, l clog.Logger) error {
+	initLogsOrExit(rootArgs)
+
+	restConfig, _, err := manifest.InitK8SRestClient(gcArgs.kubeConfigPath, gcArgs.context)
+	if err != nil {
+		return err
+	}
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return err
+	}
+	stale, err := helmreconciler.StaleInstalledStates(cl, gcArgs.istioNamespace, installedSpecCRPrefix)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		l.LogAndPrintf("No stale installed-state IstioOperator CRs found in namespace %s.", gcArgs.istioNamespace)
+		return nil
+	}
+	for _, iop := range stale {
+		rev := iop.Spec.Revision
+		if rev == "" {
+			rev = "default"
+		}
+		if rootArgs.dryRun {
+			l.LogAndPrintf("Dry run: would delete IstioOperator %s/%s, no istiod is running for revision %s.",
+				iop.Namespace, iop.Name, rev)
+			continue
+		}
+		if err := helmreconciler.DeleteInstalledState(cl, iop); err != nil {
+			return err
+		}
+		l.LogAndPrintf("Deleted IstioOperator %s/%s, no istiod is running for revision %s.", iop.Namespace, iop.Name, rev)
+	}
+	if !rootArgs.dryRun {
+		l.LogAndPrintf("Removed %d stale installed-state IstioOperator CRs.", len(stale))
+	}
+	return nil
+}
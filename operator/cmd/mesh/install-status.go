@@ -0,0 +1,393 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/operator/pkg/apis"
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+type installStatusArgs struct {
+	// kubeConfigPath is the path to kube config file.
+	kubeConfigPath string
+	// context is the cluster context in the kube config
+	context string
+	// istioNamespace is the namespace holding the installed-state IstioOperator CRs.
+	istioNamespace string
+	// outputFormat is the format of the output, one of table|json.
+	outputFormat string
+	// watch prints the status each time it changes until the installation is healthy or fails.
+	watch bool
+	// timeout is the maximum time to watch for.
+	timeout time.Duration
+}
+
+const (
+	tableOutput = "table"
+)
+
+// revisionStatus is the status of a control plane revision, as printed by install status.
+type revisionStatus struct {
+	Revision   string                            `json:"revision"`
+	Name       string                            `json:"name"`
+	Status     string                            `json:"status,omitempty"`
+	Provenance *helmreconciler.Provenance        `json:"provenance"`
+	Components []*helmreconciler.ComponentHealth `json:"components,omitempty"`
+	// ResourceErrors are the resources which failed to apply in the last reconcile.
+	ResourceErrors []helmreconciler.ResourceError `json:"resourceErrors,omitempty"`
+	// LastApply is what the last reconcile did to the objects of each component.
+	LastApply *helmreconciler.ApplyReport `json:"lastApply,omitempty"`
+}
+
+func addInstallStatusFlags(cmd *cobra.Command, args *installStatusArgs) {
+	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
+	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+	MarkContextFlagCompletion(cmd)
+	cmd.PersistentFlags().StringVar(&args.istioNamespace, "istioNamespace", "istio-system",
+		"The namespace of the installed-state IstioOperator CRs.")
+	cmd.PersistentFlags().StringVarP(&args.outputFormat, "output", "o", tableOutput,
+		"Output format: one of table|json")
+	cmd.PersistentFlags().BoolVar(&args.watch, "watch", false,
+		"Print the status each time it changes, and exit once all revisions are healthy, with an error once one of "+
+			"them fails. Can be used to wait for an install or an operator reconcile from another process.")
+	cmd.PersistentFlags().DurationVar(&args.timeout, "timeout", 300*time.Second,
+		"Maximum time to --watch for before exiting with an error")
+}
+
+// installStatusCmd is a command that prints which version and configuration manages each installed revision, and the
+// live health of its components.
+func installStatusCmd() *cobra.Command {
+	rootArgs := &rootArgs{}
+	statusArgs := &installStatusArgs{}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Prints the version, configuration and health of each installed revision",
+		Long: "The status subcommand prints, for each control plane revision installed with istioctl install, the " +
+			"version and git revision of the binary which last applied it, the profile it was applied with, the " +
+			"digest of the charts it was rendered from and the time of the apply. This is recorded in the " +
+			"annotations of the installed-state IstioOperator CRs. It then prints the health of each component, " +
+			"combining the status recorded in the CR with the replica readiness and versions of the live " +
+			"Deployments and DaemonSets and the state of the webhook configurations. With --watch, the status is " +
+			"printed again each time it changes until the installation is healthy or fails.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return installStatus(rootArgs, statusArgs, cmd.OutOrStdout(), l)
+		},
+	}
+	addFlags(cmd, rootArgs)
+	addInstallStatusFlags(cmd, statusArgs)
+	return cmd
+}
+
+func installStatus(rootArgs *rootArgs, statusArgs *installStatusArgs, w io.Writer, l clog.Logger) error {
+	initLogsOrExit(rootArgs)
+
+	if statusArgs.outputFormat != tableOutput && statusArgs.outputFormat != jsonOutput {
+		return fmt.Errorf("unknown output format: %v", statusArgs.outputFormat)
+	}
+	restConfig, _, err := manifest.InitK8SRestClient(statusArgs.kubeConfigPath, statusArgs.context)
+	if err != nil {
+		return err
+	}
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+	if statusArgs.watch {
+		return watchInstallStatus(restConfig, statusArgs, w, l)
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return err
+	}
+	statuses, err := revisionStatuses(cl, statusArgs.istioNamespace)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		l.LogAndPrintf("No installed-state IstioOperator CRs found in namespace %s.", statusArgs.istioNamespace)
+		return nil
+	}
+	return printInstallStatus(w, statuses, statusArgs.outputFormat)
+}
+
+// watchInstallStatus prints the status of the installed revisions each time it changes, until all of them are
+// healthy, one of them fails, or statusArgs.timeout passes. Changes are observed with informers on the
+// IstioOperator CRs and the component workloads and webhook configurations.
+func watchInstallStatus(restConfig *rest.Config, statusArgs *installStatusArgs, w io.Writer, l clog.Logger) error {
+	c, err := cache.New(restConfig, cache.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return err
+	}
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	handler := toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	}
+	watched := []runtime.Object{
+		&iopv1alpha1.IstioOperator{},
+		&appsv1.Deployment{},
+		&appsv1.DaemonSet{},
+		&admissionv1beta1.MutatingWebhookConfiguration{},
+		&admissionv1beta1.ValidatingWebhookConfiguration{},
+	}
+	for _, obj := range watched {
+		informer, err := c.GetInformer(context.TODO(), obj)
+		if err != nil {
+			return err
+		}
+		informer.AddEventHandler(handler)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		if err := c.Start(stop); err != nil {
+			l.LogAndErrorf("Failed to start the informers: %s", err)
+		}
+	}()
+	if !c.WaitForCacheSync(stop) {
+		return fmt.Errorf("failed to sync the informer caches")
+	}
+
+	timeout := time.After(statusArgs.timeout)
+	last := ""
+	for {
+		statuses, err := revisionStatuses(c, statusArgs.istioNamespace)
+		if err != nil {
+			return err
+		}
+		var b bytes.Buffer
+		if len(statuses) == 0 {
+			fmt.Fprintf(&b, "Waiting for installed-state IstioOperator CRs in namespace %s...\n", statusArgs.istioNamespace)
+		} else if err := printInstallStatus(&b, statuses, statusArgs.outputFormat); err != nil {
+			return err
+		}
+		if b.String() != last {
+			if last != "" && statusArgs.outputFormat == tableOutput {
+				fmt.Fprintln(w)
+			}
+			if _, err := w.Write(b.Bytes()); err != nil {
+				return err
+			}
+			last = b.String()
+		}
+		if done, err := installDone(statuses); done {
+			return err
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			return fmt.Errorf("timed out after %s waiting for the installation to become healthy", statusArgs.timeout)
+		}
+	}
+}
+
+// installDone reports whether the revisions in statuses reached a final state. This is the case once all of them are
+// HEALTHY with all the replicas of their workloads ready and a caBundle in all their webhooks, or as soon as one of
+// them is in ERROR, in which case the returned error lists the failed components.
+func installDone(statuses []*revisionStatus) (bool, error) {
+	if len(statuses) == 0 {
+		return false, nil
+	}
+	var errs util.Errors
+	healthy := true
+	for _, rs := range statuses {
+		if rs.Status == v1alpha1.InstallStatus_ERROR.String() {
+			for _, c := range rs.Components {
+				if c.Status == v1alpha1.InstallStatus_ERROR.String() {
+					errs = util.AppendErr(errs, fmt.Errorf("revision %s: component %s failed: %s", rs.Revision, c.Component, c.Error))
+				}
+			}
+			if len(errs) == 0 {
+				errs = util.AppendErr(errs, fmt.Errorf("revision %s failed", rs.Revision))
+			}
+			continue
+		}
+		if rs.Status != v1alpha1.InstallStatus_HEALTHY.String() {
+			healthy = false
+			continue
+		}
+		for _, c := range rs.Components {
+			if ready, desired := c.Ready(); ready < desired {
+				healthy = false
+			}
+			for _, wh := range c.Webhooks {
+				if wh.CABundles < wh.Webhooks {
+					healthy = false
+				}
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return true, fmt.Errorf("the installation failed:\n%s", util.ToString(errs, "\n"))
+	}
+	return healthy, nil
+}
+
+// revisionStatuses returns the status of each installed-state CR in istioNamespace and of its components.
+func revisionStatuses(cl client.Reader, istioNamespace string) ([]*revisionStatus, error) {
+	iops, err := helmreconciler.InstalledStates(cl, istioNamespace, installedSpecCRPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*revisionStatus
+	for _, iop := range iops {
+		rs := newRevisionStatus(iop)
+		if rs.Components, err = helmreconciler.GetComponentHealth(cl, iop); err != nil {
+			return nil, err
+		}
+		if rs.ResourceErrors, err = helmreconciler.ReadResourceErrors(cl, iop.Name, iop.Namespace); err != nil {
+			return nil, err
+		}
+		if rs.LastApply, err = helmreconciler.ReadApplyReport(cl, iop.Name, iop.Namespace); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, rs)
+	}
+	return statuses, nil
+}
+
+// printInstallStatus writes statuses to w in outputFormat.
+func printInstallStatus(w io.Writer, statuses []*revisionStatus, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		b, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+	return writeInstallStatus(w, statuses)
+}
+
+// newRevisionStatus returns the status of the revision of the installed-state CR iop, without its components.
+func newRevisionStatus(iop *iopv1alpha1.IstioOperator) *revisionStatus {
+	rs := &revisionStatus{
+		Revision:   defaultRevision,
+		Name:       iop.Name,
+		Provenance: helmreconciler.ReadProvenance(iop),
+	}
+	if iop.Spec != nil && iop.Spec.Revision != "" {
+		rs.Revision = iop.Spec.Revision
+	}
+	if iop.Status != nil {
+		rs.Status = iop.Status.Status.String()
+	}
+	return rs
+}
+
+// writeInstallStatus writes a table of the revisions and their provenance to w, followed by a table of the component
+// health and the failed resources of each revision. Values which were not recorded, e.g. because a CR was applied by
+// an older binary, are printed as "unknown".
+func writeInstallStatus(w io.Writer, statuses []*revisionStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "REVISION\tCR\tSTATUS\tVERSION\tGIT REVISION\tPROFILE\tCHART DIGEST\tINSTALLED")
+	for _, rs := range statuses {
+		p := rs.Provenance
+		installed := ""
+		if !p.Time.IsZero() {
+			installed = p.Time.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", rs.Revision, rs.Name, orUnknown(rs.Status), orUnknown(p.Version),
+			orUnknown(p.GitRevision), orUnknown(p.Profile), orUnknown(p.ChartDigest), orUnknown(installed))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, rs := range statuses {
+		fmt.Fprintf(w, "\nComponents of revision %s:\n", rs.Revision)
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "COMPONENT\tSTATUS\tVERSIONS\tREADY\tWEBHOOKS WITH CA\tERROR")
+		for _, c := range rs.Components {
+			ready := "-"
+			if len(c.Workloads) != 0 {
+				r, d := c.Ready()
+				ready = fmt.Sprintf("%d/%d", r, d)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", c.Component, orUnknown(c.Status), orUnknown(strings.Join(c.Versions(), ",")),
+				ready, webhookState(c.Webhooks), c.Error)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if len(rs.ResourceErrors) != 0 {
+			fmt.Fprintf(w, "Resources of revision %s which failed to apply:\n", rs.Revision)
+			for _, re := range rs.ResourceErrors {
+				fmt.Fprintf(w, "  %s: %s\n", re.Component, re)
+			}
+		}
+		if rs.LastApply != nil && len(rs.LastApply.Components) != 0 {
+			fmt.Fprintf(w, "Objects of revision %s in the last apply:\n", rs.Revision)
+			for _, c := range rs.LastApply.Components {
+				fmt.Fprintf(w, "  %s: %s\n", c.Component, c)
+			}
+		}
+	}
+	return nil
+}
+
+// webhookState summarizes the webhook configurations of a component as the number of webhooks with a caBundle out
+// of all its webhooks.
+func webhookState(whs []helmreconciler.WebhookHealth) string {
+	if len(whs) == 0 {
+		return "-"
+	}
+	ca, total := 0, 0
+	for _, wh := range whs {
+		ca += wh.CABundles
+		total += wh.Webhooks
+	}
+	return fmt.Sprintf("%d/%d", ca, total)
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/api/operator/v1alpha1"
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/helmreconciler"
+)
+
+func TestWriteInstallStatus(t *testing.T) {
+	recorded := &iopv1alpha1.IstioOperator{
+		Spec:   &v1alpha1.IstioOperatorSpec{Revision: "canary"},
+		Status: &v1alpha1.InstallStatus{Status: v1alpha1.InstallStatus_HEALTHY},
+	}
+	recorded.Name = "installed-state-canary"
+	(&helmreconciler.Provenance{
+		Version:     "1.6.0",
+		GitRevision: "abc123",
+		ChartDigest: "sha256:0123",
+		Profile:     "demo",
+		Time:        time.Date(2020, 5, 1, 12, 30, 0, 0, time.UTC),
+	}).Annotate(recorded)
+	unrecorded := &iopv1alpha1.IstioOperator{Spec: &v1alpha1.IstioOperatorSpec{}}
+	unrecorded.Name = "installed-state"
+
+	canary := newRevisionStatus(recorded)
+	canary.Components = []*helmreconciler.ComponentHealth{
+		{Component: "Base", Status: "HEALTHY"},
+		{
+			Component: "Pilot",
+			Status:    "HEALTHY",
+			Workloads: []helmreconciler.WorkloadHealth{
+				{Name: "istiod-canary", Version: "1.6.0", Ready: 1, Desired: 2},
+				{Name: "istiod-canary-2", Version: "1.6.1", Ready: 1, Desired: 1},
+			},
+			Webhooks: []helmreconciler.WebhookHealth{
+				{Name: "istio-sidecar-injector-canary", Webhooks: 1, CABundles: 1},
+				{Name: "istiod-istio-system", Webhooks: 1},
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := writeInstallStatus(&b, []*revisionStatus{newRevisionStatus(unrecorded), canary}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		got = append(got, strings.Join(strings.Fields(line), " "))
+	}
+	want := []string{
+		"REVISION CR STATUS VERSION GIT REVISION PROFILE CHART DIGEST INSTALLED",
+		"default installed-state unknown unknown unknown unknown unknown unknown",
+		"canary installed-state-canary HEALTHY 1.6.0 abc123 demo sha256:0123 2020-05-01T12:30:00Z",
+		"",
+		"Components of revision default:",
+		"COMPONENT STATUS VERSIONS READY WEBHOOKS WITH CA ERROR",
+		"",
+		"Components of revision canary:",
+		"COMPONENT STATUS VERSIONS READY WEBHOOKS WITH CA ERROR",
+		"Base HEALTHY unknown - -",
+		"Pilot HEALTHY 1.6.0,1.6.1 2/3 1/2",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestInstallDone(t *testing.T) {
+	healthyPilot := &helmreconciler.ComponentHealth{
+		Component: "Pilot",
+		Status:    "HEALTHY",
+		Workloads: []helmreconciler.WorkloadHealth{{Name: "istiod", Ready: 1, Desired: 1}},
+		Webhooks:  []helmreconciler.WebhookHealth{{Name: "istio-sidecar-injector", Webhooks: 1, CABundles: 1}},
+	}
+	tests := []struct {
+		desc     string
+		statuses []*revisionStatus
+		wantDone bool
+		wantErr  string
+	}{
+		{
+			desc: "no revisions yet",
+		},
+		{
+			desc:     "reconciling",
+			statuses: []*revisionStatus{{Revision: "default", Status: "RECONCILING"}},
+		},
+		{
+			desc:     "healthy",
+			statuses: []*revisionStatus{{Revision: "default", Status: "HEALTHY", Components: []*helmreconciler.ComponentHealth{healthyPilot}}},
+			wantDone: true,
+		},
+		{
+			desc: "replicas not ready",
+			statuses: []*revisionStatus{{Revision: "default", Status: "HEALTHY", Components: []*helmreconciler.ComponentHealth{{
+				Component: "Pilot",
+				Workloads: []helmreconciler.WorkloadHealth{{Name: "istiod", Ready: 1, Desired: 2}},
+			}}}},
+		},
+		{
+			desc: "webhook without caBundle",
+			statuses: []*revisionStatus{{Revision: "default", Status: "HEALTHY", Components: []*helmreconciler.ComponentHealth{{
+				Component: "Pilot",
+				Webhooks:  []helmreconciler.WebhookHealth{{Name: "istiod-istio-system", Webhooks: 1}},
+			}}}},
+		},
+		{
+			desc: "failed",
+			statuses: []*revisionStatus{
+				{Revision: "default", Status: "HEALTHY", Components: []*helmreconciler.ComponentHealth{healthyPilot}},
+				{Revision: "canary", Status: "ERROR", Components: []*helmreconciler.ComponentHealth{
+					{Component: "Pilot", Status: "ERROR", Error: "timeout"},
+				}},
+			},
+			wantDone: true,
+			wantErr:  "revision canary: component Pilot failed: timeout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			done, err := installDone(tt.statuses)
+			if done != tt.wantDone {
+				t.Errorf("got done %v, want %v", done, tt.wantDone)
+			}
+			if gotErr := errToString(err); !strings.Contains(gotErr, tt.wantErr) || (tt.wantErr == "") != (err == nil) {
+				t.Errorf("got error %q, want %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+type lastAppliedArgs struct {
+	// kubeConfigPath is the path to kube config file.
+	kubeConfigPath string
+	// context is the cluster context in the kube config
+	context string
+	// istioNamespace is the namespace holding the installed-state IstioOperator CR.
+	istioNamespace string
+	// revision selects the installed-state CR of the given control plane revision.
+	revision string
+	// component restricts the output to the manifest of a single component, e.g. pilot.
+	component string
+}
+
+func addLastAppliedFlags(cmd *cobra.Command, args *lastAppliedArgs) {
+	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
+	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+	cmd.PersistentFlags().StringVar(&args.istioNamespace, "istioNamespace", "istio-system",
+		"The namespace of the installed-state IstioOperator CR.")
+	cmd.PersistentFlags().StringVarP(&args.revision, "revision", "r", "",
+		"The control plane revision to show the manifests of. By default, the default revision is used.")
+	cmd.PersistentFlags().StringVar(&args.component, "component", "",
+		"The component to show the manifest of, e.g. pilot or ingressGateways. By default, all components are shown.")
+}
+
+// LastAppliedCmd is a command that prints the manifests that were last applied to the cluster.
+func LastAppliedCmd() *cobra.Command {
+	rootArgs := &rootArgs{}
+	laArgs := &lastAppliedArgs{}
+	cmd := &cobra.Command{
+		Use:   "last-applied",
+		Short: "Prints the manifests last applied to the cluster",
+		Long: "The last-applied command prints the manifests that were last applied for each component by istioctl " +
+			"install or the operator controller, as saved alongside the installed-state IstioOperator CR. Comparing " +
+			"them with the resources in the cluster helps find changes made outside of Istio.",
+		Example: `  # Print the manifest last applied for pilot
+  istioctl x last-applied --component pilot
+
+  # Print the manifests last applied for the canary revision
+  istioctl x last-applied --revision canary
+`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return lastApplied(rootArgs, laArgs, l)
+		},
+	}
+	addFlags(cmd, rootArgs)
+	addLastAppliedFlags(cmd, laArgs)
+	return cmd
+}
+
+func lastApplied(rootArgs *rootArgs, laArgs *lastAppliedArgs, l clog.Logger) error {
+	initLogsOrExit(rootArgs)
+
+	restConfig, _, err := manifest.InitK8SRestClient(laArgs.kubeConfigPath, laArgs.context)
+	if err != nil {
+		return err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return err
+	}
+	crName := installedSpecCRPrefix
+	if laArgs.revision != "" {
+		crName += "-" + laArgs.revision
+	}
+	mm, err := helmreconciler.ReadManifestSnapshot(cl, crName, laArgs.istioNamespace)
+	if err != nil {
+		return err
+	}
+	if len(mm) == 0 {
+		return fmt.Errorf("no applied manifests found for IstioOperator %s/%s", laArgs.istioNamespace, crName)
+	}
+	if laArgs.component != "" {
+		if mm, err = selectComponentManifest(mm, laArgs.component); err != nil {
+			return err
+		}
+	}
+	_, err = mm.WriteTo(clog.NewPrintWriter(l))
+	return err
+}
+
+// selectComponentManifest returns a ManifestMap holding only the manifest of component, which is matched against the
+// component names in mm regardless of case.
+func selectComponentManifest(mm name.ManifestMap, component string) (name.ManifestMap, error) {
+	var names []string
+	for c, m := range mm {
+		if strings.EqualFold(string(c), component) {
+			return name.ManifestMap{c: m}, nil
+		}
+		names = append(names, string(c))
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("no applied manifest found for component %s, applied components are: %s",
+		component, strings.Join(names, ", "))
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestSelectComponentManifest(t *testing.T) {
+	mm := name.ManifestMap{
+		name.PilotComponentName:     {"pilot"},
+		name.IngressComponentName:   {"ingress"},
+		name.IstioBaseComponentName: {"base"},
+	}
+	tests := []struct {
+		desc      string
+		component string
+		want      name.ManifestMap
+		wantErr   bool
+	}{
+		{
+			desc:      "exact",
+			component: "Pilot",
+			want:      name.ManifestMap{name.PilotComponentName: {"pilot"}},
+		},
+		{
+			desc:      "case insensitive",
+			component: "ingressgateways",
+			want:      name.ManifestMap{name.IngressComponentName: {"ingress"}},
+		},
+		{
+			desc:      "missing",
+			component: "cni",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := selectComponentManifest(mm, tt.component)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error: %v, want error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,41 +15,60 @@
 package mesh
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"istio.io/api/operator/v1alpha1"
 	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/footprint"
 	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/ipfamily"
 	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/name"
 	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/platform"
+	"istio.io/istio/operator/pkg/tpath"
 	"istio.io/istio/operator/pkg/translate"
+	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/util/clog"
+	"istio.io/istio/operator/pkg/validate"
 	"istio.io/pkg/log"
 )
 
 const (
 	// installedSpecCRPrefix is the prefix of any IstioOperator CR stored in the cluster that is a copy of the CR used
 	// in the last manifest apply operation.
-	installedSpecCRPrefix = "installed-state"
+	installedSpecCRPrefix = iopv1alpha1.InstalledStateCRPrefix
 )
 
 type manifestApplyArgs struct {
 	// inFilenames is an array of paths to the input IstioOperator CR files.
 	inFilenames []string
+	// valuesFiles is an array of paths to helm values files which are applied to spec.values.
+	valuesFiles []string
 	// kubeConfigPath is the path to kube config file.
 	kubeConfigPath string
 	// context is the cluster context in the kube config
 	context string
 	// readinessTimeout is maximum time to wait for all Istio resources to be ready.
 	readinessTimeout time.Duration
-	// wait is flag that indicates whether to wait resources ready before exiting.
+	// wait is flag that indicates whether to wait resources ready before exiting. Waiting is now the default and
+	// the flag is kept only for compatibility.
 	wait bool
+	// noWait skips waiting for resources to become ready before exiting.
+	noWait bool
+	// resume skips components which were installed successfully by a previous failed or interrupted apply.
+	resume bool
 	// skipConfirmation determines whether the user is prompted for confirmation.
 	// If set to true, the user is not prompted and a Yes response is assumed in all cases.
 	skipConfirmation bool
@@ -58,22 +77,104 @@ type manifestApplyArgs struct {
 	// set is a string with element format "path=value" where path is an IstioOperator path and the value is a
 	// value to set the node at that path to.
 	set []string
+	// revision is the control plane revision, as an alias for --set revision.
+	revision string
+	// components is a list of components to enable, as an alias for --set enablement paths.
+	components []string
+	// disableComponents is a list of components to disable, as an alias for --set enablement paths.
+	disableComponents []string
 	// charts is a path to a charts and profiles directory in the local filesystem, or URL with a release tgz.
 	charts string
+	// validateSchema validates the rendered objects against the OpenAPI schemas of the cluster before applying them.
+	validateSchema bool
+	// schemaFile is the path to an OpenAPI document which rendered objects are validated against.
+	schemaFile string
+	// policy is a directory or ConfigMap of Rego policies which rendered objects are checked against.
+	policy string
+	// platform is the Kubernetes platform of the cluster, which is detected if not set.
+	platform string
+	// adoptHelmRelease is the name of a Helm release whose resources are adopted.
+	adoptHelmRelease string
+	// includeCRDs selects whether CRDs are applied along with the other objects, alone or not at all.
+	includeCRDs string
+	// namespaced leaves out the cluster scoped resources, which are provisioned by a cluster admin, and checks the
+	// permissions for the namespaced resources before applying them.
+	namespaced bool
+	// mergeMeshConfig merges the mesh config into the live istio ConfigMap rather than replacing it.
+	mergeMeshConfig bool
+	// failOn are the preflight conditions which abort the apply. The preflight analysis only runs if this is set.
+	failOn []string
+	// discoveryCacheDir is the directory the discovery results of the cluster are cached in between runs.
+	discoveryCacheDir string
+	// waitStrategy is how the resources are read while waiting for them to become ready, by polling or watching.
+	waitStrategy string
+	// daemonSetReadyThreshold is the percentage of the eligible nodes which must run a ready pod of a DaemonSet.
+	daemonSetReadyThreshold int
+	// resourceLabels are added to every rendered object.
+	resourceLabels map[string]string
+	// resourceAnnotations are added to every rendered object.
+	resourceAnnotations map[string]string
+	// targets are the only components applied to an existing install. All components are applied if it is empty.
+	targets []string
+	// serverSide applies objects with server-side apply, reporting fields owned by other field managers as conflicts.
+	serverSide bool
+	// conflicts selects what is done with objects with field conflicts under server-side apply.
+	conflicts string
+	// forceConflicts takes over the fields owned by other field managers, as an alias for --conflicts=force.
+	forceConflicts bool
 }
 
 func addManifestApplyFlags(cmd *cobra.Command, args *manifestApplyArgs) {
 	cmd.PersistentFlags().StringSliceVarP(&args.inFilenames, "filename", "f", nil, filenameFlagHelpStr)
+	markFilenameFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.valuesFiles, "values", nil, valuesFlagHelpStr)
 	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
 	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+	MarkContextFlagCompletion(cmd)
 	cmd.PersistentFlags().BoolVarP(&args.skipConfirmation, "skip-confirmation", "y", false, skipConfirmationFlagHelpStr)
 	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
 	cmd.PersistentFlags().DurationVar(&args.readinessTimeout, "readiness-timeout", 300*time.Second, "Maximum seconds to wait for all Istio resources to be ready."+
-		" The --wait flag must be set for this flag to apply")
-	cmd.PersistentFlags().BoolVarP(&args.wait, "wait", "w", false, "Wait, if set will wait until all Pods, Services, and minimum number of Pods "+
+		" Has no effect if --no-wait is set")
+	cmd.PersistentFlags().BoolVarP(&args.wait, "wait", "w", true, "Wait until all Pods, Services, and minimum number of Pods "+
 		"of a Deployment are in a ready state before the command exits. It will wait for a maximum duration of --readiness-timeout seconds")
+	_ = cmd.PersistentFlags().MarkDeprecated("wait", "waiting is now the default, use --no-wait to disable it")
+	cmd.PersistentFlags().BoolVar(&args.noWait, "no-wait", false, "Exit as soon as the manifests are applied, without waiting "+
+		"for resources to become ready")
+	cmd.PersistentFlags().BoolVar(&args.resume, "resume", false, "Skip components which are unchanged since they were "+
+		"installed successfully by a previous failed or interrupted install")
 	cmd.PersistentFlags().StringArrayVarP(&args.set, "set", "s", nil, SetFlagHelpStr)
+	markSetFlagCompletion(cmd)
+	cmd.PersistentFlags().StringVarP(&args.revision, "revision", "r", "", revisionFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.components, "components", nil, componentsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.disableComponents, "disable-components", nil, disableComponentsFlagHelpStr)
 	cmd.PersistentFlags().StringVarP(&args.charts, "charts", "d", "", chartsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.validateSchema, "validate-schema", false, validateSchemaFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.schemaFile, "schema-file", "", schemaFileFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.policy, "policy", "", policyFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.platform, "platform", "", platformFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.adoptHelmRelease, "adopt-helm-release", "", adoptHelmReleaseFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.includeCRDs, "include-crds", manifest.IncludeCRDs, includeCRDsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.failOn, "fail-on", nil, failOnFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.namespaced, "namespaced", false, namespacedFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.mergeMeshConfig, "merge-mesh-config", false,
+		"Merge the mesh config into the one in the live istio ConfigMap, keeping the fields set by other tools, rather "+
+			"than replacing it. Fields set to different values are reported and take the value of the manifest")
+	cmd.PersistentFlags().StringVar(&args.discoveryCacheDir, "discovery-cache-dir", "", discoveryCacheDirFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.waitStrategy, "wait-strategy", manifest.WaitWatch,
+		"How to read the resources while waiting for them to become ready, one of watch, which is notified of changes "+
+			"within a second, or poll, which reads them every 2 seconds and needs no watch permissions")
+	cmd.PersistentFlags().IntVar(&args.daemonSetReadyThreshold, "daemonset-ready-threshold", 100,
+		"Percentage of the nodes a DaemonSet like istio-cni-node can run on which must have a ready pod of it when waiting "+
+			"for resources to become ready. Cordoned nodes and nodes with taints the pods do not tolerate are not counted")
+	cmd.PersistentFlags().StringToStringVar(&args.resourceLabels, "resource-labels", nil, resourceLabelsFlagHelpStr)
+	cmd.PersistentFlags().StringToStringVar(&args.resourceAnnotations, "resource-annotations", nil, resourceAnnotationsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.targets, "target", nil, targetFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.serverSide, "server-side", false, serverSideFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.conflicts, "conflicts", helmreconciler.ConflictsAbort,
+		"What to do with objects which have fields owned by other field managers under --server-side, one of abort, "+
+			"which fails them, skip, which leaves them unchanged, or force, which takes the fields over")
+	cmd.PersistentFlags().BoolVar(&args.forceConflicts, "force-conflicts", false, "Take over the fields owned by other "+
+		"field managers under --server-side, as an alias for --conflicts=force")
 }
 
 func manifestApplyCmd(rootArgs *rootArgs, maArgs *manifestApplyArgs, logOpts *log.Options) *cobra.Command {
@@ -91,6 +192,9 @@ func manifestApplyCmd(rootArgs *rootArgs, maArgs *manifestApplyArgs, logOpts *lo
   # Generate the demo profile and don't wait for confirmation
   istioctl manifest apply --set profile=demo --skip-confirmation
 
+  # Install a canary control plane revision next to the running one
+  istioctl manifest apply --revision canary
+
   # To override a setting that includes dots, escape them with a backslash (\).  Your shell may require enclosing quotes.
   istioctl manifest apply --set "values.sidecarInjectorWebhook.injectedAnnotations.container\.apparmor\.security\.beta\.kubernetes\.io/istio-proxy=runtime/default"
 `,
@@ -119,6 +223,12 @@ func InstallCmd(logOpts *log.Options) *cobra.Command {
   # Generate the demo profile and don't wait for confirmation
   istioctl install --set profile=demo --skip-confirmation
 
+  # Install a canary control plane revision next to the running one
+  istioctl install --revision canary
+
+  # Apply a values change to the ingress gateways only, leaving the other components as installed
+  istioctl install -f my-iop.yaml --target IngressGateways
+
   # To override a setting that includes dots, escape them with a backslash (\).  Your shell may require enclosing quotes.
   istioctl install --set "values.sidecarInjectorWebhook.injectedAnnotations.container\.apparmor\.security\.beta\.kubernetes\.io/istio-proxy=runtime/default"
 `,
@@ -129,76 +239,285 @@ func InstallCmd(logOpts *log.Options) *cobra.Command {
 
 	addFlags(mac, rootArgs)
 	addManifestApplyFlags(mac, macArgs)
+	mac.AddCommand(installGCCmd())
+	mac.AddCommand(installStatusCmd())
 	return mac
 }
 
 func runApplyCmd(cmd *cobra.Command, rootArgs *rootArgs, maArgs *manifestApplyArgs, logOpts *log.Options) error {
-	l := clog.NewConsoleLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
 	// Warn users if they use `manifest apply` without any config args.
-	if len(maArgs.inFilenames) == 0 && len(maArgs.set) == 0 && !rootArgs.dryRun && !maArgs.skipConfirmation {
+	if len(maArgs.inFilenames) == 0 && len(maArgs.valuesFiles) == 0 && len(maArgs.set) == 0 &&
+		len(maArgs.components) == 0 && len(maArgs.disableComponents) == 0 && maArgs.revision == "" && !rootArgs.dryRun && !maArgs.skipConfirmation {
 		if !confirm("This will install the default Istio profile into the cluster. Proceed? (y/N)", cmd.OutOrStdout()) {
 			cmd.Print("Cancelled.\n")
 			os.Exit(1)
 		}
 	}
-	if err := configLogs(rootArgs.logToStdErr, logOpts); err != nil {
+	if err := configLogs(rootArgs, logOpts); err != nil {
 		return fmt.Errorf("could not configure logs: %s", err)
 	}
-	if err := ApplyManifests(applyInstallFlagAlias(maArgs.set, maArgs.charts), maArgs.inFilenames, maArgs.force, rootArgs.dryRun, rootArgs.verbose,
-		maArgs.kubeConfigPath, maArgs.context, maArgs.wait, maArgs.readinessTimeout, l); err != nil {
+	setFlags, err := applyRevisionFlagAlias(maArgs.set, maArgs.revision)
+	if err != nil {
+		return err
+	}
+	setFlags, err = applyComponentFlagAliases(applyInstallFlagAlias(setFlags, maArgs.charts), maArgs.components, maArgs.disableComponents)
+	if err != nil {
+		return err
+	}
+	manifest.SetDiscoveryCacheDir(maArgs.discoveryCacheDir)
+	if err := manifest.SetWaitStrategy(maArgs.waitStrategy); err != nil {
+		return fmt.Errorf("bad --wait-strategy: %s", err)
+	}
+	if err := manifest.SetDaemonSetReadyThreshold(maArgs.daemonSetReadyThreshold); err != nil {
+		return fmt.Errorf("bad --daemonset-ready-threshold: %s", err)
+	}
+	conflicts := maArgs.conflicts
+	if maArgs.forceConflicts {
+		conflicts = helmreconciler.ConflictsForce
+	}
+	if err := helmreconciler.ValidateConflictPolicy(conflicts); err != nil {
+		return fmt.Errorf("bad --conflicts: %s", err)
+	}
+	if len(maArgs.failOn) != 0 {
+		if err := runPreflight(maArgs.kubeConfigPath, maArgs.context, defaultNamespace, maArgs.failOn, l); err != nil {
+			return err
+		}
+	}
+	if err := ApplyManifests(&ApplyOptions{
+		SetOverlay:          setFlags,
+		InFilenames:         maArgs.inFilenames,
+		ValuesFiles:         maArgs.valuesFiles,
+		Force:               maArgs.force,
+		DryRun:              rootArgs.dryRun,
+		Verbose:             rootArgs.verbose,
+		KubeConfigPath:      maArgs.kubeConfigPath,
+		Context:             maArgs.context,
+		Wait:                maArgs.wait && !maArgs.noWait,
+		WaitTimeout:         maArgs.readinessTimeout,
+		Resume:              maArgs.resume,
+		ValidateSchema:      maArgs.validateSchema,
+		SchemaFile:          maArgs.schemaFile,
+		PolicySource:        maArgs.policy,
+		Platform:            maArgs.platform,
+		AdoptHelmRelease:    maArgs.adoptHelmRelease,
+		IncludeCRDs:         maArgs.includeCRDs,
+		Namespaced:          maArgs.namespaced,
+		MergeMeshConfig:     maArgs.mergeMeshConfig,
+		ResourceLabels:      maArgs.resourceLabels,
+		ResourceAnnotations: maArgs.resourceAnnotations,
+		Targets:             maArgs.targets,
+		ServerSide:          maArgs.serverSide,
+		Conflicts:           conflicts,
+	}, l); err != nil {
 		return fmt.Errorf("failed to apply manifests: %v", err)
 	}
 
 	return nil
 }
 
-// ApplyManifests generates manifests from the given input files and --set flag overlays and applies them to the
+// ApplyOptions are the options of ApplyManifests. The zero value of each field is its default.
+type ApplyOptions struct {
+	// SetOverlay are --set flag overlays with element format "path=value".
+	SetOverlay []string
+	// InFilenames are the paths of the input IstioOperator CR files.
+	InFilenames []string
+	// ValuesFiles are the paths of helm values files which are applied to spec.values.
+	ValuesFiles []string
+	// Force writes validation warnings to the logger without aborting the command.
+	Force bool
+	// DryRun does all operations but writes nothing.
+	DryRun bool
+	// Verbose outputs the resources of each component and the action on each object, like kubectl apply, with their
+	// counts per component.
+	Verbose bool
+	// KubeConfigPath is the path to the kube config file.
+	KubeConfigPath string
+	// Context is the cluster context in the kube config.
+	Context string
+	// Wait blocks until Services and Deployments are ready, or times out after WaitTimeout, warning beforehand about
+	// pods which cannot be scheduled with the free capacity of the nodes.
+	Wait bool
+	// WaitTimeout is the maximum time to wait for the resources to become ready.
+	WaitTimeout time.Duration
+	// Resume skips the components which are unchanged since they were last installed successfully.
+	Resume bool
+	// ValidateSchema validates the rendered objects against the cluster OpenAPI schemas, or those in SchemaFile if
+	// set, and applies nothing if any object is invalid.
+	ValidateSchema bool
+	// SchemaFile is the path to an OpenAPI document which rendered objects are validated against.
+	SchemaFile string
+	// PolicySource is a directory or ConfigMap of Rego policies which rendered objects are checked against. Nothing is
+	// applied if there are violations.
+	PolicySource string
+	// Platform is the Kubernetes platform for platform specific defaults, detected from the cluster if neither this nor
+	// values.global.platform is set.
+	Platform string
+	// AdoptHelmRelease is the [namespace/]name of a Helm release whose resources are taken over by the install.
+	AdoptHelmRelease string
+	// IncludeCRDs is one of include, the default, only or skip, to apply CRDs with all other objects, alone or not at
+	// all. Nothing is pruned unless CRDs are included, and the installed state is not saved if only CRDs are applied.
+	IncludeCRDs string
+	// Namespaced leaves out the cluster scoped resources and the creation of the namespace, which are provisioned by a
+	// cluster admin, and applies nothing unless the user may apply and prune all namespaced resources.
+	Namespaced bool
+	// MergeMeshConfig merges the mesh config into the live istio ConfigMap, keeping the fields set by other tools.
+	MergeMeshConfig bool
+	// ResourceLabels are added to every rendered object, as values.global.resourceLabels.
+	ResourceLabels map[string]string
+	// ResourceAnnotations are added to every rendered object, as values.global.resourceAnnotations.
+	ResourceAnnotations map[string]string
+	// Targets are the only components applied to an existing install. Nothing is pruned, and the apply fails unless
+	// Force is set if any other component differs from what was last installed.
+	Targets []string
+	// ServerSide applies objects with server-side apply.
+	ServerSide bool
+	// Conflicts is what is done with fields owned by other field managers under ServerSide, one of the
+	// helmreconciler.ConflictPolicies. The default is to abort.
+	Conflicts string
+}
+
+// ApplyManifests generates manifests from the input files and --set flag overlays in opts and applies them to the
 // cluster. See GenManifests for more description of the manifest generation process.
-//  force   validation warnings are written to logger but command is not aborted
-//  dryRun  all operations are done but nothing is written
-//  verbose full manifests are output
-//  wait    block until Services and Deployments are ready, or timeout after waitTimeout
-func ApplyManifests(setOverlay []string, inFilenames []string, force bool, dryRun bool, verbose bool,
-	kubeConfigPath string, context string, wait bool, waitTimeout time.Duration, l clog.Logger) error {
+func ApplyManifests(opts *ApplyOptions, l clog.Logger) error {
+	if err := manifest.ValidateCRDMode(opts.IncludeCRDs); err != nil {
+		return err
+	}
+	var targetComponents map[name.ComponentName]bool
+	if len(opts.Targets) != 0 {
+		var err error
+		if targetComponents, err = manifest.SelectComponents(opts.Targets); err != nil {
+			return fmt.Errorf("bad --target: %s", err)
+		}
+	}
 
-	ysf, err := yamlFromSetFlags(setOverlay, force, l)
+	ysf, unsetPaths, err := yamlFromSetFlags(opts.SetOverlay, opts.Force, l)
 	if err != nil {
 		return err
 	}
+	if ysf, err = overlayValuesFiles(ysf, opts.ValuesFiles, opts.Force, l); err != nil {
+		return err
+	}
+	if ysf, err = overlayResourceMetadata(ysf, opts.ResourceLabels, opts.ResourceAnnotations); err != nil {
+		return err
+	}
 
-	restConfig, clientSet, err := manifest.InitK8SRestClient(kubeConfigPath, context)
+	restConfig, clientSet, err := manifest.InitK8SRestClient(opts.KubeConfigPath, opts.Context)
 	if err != nil {
 		return err
 	}
-	client, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	mapper, err := manifest.RESTMapper()
 	if err != nil {
 		return err
 	}
-	_, iops, err := GenerateConfig(inFilenames, ysf, force, restConfig, l)
+	client, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme, Mapper: mapper})
 	if err != nil {
 		return err
 	}
+	_, iops, err := GenerateConfig(opts.InFilenames, ysf, unsetPaths, opts.Force, restConfig, l)
+	if err != nil {
+		return err
+	}
+	if families, _ := ipfamily.Settings(iops.Values); len(families) != 0 {
+		if err := ipfamily.CheckCluster(clientSet, families); err != nil {
+			return err
+		}
+	}
+	if err := setPlatform(iops, opts.Platform, clientSet, l); err != nil {
+		return err
+	}
 
+	gatewayCR, err := userGatewayCR(opts.InFilenames)
+	if err != nil {
+		return err
+	}
 	crName := installedSpecCRPrefix
-	if iops.Revision != "" {
+	if gatewayCR != nil {
+		crName = gatewayCR.Name
+	} else if iops.Revision != "" {
 		crName += "-" + iops.Revision
 	}
 	iop, err := translate.IOPStoIOP(iops, crName, iopv1alpha1.Namespace(iops))
 	if err != nil {
 		return err
 	}
+	if gatewayCR != nil {
+		iop.Annotations = map[string]string{iopv1alpha1.UserGatewayAnnotation: "true"}
+	}
 
-	if err := manifest.CreateNamespace(iop.Namespace); err != nil {
-		return err
+	if !opts.Namespaced {
+		if err := manifest.CreateNamespace(iop.Namespace); err != nil {
+			return err
+		}
 	}
 
 	// Needed in case we are running a test through this path that doesn't start a new process.
 	helmreconciler.FlushObjectCaches()
-	reconciler, err := helmreconciler.NewHelmReconciler(client, restConfig, iop, &helmreconciler.Options{DryRun: dryRun, Log: l})
+	hopts := &helmreconciler.Options{DryRun: opts.DryRun, Log: l, Verbose: opts.Verbose, CRDs: opts.IncludeCRDs, Namespaced: opts.Namespaced,
+		MergeMeshConfig: opts.MergeMeshConfig, Targets: targetComponents, ServerSideApply: opts.ServerSide, Conflicts: opts.Conflicts}
+	if hopts.SchemaValidator, err = newSchemaValidator(opts.ValidateSchema, opts.SchemaFile, restConfig); err != nil {
+		return err
+	}
+	if hopts.PolicyChecker, err = newPolicyChecker(opts.PolicySource, clientSet); err != nil {
+		return err
+	}
+	if opts.Resume {
+		if hopts.Checkpoints, err = helmreconciler.ReadCheckpoints(client, crName, iop.Namespace); err != nil {
+			return err
+		}
+		if len(hopts.Checkpoints) == 0 {
+			l.LogAndPrintf("No checkpoints found in IstioOperator %s/%s, installing all components.", iop.Namespace, crName)
+		}
+	}
+	reconciler, err := helmreconciler.NewHelmReconciler(client, restConfig, iop, hopts)
 	if err != nil {
 		return err
 	}
-	status, err := reconciler.Reconcile()
+	if err := reconciler.CheckConflicts(); err != nil {
+		if !opts.Force {
+			return err
+		}
+		l.LogAndPrintf("Proceeding despite conflicts because of --force: %s", err)
+	}
+	if len(targetComponents) != 0 {
+		if err := checkUntargeted(reconciler, crName, iop.Namespace); err != nil {
+			if !opts.Force {
+				return err
+			}
+			l.LogAndPrintf("Proceeding despite untargeted changes because of --force: %s", err)
+		}
+	}
+	if opts.Verbose {
+		l.LogAndPrint(componentSummary(reconciler.GetManifests()))
+	}
+	if opts.Namespaced {
+		if err := manifest.CheckNamespacedPermissions(clientSet, reconciler.GetManifests().ManifestMap(), iop.Namespace); err != nil {
+			return err
+		}
+	}
+	if opts.Wait {
+		warnCapacity(clientSet, reconciler.GetManifests().ManifestMap(), l)
+	}
+	var helmRelease *helmreconciler.HelmRelease
+	if opts.AdoptHelmRelease != "" {
+		if helmRelease, err = adoptRelease(reconciler, opts.AdoptHelmRelease, iop.Namespace); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := cancelOnSignal(l)
+	defer cancel()
+	status, err := reconciler.ReconcileContext(ctx)
+	if opts.Verbose && !opts.DryRun {
+		l.LogAndPrint(applySummary(reconciler.ApplyReport()))
+	}
+	if opts.IncludeCRDs != manifest.OnlyCRDs {
+		if serr := saveInstalledState(reconciler, iops, crName, gatewayCR != nil, status, opts.DryRun); serr != nil {
+			l.LogAndPrintf("Failed to save the installed state: %s", serr)
+		}
+	}
+	if ctx.Err() != nil {
+		return interruptedInstall(l)
+	}
 	if err != nil {
 		l.LogAndPrintf("\n\n✘ Errors were logged during apply operation:\n\n%s\n", err)
 		return fmt.Errorf("errors occurred during operation")
@@ -206,15 +525,20 @@ func ApplyManifests(setOverlay []string, inFilenames []string, force bool, dryRu
 	if status.Status != v1alpha1.InstallStatus_HEALTHY {
 		return fmt.Errorf("errors occurred during operation")
 	}
+	if helmRelease != nil {
+		if err := reconciler.DeleteHelmReleaseRecords(helmRelease); err != nil {
+			return err
+		}
+		l.LogAndPrintf("Removed the records of Helm release %s/%s.", helmRelease.Namespace, helmRelease.Name)
+	}
 
-	if wait {
+	if opts.Wait {
 		l.LogAndPrint("Waiting for resources to become ready...")
-		objs, err := object.ParseK8sObjectsFromYAMLManifest(reconciler.GetManifests().String())
-		if err != nil {
-			l.LogAndPrintf("\n\n✘ Errors in manifest:\n%s\n", err)
-			return fmt.Errorf("errors during wait")
-		}
-		if err := manifest.WaitForResources(objs, clientSet, waitTimeout, dryRun, l); err != nil {
+		cms := reconciler.GetManifests()
+		if err := manifest.WaitForResourcesContext(ctx, cms.Objects(), clientSet, opts.WaitTimeout, opts.DryRun, l, cms.ReadinessChecks()...); err != nil {
+			if ctx.Err() != nil {
+				return interruptedInstall(l)
+			}
 			l.LogAndPrintf("\n\n✘ Errors during wait:\n%s\n", err)
 			return fmt.Errorf("errors during wait")
 		}
@@ -222,7 +546,139 @@ func ApplyManifests(setOverlay []string, inFilenames []string, force bool, dryRu
 
 	l.LogAndPrint("\n\n✔ Installation complete\n")
 
-	// Save state to cluster in IstioOperator CR.
+	return nil
+}
+
+// checkUntargeted returns an error if the IstioOperator CR with the given name and namespace records no installed
+// components, or if the components which are not targets of reconciler differ from what was last installed, so that a
+// partial apply would leave them out of date.
+func checkUntargeted(reconciler *helmreconciler.HelmReconciler, crName, namespace string) error {
+	checkpoints, err := helmreconciler.ReadCheckpoints(reconciler.GetClient(), crName, namespace)
+	if err != nil {
+		return err
+	}
+	if len(checkpoints) == 0 {
+		return fmt.Errorf("no installed components are recorded in IstioOperator %s/%s, --target only applies to an existing install",
+			namespace, crName)
+	}
+	if changed := reconciler.UntargetedChanges(checkpoints); len(changed) != 0 {
+		return fmt.Errorf("components which are not targets differ from what was last installed: %s. "+
+			"Add them to --target or apply all components", strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// warnCapacity warns about the pods of the workloads in manifests which cannot be scheduled with the free capacity of
+// the nodes of the cluster, so that they stay Pending and the wait is bound to time out. The check is best effort and
+// only logs if it cannot read the nodes and pods.
+func warnCapacity(cs kubernetes.Interface, manifests name.ManifestMap, l clog.Logger) {
+	r, err := footprint.New(manifests)
+	if err != nil {
+		l.LogAndPrintf("Could not compute the resources requested by the manifests: %s", err)
+		return
+	}
+	warnings, err := footprint.CheckCapacity(cs, r)
+	if err != nil {
+		l.LogAndPrintf("Could not check the cluster capacity: %s", err)
+		return
+	}
+	for _, w := range warnings {
+		l.LogAndPrintf("Warning: %s", w)
+	}
+}
+
+// setPlatform sets values.global.platform in iops to p, or to the platform detected from the cluster if neither p nor
+// the values set it, and logs any warnings about the cluster configuration the platform needs.
+func setPlatform(iops *v1alpha1.IstioOperatorSpec, p string, cs kubernetes.Interface, l clog.Logger) error {
+	if p == "" {
+		p = platform.Settings(iops.Values)
+	}
+	if p == "" {
+		detected, err := platform.Detect(cs)
+		if err != nil {
+			l.LogAndErrorf("Could not detect the platform, platform specific defaults are not applied: %s", err)
+			return nil
+		}
+		if detected == "" {
+			return nil
+		}
+		l.LogAndPrintf("Detected platform %s.", detected)
+		p = detected
+	}
+	if !platform.Platforms[p] {
+		return fmt.Errorf("unknown platform %s, must be one of gke, eks, aks or openshift", p)
+	}
+	if iops.Values == nil {
+		iops.Values = make(map[string]interface{})
+	}
+	if err := tpath.WriteNode(iops.Values, util.PathFromString(platform.ValuesPath), p); err != nil {
+		return err
+	}
+	warnings, err := platform.Check(cs, p)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		l.LogAndErrorf("! %s", w)
+	}
+	return nil
+}
+
+// adoptRelease reads the Helm release ref, of the form [namespace/]name with namespace defaulting to defaultNamespace,
+// and adopts its resources with reconciler.
+func adoptRelease(reconciler *helmreconciler.HelmReconciler, ref, defaultNamespace string) (*helmreconciler.HelmRelease, error) {
+	namespace, releaseName := defaultNamespace, ref
+	if i := strings.Index(ref, "/"); i >= 0 {
+		namespace, releaseName = ref[:i], ref[i+1:]
+	}
+	hr, err := helmreconciler.ReadHelmRelease(reconciler.GetClient(), releaseName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := reconciler.AdoptHelmRelease(hr); err != nil {
+		return nil, err
+	}
+	return hr, nil
+}
+
+// applySummary returns the counts of the actions on the objects of each component in r, for verbose output.
+func applySummary(r *helmreconciler.ApplyReport) string {
+	var sb strings.Builder
+	sb.WriteString("Applied objects:\n")
+	for _, c := range r.Components {
+		fmt.Fprintf(&sb, "  %s: %s\n", c.Component, c)
+	}
+	return sb.String()
+}
+
+// componentSummary returns the resources of each component in cms, with the version of the chart it is rendered
+// from, for verbose output.
+func componentSummary(cms helmreconciler.ComponentManifests) string {
+	var sb strings.Builder
+	sb.WriteString("Components to apply:\n")
+	for _, cm := range cms {
+		if cm.ChartVersion != "" {
+			fmt.Fprintf(&sb, "  %s (chart %s): %d resources\n", cm.Component, cm.ChartVersion, len(cm.Objects))
+		} else {
+			fmt.Fprintf(&sb, "  %s: %d resources\n", cm.Component, len(cm.Objects))
+		}
+		for _, o := range cm.Objects {
+			if o.Namespace != "" {
+				fmt.Fprintf(&sb, "    %s/%s/%s\n", o.Kind, o.Namespace, o.Name)
+			} else {
+				fmt.Fprintf(&sb, "    %s/%s\n", o.Kind, o.Name)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// saveInstalledState saves iops to the cluster as the installed-state IstioOperator CR with the given name. Unless
+// dryRun is set, status, which may be partial if the install failed or was interrupted, is recorded on the CR together
+// with checkpoints for the components which are HEALTHY, so that a later apply with --resume can skip them. The
+// rendered manifests are saved alongside the CR for istioctl x last-applied. A userGateway CR keeps its annotation.
+func saveInstalledState(reconciler *helmreconciler.HelmReconciler, iops *v1alpha1.IstioOperatorSpec, crName string,
+	userGateway bool, status *v1alpha1.InstallStatus, dryRun bool) error {
 	iopStr, err := translate.IOPStoIOPstr(iops, crName, iopv1alpha1.Namespace(iops))
 	if err != nil {
 		return err
@@ -231,9 +687,81 @@ func ApplyManifests(setOverlay []string, inFilenames []string, force bool, dryRu
 	if err != nil {
 		return err
 	}
+	// Record which binary, charts and profile manage this revision, see istioctl install status.
+	prov, err := helmreconciler.NewProvenance(iops)
+	if err != nil {
+		return err
+	}
+	prov.Annotate(obj.UnstructuredObject())
+	if userGateway {
+		u := obj.UnstructuredObject()
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[iopv1alpha1.UserGatewayAnnotation] = "true"
+		u.SetAnnotations(annotations)
+	}
 	if err := reconciler.ProcessObject("", obj.UnstructuredObject()); err != nil {
 		return err
 	}
+	if dryRun || status == nil {
+		return nil
+	}
+	if err := reconciler.SaveManifestSnapshot(); err != nil {
+		return err
+	}
+	if err := reconciler.SetStatusComplete(status); err != nil {
+		return err
+	}
+	return reconciler.SetStatusCheckpoints(status)
+}
 
-	return nil
+// userGatewayCR returns the IstioOperator CR in inFilenames if it is a user gateway CR, or nil otherwise. The name of a
+// user gateway CR is kept for the installed resources, so that it is pruned independently of the control plane.
+func userGatewayCR(inFilenames []string) (*iopv1alpha1.IstioOperator, error) {
+	y, err := ReadLayeredYAMLs(inFilenames)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(y) == "" {
+		return nil, nil
+	}
+	iop, err := validate.UnmarshalIOP(y)
+	if err != nil {
+		return nil, err
+	}
+	if !iopv1alpha1.IsUserGateway(iop) {
+		return nil, nil
+	}
+	if iop.Name == "" {
+		return nil, fmt.Errorf("user gateway IstioOperator CR must set metadata.name")
+	}
+	return iop, nil
+}
+
+// interruptedInstall prints guidance on how to continue after an apply was cancelled by a signal.
+func interruptedInstall(l clog.Logger) error {
+	l.LogAndPrint("\n\n✘ Installation was interrupted, the cluster may contain a partial Istio install.\n")
+	l.LogAndPrint("To resume, run the same command again with --resume. To remove the installed resources, run the " +
+		"equivalent \"istioctl manifest generate\" command and pipe its output to \"kubectl delete -f -\".")
+	return fmt.Errorf("installation interrupted")
+}
+
+// cancelOnSignal returns a context which is cancelled when the process receives SIGINT or SIGTERM. The returned
+// cancel function must be called to stop listening for signals.
+func cancelOnSignal(l clog.Logger) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(sigCh)
+		select {
+		case sig := <-sigCh:
+			l.LogAndPrintf("\nReceived %s, cancelling after the components in progress finish...", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
 }
@@ -19,15 +19,27 @@ import (
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"istio.io/api/operator/v1alpha1"
 	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/policy"
+	"istio.io/istio/operator/pkg/resourcemeta"
+	"istio.io/istio/operator/pkg/schema"
 	"istio.io/istio/operator/pkg/tpath"
 	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/util/clog"
 	"istio.io/istio/operator/pkg/validate"
 )
 
+const (
+	// unsetFlagValue is the value of a --set flag which removes the node at the flag path, e.g.
+	// --set components.egressGateways[name:istio-egressgateway]=null.
+	unsetFlagValue = "null"
+)
+
 var (
 	ignoreStdErrList = []string{
 		// TODO: remove when https://github.com/kubernetes/kubernetes/issues/82154 is fixed.
@@ -45,20 +57,149 @@ func ignoreError(stderr string) bool {
 	return trimmedStdErr == ""
 }
 
-// yamlFromSetFlags takes a slice of --set flag key-value pairs and returns a YAML tree representation.
+// yamlFromSetFlags takes a slice of --set flag key-value pairs and returns a YAML tree representation, together with
+// the paths of the flags with the value null, which must be removed from the merged configuration with removePaths.
 // If force is set, validation errors cause warning messages to be written to logger rather than causing error.
-func yamlFromSetFlags(setOverlay []string, force bool, l clog.Logger) (string, error) {
+func yamlFromSetFlags(setOverlay []string, force bool, l clog.Logger) (string, []string, error) {
+	setOverlay, unsetPaths := splitUnsetFlags(setOverlay)
 	out, err := makeTreeFromSetList(setOverlay)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate tree from the set overlay, error: %v", err)
+		return "", nil, fmt.Errorf("failed to generate tree from the set overlay, error: %v", err)
+	}
+	if err := validateSetPaths(setOverlay); err != nil {
+		if !force {
+			return "", nil, fmt.Errorf("validation errors (use --force to override): \n%s", err)
+		}
+		l.LogAndErrorf("Validation errors (continuing because of --force):\n%s", err)
 	}
 	if err := validate.ValidIOPYAML(out); err != nil {
 		if !force {
-			return "", fmt.Errorf("validation errors (use --force to override): \n%s", err)
+			return "", nil, fmt.Errorf("validation errors (use --force to override): \n%s", err)
 		}
 		l.LogAndErrorf("Validation errors (continuing because of --force):\n%s", err)
 	}
-	return out, nil
+	return out, unsetPaths, nil
+}
+
+// splitUnsetFlags returns the flags in setOverlay which set a value, and the paths of those with the value null.
+func splitUnsetFlags(setOverlay []string) (sets, unsetPaths []string) {
+	for _, kv := range setOverlay {
+		kvv := strings.Split(kv, "=")
+		if len(kvv) == 2 && kvv[1] == unsetFlagValue {
+			unsetPaths = append(unsetPaths, kvv[0])
+			continue
+		}
+		sets = append(sets, kv)
+	}
+	return sets, unsetPaths
+}
+
+// removePaths removes the nodes at paths from the IstioOperatorSpec YAML iopsYAML. Map entries are deleted and list
+// items, selected by index or by key:value, are removed from their list. Paths which do not exist are ignored.
+func removePaths(iopsYAML string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return iopsYAML, nil
+	}
+	tree := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(iopsYAML), &tree); err != nil {
+		return "", err
+	}
+	for _, p := range paths {
+		pc, _, err := tpath.GetPathContext(tree, util.PathFromString(p), false)
+		if err != nil {
+			scope.Infof("Not unsetting %s: %s", p, err)
+			continue
+		}
+		if m, ok := pc.Parent.Node.(map[string]interface{}); ok {
+			delete(m, pc.Parent.KeyToChild.(string))
+			continue
+		}
+		if err := tpath.WritePathContext(pc, nil, false); err != nil {
+			return "", fmt.Errorf("failed to unset %s: %s", p, err)
+		}
+	}
+	out, err := yaml.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// overlayValuesFiles reads the helm values files in valuesFiles, overlaid in order, and returns setOverlayYAML overlaid
+// on top of the values placed under spec.values. Values files are plain helm values, not IstioOperator CRs.
+// If force is set, validation errors cause warning messages to be written to logger rather than causing error.
+func overlayValuesFiles(setOverlayYAML string, valuesFiles []string, force bool, l clog.Logger) (string, error) {
+	if len(valuesFiles) == 0 {
+		return setOverlayYAML, nil
+	}
+	vy, err := ReadLayeredYAMLs(valuesFiles)
+	if err != nil {
+		return "", fmt.Errorf("could not read values files: %s", err)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(vy), &values); err != nil {
+		return "", fmt.Errorf("could not parse values files: %s", err)
+	}
+	if values["kind"] == "IstioOperator" {
+		return "", fmt.Errorf("values files must contain helm values, use --filename for IstioOperator custom resources")
+	}
+	out, err := yaml.Marshal(map[string]interface{}{"values": values})
+	if err != nil {
+		return "", err
+	}
+	valuesYAML, err := tpath.AddSpecRoot(string(out))
+	if err != nil {
+		return "", err
+	}
+	if err := validate.ValidIOPYAML(valuesYAML); err != nil {
+		if !force {
+			return "", fmt.Errorf("validation errors in values files (use --force to override): \n%s", err)
+		}
+		l.LogAndErrorf("Validation errors in values files (continuing because of --force):\n%s", err)
+	}
+	return util.OverlayYAML(valuesYAML, setOverlayYAML)
+}
+
+// overlayResourceMetadata returns setOverlayYAML overlaid with values.global.resourceLabels and
+// values.global.resourceAnnotations set to labels and annotations, from the --resource-labels and
+// --resource-annotations flags. Unlike --set, the flags keep values like "123" and "true" as strings, as labels need.
+func overlayResourceMetadata(setOverlayYAML string, labels, annotations map[string]string) (string, error) {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return setOverlayYAML, nil
+	}
+	if err := resourcemeta.Validate(labels, annotations); err != nil {
+		return "", err
+	}
+	global := make(map[string]interface{})
+	if len(labels) != 0 {
+		global["resourceLabels"] = labels
+	}
+	if len(annotations) != 0 {
+		global["resourceAnnotations"] = annotations
+	}
+	out, err := yaml.Marshal(map[string]interface{}{"values": map[string]interface{}{"global": global}})
+	if err != nil {
+		return "", err
+	}
+	metaYAML, err := tpath.AddSpecRoot(string(out))
+	if err != nil {
+		return "", err
+	}
+	return util.OverlayYAML(setOverlayYAML, metaYAML)
+}
+
+// validateSetPaths checks the paths and enum values in a slice of --set flag key-value pairs against the
+// IstioOperatorSpec schema. Values may contain "=". Pairs without a value are reported by makeTreeFromSetList.
+func validateSetPaths(setOverlay []string) error {
+	var errs util.Errors
+	for _, kv := range setOverlay {
+		kvv := strings.SplitN(kv, "=", 2)
+		if len(kvv) != 2 {
+			continue
+		}
+		errs = util.AppendErr(errs, schema.ValidSetPath(kvv[0], kvv[1]))
+	}
+	return errs.ToError()
 }
 
 // makeTreeFromSetList creates a YAML tree from a string slice containing key-value pairs in the format key=value.
@@ -111,3 +252,75 @@ func applyInstallFlagAlias(flags []string, charts string) []string {
 	}
 	return flags
 }
+
+// --revision is an alias for --set revision. It is an error to set a different revision with both.
+func applyRevisionFlagAlias(flags []string, revision string) ([]string, error) {
+	if revision == "" {
+		return flags, nil
+	}
+	for _, f := range flags {
+		if v := strings.TrimPrefix(f, "revision="); v != f && v != revision {
+			return nil, fmt.Errorf("--revision %s conflicts with --set revision=%s", revision, v)
+		}
+	}
+	return append(flags, "revision="+revision), nil
+}
+
+// --components c1,c2 and --disable-components c3 are aliases for --set enablement paths of the named components.
+// Gateways are enabled through values so that the gateway settings in the profile are preserved. Any other names are
+// treated as addon components. Explicit --set flags take precedence over the aliases.
+func applyComponentFlagAliases(flags []string, enable, disable []string) ([]string, error) {
+	var aliases []string
+	enabled := make(map[string]bool)
+	for _, c := range enable {
+		aliases = append(aliases, fmt.Sprintf("%s=true", componentEnablementPath(c)))
+		enabled[strings.ToLower(c)] = true
+	}
+	for _, c := range disable {
+		if enabled[strings.ToLower(c)] {
+			return nil, fmt.Errorf("component %s cannot be both enabled with --components and disabled with --disable-components", c)
+		}
+		aliases = append(aliases, fmt.Sprintf("%s=false", componentEnablementPath(c)))
+	}
+	return append(aliases, flags...), nil
+}
+
+// componentEnablementPath returns the --set path for the enablement of the component with the given name.
+func componentEnablementPath(component string) string {
+	switch {
+	case strings.EqualFold(component, string(name.IngressComponentName)):
+		return "values.gateways.istio-ingressgateway.enabled"
+	case strings.EqualFold(component, string(name.EgressComponentName)):
+		return "values.gateways.istio-egressgateway.enabled"
+	}
+	for _, cn := range name.AllCoreComponentNames {
+		if strings.EqualFold(component, string(cn)) {
+			return fmt.Sprintf("components.%s.enabled", strings.ToLower(string(cn)))
+		}
+	}
+	return fmt.Sprintf("addonComponents.%s.enabled", component)
+}
+
+// newSchemaValidator returns a validator for the OpenAPI document at schemaFile or, if schemaFile is empty, for the
+// schemas of the cluster at restConfig. It returns nil if neither validateSchema nor schemaFile is set.
+func newSchemaValidator(validateSchema bool, schemaFile string, restConfig *rest.Config) (*validate.SchemaValidator, error) {
+	switch {
+	case schemaFile != "":
+		return validate.NewFileSchemaValidator(schemaFile)
+	case validateSchema:
+		return validate.NewClusterSchemaValidator(restConfig)
+	}
+	return nil, nil
+}
+
+// newPolicyChecker returns a checker for the policies at source, see policy.ReadModules, or nil if source is empty.
+func newPolicyChecker(source string, cs kubernetes.Interface) (*policy.Checker, error) {
+	if source == "" {
+		return nil, nil
+	}
+	modules, err := policy.ReadModules(source, cs)
+	if err != nil {
+		return nil, err
+	}
+	return policy.NewChecker(modules)
+}
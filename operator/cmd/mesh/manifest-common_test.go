@@ -0,0 +1,292 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+func TestOverlayValuesFiles(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "values-files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	files := map[string]string{
+		"base.yaml": `
+global:
+  hub: docker.io/istio
+  tag: 1.6.0
+pilot:
+  traceSampling: 1.0
+`,
+		"override.yaml": `
+global:
+  tag: 1.6.1
+`,
+		"iop.yaml": `
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+spec:
+  profile: demo
+`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(tmp, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l := clog.NewConsoleLogger(true, os.Stdout, os.Stderr)
+
+	tests := []struct {
+		desc        string
+		set         []string
+		valuesFiles []string
+		want        string
+		wantErr     string
+	}{
+		{
+			desc: "no values files",
+			set:  []string{"values.global.tag=1.6.2"},
+			want: `
+spec:
+  values:
+    global:
+      tag: 1.6.2
+`,
+		},
+		{
+			desc:        "values files overlaid in order",
+			valuesFiles: []string{"base.yaml", "override.yaml"},
+			want: `
+spec:
+  values:
+    global:
+      hub: docker.io/istio
+      tag: 1.6.1
+    pilot:
+      traceSampling: 1.0
+`,
+		},
+		{
+			desc:        "set overrides values files",
+			set:         []string{"values.global.tag=1.6.2"},
+			valuesFiles: []string{"base.yaml", "override.yaml"},
+			want: `
+spec:
+  values:
+    global:
+      hub: docker.io/istio
+      tag: 1.6.2
+    pilot:
+      traceSampling: 1.0
+`,
+		},
+		{
+			desc:        "IstioOperator is not a values file",
+			valuesFiles: []string{"iop.yaml"},
+			wantErr:     "use --filename for IstioOperator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ysf, _, err := yamlFromSetFlags(tt.set, false, l)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var valuesFiles []string
+			for _, f := range tt.valuesFiles {
+				valuesFiles = append(valuesFiles, filepath.Join(tmp, f))
+			}
+			got, err := overlayValuesFiles(ysf, valuesFiles, false, l)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !util.IsYAMLEqual(got, tt.want) {
+				t.Errorf("got:\n%s\nwant:\n%s\ndiff:\n%s", got, tt.want, util.YAMLDiff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestApplyComponentFlagAliases(t *testing.T) {
+	tests := []struct {
+		desc    string
+		set     []string
+		enable  []string
+		disable []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "no aliases",
+			set:  []string{"profile=demo"},
+			want: []string{"profile=demo"},
+		},
+		{
+			desc:    "core components, gateways and addons",
+			set:     []string{"profile=demo"},
+			enable:  []string{"pilot", "CNI", "ingressGateways", "grafana"},
+			disable: []string{"egressgateways", "policy"},
+			want: []string{
+				"components.pilot.enabled=true",
+				"components.cni.enabled=true",
+				"values.gateways.istio-ingressgateway.enabled=true",
+				"addonComponents.grafana.enabled=true",
+				"values.gateways.istio-egressgateway.enabled=false",
+				"components.policy.enabled=false",
+				"profile=demo",
+			},
+		},
+		{
+			desc:    "enabled and disabled",
+			enable:  []string{"pilot"},
+			disable: []string{"Pilot"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := applyComponentFlagAliases(tt.set, tt.enable, tt.disable)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRevisionFlagAlias(t *testing.T) {
+	tests := []struct {
+		desc     string
+		set      []string
+		revision string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			desc: "no revision",
+			set:  []string{"profile=demo"},
+			want: []string{"profile=demo"},
+		},
+		{
+			desc:     "revision",
+			set:      []string{"profile=demo"},
+			revision: "canary",
+			want:     []string{"profile=demo", "revision=canary"},
+		},
+		{
+			desc:     "same revision set",
+			set:      []string{"revision=canary"},
+			revision: "canary",
+			want:     []string{"revision=canary", "revision=canary"},
+		},
+		{
+			desc:     "different revision set",
+			set:      []string{"revision=stable"},
+			revision: "canary",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := applyRevisionFlagAlias(tt.set, tt.revision)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemovePaths(t *testing.T) {
+	iopsYAML := `
+profile: demo
+components:
+  pilot:
+    enabled: true
+    k8s:
+      replicaCount: 2
+  egressGateways:
+  - name: istio-egressgateway
+    enabled: true
+  - name: other-egressgateway
+    enabled: true
+`
+	tests := []struct {
+		desc  string
+		paths []string
+		want  string
+	}{
+		{
+			desc:  "map entry and list item",
+			paths: []string{"components.pilot.k8s", "components.egressGateways[name:istio-egressgateway]"},
+			want: `
+profile: demo
+components:
+  pilot:
+    enabled: true
+  egressGateways:
+  - name: other-egressgateway
+    enabled: true
+`,
+		},
+		{
+			desc:  "missing paths",
+			paths: []string{"components.cni", "components.egressGateways[name:missing]"},
+			want:  iopsYAML,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := removePaths(iopsYAML, tt.paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !util.IsYAMLEqual(got, tt.want) {
+				t.Errorf("got:\n%s\nwant:\n%s\ndiff:\n%s", got, tt.want, util.YAMLDiff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestSplitUnsetFlags(t *testing.T) {
+	sets, unsets := splitUnsetFlags([]string{"profile=demo", "components.cni=null", "values.global.hub=nullhub"})
+	if want := []string{"profile=demo", "values.global.hub=nullhub"}; !reflect.DeepEqual(sets, want) {
+		t.Errorf("got sets %v, want %v", sets, want)
+	}
+	if want := []string{"components.cni"}; !reflect.DeepEqual(unsets, want) {
+		t.Errorf("got unsets %v, want %v", unsets, want)
+	}
+}
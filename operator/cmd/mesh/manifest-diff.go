@@ -15,15 +15,16 @@
 package mesh
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
-	"istio.io/istio/operator/pkg/compare"
-
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
+	"istio.io/istio/operator/pkg/compare"
 	"istio.io/istio/operator/pkg/util"
 )
 
@@ -45,8 +46,16 @@ type manifestDiffArgs struct {
 	// The format of each renaming pair is A->B, all renaming pairs are comma separated.
 	// e.g. Service:*:istio-pilot->Service:*:istio-control - rename istio-pilot service into istio-control
 	renameResources string
+	// semantic ignores differences which don't affect the desired state, such as list ordering and default values.
+	semantic bool
+	// outputFormat is the format of the diff output, one of text|json|yaml. json and yaml imply semantic.
+	outputFormat string
 }
 
+const (
+	textOutput = "text"
+)
+
 func addManifestDiffFlags(cmd *cobra.Command, diffArgs *manifestDiffArgs) {
 	cmd.PersistentFlags().BoolVarP(&diffArgs.compareDir, "directory", "r",
 		false, "compare directory")
@@ -62,6 +71,13 @@ func addManifestDiffFlags(cmd *cobra.Command, diffArgs *manifestDiffArgs) {
 		"renameResources identifies renamed resources before comparison.\n"+
 			"The format of each renaming pair is A->B, all renaming pairs are comma separated.\n"+
 			"e.g. Service:*:istiod->Service:*:istio-control - rename istiod service into istio-control")
+	cmd.PersistentFlags().BoolVar(&diffArgs.semantic, "semantic", false,
+		"Ignore differences which don't affect the desired state: ordering of containers, ports, volumes and\n"+
+			"volume mounts, fields set to their default values, empty values and server generated metadata. The rename\n"+
+			"option is not supported.")
+	cmd.PersistentFlags().StringVarP(&diffArgs.outputFormat, "output", "o", textOutput,
+		"Output format: one of text|json|yaml. The json and yaml formats output a semantic diff as a list of\n"+
+			"changed objects grouped by component.")
 }
 
 func manifestDiffCmd(rootArgs *rootArgs, diffArgs *manifestDiffArgs) *cobra.Command {
@@ -78,20 +94,16 @@ func manifestDiffCmd(rootArgs *rootArgs, diffArgs *manifestDiffArgs) *cobra.Comm
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 			var equal bool
+			switch diffArgs.outputFormat {
+			case textOutput, jsonOutput, yamlOutput:
+			default:
+				return fmt.Errorf("unknown output format: %v", diffArgs.outputFormat)
+			}
 			if diffArgs.compareDir {
-				equal, err = compareManifestsFromDirs(rootArgs, args[0], args[1], diffArgs.renameResources,
-					diffArgs.selectResources, diffArgs.ignoreResources)
-				if err != nil {
-					return err
-				}
-				if !equal {
-					os.Exit(1)
-				}
-				return nil
+				equal, err = compareManifestsFromDirs(rootArgs, args[0], args[1], diffArgs)
+			} else {
+				equal, err = compareManifestsFromFiles(rootArgs, args, diffArgs)
 			}
-
-			equal, err = compareManifestsFromFiles(rootArgs, args, diffArgs.renameResources,
-				diffArgs.selectResources, diffArgs.ignoreResources)
 			if err != nil {
 				return err
 			}
@@ -104,8 +116,7 @@ func manifestDiffCmd(rootArgs *rootArgs, diffArgs *manifestDiffArgs) *cobra.Comm
 }
 
 //compareManifestsFromFiles compares two manifest files
-func compareManifestsFromFiles(rootArgs *rootArgs, args []string,
-	renameResources, selectResources, ignoreResources string) (bool, error) {
+func compareManifestsFromFiles(rootArgs *rootArgs, args []string, diffArgs *manifestDiffArgs) (bool, error) {
 	initLogsOrExit(rootArgs)
 
 	a, err := ioutil.ReadFile(args[0])
@@ -117,18 +128,7 @@ func compareManifestsFromFiles(rootArgs *rootArgs, args []string,
 		return false, fmt.Errorf("could not read %q: %v", args[1], err)
 	}
 
-	diff, err := compare.ManifestDiffWithRenameSelectIgnore(string(a), string(b), renameResources, selectResources,
-		ignoreResources, rootArgs.verbose)
-	if err != nil {
-		return false, err
-	}
-	if diff != "" {
-		fmt.Printf("Differences in manifests are:\n%s\n", diff)
-		return false, nil
-	}
-
-	fmt.Println("Manifests are identical")
-	return true, nil
+	return compareManifests(rootArgs, string(a), string(b), diffArgs)
 }
 
 func yamlFileFilter(path string) bool {
@@ -136,8 +136,7 @@ func yamlFileFilter(path string) bool {
 }
 
 //compareManifestsFromDirs compares manifests from two directories
-func compareManifestsFromDirs(rootArgs *rootArgs, dirName1, dirName2,
-	renameResources, selectResources, ignoreResources string) (bool, error) {
+func compareManifestsFromDirs(rootArgs *rootArgs, dirName1, dirName2 string, diffArgs *manifestDiffArgs) (bool, error) {
 	initLogsOrExit(rootArgs)
 
 	mf1, err := util.ReadFilesWithFilter(dirName1, yamlFileFilter)
@@ -149,8 +148,17 @@ func compareManifestsFromDirs(rootArgs *rootArgs, dirName1, dirName2,
 		return false, err
 	}
 
-	diff, err := compare.ManifestDiffWithRenameSelectIgnore(mf1, mf2, renameResources, selectResources,
-		ignoreResources, rootArgs.verbose)
+	return compareManifests(rootArgs, mf1, mf2, diffArgs)
+}
+
+// compareManifests compares manifests a and b, prints the differences and returns true if there are none.
+func compareManifests(rootArgs *rootArgs, a, b string, diffArgs *manifestDiffArgs) (bool, error) {
+	if diffArgs.semantic || diffArgs.outputFormat != textOutput {
+		return compareManifestsSemantic(a, b, diffArgs)
+	}
+
+	diff, err := compare.ManifestDiffWithRenameSelectIgnore(a, b, diffArgs.renameResources, diffArgs.selectResources,
+		diffArgs.ignoreResources, rootArgs.verbose)
 	if err != nil {
 		return false, err
 	}
@@ -162,3 +170,46 @@ func compareManifestsFromDirs(rootArgs *rootArgs, dirName1, dirName2,
 	fmt.Println("Manifests are identical")
 	return true, nil
 }
+
+// compareManifestsSemantic compares manifests a and b using a semantic diff and prints the changes in the requested
+// output format.
+func compareManifestsSemantic(a, b string, diffArgs *manifestDiffArgs) (bool, error) {
+	if diffArgs.renameResources != "" {
+		return false, fmt.Errorf("--rename is not supported with semantic diffs")
+	}
+	a, err := compare.FilterManifest(a, diffArgs.selectResources, diffArgs.ignoreResources)
+	if err != nil {
+		return false, err
+	}
+	b, err = compare.FilterManifest(b, diffArgs.selectResources, diffArgs.ignoreResources)
+	if err != nil {
+		return false, err
+	}
+	changes, err := compare.SemanticManifestDiff(a, b)
+	if err != nil {
+		return false, err
+	}
+	grouped := compare.GroupByComponent(changes)
+
+	switch diffArgs.outputFormat {
+	case jsonOutput:
+		j, err := json.MarshalIndent(grouped, "", "    ")
+		if err != nil {
+			return false, err
+		}
+		fmt.Println(string(j))
+	case yamlOutput:
+		y, err := yaml.Marshal(grouped)
+		if err != nil {
+			return false, err
+		}
+		fmt.Print(string(y))
+	default:
+		if len(changes) == 0 {
+			fmt.Println("Manifests are identical")
+		} else {
+			fmt.Printf("Differences in manifests are:\n%s\n", grouped)
+		}
+	}
+	return len(changes) == 0, nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/operator/pkg/component"
+	"istio.io/istio/operator/pkg/controlplane"
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/translate"
+	"istio.io/istio/operator/pkg/util/clog"
+	"istio.io/istio/operator/version"
+	"istio.io/pkg/log"
+)
+
+const (
+	// exportHelmReleasesFilename is the name of the file listing the exported releases in install order.
+	exportHelmReleasesFilename = "releases.yaml"
+	// exportHelmChartDirname is the name of the directory a component chart is exported to.
+	exportHelmChartDirname = "chart"
+	// exportHelmValuesFilename is the name of the values file of an exported component.
+	exportHelmValuesFilename = "values.yaml"
+	// exportHelmPostRenderFilename is the name of the file holding the resources of a component that the chart alone
+	// does not render the way the operator does.
+	exportHelmPostRenderFilename = "postrender.yaml"
+)
+
+type manifestExportHelmArgs struct {
+	// inFilenames is an array of paths to the input IstioOperator CR files.
+	inFilenames []string
+	// valuesFiles is an array of paths to helm values files which are applied to spec.values.
+	valuesFiles []string
+	// outDir is the directory to write the charts and values to.
+	outDir string
+	// set is a string with element format "path=value" where path is an IstioOperator path and the value is a
+	// value to set the node at that path to.
+	set []string
+	// components is a list of components to enable, as an alias for --set enablement paths.
+	components []string
+	// disableComponents is a list of components to disable, as an alias for --set enablement paths.
+	disableComponents []string
+	// force proceeds even if there are validation errors
+	force bool
+	// charts is a path to a charts and profiles directory in the local filesystem, or URL with a release tgz.
+	charts string
+}
+
+// helmReleases is the list of exported releases, in the order they must be installed in.
+type helmReleases struct {
+	Releases []*helmRelease `json:"releases"`
+}
+
+// helmRelease describes the release of a single exported component.
+type helmRelease struct {
+	// Name is the name of the release and of its directory in the export.
+	Name string `json:"name"`
+	// Namespace is the namespace to install the release into.
+	Namespace string `json:"namespace"`
+	// Chart is the path of the chart, relative to the export directory.
+	Chart string `json:"chart"`
+	// Values is the path of the values file, relative to the export directory.
+	Values string `json:"values"`
+	// PostRender is the path of the file of resources that must replace the chart output, relative to the export
+	// directory. It is empty if the chart renders the same resources as the operator.
+	PostRender string `json:"postRender,omitempty"`
+}
+
+func addManifestExportHelmFlags(cmd *cobra.Command, args *manifestExportHelmArgs) {
+	cmd.PersistentFlags().StringSliceVarP(&args.inFilenames, "filename", "f", nil, filenameFlagHelpStr)
+	markFilenameFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.valuesFiles, "values", nil, valuesFlagHelpStr)
+	cmd.PersistentFlags().StringVarP(&args.outDir, "output", "o", "",
+		"Directory to write the charts and values to. Defaults to istio-<version>-helm in the current directory")
+	cmd.PersistentFlags().StringArrayVarP(&args.set, "set", "s", nil, SetFlagHelpStr)
+	markSetFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.components, "components", nil, componentsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.disableComponents, "disable-components", nil, disableComponentsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
+	cmd.PersistentFlags().StringVarP(&args.charts, "charts", "d", "", chartsFlagHelpStr)
+}
+
+func manifestExportHelmCmd(rootArgs *rootArgs, mehArgs *manifestExportHelmArgs, logOpts *log.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-helm",
+		Short: "Exports an installation as Helm charts and values",
+		Long: "The export-helm subcommand writes the chart and the values of each enabled component, as the operator " +
+			"renders them, to a directory that can be installed with Helm or a GitOps Helm controller. Resources the " +
+			"chart does not render the same way, e.g. because of k8s settings in the IstioOperator CR, are written " +
+			"alongside the values, to be applied with a post-renderer.",
+		Example: `  # Export the current installation, as recorded in the installed-state IstioOperator CR
+  kubectl -n istio-system get istiooperator installed-state -o yaml > installed-state.yaml
+  istioctl manifest export-helm -f installed-state.yaml -o istio-helm
+
+  # Export the demo profile
+  istioctl manifest export-helm --set profile=demo -o istio-demo-helm
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("export-helm accepts no positional arguments, got %#v", args)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return manifestExportHelm(rootArgs, mehArgs, logOpts, l)
+		}}
+}
+
+func manifestExportHelm(args *rootArgs, mehArgs *manifestExportHelmArgs, logopts *log.Options, l clog.Logger) error {
+	if err := configLogs(args, logopts); err != nil {
+		return fmt.Errorf("could not configure logs: %s", err)
+	}
+
+	setFlags, err := applyComponentFlagAliases(applyInstallFlagAlias(mehArgs.set, mehArgs.charts), mehArgs.components, mehArgs.disableComponents)
+	if err != nil {
+		return err
+	}
+	ysf, unsetPaths, err := yamlFromSetFlags(setFlags, mehArgs.force, l)
+	if err != nil {
+		return err
+	}
+	if ysf, err = overlayValuesFiles(ysf, mehArgs.valuesFiles, mehArgs.force, l); err != nil {
+		return err
+	}
+	mergedYAML, _, err := GenerateConfig(mehArgs.inFilenames, ysf, unsetPaths, mehArgs.force, nil, l)
+	if err != nil {
+		return err
+	}
+	iops, err := unmarshalAndValidateIOPS(mergedYAML, mehArgs.force, l)
+	if err != nil {
+		return err
+	}
+	t, err := translate.NewTranslator(version.OperatorBinaryVersion.MinorVersion)
+	if err != nil {
+		return err
+	}
+	cp, err := controlplane.NewIstioOperator(iops, t)
+	if err != nil {
+		return err
+	}
+	if err := cp.Run(); err != nil {
+		return err
+	}
+	charts, manifests, err := cp.HelmCharts()
+	if err != nil {
+		return err
+	}
+
+	outDir := mehArgs.outDir
+	if outDir == "" {
+		outDir = packageDirname() + "-helm"
+	}
+	if args.dryRun {
+		l.LogAndPrintf("Dry run: would export %d Helm releases to %s", len(charts), outDir)
+		return nil
+	}
+	releases := &helmReleases{}
+	for i, hc := range charts {
+		hr, err := exportHelmRelease(hc, manifests[i], iops.InstallPackagePath, outDir, l)
+		if err != nil {
+			return fmt.Errorf("could not export component %s: %s", hc.ComponentName, err)
+		}
+		releases.Releases = append(releases.Releases, hr)
+	}
+	b, err := yaml.Marshal(releases)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outDir, exportHelmReleasesFilename), b, 0644); err != nil {
+		return err
+	}
+	l.LogAndPrintf("Exported %d Helm releases to %s, install them in the order listed in %s.", len(releases.Releases),
+		outDir, exportHelmReleasesFilename)
+	return nil
+}
+
+// exportHelmRelease writes the chart and values of hc to a directory under outDir. Any resources in manifest, the
+// manifest the operator renders for the component, which differ from the chart output are written to a post-render
+// file in the same directory.
+func exportHelmRelease(hc *component.HelmChart, manifest, installPackagePath, outDir string, l clog.Logger) (*helmRelease, error) {
+	hr := &helmRelease{
+		Name:      helmReleaseName(hc),
+		Namespace: hc.Namespace,
+	}
+	hr.Chart = filepath.Join(hr.Name, exportHelmChartDirname)
+	hr.Values = filepath.Join(hr.Name, exportHelmValuesFilename)
+	if err := helm.ExportChart(installPackagePath, hc.Subdir, hc.Path, filepath.Join(outDir, hr.Chart)); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outDir, hr.Values), []byte(hc.Values), 0644); err != nil {
+		return nil, err
+	}
+
+	changed, removed, err := postRenderObjects(hc.Manifest, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(removed) != 0 {
+		l.LogAndPrintf("! Release %s: the chart renders resources the operator does not install, remove them with a "+
+			"post-renderer: %s", hr.Name, strings.Join(removed, ", "))
+	}
+	if len(changed) == 0 {
+		return hr, nil
+	}
+	ym, err := changed.YAMLManifest()
+	if err != nil {
+		return nil, err
+	}
+	hr.PostRender = filepath.Join(hr.Name, exportHelmPostRenderFilename)
+	if err := os.MkdirAll(filepath.Join(outDir, hr.Name), os.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outDir, hr.PostRender), []byte(ym), 0644); err != nil {
+		return nil, err
+	}
+	l.LogAndPrintf("! Release %s: %d resources differ from the chart output and are written to %s, apply them with "+
+		"a post-renderer.", hr.Name, len(changed), hr.PostRender)
+	return hr, nil
+}
+
+// postRenderObjects compares chartManifest, rendered from the chart and values alone, with manifest, the manifest the
+// operator renders. It returns the objects of manifest which are missing from or differ in chartManifest, and the
+// names of the objects of chartManifest which are missing from manifest.
+func postRenderObjects(chartManifest, manifest string) (changed object.K8sObjects, removed []string, err error) {
+	chartObjs, err := object.ParseK8sObjectsFromYAMLManifest(chartManifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	chartMap, objMap := chartObjs.ToMap(), objs.ToMap()
+	for _, o := range objs {
+		if !o.Equal(chartMap[o.Hash()]) {
+			changed = append(changed, o)
+		}
+	}
+	for _, o := range chartObjs {
+		if objMap[o.Hash()] == nil {
+			removed = append(removed, o.Hash())
+		}
+	}
+	return changed, removed, nil
+}
+
+// helmReleaseName returns the release name for the component of hc, which is unique within an installation.
+func helmReleaseName(hc *component.HelmChart) string {
+	switch {
+	case hc.ComponentName.IsGateway() && hc.ResourceName != "":
+		return hc.ResourceName
+	case hc.AddonName != "":
+		return hc.AddonName
+	}
+	return strings.ToLower(string(hc.ComponentName))
+}
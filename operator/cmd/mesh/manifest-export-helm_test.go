@@ -0,0 +1,81 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPostRenderObjects(t *testing.T) {
+	chartManifest := `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istiod
+  namespace: istio-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unused
+  namespace: istio-system
+`
+	manifest := `
+# Resources for Pilot component
+
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istiod
+  namespace: istio-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  replicas: 3
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: istiod-scc
+  namespace: istio-system
+`
+	changed, removed, err := postRenderObjects(chartManifest, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range changed {
+		got = append(got, o.Hash())
+	}
+	if want := []string{"Deployment:istio-system:istiod", "Role:istio-system:istiod-scc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got changed %v, want %v", got, want)
+	}
+	if want := []string{"ConfigMap:istio-system:unused"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("got removed %v, want %v", removed, want)
+	}
+}
@@ -16,18 +16,27 @@ package mesh
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
-	"sort"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	"istio.io/api/operator/v1alpha1"
 	"istio.io/istio/operator/pkg/controlplane"
+	"istio.io/istio/operator/pkg/digest"
+	"istio.io/istio/operator/pkg/footprint"
+	"istio.io/istio/operator/pkg/gitops"
 	"istio.io/istio/operator/pkg/helm"
 	"istio.io/istio/operator/pkg/manifest"
 	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/policy"
+	"istio.io/istio/operator/pkg/sbom"
 	"istio.io/istio/operator/pkg/translate"
 	"istio.io/istio/operator/pkg/util/clog"
 	"istio.io/istio/operator/version"
@@ -37,23 +46,102 @@ import (
 type manifestGenerateArgs struct {
 	// inFilenames is an array of paths to the input IstioOperator CR files.
 	inFilename []string
+	// valuesFiles is an array of paths to helm values files which are applied to spec.values.
+	valuesFiles []string
 	// outFilename is the path to the generated output directory.
 	outFilename string
 	// set is a string with element format "path=value" where path is an IstioOperator path and the value is a
 	// value to set the node at that path to.
 	set []string
+	// revision is the control plane revision, as an alias for --set revision.
+	revision string
+	// components is a list of components to enable, as an alias for --set enablement paths.
+	components []string
+	// disableComponents is a list of components to disable, as an alias for --set enablement paths.
+	disableComponents []string
 	// force proceeds even if there are validation errors
 	force bool
 	// charts is a path to a charts and profiles directory in the local filesystem, or URL with a release tgz.
 	charts string
+	// sbom is the path to write a CycloneDX software bill of materials for the generated manifest to.
+	sbom string
+	// resolveDigests queries image registries and rewrites image references in the manifest to digests.
+	resolveDigests bool
+	// digestLockfile is the path of a lockfile of image digests, which is read and updated when resolving digests.
+	digestLockfile string
+	// validateSchema validates the generated objects against the OpenAPI schemas of the cluster.
+	validateSchema bool
+	// schemaFile is the path to an OpenAPI document which generated objects are validated against.
+	schemaFile string
+	// policy is a directory or ConfigMap of Rego policies which generated objects are checked against.
+	policy string
+	// gitops orders the generated objects for a GitOps controller, one of argocd or flux.
+	gitops string
+	// includeCRDs selects whether CRDs are generated along with the other objects, alone or not at all.
+	includeCRDs string
+	// namespaced leaves out the cluster scoped resources, which are provisioned by a cluster admin.
+	namespaced bool
+	// forKubectlDiff strips the fields populated by the API server or by Istio after installation from the output.
+	forKubectlDiff bool
+	// component selects the components to output. All components are output if it is empty.
+	component []string
+	// filter are expressions selecting the objects to output, see object.ParseFilter.
+	filter []string
+	// resourceLabels are added to every rendered object.
+	resourceLabels map[string]string
+	// resourceAnnotations are added to every rendered object.
+	resourceAnnotations map[string]string
+	// resourceReport prints the CPU and memory requested by the rendered workloads instead of the manifest.
+	resourceReport bool
 }
 
 func addManifestGenerateFlags(cmd *cobra.Command, args *manifestGenerateArgs) {
 	cmd.PersistentFlags().StringSliceVarP(&args.inFilename, "filename", "f", nil, filenameFlagHelpStr)
+	markFilenameFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.valuesFiles, "values", nil, valuesFlagHelpStr)
 	cmd.PersistentFlags().StringVarP(&args.outFilename, "output", "o", "", "Manifest output directory path")
 	cmd.PersistentFlags().StringArrayVarP(&args.set, "set", "s", nil, SetFlagHelpStr)
+	markSetFlagCompletion(cmd)
+	cmd.PersistentFlags().StringVarP(&args.revision, "revision", "r", "", revisionFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.components, "components", nil, componentsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.disableComponents, "disable-components", nil, disableComponentsFlagHelpStr)
 	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
 	cmd.PersistentFlags().StringVarP(&args.charts, "charts", "d", "", chartsFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.sbom, "sbom", "",
+		"Path to write a CycloneDX software bill of materials to, listing the istioctl version, chart versions, "+
+			"profile, images and input file hashes of the generated manifest")
+	cmd.PersistentFlags().BoolVar(&args.resolveDigests, "resolve-digests", false,
+		"Query the image registry for each image tag in the generated manifest and pin image references to digests")
+	cmd.PersistentFlags().StringVar(&args.digestLockfile, "digest-lockfile", "",
+		"Path to a lockfile of image digests. Digests are pinned from the lockfile if present, "+
+			"and images resolved with --resolve-digests are added to it. If unset, digests are pinned from the "+
+			"lockfile of the installation package set with --charts, if it has one")
+	cmd.PersistentFlags().BoolVar(&args.validateSchema, "validate-schema", false, validateSchemaFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.schemaFile, "schema-file", "", schemaFileFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.policy, "policy", "", policyFlagHelpStr)
+	cmd.PersistentFlags().StringVar(&args.gitops, "gitops", "",
+		"Order the generated objects for a GitOps controller, which applies CRDs, the control plane, webhooks and gateways "+
+			"in turn. argocd adds sync-wave annotations. flux writes a directory per stage to --output, with Flux "+
+			"Kustomizations for the paths relative to the repository root that depend on the previous stage")
+	cmd.PersistentFlags().StringVar(&args.includeCRDs, "include-crds", manifest.IncludeCRDs, includeCRDsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.namespaced, "namespaced", false, namespacedFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.forKubectlDiff, "for-kubectl-diff", false,
+		"Remove the fields populated by the API server or by Istio after installation, like status, creationTimestamp "+
+			"and empty webhook caBundles, and sort the objects of each component, so that the output can be piped to "+
+			"kubectl diff -f -. Set values.kiali.dashboard.auth.signingKey for the Kiali config to be deterministic")
+	cmd.PersistentFlags().StringSliceVar(&args.component, "component", nil,
+		"Comma separated list of components to output, e.g. Pilot,IngressGateways, leaving out the others. Unlike "+
+			"--components, this does not change which components are enabled")
+	cmd.PersistentFlags().StringArrayVar(&args.filter, "filter", nil,
+		"Output only the objects matching a filter of comma separated field=value or field!=value terms, e.g. "+
+			"kind=Deployment,namespace=istio-system. The fields are kind, name, namespace, group and apiVersion, and "+
+			"values may be shell patterns like istio-*. May be repeated to output the objects matching any filter")
+	cmd.PersistentFlags().StringToStringVar(&args.resourceLabels, "resource-labels", nil, resourceLabelsFlagHelpStr)
+	cmd.PersistentFlags().StringToStringVar(&args.resourceAnnotations, "resource-annotations", nil, resourceAnnotationsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.resourceReport, "resource-report", false,
+		"Print the CPU and memory requested by each rendered Deployment, StatefulSet and DaemonSet and their totals "+
+			"instead of the manifest, with the minimum and maximum replicas of autoscaled workloads. Injected sidecars "+
+			"are not counted")
 }
 
 func manifestGenerateCmd(rootArgs *rootArgs, mgArgs *manifestGenerateArgs, logOpts *log.Options) *cobra.Command {
@@ -71,6 +159,21 @@ func manifestGenerateCmd(rootArgs *rootArgs, mgArgs *manifestGenerateArgs, logOp
   # Generate the demo profile
   istioctl manifest generate --set profile=demo
 
+  # Generate manifests to be synced by ArgoCD in sync waves
+  istioctl manifest generate --gitops argocd > istio.yaml
+
+  # Generate only the istiod and ingress gateway resources
+  istioctl manifest generate --component Pilot,IngressGateways
+
+  # Generate only the Deployments in istio-system
+  istioctl manifest generate --filter kind=Deployment,namespace=istio-system
+
+  # Show the CPU and memory the demo profile requests
+  istioctl manifest generate --set profile=demo --resource-report
+
+  # Show the changes applying the demo profile would make to the cluster
+  istioctl manifest generate --set profile=demo --for-kubectl-diff | kubectl diff -f -
+
   # To override a setting that includes dots, escape them with a backslash (\).  Your shell may require enclosing quotes.
   istioctl manifest generate --set "values.sidecarInjectorWebhook.injectedAnnotations.container\.apparmor\.security\.beta\.kubernetes\.io/istio-proxy=runtime/default"
 `,
@@ -81,32 +184,125 @@ func manifestGenerateCmd(rootArgs *rootArgs, mgArgs *manifestGenerateArgs, logOp
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			l := clog.NewConsoleLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
 			return manifestGenerate(rootArgs, mgArgs, logOpts, l)
 		}}
 
 }
 
 func manifestGenerate(args *rootArgs, mgArgs *manifestGenerateArgs, logopts *log.Options, l clog.Logger) error {
-	if err := configLogs(args.logToStdErr, logopts); err != nil {
+	if err := configLogs(args, logopts); err != nil {
 		return fmt.Errorf("could not configure logs: %s", err)
 	}
+	if mgArgs.gitops != "" && !gitops.Modes[mgArgs.gitops] {
+		return fmt.Errorf("unknown --gitops mode %s, must be one of %s or %s", mgArgs.gitops, gitops.ArgoCD, gitops.Flux)
+	}
+	if mgArgs.gitops == gitops.Flux && mgArgs.outFilename == "" {
+		return fmt.Errorf("--gitops %s requires an --output directory", gitops.Flux)
+	}
+	if err := manifest.ValidateCRDMode(mgArgs.includeCRDs); err != nil {
+		return err
+	}
 
-	ysf, err := yamlFromSetFlags(applyInstallFlagAlias(mgArgs.set, mgArgs.charts), mgArgs.force, l)
+	setFlags, err := applyRevisionFlagAlias(mgArgs.set, mgArgs.revision)
 	if err != nil {
 		return err
 	}
+	setFlags, err = applyComponentFlagAliases(applyInstallFlagAlias(setFlags, mgArgs.charts), mgArgs.components, mgArgs.disableComponents)
+	if err != nil {
+		return err
+	}
+	ysf, unsetPaths, err := yamlFromSetFlags(setFlags, mgArgs.force, l)
+	if err != nil {
+		return err
+	}
+	if ysf, err = overlayValuesFiles(ysf, mgArgs.valuesFiles, mgArgs.force, l); err != nil {
+		return err
+	}
+	if ysf, err = overlayResourceMetadata(ysf, mgArgs.resourceLabels, mgArgs.resourceAnnotations); err != nil {
+		return err
+	}
 
-	manifests, _, err := GenManifests(mgArgs.inFilename, ysf, mgArgs.force, nil, l)
+	manifests, iops, cp, err := genManifests(mgArgs.inFilename, ysf, unsetPaths, mgArgs.force, nil, l)
 	if err != nil {
 		return err
 	}
+	if manifests, err = manifest.FilterCRDs(manifests, mgArgs.includeCRDs); err != nil {
+		return err
+	}
+	if mgArgs.namespaced {
+		if manifests, err = manifest.FilterNamespaced(manifests); err != nil {
+			return err
+		}
+	}
 
-	if mgArgs.outFilename == "" {
-		for _, m := range orderedManifests(manifests) {
-			l.Print(m + "\n")
+	overridden, err := overridesInjection(mgArgs.inFilename, ysf)
+	if err != nil {
+		return err
+	}
+	if overridden && ValidateInjectionTemplates != nil {
+		if err := ValidateInjectionTemplates(manifests, iops.Revision); err != nil {
+			return err
+		}
+	}
+	if manifests, err = manifest.FilterComponents(manifests, mgArgs.component); err != nil {
+		return err
+	}
+	if len(mgArgs.filter) != 0 {
+		if manifests, err = filterManifestObjects(manifests, mgArgs.filter); err != nil {
+			return err
 		}
-	} else {
+	}
+
+	if mgArgs.resourceReport {
+		r, err := footprint.New(manifests)
+		if err != nil {
+			return fmt.Errorf("could not compute the resource report: %s", err)
+		}
+		return r.Write(clog.NewPrintWriter(l))
+	}
+
+	packageLockfile := digest.PackageLockfile(iops.InstallPackagePath)
+	if mgArgs.resolveDigests || mgArgs.digestLockfile != "" || packageLockfile != "" {
+		if manifests, err = pinImageDigests(manifests, mgArgs.resolveDigests, mgArgs.digestLockfile, packageLockfile, args.dryRun); err != nil {
+			return err
+		}
+	}
+
+	if mgArgs.validateSchema || mgArgs.schemaFile != "" {
+		if err := validateManifestSchemas(manifests, mgArgs.validateSchema, mgArgs.schemaFile); err != nil {
+			return err
+		}
+	}
+
+	if mgArgs.policy != "" {
+		if err := checkManifestPolicies(manifests, mgArgs.policy); err != nil {
+			return err
+		}
+	}
+
+	if mgArgs.gitops == gitops.ArgoCD {
+		if manifests, err = gitops.AnnotateSyncWaves(manifests); err != nil {
+			return err
+		}
+	}
+
+	if mgArgs.forKubectlDiff {
+		if manifests, err = manifest.ForKubectlDiff(manifests); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case mgArgs.gitops == gitops.Flux:
+		if err := writeFluxStages(manifests, mgArgs.outFilename, args.dryRun, l); err != nil {
+			return err
+		}
+	case mgArgs.outFilename == "":
+		if err := writeOrderedManifests(clog.NewPrintWriter(l), manifests); err != nil {
+			return err
+		}
+	default:
 		if err := os.MkdirAll(mgArgs.outFilename, os.ModePerm); err != nil {
 			return err
 		}
@@ -115,52 +311,218 @@ func manifestGenerate(args *rootArgs, mgArgs *manifestGenerateArgs, logopts *log
 		}
 	}
 
+	if mgArgs.sbom != "" {
+		return writeSBOM(mgArgs.sbom, manifests, cp.RenderedChartVersions(manifests), iops, mgArgs.inFilename, args.dryRun, l)
+	}
+	return nil
+}
+
+// filterManifestObjects returns manifests with only the objects matching any of the filter expressions in filters.
+func filterManifestObjects(manifests name.ManifestMap, filters []string) (name.ManifestMap, error) {
+	var ps []object.Predicate
+	for _, f := range filters {
+		p, err := object.ParseFilter(f)
+		if err != nil {
+			return nil, fmt.Errorf("bad --filter: %s", err)
+		}
+		ps = append(ps, p)
+	}
+	return manifest.FilterObjects(manifests, object.Or(ps...))
+}
+
+// pinImageDigests rewrites image references in manifests to digests from the lockfile at lockfilePath, or from the
+// lockfile of the installation package at packageLockfilePath if lockfilePath is empty, and, if resolve is set, from
+// the image registries. Newly resolved digests are written back to the lockfile at lockfilePath.
+func pinImageDigests(manifests name.ManifestMap, resolve bool, lockfilePath, packageLockfilePath string, dryRun bool) (name.ManifestMap, error) {
+	lock := &digest.Lock{Images: make(map[string]string)}
+	readPath := lockfilePath
+	if readPath == "" {
+		readPath = packageLockfilePath
+	}
+	if readPath != "" {
+		var err error
+		if lock, err = digest.ReadLockfile(readPath); err != nil {
+			return nil, err
+		}
+	}
+	var resolver digest.Resolver
+	if resolve {
+		resolver = digest.NewRegistryResolver()
+	}
+	out, err := digest.PinManifests(manifests, lock, resolver)
+	if err != nil {
+		return nil, fmt.Errorf("could not pin image digests: %s", err)
+	}
+	if lockfilePath != "" && resolve && !dryRun {
+		if err := lock.Write(lockfilePath); err != nil {
+			return nil, fmt.Errorf("could not write digest lockfile: %s", err)
+		}
+	}
+	return out, nil
+}
+
+// validateManifestSchemas validates the objects in manifests against the OpenAPI document at schemaFile or, if
+// schemaFile is empty, against the schemas of the cluster in the default kube config.
+func validateManifestSchemas(manifests name.ManifestMap, validateSchema bool, schemaFile string) error {
+	var restConfig *rest.Config
+	if schemaFile == "" {
+		var err error
+		if restConfig, _, err = manifest.InitK8SRestClient("", ""); err != nil {
+			return err
+		}
+	}
+	v, err := newSchemaValidator(validateSchema, schemaFile, restConfig)
+	if err != nil {
+		return err
+	}
+	if errs := v.ValidateManifests(manifests); len(errs) != 0 {
+		return fmt.Errorf("generated manifests failed schema validation:\n%s", errs)
+	}
+	return nil
+}
+
+// checkManifestPolicies checks the objects in manifests against the policies at source. A ConfigMap source is read
+// from the cluster in the default kube config.
+func checkManifestPolicies(manifests name.ManifestMap, source string) error {
+	var cs kubernetes.Interface
+	if strings.HasPrefix(source, policy.ConfigMapSourcePrefix) {
+		var err error
+		if _, cs, err = manifest.InitK8SRestClient("", ""); err != nil {
+			return err
+		}
+	}
+	c, err := newPolicyChecker(source, cs)
+	if err != nil {
+		return err
+	}
+	if errs := c.CheckManifests(manifests); len(errs) != 0 {
+		return fmt.Errorf("generated manifests violate policies:\n%s", errs)
+	}
 	return nil
 }
 
+// writeFluxStages writes the objects in manifests to a directory per stage under outDir, together with the Flux
+// Kustomizations which apply the stages in order.
+func writeFluxStages(manifests name.ManifestMap, outDir string, dryRun bool, l clog.Logger) error {
+	stages, err := gitops.Split(manifests)
+	if err != nil {
+		return err
+	}
+	for s, objs := range stages {
+		if len(objs) == 0 {
+			continue
+		}
+		ym, err := objs.YAMLManifest()
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(outDir, gitops.StageDir(s))
+		l.LogAndPrintf("Writing %d objects of stage %s to %s", len(objs), gitops.Stages[s], dir)
+		if dryRun {
+			continue
+		}
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, gitops.Stages[s]+".yaml"), []byte(ym), 0644); err != nil {
+			return err
+		}
+	}
+	ks, err := gitops.FluxKustomizations(stages, filepath.ToSlash(outDir))
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	return ioutil.WriteFile(filepath.Join(outDir, gitops.FluxKustomizationsFilename), []byte(ks), 0644)
+}
+
+// writeSBOM writes a software bill of materials for the given manifests, the charts they were rendered from and the
+// IstioOperatorSpec they were generated from to path.
+func writeSBOM(path string, manifests name.ManifestMap, chartVersions map[string]string, iops *v1alpha1.IstioOperatorSpec,
+	inFilenames []string, dryRun bool, l clog.Logger) error {
+	bom, err := sbom.New(&sbom.Options{
+		ToolName:      "istioctl",
+		ToolVersion:   version.OperatorVersionString,
+		Profile:       iops.Profile,
+		ChartVersions: chartVersions,
+		Manifests:     manifests,
+		InputFiles:    inFilenames,
+	})
+	if err != nil {
+		return err
+	}
+	b, err := bom.JSON()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		l.LogAndPrintf("Dry run: the SBOM was not written to %s.", path)
+		return nil
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
 // GenManifests generates a manifest map, keyed by the component name, from input file list and a YAML tree
-// representation of path-values passed through the --set flag.
+// representation of path-values passed through the --set flag, with the paths of --set flags unsetting a value.
 // If force is set, validation errors will not cause processing to abort but will result in warnings going to the
 // supplied logger.
-func GenManifests(inFilename []string, setOverlayYAML string, force bool,
+func GenManifests(inFilename []string, setOverlayYAML string, unsetPaths []string, force bool,
 	kubeConfig *rest.Config, l clog.Logger) (name.ManifestMap, *v1alpha1.IstioOperatorSpec, error) {
-	mergedYAML, _, err := GenerateConfig(inFilename, setOverlayYAML, force, kubeConfig, l)
+	manifests, iops, _, err := genManifests(inFilename, setOverlayYAML, unsetPaths, force, kubeConfig, l)
+	return manifests, iops, err
+}
+
+// genManifests is GenManifests which also returns the control plane the manifests are rendered with.
+func genManifests(inFilename []string, setOverlayYAML string, unsetPaths []string, force bool,
+	kubeConfig *rest.Config, l clog.Logger) (name.ManifestMap, *v1alpha1.IstioOperatorSpec, *controlplane.IstioOperator, error) {
+	mergedYAML, _, err := GenerateConfig(inFilename, setOverlayYAML, unsetPaths, force, kubeConfig, l)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	mergedIOPS, err := unmarshalAndValidateIOPS(mergedYAML, force, l)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	t, err := translate.NewTranslator(version.OperatorBinaryVersion.MinorVersion)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	cp, err := controlplane.NewIstioOperator(mergedIOPS, t)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if err := cp.Run(); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	manifests, errs := cp.RenderManifest()
 	if errs != nil {
-		return manifests, mergedIOPS, errs.ToError()
+		return manifests, mergedIOPS, cp, errs.ToError()
 	}
-	return manifests, mergedIOPS, nil
+	return manifests, mergedIOPS, cp, nil
 }
 
-func orderedManifests(mm name.ManifestMap) []string {
-	var keys, out []string
-	for k := range mm {
-		keys = append(keys, string(k))
-	}
-	sort.Strings(keys)
-	for _, k := range keys {
-		out = append(out, strings.Join(mm[name.ComponentName(k)], helm.YAMLSeparator))
+// writeOrderedManifests writes the manifests for each component in mm to w, ordered by component name and separated
+// by YAML separators.
+func writeOrderedManifests(w io.Writer, mm name.ManifestMap) error {
+	first := true
+	for _, cn := range mm.SortedComponentNames() {
+		for _, m := range mm[cn] {
+			if !first {
+				if _, err := io.WriteString(w, helm.YAMLSeparator); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := io.WriteString(w, m); err != nil {
+				return err
+			}
+		}
 	}
-	return out
+	_, err := io.WriteString(w, "\n")
+	return err
 }
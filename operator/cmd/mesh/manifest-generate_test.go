@@ -15,6 +15,7 @@
 package mesh
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -28,8 +29,11 @@ import (
 	. "github.com/onsi/gomega"
 
 	"istio.io/istio/operator/pkg/compare"
+	"istio.io/istio/operator/pkg/digest"
 	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
 	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/sbom"
 	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/util/clog"
 	"istio.io/istio/operator/pkg/util/httpserver"
@@ -357,6 +361,119 @@ func TestManifestGenerateHelmValues(t *testing.T) {
 	})
 }
 
+// TestManifestGenerateThirdPartyAddon tests that an addon chart outside the installation package is rendered with
+// the values under its addon name and the global values.
+func TestManifestGenerateThirdPartyAddon(t *testing.T) {
+	testDataDir = filepath.Join(operatorRootDir, "cmd/mesh/testdata/manifest-generate")
+	chartPath := filepath.Join(testDataDir, "third-party-charts/example")
+	flags := "--set addonComponents.example.enabled=true --set addonComponents.example.chartPath=" + chartPath +
+		" --set unvalidatedValues.example.message=hello --set values.global.hub=docker.io/test"
+	_, objs, err := generateManifest("all_off", flags, snapshotCharts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	for _, o := range objs {
+		if o.Kind == "ConfigMap" && o.Name == "example" {
+			got = o.UnstructuredObject().Object
+		}
+	}
+	if got == nil {
+		t.Fatalf("ConfigMap example not found in manifest")
+	}
+	want := map[string]interface{}{
+		"message": "hello",
+		"hub":     "docker.io/test",
+	}
+	if !reflect.DeepEqual(got["data"], want) {
+		t.Errorf("got data %v, want %v", got["data"], want)
+	}
+}
+
+// TestManifestGenerateSBOM tests that the bill of materials lists the charts of the rendered components only.
+func TestManifestGenerateSBOM(t *testing.T) {
+	testDataDir = filepath.Join(operatorRootDir, "cmd/mesh/testdata/manifest-generate")
+	tmpDir, err := ioutil.TempDir("", "sbom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	sbomPath := filepath.Join(tmpDir, "sbom.json")
+	if _, _, err := generateManifest("all_off", "--set components.pilot.enabled=true --sbom "+sbomPath, snapshotCharts); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(sbomPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bom := &sbom.BOM{}
+	if err := json.Unmarshal(b, bom); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range bom.Components {
+		for _, p := range c.Properties {
+			if p.Name == "istio:helm-chart" {
+				got = append(got, c.Name)
+			}
+		}
+	}
+	if want := []string{"istio-discovery"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got charts %v, want %v", got, want)
+	}
+}
+
+// fixedResolver resolves every image to the same digest.
+type fixedResolver string
+
+func (r fixedResolver) Resolve(string) (string, error) {
+	return string(r), nil
+}
+
+func TestManifestGeneratePackageDigests(t *testing.T) {
+	testDataDir = filepath.Join(operatorRootDir, "cmd/mesh/testdata/manifest-generate")
+	const flags = "--set components.pilot.enabled=true"
+	unpinned, _, err := generateManifest("all_off", flags, snapshotCharts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir, err := ioutil.TempDir("", "package-digests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Package the snapshot charts with a lockfile covering all the images they render.
+	lock := &digest.Lock{Images: make(map[string]string)}
+	if _, err := digest.PinManifests(name.ManifestMap{name.PilotComponentName: {unpinned}}, lock, fixedResolver("sha256:1111")); err != nil {
+		t.Fatal(err)
+	}
+	manifestsDir := filepath.Join(tmpDir, "pkg", "istio-test", helm.OperatorSubdirFilePath)
+	if err := helm.ExportInstallPackage(filepath.Join(testDataDir, "data-snapshot"), manifestsDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.Write(filepath.Join(manifestsDir, digest.PackageLockfileName)); err != nil {
+		t.Fatal(err)
+	}
+	archivePath := filepath.Join(tmpDir, "istio-test.tar.gz")
+	if err := tgz.Create(filepath.Join(tmpDir, "pkg"), archivePath); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err := generateManifest("all_off", flags+" --set installPackagePath="+archivePath, compiledInCharts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for image := range lock.Images {
+		if strings.Contains(got, image+"\"") || strings.Contains(got, image+"\n") {
+			t.Errorf("got unpinned image %s in the manifest rendered from the package", image)
+		}
+		if !strings.Contains(got, image+"@sha256:1111") {
+			t.Errorf("got no image %s pinned to the packaged digest", image)
+		}
+	}
+}
+
 func TestManifestGenerateOrdered(t *testing.T) {
 	testDataDir = filepath.Join(operatorRootDir, "cmd/mesh/testdata/manifest-generate")
 	// Since this is testing the special case of stable YAML output order, it
@@ -549,11 +666,11 @@ func TestLDFlags(t *testing.T) {
 	version.DockerInfo.Hub = "testHub"
 	version.DockerInfo.Tag = "testTag"
 	l := clog.NewConsoleLogger(true, os.Stdout, os.Stderr)
-	ysf, err := yamlFromSetFlags([]string{"installPackagePath=" + liveInstallPackageDir}, false, l)
+	ysf, _, err := yamlFromSetFlags([]string{"installPackagePath=" + liveInstallPackageDir}, false, l)
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, iops, err := GenerateConfig(nil, ysf, true, nil, l)
+	_, iops, err := GenerateConfig(nil, ysf, nil, true, nil, l)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -630,8 +747,10 @@ func runTestGroup(t *testing.T, tests testGroup) {
 
 // runManifestGenerate runs the manifest generate command. If filenames is set, passes the given filenames as -f flag,
 // flags is passed to the command verbatim. If you set both flags and path, make sure to not use -f in flags.
+// Config checksums are disabled, since they change with the content of ConfigMaps which some golden files ignore,
+// and are covered by the configchecksum package tests.
 func runManifestGenerate(filenames []string, flags string, chartSource chartSourceType) (string, error) {
-	args := "manifest generate"
+	args := "manifest generate --set values.global.configChecksums=false"
 	for _, f := range filenames {
 		args += " -f " + f
 	}
@@ -0,0 +1,51 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/util"
+)
+
+// ValidateInjectionTemplates validates the sidecar injection templates in the manifests of the given revision. generate
+// calls it when the injection settings are overridden. It is set by the binaries which include the manifest commands,
+// to injection.ValidateTemplates, since the injector tests import this package and it therefore cannot import the
+// injector. Nothing is validated if it is nil.
+var ValidateInjectionTemplates func(manifests name.ManifestMap, revision string) error
+
+// overridesInjection reports whether the IstioOperator overlays in inFilenames and setOverlayYAML override any
+// values.sidecarInjectorWebhook setting or the charts, which hold the injection templates.
+func overridesInjection(inFilenames []string, setOverlayYAML string) (bool, error) {
+	y, err := ReadLayeredYAMLs(inFilenames)
+	if err != nil {
+		return false, err
+	}
+	if y, err = util.OverlayYAML(y, setOverlayYAML); err != nil {
+		return false, err
+	}
+	tree := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(y), &tree); err != nil {
+		return false, err
+	}
+	for _, path := range [][]string{{"spec", "values", "sidecarInjectorWebhook"}, {"spec", "installPackagePath"}} {
+		if _, found, _ := unstructured.NestedFieldNoCopy(tree, path...); found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
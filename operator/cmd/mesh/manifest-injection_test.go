@@ -0,0 +1,58 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"testing"
+)
+
+func TestOverridesInjection(t *testing.T) {
+	tests := []struct {
+		desc string
+		set  string
+		want bool
+	}{
+		{
+			desc: "no overlay",
+			want: false,
+		},
+		{
+			desc: "other values",
+			set:  "spec:\n  values:\n    global:\n      hub: docker.io/istio\n",
+			want: false,
+		},
+		{
+			desc: "injector values",
+			set:  "spec:\n  values:\n    sidecarInjectorWebhook:\n      rewriteAppHTTPProbe: true\n",
+			want: true,
+		},
+		{
+			desc: "charts",
+			set:  "spec:\n  installPackagePath: /tmp/charts\n",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := overridesInjection(nil, tt.set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("overridesInjection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/mholt/archiver"
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/operator/pkg/digest"
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util/clog"
+	"istio.io/istio/operator/version"
+	"istio.io/pkg/log"
+)
+
+const (
+	// packageImagesFilename is the name of the file listing the images used by the packaged manifest.
+	packageImagesFilename = "images.yaml"
+)
+
+type manifestPackageArgs struct {
+	// inFilenames is an array of paths to the input IstioOperator CR files.
+	inFilenames []string
+	// valuesFiles is an array of paths to helm values files which are applied to spec.values.
+	valuesFiles []string
+	// outFilename is the path of the package tar to write.
+	outFilename string
+	// set is a string with element format "path=value" where path is an IstioOperator path and the value is a
+	// value to set the node at that path to.
+	set []string
+	// components is a list of components to enable, as an alias for --set enablement paths.
+	components []string
+	// disableComponents is a list of components to disable, as an alias for --set enablement paths.
+	disableComponents []string
+	// force proceeds even if there are validation errors
+	force bool
+	// charts is a path to a charts and profiles directory in the local filesystem, or URL with a release tgz.
+	charts string
+	// resolveDigests queries image registries and records the digest of each packaged image.
+	resolveDigests bool
+	// digestLockfile is the path of a lockfile of image digests to include in the package.
+	digestLockfile string
+}
+
+// packageImages is the list of images used by the packaged manifest.
+type packageImages struct {
+	Images []string `json:"images"`
+}
+
+func addManifestPackageFlags(cmd *cobra.Command, args *manifestPackageArgs) {
+	cmd.PersistentFlags().StringSliceVarP(&args.inFilenames, "filename", "f", nil, filenameFlagHelpStr)
+	markFilenameFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.valuesFiles, "values", nil, valuesFlagHelpStr)
+	cmd.PersistentFlags().StringVarP(&args.outFilename, "output", "o", "",
+		"Path of the package tar to write. Defaults to istio-<version>.tar.gz in the current directory")
+	cmd.PersistentFlags().StringArrayVarP(&args.set, "set", "s", nil, SetFlagHelpStr)
+	markSetFlagCompletion(cmd)
+	cmd.PersistentFlags().StringSliceVar(&args.components, "components", nil, componentsFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVar(&args.disableComponents, "disable-components", nil, disableComponentsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
+	cmd.PersistentFlags().StringVarP(&args.charts, "charts", "d", "", chartsFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.resolveDigests, "resolve-digests", false,
+		"Query the image registry for each image tag in the generated manifest and include a digest lockfile in the package")
+	cmd.PersistentFlags().StringVar(&args.digestLockfile, "digest-lockfile", "",
+		"Path to a lockfile of image digests to pin the packaged images with")
+}
+
+func manifestPackageCmd(rootArgs *rootArgs, mpArgs *manifestPackageArgs, logOpts *log.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "package",
+		Short: "Bundles charts, profiles and images into an offline installation package",
+		Long: "The package subcommand writes the charts and profiles, and the list of images used by the generated " +
+			"manifest, to a tar that can be passed to --charts for installing without network access.",
+		Example: `  # Package the compiled in charts and the images of the default profile
+  istioctl manifest package -o istio-offline.tar.gz
+
+  # Package the demo profile with pinned image digests
+  istioctl manifest package --set profile=demo --resolve-digests -o istio-demo.tar.gz
+
+  # Generate a manifest from the package without network access
+  istioctl manifest generate --charts istio-demo.tar.gz --set profile=demo
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("package accepts no positional arguments, got %#v", args)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return manifestPackage(rootArgs, mpArgs, logOpts, l)
+		}}
+}
+
+func manifestPackage(args *rootArgs, mpArgs *manifestPackageArgs, logopts *log.Options, l clog.Logger) error {
+	if err := configLogs(args, logopts); err != nil {
+		return fmt.Errorf("could not configure logs: %s", err)
+	}
+
+	setFlags, err := applyComponentFlagAliases(applyInstallFlagAlias(mpArgs.set, mpArgs.charts), mpArgs.components, mpArgs.disableComponents)
+	if err != nil {
+		return err
+	}
+	ysf, unsetPaths, err := yamlFromSetFlags(setFlags, mpArgs.force, l)
+	if err != nil {
+		return err
+	}
+	if ysf, err = overlayValuesFiles(ysf, mpArgs.valuesFiles, mpArgs.force, l); err != nil {
+		return err
+	}
+	manifests, iops, err := GenManifests(mpArgs.inFilenames, ysf, unsetPaths, mpArgs.force, nil, l)
+	if err != nil {
+		return err
+	}
+
+	outFilename := mpArgs.outFilename
+	if outFilename == "" {
+		outFilename = packageDirname() + ".tar.gz"
+	}
+	if args.dryRun {
+		l.LogAndPrintf("Dry run: would write install package %s", outFilename)
+		return nil
+	}
+
+	tmpDir, err := ioutil.TempDir("", "istio-package")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	pkgDir := filepath.Join(tmpDir, packageDirname())
+	manifestsDir := filepath.Join(pkgDir, helm.OperatorSubdirFilePath)
+	if err := os.MkdirAll(manifestsDir, os.ModePerm); err != nil {
+		return err
+	}
+	if err := helm.ExportInstallPackage(iops.InstallPackagePath, manifestsDir); err != nil {
+		return fmt.Errorf("could not export charts and profiles: %s", err)
+	}
+	if err := writePackageImages(manifests, mpArgs.resolveDigests, mpArgs.digestLockfile, manifestsDir); err != nil {
+		return err
+	}
+
+	targz := archiver.TarGz{Tar: &archiver.Tar{OverwriteExisting: true, MkdirAll: true}}
+	if err := targz.Archive([]string{pkgDir}, outFilename); err != nil {
+		return fmt.Errorf("could not write install package %s: %s", outFilename, err)
+	}
+	l.LogAndPrintf("Wrote install package %s", outFilename)
+	return nil
+}
+
+// writePackageImages writes the list of images in manifests to manifestsDir. If resolve is set or lockfilePath is
+// not empty, the images are pinned to digests and a digest lockfile is also written to manifestsDir.
+func writePackageImages(manifests name.ManifestMap, resolve bool, lockfilePath, manifestsDir string) error {
+	if resolve || lockfilePath != "" {
+		lock := &digest.Lock{Images: make(map[string]string)}
+		if lockfilePath != "" {
+			var err error
+			if lock, err = digest.ReadLockfile(lockfilePath); err != nil {
+				return err
+			}
+		}
+		var resolver digest.Resolver
+		if resolve {
+			resolver = digest.NewRegistryResolver()
+		}
+		if _, err := digest.PinManifests(manifests, lock, resolver); err != nil {
+			return fmt.Errorf("could not pin image digests: %s", err)
+		}
+		if err := lock.Write(filepath.Join(manifestsDir, digest.PackageLockfileName)); err != nil {
+			return fmt.Errorf("could not write digest lockfile: %s", err)
+		}
+	}
+
+	var objs object.K8sObjects
+	for _, ms := range manifests {
+		for _, m := range ms {
+			mobjs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return err
+			}
+			objs = append(objs, mobjs...)
+		}
+	}
+	b, err := yaml.Marshal(&packageImages{Images: objs.ContainerImages()})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(manifestsDir, packageImagesFilename), b, 0644)
+}
+
+// packageDirname returns the name of the top level directory in the install package tar.
+func packageDirname() string {
+	return "istio-" + version.OperatorVersionString
+}
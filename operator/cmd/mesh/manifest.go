@@ -33,6 +33,8 @@ func ManifestCmd(logOpts *log.Options) *cobra.Command {
 	macArgs := &manifestApplyArgs{}
 	mvArgs := &manifestVersionsArgs{}
 	mmcArgs := &manifestMigrateArgs{}
+	mpcArgs := &manifestPackageArgs{}
+	mehArgs := &manifestExportHelmArgs{}
 
 	args := &rootArgs{}
 
@@ -41,6 +43,8 @@ func ManifestCmd(logOpts *log.Options) *cobra.Command {
 	mac := manifestApplyCmd(args, macArgs, logOpts)
 	mvc := manifestVersionsCmd(args, mvArgs)
 	mmc := manifestMigrateCmd(args, mmcArgs)
+	mpc := manifestPackageCmd(args, mpcArgs, logOpts)
+	mehc := manifestExportHelmCmd(args, mehArgs, logOpts)
 
 	addFlags(mc, args)
 	addFlags(mgc, args)
@@ -48,18 +52,24 @@ func ManifestCmd(logOpts *log.Options) *cobra.Command {
 	addFlags(mac, args)
 	addFlags(mvc, args)
 	addFlags(mmc, args)
+	addFlags(mpc, args)
+	addFlags(mehc, args)
 
 	addManifestGenerateFlags(mgc, mgcArgs)
 	addManifestDiffFlags(mdc, mdcArgs)
 	addManifestApplyFlags(mac, macArgs)
 	addManifestVersionsFlags(mvc, mvArgs)
 	addManifestMigrateFlags(mmc, mmcArgs)
+	addManifestPackageFlags(mpc, mpcArgs)
+	addManifestExportHelmFlags(mehc, mehArgs)
 
 	mc.AddCommand(mgc)
 	mc.AddCommand(mdc)
 	mc.AddCommand(mac)
 	mc.AddCommand(mmc)
 	mc.AddCommand(mvc)
+	mc.AddCommand(mpc)
+	mc.AddCommand(mehc)
 
 	return mc
 }
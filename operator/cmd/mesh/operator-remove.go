@@ -51,7 +51,7 @@ func operatorRemoveCmd(rootArgs *rootArgs, orArgs *operatorRemoveArgs) *cobra.Co
 		Long:  "The remove subcommand removes the Istio operator controller from the cluster.",
 		Args:  cobra.ExactArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
-			l := clog.NewConsoleLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.OutOrStderr())
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.OutOrStderr())
 			operatorRemove(rootArgs, orArgs, l, defaultManifestDeleter)
 		}}
 }
@@ -0,0 +1,49 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"strings"
+
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/preflight"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+// runPreflight analyzes the existing mesh config and the other meshes in the cluster and prints the findings. It
+// returns an error if the findings fail any of the failOn conditions.
+func runPreflight(kubeConfigPath, context, istioNamespace string, failOn []string, l clog.Logger) error {
+	if err := preflight.ValidateFailOn(failOn); err != nil {
+		return err
+	}
+	restConfig, _, err := manifest.InitK8SRestClient(kubeConfigPath, context)
+	if err != nil {
+		return err
+	}
+	result, err := preflight.Analyze(restConfig, istioNamespace)
+	if err != nil {
+		return err
+	}
+	if s := result.String(); s != "" {
+		l.LogAndPrintf("Preflight analysis of the existing mesh config:\n%s\n", s)
+	} else {
+		l.LogAndPrintf("Preflight analysis found no issues in the existing mesh config.")
+	}
+	if result.Fails(failOn) {
+		return fmt.Errorf("preflight analysis failed --fail-on=%s, fix the findings or remove the conditions", strings.Join(failOn, ","))
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"istio.io/istio/operator/pkg/apis/istio/v1alpha1/validation"
 	"istio.io/istio/operator/pkg/helm"
 	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/sizing"
 	"istio.io/istio/operator/pkg/tpath"
 	"istio.io/istio/operator/pkg/translate"
 	"istio.io/istio/operator/pkg/util"
@@ -44,13 +45,14 @@ var scope = log.RegisterScope("installer", "installer", 0)
 // 2. Profile overlay, if non-default overlay is selected. This also comes either from compiled in or path specified in IOP contained in inFilenames.
 // 3. User overlays stored in inFilenames.
 // 4. setOverlayYAML, which comes from --set flag passed to manifest command.
+// Finally, the nodes at unsetPaths, which come from --set flags with a null value, are removed.
 //
 // Note that the user overlay at inFilenames can optionally contain a file path to a set of profiles different from the
 // ones that are compiled in. If it does, the starting point will be the base and profile YAMLs at that file path.
 // Otherwise it will be the compiled in profile YAMLs.
 // In step 3, the remaining fields in the same user overlay are applied on the resulting profile base.
 // The force flag causes validation errors not to abort but only emit log/console warnings.
-func GenerateConfig(inFilenames []string, setOverlayYAML string, force bool, kubeConfig *rest.Config,
+func GenerateConfig(inFilenames []string, setOverlayYAML string, unsetPaths []string, force bool, kubeConfig *rest.Config,
 	l clog.Logger) (string, *v1alpha1.IstioOperatorSpec, error) {
 	fy, profile, err := readYamlProfle(inFilenames, setOverlayYAML, force, l)
 	if err != nil {
@@ -61,6 +63,15 @@ func GenerateConfig(inFilenames []string, setOverlayYAML string, force bool, kub
 	if err != nil {
 		return "", nil, err
 	}
+	if len(unsetPaths) != 0 {
+		if iopsString, err = removePaths(iopsString, unsetPaths); err != nil {
+			return "", nil, err
+		}
+		if iops, err = unmarshalAndValidateIOPS(iopsString, force, l); err != nil {
+			return "", nil, err
+		}
+		iopsString = util.ToYAMLWithJSONPB(iops)
+	}
 
 	errs, warning := validation.ValidateConfig(false, iops.Values, iops)
 	if warning != "" {
@@ -194,6 +205,12 @@ func genIOPSFromProfile(profileOrPath, fileOverlayYAML, setOverlayYAML string, s
 		return "", nil, fmt.Errorf("could not overlay user config over base: %s", err)
 	}
 
+	// Scale the components to the sizing preset, keeping the sizing the user overlays set.
+	outYAML, err = sizing.Overlay(outYAML, userOverlayYAML)
+	if err != nil {
+		return "", nil, err
+	}
+
 	if err := name.ScanBundledAddonComponents(installPackagePath); err != nil {
 		return "", nil, err
 	}
@@ -218,17 +235,22 @@ func genIOPSFromProfile(profileOrPath, fileOverlayYAML, setOverlayYAML string, s
 	return util.ToYAMLWithJSONPB(finalIOPS), finalIOPS, nil
 }
 
-// rewriteURLToLocalInstallPath checks installPackagePath and if it is a URL, it tries to download and extract the
-// Istio release tar at the URL to a local file path. If successful, it returns the resulting local paths to the
-// installation charts and profile file.
-// If installPackagePath is not a URL, it returns installPackagePath and profileOrPath unmodified.
+// rewriteURLToLocalInstallPath checks installPackagePath and if it is a URL or a local package tar, it tries to
+// download and extract the Istio release tar at the URL, or extract the local tar, to a local file path. If successful,
+// it returns the resulting local paths to the installation charts and profile file.
+// If installPackagePath is neither, it returns installPackagePath and profileOrPath unmodified.
 func rewriteURLToLocalInstallPath(installPackagePath, profileOrPath string, skipValidation bool) (string, string, error) {
+	isArchive := helm.IsInstallPackageArchive(installPackagePath)
 	isURL, err := util.IsHTTPURL(installPackagePath)
-	if err != nil && !skipValidation {
+	if err != nil && !skipValidation && !isArchive {
 		return "", "", err
 	}
-	if isURL {
-		installPackagePath, err = fetchExtractInstallPackageHTTP(installPackagePath)
+	if isURL || isArchive {
+		if isURL {
+			installPackagePath, err = fetchExtractInstallPackageHTTP(installPackagePath)
+		} else {
+			installPackagePath, err = helm.ExtractInstallPackage(installPackagePath, "")
+		}
 		if err != nil {
 			return "", "", err
 		}
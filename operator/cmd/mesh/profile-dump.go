@@ -49,6 +49,7 @@ kind: IstioOperator
 
 func addProfileDumpFlags(cmd *cobra.Command, args *profileDumpArgs) {
 	cmd.PersistentFlags().StringSliceVarP(&args.inFilenames, "filename", "f", nil, filenameFlagHelpStr)
+	markFilenameFlagCompletion(cmd)
 	cmd.PersistentFlags().StringVarP(&args.configPath, "config-path", "p", "",
 		"The path the root of the configuration subtree to dump e.g. components.pilot. By default, dump whole tree")
 	cmd.PersistentFlags().StringVarP(&args.outputFormat, "output", "o", yamlOutput,
@@ -57,9 +58,10 @@ func addProfileDumpFlags(cmd *cobra.Command, args *profileDumpArgs) {
 
 func profileDumpCmd(rootArgs *rootArgs, pdArgs *profileDumpArgs) *cobra.Command {
 	return &cobra.Command{
-		Use:   "dump [<profile>]",
-		Short: "Dumps an Istio configuration profile",
-		Long:  "The dump subcommand dumps the values in an Istio configuration profile.",
+		Use:       "dump [<profile>]",
+		Short:     "Dumps an Istio configuration profile",
+		Long:      "The dump subcommand dumps the values in an Istio configuration profile.",
+		ValidArgs: builtinProfiles(),
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 {
 				return fmt.Errorf("too many positional arguments")
@@ -67,7 +69,7 @@ func profileDumpCmd(rootArgs *rootArgs, pdArgs *profileDumpArgs) *cobra.Command
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			l := clog.NewConsoleLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
 			return profileDump(args, rootArgs, pdArgs, l)
 		}}
 
@@ -126,22 +128,28 @@ func profileDump(args []string, rootArgs *rootArgs, pdArgs *profileDumpArgs, l c
 		}
 	}
 
-	y, _, err := GenerateConfig(pdArgs.inFilenames, setFlagYAML, true, nil, l)
+	y, _, err := GenerateConfig(pdArgs.inFilenames, setFlagYAML, nil, true, nil, l)
 	if err != nil {
 		return err
 	}
 
-	if pdArgs.configPath == "" {
+	return printConfig(y, pdArgs.configPath, pdArgs.outputFormat, l)
+}
+
+// printConfig prints the IstioOperatorSpec YAML y, or the subtree at configPath if set, in the given output format.
+func printConfig(y, configPath, outputFormat string, l clog.Logger) error {
+	var err error
+	if configPath == "" {
 		if y, err = prependHeader(y); err != nil {
 			return err
 		}
 	} else {
-		if y, err = tpath.GetConfigSubtree(y, pdArgs.configPath); err != nil {
+		if y, err = tpath.GetConfigSubtree(y, configPath); err != nil {
 			return err
 		}
 	}
 
-	switch pdArgs.outputFormat {
+	switch outputFormat {
 	case jsonOutput:
 		j, err := yamlToPrettyJSON(y)
 		if err != nil {
@@ -0,0 +1,121 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/backup"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+type restoreArgs struct {
+	// kubeConfigPath is the path to kube config file.
+	kubeConfigPath string
+	// context is the cluster context in the kube config
+	context string
+	// backupDir is the directory holding the backup, as written by upgrade --backup-dir.
+	backupDir string
+}
+
+func addRestoreFlags(cmd *cobra.Command, args *restoreArgs) {
+	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
+	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+	MarkContextFlagCompletion(cmd)
+	cmd.PersistentFlags().StringVar(&args.backupDir, "backup-dir", "", "The directory holding the backup to restore.")
+}
+
+// RestoreCmd is a command that restores the Istio configuration saved by upgrade --backup-dir.
+func RestoreCmd() *cobra.Command {
+	rootArgs := &rootArgs{}
+	rArgs := &restoreArgs{}
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restores the Istio configuration from a backup",
+		Long: "The restore command creates or updates the Istio CRs, ConfigMaps and Secrets saved by upgrade " +
+			"--backup-dir. Secrets and ConfigMaps, which hold the CA certificates and the mesh config, are restored " +
+			"first. The control plane itself is not changed, to roll it back apply the IstioOperator CR of the " +
+			"previous install, which is part of the backup.",
+		Example: `  # Back up the configuration while upgrading
+  istioctl upgrade -f iop.yaml --backup-dir ./istio-backup
+
+  # Restore it after rolling back the control plane
+  istioctl restore --backup-dir ./istio-backup
+`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if rArgs.backupDir == "" {
+				return fmt.Errorf("--backup-dir is required")
+			}
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return restore(rootArgs, rArgs, l)
+		},
+	}
+	addFlags(cmd, rootArgs)
+	addRestoreFlags(cmd, rArgs)
+	return cmd
+}
+
+func restore(rootArgs *rootArgs, rArgs *restoreArgs, l clog.Logger) error {
+	initLogsOrExit(rootArgs)
+
+	_, cl, err := backupClient(rArgs.kubeConfigPath, rArgs.context)
+	if err != nil {
+		return err
+	}
+	summary, err := backup.Restore(cl, rArgs.backupDir, rootArgs.dryRun)
+	if err != nil {
+		return err
+	}
+	if rootArgs.dryRun {
+		l.LogAndPrintf("Dry run: would restore %s from %s.", summary, rArgs.backupDir)
+		return nil
+	}
+	l.LogAndPrintf("Restored %s from %s.", summary, rArgs.backupDir)
+	return nil
+}
+
+// backupConfig saves the Istio configuration of the cluster to dir.
+func backupConfig(kubeConfigPath, context, istioNamespace, dir string, l clog.Logger) error {
+	restConfig, cl, err := backupClient(kubeConfigPath, context)
+	if err != nil {
+		return err
+	}
+	summary, err := backup.Create(restConfig, cl, istioNamespace, dir)
+	if err != nil {
+		return err
+	}
+	l.LogAndPrintf("Backed up the Istio configuration to %s (%s). "+
+		"It holds Secrets, keep it safe. Use `istioctl restore --backup-dir %s` to restore it.", dir, summary, dir)
+	return nil
+}
+
+func backupClient(kubeConfigPath, context string) (*rest.Config, client.Client, error) {
+	restConfig, _, err := manifest.InitK8SRestClient(kubeConfigPath, context)
+	if err != nil {
+		return nil, nil, err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return nil, nil, err
+	}
+	return restConfig, cl, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+type revisionArgs struct {
+	// kubeConfigPath is the path to kube config file.
+	kubeConfigPath string
+	// context is the cluster context in the kube config
+	context string
+	// istioNamespace is the namespace of the control plane revisions.
+	istioNamespace string
+}
+
+func addRevisionFlags(cmd *cobra.Command, args *revisionArgs) {
+	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
+	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+	MarkContextFlagCompletion(cmd)
+	cmd.PersistentFlags().StringVar(&args.istioNamespace, "istioNamespace", defaultNamespace,
+		"The namespace of the control plane revisions.")
+}
+
+// RevisionCmd is a command that manages the control plane revisions of a cluster.
+func RevisionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revision",
+		Short: "Commands related to control plane revisions",
+		Long:  "The revision subcommands manage the control plane revisions installed with istioctl install --revision.",
+	}
+	cmd.AddCommand(revisionSetDefaultCmd())
+	return cmd
+}
+
+// revisionSetDefaultCmd is a command that makes a control plane revision the default revision.
+func revisionSetDefaultCmd() *cobra.Command {
+	rootArgs := &rootArgs{}
+	rArgs := &revisionArgs{}
+	cmd := &cobra.Command{
+		Use:   "set-default <revision>",
+		Short: "Makes a control plane revision the default revision",
+		Long: "The set-default subcommand points the default injector webhook, which injects the namespaces labeled " +
+			"istio-injection=enabled, and the config validation webhook at the istiod of the given revision. Both " +
+			"webhooks are updated, or neither is. Namespaces labeled istio.io/rev keep their revision, and workloads " +
+			"move to the new default revision when they are restarted.\n\n" +
+			"The default injector webhook is removed together with the revision. Reinstalling the control plane " +
+			"without a revision, or its base component, points the webhooks back at it.",
+		Example: `  # Make the canary revision the default
+  istioctl revision set-default canary
+
+  # Show the webhooks which would be changed
+  istioctl revision set-default canary --dry-run
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			return revisionSetDefault(rootArgs, rArgs, args[0], l)
+		},
+	}
+	addFlags(cmd, rootArgs)
+	addRevisionFlags(cmd, rArgs)
+	return cmd
+}
+
+func revisionSetDefault(rootArgs *rootArgs, rArgs *revisionArgs, revision string, l clog.Logger) error {
+	initLogsOrExit(rootArgs)
+
+	restConfig, _, err := manifest.InitK8SRestClient(rArgs.kubeConfigPath, rArgs.context)
+	if err != nil {
+		return err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return err
+	}
+	change, err := helmreconciler.SetDefaultRevision(cl, rArgs.istioNamespace, revision, rootArgs.dryRun)
+	if err != nil {
+		return err
+	}
+	prefix := "Updated "
+	if rootArgs.dryRun {
+		prefix = "Dry run: would update "
+	}
+	l.LogAndPrintf("%sMutatingWebhookConfiguration %s to inject with revision %s.", prefix, change.Injector.Name, revision)
+	if change.Validation != nil {
+		l.LogAndPrintf("%sValidatingWebhookConfiguration %s to validate with revision %s.", prefix, change.Validation.Name, revision)
+	} else {
+		l.LogAndPrintf("No config validation webhook found in namespace %s.", rArgs.istioNamespace)
+	}
+	if change.Previous != "" && change.Previous != revision && !rootArgs.dryRun {
+		l.LogAndPrintf("Revision %s is now the default instead of %s. Restart the workloads in the namespaces labeled "+
+			"istio-injection=enabled to move their proxies:\n"+
+			"    kubectl rollout restart deployment --namespace <namespace>", revision, change.Previous)
+	}
+	return nil
+}
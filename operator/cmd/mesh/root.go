@@ -19,6 +19,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"istio.io/istio/operator/pkg/util/clog"
 	binversion "istio.io/istio/operator/version"
 	"istio.io/pkg/log"
 	"istio.io/pkg/version"
@@ -27,7 +28,8 @@ import (
 const (
 	SetFlagHelpStr = `Override an IstioOperator value, e.g. to choose a profile
 (--set profile=demo), enable or disable components (--set components.policy.enabled=true), or override Istio
-settings (--set values.grafana.enabled=true). See documentation for more info:
+settings (--set values.grafana.enabled=true). A value of null removes the value or list item at the path, e.g.
+--set components.egressGateways[name:istio-egressgateway]=null. See documentation for more info:
 https://istio.io/docs/reference/config/istio.operator.v1alpha12.pb/#IstioControlPlaneSpec`
 	chartsFlagHelpStr = `Specify a path to a directory of charts and profiles
 (e.g. ~/Downloads/istio-1.5.0/install/kubernetes/operator)
@@ -37,24 +39,83 @@ or release tar URL (e.g. https://github.com/istio/istio/releases/download/1.5.1/
 If set to true, the user is not prompted and a Yes response is assumed in all cases.`
 	filenameFlagHelpStr = `Path to file containing IstioOperator custom resource
 This flag can be specified multiple times to overlay multiple files. Multiple files are overlaid in left to right order.`
+	valuesFlagHelpStr = `Path to a helm values file, e.g. one used with helm install, to apply to spec.values.
+This flag can be specified multiple times to overlay multiple files. Multiple files are overlaid in left to right order.
+Values files take precedence over --filename and are overridden by --set.`
+	componentsFlagHelpStr = `Comma separated list of components to enable, e.g. pilot,ingressGateways,grafana.
+This is shorthand for setting the enabled path of each component with --set, which takes precedence.`
+	disableComponentsFlagHelpStr = `Comma separated list of components to disable, e.g. egressGateways,policy.
+This is shorthand for setting the enabled path of each component with --set, which takes precedence.`
+	revisionFlagHelpStr = `The control plane revision, e.g. 1-7-0, which suffixes the names of the istiod resources so that it can be
+installed next to other revisions. This is shorthand for --set revision.`
+	validateSchemaFlagHelpStr = `Validate every rendered object against the OpenAPI schemas of the target cluster, or of --schema-file,
+to catch overlays with wrong field names or types.`
+	schemaFileFlagHelpStr = `Path to an OpenAPI v2 document, e.g. saved with kubectl get --raw /openapi/v2, to validate rendered
+objects against instead of the schemas of the cluster. Implies --validate-schema and does not need cluster access.`
+	policyFlagHelpStr = `Directory of Rego policy files, or a ConfigMap of them in the form configmap:<namespace>/<name>, to check
+rendered objects against. Each violation added to the deny set of package istio.install fails the command.`
+	adoptHelmReleaseFlagHelpStr = `Name of a Helm 3 release of Istio, optionally as <namespace>/<name>, whose resources are taken over
+by the operator. The resources are updated to the generated manifests in place, resources which are no longer
+generated are pruned and the Helm release records are removed after a successful install.`
+	platformFlagHelpStr = `The Kubernetes platform of the cluster, one of gke, eks, aks or openshift, for platform specific defaults
+and checks. Overrides values.global.platform. If neither is set, the platform is detected from the cluster nodes.`
+	includeCRDsFlagHelpStr = `Whether to include CRDs, one of include, only or skip. only selects just the CRDs, e.g. to manage them in an
+earlier pipeline step, and skip selects everything else, e.g. to apply with namespace scoped permissions afterwards.`
+	namespacedFlagHelpStr = `Leave out the cluster scoped resources, like CRDs, ClusterRoles and webhook configurations, for installing
+a revision with permissions in its own namespace only, after a cluster admin provisioned them. install checks that all
+namespaced resources can be applied and pruned before changing anything.`
+	failOnFlagHelpStr = `Conditions which abort the command before the control plane is changed. analyzer-errors fails if the Istio config
+analyzers report errors for the existing mesh config, or if there are resources of kinds this version does not support.
+mesh-conflicts fails if another service mesh, like Linkerd, or an Istio Helm release is installed, since their injectors
+and iptables rules conflict with the new control plane.`
+	resourceLabelsFlagHelpStr = `Labels to add to every rendered object, e.g. team=mesh,cost-center=123, as values.global.resourceLabels.
+Labels set by the charts take precedence.`
+	resourceAnnotationsFlagHelpStr = `Annotations to add to every rendered object, e.g. owner=mesh-team, as
+values.global.resourceAnnotations. Annotations set by the charts take precedence.`
+	targetFlagHelpStr = `Comma separated list of components to apply to an existing install, e.g. IngressGateways, to limit the
+change to them. Nothing is pruned, and the command fails unless --force is set if any other component differs from what
+was last installed.`
+	serverSideFlagHelpStr = `Apply objects with server-side apply rather than updating them, so that fields owned by other field managers,
+like the replicas set by an HPA or fields edited with kubectl, are reported as conflicts instead of being overwritten. See
+--conflicts.`
+	discoveryCacheDirFlagHelpStr = `Directory to cache the API groups and resources of the cluster in between runs, e.g.
+~/.kube/cache/discovery/<host> as used by kubectl, for 10 minutes. They are only cached in memory during the run if unset.`
 )
 
 type rootArgs struct {
 	// logToStdErr controls whether logs are sent to stderr.
 	logToStdErr bool
+	// logFormat is the format of log messages, text or json.
+	logFormat string
 	// Dry run performs all steps except actually applying the manifests or creating output dirs/files.
 	dryRun bool
 	// Verbose controls whether additional debug output is displayed and logged.
 	verbose bool
+	// quiet suppresses all console output except errors and command results, like manifests or --output json.
+	quiet bool
+	// noColor disables the colors of console output, which are otherwise used when it is a terminal.
+	noColor bool
+	// plain replaces the ✔ and ✘ glyphs of console output with ASCII and disables colors.
+	plain bool
 }
 
 func addFlags(cmd *cobra.Command, rootArgs *rootArgs) {
 	cmd.PersistentFlags().BoolVarP(&rootArgs.logToStdErr, "logtostderr", "",
 		false, "Send logs to stderr.")
+	cmd.PersistentFlags().StringVar(&rootArgs.logFormat, "log-format", string(clog.TextFormat),
+		"Format of log messages, one of text or json. Output like manifests is not affected.")
 	cmd.PersistentFlags().BoolVarP(&rootArgs.dryRun, "dry-run", "",
 		false, "Console/log output only, make no changes.")
 	cmd.PersistentFlags().BoolVarP(&rootArgs.verbose, "verbose", "",
 		false, "Verbose output.")
+	cmd.PersistentFlags().BoolVarP(&rootArgs.quiet, "quiet", "q", false,
+		"Suppress all console output except errors and command results, like manifests or --output json, for use in "+
+			"scripts. Takes precedence over --verbose.")
+	cmd.PersistentFlags().BoolVar(&rootArgs.noColor, "no-color", false,
+		"Disable colors in console output. Colors are only used when the output is a terminal and NO_COLOR is not set.")
+	cmd.PersistentFlags().BoolVar(&rootArgs.plain, "plain", false,
+		"Replace the ✔ and ✘ glyphs in console output with [OK] and [X] and disable colors, for logs stored in systems "+
+			"which do not handle unicode.")
 }
 
 // GetRootCmd returns the root of the cobra command-tree.
@@ -74,6 +135,10 @@ func GetRootCmd(args []string) *cobra.Command {
 	rootCmd.AddCommand(OperatorCmd())
 	rootCmd.AddCommand(version.CobraCommand())
 	rootCmd.AddCommand(UpgradeCmd())
+	rootCmd.AddCommand(RestoreCmd())
+	rootCmd.AddCommand(EffectiveConfigCmd())
+	rootCmd.AddCommand(CompletionCmd())
+	rootCmd.BashCompletionFunction = BashCompletionFunc
 
 	version.Info.Version = binversion.OperatorVersionString
 
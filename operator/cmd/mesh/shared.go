@@ -17,13 +17,16 @@
 package mesh
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 
 	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/util/clog"
 	"istio.io/pkg/log"
 )
 
@@ -34,30 +37,69 @@ var (
 )
 
 func initLogsOrExit(args *rootArgs) {
-	if err := configLogs(args.logToStdErr, log.DefaultOptions()); err != nil {
+	if err := configLogs(args, log.DefaultOptions()); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Could not configure logs: %s", err)
 		os.Exit(1)
 	}
 }
 
-func configLogs(logToStdErr bool, opt *log.Options) error {
+func configLogs(args *rootArgs, opt *log.Options) error {
+	format, err := clog.ParseFormat(args.logFormat)
+	if err != nil {
+		return err
+	}
 	op := []string{"/dev/null"}
-	if logToStdErr {
+	if args.logToStdErr {
 		op = []string{"stderr"}
 	}
 	opt2 := *opt
 	opt2.OutputPaths = op
 	opt2.ErrorOutputPaths = op
+	opt2.JSONEncoding = format == clog.JSONFormat
 
 	return log.Configure(&opt2)
 }
 
+// newConsoleLogger returns a console logger writing to stdOut and stdErr, configured from args. An invalid
+// --log-format falls back to text, the error is returned by configLogs.
+func newConsoleLogger(args *rootArgs, stdOut, stdErr io.Writer) *clog.ConsoleLogger {
+	l := clog.NewConsoleLogger(args.logToStdErr, stdOut, stdErr)
+	if format, err := clog.ParseFormat(args.logFormat); err == nil {
+		l.SetFormat(format)
+	}
+	l.SetQuiet(args.quiet)
+	if args.noColor {
+		l.SetColor(false)
+	}
+	l.SetPlain(args.plain)
+	return l
+}
+
 func refreshGoldenFiles() bool {
 	return os.Getenv("REFRESH_GOLDEN") == "true"
 }
 
+var (
+	stdinOnce  sync.Once
+	stdinBytes []byte
+	stdinErr   error
+)
+
+// ReadLayeredYAMLs reads filenames and overlays them in order, "-" being stdin. Stdin is read once and kept, since a
+// command may read the same filenames more than once.
 func ReadLayeredYAMLs(filenames []string) (string, error) {
-	return readLayeredYAMLs(filenames, os.Stdin)
+	for _, fn := range filenames {
+		if fn == "-" {
+			stdinOnce.Do(func() {
+				stdinBytes, stdinErr = ioutil.ReadAll(os.Stdin)
+			})
+			if stdinErr != nil {
+				return "", stdinErr
+			}
+			break
+		}
+	}
+	return readLayeredYAMLs(filenames, bytes.NewReader(stdinBytes))
 }
 
 func readLayeredYAMLs(filenames []string, stdinReader io.Reader) (string, error) {
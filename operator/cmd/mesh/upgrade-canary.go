@@ -0,0 +1,300 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/apis"
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+const (
+	// injectionLabel is the namespace label enabling injection by the default revision. It takes precedence over
+	// the istio.io/rev revision label, so it is removed when a namespace is moved to a revision.
+	injectionLabel = "istio-injection"
+	// defaultRevision is the revision of a control plane installed without one.
+	defaultRevision = "default"
+	// canaryRolloutTimeout is how long to wait for the workloads of the canary namespace to be restarted.
+	canaryRolloutTimeout = 5 * time.Minute
+)
+
+// canaryUpgrade upgrades the control plane by installing targetRevision next to the running revisions and then
+// moving the mesh over to it, one guarded step at a time:
+//
+//  1. install the new revision and wait for it to be ready
+//  2. check that its istiod is running at the target version
+//  3. optionally move canaryNamespace to it and restart its workloads
+//  4. move all namespaces of the old revisions to it
+//  5. optionally remove the old revisions
+//
+// Each step after the first asks for confirmation unless skipConfirmation is set. The old revisions are only
+// removed if removeOld is set, or if confirmed interactively.
+func canaryUpgrade(rootArgs *rootArgs, args *upgradeArgs, kubeClient manifest.ExecClient, istioNamespace, targetVersion string,
+	l clog.Logger) error {
+	cv, err := kubeClient.GetIstioVersions(istioNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve Istio control plane versions, error: %v", err)
+	}
+	oldRevisions := istiodRevisions(cv, args.revision)
+	if len(oldRevisions) == 0 {
+		return fmt.Errorf("no istiod of a revision other than %s found in namespace %s", args.revision, istioNamespace)
+	}
+	for _, r := range istiodRevisions(cv, "") {
+		if r == args.revision {
+			return fmt.Errorf("revision %s is already installed, choose a new revision for the canary upgrade", args.revision)
+		}
+	}
+
+	restConfig, clientSet, err := manifest.InitK8SRestClient(args.kubeConfigPath, args.context)
+	if err != nil {
+		return err
+	}
+	step := func(n int, msg string) { l.LogAndPrintf("\nStep %d/5: %s", n, msg) }
+
+	step(1, fmt.Sprintf("installing revision %s next to %v", args.revision, oldRevisions))
+	err = ApplyManifests(&ApplyOptions{
+		SetOverlay:     append(args.set, "revision="+args.revision),
+		InFilenames:    args.inFilenames,
+		Force:          args.force,
+		DryRun:         rootArgs.dryRun,
+		Verbose:        rootArgs.verbose,
+		KubeConfigPath: args.kubeConfigPath,
+		Context:        args.context,
+		Wait:           true,
+		WaitTimeout:    upgradeWaitSecWhenApply,
+	}, l)
+	if err != nil {
+		return fmt.Errorf("failed to install revision %s, the old revisions are unchanged. Error: %v", args.revision, err)
+	}
+
+	step(2, fmt.Sprintf("verifying revision %s", args.revision))
+	if !rootArgs.dryRun {
+		if err := verifyRevision(kubeClient, istioNamespace, args.revision, targetVersion); err != nil {
+			return fmt.Errorf("revision %s is not healthy, the old revisions are unchanged. Error: %v", args.revision, err)
+		}
+	}
+	l.LogAndPrintf("✔ Revision %s is running at version %s.", args.revision, targetVersion)
+
+	step(3, "moving the canary namespace")
+	if args.canaryNamespace == "" {
+		l.LogAndPrintf("No --canary-namespace set, skipping.")
+	} else {
+		gate(args.skipConfirmation, fmt.Sprintf("Move namespace %s to revision %s and restart its workloads [y/N]?",
+			args.canaryNamespace, args.revision), l)
+		if err := moveNamespaces(clientSet, oldRevisions, args.revision, args.canaryNamespace, rootArgs.dryRun, l); err != nil {
+			return err
+		}
+		if err := restartWorkloads(clientSet, args.canaryNamespace, rootArgs.dryRun, l); err != nil {
+			return fmt.Errorf("workloads in canary namespace %s did not become ready with revision %s, move it back by "+
+				"relabeling it and restarting them. Error: %v", args.canaryNamespace, args.revision, err)
+		}
+	}
+
+	step(4, "moving the mesh")
+	gate(args.skipConfirmation, fmt.Sprintf("Move all namespaces of revisions %v to revision %s [y/N]?", oldRevisions, args.revision), l)
+	if err := moveNamespaces(clientSet, oldRevisions, args.revision, "", rootArgs.dryRun, l); err != nil {
+		return err
+	}
+	l.LogAndPrintf("Restart the workloads in these namespaces to move their proxies to revision %s:\n"+
+		"    kubectl rollout restart deployment --namespace <namespace>", args.revision)
+
+	step(5, "removing the old revisions")
+	remove := args.removeOld
+	if !remove && !args.skipConfirmation {
+		remove = confirm(fmt.Sprintf("Remove the istiod of revisions %v? Only do this once no proxies use them [y/N]?",
+			oldRevisions), os.Stdout)
+	}
+	if !remove {
+		l.LogAndPrintf("Keeping revisions %v. Re-run with --remove-old once all workloads are restarted.", oldRevisions)
+		return nil
+	}
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return err
+	}
+	for _, r := range oldRevisions {
+		deleted, err := helmreconciler.DeleteControlPlaneRevision(cl, revisionOrEmpty(r), rootArgs.dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to remove revision %s: %v", r, err)
+		}
+		l.LogAndPrintf("Removed %d resources of revision %s.", len(deleted), r)
+	}
+	return nil
+}
+
+// gate asks for confirmation to continue unless skipConfirmation is set, and aborts otherwise.
+func gate(skipConfirmation bool, msg string, l clog.Logger) {
+	if skipConfirmation {
+		return
+	}
+	if !confirm(msg, os.Stdout) {
+		l.LogAndFatalf("Abort. Completed steps are not rolled back, re-run to continue.")
+	}
+}
+
+// istiodRevisions returns the sorted revisions of the istiod pods in cv, except exclude.
+func istiodRevisions(cv []manifest.ComponentVersion, exclude string) []string {
+	revs := make(map[string]bool)
+	for _, c := range cv {
+		if c.Pod.Labels["app"] != "istiod" {
+			continue
+		}
+		r := c.Pod.Labels[revisionLabel]
+		if r == "" {
+			r = defaultRevision
+		}
+		if r != exclude {
+			revs[r] = true
+		}
+	}
+	var out []string
+	for r := range revs {
+		out = append(out, r)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// verifyRevision checks that all istiod pods of revision run at targetVersion.
+func verifyRevision(kubeClient manifest.ExecClient, istioNamespace, revision, targetVersion string) error {
+	cv, err := kubeClient.GetIstioVersions(istioNamespace)
+	if err != nil {
+		return err
+	}
+	var found bool
+	for _, c := range cv {
+		if c.Pod.Labels["app"] != "istiod" || c.Pod.Labels[revisionLabel] != revision {
+			continue
+		}
+		if targetVersion != "" && c.Version != targetVersion {
+			return fmt.Errorf("%s does not match the target version %s", c, targetVersion)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no running istiod pod found for revision %s", revision)
+	}
+	return nil
+}
+
+// revisionLabels returns the labels of a namespace with labels moved from one of oldRevisions to newRevision, and
+// whether they changed.
+func revisionLabels(labels map[string]string, oldRevisions []string, newRevision string) (map[string]string, bool) {
+	current := labels[revisionLabel]
+	if labels[injectionLabel] == "enabled" {
+		current = defaultRevision
+	}
+	if current == "" || current == newRevision {
+		return labels, false
+	}
+	var old bool
+	for _, r := range oldRevisions {
+		old = old || r == current
+	}
+	if !old {
+		return labels, false
+	}
+	out := make(map[string]string)
+	for k, v := range labels {
+		out[k] = v
+	}
+	delete(out, injectionLabel)
+	out[revisionLabel] = newRevision
+	return out, true
+}
+
+// moveNamespaces relabels the namespaces injected by one of oldRevisions, or only namespace if set, to be injected by
+// newRevision.
+func moveNamespaces(cs kubernetes.Interface, oldRevisions []string, newRevision, namespace string, dryRun bool, l clog.Logger) error {
+	nsl, err := cs.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range nsl.Items {
+		ns := &nsl.Items[i]
+		if namespace != "" && ns.Name != namespace {
+			continue
+		}
+		labels, changed := revisionLabels(ns.Labels, oldRevisions, newRevision)
+		if !changed {
+			continue
+		}
+		if dryRun {
+			l.LogAndPrintf("Dry run: would move namespace %s to revision %s.", ns.Name, newRevision)
+			continue
+		}
+		ns.Labels = labels
+		if _, err := cs.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to move namespace %s to revision %s: %v", ns.Name, newRevision, err)
+		}
+		l.LogAndPrintf("Moved namespace %s to revision %s.", ns.Name, newRevision)
+	}
+	return nil
+}
+
+// restartWorkloads restarts the Deployments in namespace, like kubectl rollout restart, and waits for them to be
+// ready.
+func restartWorkloads(cs kubernetes.Interface, namespace string, dryRun bool, l clog.Logger) error {
+	dl, err := cs.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		time.Now().Format(time.RFC3339))
+	var objs object.K8sObjects
+	for _, d := range dl.Items {
+		if dryRun {
+			l.LogAndPrintf("Dry run: would restart Deployment %s/%s.", namespace, d.Name)
+			continue
+		}
+		if _, err := cs.AppsV1().Deployments(namespace).Patch(context.TODO(), d.Name, types.StrategicMergePatchType,
+			[]byte(patch), metav1.PatchOptions{}); err != nil {
+			return err
+		}
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetNamespace(namespace)
+		u.SetName(d.Name)
+		objs = append(objs, object.NewK8sObject(u, nil, nil))
+	}
+	return manifest.WaitForResources(objs, cs, canaryRolloutTimeout, dryRun, l)
+}
+
+// revisionOrEmpty returns the revision as set in an IstioOperator CR, where the default revision is empty.
+func revisionOrEmpty(revision string) string {
+	if revision == defaultRevision {
+		return ""
+	}
+	return revision
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/operator/pkg/manifest"
+)
+
+func TestIstiodRevisions(t *testing.T) {
+	pod := func(labels map[string]string) manifest.ComponentVersion {
+		return manifest.ComponentVersion{Pod: v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: labels}}}
+	}
+	cv := []manifest.ComponentVersion{
+		pod(map[string]string{"app": "istiod"}),
+		pod(map[string]string{"app": "istiod", "istio.io/rev": "1-6-0"}),
+		pod(map[string]string{"app": "istiod", "istio.io/rev": "1-7-0"}),
+		pod(map[string]string{"app": "istio-ingressgateway"}),
+	}
+	if got, want := istiodRevisions(cv, "1-7-0"), []string{"1-6-0", "default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRevisionLabels(t *testing.T) {
+	old := []string{"default", "1-6-0"}
+	tests := []struct {
+		desc        string
+		labels      map[string]string
+		want        map[string]string
+		wantChanged bool
+	}{
+		{
+			desc:        "injection label",
+			labels:      map[string]string{"istio-injection": "enabled", "team": "a"},
+			want:        map[string]string{"istio.io/rev": "1-7-0", "team": "a"},
+			wantChanged: true,
+		},
+		{
+			desc:        "old revision",
+			labels:      map[string]string{"istio.io/rev": "1-6-0"},
+			want:        map[string]string{"istio.io/rev": "1-7-0"},
+			wantChanged: true,
+		},
+		{
+			desc:   "other revision",
+			labels: map[string]string{"istio.io/rev": "1-5-0"},
+			want:   map[string]string{"istio.io/rev": "1-5-0"},
+		},
+		{
+			desc:   "not injected",
+			labels: map[string]string{"istio-injection": "disabled"},
+			want:   map[string]string{"istio-injection": "disabled"},
+		},
+		{
+			desc:   "already moved",
+			labels: map[string]string{"istio.io/rev": "1-7-0"},
+			want:   map[string]string{"istio.io/rev": "1-7-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, changed := revisionLabels(tt.labels, old, "1-7-0")
+			if changed != tt.wantChanged || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, %v, want %v, %v", got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
@@ -15,18 +15,25 @@
 package mesh
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	goversion "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	iop "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
 	"istio.io/istio/operator/pkg/compare"
 	"istio.io/istio/operator/pkg/hooks"
 	"istio.io/istio/operator/pkg/manifest"
 	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/util/clog"
 	pkgversion "istio.io/istio/operator/pkg/version"
 	"istio.io/pkg/log"
@@ -71,18 +78,33 @@ type upgradeArgs struct {
 	skipConfirmation bool
 	// force means directly applying the upgrade without eligibility checks.
 	force bool
+	// backupDir is a directory to save the Istio configuration to before the control plane is changed.
+	backupDir string
+	// failOn are the preflight conditions which abort the upgrade.
+	failOn []string
+	// auto selects a canary upgrade, which installs revision next to the running control plane and moves the mesh
+	// over to it.
+	auto bool
+	// revision is the control plane revision installed by a canary upgrade.
+	revision string
+	// canaryNamespace is a namespace which a canary upgrade moves to the new revision first.
+	canaryNamespace string
+	// removeOld removes the old revisions at the end of a canary upgrade without asking.
+	removeOld bool
 }
 
 // addUpgradeFlags adds upgrade related flags into cobra command
 func addUpgradeFlags(cmd *cobra.Command, args *upgradeArgs) {
 	cmd.PersistentFlags().StringSliceVarP(&args.inFilenames, "filename",
 		"f", nil, "Path to file containing IstioOperator custom resource")
+	markFilenameFlagCompletion(cmd)
 	cmd.PersistentFlags().StringVarP(&args.versionsURI, "versionsURI", "u",
 		"", "URI for operator versions to Istio versions map")
 	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig",
 		"c", "", "Path to kube config")
 	cmd.PersistentFlags().StringVar(&args.context, "context", "",
 		"The name of the kubeconfig context to use")
+	MarkContextFlagCompletion(cmd)
 	cmd.PersistentFlags().BoolVarP(&args.skipConfirmation, "skip-confirmation", "y", false,
 		"If skip-confirmation is set, skips the prompting confirmation for value changes in this upgrade")
 	cmd.PersistentFlags().BoolVarP(&args.wait, "wait", "w", false,
@@ -92,7 +114,22 @@ func addUpgradeFlags(cmd *cobra.Command, args *upgradeArgs) {
 			upgradeWaitCheckVerMaxAttempts).String())
 	cmd.PersistentFlags().BoolVar(&args.force, "force", false,
 		"Apply the upgrade without eligibility checks")
+	cmd.PersistentFlags().StringVar(&args.backupDir, "backup-dir", "",
+		"If set, saves all Istio CRs and the ConfigMaps and Secrets of the Istio namespace to this directory before "+
+			"upgrading. Use the restore command to apply the backup again.")
+	cmd.PersistentFlags().StringSliceVar(&args.failOn, "fail-on", nil, failOnFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.auto, "auto", false,
+		"Upgrade with a canary: install --revision next to the running control plane, verify it, optionally move "+
+			"--canary-namespace first, then move all namespaces to it and optionally remove the old revisions. "+
+			"Each step asks for confirmation unless --skip-confirmation is set.")
+	cmd.PersistentFlags().StringVar(&args.revision, "revision", "",
+		"The control plane revision to install with --auto, e.g. 1-7-0")
+	cmd.PersistentFlags().StringVar(&args.canaryNamespace, "canary-namespace", "",
+		"A namespace to move to the new revision and restart before the rest of the mesh, with --auto")
+	cmd.PersistentFlags().BoolVar(&args.removeOld, "remove-old", false,
+		"Remove the istiod of the old revisions at the end of an --auto upgrade without asking")
 	cmd.PersistentFlags().StringArrayVarP(&args.set, "set", "s", nil, SetFlagHelpStr)
+	markSetFlagCompletion(cmd)
 }
 
 // UpgradeCmd upgrades Istio control plane in-place with eligibility checks
@@ -105,9 +142,12 @@ func UpgradeCmd() *cobra.Command {
 		Long: "The upgrade command checks for upgrade version eligibility and," +
 			" if eligible, upgrades the Istio control plane components in-place. Warning: " +
 			"traffic may be disrupted during upgrade. Please ensure PodDisruptionBudgets " +
-			"are defined to maintain service continuity.",
+			"are defined to maintain service continuity. With --auto, a new control plane revision is " +
+			"installed next to the running one instead and the mesh is moved over to it step by step. " +
+			"The target may also be up to one minor version older than the control plane, in which case the command " +
+			"checks that the installation uses no fields which the older version does not support.",
 		RunE: func(cmd *cobra.Command, args []string) (e error) {
-			l := clog.NewConsoleLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.OutOrStderr())
+			l := newConsoleLogger(rootArgs, cmd.OutOrStdout(), cmd.OutOrStderr())
 			initLogsOrExit(rootArgs)
 			err := upgrade(rootArgs, macArgs, l)
 			if err != nil {
@@ -123,17 +163,23 @@ func UpgradeCmd() *cobra.Command {
 
 // upgrade is the main function for Upgrade command
 func upgrade(rootArgs *rootArgs, args *upgradeArgs, l clog.Logger) (err error) {
+	if args.auto && args.revision == "" {
+		return fmt.Errorf("--auto requires --revision to name the new control plane revision")
+	}
+	if !args.auto && (args.revision != "" || args.canaryNamespace != "" || args.removeOld) {
+		return fmt.Errorf("--revision, --canary-namespace and --remove-old can only be used with --auto")
+	}
 	// Create a kube client from args.kubeConfigPath and  args.context
 	kubeClient, err := manifest.NewClient(args.kubeConfigPath, args.context)
 	if err != nil {
 		return fmt.Errorf("failed to connect Kubernetes API server, error: %v", err)
 	}
-	ysf, err := yamlFromSetFlags(args.set, args.force, l)
+	ysf, unsetPaths, err := yamlFromSetFlags(args.set, args.force, l)
 	if err != nil {
 		return err
 	}
 	// Generate IOPS parseObjectSetFromManifest
-	targetIOPSYaml, targetIOPS, err := GenerateConfig(args.inFilenames, ysf, args.force, nil, l)
+	targetIOPSYaml, targetIOPS, err := GenerateConfig(args.inFilenames, ysf, unsetPaths, args.force, nil, l)
 	if err != nil {
 		return fmt.Errorf("failed to generate Istio configs from file %s, error: %s", args.inFilenames, err)
 	}
@@ -159,12 +205,42 @@ func upgrade(rootArgs *rootArgs, args *upgradeArgs, l clog.Logger) (err error) {
 	}
 
 	// Check if the upgrade currentVersion -> targetVersion is supported
-	err = checkSupportedVersions(currentVersion, targetVersion, args.versionsURI)
-	if err != nil && !args.force {
-		return fmt.Errorf("upgrade version check failed: %v -> %v. Error: %v",
-			currentVersion, targetVersion, err)
+	downgrade := false
+	if currentVersion != "" && targetVersion != "" {
+		if downgrade, err = manifest.IsDowngrade(currentVersion, targetVersion); err != nil && !args.force {
+			return fmt.Errorf("upgrade version check failed: %v -> %v. Error: %v", currentVersion, targetVersion, err)
+		}
+	}
+	if downgrade {
+		err = manifest.CheckDowngrade(currentVersion, targetVersion)
+		if err != nil && !args.force {
+			return fmt.Errorf("downgrade version check failed: %v -> %v. Error: %v",
+				currentVersion, targetVersion, err)
+		}
+		l.LogAndPrintf("Downgrade version check passed: %v -> %v.\n", currentVersion, targetVersion)
+	} else {
+		err = checkSupportedVersions(currentVersion, targetVersion, args.versionsURI)
+		if err != nil && !args.force {
+			return fmt.Errorf("upgrade version check failed: %v -> %v. Error: %v",
+				currentVersion, targetVersion, err)
+		}
+		l.LogAndPrintf("Upgrade version check passed: %v -> %v.\n", currentVersion, targetVersion)
+	}
+
+	// Check that the control plane and the proxies stay within the supported version skew
+	if targetVersion != "" {
+		if err := checkVersionSkew(kubeClient, istioNamespace, targetVersion); err != nil {
+			if !args.force {
+				return fmt.Errorf("upgrade version skew check failed, use --force to upgrade anyway:\n%v", err)
+			}
+			l.LogAndPrintf("Warning: upgrade version skew check failed:\n%v\n", err)
+		}
+	}
+
+	// Analyze the existing mesh config with the analyzers of the target version
+	if err := runPreflight(args.kubeConfigPath, args.context, istioNamespace, args.failOn, l); err != nil {
+		return err
 	}
-	l.LogAndPrintf("Upgrade version check passed: %v -> %v.\n", currentVersion, targetVersion)
 
 	// Read the overridden IOPS from args.inFilenames
 	overrideIOPSYaml := ""
@@ -190,18 +266,39 @@ func upgrade(rootArgs *rootArgs, args *upgradeArgs, l clog.Logger) (err error) {
 	if targetIOPS.Profile != "" {
 		currentSets = append(currentSets, "profile="+targetIOPS.Profile)
 	}
-	if ysf, err = yamlFromSetFlags(currentSets, args.force, l); err != nil {
+	if ysf, unsetPaths, err = yamlFromSetFlags(currentSets, args.force, l); err != nil {
 		return err
 	}
-	currentProfileIOPSYaml, _, err := GenerateConfig(nil, ysf, args.force, nil, l)
+	currentProfileIOPSYaml, _, err := GenerateConfig(nil, ysf, unsetPaths, args.force, nil, l)
 	if err != nil {
 		return fmt.Errorf("failed to generate Istio configs from file %s for the current version: %s, error: %v",
 			args.inFilenames, currentVersion, err)
 	}
 	checkUpgradeIOPS(currentProfileIOPSYaml, targetIOPSYaml, overrideIOPSYaml, l)
 
+	if downgrade {
+		err := checkDowngradeFields(args.kubeConfigPath, args.context, istioNamespace, targetIOPS.Revision,
+			currentProfileIOPSYaml, targetIOPSYaml, l)
+		if err != nil {
+			if !args.force {
+				return fmt.Errorf("downgrade check failed, use --force to downgrade anyway:\n%v", err)
+			}
+			l.LogAndPrintf("Warning: downgrade check failed:\n%v\n", err)
+		}
+	}
+
 	waitForConfirmation(args.skipConfirmation, l)
 
+	if args.backupDir != "" {
+		if err := backupConfig(args.kubeConfigPath, args.context, istioNamespace, args.backupDir, l); err != nil {
+			return fmt.Errorf("failed to back up the Istio configuration, error: %v", err)
+		}
+	}
+
+	if args.auto {
+		return canaryUpgrade(rootArgs, args, kubeClient, istioNamespace, targetVersion, l)
+	}
+
 	// Run pre-upgrade hooks
 	hparams := &hooks.HookCommonParams{
 		SourceVer:  currentVersion,
@@ -215,8 +312,16 @@ func upgrade(rootArgs *rootArgs, args *upgradeArgs, l clog.Logger) (err error) {
 	}
 
 	// Apply the Istio Control Plane specs reading from inFilenames to the cluster
-	err = ApplyManifests(nil, args.inFilenames, args.force, rootArgs.dryRun,
-		rootArgs.verbose, args.kubeConfigPath, args.context, args.wait, upgradeWaitSecWhenApply, l)
+	err = ApplyManifests(&ApplyOptions{
+		InFilenames:    args.inFilenames,
+		Force:          args.force,
+		DryRun:         rootArgs.dryRun,
+		Verbose:        rootArgs.verbose,
+		KubeConfigPath: args.kubeConfigPath,
+		Context:        args.context,
+		Wait:           args.wait,
+		WaitTimeout:    upgradeWaitSecWhenApply,
+	}, l)
 	if err != nil {
 		return fmt.Errorf("failed to apply the Istio Control Plane specs. Error: %v", err)
 	}
@@ -324,6 +429,79 @@ func retrieveControlPlaneVersion(kubeClient manifest.ExecClient, istioNamespace
 	return v, nil
 }
 
+// checkVersionSkew checks that the Istio components in istioNamespace and the proxies in the cluster are within
+// the version skew supported by a control plane at targetVer.
+func checkVersionSkew(kubeClient manifest.ExecClient, istioNamespace, targetVer string) error {
+	cv, err := kubeClient.GetIstioVersions(istioNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve Istio control plane versions, error: %v", err)
+	}
+	pv, err := kubeClient.GetProxyVersions()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve proxy versions, error: %v", err)
+	}
+	if errs := manifest.CheckVersionSkew(targetVer, cv, pv); len(errs) != 0 {
+		return fmt.Errorf("%s", util.ToString(errs, "\n"))
+	}
+	return nil
+}
+
+// checkDowngradeFields returns an error listing the fields of the installed-state IstioOperator CR of revision which
+// are set to a value other than the currentDefaults but are unknown to the targetIOPS of the downgrade, since they
+// would be silently dropped.
+func checkDowngradeFields(kubeConfigPath, context, istioNamespace, revision, currentDefaults, targetIOPS string,
+	l clog.Logger) error {
+	_, cl, err := backupClient(kubeConfigPath, context)
+	if err != nil {
+		return err
+	}
+	installed, err := installedSpecYAML(cl, istioNamespace, revision)
+	if err != nil {
+		return err
+	}
+	if installed == "" {
+		l.LogAndPrintf("Downgrade check: no installed-state IstioOperator CR found in namespace %s, "+
+			"skipping the check for fields which require the current version.\n", istioNamespace)
+		return nil
+	}
+	fields, err := manifest.FieldsRequiringNewerVersion(installed, currentDefaults, targetIOPS)
+	if err != nil {
+		return err
+	}
+	if len(fields) != 0 {
+		return fmt.Errorf("the installation uses the following fields, which are not supported by the target version:\n%s",
+			strings.Join(fields, "\n"))
+	}
+	l.LogAndPrintf("Downgrade check passed: the installation uses no fields which require the current version.\n")
+	return nil
+}
+
+// installedSpecYAML returns the spec of the installed-state IstioOperator CR of revision, or an empty string if
+// there is none. The CR is read as unstructured, since it may hold fields unknown to this version.
+func installedSpecYAML(cl client.Client, istioNamespace, revision string) (string, error) {
+	crName := installedSpecCRPrefix
+	if revision != "" {
+		crName += "-" + revision
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(iop.IstioOperatorGVK)
+	if err := cl.Get(context.TODO(), client.ObjectKey{Namespace: istioNamespace, Name: crName}, u); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get IstioOperator %s/%s: %s", istioNamespace, crName, err)
+	}
+	spec, ok := u.Object["spec"]
+	if !ok {
+		return "", nil
+	}
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
 // waitUpgradeComplete waits for the upgrade to complete by periodically comparing the current component version
 // to the target version.
 func waitUpgradeComplete(kubeClient manifest.ExecClient, istioNamespace string, targetVer string, l clog.Logger) error {
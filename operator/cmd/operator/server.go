@@ -26,9 +26,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
+	"istio.io/istio/operator/pkg/admin"
 	"istio.io/istio/operator/pkg/apis"
 	"istio.io/istio/operator/pkg/controller"
 	"istio.io/istio/operator/pkg/controller/istiocontrolplane"
+	"istio.io/istio/operator/pkg/webhook"
 	"istio.io/pkg/ctrlz"
 	"istio.io/pkg/log"
 )
@@ -42,6 +44,7 @@ const (
 func serverCmd() *cobra.Command {
 	loggingOptions := log.DefaultOptions()
 	introspectionOptions := ctrlz.DefaultOptions()
+	tracingOpts := &tracingOptions{}
 
 	serverCmd := &cobra.Command{
 		Use:   "server",
@@ -58,6 +61,12 @@ func serverCmd() *cobra.Command {
 				log.Errorf("Unable to initialize ControlZ: %v", err)
 			}
 
+			closeTracing, err := configureTracing(tracingOpts)
+			if err != nil {
+				return err
+			}
+			defer closeTracing()
+
 			run()
 			return nil
 		},
@@ -65,7 +74,10 @@ func serverCmd() *cobra.Command {
 
 	loggingOptions.AttachCobraFlags(serverCmd)
 	introspectionOptions.AttachCobraFlags(serverCmd)
+	tracingOpts.attachCobraFlags(serverCmd)
 	istiocontrolplane.AttachCobraFlags(serverCmd)
+	webhook.AttachCobraFlags(serverCmd)
+	admin.AttachCobraFlags(serverCmd)
 
 	return serverCmd
 }
@@ -125,6 +137,16 @@ func run() {
 		log.Fatalf("Could not add all controllers to operator manager: %v", err)
 	}
 
+	// Setup admission webhooks
+	if err := webhook.AddToManager(mgr); err != nil {
+		log.Fatalf("Could not add webhooks to operator manager: %v", err)
+	}
+
+	// Setup the admin API
+	if err := admin.AddToManager(mgr); err != nil {
+		log.Fatalf("Could not add admin API to operator manager: %v", err)
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
@@ -0,0 +1,64 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	oczipkin "contrib.go.opencensus.io/exporter/zipkin"
+	zgo "github.com/openzipkin/zipkin-go"
+	zhttp "github.com/openzipkin/zipkin-go/reporter/http"
+	"github.com/spf13/cobra"
+	"go.opencensus.io/trace"
+)
+
+// tracingOptions configures export of the reconcile traces of the operator.
+type tracingOptions struct {
+	// zipkinURL is the URL of the Zipkin API endpoint spans are reported to, e.g.
+	// http://zipkin.istio-system:9411/api/v2/spans. Tracing is disabled if empty.
+	zipkinURL string
+	// samplingRate is the probability, between 0 and 1, that a reconcile is traced.
+	samplingRate float64
+}
+
+func (o *tracingOptions) attachCobraFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.zipkinURL, "trace-zipkin-url", "",
+		"URL of a Zipkin API endpoint to report reconcile traces to. Tracing is disabled if not set.")
+	cmd.PersistentFlags().Float64Var(&o.samplingRate, "trace-sampling-rate", 1.0,
+		"Probability between 0 and 1 that a reconcile is traced.")
+}
+
+// configureTracing registers a Zipkin exporter for reconcile spans if o.zipkinURL is set. The returned function
+// flushes and closes the exporter.
+func configureTracing(o *tracingOptions) (func(), error) {
+	if o.zipkinURL == "" {
+		return func() {}, nil
+	}
+	if o.samplingRate < 0 || o.samplingRate > 1 {
+		return nil, fmt.Errorf("trace sampling rate must be between 0 and 1, got %v", o.samplingRate)
+	}
+	endpoint, err := zgo.NewEndpoint("istio-operator", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Zipkin endpoint: %s", err)
+	}
+	reporter := zhttp.NewReporter(o.zipkinURL)
+	exporter := oczipkin.NewExporter(reporter, endpoint)
+	trace.RegisterExporter(exporter)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(o.samplingRate)})
+	return func() {
+		trace.UnregisterExporter(exporter)
+		_ = reporter.Close()
+	}, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin implements an HTTP admin API for the operator controller. It lets clients without kubectl access,
+// like platform dashboards, trigger reconciles and read the install status and rendered manifests of IstioOperator
+// resources. Requests must carry a Kubernetes bearer token, which is checked against the IstioOperator RBAC
+// permissions of its user.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"istio.io/api/operator/v1alpha1"
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/controller/istiocontrolplane"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/pkg/log"
+)
+
+const (
+	// ReconcilePath is the path on which a POST queues a reconcile of an IstioOperator.
+	ReconcilePath = "/v1/reconcile"
+	// StatusPath is the path on which a GET returns the install status of an IstioOperator.
+	StatusPath = "/v1/status"
+	// ManifestsPath is the path on which a GET returns the manifests rendered by the last reconcile of an
+	// IstioOperator, as a JSON object of component name to manifest. With the component query parameter, only the
+	// manifest of that component is returned, as YAML.
+	ManifestsPath = "/v1/manifests"
+)
+
+// server serves the admin API. All requests identify the IstioOperator with the namespace and name query parameters.
+type server struct {
+	auth authorizer
+	// reconcile queues a reconcile, returning false if the queue is full.
+	reconcile func(namespace, iopName string) bool
+	// status returns the current status of an IstioOperator.
+	status func(namespace, iopName string) (*v1alpha1.InstallStatus, error)
+	// manifests returns the last rendered manifests of an IstioOperator, or nil if there are none.
+	manifests func(namespace, iopName string) name.ManifestMap
+}
+
+// AddToManager adds the admin API server to the manager, if enabled. It runs only on the leader, which is the
+// controller instance that reconciles.
+func AddToManager(m manager.Manager) error {
+	if !adminOptions.Enabled {
+		log.Info("Operator admin API is disabled")
+		return nil
+	}
+	cs, err := kubernetes.NewForConfig(m.GetConfig())
+	if err != nil {
+		return err
+	}
+	cl := m.GetClient()
+	s := &server{
+		auth:      &kubeAuthorizer{client: cs},
+		reconcile: istiocontrolplane.RequestReconcile,
+		status: func(namespace, iopName string) (*v1alpha1.InstallStatus, error) {
+			return getStatus(cl, namespace, iopName)
+		},
+		manifests: istiocontrolplane.LastManifests,
+	}
+	return m.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		return s.run(stop)
+	}))
+}
+
+// run serves the admin API until stop is closed.
+func (s *server) run(stop <-chan struct{}) error {
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", adminOptions.Port),
+		Handler: s.handler(),
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		log.Infof("Operator admin API listening on port %d", adminOptions.Port)
+		if adminOptions.CertDir == "" {
+			errCh <- srv.ListenAndServe()
+		} else {
+			errCh <- srv.ListenAndServeTLS(filepath.Join(adminOptions.CertDir, "tls.crt"), filepath.Join(adminOptions.CertDir, "tls.key"))
+		}
+	}()
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("operator admin API server failed: %s", err)
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	}
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReconcilePath, s.authorized(http.MethodPost, "update", s.handleReconcile))
+	mux.HandleFunc(StatusPath, s.authorized(http.MethodGet, "get", s.handleStatus))
+	mux.HandleFunc(ManifestsPath, s.authorized(http.MethodGet, "get", s.handleManifests))
+	return mux
+}
+
+// authorized wraps h so that it is only called for requests with the given method, namespace and name query
+// parameters, and a caller allowed to perform verb on the IstioOperator.
+func (s *server) authorized(method, verb string, h func(w http.ResponseWriter, r *http.Request, nn types.NamespacedName)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			http.Error(w, fmt.Sprintf("method %s not allowed, use %s", r.Method, method), http.StatusMethodNotAllowed)
+			return
+		}
+		nn := types.NamespacedName{Namespace: r.URL.Query().Get("namespace"), Name: r.URL.Query().Get("name")}
+		if nn.Namespace == "" || nn.Name == "" {
+			http.Error(w, "namespace and name query parameters are required", http.StatusBadRequest)
+			return
+		}
+		if code, err := s.auth.authorize(r, verb, nn.Namespace, nn.Name); err != nil {
+			log.Warnf("Admin API request %s %s for %s denied: %s", r.Method, r.URL.Path, nn, err)
+			http.Error(w, err.Error(), code)
+			return
+		}
+		h(w, r, nn)
+	}
+}
+
+func (s *server) handleReconcile(w http.ResponseWriter, _ *http.Request, nn types.NamespacedName) {
+	if !s.reconcile(nn.Namespace, nn.Name) {
+		http.Error(w, "too many reconcile requests queued, retry later", http.StatusServiceUnavailable)
+		return
+	}
+	log.Infof("Reconcile of IstioOperator %s requested through the admin API", nn)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *server) handleStatus(w http.ResponseWriter, _ *http.Request, nn types.NamespacedName) {
+	status, err := s.status(nn.Namespace, nn.Name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if status == nil {
+		http.Error(w, fmt.Sprintf("IstioOperator %s has no status", nn), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := (&jsonpb.Marshaler{}).Marshal(w, status); err != nil {
+		log.Errorf("Failed to write status of IstioOperator %s: %s", nn, err)
+	}
+}
+
+func (s *server) handleManifests(w http.ResponseWriter, r *http.Request, nn types.NamespacedName) {
+	mm := s.manifests(nn.Namespace, nn.Name)
+	if mm == nil {
+		http.Error(w, fmt.Sprintf("IstioOperator %s was not reconciled since the operator started", nn), http.StatusNotFound)
+		return
+	}
+	if c := r.URL.Query().Get("component"); c != "" {
+		ms, ok := mm[name.ComponentName(c)]
+		if !ok {
+			http.Error(w, fmt.Sprintf("IstioOperator %s has no component %s", nn, c), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = (name.ManifestMap{name.ComponentName(c): ms}).WriteTo(w)
+		return
+	}
+	out := make(map[string]string, len(mm))
+	for c, ms := range mm {
+		out[string(c)] = (name.ManifestMap{c: ms}).String()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		log.Errorf("Failed to write manifests of IstioOperator %s: %s", nn, err)
+	}
+}
+
+// getStatus returns the status of the IstioOperator with the given namespace and name.
+func getStatus(cl client.Client, namespace, iopName string) (*v1alpha1.InstallStatus, error) {
+	iop := &iopv1alpha1.IstioOperator{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: iopName}, iop); err != nil {
+		return nil, fmt.Errorf("failed to get IstioOperator %s/%s: %s", namespace, iopName, err)
+	}
+	return iop.Status, nil
+}
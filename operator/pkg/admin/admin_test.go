@@ -0,0 +1,135 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+// fakeAuthorizer allows only the verbs in allowed.
+type fakeAuthorizer struct {
+	allowed map[string]bool
+}
+
+func (a *fakeAuthorizer) authorize(_ *http.Request, verb, namespace, iopName string) (int, error) {
+	if !a.allowed[verb] {
+		return http.StatusForbidden, fmt.Errorf("may not %s %s/%s", verb, namespace, iopName)
+	}
+	return http.StatusOK, nil
+}
+
+func TestHandler(t *testing.T) {
+	var reconciled []string
+	s := &server{
+		auth: &fakeAuthorizer{allowed: map[string]bool{"get": true}},
+		reconcile: func(namespace, iopName string) bool {
+			reconciled = append(reconciled, namespace+"/"+iopName)
+			return true
+		},
+		status: func(namespace, iopName string) (*v1alpha1.InstallStatus, error) {
+			return &v1alpha1.InstallStatus{Status: v1alpha1.InstallStatus_HEALTHY}, nil
+		},
+		manifests: func(namespace, iopName string) name.ManifestMap {
+			if iopName != "installed" {
+				return nil
+			}
+			return name.ManifestMap{name.PilotComponentName: {"kind: Deployment"}}
+		},
+	}
+	h := s.handler()
+
+	tests := []struct {
+		desc     string
+		method   string
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{
+			desc:     "status",
+			method:   http.MethodGet,
+			url:      StatusPath + "?namespace=istio-system&name=installed",
+			wantCode: http.StatusOK,
+			wantBody: "HEALTHY",
+		},
+		{
+			desc:     "missing name",
+			method:   http.MethodGet,
+			url:      StatusPath + "?namespace=istio-system",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc:     "wrong method",
+			method:   http.MethodPost,
+			url:      StatusPath + "?namespace=istio-system&name=installed",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			desc:     "reconcile not allowed",
+			method:   http.MethodPost,
+			url:      ReconcilePath + "?namespace=istio-system&name=installed",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			desc:     "manifests",
+			method:   http.MethodGet,
+			url:      ManifestsPath + "?namespace=istio-system&name=installed",
+			wantCode: http.StatusOK,
+			wantBody: `"Pilot":"kind: Deployment\n---\n"`,
+		},
+		{
+			desc:     "component manifest",
+			method:   http.MethodGet,
+			url:      ManifestsPath + "?namespace=istio-system&name=installed&component=Pilot",
+			wantCode: http.StatusOK,
+			wantBody: "kind: Deployment",
+		},
+		{
+			desc:     "manifests not reconciled",
+			method:   http.MethodGet,
+			url:      ManifestsPath + "?namespace=istio-system&name=other",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("got code %d, want %d, body: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("got body:\n%s\nwant it to contain:\n%s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+	if len(reconciled) != 0 {
+		t.Errorf("reconcile was called without permission: %v", reconciled)
+	}
+
+	s.auth = &fakeAuthorizer{allowed: map[string]bool{"update": true}}
+	rec := httptest.NewRecorder()
+	s.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ReconcilePath+"?namespace=istio-system&name=installed", nil))
+	if rec.Code != http.StatusAccepted || len(reconciled) != 1 || reconciled[0] != "istio-system/installed" {
+		t.Errorf("reconcile: got code %d, reconciled %v", rec.Code, reconciled)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Options represents the details used to configure the admin API server.
+type Options struct {
+	// Enabled determines whether the admin API is served.
+	Enabled bool
+	// Port is the port the admin API server listens on.
+	Port int
+	// CertDir is the directory containing tls.crt and tls.key for the admin API server. If empty, the API is served
+	// over plain HTTP, which should only be used behind a port-forward or a TLS terminating proxy.
+	CertDir string
+}
+
+var adminOptions = &Options{
+	Port: 9445,
+}
+
+// AttachCobraFlags attaches the set of flags used to configure the admin API server to the given Cobra command.
+func AttachCobraFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&adminOptions.Enabled, "admin-api-enabled", adminOptions.Enabled,
+		"If set, the operator serves an admin API to trigger reconciles and read IstioOperator status and manifests.")
+	cmd.PersistentFlags().IntVar(&adminOptions.Port, "admin-api-port", adminOptions.Port,
+		"The port the admin API server listens on.")
+	cmd.PersistentFlags().StringVar(&adminOptions.CertDir, "admin-api-cert-dir", adminOptions.CertDir,
+		"The directory containing the tls.crt and tls.key files used by the admin API server. If not set, the API is "+
+			"served over plain HTTP.")
+}
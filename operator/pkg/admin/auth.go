@@ -0,0 +1,86 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+// authorizer checks whether the caller of a request may perform verb on the IstioOperator with the given namespace
+// and name. It returns the HTTP status to respond with if not.
+type authorizer interface {
+	authorize(r *http.Request, verb, namespace, iopName string) (int, error)
+}
+
+// kubeAuthorizer authenticates the bearer token of a request with a TokenReview and checks the IstioOperator RBAC
+// permissions of the user with a SubjectAccessReview.
+type kubeAuthorizer struct {
+	client kubernetes.Interface
+}
+
+func (a *kubeAuthorizer) authorize(r *http.Request, verb, namespace, iopName string) (int, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return http.StatusUnauthorized, fmt.Errorf("missing bearer token")
+	}
+
+	tr, err := a.client.AuthenticationV1().TokenReviews().Create(context.TODO(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("token review failed: %s", err)
+	}
+	if !tr.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("token is not valid: %s", tr.Status.Error)
+	}
+
+	user := tr.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:     iopv1alpha1.SchemeGroupVersion.Group,
+				Resource:  "istiooperators",
+				Verb:      verb,
+				Namespace: namespace,
+				Name:      iopName,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("subject access review failed: %s", err)
+	}
+	if !sar.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s may not %s istiooperators %s/%s", user.Username, verb, namespace, iopName)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conversion converts IstioOperator resources between the API versions of the install.istio.io group.
+// Conversions operate on the untyped object tree so that a version only needs a declaration of what changed
+// relative to the previous version, rather than a full set of generated types and conversion functions.
+package conversion
+
+import (
+	"fmt"
+	"strings"
+
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// Group is the API group of the IstioOperator resource.
+	Group = "install.istio.io"
+)
+
+// FieldRename moves the value at path From in the older version to path To in the newer version. Paths are relative
+// to the object root, e.g. spec.components.pilot.
+type FieldRename struct {
+	From util.Path
+	To   util.Path
+}
+
+// VersionChange describes the changes introduced by Version relative to the version preceding it.
+type VersionChange struct {
+	// Version is the API version, e.g. v1alpha2.
+	Version string
+	// Renames are the fields moved in this version.
+	Renames []FieldRename
+}
+
+// versionChanges lists all API versions in order, oldest first. The first entry is the base version and has no
+// changes.
+// v1alpha2 is served by the CRD alongside the v1alpha1 storage version; it starts out identical to v1alpha1 and
+// collects renames and removals as the API evolves.
+var versionChanges = []VersionChange{
+	{Version: "v1alpha1"},
+	{Version: "v1alpha2"},
+}
+
+// Versions returns all known API versions, oldest first.
+func Versions() []string {
+	var out []string
+	for _, vc := range versionChanges {
+		out = append(out, vc.Version)
+	}
+	return out
+}
+
+// Convert converts the IstioOperator object tree obj in place to the API version toVersion, e.g. v1alpha2.
+func Convert(obj map[string]interface{}, toVersion string) error {
+	return convert(versionChanges, obj, toVersion)
+}
+
+func convert(changes []VersionChange, obj map[string]interface{}, toVersion string) error {
+	apiVersion, ok := obj["apiVersion"].(string)
+	if !ok {
+		return fmt.Errorf("object has no apiVersion")
+	}
+	fromVersion, err := versionFromAPIVersion(apiVersion)
+	if err != nil {
+		return err
+	}
+	from, to := versionIndex(changes, fromVersion), versionIndex(changes, toVersion)
+	if from < 0 {
+		return fmt.Errorf("unknown API version %s", fromVersion)
+	}
+	if to < 0 {
+		return fmt.Errorf("unknown API version %s", toVersion)
+	}
+	for i := from + 1; i <= to; i++ {
+		for _, r := range changes[i].Renames {
+			if err := moveNode(obj, r.From, r.To); err != nil {
+				return fmt.Errorf("converting to %s: %s", changes[i].Version, err)
+			}
+		}
+	}
+	for i := from; i > to; i-- {
+		for _, r := range changes[i].Renames {
+			if err := moveNode(obj, r.To, r.From); err != nil {
+				return fmt.Errorf("converting from %s: %s", changes[i].Version, err)
+			}
+		}
+	}
+	obj["apiVersion"] = Group + "/" + toVersion
+	return nil
+}
+
+// moveNode moves the node at path from to path to, if it exists.
+func moveNode(obj map[string]interface{}, from, to util.Path) error {
+	val, found, err := tpath.GetFromTreePath(obj, from)
+	if err != nil || !found {
+		return err
+	}
+	if err := tpath.WriteNode(obj, to, val); err != nil {
+		return err
+	}
+	parent := obj
+	if len(from) > 1 {
+		p, _, err := tpath.GetFromTreePath(obj, from[:len(from)-1])
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if parent, ok = p.(map[string]interface{}); !ok {
+			return fmt.Errorf("parent of %s is not a map", from)
+		}
+	}
+	delete(parent, from[len(from)-1])
+	return nil
+}
+
+// versionFromAPIVersion returns the version part of an install.istio.io apiVersion string.
+func versionFromAPIVersion(apiVersion string) (string, error) {
+	gv := strings.Split(apiVersion, "/")
+	if len(gv) != 2 || gv[0] != Group {
+		return "", fmt.Errorf("bad apiVersion %s, expect %s/<version>", apiVersion, Group)
+	}
+	return gv[1], nil
+}
+
+func versionIndex(changes []VersionChange, version string) int {
+	for i, vc := range changes {
+		if vc.Version == version {
+			return i
+		}
+	}
+	return -1
+}
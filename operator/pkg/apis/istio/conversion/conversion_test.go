@@ -0,0 +1,139 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/istio/operator/pkg/util"
+)
+
+func TestConvert(t *testing.T) {
+	changes := []VersionChange{
+		{Version: "v1alpha1"},
+		{
+			Version: "v1alpha2",
+			Renames: []FieldRename{
+				{From: util.PathFromString("spec.components.pilot"), To: util.PathFromString("spec.components.istiod")},
+			},
+		},
+	}
+	tests := []struct {
+		desc      string
+		in        string
+		toVersion string
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc: "upgrade",
+			in: `
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+spec:
+  components:
+    pilot:
+      enabled: true
+`,
+			toVersion: "v1alpha2",
+			want: `
+apiVersion: install.istio.io/v1alpha2
+kind: IstioOperator
+spec:
+  components:
+    istiod:
+      enabled: true
+`,
+		},
+		{
+			desc: "downgrade",
+			in: `
+apiVersion: install.istio.io/v1alpha2
+kind: IstioOperator
+spec:
+  components:
+    istiod:
+      enabled: false
+`,
+			toVersion: "v1alpha1",
+			want: `
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+spec:
+  components:
+    pilot:
+      enabled: false
+`,
+		},
+		{
+			desc: "missing field",
+			in: `
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+spec:
+  profile: demo
+`,
+			toVersion: "v1alpha2",
+			want: `
+apiVersion: install.istio.io/v1alpha2
+kind: IstioOperator
+spec:
+  profile: demo
+`,
+		},
+		{
+			desc: "unknown version",
+			in: `
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+`,
+			toVersion: "v2",
+			wantErr:   true,
+		},
+		{
+			desc: "wrong group",
+			in: `
+apiVersion: foo.io/v1alpha1
+kind: IstioOperator
+`,
+			toVersion: "v1alpha2",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			obj := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(tt.in), &obj); err != nil {
+				t.Fatal(err)
+			}
+			err := convert(changes, obj, tt.toVersion)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := yaml.Marshal(obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !util.IsYAMLEqual(string(got), tt.want) {
+				t.Errorf("convert() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
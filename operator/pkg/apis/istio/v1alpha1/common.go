@@ -15,6 +15,9 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"strings"
+
 	"istio.io/api/operator/v1alpha1"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -24,6 +27,57 @@ import (
 const (
 	globalKey         = "global"
 	istioNamespaceKey = "istioNamespace"
+
+	// PausedAnnotation is an annotation on an IstioOperator CR which, if set to "true", suspends reconciliation and
+	// pruning of its resources by the operator controller.
+	PausedAnnotation = "install.istio.io/paused"
+	// IgnoreAnnotation is an annotation on a live object which, if set to "true", prevents it from being updated or
+	// pruned when its IstioOperator is applied, so that changes made to it in the cluster are kept.
+	IgnoreAnnotation = "install.istio.io/ignore"
+	// DeleteCRDsAnnotation is an annotation on an IstioOperator CR which, if set to "true", makes the operator
+	// controller also delete the Istio CRDs, and with them all Istio config, when the CR is deleted.
+	DeleteCRDsAnnotation = "install.istio.io/delete-crds"
+	// DependsOnAnnotation is an annotation on an IstioOperator CR which declares dependencies between components in
+	// addition to the built in ones, as a JSON map of component names to the component names they depend on.
+	DependsOnAnnotation = "install.istio.io/depends-on"
+	// ReconcilePolicyAnnotation is an annotation on an IstioOperator CR which sets the retries, backoff and failure
+	// action of components, as a JSON map of component names to reconcile policies.
+	ReconcilePolicyAnnotation = "install.istio.io/reconcile-policy"
+	// ApplyOrderAnnotation is an annotation on an IstioOperator CR which overrides the order in which the objects of
+	// each component are applied, and deleted in reverse, as a comma separated list of kinds, where * stands for the
+	// default order, for example "apiextensions.k8s.io/CustomResourceDefinition,example.com/Widget,*".
+	ApplyOrderAnnotation = "install.istio.io/apply-order"
+	// DryRunAnnotation is an annotation on an IstioOperator CR which, if set to "true", makes the operator controller
+	// render and diff the CR against the cluster and write the changes it would make into the status, without applying
+	// or pruning anything.
+	DryRunAnnotation = "install.istio.io/dry-run"
+	// UserGatewayAnnotation is an annotation on an IstioOperator CR which, if set to "true", makes it a user gateway
+	// CR, which only installs its gateway components against the existing control plane of spec.revision. This lets
+	// app teams manage their gateways independently of the CR of the control plane.
+	UserGatewayAnnotation = "install.istio.io/user-gateway"
+	// MergeMeshConfigAnnotation is an annotation on an IstioOperator CR which, if set to "true", merges the rendered
+	// mesh config into the one in the live istio ConfigMap, keeping the fields set by other tools, rather than
+	// replacing it.
+	MergeMeshConfigAnnotation = "install.istio.io/merge-mesh-config"
+
+	// InstalledStateCRPrefix is the name prefix of the installed-state IstioOperator CRs, the copies of the CRs used
+	// in the last manifest apply operation which istioctl stores in the cluster.
+	InstalledStateCRPrefix = "installed-state"
+	// InstalledVersionAnnotation is an annotation on an installed-state IstioOperator CR holding the version of the
+	// istioctl or operator binary which last applied it.
+	InstalledVersionAnnotation = "install.istio.io/installed-version"
+	// InstalledGitRevisionAnnotation is an annotation on an installed-state IstioOperator CR holding the git revision
+	// of the binary which last applied it.
+	InstalledGitRevisionAnnotation = "install.istio.io/installed-git-revision"
+	// ChartDigestAnnotation is an annotation on an installed-state IstioOperator CR holding the digest of the charts
+	// it was last rendered from.
+	ChartDigestAnnotation = "install.istio.io/chart-digest"
+	// InstalledProfileAnnotation is an annotation on an installed-state IstioOperator CR holding the profile it was
+	// last applied with.
+	InstalledProfileAnnotation = "install.istio.io/installed-profile"
+	// InstalledAtAnnotation is an annotation on an installed-state IstioOperator CR holding the RFC 3339 time it was
+	// last applied.
+	InstalledAtAnnotation = "install.istio.io/installed-at"
 )
 
 // Namespace returns the namespace of the containing CR.
@@ -60,6 +114,74 @@ func SetNamespace(iops *v1alpha1.IstioOperatorSpec, namespace string) {
 	v[istioNamespaceKey] = namespace
 }
 
+// IsPaused reports whether reconciliation of iop is paused through PausedAnnotation.
+func IsPaused(iop *IstioOperator) bool {
+	return strings.EqualFold(iop.GetAnnotations()[PausedAnnotation], "true")
+}
+
+// IsDryRun reports whether changes to iop are only previewed through DryRunAnnotation.
+func IsDryRun(iop *IstioOperator) bool {
+	return strings.EqualFold(iop.GetAnnotations()[DryRunAnnotation], "true")
+}
+
+// IsUserGateway reports whether iop only installs gateways through UserGatewayAnnotation.
+func IsUserGateway(iop *IstioOperator) bool {
+	return strings.EqualFold(iop.GetAnnotations()[UserGatewayAnnotation], "true")
+}
+
+// IsInstalledState reports whether the IstioOperator CR with the given name is an installed-state CR, see
+// InstalledStateCRPrefix.
+func IsInstalledState(name string) bool {
+	return strings.HasPrefix(name, InstalledStateCRPrefix)
+}
+
+// MergesMeshConfig reports whether the mesh config of iop is merged into the live one through
+// MergeMeshConfigAnnotation.
+func MergesMeshConfig(iop *IstioOperator) bool {
+	return strings.EqualFold(iop.GetAnnotations()[MergeMeshConfigAnnotation], "true")
+}
+
+// DeletesCRDs reports whether the Istio CRDs are deleted along with iop through DeleteCRDsAnnotation.
+func DeletesCRDs(iop *IstioOperator) bool {
+	return strings.EqualFold(iop.GetAnnotations()[DeleteCRDsAnnotation], "true")
+}
+
+// InstallStatusFields are the fields of the typed InstallStatus in the status of an IstioOperator CR. The other
+// fields of the status, like the effective spec and the checkpoints, are written by the reconciler through the
+// unstructured CR.
+var InstallStatusFields = []string{"status", "componentStatus"}
+
+// UnmarshalJSON unmarshals an IstioOperator CR, leaving out the fields of its status which are not InstallStatusFields,
+// since the unmarshaler of InstallStatus rejects unknown fields.
+func (m *IstioOperator) UnmarshalJSON(b []byte) error {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if st, ok := fields["status"]; ok && string(st) != "null" {
+		statusFields := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(st, &statusFields); err != nil {
+			return err
+		}
+		typed := make(map[string]json.RawMessage)
+		for _, f := range InstallStatusFields {
+			if v, ok := statusFields[f]; ok {
+				typed[f] = v
+			}
+		}
+		var err error
+		if fields["status"], err = json.Marshal(typed); err != nil {
+			return err
+		}
+		if b, err = json.Marshal(fields); err != nil {
+			return err
+		}
+	}
+	// iop has the fields of IstioOperator without this method.
+	type iop IstioOperator
+	return json.Unmarshal(b, (*iop)(m))
+}
+
 // define new type from k8s intstr to marshal/unmarshal jsonpb
 type IntOrStringForPB struct {
 	intstr.IntOrString
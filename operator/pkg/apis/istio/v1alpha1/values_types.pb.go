@@ -173,7 +173,7 @@ func (x OutboundTrafficPolicyConfig_Mode) String() string {
 }
 
 func (OutboundTrafficPolicyConfig_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{29, 0}
+	return fileDescriptor_261260e22432516f, []int{31, 0}
 }
 
 // ArchConfig specifies the pod scheduling target architecture(amd64, ppc64le, s390x) for all the Istio control plane components.
@@ -183,7 +183,9 @@ type ArchConfig struct {
 	// Sets pod scheduling weight for ppc64le arch.
 	Ppc64Le uint32 `protobuf:"varint,2,opt,name=ppc64le,proto3" json:"ppc64le,omitempty"`
 	// Sets pod scheduling weight for s390x arch.
-	S390X                uint32   `protobuf:"varint,3,opt,name=s390x,proto3" json:"s390x,omitempty"`
+	S390X uint32 `protobuf:"varint,3,opt,name=s390x,proto3" json:"s390x,omitempty"`
+	// Sets pod scheduling weight for arm64 arch.
+	Arm64                uint32   `protobuf:"varint,4,opt,name=arm64,proto3" json:"arm64,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -235,6 +237,13 @@ func (m *ArchConfig) GetS390X() uint32 {
 	return 0
 }
 
+func (m *ArchConfig) GetArm64() uint32 {
+	if m != nil {
+		return m.Arm64
+	}
+	return 0
+}
+
 // Configuration for CNI.
 type CNIConfig struct {
 	// Controls whether CNI is enabled.
@@ -848,9 +857,11 @@ type EgressGatewayConfig struct {
 	// Controls whether an egress gateway is enabled.
 	Enabled *protobuf.BoolValue `protobuf:"bytes,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	// Environment variables passed to the proxy container.
-	Env    map[string]interface{} `protobuf:"bytes,8,opt,name=env,proto3" json:"env,omitempty"`
-	Labels *GatewayLabelsConfig    `protobuf:"bytes,9,opt,name=labels,proto3" json:"labels,omitempty"`
-	Name   string                  `protobuf:"bytes,25,opt,name=name,proto3" json:"name,omitempty"`
+	Env map[string]interface{} `protobuf:"bytes,8,opt,name=env,proto3" json:"env,omitempty"`
+	// Overrides global.hub for the proxy image of egress gateways, e.g. to roll them out before or after istiod.
+	Hub    string               `protobuf:"bytes,26,opt,name=hub,proto3" json:"hub,omitempty"`
+	Labels *GatewayLabelsConfig `protobuf:"bytes,9,opt,name=labels,proto3" json:"labels,omitempty"`
+	Name   string               `protobuf:"bytes,25,opt,name=name,proto3" json:"name,omitempty"`
 	// K8s node selector.
 	//
 	// See https://kubernetes.io/docs/concepts/configuration/assign-pod-node/#nodeselector
@@ -907,9 +918,14 @@ type EgressGatewayConfig struct {
 	RollingMaxUnavailable *IntOrStringForPB          `protobuf:"bytes,22,opt,name=rollingMaxUnavailable,proto3" json:"rollingMaxUnavailable,omitempty"` // Deprecated: Do not use.
 	ConfigVolumes         []map[string]interface{} `protobuf:"bytes,23,opt,name=configVolumes,proto3" json:"configVolumes,omitempty"`
 	AdditionalContainers  []map[string]interface{} `protobuf:"bytes,24,opt,name=additionalContainers,proto3" json:"additionalContainers,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{}                       `json:"-"`
-	XXX_unrecognized      []byte                         `json:"-"`
-	XXX_sizecache         int32                          `json:"-"`
+	// Overrides global.tag for the proxy image of egress gateways.
+	Tag interface{} `protobuf:"bytes,27,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Name of the sidecar injection template egress gateway pods are injected with, e.g. gateway. If set, the
+	// Deployment only has a minimal proxy container and the rest comes from the injector.
+	InjectionTemplate    string   `protobuf:"bytes,28,opt,name=injectionTemplate,proto3" json:"injectionTemplate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EgressGatewayConfig) Reset()         { *m = EgressGatewayConfig{} }
@@ -994,6 +1010,13 @@ func (m *EgressGatewayConfig) GetEnv() map[string]interface{} {
 	return nil
 }
 
+func (m *EgressGatewayConfig) GetHub() string {
+	if m != nil {
+		return m.Hub
+	}
+	return ""
+}
+
 func (m *EgressGatewayConfig) GetLabels() *GatewayLabelsConfig {
 	if m != nil {
 		return m.Labels
@@ -1121,6 +1144,72 @@ func (m *EgressGatewayConfig) GetAdditionalContainers() []map[string]interface{}
 	return nil
 }
 
+func (m *EgressGatewayConfig) GetTag() interface{} {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+func (m *EgressGatewayConfig) GetInjectionTemplate() string {
+	if m != nil {
+		return m.InjectionTemplate
+	}
+	return ""
+}
+
+// EgressLockdownConfig only allows egress traffic to the services in the mesh registry.
+type EgressLockdownConfig struct {
+	// Sets meshConfig.outboundTrafficPolicy.mode to REGISTRY_ONLY, enables the istio-egressgateway and installs a
+	// baseline ServiceEntry for allowedHosts with the control plane without a revision.
+	Enabled *protobuf.BoolValue `protobuf:"bytes,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// External hosts which workloads may reach, e.g. *.googleapis.com, added to the mesh registry by the baseline
+	// ServiceEntry.
+	AllowedHosts         []string `protobuf:"bytes,2,rep,name=allowedHosts,proto3" json:"allowedHosts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EgressLockdownConfig) Reset()         { *m = EgressLockdownConfig{} }
+func (m *EgressLockdownConfig) String() string { return proto.CompactTextString(m) }
+func (*EgressLockdownConfig) ProtoMessage()    {}
+func (*EgressLockdownConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_261260e22432516f, []int{9}
+}
+
+func (m *EgressLockdownConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EgressLockdownConfig.Unmarshal(m, b)
+}
+func (m *EgressLockdownConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EgressLockdownConfig.Marshal(b, m, deterministic)
+}
+func (m *EgressLockdownConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EgressLockdownConfig.Merge(m, src)
+}
+func (m *EgressLockdownConfig) XXX_Size() int {
+	return xxx_messageInfo_EgressLockdownConfig.Size(m)
+}
+func (m *EgressLockdownConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_EgressLockdownConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EgressLockdownConfig proto.InternalMessageInfo
+
+func (m *EgressLockdownConfig) GetEnabled() *protobuf.BoolValue {
+	if m != nil {
+		return m.Enabled
+	}
+	return nil
+}
+
+func (m *EgressLockdownConfig) GetAllowedHosts() []string {
+	if m != nil {
+		return m.AllowedHosts
+	}
+	return nil
+}
+
 // EnvoyMetricsConfig is a set of configuration options for Envoy metrics.
 type EnvoyMetricsConfig struct {
 	// Enables the Envoy Metrics Service.
@@ -1140,7 +1229,7 @@ func (m *EnvoyMetricsConfig) Reset()         { *m = EnvoyMetricsConfig{} }
 func (m *EnvoyMetricsConfig) String() string { return proto.CompactTextString(m) }
 func (*EnvoyMetricsConfig) ProtoMessage()    {}
 func (*EnvoyMetricsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{9}
+	return fileDescriptor_261260e22432516f, []int{10}
 }
 
 func (m *EnvoyMetricsConfig) XXX_Unmarshal(b []byte) error {
@@ -1209,7 +1298,7 @@ func (m *GatewayLabelsConfig) Reset()         { *m = GatewayLabelsConfig{} }
 func (m *GatewayLabelsConfig) String() string { return proto.CompactTextString(m) }
 func (*GatewayLabelsConfig) ProtoMessage()    {}
 func (*GatewayLabelsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{10}
+	return fileDescriptor_261260e22432516f, []int{11}
 }
 
 func (m *GatewayLabelsConfig) XXX_Unmarshal(b []byte) error {
@@ -1261,7 +1350,7 @@ func (m *GatewaysConfig) Reset()         { *m = GatewaysConfig{} }
 func (m *GatewaysConfig) String() string { return proto.CompactTextString(m) }
 func (*GatewaysConfig) ProtoMessage()    {}
 func (*GatewaysConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{11}
+	return fileDescriptor_261260e22432516f, []int{12}
 }
 
 func (m *GatewaysConfig) XXX_Unmarshal(b []byte) error {
@@ -1312,6 +1401,9 @@ type GlobalConfig struct {
 	//   3 - Most preferred
 	Arch         *ArchConfig                    `protobuf:"bytes,1,opt,name=arch,proto3" json:"arch,omitempty"`
 	Certificates []map[string]interface{} `protobuf:"bytes,40,opt,name=certificates,proto3" json:"certificates,omitempty"`
+	// Controls whether workloads get pod template annotations with checksums of the ConfigMaps and Secrets they mount or
+	// reference, so that config changes roll their pods. Enabled by default.
+	ConfigChecksums *protobuf.BoolValue `protobuf:"bytes,69,opt,name=configChecksums,proto3" json:"configChecksums,omitempty"`
 	// Specifies the namespace for the configuration and validation component.
 	ConfigNamespace     string `protobuf:"bytes,2,opt,name=configNamespace,proto3" json:"configNamespace,omitempty"`
 	ConfigRootNamespace string `protobuf:"bytes,50,opt,name=configRootNamespace,proto3" json:"configRootNamespace,omitempty"`
@@ -1326,6 +1418,9 @@ type GlobalConfig struct {
 	DefaultNodeSelector map[string]interface{} `protobuf:"bytes,6,opt,name=defaultNodeSelector,proto3" json:"defaultNodeSelector,omitempty"` // Deprecated: Do not use.
 	// Specifies the default pod disruption budget configuration.
 	DefaultPodDisruptionBudget *DefaultPodDisruptionBudgetConfig `protobuf:"bytes,7,opt,name=defaultPodDisruptionBudget,proto3" json:"defaultPodDisruptionBudget,omitempty"` // Deprecated: Do not use.
+	// Scheduling constraints for the pods of all components except CNI, which runs on every node, unless set in the k8s
+	// settings of a component.
+	DefaultPodScheduling *PodSchedulingConfig `protobuf:"bytes,73,opt,name=defaultPodScheduling,proto3" json:"defaultPodScheduling,omitempty"`
 	// Controls whether the policy enforcement is enabled.
 	DisablePolicyChecks *protobuf.BoolValue `protobuf:"bytes,8,opt,name=disablePolicyChecks,proto3" json:"disablePolicyChecks,omitempty"`
 	// Default k8s resources settings for all Istio control plane components.
@@ -1333,6 +1428,8 @@ type GlobalConfig struct {
 	// See https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/#resource-requests-and-limits-of-pod-and-container
 	DefaultResources   *DefaultResourcesConfig        `protobuf:"bytes,9,opt,name=defaultResources,proto3" json:"defaultResources,omitempty"`      // Deprecated: Do not use.
 	DefaultTolerations []map[string]interface{} `protobuf:"bytes,55,opt,name=defaultTolerations,proto3" json:"defaultTolerations,omitempty"` // Deprecated: Do not use.
+	// Locks down egress traffic to the services in the mesh registry.
+	EgressLockdown *EgressLockdownConfig `protobuf:"bytes,70,opt,name=egressLockdown,proto3" json:"egressLockdown,omitempty"`
 	// Controls whether the helm test templates are enabled.
 	EnableHelmTest *protobuf.BoolValue `protobuf:"bytes,10,opt,name=enableHelmTest,proto3" json:"enableHelmTest,omitempty"`
 	// Controls whether the distributed tracing for the applications is enabled.
@@ -1347,6 +1444,14 @@ type GlobalConfig struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/images#updating-images
 	ImagePullPolicy  string   `protobuf:"bytes,13,opt,name=imagePullPolicy,proto3" json:"imagePullPolicy,omitempty"`
 	ImagePullSecrets []string `protobuf:"bytes,37,rep,name=imagePullSecrets,proto3" json:"imagePullSecrets,omitempty"`
+	// Specifies the image variant of all Istio images, e.g. distroless, debug or fips. The tag of every Istio image,
+	// including the proxy image used by the sidecar injector, is suffixed with -<variant>.
+	ImageVariant string `protobuf:"bytes,63,opt,name=imageVariant,proto3" json:"imageVariant,omitempty"`
+	// Specifies the IP families of all Istio Services, IPv4 and/or IPv6, for single-stack IPv6 or dual-stack clusters.
+	// The first family is the primary one. The cluster nodes must have pod CIDRs of every family.
+	IpFamilies []string `protobuf:"bytes,64,rep,name=ipFamilies,proto3" json:"ipFamilies,omitempty"`
+	// Specifies the IP family policy of all Istio Services: SingleStack, PreferDualStack or RequireDualStack.
+	IpFamilyPolicy string `protobuf:"bytes,65,opt,name=ipFamilyPolicy,proto3" json:"ipFamilyPolicy,omitempty"`
 	// Specifies the default namespace for the Istio control plane components.
 	IstioNamespace string `protobuf:"bytes,14,opt,name=istioNamespace,proto3" json:"istioNamespace,omitempty"`
 	// Specifies the global locality load balancing settings.
@@ -1408,13 +1513,21 @@ type GlobalConfig struct {
 	Mtls *MTLSConfig `protobuf:"bytes,21,opt,name=mtls,proto3" json:"mtls,omitempty"`
 	// Specifies the Configuration for Istio mesh across multiple clusters through Istio gateways.
 	MultiCluster *MultiClusterConfig `protobuf:"bytes,22,opt,name=multiCluster,proto3" json:"multiCluster,omitempty"`
-	Network      string              `protobuf:"bytes,39,opt,name=network,proto3" json:"network,omitempty"`
+	// Configures all components for clusters with both amd64 and arm64 nodes.
+	MultiArch *MultiArchConfig `protobuf:"bytes,66,opt,name=multiArch,proto3" json:"multiArch,omitempty"`
+	Network   string           `protobuf:"bytes,39,opt,name=network,proto3" json:"network,omitempty"`
+	// Specifies the node operating systems, linux or windows, which Istio pods are scheduled on. By default, DaemonSets
+	// are restricted to linux nodes and other pods are not restricted. If set, all Istio pods select nodes of these
+	// operating systems through the kubernetes.io/os node label.
+	NodeOS []string `protobuf:"bytes,68,rep,name=nodeOS,proto3" json:"nodeOS,omitempty"`
 	// Custom DNS config for the pod to resolve names of services in other
 	// clusters. Use this to add additional search domains, and other settings.
 	// see https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#dns-config
 	// This does not apply to gateway pods as they typically need a different
 	// set of DNS settings than the normal application pods (e.g. in multicluster scenarios).
-	PodDNSSearchNamespaces       []string            `protobuf:"bytes,43,rep,name=podDNSSearchNamespaces,proto3" json:"podDNSSearchNamespaces,omitempty"`
+	PodDNSSearchNamespaces []string `protobuf:"bytes,43,rep,name=podDNSSearchNamespaces,proto3" json:"podDNSSearchNamespaces,omitempty"`
+	// Configures the rendered components to run under restricted pod security policies.
+	PodSecurity                  *PodSecurityConfig  `protobuf:"bytes,62,opt,name=podSecurity,proto3" json:"podSecurity,omitempty"`
 	OmitSidecarInjectorConfigMap *protobuf.BoolValue `protobuf:"bytes,38,opt,name=omitSidecarInjectorConfigMap,proto3" json:"omitSidecarInjectorConfigMap,omitempty"`
 	// Controls whether to restrict the applications namespace the controller manages;
 	// If set it to false, the controller watches all namespaces.
@@ -1422,6 +1535,9 @@ type GlobalConfig struct {
 	OperatorManageWebhooks *protobuf.BoolValue `protobuf:"bytes,41,opt,name=operatorManageWebhooks,proto3" json:"operatorManageWebhooks,omitempty"`
 	// Controls the default behavior of the sidecar for handling outbound traffic from the application.
 	OutboundTrafficPolicy *OutboundTrafficPolicyConfig `protobuf:"bytes,24,opt,name=outboundTrafficPolicy,proto3" json:"outboundTrafficPolicy,omitempty"`
+	// Specifies the Kubernetes platform, gke, eks, aks or openshift, for platform specific defaults. istioctl detects the
+	// platform from the cluster nodes if it is not set.
+	Platform string `protobuf:"bytes,67,opt,name=platform,proto3" json:"platform,omitempty"`
 	// Controls whether to allow traffic in cases when the mixer policy service cannot be reached.
 	PolicyCheckFailOpen *protobuf.BoolValue `protobuf:"bytes,25,opt,name=policyCheckFailOpen,proto3" json:"policyCheckFailOpen,omitempty"`
 	// Specifies the namespace for the policy component.
@@ -1435,6 +1551,15 @@ type GlobalConfig struct {
 	Proxy *ProxyConfig `protobuf:"bytes,28,opt,name=proxy,proto3" json:"proxy,omitempty"`
 	// Specifies the Configuration for proxy_init container which sets the pods' networking to intercept the inbound/outbound traffic.
 	ProxyInit *ProxyInitConfig `protobuf:"bytes,29,opt,name=proxy_init,proto3" json:"proxy_init,omitempty"`
+	// Annotations added to the metadata of every rendered object, e.g. for ownership or admission policies. Annotations
+	// set by the charts take precedence.
+	ResourceAnnotations map[string]string `protobuf:"bytes,71,rep,name=resourceAnnotations,proto3" json:"resourceAnnotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Labels added to the metadata of every rendered object, e.g. for cost allocation. Labels set by the charts take
+	// precedence.
+	ResourceLabels map[string]string `protobuf:"bytes,72,rep,name=resourceLabels,proto3" json:"resourceLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Sizing preset of the replicas, autoscaling bounds and resources of the control plane and gateways: small, medium,
+	// large or custom. Values set explicitly take precedence over the preset; custom or unset applies no preset.
+	Sizing string `protobuf:"bytes,74,opt,name=sizing,proto3" json:"sizing,omitempty"`
 	// Specifies the Configuration for the SecretDiscoveryService instead of using K8S secrets to mount the certificates.
 	Sds *SDSConfig `protobuf:"bytes,30,opt,name=sds,proto3" json:"sds,omitempty"`
 	// Specifies the tag for the Istio docker images.
@@ -1487,7 +1612,7 @@ func (m *GlobalConfig) Reset()         { *m = GlobalConfig{} }
 func (m *GlobalConfig) String() string { return proto.CompactTextString(m) }
 func (*GlobalConfig) ProtoMessage()    {}
 func (*GlobalConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{12}
+	return fileDescriptor_261260e22432516f, []int{13}
 }
 
 func (m *GlobalConfig) XXX_Unmarshal(b []byte) error {
@@ -1522,6 +1647,13 @@ func (m *GlobalConfig) GetCertificates() []map[string]interface{} {
 	return nil
 }
 
+func (m *GlobalConfig) GetConfigChecksums() *protobuf.BoolValue {
+	if m != nil {
+		return m.ConfigChecksums
+	}
+	return nil
+}
+
 func (m *GlobalConfig) GetConfigNamespace() string {
 	if m != nil {
 		return m.ConfigNamespace
@@ -1573,6 +1705,13 @@ func (m *GlobalConfig) GetDefaultPodDisruptionBudget() *DefaultPodDisruptionBudg
 	return nil
 }
 
+func (m *GlobalConfig) GetDefaultPodScheduling() *PodSchedulingConfig {
+	if m != nil {
+		return m.DefaultPodScheduling
+	}
+	return nil
+}
+
 func (m *GlobalConfig) GetDisablePolicyChecks() *protobuf.BoolValue {
 	if m != nil {
 		return m.DisablePolicyChecks
@@ -1596,6 +1735,13 @@ func (m *GlobalConfig) GetDefaultTolerations() []map[string]interface{} {
 	return nil
 }
 
+func (m *GlobalConfig) GetEgressLockdown() *EgressLockdownConfig {
+	if m != nil {
+		return m.EgressLockdown
+	}
+	return nil
+}
+
 func (m *GlobalConfig) GetEnableHelmTest() *protobuf.BoolValue {
 	if m != nil {
 		return m.EnableHelmTest
@@ -1631,6 +1777,27 @@ func (m *GlobalConfig) GetImagePullSecrets() []string {
 	return nil
 }
 
+func (m *GlobalConfig) GetImageVariant() string {
+	if m != nil {
+		return m.ImageVariant
+	}
+	return ""
+}
+
+func (m *GlobalConfig) GetIpFamilies() []string {
+	if m != nil {
+		return m.IpFamilies
+	}
+	return nil
+}
+
+func (m *GlobalConfig) GetIpFamilyPolicy() string {
+	if m != nil {
+		return m.IpFamilyPolicy
+	}
+	return ""
+}
+
 func (m *GlobalConfig) GetIstioNamespace() string {
 	if m != nil {
 		return m.IstioNamespace
@@ -1701,6 +1868,13 @@ func (m *GlobalConfig) GetMultiCluster() *MultiClusterConfig {
 	return nil
 }
 
+func (m *GlobalConfig) GetMultiArch() *MultiArchConfig {
+	if m != nil {
+		return m.MultiArch
+	}
+	return nil
+}
+
 func (m *GlobalConfig) GetNetwork() string {
 	if m != nil {
 		return m.Network
@@ -1708,6 +1882,13 @@ func (m *GlobalConfig) GetNetwork() string {
 	return ""
 }
 
+func (m *GlobalConfig) GetNodeOS() []string {
+	if m != nil {
+		return m.NodeOS
+	}
+	return nil
+}
+
 func (m *GlobalConfig) GetPodDNSSearchNamespaces() []string {
 	if m != nil {
 		return m.PodDNSSearchNamespaces
@@ -1715,6 +1896,13 @@ func (m *GlobalConfig) GetPodDNSSearchNamespaces() []string {
 	return nil
 }
 
+func (m *GlobalConfig) GetPodSecurity() *PodSecurityConfig {
+	if m != nil {
+		return m.PodSecurity
+	}
+	return nil
+}
+
 func (m *GlobalConfig) GetOmitSidecarInjectorConfigMap() *protobuf.BoolValue {
 	if m != nil {
 		return m.OmitSidecarInjectorConfigMap
@@ -1743,6 +1931,13 @@ func (m *GlobalConfig) GetOutboundTrafficPolicy() *OutboundTrafficPolicyConfig {
 	return nil
 }
 
+func (m *GlobalConfig) GetPlatform() string {
+	if m != nil {
+		return m.Platform
+	}
+	return ""
+}
+
 func (m *GlobalConfig) GetPolicyCheckFailOpen() *protobuf.BoolValue {
 	if m != nil {
 		return m.PolicyCheckFailOpen
@@ -1786,6 +1981,27 @@ func (m *GlobalConfig) GetProxyInit() *ProxyInitConfig {
 	return nil
 }
 
+func (m *GlobalConfig) GetResourceAnnotations() map[string]string {
+	if m != nil {
+		return m.ResourceAnnotations
+	}
+	return nil
+}
+
+func (m *GlobalConfig) GetResourceLabels() map[string]string {
+	if m != nil {
+		return m.ResourceLabels
+	}
+	return nil
+}
+
+func (m *GlobalConfig) GetSizing() string {
+	if m != nil {
+		return m.Sizing
+	}
+	return ""
+}
+
 func (m *GlobalConfig) GetSds() *SDSConfig {
 	if m != nil {
 		return m.Sds
@@ -1940,7 +2156,7 @@ func (m *STSConfig) Reset()         { *m = STSConfig{} }
 func (m *STSConfig) String() string { return proto.CompactTextString(m) }
 func (*STSConfig) ProtoMessage()    {}
 func (*STSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{13}
+	return fileDescriptor_261260e22432516f, []int{14}
 }
 
 func (m *STSConfig) XXX_Unmarshal(b []byte) error {
@@ -1983,7 +2199,7 @@ func (m *IstiodConfig) Reset()         { *m = IstiodConfig{} }
 func (m *IstiodConfig) String() string { return proto.CompactTextString(m) }
 func (*IstiodConfig) ProtoMessage()    {}
 func (*IstiodConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{14}
+	return fileDescriptor_261260e22432516f, []int{15}
 }
 
 func (m *IstiodConfig) XXX_Unmarshal(b []byte) error {
@@ -2034,7 +2250,7 @@ func (m *GlobalLoggingConfig) Reset()         { *m = GlobalLoggingConfig{} }
 func (m *GlobalLoggingConfig) String() string { return proto.CompactTextString(m) }
 func (*GlobalLoggingConfig) ProtoMessage()    {}
 func (*GlobalLoggingConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{15}
+	return fileDescriptor_261260e22432516f, []int{16}
 }
 
 func (m *GlobalLoggingConfig) XXX_Unmarshal(b []byte) error {
@@ -2082,15 +2298,17 @@ type IngressGatewayConfig struct {
 	// Controls whether an ingress gateway is enabled.
 	Enabled *protobuf.BoolValue `protobuf:"bytes,10,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	// Environment variables passed to the proxy container.
-	Env                      map[string]interface{} `protobuf:"bytes,11,opt,name=env,proto3" json:"env,omitempty"`
-	ExternalIPs              []string                `protobuf:"bytes,12,rep,name=externalIPs,proto3" json:"externalIPs,omitempty"`
-	K8SIngress               *protobuf.BoolValue     `protobuf:"bytes,13,opt,name=k8sIngress,proto3" json:"k8sIngress,omitempty"`
-	K8SIngressHttps          *protobuf.BoolValue     `protobuf:"bytes,14,opt,name=k8sIngressHttps,proto3" json:"k8sIngressHttps,omitempty"`
-	Labels                   *GatewayLabelsConfig    `protobuf:"bytes,15,opt,name=labels,proto3" json:"labels,omitempty"`
-	LoadBalancerIP           string                  `protobuf:"bytes,16,opt,name=loadBalancerIP,proto3" json:"loadBalancerIP,omitempty"`
-	LoadBalancerSourceRanges []string                `protobuf:"bytes,17,rep,name=loadBalancerSourceRanges,proto3" json:"loadBalancerSourceRanges,omitempty"`
-	MeshExpansionPorts       []*PortsConfig          `protobuf:"bytes,18,rep,name=meshExpansionPorts,proto3" json:"meshExpansionPorts,omitempty"`
-	Name                     string                  `protobuf:"bytes,44,opt,name=name,proto3" json:"name,omitempty"`
+	Env         map[string]interface{} `protobuf:"bytes,11,opt,name=env,proto3" json:"env,omitempty"`
+	ExternalIPs []string                `protobuf:"bytes,12,rep,name=externalIPs,proto3" json:"externalIPs,omitempty"`
+	// Overrides global.hub for the proxy image of ingress gateways, e.g. to roll them out before or after istiod.
+	Hub                      string               `protobuf:"bytes,45,opt,name=hub,proto3" json:"hub,omitempty"`
+	K8SIngress               *protobuf.BoolValue  `protobuf:"bytes,13,opt,name=k8sIngress,proto3" json:"k8sIngress,omitempty"`
+	K8SIngressHttps          *protobuf.BoolValue  `protobuf:"bytes,14,opt,name=k8sIngressHttps,proto3" json:"k8sIngressHttps,omitempty"`
+	Labels                   *GatewayLabelsConfig `protobuf:"bytes,15,opt,name=labels,proto3" json:"labels,omitempty"`
+	LoadBalancerIP           string               `protobuf:"bytes,16,opt,name=loadBalancerIP,proto3" json:"loadBalancerIP,omitempty"`
+	LoadBalancerSourceRanges []string             `protobuf:"bytes,17,rep,name=loadBalancerSourceRanges,proto3" json:"loadBalancerSourceRanges,omitempty"`
+	MeshExpansionPorts       []*PortsConfig       `protobuf:"bytes,18,rep,name=meshExpansionPorts,proto3" json:"meshExpansionPorts,omitempty"`
+	Name                     string               `protobuf:"bytes,44,opt,name=name,proto3" json:"name,omitempty"`
 	// K8s node selector.
 	//
 	// See https://kubernetes.io/docs/concepts/configuration/assign-pod-node/#nodeselector
@@ -2139,16 +2357,21 @@ type IngressGatewayConfig struct {
 	TelemetryAddonGateways map[string]interface{}        `protobuf:"bytes,41,opt,name=telemetry_addon_gateways,json=telemetryAddonGateways,proto3" json:"telemetry_addon_gateways,omitempty"`
 	Hosts                  []map[string]interface{} `protobuf:"bytes,42,opt,name=hosts,proto3" json:"hosts,omitempty"`
 	TelemetryDomainName    string                         `protobuf:"bytes,43,opt,name=telemetry_domain_name,json=telemetryDomainName,proto3" json:"telemetry_domain_name,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{}                       `json:"-"`
-	XXX_unrecognized       []byte                         `json:"-"`
-	XXX_sizecache          int32                          `json:"-"`
+	// Overrides global.tag for the proxy image of ingress gateways.
+	Tag interface{} `protobuf:"bytes,46,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Name of the sidecar injection template ingress gateway pods are injected with, e.g. gateway. If set, the
+	// Deployment only has a minimal proxy container and the rest comes from the injector.
+	InjectionTemplate    string   `protobuf:"bytes,47,opt,name=injectionTemplate,proto3" json:"injectionTemplate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *IngressGatewayConfig) Reset()         { *m = IngressGatewayConfig{} }
 func (m *IngressGatewayConfig) String() string { return proto.CompactTextString(m) }
 func (*IngressGatewayConfig) ProtoMessage()    {}
 func (*IngressGatewayConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{16}
+	return fileDescriptor_261260e22432516f, []int{17}
 }
 
 func (m *IngressGatewayConfig) XXX_Unmarshal(b []byte) error {
@@ -2254,6 +2477,13 @@ func (m *IngressGatewayConfig) GetExternalIPs() []string {
 	return nil
 }
 
+func (m *IngressGatewayConfig) GetHub() string {
+	if m != nil {
+		return m.Hub
+	}
+	return ""
+}
+
 func (m *IngressGatewayConfig) GetK8SIngress() *protobuf.BoolValue {
 	if m != nil {
 		return m.K8SIngress
@@ -2487,6 +2717,20 @@ func (m *IngressGatewayConfig) GetTelemetryDomainName() string {
 	return ""
 }
 
+func (m *IngressGatewayConfig) GetTag() interface{} {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+func (m *IngressGatewayConfig) GetInjectionTemplate() string {
+	if m != nil {
+		return m.InjectionTemplate
+	}
+	return ""
+}
+
 // Secret Discovery Service (SDS) Configuration for ingress gateway.
 type IngressGatewaySdsConfig struct {
 	// If true, ingress gateway fetches credentials from SDS server to handle TLS connections.
@@ -2507,7 +2751,7 @@ func (m *IngressGatewaySdsConfig) Reset()         { *m = IngressGatewaySdsConfig
 func (m *IngressGatewaySdsConfig) String() string { return proto.CompactTextString(m) }
 func (*IngressGatewaySdsConfig) ProtoMessage()    {}
 func (*IngressGatewaySdsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{17}
+	return fileDescriptor_261260e22432516f, []int{18}
 }
 
 func (m *IngressGatewaySdsConfig) XXX_Unmarshal(b []byte) error {
@@ -2564,7 +2808,7 @@ func (m *IngressGatewayZvpnConfig) Reset()         { *m = IngressGatewayZvpnConf
 func (m *IngressGatewayZvpnConfig) String() string { return proto.CompactTextString(m) }
 func (*IngressGatewayZvpnConfig) ProtoMessage()    {}
 func (*IngressGatewayZvpnConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{18}
+	return fileDescriptor_261260e22432516f, []int{19}
 }
 
 func (m *IngressGatewayZvpnConfig) XXX_Unmarshal(b []byte) error {
@@ -2614,7 +2858,7 @@ func (m *KubernetesEnvMixerAdapterConfig) Reset()         { *m = KubernetesEnvMi
 func (m *KubernetesEnvMixerAdapterConfig) String() string { return proto.CompactTextString(m) }
 func (*KubernetesEnvMixerAdapterConfig) ProtoMessage()    {}
 func (*KubernetesEnvMixerAdapterConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{19}
+	return fileDescriptor_261260e22432516f, []int{20}
 }
 
 func (m *KubernetesEnvMixerAdapterConfig) XXX_Unmarshal(b []byte) error {
@@ -2655,7 +2899,7 @@ func (m *LoadSheddingConfig) Reset()         { *m = LoadSheddingConfig{} }
 func (m *LoadSheddingConfig) String() string { return proto.CompactTextString(m) }
 func (*LoadSheddingConfig) ProtoMessage()    {}
 func (*LoadSheddingConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{20}
+	return fileDescriptor_261260e22432516f, []int{21}
 }
 
 func (m *LoadSheddingConfig) XXX_Unmarshal(b []byte) error {
@@ -2693,18 +2937,21 @@ func (m *LoadSheddingConfig) GetMode() Mode {
 // MTLS settings for the applications that Istio manages.
 type MTLSConfig struct {
 	// Enables MTLS for service to service traffic.
-	Enabled              *protobuf.BoolValue `protobuf:"bytes,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Auto                 *protobuf.BoolValue `protobuf:"bytes,2,opt,name=auto,proto3" json:"auto,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Enabled *protobuf.BoolValue `protobuf:"bytes,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Auto    *protobuf.BoolValue `protobuf:"bytes,2,opt,name=auto,proto3" json:"auto,omitempty"`
+	// mTLS mode of the mesh-wide PeerAuthentication installed by the control plane without a revision, one of STRICT,
+	// PERMISSIVE or DISABLE. No PeerAuthentication is installed if empty.
+	Mode                 string   `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *MTLSConfig) Reset()         { *m = MTLSConfig{} }
 func (m *MTLSConfig) String() string { return proto.CompactTextString(m) }
 func (*MTLSConfig) ProtoMessage()    {}
 func (*MTLSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{21}
+	return fileDescriptor_261260e22432516f, []int{22}
 }
 
 func (m *MTLSConfig) XXX_Unmarshal(b []byte) error {
@@ -2739,6 +2986,13 @@ func (m *MTLSConfig) GetAuto() *protobuf.BoolValue {
 	return nil
 }
 
+func (m *MTLSConfig) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
 // Configuration for Istio mesh expansion to bare metal.
 type MeshExpansionConfig struct {
 	// Exposes Pilot and Citadel mTLS on the ingress gateway.
@@ -2754,7 +3008,7 @@ func (m *MeshExpansionConfig) Reset()         { *m = MeshExpansionConfig{} }
 func (m *MeshExpansionConfig) String() string { return proto.CompactTextString(m) }
 func (*MeshExpansionConfig) ProtoMessage()    {}
 func (*MeshExpansionConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{22}
+	return fileDescriptor_261260e22432516f, []int{23}
 }
 
 func (m *MeshExpansionConfig) XXX_Unmarshal(b []byte) error {
@@ -2810,7 +3064,7 @@ func (m *MixerTelemetryAdaptersConfig) Reset()         { *m = MixerTelemetryAdap
 func (m *MixerTelemetryAdaptersConfig) String() string { return proto.CompactTextString(m) }
 func (*MixerTelemetryAdaptersConfig) ProtoMessage()    {}
 func (*MixerTelemetryAdaptersConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{23}
+	return fileDescriptor_261260e22432516f, []int{24}
 }
 
 func (m *MixerTelemetryAdaptersConfig) XXX_Unmarshal(b []byte) error {
@@ -2887,7 +3141,7 @@ func (m *MixerPolicyAdaptersConfig) Reset()         { *m = MixerPolicyAdaptersCo
 func (m *MixerPolicyAdaptersConfig) String() string { return proto.CompactTextString(m) }
 func (*MixerPolicyAdaptersConfig) ProtoMessage()    {}
 func (*MixerPolicyAdaptersConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{24}
+	return fileDescriptor_261260e22432516f, []int{25}
 }
 
 func (m *MixerPolicyAdaptersConfig) XXX_Unmarshal(b []byte) error {
@@ -2960,7 +3214,7 @@ func (m *MixerConfig) Reset()         { *m = MixerConfig{} }
 func (m *MixerConfig) String() string { return proto.CompactTextString(m) }
 func (*MixerConfig) ProtoMessage()    {}
 func (*MixerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{25}
+	return fileDescriptor_261260e22432516f, []int{26}
 }
 
 func (m *MixerConfig) XXX_Unmarshal(b []byte) error {
@@ -3065,7 +3319,7 @@ func (m *MixerPolicyConfig) Reset()         { *m = MixerPolicyConfig{} }
 func (m *MixerPolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*MixerPolicyConfig) ProtoMessage()    {}
 func (*MixerPolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{26}
+	return fileDescriptor_261260e22432516f, []int{27}
 }
 
 func (m *MixerPolicyConfig) XXX_Unmarshal(b []byte) error {
@@ -3303,7 +3557,7 @@ func (m *MixerTelemetryConfig) Reset()         { *m = MixerTelemetryConfig{} }
 func (m *MixerTelemetryConfig) String() string { return proto.CompactTextString(m) }
 func (*MixerTelemetryConfig) ProtoMessage()    {}
 func (*MixerTelemetryConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{27}
+	return fileDescriptor_261260e22432516f, []int{28}
 }
 
 func (m *MixerTelemetryConfig) XXX_Unmarshal(b []byte) error {
@@ -3495,6 +3749,58 @@ func (m *MixerTelemetryConfig) GetTag() interface{} {
 	return nil
 }
 
+// MultiArchConfig configures scheduling and images for clusters with both amd64 and arm64 nodes.
+type MultiArchConfig struct {
+	// Controls whether pods of all components may be scheduled on amd64 and arm64 nodes. The required node affinity
+	// of each pod allows both architectures and pods tolerate the kubernetes.io/arch=arm64:NoSchedule taint which
+	// is commonly set on arm64 node pools.
+	Enabled *protobuf.BoolValue `protobuf:"bytes,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Specifies a hub with multi-arch images for amd64 and arm64, which replaces global.hub when enabled.
+	Hub                  string   `protobuf:"bytes,2,opt,name=hub,proto3" json:"hub,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MultiArchConfig) Reset()         { *m = MultiArchConfig{} }
+func (m *MultiArchConfig) String() string { return proto.CompactTextString(m) }
+func (*MultiArchConfig) ProtoMessage()    {}
+func (*MultiArchConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_261260e22432516f, []int{29}
+}
+
+func (m *MultiArchConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MultiArchConfig.Unmarshal(m, b)
+}
+func (m *MultiArchConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MultiArchConfig.Marshal(b, m, deterministic)
+}
+func (m *MultiArchConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultiArchConfig.Merge(m, src)
+}
+func (m *MultiArchConfig) XXX_Size() int {
+	return xxx_messageInfo_MultiArchConfig.Size(m)
+}
+func (m *MultiArchConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultiArchConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MultiArchConfig proto.InternalMessageInfo
+
+func (m *MultiArchConfig) GetEnabled() *protobuf.BoolValue {
+	if m != nil {
+		return m.Enabled
+	}
+	return nil
+}
+
+func (m *MultiArchConfig) GetHub() string {
+	if m != nil {
+		return m.Hub
+	}
+	return ""
+}
+
 // MultiClusterConfig specifies the Configuration for Istio mesh across multiple clusters through the istio gateways.
 type MultiClusterConfig struct {
 	// Enables the connection between two kubernetes clusters via their respective ingressgateway services.
@@ -3510,7 +3816,7 @@ func (m *MultiClusterConfig) Reset()         { *m = MultiClusterConfig{} }
 func (m *MultiClusterConfig) String() string { return proto.CompactTextString(m) }
 func (*MultiClusterConfig) ProtoMessage()    {}
 func (*MultiClusterConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{28}
+	return fileDescriptor_261260e22432516f, []int{30}
 }
 
 func (m *MultiClusterConfig) XXX_Unmarshal(b []byte) error {
@@ -3557,7 +3863,7 @@ func (m *OutboundTrafficPolicyConfig) Reset()         { *m = OutboundTrafficPoli
 func (m *OutboundTrafficPolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*OutboundTrafficPolicyConfig) ProtoMessage()    {}
 func (*OutboundTrafficPolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{29}
+	return fileDescriptor_261260e22432516f, []int{31}
 }
 
 func (m *OutboundTrafficPolicyConfig) XXX_Unmarshal(b []byte) error {
@@ -3693,7 +3999,7 @@ func (m *PilotConfig) Reset()         { *m = PilotConfig{} }
 func (m *PilotConfig) String() string { return proto.CompactTextString(m) }
 func (*PilotConfig) ProtoMessage()    {}
 func (*PilotConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{30}
+	return fileDescriptor_261260e22432516f, []int{32}
 }
 
 func (m *PilotConfig) XXX_Unmarshal(b []byte) error {
@@ -3975,7 +4281,7 @@ func (m *PilotIngressConfig) Reset()         { *m = PilotIngressConfig{} }
 func (m *PilotIngressConfig) String() string { return proto.CompactTextString(m) }
 func (*PilotIngressConfig) ProtoMessage()    {}
 func (*PilotIngressConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{31}
+	return fileDescriptor_261260e22432516f, []int{33}
 }
 
 func (m *PilotIngressConfig) XXX_Unmarshal(b []byte) error {
@@ -4030,7 +4336,7 @@ func (m *PilotPolicyConfig) Reset()         { *m = PilotPolicyConfig{} }
 func (m *PilotPolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*PilotPolicyConfig) ProtoMessage()    {}
 func (*PilotPolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{32}
+	return fileDescriptor_261260e22432516f, []int{34}
 }
 
 func (m *PilotPolicyConfig) XXX_Unmarshal(b []byte) error {
@@ -4075,7 +4381,7 @@ func (m *TelemetryConfig) Reset()         { *m = TelemetryConfig{} }
 func (m *TelemetryConfig) String() string { return proto.CompactTextString(m) }
 func (*TelemetryConfig) ProtoMessage()    {}
 func (*TelemetryConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{33}
+	return fileDescriptor_261260e22432516f, []int{35}
 }
 
 func (m *TelemetryConfig) XXX_Unmarshal(b []byte) error {
@@ -4130,7 +4436,7 @@ func (m *TelemetryV1Config) Reset()         { *m = TelemetryV1Config{} }
 func (m *TelemetryV1Config) String() string { return proto.CompactTextString(m) }
 func (*TelemetryV1Config) ProtoMessage()    {}
 func (*TelemetryV1Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{34}
+	return fileDescriptor_261260e22432516f, []int{36}
 }
 
 func (m *TelemetryV1Config) XXX_Unmarshal(b []byte) error {
@@ -4174,7 +4480,7 @@ func (m *TelemetryV2Config) Reset()         { *m = TelemetryV2Config{} }
 func (m *TelemetryV2Config) String() string { return proto.CompactTextString(m) }
 func (*TelemetryV2Config) ProtoMessage()    {}
 func (*TelemetryV2Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{35}
+	return fileDescriptor_261260e22432516f, []int{37}
 }
 
 func (m *TelemetryV2Config) XXX_Unmarshal(b []byte) error {
@@ -4235,7 +4541,7 @@ func (m *TelemetryV2MetadataExchangeConfig) Reset()         { *m = TelemetryV2Me
 func (m *TelemetryV2MetadataExchangeConfig) String() string { return proto.CompactTextString(m) }
 func (*TelemetryV2MetadataExchangeConfig) ProtoMessage()    {}
 func (*TelemetryV2MetadataExchangeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{36}
+	return fileDescriptor_261260e22432516f, []int{38}
 }
 
 func (m *TelemetryV2MetadataExchangeConfig) XXX_Unmarshal(b []byte) error {
@@ -4278,7 +4584,7 @@ func (m *TelemetryV2PrometheusConfig) Reset()         { *m = TelemetryV2Promethe
 func (m *TelemetryV2PrometheusConfig) String() string { return proto.CompactTextString(m) }
 func (*TelemetryV2PrometheusConfig) ProtoMessage()    {}
 func (*TelemetryV2PrometheusConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{37}
+	return fileDescriptor_261260e22432516f, []int{39}
 }
 
 func (m *TelemetryV2PrometheusConfig) XXX_Unmarshal(b []byte) error {
@@ -4330,7 +4636,7 @@ func (m *TelemetryV2StackDriverConfig) Reset()         { *m = TelemetryV2StackDr
 func (m *TelemetryV2StackDriverConfig) String() string { return proto.CompactTextString(m) }
 func (*TelemetryV2StackDriverConfig) ProtoMessage()    {}
 func (*TelemetryV2StackDriverConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{38}
+	return fileDescriptor_261260e22432516f, []int{40}
 }
 
 func (m *TelemetryV2StackDriverConfig) XXX_Unmarshal(b []byte) error {
@@ -4408,7 +4714,7 @@ func (m *PilotConfigSource) Reset()         { *m = PilotConfigSource{} }
 func (m *PilotConfigSource) String() string { return proto.CompactTextString(m) }
 func (*PilotConfigSource) ProtoMessage()    {}
 func (*PilotConfigSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{39}
+	return fileDescriptor_261260e22432516f, []int{41}
 }
 
 func (m *PilotConfigSource) XXX_Unmarshal(b []byte) error {
@@ -4436,6 +4742,122 @@ func (m *PilotConfigSource) GetSubscribedResources() []string {
 	return nil
 }
 
+// PodSchedulingConfig constrains the nodes pods are scheduled to, e.g. to run Istio on a dedicated node pool.
+type PodSchedulingConfig struct {
+	// Node labels the pods must be scheduled to nodes with.
+	//
+	// See https://kubernetes.io/docs/concepts/configuration/assign-pod-node/#nodeselector
+	NodeSelector map[string]string `protobuf:"bytes,1,rep,name=nodeSelector,proto3" json:"nodeSelector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Tolerations of the pods, e.g. for the taints of a dedicated node pool.
+	Tolerations []map[string]interface{} `protobuf:"bytes,2,opt,name=tolerations,proto3" json:"tolerations,omitempty"`
+	// Affinity of the pods, which replaces the architecture affinity set by the charts.
+	Affinity             map[string]interface{} `protobuf:"bytes,3,opt,name=affinity,proto3" json:"affinity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *PodSchedulingConfig) Reset()         { *m = PodSchedulingConfig{} }
+func (m *PodSchedulingConfig) String() string { return proto.CompactTextString(m) }
+func (*PodSchedulingConfig) ProtoMessage()    {}
+func (*PodSchedulingConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_261260e22432516f, []int{42}
+}
+
+func (m *PodSchedulingConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodSchedulingConfig.Unmarshal(m, b)
+}
+func (m *PodSchedulingConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodSchedulingConfig.Marshal(b, m, deterministic)
+}
+func (m *PodSchedulingConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodSchedulingConfig.Merge(m, src)
+}
+func (m *PodSchedulingConfig) XXX_Size() int {
+	return xxx_messageInfo_PodSchedulingConfig.Size(m)
+}
+func (m *PodSchedulingConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodSchedulingConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodSchedulingConfig proto.InternalMessageInfo
+
+func (m *PodSchedulingConfig) GetNodeSelector() map[string]string {
+	if m != nil {
+		return m.NodeSelector
+	}
+	return nil
+}
+
+func (m *PodSchedulingConfig) GetTolerations() []map[string]interface{} {
+	if m != nil {
+		return m.Tolerations
+	}
+	return nil
+}
+
+func (m *PodSchedulingConfig) GetAffinity() map[string]interface{} {
+	if m != nil {
+		return m.Affinity
+	}
+	return nil
+}
+
+// Configuration for restricted pod security.
+type PodSecurityConfig struct {
+	// Controls whether all components are rendered to comply with the restricted PodSecurity profile and PSPs: pods
+	// run as non root with the runtime/default seccomp profile, privilege escalation is disallowed and all
+	// capabilities are dropped. Settings which need NET_ADMIN or privileged containers, like sidecar traffic
+	// redirection without istio-cni, are rejected.
+	Restricted *protobuf.BoolValue `protobuf:"bytes,1,opt,name=restricted,proto3" json:"restricted,omitempty"`
+	// Controls whether the components are rendered for OpenShift SCCs. Fixed user and group IDs are removed so that
+	// the SCC can assign them, and a Role and RoleBinding granting the restricted SCC to the service accounts of
+	// each component are generated.
+	Openshift            *protobuf.BoolValue `protobuf:"bytes,2,opt,name=openshift,proto3" json:"openshift,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PodSecurityConfig) Reset()         { *m = PodSecurityConfig{} }
+func (m *PodSecurityConfig) String() string { return proto.CompactTextString(m) }
+func (*PodSecurityConfig) ProtoMessage()    {}
+func (*PodSecurityConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_261260e22432516f, []int{43}
+}
+
+func (m *PodSecurityConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodSecurityConfig.Unmarshal(m, b)
+}
+func (m *PodSecurityConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodSecurityConfig.Marshal(b, m, deterministic)
+}
+func (m *PodSecurityConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodSecurityConfig.Merge(m, src)
+}
+func (m *PodSecurityConfig) XXX_Size() int {
+	return xxx_messageInfo_PodSecurityConfig.Size(m)
+}
+func (m *PodSecurityConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodSecurityConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodSecurityConfig proto.InternalMessageInfo
+
+func (m *PodSecurityConfig) GetRestricted() *protobuf.BoolValue {
+	if m != nil {
+		return m.Restricted
+	}
+	return nil
+}
+
+func (m *PodSecurityConfig) GetOpenshift() *protobuf.BoolValue {
+	if m != nil {
+		return m.Openshift
+	}
+	return nil
+}
+
 // Configuration for a port.
 type PortsConfig struct {
 	// Port name.
@@ -4455,7 +4877,7 @@ func (m *PortsConfig) Reset()         { *m = PortsConfig{} }
 func (m *PortsConfig) String() string { return proto.CompactTextString(m) }
 func (*PortsConfig) ProtoMessage()    {}
 func (*PortsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{40}
+	return fileDescriptor_261260e22432516f, []int{44}
 }
 
 func (m *PortsConfig) XXX_Unmarshal(b []byte) error {
@@ -4545,7 +4967,7 @@ func (m *PrometheusConfig) Reset()         { *m = PrometheusConfig{} }
 func (m *PrometheusConfig) String() string { return proto.CompactTextString(m) }
 func (*PrometheusConfig) ProtoMessage()    {}
 func (*PrometheusConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{41}
+	return fileDescriptor_261260e22432516f, []int{45}
 }
 
 func (m *PrometheusConfig) XXX_Unmarshal(b []byte) error {
@@ -4725,7 +5147,7 @@ func (m *PrometheusMixerAdapterConfig) Reset()         { *m = PrometheusMixerAda
 func (m *PrometheusMixerAdapterConfig) String() string { return proto.CompactTextString(m) }
 func (*PrometheusMixerAdapterConfig) ProtoMessage()    {}
 func (*PrometheusMixerAdapterConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{42}
+	return fileDescriptor_261260e22432516f, []int{46}
 }
 
 func (m *PrometheusMixerAdapterConfig) XXX_Unmarshal(b []byte) error {
@@ -4773,7 +5195,7 @@ func (m *PrometheusSecurityConfig) Reset()         { *m = PrometheusSecurityConf
 func (m *PrometheusSecurityConfig) String() string { return proto.CompactTextString(m) }
 func (*PrometheusSecurityConfig) ProtoMessage()    {}
 func (*PrometheusSecurityConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{43}
+	return fileDescriptor_261260e22432516f, []int{47}
 }
 
 func (m *PrometheusSecurityConfig) XXX_Unmarshal(b []byte) error {
@@ -4814,7 +5236,7 @@ func (m *PrometheusServiceConfig) Reset()         { *m = PrometheusServiceConfig
 func (m *PrometheusServiceConfig) String() string { return proto.CompactTextString(m) }
 func (*PrometheusServiceConfig) ProtoMessage()    {}
 func (*PrometheusServiceConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{44}
+	return fileDescriptor_261260e22432516f, []int{48}
 }
 
 func (m *PrometheusServiceConfig) XXX_Unmarshal(b []byte) error {
@@ -4863,7 +5285,7 @@ func (m *PrometheusServiceNodePortConfig) Reset()         { *m = PrometheusServi
 func (m *PrometheusServiceNodePortConfig) String() string { return proto.CompactTextString(m) }
 func (*PrometheusServiceNodePortConfig) ProtoMessage()    {}
 func (*PrometheusServiceNodePortConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{45}
+	return fileDescriptor_261260e22432516f, []int{49}
 }
 
 func (m *PrometheusServiceNodePortConfig) XXX_Unmarshal(b []byte) error {
@@ -4979,7 +5401,7 @@ func (m *ProxyConfig) Reset()         { *m = ProxyConfig{} }
 func (m *ProxyConfig) String() string { return proto.CompactTextString(m) }
 func (*ProxyConfig) ProtoMessage()    {}
 func (*ProxyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{46}
+	return fileDescriptor_261260e22432516f, []int{50}
 }
 
 func (m *ProxyConfig) XXX_Unmarshal(b []byte) error {
@@ -5241,7 +5663,7 @@ func (m *EnvoyAccessLogConfig) Reset()         { *m = EnvoyAccessLogConfig{} }
 func (m *EnvoyAccessLogConfig) String() string { return proto.CompactTextString(m) }
 func (*EnvoyAccessLogConfig) ProtoMessage()    {}
 func (*EnvoyAccessLogConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{47}
+	return fileDescriptor_261260e22432516f, []int{51}
 }
 
 func (m *EnvoyAccessLogConfig) XXX_Unmarshal(b []byte) error {
@@ -5313,7 +5735,7 @@ func (m *EnvoyAccessLogtlsSettings) Reset()         { *m = EnvoyAccessLogtlsSett
 func (m *EnvoyAccessLogtlsSettings) String() string { return proto.CompactTextString(m) }
 func (*EnvoyAccessLogtlsSettings) ProtoMessage()    {}
 func (*EnvoyAccessLogtlsSettings) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{48}
+	return fileDescriptor_261260e22432516f, []int{52}
 }
 
 func (m *EnvoyAccessLogtlsSettings) XXX_Unmarshal(b []byte) error {
@@ -5393,7 +5815,7 @@ func (m *ProxyInitConfig) Reset()         { *m = ProxyInitConfig{} }
 func (m *ProxyInitConfig) String() string { return proto.CompactTextString(m) }
 func (*ProxyInitConfig) ProtoMessage()    {}
 func (*ProxyInitConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{49}
+	return fileDescriptor_261260e22432516f, []int{53}
 }
 
 func (m *ProxyInitConfig) XXX_Unmarshal(b []byte) error {
@@ -5442,7 +5864,7 @@ func (m *ResourcesRequestsConfig) Reset()         { *m = ResourcesRequestsConfig
 func (m *ResourcesRequestsConfig) String() string { return proto.CompactTextString(m) }
 func (*ResourcesRequestsConfig) ProtoMessage()    {}
 func (*ResourcesRequestsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{50}
+	return fileDescriptor_261260e22432516f, []int{54}
 }
 
 func (m *ResourcesRequestsConfig) XXX_Unmarshal(b []byte) error {
@@ -5497,7 +5919,7 @@ func (m *SDSConfig) Reset()         { *m = SDSConfig{} }
 func (m *SDSConfig) String() string { return proto.CompactTextString(m) }
 func (*SDSConfig) ProtoMessage()    {}
 func (*SDSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{51}
+	return fileDescriptor_261260e22432516f, []int{55}
 }
 
 func (m *SDSConfig) XXX_Unmarshal(b []byte) error {
@@ -5574,7 +5996,7 @@ func (m *SecretVolume) Reset()         { *m = SecretVolume{} }
 func (m *SecretVolume) String() string { return proto.CompactTextString(m) }
 func (*SecretVolume) ProtoMessage()    {}
 func (*SecretVolume) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{52}
+	return fileDescriptor_261260e22432516f, []int{56}
 }
 
 func (m *SecretVolume) XXX_Unmarshal(b []byte) error {
@@ -5631,7 +6053,7 @@ func (m *ServiceConfig) Reset()         { *m = ServiceConfig{} }
 func (m *ServiceConfig) String() string { return proto.CompactTextString(m) }
 func (*ServiceConfig) ProtoMessage()    {}
 func (*ServiceConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{53}
+	return fileDescriptor_261260e22432516f, []int{57}
 }
 
 func (m *ServiceConfig) XXX_Unmarshal(b []byte) error {
@@ -5699,7 +6121,10 @@ type SidecarInjectorConfig struct {
 	// This is primarily to support PSP annotations.
 	InjectedAnnotations map[string]interface{} `protobuf:"bytes,19,opt,name=injectedAnnotations,proto3" json:"injectedAnnotations,omitempty"`
 	// Enable objectSelector to filter out pods with no need for sidecar before calling istio-sidecar-injector.
-	ObjectSelector       map[string]interface{} `protobuf:"bytes,21,opt,name=objectSelector,proto3" json:"objectSelector,omitempty"`
+	ObjectSelector map[string]interface{} `protobuf:"bytes,21,opt,name=objectSelector,proto3" json:"objectSelector,omitempty"`
+	// Named custom injection templates, added to the built-in templates of the injector. Pods select a template with
+	// the inject.istio.io/templates annotation.
+	Templates            map[string]interface{} `protobuf:"bytes,22,opt,name=templates,proto3" json:"templates,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
 	XXX_sizecache        int32                   `json:"-"`
@@ -5709,7 +6134,7 @@ func (m *SidecarInjectorConfig) Reset()         { *m = SidecarInjectorConfig{} }
 func (m *SidecarInjectorConfig) String() string { return proto.CompactTextString(m) }
 func (*SidecarInjectorConfig) ProtoMessage()    {}
 func (*SidecarInjectorConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{54}
+	return fileDescriptor_261260e22432516f, []int{58}
 }
 
 func (m *SidecarInjectorConfig) XXX_Unmarshal(b []byte) error {
@@ -5779,6 +6204,13 @@ func (m *SidecarInjectorConfig) GetObjectSelector() map[string]interface{} {
 	return nil
 }
 
+func (m *SidecarInjectorConfig) GetTemplates() map[string]interface{} {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
 // Configuration for stdio adapter in mixer, recommended for debug usage only.
 type StdioMixerAdapterConfig struct {
 	// Enable stdio adapter to output logs and metrics to local machine.
@@ -5794,7 +6226,7 @@ func (m *StdioMixerAdapterConfig) Reset()         { *m = StdioMixerAdapterConfig
 func (m *StdioMixerAdapterConfig) String() string { return proto.CompactTextString(m) }
 func (*StdioMixerAdapterConfig) ProtoMessage()    {}
 func (*StdioMixerAdapterConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{55}
+	return fileDescriptor_261260e22432516f, []int{59}
 }
 
 func (m *StdioMixerAdapterConfig) XXX_Unmarshal(b []byte) error {
@@ -5846,7 +6278,7 @@ func (m *StackdriverMixerAdapterConfig) Reset()         { *m = StackdriverMixerA
 func (m *StackdriverMixerAdapterConfig) String() string { return proto.CompactTextString(m) }
 func (*StackdriverMixerAdapterConfig) ProtoMessage()    {}
 func (*StackdriverMixerAdapterConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{56}
+	return fileDescriptor_261260e22432516f, []int{60}
 }
 
 func (m *StackdriverMixerAdapterConfig) XXX_Unmarshal(b []byte) error {
@@ -5924,7 +6356,7 @@ func (m *StackdriverMixerAdapterConfig_EnabledConfig) String() string {
 }
 func (*StackdriverMixerAdapterConfig_EnabledConfig) ProtoMessage() {}
 func (*StackdriverMixerAdapterConfig_EnabledConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{56, 0}
+	return fileDescriptor_261260e22432516f, []int{60, 0}
 }
 
 func (m *StackdriverMixerAdapterConfig_EnabledConfig) XXX_Unmarshal(b []byte) error {
@@ -5965,7 +6397,7 @@ func (m *StackdriverAuthConfig) Reset()         { *m = StackdriverAuthConfig{} }
 func (m *StackdriverAuthConfig) String() string { return proto.CompactTextString(m) }
 func (*StackdriverAuthConfig) ProtoMessage()    {}
 func (*StackdriverAuthConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{57}
+	return fileDescriptor_261260e22432516f, []int{61}
 }
 
 func (m *StackdriverAuthConfig) XXX_Unmarshal(b []byte) error {
@@ -6019,7 +6451,7 @@ func (m *StackdriverTracerConfig) Reset()         { *m = StackdriverTracerConfig
 func (m *StackdriverTracerConfig) String() string { return proto.CompactTextString(m) }
 func (*StackdriverTracerConfig) ProtoMessage()    {}
 func (*StackdriverTracerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{58}
+	return fileDescriptor_261260e22432516f, []int{62}
 }
 
 func (m *StackdriverTracerConfig) XXX_Unmarshal(b []byte) error {
@@ -6065,7 +6497,7 @@ func (m *StackdriverContextGraph) Reset()         { *m = StackdriverContextGraph
 func (m *StackdriverContextGraph) String() string { return proto.CompactTextString(m) }
 func (*StackdriverContextGraph) ProtoMessage()    {}
 func (*StackdriverContextGraph) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{59}
+	return fileDescriptor_261260e22432516f, []int{63}
 }
 
 func (m *StackdriverContextGraph) XXX_Unmarshal(b []byte) error {
@@ -6112,7 +6544,7 @@ func (m *TracerConfig) Reset()         { *m = TracerConfig{} }
 func (m *TracerConfig) String() string { return proto.CompactTextString(m) }
 func (*TracerConfig) ProtoMessage()    {}
 func (*TracerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{60}
+	return fileDescriptor_261260e22432516f, []int{64}
 }
 
 func (m *TracerConfig) XXX_Unmarshal(b []byte) error {
@@ -6174,7 +6606,7 @@ func (m *TracerDatadogConfig) Reset()         { *m = TracerDatadogConfig{} }
 func (m *TracerDatadogConfig) String() string { return proto.CompactTextString(m) }
 func (*TracerDatadogConfig) ProtoMessage()    {}
 func (*TracerDatadogConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{61}
+	return fileDescriptor_261260e22432516f, []int{65}
 }
 
 func (m *TracerDatadogConfig) XXX_Unmarshal(b []byte) error {
@@ -6221,7 +6653,7 @@ func (m *TracerLightStepConfig) Reset()         { *m = TracerLightStepConfig{} }
 func (m *TracerLightStepConfig) String() string { return proto.CompactTextString(m) }
 func (*TracerLightStepConfig) ProtoMessage()    {}
 func (*TracerLightStepConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{62}
+	return fileDescriptor_261260e22432516f, []int{66}
 }
 
 func (m *TracerLightStepConfig) XXX_Unmarshal(b []byte) error {
@@ -6285,7 +6717,7 @@ func (m *TracerZipkinConfig) Reset()         { *m = TracerZipkinConfig{} }
 func (m *TracerZipkinConfig) String() string { return proto.CompactTextString(m) }
 func (*TracerZipkinConfig) ProtoMessage()    {}
 func (*TracerZipkinConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{63}
+	return fileDescriptor_261260e22432516f, []int{67}
 }
 
 func (m *TracerZipkinConfig) XXX_Unmarshal(b []byte) error {
@@ -6332,7 +6764,7 @@ func (m *TracerStackdriverConfig) Reset()         { *m = TracerStackdriverConfig
 func (m *TracerStackdriverConfig) String() string { return proto.CompactTextString(m) }
 func (*TracerStackdriverConfig) ProtoMessage()    {}
 func (*TracerStackdriverConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{64}
+	return fileDescriptor_261260e22432516f, []int{68}
 }
 
 func (m *TracerStackdriverConfig) XXX_Unmarshal(b []byte) error {
@@ -6413,7 +6845,7 @@ func (m *TracingConfig) Reset()         { *m = TracingConfig{} }
 func (m *TracingConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingConfig) ProtoMessage()    {}
 func (*TracingConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{65}
+	return fileDescriptor_261260e22432516f, []int{69}
 }
 
 func (m *TracingConfig) XXX_Unmarshal(b []byte) error {
@@ -6538,7 +6970,7 @@ func (m *TracingOpencensusConfig) Reset()         { *m = TracingOpencensusConfig
 func (m *TracingOpencensusConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingOpencensusConfig) ProtoMessage()    {}
 func (*TracingOpencensusConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{66}
+	return fileDescriptor_261260e22432516f, []int{70}
 }
 
 func (m *TracingOpencensusConfig) XXX_Unmarshal(b []byte) error {
@@ -6607,7 +7039,7 @@ func (m *TracingOpencensusExportersConfig) Reset()         { *m = TracingOpencen
 func (m *TracingOpencensusExportersConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingOpencensusExportersConfig) ProtoMessage()    {}
 func (*TracingOpencensusExportersConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{67}
+	return fileDescriptor_261260e22432516f, []int{71}
 }
 
 func (m *TracingOpencensusExportersConfig) XXX_Unmarshal(b []byte) error {
@@ -6665,7 +7097,7 @@ func (m *TracingJaegerConfig) Reset()         { *m = TracingJaegerConfig{} }
 func (m *TracingJaegerConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingJaegerConfig) ProtoMessage()    {}
 func (*TracingJaegerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{68}
+	return fileDescriptor_261260e22432516f, []int{72}
 }
 
 func (m *TracingJaegerConfig) XXX_Unmarshal(b []byte) error {
@@ -6771,7 +7203,7 @@ func (m *TracingJaegerMemoryConfig) Reset()         { *m = TracingJaegerMemoryCo
 func (m *TracingJaegerMemoryConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingJaegerMemoryConfig) ProtoMessage()    {}
 func (*TracingJaegerMemoryConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{69}
+	return fileDescriptor_261260e22432516f, []int{73}
 }
 
 func (m *TracingJaegerMemoryConfig) XXX_Unmarshal(b []byte) error {
@@ -6837,7 +7269,7 @@ func (m *TracingZipkinConfig) Reset()         { *m = TracingZipkinConfig{} }
 func (m *TracingZipkinConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingZipkinConfig) ProtoMessage()    {}
 func (*TracingZipkinConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{70}
+	return fileDescriptor_261260e22432516f, []int{74}
 }
 
 func (m *TracingZipkinConfig) XXX_Unmarshal(b []byte) error {
@@ -6950,7 +7382,7 @@ func (m *TracingZipkinNodeConfig) Reset()         { *m = TracingZipkinNodeConfig
 func (m *TracingZipkinNodeConfig) String() string { return proto.CompactTextString(m) }
 func (*TracingZipkinNodeConfig) ProtoMessage()    {}
 func (*TracingZipkinNodeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{71}
+	return fileDescriptor_261260e22432516f, []int{75}
 }
 
 func (m *TracingZipkinNodeConfig) XXX_Unmarshal(b []byte) error {
@@ -6991,7 +7423,7 @@ func (m *KialiSecurityConfig) Reset()         { *m = KialiSecurityConfig{} }
 func (m *KialiSecurityConfig) String() string { return proto.CompactTextString(m) }
 func (*KialiSecurityConfig) ProtoMessage()    {}
 func (*KialiSecurityConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{72}
+	return fileDescriptor_261260e22432516f, []int{76}
 }
 
 func (m *KialiSecurityConfig) XXX_Unmarshal(b []byte) error {
@@ -7048,7 +7480,7 @@ func (m *KialiServiceConfig) Reset()         { *m = KialiServiceConfig{} }
 func (m *KialiServiceConfig) String() string { return proto.CompactTextString(m) }
 func (*KialiServiceConfig) ProtoMessage()    {}
 func (*KialiServiceConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{73}
+	return fileDescriptor_261260e22432516f, []int{77}
 }
 
 func (m *KialiServiceConfig) XXX_Unmarshal(b []byte) error {
@@ -7102,7 +7534,7 @@ func (m *KialiDashboardConfig) Reset()         { *m = KialiDashboardConfig{} }
 func (m *KialiDashboardConfig) String() string { return proto.CompactTextString(m) }
 func (*KialiDashboardConfig) ProtoMessage()    {}
 func (*KialiDashboardConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{74}
+	return fileDescriptor_261260e22432516f, []int{78}
 }
 
 func (m *KialiDashboardConfig) XXX_Unmarshal(b []byte) error {
@@ -7250,7 +7682,7 @@ func (m *KialiConfig) Reset()         { *m = KialiConfig{} }
 func (m *KialiConfig) String() string { return proto.CompactTextString(m) }
 func (*KialiConfig) ProtoMessage()    {}
 func (*KialiConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{75}
+	return fileDescriptor_261260e22432516f, []int{79}
 }
 
 func (m *KialiConfig) XXX_Unmarshal(b []byte) error {
@@ -7439,7 +7871,7 @@ func (m *Values) Reset()         { *m = Values{} }
 func (m *Values) String() string { return proto.CompactTextString(m) }
 func (*Values) ProtoMessage()    {}
 func (*Values) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{76}
+	return fileDescriptor_261260e22432516f, []int{80}
 }
 
 func (m *Values) XXX_Unmarshal(b []byte) error {
@@ -7610,7 +8042,7 @@ func (m *ZeroVPNConfig) Reset()         { *m = ZeroVPNConfig{} }
 func (m *ZeroVPNConfig) String() string { return proto.CompactTextString(m) }
 func (*ZeroVPNConfig) ProtoMessage()    {}
 func (*ZeroVPNConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_261260e22432516f, []int{80}
+	return fileDescriptor_261260e22432516f, []int{84}
 }
 
 func (m *ZeroVPNConfig) XXX_Unmarshal(b []byte) error {
@@ -7663,10 +8095,13 @@ func init() {
 	proto.RegisterType((*DefaultPodDisruptionBudgetConfig)(nil), "v1alpha1.DefaultPodDisruptionBudgetConfig")
 	proto.RegisterType((*DefaultResourcesConfig)(nil), "v1alpha1.DefaultResourcesConfig")
 	proto.RegisterType((*EgressGatewayConfig)(nil), "v1alpha1.EgressGatewayConfig")
+	proto.RegisterType((*EgressLockdownConfig)(nil), "v1alpha1.EgressLockdownConfig")
 	proto.RegisterType((*EnvoyMetricsConfig)(nil), "v1alpha1.EnvoyMetricsConfig")
 	proto.RegisterType((*GatewayLabelsConfig)(nil), "v1alpha1.GatewayLabelsConfig")
 	proto.RegisterType((*GatewaysConfig)(nil), "v1alpha1.GatewaysConfig")
 	proto.RegisterType((*GlobalConfig)(nil), "v1alpha1.GlobalConfig")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha1.GlobalConfig.ResourceAnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha1.GlobalConfig.ResourceLabelsEntry")
 	proto.RegisterType((*STSConfig)(nil), "v1alpha1.STSConfig")
 	proto.RegisterType((*IstiodConfig)(nil), "v1alpha1.IstiodConfig")
 	proto.RegisterType((*GlobalLoggingConfig)(nil), "v1alpha1.GlobalLoggingConfig")
@@ -7682,6 +8117,7 @@ func init() {
 	proto.RegisterType((*MixerConfig)(nil), "v1alpha1.MixerConfig")
 	proto.RegisterType((*MixerPolicyConfig)(nil), "v1alpha1.MixerPolicyConfig")
 	proto.RegisterType((*MixerTelemetryConfig)(nil), "v1alpha1.MixerTelemetryConfig")
+	proto.RegisterType((*MultiArchConfig)(nil), "v1alpha1.MultiArchConfig")
 	proto.RegisterType((*MultiClusterConfig)(nil), "v1alpha1.MultiClusterConfig")
 	proto.RegisterType((*OutboundTrafficPolicyConfig)(nil), "v1alpha1.OutboundTrafficPolicyConfig")
 	proto.RegisterType((*PilotConfig)(nil), "v1alpha1.PilotConfig")
@@ -7694,6 +8130,9 @@ func init() {
 	proto.RegisterType((*TelemetryV2PrometheusConfig)(nil), "v1alpha1.TelemetryV2PrometheusConfig")
 	proto.RegisterType((*TelemetryV2StackDriverConfig)(nil), "v1alpha1.TelemetryV2StackDriverConfig")
 	proto.RegisterType((*PilotConfigSource)(nil), "v1alpha1.PilotConfigSource")
+	proto.RegisterType((*PodSchedulingConfig)(nil), "v1alpha1.PodSchedulingConfig")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha1.PodSchedulingConfig.NodeSelectorEntry")
+	proto.RegisterType((*PodSecurityConfig)(nil), "v1alpha1.PodSecurityConfig")
 	proto.RegisterType((*PortsConfig)(nil), "v1alpha1.PortsConfig")
 	proto.RegisterType((*PrometheusConfig)(nil), "v1alpha1.PrometheusConfig")
 	proto.RegisterType((*PrometheusMixerAdapterConfig)(nil), "v1alpha1.PrometheusMixerAdapterConfig")
@@ -7740,460 +8179,487 @@ func init() {
 }
 
 var fileDescriptor_261260e22432516f = []byte{
-	// 7266 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x7d, 0x49, 0x6f, 0x1c, 0x49,
-	0x76, 0x70, 0x17, 0xf7, 0x7a, 0x55, 0x45, 0x16, 0x83, 0x8b, 0x52, 0x14, 0xb5, 0x65, 0x6f, 0x1a,
-	0x4a, 0x43, 0x49, 0x6c, 0xb5, 0xa4, 0x56, 0xab, 0x35, 0xcd, 0x4d, 0x2d, 0x76, 0x73, 0x9b, 0x2a,
-	0xf6, 0x3a, 0xdf, 0x37, 0xfa, 0x82, 0x99, 0xc1, 0x62, 0x36, 0xb3, 0x32, 0x73, 0x32, 0xa2, 0x28,
-	0xb2, 0x81, 0x0f, 0xc6, 0x9c, 0x0c, 0xc3, 0xc6, 0x18, 0x63, 0x18, 0xf0, 0xc5, 0x80, 0x61, 0xd8,
-	0xc6, 0x9c, 0x6d, 0x18, 0x98, 0x1f, 0x60, 0x03, 0xbe, 0xf8, 0x0f, 0xf8, 0x38, 0xf0, 0xc9, 0x3e,
-	0xf8, 0xe4, 0xb9, 0xd8, 0x03, 0xd8, 0x88, 0x25, 0xf7, 0xac, 0x85, 0x45, 0x69, 0x7a, 0x80, 0x99,
-	0x5b, 0xe5, 0x8b, 0xf7, 0x22, 0x23, 0x23, 0x5e, 0xbc, 0x35, 0x5e, 0x14, 0x2c, 0x78, 0x47, 0x8d,
-	0xdb, 0xd8, 0xb3, 0xe8, 0x6d, 0x8b, 0x32, 0xcb, 0xbd, 0x7d, 0x7c, 0x17, 0xdb, 0xde, 0x21, 0xbe,
-	0x7b, 0xfb, 0x18, 0xdb, 0x2d, 0x42, 0x9f, 0xb3, 0x53, 0x8f, 0xd0, 0x45, 0xcf, 0x77, 0x99, 0x8b,
-	0xc6, 0x82, 0xc6, 0xb9, 0x2b, 0x0d, 0xd7, 0x6d, 0xd8, 0xe4, 0xb6, 0x80, 0xef, 0xb7, 0x0e, 0x6e,
-	0x9b, 0x2d, 0x1f, 0x33, 0xcb, 0x75, 0x24, 0xe6, 0xdc, 0x87, 0x0d, 0x8b, 0x1d, 0xb6, 0xf6, 0x17,
-	0x0d, 0xb7, 0x79, 0xbb, 0xe1, 0x36, 0xdc, 0x08, 0x31, 0xfc, 0x91, 0xee, 0xe1, 0x85, 0x8f, 0x3d,
-	0x8f, 0xf8, 0xea, 0x5d, 0x7a, 0x0d, 0x60, 0xd9, 0x37, 0x0e, 0x57, 0x5d, 0xe7, 0xc0, 0x6a, 0xa0,
-	0x69, 0x18, 0xc6, 0x4d, 0xf3, 0xfe, 0x3d, 0xad, 0x70, 0xad, 0x70, 0xa3, 0x52, 0x93, 0x0f, 0x48,
-	0x83, 0x51, 0xcf, 0x33, 0xee, 0xdf, 0xb3, 0x89, 0x36, 0x20, 0xe0, 0xc1, 0x23, 0xc7, 0xa7, 0xef,
-	0xbc, 0x77, 0xe7, 0x44, 0x1b, 0x94, 0xf8, 0xe2, 0x41, 0xff, 0xa7, 0x21, 0x28, 0xae, 0x6e, 0x6f,
-	0xa8, 0x3e, 0xef, 0xc1, 0x28, 0x71, 0xf0, 0xbe, 0x4d, 0x4c, 0xd1, 0x6b, 0x69, 0x69, 0x6e, 0x51,
-	0x8e, 0x69, 0x31, 0x18, 0xd3, 0xe2, 0x8a, 0xeb, 0xda, 0x9f, 0xf1, 0x79, 0xa8, 0x05, 0xa8, 0xa8,
-	0x0a, 0x83, 0x87, 0xad, 0x7d, 0xf1, 0xbe, 0x62, 0x8d, 0xff, 0x44, 0xdf, 0x81, 0x41, 0x86, 0x1b,
-	0xe2, 0x4d, 0xa5, 0xa5, 0x0b, 0x8b, 0xc1, 0x1c, 0x2d, 0xee, 0x9d, 0x7a, 0x64, 0xc3, 0x61, 0xc4,
-	0x3f, 0xc0, 0x06, 0xa9, 0x71, 0x1c, 0x3e, 0x2c, 0xab, 0x89, 0x1b, 0x44, 0x1b, 0x12, 0xe4, 0xf2,
-	0x01, 0x5d, 0x01, 0xf0, 0x5a, 0xb6, 0xbd, 0xeb, 0xda, 0x96, 0x71, 0xaa, 0x0d, 0x8b, 0xa6, 0x18,
-	0x04, 0xcd, 0x43, 0xd1, 0x70, 0xac, 0x15, 0xcb, 0x59, 0xb3, 0x7c, 0x6d, 0x44, 0x34, 0x47, 0x00,
-	0x4e, 0x6d, 0x38, 0x16, 0xff, 0x26, 0xde, 0x3c, 0x2a, 0xa9, 0x23, 0x08, 0xba, 0x01, 0x13, 0xea,
-	0xe9, 0xa9, 0x65, 0x93, 0x6d, 0xdc, 0x24, 0xda, 0x98, 0x40, 0x4a, 0x83, 0xd1, 0x2d, 0x98, 0x24,
-	0x27, 0x86, 0xdd, 0x32, 0xc5, 0x23, 0xf5, 0xb0, 0x41, 0xa8, 0x56, 0xbc, 0x36, 0x78, 0xa3, 0x58,
-	0xcb, 0x36, 0xa0, 0x4d, 0x18, 0xf7, 0x5c, 0x73, 0xd9, 0x71, 0x5c, 0x26, 0x56, 0x9e, 0x6a, 0x20,
-	0x66, 0xe0, 0x5a, 0x72, 0x06, 0xb6, 0xb0, 0x57, 0x67, 0xbe, 0xe5, 0x34, 0xc2, 0xa9, 0x58, 0x19,
-	0xd0, 0x0a, 0xb5, 0x14, 0x2d, 0xba, 0x01, 0x55, 0x8f, 0x7a, 0xcf, 0x0d, 0xbb, 0x45, 0x19, 0xf1,
-	0x9f, 0xfb, 0xae, 0x4d, 0xb4, 0x92, 0x18, 0xe6, 0xb8, 0x47, 0xbd, 0x55, 0x09, 0xae, 0xb9, 0x36,
-	0x41, 0x73, 0x30, 0x66, 0xbb, 0x8d, 0x4d, 0x72, 0x4c, 0x6c, 0xad, 0x2c, 0x30, 0xc2, 0x67, 0x74,
-	0x17, 0x46, 0x7c, 0xe2, 0x61, 0xcb, 0xd7, 0x2a, 0x62, 0x2c, 0x17, 0xa3, 0xb1, 0xac, 0x6e, 0x6f,
-	0xd4, 0x44, 0x93, 0x5c, 0xfd, 0x9a, 0x42, 0xe4, 0x5c, 0x60, 0x1c, 0x62, 0xcb, 0x21, 0xa6, 0x36,
-	0xde, 0x9d, 0x0b, 0x14, 0xaa, 0xfe, 0x93, 0x41, 0x98, 0x48, 0xf5, 0xf8, 0x9b, 0xc3, 0x4f, 0xf3,
-	0x50, 0xb4, 0xf1, 0x3e, 0xb1, 0x77, 0x5d, 0x93, 0x0a, 0x76, 0x1a, 0xab, 0x45, 0x00, 0xf4, 0x16,
-	0x94, 0x0d, 0x9f, 0x60, 0x46, 0xd6, 0x8f, 0x89, 0xc3, 0xa8, 0x64, 0x28, 0xb1, 0x26, 0x09, 0x38,
-	0xe7, 0x2b, 0x93, 0xd8, 0x84, 0x11, 0xd1, 0xcd, 0xa8, 0xe8, 0x26, 0x06, 0xe1, 0xdc, 0xb2, 0xef,
-	0xbb, 0x47, 0xc4, 0xd9, 0x75, 0xcd, 0x4d, 0xde, 0xfb, 0x27, 0xe4, 0x54, 0x71, 0x56, 0xb6, 0x01,
-	0xdd, 0x81, 0xa9, 0x24, 0x50, 0x4c, 0x83, 0x56, 0x14, 0xf8, 0x79, 0x4d, 0xbc, 0x7f, 0xcb, 0xb1,
-	0xd8, 0xaa, 0xeb, 0x30, 0x3e, 0xe7, 0xbe, 0xe0, 0x5c, 0x90, 0xfd, 0x67, 0x1a, 0xf4, 0x2f, 0x60,
-	0x6e, 0x75, 0xf7, 0xd3, 0x3d, 0xec, 0x37, 0x08, 0xfb, 0x94, 0x59, 0xb6, 0xf5, 0x8d, 0x60, 0x2c,
-	0xb5, 0x34, 0x8f, 0x40, 0x63, 0xa2, 0x69, 0xf9, 0x98, 0xf8, 0xb8, 0x41, 0x62, 0x18, 0x62, 0xad,
-	0x86, 0x6b, 0x6d, 0xdb, 0xf5, 0xff, 0x2e, 0x40, 0xb1, 0x46, 0xa8, 0xdb, 0xf2, 0x39, 0xd7, 0x3f,
-	0x80, 0x11, 0xdb, 0x6a, 0x5a, 0x8c, 0x6a, 0x85, 0x6b, 0x83, 0x37, 0x4a, 0x4b, 0x57, 0xa3, 0xf5,
-	0x09, 0x91, 0x16, 0x37, 0x05, 0xc6, 0xba, 0xc3, 0xfc, 0xd3, 0x9a, 0x42, 0x47, 0x1f, 0xc0, 0x98,
-	0x4f, 0x7e, 0xd4, 0x22, 0x94, 0x51, 0x6d, 0x40, 0x90, 0x5e, 0xcf, 0x23, 0xad, 0x29, 0x1c, 0x49,
-	0x1c, 0x92, 0xcc, 0xbd, 0x07, 0xa5, 0x58, 0xaf, 0x9c, 0x6b, 0x8e, 0xc8, 0xa9, 0x18, 0x7b, 0xb1,
-	0xc6, 0x7f, 0x72, 0x56, 0x10, 0x12, 0x5b, 0x71, 0x92, 0x7c, 0x78, 0x34, 0xf0, 0xb0, 0x30, 0xf7,
-	0x3e, 0x54, 0x12, 0xbd, 0x9e, 0x85, 0x58, 0xff, 0xe9, 0x28, 0x54, 0x56, 0x5d, 0x9f, 0xac, 0x6d,
-	0xd7, 0xcf, 0xc5, 0xe6, 0x3a, 0x94, 0x0d, 0xd9, 0xcd, 0x86, 0x60, 0x58, 0xf9, 0xa2, 0x04, 0x4c,
-	0x48, 0x32, 0xf9, 0xbc, 0xa7, 0xf8, 0x9f, 0x4b, 0xb2, 0x10, 0x82, 0x16, 0x01, 0xa9, 0xa7, 0x5d,
-	0xbb, 0xd5, 0xb0, 0x9c, 0x8d, 0x18, 0xeb, 0xe7, 0xb4, 0xa0, 0x67, 0x50, 0x76, 0x5c, 0x93, 0xd4,
-	0x89, 0x4d, 0x0c, 0xe6, 0xfa, 0x62, 0x2b, 0xf4, 0x2a, 0x9f, 0x12, 0x94, 0x7c, 0xcf, 0xf8, 0xc4,
-	0xb3, 0x2d, 0x03, 0xaf, 0xba, 0x2d, 0x87, 0x89, 0x3d, 0x53, 0x91, 0x78, 0x71, 0x78, 0x8e, 0x4c,
-	0x1c, 0x3d, 0x87, 0x4c, 0x7c, 0x17, 0x8a, 0x7e, 0xc0, 0x18, 0x62, 0x67, 0x95, 0x96, 0xa6, 0x72,
-	0x78, 0x46, 0xd0, 0x46, 0x98, 0x68, 0x13, 0x26, 0x7c, 0xd7, 0xb6, 0x2d, 0xa7, 0xb1, 0x85, 0x4f,
-	0xea, 0x2d, 0xbf, 0x21, 0xb7, 0x59, 0x69, 0xe9, 0x4a, 0x46, 0x96, 0xec, 0xf8, 0x72, 0x1c, 0x4f,
-	0x5d, 0x7f, 0x77, 0x45, 0xf4, 0x93, 0x26, 0x45, 0x5f, 0xc0, 0x4c, 0x04, 0xfa, 0xd4, 0xc1, 0xc7,
-	0xd8, 0xb2, 0xf9, 0x92, 0x2a, 0x69, 0xdf, 0x4b, 0x9f, 0xf9, 0x1d, 0x20, 0x17, 0xe6, 0xc5, 0x07,
-	0x33, 0x6b, 0xf9, 0xe0, 0x80, 0xef, 0xe8, 0x53, 0xb1, 0xfb, 0xc3, 0xe5, 0x2a, 0x89, 0x17, 0xbc,
-	0x9d, 0x7c, 0x41, 0xdd, 0xb6, 0x0c, 0xb2, 0x73, 0xd0, 0x66, 0x06, 0x3b, 0x76, 0x88, 0x5e, 0xc0,
-	0xb5, 0x54, 0xfb, 0x1e, 0xf1, 0x9b, 0xc9, 0x97, 0x96, 0xcf, 0xfe, 0xd2, 0xae, 0x9d, 0xa2, 0x2d,
-	0x28, 0x31, 0xd7, 0x26, 0xbe, 0xe2, 0x89, 0xca, 0xd9, 0xdf, 0x11, 0xa7, 0xd7, 0xbf, 0x80, 0x6b,
-	0x6b, 0xe4, 0x00, 0xb7, 0x6c, 0xb6, 0xeb, 0x9a, 0x6b, 0x16, 0xf5, 0x5b, 0x1e, 0x6f, 0x58, 0x69,
-	0x99, 0x0d, 0xc2, 0xce, 0xb3, 0x4b, 0xf5, 0xcf, 0x61, 0x56, 0xf5, 0x1c, 0x72, 0x97, 0xea, 0x2f,
-	0x2e, 0xbe, 0x64, 0x87, 0x79, 0xe2, 0x2b, 0x90, 0x33, 0x4a, 0xc7, 0x86, 0x24, 0xfa, 0xbf, 0x94,
-	0x61, 0x6a, 0xbd, 0xe1, 0x13, 0x4a, 0x3f, 0xc2, 0x8c, 0xbc, 0xc0, 0xa7, 0xaa, 0xdb, 0xa7, 0x50,
-	0xc5, 0x2d, 0xe6, 0x52, 0x03, 0xdb, 0x64, 0xbd, 0xe7, 0xf1, 0x66, 0x68, 0xb8, 0x78, 0x09, 0x61,
-	0x5b, 0xf8, 0x44, 0x99, 0x83, 0x09, 0x58, 0x12, 0xc7, 0x72, 0x94, 0x69, 0x98, 0x80, 0xa1, 0xb7,
-	0x60, 0xdc, 0x70, 0x1d, 0x87, 0x18, 0x6c, 0xcf, 0x6a, 0x12, 0xb7, 0xc5, 0x94, 0x78, 0x49, 0x41,
-	0xd1, 0x23, 0x18, 0x34, 0xbc, 0x96, 0x92, 0x28, 0x6f, 0xc4, 0xac, 0x8c, 0xb6, 0x3a, 0x48, 0x2c,
-	0x23, 0x27, 0x42, 0xdf, 0x83, 0x8a, 0xe9, 0x63, 0xcb, 0x59, 0x53, 0x26, 0xb3, 0x90, 0x26, 0xdc,
-	0x56, 0x49, 0x7f, 0x70, 0x80, 0x50, 0x4b, 0xe2, 0xc7, 0xd7, 0x76, 0xb4, 0x77, 0x09, 0xbc, 0x04,
-	0x83, 0xc4, 0x39, 0x56, 0x72, 0xa4, 0xab, 0x40, 0xaa, 0x71, 0x64, 0xf4, 0x2e, 0x8c, 0x08, 0xc3,
-	0x81, 0x2a, 0x09, 0x72, 0x39, 0x22, 0x53, 0xeb, 0x28, 0x18, 0x3d, 0x58, 0x6f, 0x85, 0x8c, 0x10,
-	0x0c, 0x39, 0x5c, 0x5b, 0x5f, 0x14, 0x73, 0x27, 0x7e, 0x67, 0x84, 0x31, 0xf4, 0x2d, 0x8c, 0xb3,
-	0x42, 0xb6, 0x74, 0x0e, 0x21, 0xdb, 0x4d, 0x0a, 0x95, 0xbf, 0x0d, 0x29, 0x54, 0x79, 0x15, 0x52,
-	0xe8, 0x26, 0x0c, 0x7b, 0xae, 0xcf, 0xa8, 0x36, 0x2e, 0xcc, 0x8f, 0x99, 0xa8, 0xf7, 0x5d, 0x0e,
-	0x56, 0x6b, 0x28, 0x71, 0x92, 0xba, 0x67, 0xa2, 0x67, 0xdd, 0xf3, 0x18, 0x2a, 0x94, 0x18, 0x3e,
-	0x61, 0x9f, 0xb9, 0x76, 0xab, 0x49, 0xa8, 0x56, 0x15, 0xef, 0x9a, 0x8d, 0x48, 0xeb, 0xb1, 0xe6,
-	0x5a, 0x12, 0x19, 0xed, 0x02, 0xa2, 0xc4, 0x3f, 0xb6, 0x0c, 0x12, 0x5f, 0xdd, 0xc9, 0x1e, 0x39,
-	0x36, 0x87, 0x96, 0x73, 0x22, 0x77, 0x60, 0x35, 0x24, 0x39, 0x91, 0xff, 0x46, 0x37, 0x61, 0xe8,
-	0x9b, 0x63, 0xcf, 0xd1, 0xa6, 0xd2, 0x06, 0xf6, 0x57, 0xc4, 0x77, 0x3f, 0xdb, 0xdd, 0x56, 0x13,
-	0x21, 0x90, 0xd2, 0xa2, 0x7b, 0xfa, 0x7c, 0xa2, 0x3b, 0x4f, 0x37, 0xcf, 0xbc, 0x02, 0xdd, 0x3c,
-	0x7b, 0x5e, 0xdd, 0xbc, 0x05, 0x15, 0x43, 0x4c, 0x43, 0xb0, 0x8e, 0x17, 0xce, 0xf4, 0xe1, 0xb5,
-	0x24, 0x35, 0xfa, 0x01, 0x4c, 0x63, 0xd3, 0xb4, 0xf8, 0x1c, 0x60, 0x3b, 0x34, 0xdc, 0xa9, 0xa6,
-	0x9d, 0xad, 0xd7, 0xdc, 0x4e, 0xf4, 0x5f, 0x15, 0x00, 0xad, 0x3b, 0xc7, 0xee, 0xe9, 0x16, 0x61,
-	0xbe, 0x65, 0xd0, 0x73, 0xd9, 0xa9, 0x08, 0x86, 0x0e, 0x5d, 0xca, 0x94, 0x7d, 0x2a, 0x7e, 0x73,
-	0x18, 0xdf, 0x14, 0x42, 0x61, 0x0c, 0xd7, 0xc4, 0x6f, 0xb4, 0x02, 0x25, 0x66, 0xd3, 0x3a, 0x61,
-	0xcc, 0x72, 0x1a, 0x54, 0x68, 0x89, 0x5e, 0x78, 0x34, 0x4e, 0x84, 0xd6, 0xa0, 0xcc, 0x0c, 0xef,
-	0x13, 0x42, 0x3c, 0x6c, 0x5b, 0xc7, 0xa4, 0x57, 0xfb, 0xb4, 0x96, 0xa0, 0xd2, 0x3f, 0x80, 0xa9,
-	0x1c, 0x59, 0xcc, 0x8d, 0x7c, 0xec, 0x79, 0x81, 0x91, 0x8f, 0x3d, 0x4f, 0x38, 0x8b, 0x94, 0x59,
-	0x6e, 0x60, 0xe4, 0x8b, 0x07, 0xfd, 0xdf, 0x0a, 0x30, 0xae, 0xe8, 0x03, 0xd2, 0x6d, 0x98, 0x12,
-	0x6d, 0xcf, 0x89, 0xd0, 0xd8, 0x0d, 0xd9, 0xaa, 0x66, 0x31, 0xa6, 0x02, 0x72, 0x14, 0x7a, 0x0d,
-	0x09, 0xca, 0xf5, 0x38, 0x61, 0x7c, 0x25, 0x06, 0x7a, 0x5f, 0x89, 0xef, 0xc3, 0xb4, 0x1c, 0x85,
-	0xe5, 0x24, 0x86, 0x31, 0x94, 0xe6, 0xed, 0x0d, 0x27, 0x67, 0x1c, 0xf2, 0x0b, 0x36, 0x12, 0xa4,
-	0xfa, 0x7f, 0xce, 0x41, 0xf9, 0x23, 0xdb, 0xdd, 0x17, 0xec, 0xc3, 0xbf, 0xf4, 0x06, 0x0c, 0x61,
-	0xdf, 0x38, 0x54, 0x9f, 0x36, 0x1d, 0xf5, 0x19, 0x85, 0x9e, 0x6a, 0x02, 0x03, 0x7d, 0x02, 0x65,
-	0x83, 0xf8, 0xcc, 0x3a, 0xb0, 0x0c, 0xcc, 0x08, 0xd5, 0x6e, 0x9c, 0x8d, 0x73, 0x13, 0xc4, 0x22,
-	0x24, 0x23, 0x3a, 0x0f, 0xc3, 0x29, 0x6a, 0x4d, 0xd2, 0x60, 0xee, 0x36, 0x4b, 0x50, 0xcd, 0x75,
-	0x59, 0x84, 0xbd, 0x24, 0xdd, 0xe6, 0x9c, 0x26, 0x6e, 0x51, 0xa9, 0xbd, 0x87, 0x6d, 0xcb, 0x94,
-	0x06, 0xc6, 0x60, 0x77, 0x8b, 0x2a, 0x4d, 0x83, 0xfe, 0x0f, 0x5c, 0x32, 0x5c, 0x87, 0xf9, 0xae,
-	0xbd, 0x6b, 0x63, 0x87, 0xd4, 0x89, 0xd1, 0xf2, 0x2d, 0x76, 0x1a, 0x18, 0x69, 0x43, 0x5d, 0xbb,
-	0xec, 0x44, 0x8e, 0x9e, 0xc1, 0x55, 0x53, 0x1a, 0x9a, 0x72, 0x96, 0x3f, 0xb3, 0xa8, 0xb5, 0x6f,
-	0xd9, 0x16, 0x3b, 0x0d, 0xb7, 0xd4, 0x3d, 0x11, 0x78, 0xea, 0x86, 0x86, 0x3e, 0x83, 0x29, 0x85,
-	0xb2, 0x1d, 0x37, 0x2f, 0x46, 0xce, 0x60, 0x12, 0xe4, 0x75, 0x80, 0x1c, 0x98, 0x33, 0xdb, 0x1a,
-	0xd9, 0xca, 0xee, 0x5a, 0x88, 0xba, 0xef, 0x66, 0x90, 0x8b, 0x17, 0x75, 0xe8, 0x11, 0x6d, 0xc2,
-	0x94, 0x69, 0x51, 0x3e, 0x3b, 0x32, 0xea, 0xb7, 0x7a, 0x48, 0x8c, 0xa3, 0xc0, 0xed, 0xeb, 0x34,
-	0xcf, 0x79, 0x64, 0x68, 0x17, 0xaa, 0x66, 0xca, 0x90, 0x57, 0x26, 0xdc, 0xb5, 0xcc, 0x98, 0x53,
-	0xa6, 0xbe, 0x18, 0x69, 0x86, 0x1a, 0xfd, 0x00, 0x90, 0x82, 0xed, 0xc5, 0xf4, 0xe1, 0x83, 0xb3,
-	0xeb, 0xc3, 0x9c, 0x6e, 0xd0, 0x0a, 0x8c, 0xcb, 0x6d, 0xff, 0x8c, 0xd8, 0xcd, 0x3d, 0x42, 0x99,
-	0x32, 0x0f, 0x3b, 0x7d, 0x77, 0x8a, 0x02, 0x7d, 0x08, 0x15, 0x09, 0xd9, 0xf3, 0xb1, 0x61, 0x39,
-	0x0d, 0x65, 0x15, 0x76, 0xea, 0x22, 0x49, 0x10, 0x84, 0xe2, 0xca, 0x51, 0x28, 0xee, 0x06, 0x4c,
-	0x88, 0x90, 0xda, 0x6e, 0x14, 0x9e, 0xad, 0xc8, 0x8d, 0x9a, 0x02, 0xa3, 0x05, 0xa8, 0x86, 0x20,
-	0x69, 0xe2, 0x50, 0xed, 0x4d, 0xc1, 0xc1, 0x19, 0x38, 0x77, 0x32, 0x84, 0x74, 0x8a, 0xf6, 0xf3,
-	0xb8, 0x74, 0x32, 0x92, 0x50, 0xb4, 0x0d, 0x93, 0xb6, 0x6b, 0x60, 0xce, 0xee, 0x9b, 0xfb, 0x8a,
-	0xe1, 0x95, 0x2d, 0xd6, 0x5d, 0x49, 0x64, 0x49, 0xd1, 0x43, 0x28, 0xda, 0x6e, 0x63, 0x99, 0x7e,
-	0x4c, 0x5d, 0x47, 0x7b, 0xa3, 0xeb, 0xec, 0x44, 0xc8, 0xe8, 0x01, 0x8c, 0xda, 0x6e, 0xa3, 0xc1,
-	0xdf, 0x3f, 0x99, 0x71, 0x04, 0x84, 0x40, 0xdd, 0x94, 0xcd, 0x4a, 0x66, 0x06, 0xd8, 0x68, 0x15,
-	0x2a, 0x4d, 0x42, 0x0f, 0xd7, 0x4f, 0x3c, 0xec, 0x50, 0x2e, 0x8a, 0x50, 0x9a, 0x7c, 0x2b, 0xde,
-	0xac, 0xc8, 0x93, 0x34, 0x68, 0x16, 0x46, 0x38, 0x60, 0x63, 0x4d, 0x7b, 0x57, 0xcc, 0x93, 0x7a,
-	0xe2, 0xfa, 0x93, 0xff, 0xda, 0x26, 0xec, 0x85, 0xeb, 0x1f, 0x51, 0x65, 0xd0, 0xf5, 0xa0, 0x3f,
-	0xe3, 0x54, 0x7c, 0x35, 0x9a, 0xae, 0x63, 0x31, 0x97, 0x23, 0x71, 0x4b, 0x58, 0x18, 0x79, 0x95,
-	0x5a, 0x0a, 0xca, 0x75, 0x45, 0x93, 0xd9, 0x54, 0xd9, 0x6b, 0x31, 0x5d, 0xb1, 0xb5, 0xb7, 0x59,
-	0x0f, 0x74, 0x05, 0xc7, 0x40, 0x1f, 0x42, 0xb9, 0xd9, 0xb2, 0x99, 0xa5, 0xa2, 0xd6, 0xca, 0x1a,
-	0x9b, 0x8f, 0x51, 0xc4, 0x5a, 0x15, 0x65, 0x82, 0x02, 0x69, 0x30, 0xea, 0xc8, 0xf1, 0x69, 0x6f,
-	0x8b, 0x4f, 0x0e, 0x1e, 0xd1, 0x7d, 0x98, 0xf5, 0x5c, 0x73, 0x6d, 0xbb, 0x5e, 0x27, 0x5c, 0x2f,
-	0xc5, 0x02, 0xf5, 0x37, 0x05, 0xb7, 0xb5, 0x69, 0x45, 0x3f, 0x84, 0x79, 0xb7, 0x69, 0xb1, 0xba,
-	0x65, 0x12, 0x03, 0xfb, 0x1b, 0xce, 0xd7, 0x42, 0xca, 0xc9, 0x97, 0x6f, 0x61, 0x4f, 0x7b, 0xab,
-	0x2b, 0x3b, 0x74, 0xa4, 0x47, 0x4f, 0xa0, 0xec, 0x3a, 0x51, 0x7a, 0x40, 0xd9, 0x8b, 0x9d, 0xfa,
-	0x4b, 0xe0, 0xa3, 0x1a, 0xcc, 0xba, 0x1e, 0x97, 0x07, 0xae, 0xbf, 0x85, 0x1d, 0xdc, 0x20, 0x9f,
-	0x93, 0xfd, 0x43, 0xd7, 0x3d, 0xa2, 0xda, 0x77, 0xba, 0xf6, 0xd4, 0x86, 0x12, 0xfd, 0x00, 0x66,
-	0xdc, 0x16, 0xdb, 0x77, 0x5b, 0x8e, 0xb9, 0xe7, 0xe3, 0x83, 0x03, 0xcb, 0x50, 0x7b, 0x58, 0x9a,
-	0x9d, 0x6f, 0x46, 0x0b, 0xb2, 0x93, 0x87, 0xa6, 0x56, 0x26, 0xbf, 0x0f, 0x2e, 0xaf, 0xbd, 0x48,
-	0xe2, 0x3e, 0xc5, 0x96, 0xbd, 0xe3, 0x11, 0x47, 0xb8, 0xbc, 0x5d, 0xe4, 0x75, 0x0e, 0x19, 0x17,
-	0x34, 0x12, 0x1c, 0xcd, 0xe0, 0x9c, 0x14, 0x34, 0x29, 0x30, 0xba, 0x03, 0x93, 0x9e, 0x6f, 0xb9,
-	0x5c, 0x99, 0xae, 0xda, 0x98, 0x52, 0x11, 0x16, 0xbf, 0x14, 0xc6, 0xf0, 0xb3, 0x8d, 0xdc, 0x86,
-	0xf0, 0x7c, 0xb7, 0x49, 0xd8, 0x21, 0x69, 0xd1, 0xa8, 0xff, 0x77, 0xa4, 0x0d, 0x91, 0xd3, 0x24,
-	0x3c, 0x45, 0xdf, 0x3d, 0x39, 0xd5, 0xe6, 0xc5, 0xd7, 0xc4, 0x3d, 0x45, 0x0e, 0x0e, 0x3d, 0x45,
-	0xfe, 0x80, 0x1e, 0x40, 0x51, 0xfc, 0xd8, 0x70, 0x2c, 0xa6, 0x5d, 0x4e, 0xa7, 0x5d, 0x76, 0x83,
-	0x26, 0x45, 0x14, 0xe1, 0xa2, 0x37, 0x61, 0x90, 0x9a, 0x54, 0xbb, 0x92, 0x76, 0x2e, 0xeb, 0x6b,
-	0xc1, 0x76, 0xe2, 0xed, 0x41, 0x3a, 0xe4, 0x6a, 0x0f, 0xe9, 0x90, 0x45, 0x40, 0x8c, 0xd8, 0xa4,
-	0x49, 0x98, 0x1f, 0x9b, 0xc8, 0x6b, 0x32, 0x40, 0x9c, 0x6d, 0x41, 0x8b, 0x30, 0xc2, 0x7c, 0x6c,
-	0x10, 0x5f, 0xbb, 0x2e, 0x7a, 0x8f, 0xb9, 0xa9, 0x7b, 0x02, 0x1e, 0xc4, 0x35, 0x24, 0x16, 0xba,
-	0x06, 0x25, 0xe6, 0xb7, 0x28, 0x5b, 0x73, 0x9b, 0xd8, 0x72, 0x34, 0x5d, 0x74, 0x1c, 0x07, 0x89,
-	0x11, 0x44, 0x8f, 0xcb, 0xb6, 0x85, 0x29, 0xa1, 0xda, 0x82, 0xd8, 0x9a, 0x39, 0x2d, 0x68, 0x09,
-	0x46, 0x5a, 0x94, 0x6c, 0xad, 0xee, 0x6a, 0xaf, 0x77, 0x65, 0x1c, 0x85, 0x89, 0x1e, 0x43, 0x49,
-	0x28, 0x8a, 0x1a, 0x69, 0xba, 0x8c, 0x68, 0xb7, 0xba, 0x12, 0xc6, 0xd1, 0xd1, 0x67, 0xa0, 0xc9,
-	0x34, 0x8f, 0x7c, 0xae, 0x1f, 0x1b, 0xeb, 0x8e, 0xe9, 0xb9, 0x96, 0xc3, 0xa8, 0xf6, 0xdd, 0xae,
-	0x5d, 0xb5, 0xa5, 0xe5, 0x02, 0xc6, 0x17, 0xd0, 0x5d, 0xcb, 0x76, 0xd9, 0xaa, 0x40, 0x8b, 0x21,
-	0x68, 0x8b, 0xdd, 0x05, 0x4c, 0x27, 0x7a, 0xce, 0xc5, 0xaa, 0x5d, 0x6c, 0x88, 0x65, 0xd3, 0xe4,
-	0x96, 0xbd, 0x76, 0x5b, 0x72, 0x71, 0x4e, 0x13, 0x5f, 0x8b, 0x58, 0x8f, 0x01, 0xc1, 0x1d, 0xc9,
-	0x0d, 0xd9, 0x16, 0x2e, 0x5a, 0x25, 0x74, 0x2f, 0xe0, 0x94, 0x80, 0xe6, 0xae, 0xa0, 0x69, 0xd3,
-	0xca, 0xb9, 0x48, 0x4c, 0xb0, 0xa9, 0xdd, 0x4f, 0x73, 0xd1, 0x86, 0x80, 0x07, 0x5c, 0x24, 0xb1,
-	0xd0, 0x2d, 0x98, 0xf4, 0xc4, 0x37, 0x12, 0x9f, 0xed, 0xfa, 0xee, 0xb1, 0x65, 0x12, 0x5f, 0x7b,
-	0x28, 0x13, 0x5b, 0x99, 0x06, 0x34, 0x0f, 0xc5, 0xaf, 0x5f, 0x30, 0x25, 0xb8, 0xde, 0x93, 0xc9,
-	0xdf, 0x10, 0x20, 0xf6, 0x10, 0xa3, 0xda, 0xa3, 0xcc, 0x1e, 0xda, 0x8b, 0xf6, 0x10, 0xa3, 0x68,
-	0x0e, 0xc6, 0x7c, 0x72, 0x6c, 0x09, 0x0d, 0xfc, 0xbe, 0xcc, 0x99, 0x06, 0xcf, 0xdc, 0xf6, 0x6a,
-	0xba, 0x2d, 0x87, 0x6d, 0x31, 0x9b, 0xf2, 0x37, 0x53, 0xed, 0x71, 0x77, 0xdb, 0x2b, 0x49, 0x21,
-	0x32, 0xd4, 0x38, 0x98, 0xad, 0x0f, 0x54, 0x86, 0x3a, 0x00, 0xe8, 0xdf, 0x85, 0x62, 0x38, 0x1e,
-	0xbe, 0x87, 0x54, 0x9c, 0x46, 0xe8, 0x5a, 0x99, 0xcf, 0x8f, 0x83, 0xf4, 0x3f, 0x2a, 0x40, 0x39,
-	0x3e, 0x71, 0xe8, 0xe1, 0x19, 0x3c, 0x79, 0x21, 0x04, 0x43, 0x1f, 0x32, 0xb4, 0x2b, 0x97, 0x1d,
-	0x6c, 0x9f, 0x52, 0x8b, 0xf6, 0xe0, 0x80, 0xa6, 0x28, 0xf4, 0x9b, 0x30, 0x95, 0x63, 0xe2, 0x70,
-	0x6f, 0xda, 0x16, 0x39, 0x68, 0xe9, 0x61, 0xcb, 0x07, 0xfd, 0xbf, 0xa6, 0x61, 0x3a, 0xcf, 0x1f,
-	0xfd, 0xad, 0x0c, 0x74, 0x7f, 0x08, 0x15, 0xa3, 0x45, 0x99, 0xdb, 0xac, 0xcb, 0xd5, 0x55, 0x4e,
-	0x59, 0x47, 0x8b, 0x3c, 0x41, 0xc0, 0x27, 0xd9, 0x24, 0xfb, 0xad, 0x86, 0x3a, 0xd6, 0x20, 0x1f,
-	0xb8, 0x3d, 0x68, 0x4a, 0x09, 0x2c, 0xd3, 0xcd, 0xea, 0x29, 0x1b, 0x58, 0x2f, 0xf6, 0x1f, 0x58,
-	0x87, 0x33, 0x07, 0xd6, 0x4b, 0x67, 0x09, 0xac, 0x5f, 0x83, 0x12, 0x39, 0x61, 0xc4, 0x77, 0xb0,
-	0xbd, 0xb1, 0x4b, 0xb5, 0xb2, 0x50, 0x10, 0x71, 0x10, 0x7a, 0x04, 0x70, 0xf4, 0x90, 0x2a, 0x5e,
-	0x52, 0x01, 0xe1, 0x4e, 0xc3, 0x89, 0x61, 0xa3, 0x35, 0x98, 0x88, 0x9e, 0x9e, 0x31, 0xe6, 0xd1,
-	0x1e, 0xce, 0x36, 0xa4, 0x49, 0x62, 0xc1, 0xff, 0x89, 0xb3, 0x04, 0xff, 0xdf, 0x82, 0x71, 0xdb,
-	0xc5, 0xe6, 0x0a, 0xb6, 0xb1, 0x63, 0x10, 0x7f, 0x63, 0x57, 0xab, 0x4a, 0xce, 0x4a, 0x42, 0xd1,
-	0x23, 0xd0, 0xe2, 0x90, 0xba, 0xf0, 0x33, 0x6b, 0xd8, 0x69, 0x10, 0xaa, 0x4d, 0x8a, 0xf9, 0x68,
-	0xdb, 0x8e, 0xd6, 0x01, 0x25, 0x5c, 0x04, 0x11, 0xc0, 0xd6, 0x50, 0xa7, 0xb8, 0x76, 0x0e, 0x41,
-	0x98, 0xa7, 0xb8, 0xd5, 0x21, 0x4f, 0x31, 0xf5, 0x12, 0xf3, 0x14, 0xd3, 0xaf, 0x30, 0x4f, 0x31,
-	0xf3, 0x6d, 0xe4, 0x29, 0x66, 0x5f, 0x69, 0x9e, 0xe2, 0x42, 0x0f, 0x79, 0x8a, 0x74, 0x66, 0x5e,
-	0x6b, 0x93, 0x99, 0x5f, 0x89, 0xe7, 0x33, 0x2e, 0x9e, 0x61, 0x1d, 0x62, 0xc9, 0x8d, 0x77, 0xa4,
-	0xc1, 0x3a, 0x97, 0x4e, 0x7f, 0x26, 0x05, 0x7e, 0xdd, 0xa4, 0x71, 0xf3, 0x35, 0x93, 0x11, 0xb9,
-	0x74, 0xfe, 0x8c, 0xc8, 0xfc, 0x4b, 0xc8, 0x88, 0x5c, 0x8e, 0x65, 0x44, 0xee, 0xab, 0x8c, 0x88,
-	0x34, 0xc5, 0xf5, 0x76, 0x5f, 0xf6, 0xd5, 0xb1, 0xe7, 0x24, 0x92, 0x23, 0x39, 0xd9, 0x8c, 0xab,
-	0xaf, 0x20, 0x9b, 0x71, 0xed, 0xbc, 0xd9, 0x8c, 0x05, 0xa8, 0x62, 0x4f, 0x30, 0x03, 0x0b, 0x85,
-	0xc5, 0x75, 0xf1, 0xfd, 0x19, 0x38, 0xba, 0x07, 0x33, 0x81, 0x18, 0x4e, 0x3a, 0x8d, 0xd2, 0xda,
-	0xcf, 0x6f, 0x4c, 0xa7, 0x89, 0x5e, 0x3f, 0x67, 0x9a, 0xe8, 0x13, 0x28, 0xab, 0xa8, 0xb7, 0x1c,
-	0xec, 0x1b, 0x67, 0x8c, 0x36, 0xc7, 0x89, 0xdb, 0x26, 0x5f, 0xde, 0x7c, 0x09, 0xc9, 0x97, 0x6c,
-	0xa2, 0xe8, 0xad, 0x73, 0x25, 0x8a, 0x9e, 0xa4, 0xc2, 0xec, 0x6f, 0x77, 0x0f, 0x23, 0x24, 0x22,
-	0xeb, 0xb7, 0x60, 0x90, 0xd9, 0x41, 0x74, 0xbe, 0x13, 0x19, 0x47, 0x43, 0x5f, 0x81, 0x16, 0x7a,
-	0x85, 0xcf, 0xb1, 0x69, 0xba, 0xce, 0x73, 0x95, 0x2a, 0x08, 0xc2, 0x0e, 0xdd, 0xf7, 0xd8, 0x2c,
-	0x8b, 0xf9, 0x03, 0xae, 0x13, 0xa4, 0x52, 0xd0, 0x07, 0x30, 0x7c, 0xe8, 0x72, 0xdb, 0x7c, 0xe1,
-	0x6c, 0x13, 0x22, 0xa9, 0xd0, 0x12, 0xcc, 0x44, 0x43, 0x93, 0xf6, 0xcd, 0x73, 0xa1, 0xab, 0x6e,
-	0x4a, 0x87, 0x27, 0x6c, 0x94, 0xfe, 0xa4, 0x38, 0x03, 0xf7, 0xe7, 0x05, 0xb8, 0xd0, 0x46, 0x16,
-	0xf5, 0x99, 0x0d, 0x0b, 0xcf, 0x17, 0x0e, 0xc4, 0xcf, 0x17, 0x26, 0x72, 0xc3, 0x83, 0xbd, 0xe6,
-	0x86, 0xf5, 0x43, 0xd0, 0xda, 0xc9, 0x93, 0x3e, 0x87, 0x37, 0x0b, 0x23, 0xb4, 0x75, 0x70, 0x60,
-	0x9d, 0xa8, 0xf1, 0xa9, 0x27, 0xfd, 0x73, 0xb8, 0xfa, 0x49, 0x6b, 0x9f, 0xf8, 0x0e, 0x61, 0x84,
-	0xae, 0x3b, 0xc7, 0x5b, 0xd6, 0x09, 0xf1, 0x97, 0x4d, 0xec, 0x85, 0xf1, 0xb6, 0x3e, 0xcf, 0xc7,
-	0x98, 0x80, 0x36, 0x5d, 0x6c, 0xd6, 0x0f, 0x89, 0x69, 0x46, 0xae, 0xc0, 0x02, 0x54, 0x6d, 0xcc,
-	0x88, 0x63, 0x9c, 0xee, 0x1d, 0xfa, 0x84, 0x1e, 0xba, 0xb6, 0xa9, 0xbc, 0x82, 0x0c, 0x1c, 0xe9,
-	0x30, 0xd4, 0x74, 0x4d, 0x39, 0xa1, 0xe3, 0x4b, 0xe3, 0xd1, 0xb4, 0x71, 0x68, 0x4d, 0xb4, 0xe9,
-	0x3e, 0x40, 0x14, 0x53, 0xec, 0x73, 0x6a, 0x16, 0x61, 0x88, 0xdb, 0xfb, 0x3d, 0xf8, 0x3b, 0x02,
-	0x4f, 0xff, 0x3d, 0x98, 0xca, 0x89, 0xc4, 0xf6, 0xf9, 0x72, 0x19, 0xd5, 0xd8, 0xd8, 0x5c, 0xe9,
-	0xe1, 0xf5, 0x0a, 0x53, 0xff, 0x9f, 0x01, 0x98, 0x17, 0xeb, 0x14, 0xf3, 0xaf, 0xc5, 0x82, 0x05,
-	0x1c, 0xbc, 0x03, 0x95, 0xa3, 0x70, 0x51, 0xb9, 0xc1, 0x2d, 0x07, 0xf4, 0x9d, 0x68, 0x0a, 0xbb,
-	0xac, 0x79, 0x2d, 0x49, 0x8f, 0x9e, 0x02, 0x44, 0xc1, 0x2f, 0x35, 0xd2, 0xb7, 0x12, 0x91, 0x2b,
-	0xd5, 0x96, 0xd3, 0x55, 0x8c, 0x12, 0x3d, 0x80, 0x61, 0xca, 0x4c, 0xcb, 0x55, 0x5b, 0x21, 0x66,
-	0x18, 0xd4, 0x39, 0x38, 0x87, 0x5a, 0xe2, 0xa3, 0x0d, 0x28, 0x51, 0x86, 0x8d, 0x23, 0xd3, 0xb7,
-	0x8e, 0x89, 0xaf, 0x52, 0x6a, 0x6f, 0xc7, 0xc9, 0xc3, 0xc6, 0x9c, 0x4e, 0xe2, 0xb4, 0xdc, 0xd1,
-	0x6d, 0x51, 0x12, 0x20, 0xd4, 0xd6, 0xa8, 0xf2, 0xd8, 0x3a, 0x3a, 0xba, 0x49, 0x0a, 0xfd, 0x57,
-	0x03, 0x70, 0x51, 0xbc, 0x27, 0x08, 0xa3, 0xfc, 0x6e, 0xfa, 0x7f, 0x9d, 0xd3, 0xff, 0x8f, 0x05,
-	0x28, 0x89, 0xf7, 0xa8, 0x09, 0x7f, 0x07, 0x46, 0x64, 0xec, 0x57, 0xcd, 0xf4, 0xa5, 0x58, 0xfe,
-	0x20, 0x5a, 0xa5, 0xc0, 0xf9, 0x92, 0xa8, 0xe8, 0x31, 0x14, 0x43, 0xcd, 0xa0, 0xe6, 0xf4, 0x4a,
-	0x8a, 0x2e, 0xdc, 0x5f, 0x41, 0x44, 0x36, 0x24, 0x40, 0x2b, 0x30, 0x86, 0xd5, 0xaa, 0xab, 0xd9,
-	0x7c, 0xab, 0x1d, 0x71, 0x92, 0x3b, 0x6a, 0x21, 0x9d, 0xfe, 0x07, 0x00, 0x93, 0x99, 0xf1, 0xfd,
-	0xc6, 0x85, 0x3f, 0x54, 0x58, 0x63, 0xa8, 0x9f, 0xb0, 0x46, 0x4c, 0x26, 0x0e, 0xf7, 0xa1, 0x4a,
-	0x47, 0xe2, 0xaa, 0xf4, 0xe5, 0x1e, 0x18, 0x4e, 0x3b, 0x43, 0x63, 0x6d, 0x9c, 0xa1, 0xef, 0xc5,
-	0xd6, 0x59, 0xc6, 0x48, 0x5e, 0xcf, 0x65, 0xae, 0x76, 0x8b, 0x8c, 0x6a, 0x30, 0x4b, 0x09, 0xe5,
-	0x7a, 0x22, 0x70, 0xe3, 0xd6, 0x7b, 0x8e, 0x9b, 0xb4, 0xa1, 0x4c, 0x5a, 0x15, 0xa5, 0xf3, 0x9c,
-	0x76, 0x2e, 0xbf, 0x02, 0x1f, 0xa4, 0xf2, 0xaa, 0x4f, 0x3b, 0x8f, 0x7f, 0x1b, 0xfe, 0xfb, 0xc4,
-	0xab, 0xf0, 0xdf, 0xd3, 0x11, 0x94, 0x6a, 0xdf, 0x11, 0x14, 0x15, 0x59, 0x9b, 0x3c, 0x4b, 0x64,
-	0x2d, 0xe5, 0x89, 0xa1, 0x73, 0x7a, 0x62, 0xea, 0x4c, 0xc0, 0x54, 0xa6, 0x3c, 0x67, 0xba, 0x7b,
-	0x3e, 0x4a, 0xff, 0x59, 0x09, 0xa6, 0xf3, 0x64, 0x6e, 0xae, 0x38, 0x1c, 0x78, 0x09, 0xe2, 0x70,
-	0xb0, 0x07, 0x71, 0x38, 0xd4, 0x5e, 0x1c, 0x0e, 0x9f, 0x53, 0x1c, 0x8e, 0x9c, 0x39, 0x68, 0x3a,
-	0x7a, 0x96, 0xa5, 0x0d, 0x45, 0xe8, 0x58, 0x5c, 0x84, 0x7e, 0x08, 0x65, 0xdb, 0xc5, 0x26, 0x55,
-	0x36, 0xb9, 0x12, 0x68, 0xb1, 0x6c, 0x7b, 0xd6, 0x62, 0xaf, 0x25, 0x28, 0x7e, 0x63, 0x8f, 0x26,
-	0xa7, 0xc5, 0x79, 0xb9, 0x6d, 0xd5, 0x49, 0x46, 0x04, 0x4e, 0xbc, 0x02, 0x11, 0x58, 0x3d, 0xaf,
-	0x08, 0x8c, 0x92, 0x9d, 0x93, 0x3d, 0x27, 0x3b, 0x45, 0x12, 0xcf, 0x73, 0x7d, 0xb6, 0x82, 0x99,
-	0x71, 0xb8, 0x85, 0x4f, 0xf6, 0xac, 0x66, 0x70, 0x9c, 0x37, 0xa7, 0x05, 0xdd, 0x83, 0x99, 0x24,
-	0x74, 0xdd, 0x61, 0xbe, 0x45, 0xe4, 0xe1, 0x90, 0x4a, 0x2d, 0xbf, 0x31, 0xa9, 0x7b, 0x2a, 0x3d,
-	0xeb, 0x9e, 0xf6, 0x6a, 0x70, 0xbc, 0x6f, 0x35, 0xd8, 0x4d, 0x4f, 0x4c, 0x7f, 0x1b, 0x7a, 0x62,
-	0xe6, 0xd7, 0x50, 0x15, 0x33, 0xfb, 0x72, 0x24, 0xf5, 0x85, 0x8c, 0xa4, 0xd6, 0x7a, 0x90, 0xd4,
-	0x36, 0xa0, 0xec, 0xa1, 0x9c, 0x3e, 0xbd, 0xdf, 0x6b, 0x50, 0x52, 0x65, 0xac, 0xe2, 0x6c, 0x86,
-	0x0c, 0x4d, 0xc4, 0x41, 0xfa, 0xef, 0x17, 0xe0, 0x52, 0x87, 0x23, 0x27, 0xe8, 0x49, 0x22, 0x48,
-	0xb0, 0xd0, 0xd3, 0x39, 0x95, 0xc5, 0xad, 0x28, 0x80, 0x70, 0x03, 0x86, 0xf8, 0x13, 0xaa, 0x40,
-	0x71, 0x79, 0x73, 0x73, 0xe7, 0xf3, 0xe7, 0xcb, 0xdb, 0x5f, 0x56, 0x5f, 0x43, 0x93, 0x50, 0xa9,
-	0xad, 0x7f, 0xb4, 0x51, 0xdf, 0xab, 0x7d, 0xf9, 0x7c, 0x67, 0x7b, 0xf3, 0xcb, 0x6a, 0x41, 0xff,
-	0x45, 0x15, 0x4a, 0x32, 0xe1, 0x7e, 0x9e, 0x2f, 0x7e, 0x25, 0xea, 0xac, 0x8d, 0xe5, 0x9e, 0x56,
-	0x79, 0x43, 0x39, 0x2a, 0x2f, 0x2d, 0x38, 0x87, 0xdb, 0x08, 0xce, 0x7c, 0x9b, 0xfc, 0x1e, 0x8c,
-	0x52, 0x79, 0xcc, 0xa9, 0x97, 0xf2, 0x1a, 0x85, 0x8a, 0xde, 0x80, 0x8a, 0x38, 0x25, 0x52, 0xc7,
-	0x4d, 0x8f, 0xcb, 0x3e, 0xa1, 0xa4, 0x0a, 0xb5, 0x24, 0x30, 0x29, 0x68, 0x8a, 0x3d, 0x0b, 0x9a,
-	0x9c, 0x03, 0xc3, 0x90, 0x7f, 0x60, 0x58, 0x69, 0xf2, 0x52, 0x3f, 0x9a, 0x3c, 0xad, 0x07, 0xcb,
-	0x7d, 0xeb, 0x41, 0x03, 0xae, 0x1e, 0x05, 0x07, 0xd4, 0xb9, 0x62, 0x21, 0xfe, 0xb1, 0xd8, 0x54,
-	0x0e, 0x31, 0xf8, 0x8b, 0x97, 0x1b, 0x24, 0x2c, 0xd0, 0x6e, 0x9b, 0x9b, 0xed, 0xd6, 0x03, 0xda,
-	0x84, 0xaa, 0x49, 0x3c, 0xdb, 0x3d, 0x6d, 0x12, 0x87, 0xc9, 0x54, 0xa4, 0x92, 0xbb, 0xdd, 0xed,
-	0x89, 0x0c, 0x65, 0x57, 0xb9, 0x5b, 0xfd, 0x36, 0xe4, 0xee, 0xe4, 0xab, 0x90, 0xbb, 0x0f, 0xa1,
-	0x68, 0x84, 0xe7, 0xfe, 0x50, 0xf7, 0x63, 0xa0, 0x21, 0x32, 0xba, 0x0f, 0xa3, 0x2a, 0xb3, 0xa0,
-	0xd2, 0xa2, 0x31, 0x2b, 0x4b, 0x48, 0x11, 0x15, 0xdf, 0x0d, 0x4e, 0x81, 0x2a, 0xe4, 0x98, 0xe2,
-	0x9f, 0xee, 0x59, 0xf1, 0x2b, 0x03, 0x71, 0xe6, 0x2c, 0x06, 0x62, 0x14, 0x32, 0x99, 0x4d, 0x87,
-	0x4c, 0xc4, 0xf0, 0x72, 0x43, 0x26, 0x39, 0xd6, 0x93, 0xf6, 0x0a, 0xac, 0xa7, 0x8b, 0xe7, 0x2f,
-	0xc9, 0x49, 0xa8, 0xcb, 0xb9, 0x73, 0xaa, 0xcb, 0x2d, 0xa8, 0x60, 0xcf, 0x8b, 0x9d, 0x1f, 0xbd,
-	0x74, 0xc6, 0xc4, 0x4d, 0x82, 0x1a, 0x1d, 0xc2, 0x75, 0xa9, 0x0d, 0x76, 0xf9, 0x92, 0x1a, 0xae,
-	0x5d, 0x77, 0x2c, 0xce, 0x81, 0xfc, 0xbb, 0x02, 0xad, 0xa5, 0xf2, 0x96, 0x9d, 0x56, 0xbf, 0x7b,
-	0x27, 0xe8, 0x00, 0xae, 0xb5, 0x45, 0xda, 0x70, 0xe4, 0x8b, 0x2e, 0x77, 0x7d, 0x51, 0xd7, 0x3e,
-	0x72, 0x6c, 0xf9, 0x2b, 0xe7, 0xb0, 0xe5, 0xbf, 0x07, 0x65, 0xb9, 0x8f, 0xe4, 0x39, 0x06, 0x95,
-	0x27, 0x4d, 0x33, 0xe8, 0x6a, 0x0c, 0xa5, 0x96, 0x20, 0x40, 0x0f, 0xe1, 0xc2, 0xd7, 0x2f, 0x8e,
-	0x28, 0x57, 0x11, 0xf6, 0x31, 0xf1, 0xd7, 0x4f, 0x98, 0x8f, 0x6b, 0xae, 0xcb, 0x56, 0x97, 0xd5,
-	0x01, 0xc7, 0x76, 0xcd, 0x68, 0x19, 0x46, 0x3d, 0x51, 0x15, 0x4f, 0xd5, 0x31, 0xc7, 0x9e, 0xd7,
-	0x38, 0xa0, 0x0b, 0x6c, 0x2b, 0x3d, 0x63, 0x5b, 0xbd, 0xde, 0x83, 0x6d, 0xf5, 0xf3, 0x02, 0xa0,
-	0xac, 0x74, 0x10, 0xa7, 0xe0, 0x25, 0x20, 0x38, 0x1e, 0x54, 0x50, 0xa7, 0xe0, 0x13, 0x50, 0xf4,
-	0x29, 0xcc, 0x58, 0x21, 0x21, 0xe3, 0x7b, 0x83, 0xf8, 0x5b, 0x91, 0x75, 0x14, 0xbb, 0x80, 0x21,
-	0x17, 0xad, 0x96, 0x4f, 0xcd, 0xed, 0x88, 0xa0, 0xc1, 0xc6, 0x94, 0xaa, 0xeb, 0x06, 0x12, 0x30,
-	0x7d, 0x03, 0x26, 0x33, 0x72, 0xa3, 0xcf, 0xcc, 0xd1, 0x5f, 0x16, 0x60, 0x22, 0x1d, 0x05, 0xe8,
-	0xcf, 0xd8, 0xba, 0x09, 0x03, 0xc7, 0x77, 0x95, 0x79, 0x15, 0xe3, 0x9f, 0xb0, 0xf3, 0xcf, 0xee,
-	0x2a, 0x01, 0x37, 0x70, 0x7c, 0x57, 0x20, 0x2f, 0xa9, 0x58, 0x6e, 0x2e, 0xf2, 0x52, 0x88, 0xbc,
-	0xc4, 0x3f, 0x37, 0xd3, 0x4b, 0x9f, 0x9f, 0xfb, 0xf7, 0x03, 0xf1, 0xbe, 0x96, 0xce, 0xf5, 0xc1,
-	0x5f, 0xc0, 0x64, 0x93, 0x30, 0x6c, 0x62, 0x86, 0x9f, 0x93, 0x13, 0xe3, 0x10, 0x3b, 0xea, 0xd6,
-	0x87, 0xd2, 0xd2, 0xcd, 0xdc, 0x4f, 0xda, 0x52, 0xd8, 0xeb, 0x0a, 0x59, 0x7d, 0x62, 0xb5, 0x99,
-	0x82, 0xa3, 0xf5, 0x9c, 0x14, 0xc4, 0x9b, 0xb9, 0x5d, 0x46, 0xd9, 0x88, 0x9c, 0x0c, 0xc4, 0xb3,
-	0x64, 0x22, 0x21, 0x13, 0x39, 0x8f, 0xf5, 0x23, 0x72, 0x0a, 0x6b, 0x02, 0x2f, 0x27, 0x8f, 0xa0,
-	0x63, 0xb8, 0xde, 0xf5, 0x3b, 0xd0, 0x63, 0x28, 0xbd, 0xc0, 0xb4, 0xd9, 0xbb, 0xa1, 0x1d, 0x47,
-	0xd7, 0x7f, 0x5a, 0x80, 0x4b, 0x1d, 0x3e, 0xac, 0xcf, 0x35, 0x3a, 0xdf, 0x98, 0x7e, 0x32, 0x08,
-	0xf3, 0x9d, 0x26, 0xa9, 0xcf, 0x41, 0xdd, 0x8b, 0xaa, 0x56, 0x7a, 0xa8, 0x3b, 0x0c, 0x4a, 0x56,
-	0x1e, 0x01, 0x44, 0x95, 0x1f, 0x3d, 0x94, 0xce, 0xc5, 0xb0, 0xd1, 0x7d, 0x18, 0x63, 0xae, 0xe7,
-	0xda, 0x6e, 0xe3, 0xb4, 0x87, 0x0a, 0xb9, 0x10, 0x17, 0xad, 0xc1, 0x84, 0xaa, 0xe2, 0x0a, 0x75,
-	0x65, 0xf7, 0x58, 0x5a, 0x9a, 0x04, 0x3d, 0x13, 0x67, 0x3a, 0x0f, 0xac, 0xc6, 0xce, 0x31, 0xf1,
-	0x7d, 0xcb, 0xec, 0xbd, 0xa2, 0x34, 0x45, 0xa7, 0xaf, 0x2b, 0xc1, 0x17, 0xd7, 0x47, 0xe8, 0x0e,
-	0x4c, 0xd1, 0xd6, 0x3e, 0x35, 0x7c, 0x6b, 0x9f, 0x98, 0x51, 0x59, 0x59, 0x41, 0x9c, 0xd5, 0xcb,
-	0x6b, 0xd2, 0x7f, 0x04, 0xa5, 0xd8, 0x91, 0xad, 0xf0, 0xb8, 0x5d, 0x21, 0x76, 0xdc, 0x2e, 0xa8,
-	0xad, 0x1d, 0x88, 0xd5, 0xd6, 0xce, 0xc1, 0x18, 0xf7, 0x26, 0x76, 0xa3, 0x9a, 0xdb, 0xf0, 0x19,
-	0x5d, 0x01, 0x90, 0x37, 0xf5, 0x88, 0xd6, 0x21, 0xd1, 0x1a, 0x83, 0xe8, 0xff, 0x5c, 0x84, 0x6a,
-	0x86, 0xa7, 0xc3, 0x33, 0xef, 0x51, 0x4b, 0x30, 0xc8, 0x1e, 0xf8, 0xa9, 0x2d, 0x6d, 0x9f, 0x85,
-	0xad, 0x69, 0xef, 0x74, 0xb0, 0x8d, 0x77, 0xaa, 0x94, 0xee, 0x50, 0x46, 0xe9, 0x0e, 0xf7, 0x50,
-	0x0a, 0x31, 0xcf, 0x1d, 0x4d, 0x46, 0x9c, 0xf0, 0x82, 0x89, 0x62, 0x2d, 0x02, 0x64, 0x3c, 0xbd,
-	0xd1, 0xbe, 0x3d, 0xbd, 0x65, 0x18, 0xa7, 0x86, 0x8f, 0xd5, 0xfb, 0x8f, 0xb1, 0xad, 0x2a, 0x16,
-	0x3b, 0x38, 0x76, 0x29, 0x02, 0x11, 0x2f, 0x71, 0x1d, 0x46, 0x4e, 0xd8, 0x2e, 0x66, 0x87, 0xea,
-	0x4a, 0xa8, 0x38, 0x08, 0xbd, 0x0f, 0xa3, 0xea, 0x24, 0x9b, 0x72, 0x6c, 0xaf, 0xe7, 0xe5, 0x89,
-	0x95, 0xc1, 0x10, 0x38, 0x1f, 0x8a, 0x02, 0x3d, 0x81, 0x31, 0xaa, 0xaa, 0x4f, 0x95, 0x47, 0xab,
-	0xe7, 0x53, 0x4b, 0x9c, 0x20, 0xd7, 0x15, 0xd0, 0xbc, 0xe4, 0xcb, 0x5b, 0x7e, 0x8b, 0xf2, 0x40,
-	0x89, 0x58, 0x47, 0xb5, 0xe7, 0x58, 0xc7, 0x16, 0x94, 0xb8, 0xd2, 0x0b, 0x08, 0xfb, 0x70, 0x81,
-	0xe3, 0xf4, 0x39, 0x66, 0x3c, 0x3a, 0x87, 0x19, 0xaf, 0x05, 0x11, 0xa3, 0xa9, 0xb0, 0xe2, 0x4a,
-	0x45, 0x8d, 0xf6, 0xe0, 0x82, 0xe7, 0xbb, 0xb2, 0xa6, 0x22, 0x26, 0x80, 0x88, 0xaa, 0x27, 0xec,
-	0x2c, 0x1b, 0xda, 0x91, 0xea, 0x7f, 0x53, 0x80, 0xf9, 0x4e, 0x27, 0x21, 0xfa, 0xd4, 0x8c, 0x3b,
-	0x30, 0xd3, 0x94, 0x97, 0x25, 0xac, 0x9f, 0x78, 0x96, 0x7f, 0x1a, 0x9e, 0x98, 0x1f, 0xe8, 0xb6,
-	0x79, 0xf3, 0xe9, 0xf4, 0x5d, 0xd0, 0xda, 0x6d, 0xa5, 0x3e, 0x2d, 0xc8, 0xbf, 0x2e, 0xc0, 0x85,
-	0x36, 0x7b, 0x1b, 0xad, 0x40, 0x09, 0xc7, 0x16, 0xb4, 0xd0, 0xeb, 0xe5, 0x0b, 0x31, 0x22, 0xb4,
-	0x1e, 0x53, 0x32, 0x03, 0xe9, 0xa3, 0x2c, 0x99, 0x17, 0x6f, 0x2b, 0xd4, 0x40, 0x3a, 0x04, 0xa4,
-	0xfa, 0x11, 0x5c, 0xed, 0x82, 0xdc, 0xff, 0x45, 0x14, 0xa1, 0x62, 0xac, 0x48, 0xc5, 0xa8, 0xff,
-	0x59, 0x05, 0x4a, 0xb1, 0x0a, 0xbc, 0x78, 0xcf, 0xaf, 0xf7, 0xde, 0xf3, 0x1b, 0x50, 0xc1, 0x86,
-	0x41, 0x28, 0xdd, 0x74, 0x1b, 0x4f, 0x2d, 0x3b, 0xd0, 0xc7, 0x49, 0x20, 0xba, 0x01, 0x13, 0x11,
-	0xc0, 0xf5, 0x9b, 0x38, 0xb8, 0x13, 0x23, 0x0d, 0x46, 0x1b, 0x30, 0x19, 0x82, 0xd6, 0x1d, 0xc3,
-	0x35, 0x03, 0xbb, 0x69, 0x3c, 0xee, 0x72, 0x64, 0x50, 0x6a, 0x59, 0x2a, 0xae, 0xdd, 0x71, 0x8b,
-	0xb9, 0xb2, 0xbc, 0x54, 0x69, 0xbe, 0x18, 0x84, 0x0f, 0x5d, 0xc5, 0xd1, 0x55, 0x09, 0x9e, 0xbc,
-	0x2c, 0x33, 0x09, 0x44, 0xb7, 0x60, 0xd2, 0x70, 0x9b, 0x9e, 0xeb, 0x10, 0x87, 0x6d, 0x06, 0x57,
-	0x45, 0x4a, 0x1d, 0x98, 0x6d, 0x50, 0xea, 0xc7, 0x68, 0xf9, 0x3e, 0x71, 0x8c, 0x53, 0xa1, 0x0a,
-	0x2b, 0xb5, 0x38, 0x28, 0xaa, 0x22, 0x12, 0x17, 0xe1, 0xb5, 0x9a, 0x9e, 0x8a, 0xdc, 0xf6, 0x50,
-	0x45, 0x14, 0x50, 0xa0, 0x6d, 0x98, 0x22, 0xb1, 0x3b, 0x4a, 0x02, 0x97, 0x17, 0xd2, 0x61, 0xb4,
-	0xec, 0x45, 0x26, 0xb5, 0x3c, 0x42, 0xf4, 0x04, 0x4a, 0x02, 0x5c, 0x67, 0x98, 0x51, 0x53, 0xa9,
-	0xc5, 0xce, 0xfd, 0xc4, 0x09, 0xb8, 0x31, 0xa7, 0xae, 0xf4, 0x54, 0xf1, 0x0e, 0x79, 0xd0, 0x58,
-	0xd6, 0xbe, 0xe7, 0x35, 0x71, 0x86, 0x08, 0xc0, 0xbb, 0xaa, 0x4c, 0x43, 0xd5, 0xc2, 0xa7, 0xc0,
-	0x51, 0x58, 0x7d, 0x3c, 0x1e, 0x56, 0xbf, 0x01, 0x13, 0x96, 0x93, 0xa4, 0xaf, 0xaa, 0x5a, 0xfa,
-	0x24, 0x38, 0x71, 0xc3, 0x27, 0x4a, 0xdd, 0xf0, 0xf9, 0x88, 0xbb, 0x6c, 0xd6, 0xb1, 0x65, 0x93,
-	0x06, 0x31, 0x55, 0x14, 0xb2, 0xa3, 0x75, 0x1e, 0x61, 0xa3, 0x15, 0x98, 0xf7, 0x09, 0x36, 0x2d,
-	0x87, 0x50, 0xba, 0xe1, 0x58, 0xcc, 0xc2, 0xf6, 0x1a, 0xb1, 0xf1, 0x69, 0x9d, 0x18, 0xae, 0x63,
-	0x52, 0x55, 0xf7, 0xdd, 0x11, 0x47, 0x16, 0x09, 0xaa, 0xf6, 0x5d, 0xe2, 0x5b, 0xae, 0x19, 0x50,
-	0xcf, 0x08, 0xea, 0x36, 0xad, 0xe8, 0x31, 0x5c, 0x0c, 0x5b, 0x9e, 0x62, 0xcb, 0x6e, 0xf9, 0x24,
-	0x3a, 0x2c, 0x3a, 0x2b, 0x48, 0xdb, 0x23, 0xf0, 0x7d, 0x41, 0x19, 0x66, 0x2d, 0x71, 0xa2, 0x5b,
-	0xa4, 0xb8, 0x2a, 0xb5, 0x18, 0x24, 0xa9, 0x6a, 0xb5, 0x33, 0xa4, 0x15, 0x82, 0xfa, 0xd7, 0x8b,
-	0x62, 0xbb, 0x56, 0x23, 0x1a, 0x09, 0x0f, 0x2b, 0x5f, 0x1f, 0x81, 0xe6, 0xa9, 0x50, 0xd9, 0x1a,
-	0x61, 0x32, 0x06, 0x1f, 0x14, 0x8e, 0xc9, 0x42, 0xe5, 0xb6, 0xed, 0x68, 0x0f, 0x66, 0x04, 0xe7,
-	0x2d, 0x07, 0xdb, 0x3d, 0x60, 0xfe, 0x4b, 0xe9, 0x90, 0xe8, 0x7a, 0x02, 0x2d, 0xa8, 0xbf, 0xce,
-	0x25, 0x46, 0x4b, 0x30, 0xad, 0xf8, 0x2e, 0xf0, 0x7f, 0x24, 0x07, 0xcf, 0x8b, 0xd1, 0xe4, 0xb6,
-	0x65, 0x0b, 0xc4, 0x2e, 0x9f, 0xb1, 0x40, 0x2c, 0x5b, 0x35, 0x77, 0x25, 0xb7, 0x6a, 0xee, 0xfb,
-	0x30, 0xeb, 0x61, 0x9f, 0x38, 0xac, 0x7e, 0xd8, 0x62, 0xa6, 0xfb, 0x22, 0x7a, 0xe3, 0xb5, 0x6e,
-	0x6f, 0x6c, 0x43, 0x88, 0xee, 0x71, 0x01, 0x12, 0x17, 0x29, 0xf2, 0xf6, 0xcb, 0xeb, 0xa1, 0x1d,
-	0x92, 0xd7, 0xcc, 0x07, 0xec, 0xb6, 0x98, 0x6d, 0x11, 0x7f, 0xd3, 0x6d, 0x08, 0xf3, 0x5a, 0xc6,
-	0xf0, 0x52, 0x50, 0xf4, 0x04, 0x8a, 0xb6, 0x75, 0x40, 0x8c, 0x53, 0xc3, 0x26, 0xaa, 0xda, 0xa0,
-	0xbb, 0x3e, 0x8d, 0x48, 0xf4, 0x1f, 0x0f, 0xc0, 0x74, 0xde, 0xea, 0xbd, 0xa2, 0x5b, 0x98, 0x8a,
-	0xca, 0x53, 0x5c, 0xcf, 0xbb, 0x85, 0xe9, 0xf5, 0x76, 0x0c, 0x15, 0x43, 0x7d, 0x15, 0x17, 0x31,
-	0xfd, 0xa2, 0x00, 0x17, 0xdb, 0xbe, 0x90, 0x0f, 0x5f, 0xe4, 0x74, 0x95, 0xf3, 0xcb, 0x7f, 0x0b,
-	0x45, 0x65, 0x5b, 0xc4, 0x11, 0x15, 0xbf, 0xaa, 0x86, 0x41, 0x7d, 0x73, 0xb6, 0x41, 0x5c, 0x13,
-	0xed, 0x5b, 0xc7, 0x98, 0x91, 0x4f, 0xc8, 0x69, 0x70, 0x3d, 0x6a, 0x04, 0x11, 0xcc, 0x89, 0x57,
-	0xe3, 0xd5, 0x13, 0x41, 0x49, 0x67, 0x02, 0xca, 0xfd, 0x4a, 0xea, 0x58, 0x4a, 0x75, 0xf2, 0x9f,
-	0x5c, 0x34, 0xd3, 0xd6, 0x3e, 0xd7, 0xb0, 0xcb, 0xb6, 0xbc, 0x4a, 0x48, 0x1b, 0x11, 0x5e, 0x7d,
-	0x1a, 0xac, 0xff, 0x10, 0x26, 0x52, 0x15, 0xfd, 0x91, 0xb4, 0x2f, 0xb4, 0xad, 0x11, 0x18, 0xee,
-	0xb9, 0x46, 0x60, 0x15, 0x2e, 0xb4, 0xb9, 0x4c, 0x92, 0x0f, 0xdb, 0xf0, 0x5a, 0xc1, 0x85, 0x56,
-	0x86, 0xd7, 0x92, 0xf7, 0x82, 0x34, 0x5d, 0x75, 0xd2, 0x55, 0xdc, 0x0b, 0xc2, 0x9f, 0xf4, 0xbf,
-	0x1d, 0x80, 0x62, 0x78, 0x89, 0xc0, 0x39, 0xaa, 0x87, 0xe7, 0x61, 0xb4, 0x65, 0x52, 0xb1, 0x6b,
-	0x06, 0xc2, 0x6d, 0x16, 0x80, 0xd0, 0x0a, 0x94, 0x5b, 0x94, 0x6c, 0x73, 0x1b, 0xc8, 0xfe, 0xf8,
-	0x05, 0xeb, 0x1e, 0x29, 0x92, 0xde, 0x73, 0x9c, 0x06, 0x6d, 0xc2, 0x64, 0x8b, 0x92, 0x3d, 0xbf,
-	0x45, 0xd9, 0x0b, 0xd7, 0x67, 0x87, 0xa7, 0xbc, 0xa3, 0xa1, 0x9e, 0x3a, 0xca, 0x12, 0xa2, 0x47,
-	0x30, 0xcc, 0xdc, 0x23, 0xe2, 0x9c, 0xe9, 0xa2, 0x5b, 0x49, 0xa2, 0xff, 0x3f, 0x28, 0xc7, 0xeb,
-	0xd0, 0xd0, 0x3c, 0x14, 0x45, 0x8d, 0xb7, 0xf8, 0x7a, 0x39, 0xe7, 0x11, 0x20, 0x8c, 0xe4, 0x0c,
-	0xc4, 0x22, 0x39, 0x5c, 0x47, 0x89, 0x1e, 0xc4, 0xa9, 0x07, 0xc5, 0x9e, 0x11, 0x44, 0xff, 0x8b,
-	0x02, 0x54, 0x5e, 0xbe, 0x19, 0xaf, 0x43, 0x39, 0xa8, 0xc8, 0xda, 0x8d, 0xcc, 0xe5, 0x04, 0x2c,
-	0x1c, 0xed, 0x60, 0x32, 0xee, 0x94, 0xbe, 0x18, 0x50, 0xff, 0xf9, 0x10, 0xcc, 0xe4, 0x5e, 0x70,
-	0x82, 0xbe, 0x80, 0x8b, 0x92, 0x29, 0xa2, 0x8c, 0xd7, 0xca, 0xa9, 0xba, 0x3e, 0xa9, 0x87, 0xd0,
-	0x4f, 0x7b, 0x62, 0xf4, 0x25, 0x4c, 0x39, 0xe4, 0x98, 0xa8, 0x17, 0xf6, 0x79, 0xf7, 0x6d, 0x2d,
-	0xaf, 0x0f, 0x51, 0xf7, 0x65, 0xbf, 0xc0, 0xa7, 0x34, 0xd5, 0x77, 0xf9, 0xac, 0x75, 0x5f, 0x39,
-	0x9d, 0xa0, 0x4d, 0x98, 0xf2, 0xc9, 0x0b, 0xdf, 0x62, 0x64, 0xd9, 0xf3, 0x9e, 0xed, 0xed, 0xed,
-	0xee, 0xfa, 0xee, 0x7e, 0x70, 0x46, 0xac, 0xe3, 0xf5, 0x27, 0x39, 0x64, 0xdc, 0x06, 0xb7, 0x44,
-	0xff, 0x22, 0x82, 0xa0, 0x16, 0x25, 0x0e, 0x42, 0x35, 0x98, 0x92, 0x8f, 0x24, 0xe1, 0xcb, 0xf7,
-	0x7a, 0xe5, 0x4f, 0x1e, 0x31, 0x7a, 0x06, 0xe3, 0xee, 0x7e, 0x62, 0x6a, 0x7a, 0xcd, 0x36, 0xa7,
-	0xe8, 0xf4, 0x3f, 0x2e, 0xc0, 0x85, 0x36, 0xd5, 0x06, 0x7d, 0x6a, 0xc0, 0x27, 0x50, 0x76, 0x5b,
-	0xcc, 0x6b, 0x31, 0x75, 0x5d, 0xd3, 0x40, 0x0f, 0xf7, 0xe9, 0xc4, 0xf0, 0xf5, 0x5f, 0x0e, 0xc2,
-	0xe5, 0x8e, 0x05, 0x0c, 0x7d, 0x8e, 0xeb, 0x1d, 0x51, 0x57, 0x74, 0xa8, 0xc6, 0x73, 0x35, 0xb7,
-	0x5a, 0x62, 0xb9, 0xc5, 0xa2, 0xcb, 0xf3, 0x5a, 0xec, 0x10, 0xbd, 0x17, 0xda, 0x99, 0x39, 0x35,
-	0x1a, 0x21, 0x59, 0xee, 0x95, 0x2b, 0xeb, 0x22, 0x6f, 0xca, 0xc8, 0x09, 0xfb, 0xc8, 0xc7, 0xde,
-	0xa1, 0x12, 0x8e, 0xf9, 0x1d, 0xac, 0xc6, 0x10, 0x6b, 0x09, 0x32, 0xb4, 0x13, 0xa5, 0x02, 0xa4,
-	0x70, 0x7c, 0xb7, 0xc7, 0x3a, 0x8f, 0x45, 0x95, 0xa3, 0x48, 0x5f, 0x6c, 0xb5, 0x03, 0xa3, 0x2a,
-	0x12, 0xa2, 0x22, 0xf5, 0xfd, 0x76, 0xa8, 0x7a, 0x99, 0x5b, 0x87, 0x4a, 0xa2, 0xa5, 0xcf, 0xb0,
-	0xc9, 0x5f, 0x15, 0x60, 0x26, 0x77, 0x29, 0xb8, 0x17, 0x8b, 0x3d, 0x6f, 0xd5, 0x27, 0x26, 0x71,
-	0xb8, 0x5b, 0x43, 0x7b, 0xe8, 0x36, 0x45, 0xc1, 0x35, 0x2e, 0xf6, 0x2c, 0x6e, 0x7e, 0x28, 0x8d,
-	0x2b, 0x9f, 0xd0, 0x62, 0x54, 0xa6, 0x6c, 0x18, 0xa1, 0xda, 0x90, 0xf2, 0x36, 0xa7, 0x45, 0xff,
-	0xff, 0x7c, 0xbb, 0xe4, 0x2e, 0x7c, 0x9f, 0x6c, 0x79, 0x0b, 0x26, 0x29, 0x6e, 0x7a, 0x22, 0xa1,
-	0xbf, 0x8f, 0xe5, 0x15, 0x81, 0x4a, 0x17, 0x64, 0x1b, 0xf4, 0x9d, 0xc4, 0xeb, 0xe3, 0x6c, 0xd3,
-	0xe7, 0xac, 0xff, 0x78, 0x00, 0xca, 0x89, 0xaf, 0x78, 0x00, 0xa3, 0x26, 0x66, 0xd8, 0x74, 0x1b,
-	0xd9, 0x6b, 0x33, 0x25, 0xe2, 0x9a, 0x6c, 0x0e, 0xd8, 0x40, 0x61, 0xa3, 0x0f, 0xb8, 0x21, 0xde,
-	0x38, 0x64, 0x94, 0x11, 0x2f, 0xbb, 0xc9, 0x24, 0xe9, 0x26, 0x47, 0xa8, 0x33, 0xe2, 0x05, 0x15,
-	0x3c, 0x21, 0x05, 0xba, 0x07, 0x23, 0xdf, 0x58, 0xde, 0x91, 0x15, 0xdc, 0xf9, 0x38, 0x9f, 0xa6,
-	0xfd, 0x4a, 0xb4, 0x06, 0x9b, 0x4c, 0xe2, 0xa2, 0xd5, 0xbc, 0x4a, 0xa8, 0xeb, 0x69, 0xd2, 0xe4,
-	0x94, 0x65, 0x72, 0x97, 0xb7, 0x61, 0x2a, 0xe7, 0xcb, 0x90, 0x06, 0xa3, 0x58, 0x5d, 0x0c, 0x23,
-	0xcd, 0x88, 0xe0, 0x51, 0xff, 0x59, 0x01, 0x66, 0x72, 0x3f, 0xa8, 0x3d, 0x0d, 0x57, 0x14, 0x32,
-	0x6a, 0xb4, 0x27, 0x0c, 0x1d, 0x75, 0xb6, 0x32, 0x06, 0x12, 0x7f, 0x22, 0xc0, 0xfb, 0x8c, 0xb3,
-	0x60, 0x0c, 0x82, 0x96, 0x60, 0x44, 0x84, 0xf6, 0x49, 0x0f, 0x09, 0x3a, 0x85, 0xa9, 0x2f, 0x02,
-	0xca, 0xce, 0x5e, 0x87, 0x2f, 0xfb, 0x65, 0x01, 0x2e, 0xb4, 0x99, 0x33, 0x74, 0x27, 0xb8, 0xd2,
-	0xa4, 0x3b, 0x7b, 0xa9, 0xeb, 0x4e, 0xee, 0xc1, 0x4c, 0x13, 0x9f, 0x6c, 0xb7, 0x9a, 0xfb, 0xc4,
-	0xdf, 0x39, 0x58, 0x66, 0xcc, 0xb7, 0xf6, 0x5b, 0xdc, 0xbc, 0x97, 0xfc, 0x9d, 0xdf, 0x88, 0xee,
-	0xc3, 0x6c, 0xbc, 0x21, 0xa6, 0x33, 0xe5, 0xa9, 0xca, 0x36, 0xad, 0xdc, 0xd3, 0x8f, 0xb5, 0x6c,
-	0x11, 0x4a, 0x71, 0x23, 0xf8, 0xab, 0x10, 0x79, 0xd6, 0xb2, 0x6d, 0xbb, 0xfe, 0xef, 0xc3, 0x50,
-	0x51, 0x97, 0x29, 0x9e, 0x6b, 0x37, 0xbf, 0x0b, 0x23, 0x5f, 0x63, 0xd2, 0x08, 0xf5, 0x45, 0x6a,
-	0xf3, 0x58, 0x4e, 0xe3, 0x63, 0xd1, 0x1c, 0xb0, 0xb1, 0x44, 0xce, 0x64, 0xb5, 0x86, 0xfa, 0xce,
-	0x6a, 0xcd, 0xc1, 0x98, 0x17, 0xdc, 0xcc, 0x24, 0xfd, 0xa4, 0xf0, 0x19, 0xdd, 0x8d, 0x92, 0x51,
-	0x23, 0xe9, 0x44, 0x5c, 0x9b, 0x14, 0xd4, 0xbb, 0xe1, 0xae, 0x1c, 0x6d, 0xf3, 0x3d, 0xb9, 0xdb,
-	0x72, 0x19, 0xc0, 0xf5, 0x88, 0x63, 0x10, 0x87, 0xb6, 0x82, 0x9b, 0x40, 0xaf, 0x67, 0x48, 0x77,
-	0x42, 0x94, 0xe0, 0x68, 0x42, 0x44, 0xd4, 0x43, 0x6e, 0xad, 0x5b, 0x3e, 0xaa, 0xf2, 0x6d, 0xe4,
-	0xa3, 0xc6, 0x7f, 0x0d, 0xe7, 0xcd, 0x27, 0xce, 0xf9, 0x2f, 0x0c, 0x7f, 0x37, 0x20, 0x37, 0x79,
-	0xce, 0x12, 0x04, 0xa9, 0xdb, 0x42, 0x26, 0x75, 0x3b, 0xd0, 0x43, 0xea, 0xf6, 0x19, 0x14, 0xc9,
-	0x89, 0xe7, 0xfa, 0xb1, 0x32, 0xcc, 0x85, 0x0e, 0xab, 0xbe, 0x1e, 0xe0, 0x06, 0xda, 0x20, 0x24,
-	0x4e, 0x5e, 0x7a, 0x32, 0xdc, 0xdf, 0xa5, 0x27, 0xd9, 0xfc, 0xd9, 0x48, 0xff, 0xf9, 0x33, 0xfd,
-	0x00, 0xae, 0x75, 0xfb, 0x00, 0xee, 0x16, 0xc6, 0xb5, 0x51, 0xcf, 0x6e, 0x61, 0x5c, 0x19, 0xfd,
-	0xeb, 0xa0, 0xd4, 0x46, 0x29, 0x51, 0x71, 0xbe, 0x85, 0x09, 0x23, 0x1d, 0x10, 0x8f, 0x74, 0xbc,
-	0x1f, 0x46, 0x21, 0x06, 0xd3, 0xe1, 0xa7, 0xc4, 0x08, 0xb6, 0x04, 0x52, 0xb0, 0xc5, 0x25, 0x89,
-	0x88, 0xbc, 0x78, 0xd8, 0xa9, 0x33, 0xd7, 0xc7, 0x0d, 0xc2, 0xdf, 0xa9, 0x82, 0x36, 0x69, 0x30,
-	0x97, 0xa4, 0x1e, 0xf1, 0xa9, 0x45, 0x59, 0x2f, 0x55, 0xa7, 0x0a, 0x15, 0x2d, 0x40, 0x95, 0xca,
-	0x4e, 0xa2, 0xcb, 0x22, 0x65, 0x26, 0x24, 0x03, 0x17, 0xc9, 0x17, 0xa1, 0x48, 0xc5, 0xe9, 0x3a,
-	0xf5, 0x47, 0x62, 0x11, 0x24, 0xc9, 0x4d, 0x63, 0x2f, 0x8b, 0x9b, 0x8a, 0xe7, 0xe0, 0xa6, 0x47,
-	0x70, 0xb1, 0xed, 0x14, 0xa3, 0xcb, 0x00, 0x4d, 0x7c, 0xf2, 0x5c, 0xf8, 0x11, 0x54, 0xdd, 0x33,
-	0x57, 0x6c, 0xe2, 0x13, 0xa1, 0x98, 0xa9, 0xfe, 0x1f, 0x11, 0x87, 0x24, 0xb4, 0xfa, 0xcb, 0xe1,
-	0x90, 0x62, 0x9c, 0x43, 0x6e, 0xc1, 0xa4, 0xc7, 0xdd, 0xdc, 0x3a, 0xc3, 0x3e, 0x6b, 0x79, 0x22,
-	0x9f, 0xa0, 0xb4, 0x70, 0xb6, 0x01, 0x3d, 0x86, 0x8b, 0xb6, 0x75, 0x4c, 0x44, 0x0a, 0x21, 0x43,
-	0x55, 0x92, 0x99, 0x82, 0xb6, 0x08, 0x68, 0x1e, 0x8a, 0x3f, 0x6a, 0x11, 0xff, 0x34, 0x3c, 0x1e,
-	0x53, 0xa9, 0x45, 0x80, 0x3e, 0xa3, 0x72, 0x48, 0x87, 0xf2, 0xd7, 0xf8, 0x18, 0xef, 0x78, 0x8c,
-	0x3e, 0x23, 0xd8, 0x93, 0x7f, 0x7f, 0x54, 0x4b, 0xc0, 0xb8, 0xca, 0x6c, 0xe2, 0x93, 0xba, 0x87,
-	0x55, 0x0d, 0x73, 0xa5, 0x16, 0x3e, 0xa3, 0x77, 0x61, 0x88, 0xab, 0xd7, 0xb6, 0x2a, 0x4c, 0x2e,
-	0xc0, 0xb6, 0x6b, 0x06, 0x9a, 0x53, 0xa0, 0xbf, 0xdc, 0x7f, 0x98, 0xd3, 0xbf, 0x1b, 0x8a, 0xeb,
-	0xf4, 0xeb, 0x10, 0x82, 0x21, 0xc3, 0x6b, 0x05, 0x4c, 0x22, 0x7e, 0xeb, 0x7f, 0x52, 0x80, 0xa9,
-	0x4f, 0x2c, 0x6c, 0x5b, 0x2f, 0x23, 0x9b, 0x8d, 0x2e, 0x41, 0x91, 0x5b, 0xa0, 0xcf, 0x0f, 0x2c,
-	0x3b, 0x88, 0x9a, 0x8d, 0x71, 0x80, 0x4a, 0xb5, 0x56, 0x55, 0x18, 0xf7, 0xf9, 0x11, 0x39, 0x95,
-	0x38, 0x83, 0xea, 0xbf, 0xef, 0xc2, 0xf0, 0x2e, 0xc7, 0xd4, 0x6d, 0x40, 0x6a, 0x4c, 0x2f, 0x3b,
-	0x8e, 0x96, 0x17, 0x0f, 0xfb, 0xd3, 0x41, 0x98, 0x16, 0xaf, 0x5b, 0xc3, 0xf4, 0x70, 0xdf, 0xc5,
-	0x7e, 0xe0, 0x9a, 0x26, 0x43, 0x7d, 0x85, 0x74, 0xa8, 0x8f, 0x5b, 0x1d, 0x2d, 0x4a, 0x7c, 0x07,
-	0x37, 0x49, 0xe4, 0x2b, 0xc6, 0x41, 0xe8, 0x0d, 0xa8, 0x78, 0x98, 0x52, 0xef, 0xd0, 0xc7, 0x34,
-	0x16, 0xce, 0x4e, 0x02, 0xd1, 0x13, 0x28, 0x1f, 0x5b, 0xe4, 0xc5, 0x8e, 0x63, 0x9f, 0x0a, 0x99,
-	0xd4, 0xdd, 0x62, 0x4f, 0xe0, 0xf3, 0x71, 0x36, 0x7c, 0x7c, 0x80, 0x1d, 0xfc, 0x69, 0x6d, 0x33,
-	0xf8, 0x63, 0xc5, 0x08, 0x22, 0xee, 0xd6, 0x14, 0x82, 0x83, 0x37, 0xab, 0x43, 0x52, 0x21, 0x00,
-	0xdd, 0x53, 0xa1, 0x8e, 0x5e, 0x6b, 0x54, 0x65, 0xac, 0xe3, 0x0e, 0x4c, 0xa9, 0x37, 0x6c, 0x38,
-	0xaa, 0x9a, 0x8c, 0xf7, 0x2e, 0x4b, 0x56, 0xf3, 0x9a, 0xb8, 0xf3, 0x2c, 0x5f, 0x9a, 0x20, 0x90,
-	0x12, 0x24, 0xa7, 0x45, 0xff, 0x87, 0x31, 0x28, 0x89, 0x65, 0x39, 0x6f, 0xcd, 0x96, 0x3c, 0xd7,
-	0xb6, 0x46, 0x9a, 0xae, 0x0c, 0xfd, 0xf6, 0x52, 0xb3, 0x95, 0xa6, 0x09, 0xe4, 0xe5, 0x60, 0x46,
-	0x5e, 0x0e, 0xf5, 0x20, 0x2f, 0x7b, 0x2d, 0xd4, 0x6a, 0x73, 0x85, 0xf1, 0x48, 0xfb, 0x2b, 0x8c,
-	0xdf, 0x8b, 0x9d, 0xfa, 0xca, 0x18, 0xdd, 0x39, 0xfb, 0x3a, 0x76, 0xe0, 0xeb, 0x31, 0x14, 0xcd,
-	0x80, 0xe1, 0x95, 0xc8, 0xba, 0x92, 0xa2, 0x4d, 0x6d, 0x88, 0x5a, 0x44, 0x90, 0xb6, 0xb8, 0x27,
-	0xb2, 0x16, 0xf7, 0xef, 0xfe, 0x09, 0xe9, 0xdb, 0xfe, 0x27, 0xa4, 0x94, 0x27, 0x30, 0x7e, 0xce,
-	0x23, 0x7d, 0xe1, 0xa1, 0xb0, 0x6a, 0xfa, 0x50, 0x58, 0x42, 0xdf, 0x4e, 0xf6, 0xac, 0x6f, 0x17,
-	0x60, 0x3c, 0xe2, 0xe9, 0x65, 0xd3, 0xf4, 0xa5, 0x58, 0x56, 0xab, 0x96, 0x68, 0x41, 0xf7, 0x23,
-	0x77, 0x34, 0x53, 0x93, 0x95, 0xd5, 0x15, 0xa1, 0x4f, 0xaa, 0xff, 0xe1, 0x18, 0x8c, 0x88, 0x3d,
-	0x4d, 0xd1, 0x9b, 0x30, 0x68, 0x38, 0x96, 0xda, 0xfd, 0x53, 0x89, 0xbf, 0x4c, 0x0d, 0x6e, 0x32,
-	0x34, 0x1c, 0x0b, 0xbd, 0x0f, 0x65, 0x71, 0x83, 0xb1, 0xe1, 0xfa, 0xc4, 0x74, 0x68, 0xf6, 0x0f,
-	0x4a, 0x13, 0xff, 0x13, 0x59, 0x4b, 0x20, 0xa3, 0x7b, 0x30, 0x16, 0x5e, 0xad, 0x26, 0x0d, 0x0f,
-	0x2d, 0x73, 0x9d, 0x68, 0x78, 0xcf, 0x48, 0x80, 0x89, 0x16, 0x61, 0xa4, 0x21, 0xee, 0xde, 0x55,
-	0x4e, 0xc7, 0x6c, 0xfa, 0x6f, 0x07, 0x02, 0x73, 0x5a, 0x62, 0xa1, 0x47, 0x30, 0xaa, 0x24, 0x6c,
-	0xcf, 0x52, 0x3b, 0x20, 0x40, 0x37, 0x61, 0xb8, 0x69, 0x9d, 0x10, 0x5f, 0x6d, 0xf9, 0x99, 0xd4,
-	0x8d, 0x28, 0xc1, 0xdd, 0x41, 0x02, 0x47, 0xdc, 0x51, 0x69, 0xd9, 0x6e, 0xf0, 0x3f, 0x15, 0x33,
-	0xb9, 0x75, 0x3c, 0x35, 0x89, 0x83, 0x1e, 0xc4, 0x2f, 0xe5, 0xb9, 0x90, 0xbe, 0x21, 0xbd, 0xc3,
-	0x7d, 0x3c, 0x8f, 0x12, 0xf5, 0x09, 0xc1, 0xff, 0x59, 0xe4, 0x9c, 0x52, 0xcb, 0x29, 0x4a, 0xf8,
-	0x1c, 0x66, 0x69, 0x32, 0x97, 0xa5, 0xee, 0xc5, 0x57, 0x5b, 0x2a, 0x1e, 0xba, 0xcf, 0xcb, 0x79,
-	0xd5, 0xda, 0x90, 0xa3, 0xbb, 0x30, 0xca, 0xd4, 0x3f, 0x6c, 0x8c, 0x67, 0x44, 0x7c, 0x3c, 0xf8,
-	0x53, 0x0b, 0xf0, 0xf8, 0x6c, 0x1d, 0x71, 0x56, 0x54, 0x3e, 0xf7, 0x4c, 0x8a, 0x43, 0x83, 0xd9,
-	0x12, 0x38, 0x48, 0x83, 0xd1, 0x63, 0xee, 0xbd, 0xb8, 0x8e, 0x3a, 0x1f, 0x14, 0x3c, 0x0a, 0x95,
-	0xa5, 0xfe, 0x08, 0x38, 0xb5, 0xa9, 0x3a, 0xab, 0xac, 0x14, 0x0d, 0xda, 0x05, 0x14, 0x4d, 0xd4,
-	0x8e, 0xfa, 0xef, 0x80, 0x5e, 0x8f, 0x85, 0xd6, 0x72, 0x68, 0xd1, 0x1d, 0x28, 0xca, 0xff, 0x2a,
-	0xe2, 0xfb, 0x68, 0xaa, 0xfd, 0x3e, 0x1a, 0x13, 0x58, 0xab, 0x8e, 0x85, 0x1e, 0x42, 0xf1, 0x48,
-	0x5c, 0x75, 0x6c, 0x7d, 0x43, 0x7a, 0x38, 0x20, 0x1a, 0x21, 0x27, 0xee, 0xf2, 0x9e, 0x49, 0xdd,
-	0xe5, 0xfd, 0x00, 0xa0, 0x49, 0xa8, 0x8a, 0xf8, 0xab, 0x73, 0x1c, 0x6d, 0x35, 0x70, 0x0c, 0x55,
-	0xd7, 0x60, 0x36, 0xff, 0x73, 0xf5, 0xab, 0x70, 0xb9, 0xa3, 0x38, 0xd4, 0x67, 0x61, 0x3a, 0xaf,
-	0x94, 0x51, 0xff, 0xbf, 0x50, 0x49, 0xfc, 0xa1, 0xda, 0x4b, 0xbe, 0xe3, 0x6f, 0x02, 0x2a, 0x89,
-	0xcf, 0x59, 0xb8, 0x2d, 0x0f, 0x58, 0xa0, 0x32, 0x8c, 0xa9, 0xc2, 0x08, 0xb3, 0xfa, 0x1a, 0x7f,
-	0xb2, 0xdd, 0xc6, 0x73, 0xd7, 0xb1, 0x4f, 0xab, 0x05, 0x54, 0xe2, 0x43, 0x38, 0x70, 0x7d, 0x83,
-	0x54, 0x07, 0x16, 0x3e, 0x6e, 0x53, 0x58, 0x86, 0x26, 0xa0, 0xf4, 0xe9, 0x76, 0x7d, 0x77, 0x7d,
-	0x75, 0xe3, 0xe9, 0xc6, 0xfa, 0x5a, 0xf5, 0x35, 0x4e, 0xb6, 0xb6, 0xfe, 0x74, 0xf9, 0xd3, 0xcd,
-	0xbd, 0x6a, 0x01, 0x01, 0x8c, 0xd4, 0xf7, 0x6a, 0x1b, 0xab, 0x7b, 0xd5, 0x01, 0x34, 0x0a, 0x83,
-	0x3b, 0x4f, 0x9f, 0x56, 0x07, 0x17, 0xde, 0xce, 0x39, 0x03, 0x89, 0xc6, 0x60, 0xe8, 0xe3, 0xfa,
-	0xce, 0x76, 0xf5, 0x35, 0xfe, 0x6b, 0x6f, 0xfd, 0x8b, 0xbd, 0x6a, 0x61, 0x61, 0x39, 0x48, 0x85,
-	0xf1, 0x7e, 0x64, 0x9c, 0xaf, 0xfa, 0x1a, 0xaa, 0xc4, 0xa2, 0xfe, 0x72, 0x98, 0x2a, 0x1f, 0x50,
-	0x1d, 0xe0, 0xa3, 0x89, 0x45, 0x36, 0xaa, 0x83, 0x2b, 0xf0, 0x55, 0xf8, 0x3f, 0xec, 0xfb, 0x23,
-	0x62, 0xea, 0xde, 0xf9, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa6, 0x4b, 0x11, 0xd8, 0xc6, 0x7d,
-	0x00, 0x00,
+	// 7709 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x7d, 0x59, 0x6f, 0x1c, 0xd7,
+	0x9a, 0x98, 0x9b, 0x7b, 0x7f, 0xcd, 0x26, 0x9b, 0x87, 0x8b, 0x4a, 0x14, 0xad, 0xa5, 0xbc, 0xe9,
+	0xd2, 0xbe, 0x94, 0x44, 0xcb, 0x92, 0x2c, 0xcb, 0xb2, 0xb9, 0xc9, 0xa2, 0xcd, 0x6d, 0xaa, 0x69,
+	0x79, 0xb9, 0xc9, 0x28, 0x87, 0x55, 0x87, 0xcd, 0x32, 0xab, 0xeb, 0xd4, 0xad, 0x3a, 0x4d, 0x91,
+	0x06, 0x82, 0x60, 0x5e, 0x12, 0x0c, 0x12, 0x4c, 0x30, 0x41, 0x80, 0xbc, 0x04, 0x08, 0x82, 0x24,
+	0x98, 0xe7, 0x04, 0x01, 0xf2, 0x03, 0x32, 0xc0, 0xbc, 0xe4, 0x4f, 0x0c, 0x82, 0x3c, 0x24, 0x0f,
+	0x79, 0x1b, 0xe4, 0x21, 0x03, 0x24, 0x38, 0x4b, 0xed, 0xd5, 0xec, 0x62, 0x53, 0xba, 0xbe, 0xc0,
+	0xdc, 0xb7, 0xae, 0xef, 0x7c, 0xdf, 0xa9, 0x53, 0x67, 0xf9, 0xd6, 0xf3, 0x7d, 0x0d, 0x8b, 0xde,
+	0x71, 0xeb, 0x0e, 0xf6, 0xec, 0xe0, 0x8e, 0x1d, 0x30, 0x9b, 0xde, 0x39, 0xb9, 0x87, 0x1d, 0xef,
+	0x08, 0xdf, 0xbb, 0x73, 0x82, 0x9d, 0x0e, 0x09, 0x5e, 0xb2, 0x33, 0x8f, 0x04, 0x4b, 0x9e, 0x4f,
+	0x19, 0x45, 0x63, 0x61, 0xe3, 0xfc, 0xf5, 0x16, 0xa5, 0x2d, 0x87, 0xdc, 0x11, 0xf0, 0x83, 0xce,
+	0xe1, 0x1d, 0xab, 0xe3, 0x63, 0x66, 0x53, 0x57, 0x62, 0xce, 0x7f, 0xd9, 0xb2, 0xd9, 0x51, 0xe7,
+	0x60, 0xc9, 0xa4, 0xed, 0x3b, 0x2d, 0xda, 0xa2, 0x31, 0x62, 0xf4, 0x23, 0xdb, 0xc3, 0x2b, 0x1f,
+	0x7b, 0x1e, 0xf1, 0xd5, 0xbb, 0xf4, 0x23, 0x80, 0x15, 0xdf, 0x3c, 0x5a, 0xa3, 0xee, 0xa1, 0xdd,
+	0x42, 0x33, 0x30, 0x8c, 0xdb, 0xd6, 0x83, 0xfb, 0x5a, 0xe5, 0x66, 0xe5, 0x76, 0xdd, 0x90, 0x0f,
+	0x48, 0x83, 0x51, 0xcf, 0x33, 0x1f, 0xdc, 0x77, 0x88, 0x36, 0x20, 0xe0, 0xe1, 0x23, 0xc7, 0x0f,
+	0x3e, 0xfe, 0xf4, 0xee, 0xa9, 0x36, 0x28, 0xf1, 0xc5, 0x83, 0xe8, 0xc5, 0x6f, 0x3f, 0xb8, 0xaf,
+	0x0d, 0xa9, 0x5e, 0xf8, 0x83, 0xfe, 0x57, 0x43, 0x50, 0x5d, 0xdb, 0xd9, 0x54, 0x6f, 0xba, 0x0f,
+	0xa3, 0xc4, 0xc5, 0x07, 0x0e, 0xb1, 0xc4, 0xbb, 0x6a, 0xcb, 0xf3, 0x4b, 0x72, 0xa4, 0x4b, 0xe1,
+	0x48, 0x97, 0x56, 0x29, 0x75, 0x5e, 0xf0, 0xd9, 0x31, 0x42, 0x54, 0xd4, 0x80, 0xc1, 0xa3, 0xce,
+	0x81, 0x18, 0x45, 0xd5, 0xe0, 0x3f, 0xd1, 0xaf, 0x60, 0x90, 0xe1, 0x96, 0x78, 0x7f, 0x6d, 0xf9,
+	0xca, 0x52, 0x38, 0x73, 0x4b, 0xfb, 0x67, 0x1e, 0xd9, 0x74, 0x19, 0xf1, 0x0f, 0xb1, 0x49, 0x0c,
+	0x8e, 0xc3, 0x87, 0x65, 0xb7, 0x71, 0x8b, 0x88, 0x61, 0x55, 0x0d, 0xf9, 0x80, 0xae, 0x03, 0x78,
+	0x1d, 0xc7, 0xd9, 0xa3, 0x8e, 0x6d, 0x9e, 0x69, 0xc3, 0xa2, 0x29, 0x01, 0x41, 0x0b, 0x50, 0x35,
+	0x5d, 0x7b, 0xd5, 0x76, 0xd7, 0x6d, 0x5f, 0x1b, 0x11, 0xcd, 0x31, 0x80, 0x53, 0x9b, 0xae, 0xcd,
+	0xbf, 0x89, 0x37, 0x8f, 0x4a, 0xea, 0x18, 0x82, 0x6e, 0xc3, 0xa4, 0x7a, 0x7a, 0x66, 0x3b, 0x64,
+	0x07, 0xb7, 0x89, 0x36, 0x26, 0x90, 0xb2, 0x60, 0xf4, 0x11, 0x4c, 0x91, 0x53, 0xd3, 0xe9, 0x58,
+	0xe2, 0x31, 0xf0, 0xb0, 0x49, 0x02, 0xad, 0x7a, 0x73, 0xf0, 0x76, 0xd5, 0xc8, 0x37, 0xa0, 0x2d,
+	0x98, 0xf0, 0xa8, 0xb5, 0xe2, 0xba, 0x94, 0x89, 0xfd, 0x10, 0x68, 0x20, 0x66, 0xe0, 0x66, 0x7a,
+	0x06, 0xb6, 0xb1, 0xd7, 0x64, 0xbe, 0xed, 0xb6, 0xa2, 0xa9, 0x58, 0x1d, 0xd0, 0x2a, 0x46, 0x86,
+	0x16, 0xdd, 0x86, 0x86, 0x17, 0x78, 0x2f, 0x4d, 0xa7, 0x13, 0x30, 0xe2, 0xbf, 0xf4, 0xa9, 0x43,
+	0xb4, 0x9a, 0x18, 0xe6, 0x84, 0x17, 0x78, 0x6b, 0x12, 0x6c, 0x50, 0x87, 0xa0, 0x79, 0x18, 0x73,
+	0x68, 0x6b, 0x8b, 0x9c, 0x10, 0x47, 0x1b, 0x17, 0x18, 0xd1, 0x33, 0xba, 0x07, 0x23, 0x3e, 0xf1,
+	0xb0, 0xed, 0x6b, 0x75, 0x31, 0x96, 0xab, 0xf1, 0x58, 0xd6, 0x76, 0x36, 0x0d, 0xd1, 0x24, 0x57,
+	0xdf, 0x50, 0x88, 0x7c, 0x17, 0x98, 0x47, 0xd8, 0x76, 0x89, 0xa5, 0x4d, 0xf4, 0xde, 0x05, 0x0a,
+	0x55, 0xff, 0xb3, 0x41, 0x98, 0xcc, 0xf4, 0xf8, 0xfb, 0xb3, 0x9f, 0x16, 0xa0, 0xea, 0xe0, 0x03,
+	0xe2, 0xec, 0x51, 0x2b, 0x10, 0xdb, 0x69, 0xcc, 0x88, 0x01, 0xe8, 0x7d, 0x18, 0x37, 0x7d, 0x82,
+	0x19, 0xd9, 0x38, 0x21, 0x2e, 0x0b, 0xe4, 0x86, 0x12, 0x6b, 0x92, 0x82, 0xf3, 0x7d, 0x65, 0x11,
+	0x87, 0x30, 0x22, 0xba, 0x19, 0x15, 0xdd, 0x24, 0x20, 0x7c, 0xb7, 0x1c, 0xf8, 0xf4, 0x98, 0xb8,
+	0x7b, 0xd4, 0xda, 0xe2, 0xbd, 0x7f, 0x43, 0xce, 0xd4, 0xce, 0xca, 0x37, 0xa0, 0xbb, 0x30, 0x9d,
+	0x06, 0x8a, 0x69, 0xd0, 0xaa, 0x02, 0xbf, 0xa8, 0x89, 0xf7, 0x6f, 0xbb, 0x36, 0x5b, 0xa3, 0x2e,
+	0xe3, 0x73, 0xee, 0x8b, 0x9d, 0x0b, 0xb2, 0xff, 0x5c, 0x83, 0xfe, 0x3d, 0xcc, 0xaf, 0xed, 0x7d,
+	0xbb, 0x8f, 0xfd, 0x16, 0x61, 0xdf, 0x32, 0xdb, 0xb1, 0x7f, 0x16, 0x1b, 0x4b, 0x2d, 0xcd, 0x63,
+	0xd0, 0x98, 0x68, 0x5a, 0x39, 0x21, 0x3e, 0x6e, 0x91, 0x04, 0x86, 0x58, 0xab, 0x61, 0xa3, 0x6b,
+	0xbb, 0xfe, 0x7f, 0x2b, 0x50, 0x35, 0x48, 0x40, 0x3b, 0x3e, 0xdf, 0xf5, 0x0f, 0x61, 0xc4, 0xb1,
+	0xdb, 0x36, 0x0b, 0xb4, 0xca, 0xcd, 0xc1, 0xdb, 0xb5, 0xe5, 0x1b, 0xf1, 0xfa, 0x44, 0x48, 0x4b,
+	0x5b, 0x02, 0x63, 0xc3, 0x65, 0xfe, 0x99, 0xa1, 0xd0, 0xd1, 0xe7, 0x30, 0xe6, 0x93, 0xdf, 0x76,
+	0x48, 0xc0, 0x02, 0x6d, 0x40, 0x90, 0xde, 0x2a, 0x22, 0x35, 0x14, 0x8e, 0x24, 0x8e, 0x48, 0xe6,
+	0x3f, 0x85, 0x5a, 0xa2, 0x57, 0xbe, 0x6b, 0x8e, 0xc9, 0x99, 0x18, 0x7b, 0xd5, 0xe0, 0x3f, 0xf9,
+	0x56, 0x10, 0x7c, 0x5c, 0xed, 0x24, 0xf9, 0xf0, 0x78, 0xe0, 0x51, 0x65, 0xfe, 0x33, 0xa8, 0xa7,
+	0x7a, 0xbd, 0x08, 0xb1, 0xfe, 0xe7, 0xa3, 0x50, 0x5f, 0xa3, 0x3e, 0x59, 0xdf, 0x69, 0x5e, 0x6a,
+	0x9b, 0xeb, 0x30, 0x6e, 0xca, 0x6e, 0x36, 0xc5, 0x86, 0x95, 0x2f, 0x4a, 0xc1, 0x04, 0x27, 0x93,
+	0xcf, 0xfb, 0x6a, 0xff, 0x73, 0x4e, 0x16, 0x41, 0xd0, 0x12, 0x20, 0xf5, 0xb4, 0xe7, 0x74, 0x5a,
+	0xb6, 0xbb, 0x99, 0xd8, 0xfa, 0x05, 0x2d, 0xe8, 0x39, 0x8c, 0xbb, 0xd4, 0x22, 0x4d, 0xe2, 0x10,
+	0x93, 0x51, 0x5f, 0x1c, 0x85, 0xb2, 0xfc, 0x29, 0x45, 0xc9, 0xcf, 0x8c, 0x4f, 0x3c, 0xc7, 0x36,
+	0xf1, 0x1a, 0xed, 0xb8, 0x4c, 0x9c, 0x99, 0xba, 0xc4, 0x4b, 0xc2, 0x0b, 0x78, 0xe2, 0xe8, 0x25,
+	0x78, 0xe2, 0x27, 0x50, 0xf5, 0xc3, 0x8d, 0x21, 0x4e, 0x56, 0x6d, 0x79, 0xba, 0x60, 0xcf, 0x08,
+	0xda, 0x18, 0x13, 0x6d, 0xc1, 0xa4, 0x4f, 0x1d, 0xc7, 0x76, 0x5b, 0xdb, 0xf8, 0xb4, 0xd9, 0xf1,
+	0x5b, 0xf2, 0x98, 0xd5, 0x96, 0xaf, 0xe7, 0x78, 0xc9, 0xae, 0x2f, 0xc7, 0xf1, 0x8c, 0xfa, 0x7b,
+	0xab, 0xa2, 0x9f, 0x2c, 0x29, 0xfa, 0x1e, 0x66, 0x63, 0xd0, 0xb7, 0x2e, 0x3e, 0xc1, 0xb6, 0xc3,
+	0x97, 0x54, 0x71, 0xfb, 0x32, 0x7d, 0x16, 0x77, 0x80, 0x28, 0x2c, 0x88, 0x0f, 0x66, 0xf6, 0xca,
+	0xe1, 0x21, 0x3f, 0xd1, 0x67, 0xe2, 0xf4, 0x47, 0xcb, 0x55, 0x13, 0x2f, 0xf8, 0x20, 0xfd, 0x82,
+	0xa6, 0x63, 0x9b, 0x64, 0xf7, 0xb0, 0xcb, 0x0c, 0x9e, 0xdb, 0x21, 0x7a, 0x05, 0x37, 0x33, 0xed,
+	0xfb, 0xc4, 0x6f, 0xa7, 0x5f, 0x3a, 0x7e, 0xf1, 0x97, 0xf6, 0xec, 0x14, 0x6d, 0x43, 0x8d, 0x51,
+	0x87, 0xf8, 0x6a, 0x4f, 0xd4, 0x2f, 0xfe, 0x8e, 0x24, 0xbd, 0xfe, 0x3d, 0xdc, 0x5c, 0x27, 0x87,
+	0xb8, 0xe3, 0xb0, 0x3d, 0x6a, 0xad, 0xdb, 0x81, 0xdf, 0xf1, 0x78, 0xc3, 0x6a, 0xc7, 0x6a, 0x11,
+	0x76, 0x99, 0x53, 0xaa, 0x7f, 0x07, 0x73, 0xaa, 0xe7, 0x68, 0x77, 0xa9, 0xfe, 0x92, 0xec, 0x4b,
+	0x76, 0x58, 0xc4, 0xbe, 0x42, 0x3e, 0xa3, 0x64, 0x6c, 0x44, 0xa2, 0xff, 0x65, 0x1d, 0xa6, 0x37,
+	0x5a, 0x3e, 0x09, 0x82, 0xaf, 0x30, 0x23, 0xaf, 0xf0, 0x99, 0xea, 0xf6, 0x19, 0x34, 0x70, 0x87,
+	0xd1, 0xc0, 0xc4, 0x0e, 0xd9, 0x28, 0x3d, 0xde, 0x1c, 0x0d, 0x67, 0x2f, 0x11, 0x6c, 0x1b, 0x9f,
+	0x2a, 0x25, 0x31, 0x05, 0x4b, 0xe3, 0xd8, 0xae, 0x52, 0x18, 0x53, 0x30, 0xf4, 0x3e, 0x4c, 0x98,
+	0xd4, 0x75, 0x89, 0xc9, 0xf6, 0xed, 0x36, 0xa1, 0x1d, 0xa6, 0xd8, 0x4b, 0x06, 0x8a, 0x1e, 0xc3,
+	0xa0, 0xe9, 0x75, 0x14, 0x47, 0x79, 0x37, 0xa1, 0x65, 0x74, 0x95, 0x41, 0x62, 0x19, 0x39, 0x11,
+	0xfa, 0x02, 0xea, 0x96, 0x8f, 0x6d, 0x77, 0x5d, 0x29, 0xd2, 0x82, 0x9b, 0x70, 0x5d, 0x25, 0xfb,
+	0xc1, 0x21, 0x82, 0x91, 0xc6, 0x4f, 0xae, 0xed, 0x68, 0x79, 0x0e, 0xbc, 0x0c, 0x83, 0xc4, 0x3d,
+	0x51, 0x7c, 0xa4, 0x27, 0x43, 0x32, 0x38, 0x72, 0xa8, 0x9c, 0xcc, 0xc7, 0xca, 0xc9, 0x27, 0x30,
+	0x22, 0x54, 0x89, 0x40, 0xf1, 0x94, 0xb7, 0xe3, 0x8e, 0xd4, 0xca, 0x8a, 0xad, 0x1f, 0xee, 0x00,
+	0x85, 0x8c, 0x10, 0x0c, 0xb9, 0x5c, 0x7e, 0x5f, 0x15, 0x3d, 0x89, 0xdf, 0x39, 0xf6, 0x0c, 0x7d,
+	0xb3, 0xe7, 0x3c, 0xdb, 0xad, 0x5d, 0x82, 0xed, 0xf6, 0xe2, 0x4b, 0xe3, 0xbf, 0x04, 0x5f, 0xaa,
+	0xbf, 0x09, 0xbe, 0xf4, 0x21, 0x0c, 0x7b, 0xd4, 0x67, 0x81, 0x36, 0x21, 0x14, 0x92, 0xd9, 0xb8,
+	0xf7, 0x3d, 0x0e, 0x56, 0x6b, 0x28, 0x71, 0xd2, 0xd2, 0x68, 0xb2, 0xb4, 0x34, 0x7a, 0x02, 0xf5,
+	0x80, 0x98, 0x3e, 0x61, 0x2f, 0xa8, 0xd3, 0x69, 0x93, 0x40, 0x6b, 0x88, 0x77, 0xcd, 0xc5, 0xa4,
+	0xcd, 0x44, 0xb3, 0x91, 0x46, 0x46, 0x7b, 0x80, 0x02, 0xe2, 0x9f, 0xd8, 0x26, 0x49, 0xae, 0xee,
+	0x54, 0xc9, 0x3d, 0x5c, 0x40, 0xcb, 0x77, 0x22, 0x37, 0x74, 0x35, 0x24, 0x77, 0x22, 0xff, 0x8d,
+	0x3e, 0x84, 0xa1, 0x9f, 0x4f, 0x3c, 0x57, 0x9b, 0xce, 0xaa, 0xdc, 0x3f, 0x12, 0x9f, 0xbe, 0xd8,
+	0xdb, 0x51, 0x13, 0x21, 0x90, 0xb2, 0xcc, 0x7c, 0xe6, 0x72, 0xcc, 0xbc, 0x48, 0x5a, 0xcf, 0xbe,
+	0x01, 0x69, 0x3d, 0x77, 0x59, 0x69, 0xbd, 0x0d, 0x75, 0x53, 0x4c, 0x43, 0xb8, 0x8e, 0x57, 0x2e,
+	0xf4, 0xe1, 0x46, 0x9a, 0x1a, 0xfd, 0x06, 0x66, 0xb0, 0x65, 0xd9, 0x7c, 0x0e, 0xb0, 0x13, 0xa9,
+	0xf2, 0x81, 0xa6, 0x5d, 0xac, 0xd7, 0xc2, 0x4e, 0x42, 0x0b, 0xea, 0x5a, 0x09, 0x0b, 0x4a, 0x58,
+	0x19, 0x3f, 0x11, 0x93, 0xf7, 0xb1, 0x4f, 0xda, 0x9e, 0x83, 0x19, 0xd1, 0x16, 0x42, 0x2b, 0x23,
+	0xd3, 0xa0, 0x7b, 0x30, 0x23, 0xa5, 0xd8, 0x16, 0x35, 0x8f, 0x2d, 0xfa, 0xca, 0xbd, 0xac, 0x4e,
+	0x8c, 0x1d, 0x87, 0xbe, 0x22, 0xd6, 0x73, 0x1a, 0x9a, 0x05, 0x55, 0x23, 0x05, 0xd3, 0xff, 0xb6,
+	0x02, 0x68, 0xc3, 0x3d, 0xa1, 0x67, 0xdb, 0x84, 0xf9, 0xb6, 0x19, 0x5c, 0xea, 0x85, 0x08, 0x86,
+	0x8e, 0x68, 0xc0, 0x94, 0xf2, 0x2d, 0x7e, 0x73, 0x18, 0x3f, 0xdf, 0x42, 0x1a, 0x0e, 0x1b, 0xe2,
+	0x37, 0x5a, 0x85, 0x1a, 0x73, 0x82, 0x26, 0x61, 0xcc, 0x76, 0x5b, 0x81, 0x10, 0x81, 0x65, 0x8e,
+	0x5b, 0x92, 0x08, 0xad, 0xc3, 0x38, 0x33, 0xbd, 0x6f, 0x08, 0xf1, 0xb0, 0x63, 0x9f, 0x90, 0xb2,
+	0xca, 0xb7, 0x91, 0xa2, 0xd2, 0x3f, 0x87, 0xe9, 0x02, 0xb1, 0xc2, 0xe5, 0x12, 0xf6, 0xbc, 0xd0,
+	0x82, 0xc1, 0x9e, 0x27, 0x2c, 0xe1, 0x80, 0xd9, 0x34, 0xb4, 0x60, 0xc4, 0x83, 0xfe, 0x3f, 0x2b,
+	0x30, 0xa1, 0xe8, 0x43, 0xd2, 0x1d, 0x98, 0x16, 0x6d, 0x2f, 0x89, 0x58, 0xc8, 0x96, 0x6c, 0x55,
+	0xb3, 0x98, 0x90, 0x66, 0x05, 0xda, 0x8a, 0x81, 0x04, 0xe5, 0x46, 0x92, 0x30, 0xb9, 0x12, 0x03,
+	0xe5, 0x57, 0xe2, 0x8f, 0x60, 0x46, 0x8e, 0xc2, 0x76, 0x53, 0xc3, 0x18, 0xca, 0x1e, 0xd3, 0x4d,
+	0xb7, 0x60, 0x1c, 0xf2, 0x0b, 0x36, 0x53, 0xa4, 0xfa, 0x5f, 0xdd, 0x82, 0xf1, 0xaf, 0x1c, 0x7a,
+	0x20, 0x4e, 0x02, 0xff, 0xd2, 0xdb, 0x30, 0x84, 0x7d, 0xf3, 0x48, 0x7d, 0xda, 0x4c, 0xdc, 0x67,
+	0xec, 0x6d, 0x33, 0x04, 0x06, 0xfa, 0x06, 0xc6, 0x4d, 0xe2, 0x33, 0xfb, 0xd0, 0x36, 0x31, 0x23,
+	0x81, 0x76, 0xfb, 0x62, 0x87, 0x30, 0x45, 0x8c, 0xd6, 0x61, 0x52, 0x1e, 0xf5, 0xb5, 0x23, 0x62,
+	0x1e, 0x07, 0x9d, 0x76, 0xa0, 0x6d, 0xf4, 0x9c, 0x98, 0x2c, 0x89, 0xf0, 0x5a, 0x09, 0x50, 0xe4,
+	0x71, 0x52, 0x2b, 0x9b, 0x05, 0xa3, 0xbb, 0x30, 0x2d, 0x41, 0x06, 0xa5, 0x2c, 0xc6, 0x5e, 0x96,
+	0x9e, 0x85, 0x82, 0x26, 0xae, 0x74, 0x2a, 0x66, 0x84, 0x1d, 0xdb, 0x92, 0x3a, 0xd8, 0x60, 0x6f,
+	0xa5, 0x33, 0x4b, 0x83, 0xfe, 0x1e, 0x5c, 0x33, 0xa9, 0xcb, 0x7c, 0xea, 0xec, 0x39, 0xd8, 0x25,
+	0x4d, 0x62, 0x76, 0x7c, 0x9b, 0x9d, 0x85, 0x7a, 0xec, 0x50, 0xcf, 0x2e, 0xcf, 0x23, 0x47, 0xcf,
+	0xe1, 0x86, 0x25, 0x75, 0x71, 0xb9, 0x56, 0x2f, 0xec, 0xc0, 0x3e, 0xb0, 0x1d, 0x9b, 0x9d, 0x45,
+	0x07, 0xf3, 0xbe, 0x60, 0x18, 0xbd, 0xd0, 0xd0, 0x0b, 0x98, 0x56, 0x28, 0x3b, 0x49, 0x7d, 0x6b,
+	0xe4, 0x02, 0x3a, 0x52, 0x51, 0x07, 0xc8, 0x85, 0x79, 0xab, 0xab, 0x1d, 0xa2, 0x54, 0xd3, 0xc5,
+	0xb8, 0xfb, 0x5e, 0x36, 0x8b, 0x78, 0xd1, 0x39, 0x3d, 0xf2, 0x43, 0x13, 0xb7, 0x36, 0xcd, 0x23,
+	0x62, 0x75, 0xb8, 0xa0, 0xd2, 0x36, 0xb3, 0x67, 0x37, 0xd5, 0xac, 0x76, 0x7a, 0x21, 0x29, 0xda,
+	0x82, 0x69, 0xcb, 0x0e, 0xf8, 0x84, 0x4b, 0x5f, 0xab, 0xdc, 0x80, 0x4a, 0x49, 0x3e, 0x6f, 0xe9,
+	0x8a, 0xc8, 0xd0, 0x1e, 0x34, 0xac, 0x8c, 0xf9, 0xa4, 0xd4, 0xe4, 0x9b, 0xb9, 0x69, 0xc8, 0x18,
+	0x58, 0xe2, 0xe3, 0x73, 0xd4, 0xe8, 0x37, 0x80, 0x14, 0x6c, 0x3f, 0xa1, 0x73, 0x3c, 0xbc, 0xb8,
+	0xce, 0x51, 0xd0, 0x0d, 0x7a, 0x06, 0x13, 0x24, 0x25, 0xcd, 0xb4, 0x67, 0x59, 0xf6, 0x53, 0x24,
+	0xed, 0x8c, 0x0c, 0x15, 0x5a, 0x85, 0x09, 0xc9, 0xd7, 0x9e, 0x13, 0xa7, 0xbd, 0x4f, 0x02, 0xa6,
+	0x54, 0xf9, 0xf3, 0xe6, 0x2f, 0x43, 0x81, 0xbe, 0x84, 0xba, 0x84, 0xec, 0xfb, 0xd8, 0xe4, 0x8b,
+	0x5a, 0xeb, 0xd9, 0x45, 0x9a, 0x20, 0xb4, 0x55, 0xc6, 0x63, 0x5b, 0xe5, 0x36, 0x4c, 0x0a, 0x87,
+	0xe8, 0x5e, 0xec, 0x5c, 0xaf, 0x4b, 0x1e, 0x92, 0x01, 0xa3, 0x45, 0x68, 0x44, 0x20, 0xa9, 0x8e,
+	0x06, 0xda, 0x7b, 0xe2, 0x70, 0xe5, 0xe0, 0x5c, 0x6a, 0x0b, 0xd8, 0x0b, 0xec, 0xdb, 0xd8, 0x65,
+	0xda, 0x17, 0xd2, 0x93, 0x95, 0x84, 0xa1, 0xeb, 0x00, 0xb6, 0xf7, 0x0c, 0xb7, 0x6d, 0xc7, 0x26,
+	0x81, 0xf6, 0xa5, 0xe8, 0x29, 0x01, 0xe1, 0x66, 0xa6, 0x7a, 0x3a, 0x53, 0x03, 0x5b, 0x91, 0x66,
+	0x66, 0x1a, 0x2a, 0xf0, 0x38, 0xab, 0x8f, 0xd9, 0xda, 0x84, 0xc2, 0x4b, 0x41, 0xd1, 0x0e, 0x4c,
+	0x39, 0xd4, 0xc4, 0xfc, 0xd4, 0x6f, 0x1d, 0xa8, 0x73, 0xaf, 0x74, 0xf4, 0xde, 0x12, 0x37, 0x4f,
+	0x8a, 0x1e, 0x41, 0xd5, 0xa1, 0xad, 0x95, 0xe0, 0xeb, 0x80, 0xba, 0xda, 0xbb, 0x3d, 0x57, 0x22,
+	0x46, 0x46, 0x0f, 0x61, 0xd4, 0xa1, 0xad, 0x16, 0x7f, 0xff, 0x54, 0xce, 0x40, 0x14, 0xd2, 0x69,
+	0x4b, 0x36, 0xab, 0xbd, 0x14, 0x62, 0xa3, 0x35, 0xa8, 0xb7, 0x49, 0x70, 0xb4, 0x71, 0xea, 0x61,
+	0x37, 0xe0, 0x1c, 0x19, 0x65, 0xc9, 0xb7, 0x93, 0xcd, 0x8a, 0x3c, 0x4d, 0x83, 0xe6, 0x60, 0x84,
+	0x03, 0x36, 0xd7, 0xb5, 0x4f, 0xc4, 0x3c, 0xa9, 0x27, 0xae, 0x8c, 0xf0, 0x5f, 0x3b, 0x84, 0xbd,
+	0xa2, 0xfe, 0x71, 0xa0, 0x14, 0xfd, 0x12, 0xca, 0x48, 0x92, 0x8a, 0xaf, 0x46, 0x9b, 0xba, 0x36,
+	0xa3, 0x1c, 0x89, 0x5b, 0x48, 0x42, 0xf9, 0xaf, 0x1b, 0x19, 0x28, 0x17, 0xbc, 0x6d, 0xe6, 0x04,
+	0x4a, 0x8f, 0x4f, 0x08, 0xde, 0xed, 0xfd, 0xad, 0x66, 0x28, 0x78, 0x39, 0x06, 0xfa, 0x12, 0xc6,
+	0xdb, 0x1d, 0x87, 0xd9, 0x2a, 0xbe, 0xa1, 0xb4, 0xf4, 0x85, 0x04, 0x45, 0xa2, 0x55, 0x51, 0xa6,
+	0x28, 0xd0, 0x43, 0xa8, 0x8a, 0x67, 0x2e, 0xd3, 0xb5, 0xd5, 0x6c, 0xd0, 0x63, 0x3b, 0x6c, 0x52,
+	0xb4, 0x31, 0x2e, 0xd2, 0x60, 0xd4, 0x95, 0x1f, 0xa6, 0x7d, 0x20, 0xe6, 0x2a, 0x7c, 0xe4, 0x93,
+	0xc8, 0xad, 0xeb, 0xdd, 0xa6, 0xb6, 0x2e, 0x36, 0xae, 0x7a, 0x42, 0x0f, 0x60, 0xce, 0xa3, 0xd6,
+	0xfa, 0x4e, 0xb3, 0x49, 0xb8, 0xd6, 0x90, 0x88, 0x11, 0x7d, 0x28, 0xf0, 0xba, 0xb4, 0xa2, 0xcf,
+	0xa1, 0xe6, 0x51, 0x2b, 0x14, 0x6f, 0xda, 0x53, 0x31, 0xc8, 0x6b, 0x69, 0x6e, 0xad, 0x1a, 0xd5,
+	0x30, 0x93, 0xf8, 0xe8, 0x8f, 0x61, 0x81, 0xb6, 0x6d, 0xd6, 0xb4, 0x2d, 0x62, 0x62, 0x7f, 0x53,
+	0xe8, 0xe4, 0x54, 0x4d, 0xc6, 0x36, 0xf6, 0xb4, 0xf7, 0x7b, 0x6e, 0xcf, 0x73, 0xe9, 0xd1, 0x53,
+	0x18, 0xa7, 0x6e, 0x1c, 0xd8, 0x52, 0x76, 0xcd, 0x79, 0xfd, 0xa5, 0xf0, 0x91, 0x01, 0x73, 0xd4,
+	0xe3, 0x3c, 0x95, 0xfa, 0xdb, 0xd8, 0xc5, 0x2d, 0xf2, 0x1d, 0x39, 0x38, 0xa2, 0xf4, 0x38, 0xd0,
+	0x7e, 0xd5, 0xb3, 0xa7, 0x2e, 0x94, 0xe8, 0x37, 0x30, 0x4b, 0x3b, 0xec, 0x80, 0x76, 0x5c, 0x6b,
+	0xdf, 0xc7, 0x87, 0x87, 0xb6, 0xa9, 0xd8, 0x84, 0x34, 0x8f, 0xde, 0x8b, 0x27, 0x6f, 0xb7, 0x08,
+	0x4d, 0x4d, 0x63, 0x71, 0x1f, 0x68, 0x1e, 0xc6, 0xb8, 0x35, 0x73, 0x48, 0xfd, 0xb6, 0xb6, 0x26,
+	0x03, 0x68, 0xe1, 0x33, 0x97, 0x87, 0x5e, 0x2c, 0xd1, 0x9e, 0x61, 0xdb, 0xd9, 0xf5, 0x88, 0x2b,
+	0xdc, 0x36, 0x3d, 0xe4, 0x61, 0x01, 0x19, 0x67, 0xc0, 0x12, 0x1c, 0xcf, 0xae, 0x74, 0x25, 0x65,
+	0xc1, 0xe8, 0x2e, 0x4c, 0x79, 0xbe, 0x4d, 0xc5, 0x1e, 0x70, 0x70, 0x10, 0x88, 0x60, 0xcf, 0xb5,
+	0x28, 0x32, 0x95, 0x6f, 0xe4, 0x6a, 0x9f, 0xe7, 0xd3, 0x36, 0x61, 0x47, 0xa4, 0x13, 0xc4, 0xfd,
+	0x7f, 0x2c, 0xd5, 0xbe, 0x82, 0x26, 0xe1, 0xed, 0xf0, 0xe9, 0xe9, 0x99, 0x30, 0xef, 0xd2, 0xde,
+	0x0e, 0x0e, 0x8e, 0xbc, 0x1d, 0xfc, 0x81, 0x9f, 0x2b, 0xf1, 0x63, 0xd3, 0xb5, 0x99, 0xf6, 0x76,
+	0xf6, 0x5c, 0xed, 0x85, 0x4d, 0xe1, 0xb9, 0x8a, 0x70, 0x11, 0x86, 0xe9, 0xd0, 0xf9, 0x91, 0x74,
+	0x59, 0x7c, 0x25, 0xbc, 0x1e, 0x77, 0xb2, 0xcc, 0x50, 0xd2, 0x47, 0xde, 0x93, 0x04, 0x85, 0x0c,
+	0x00, 0x15, 0xf5, 0x85, 0x0c, 0x98, 0x08, 0xc1, 0xd2, 0x2a, 0xd2, 0x9e, 0x8b, 0xde, 0x17, 0x7b,
+	0xf4, 0x2e, 0x91, 0x65, 0xc7, 0x99, 0x1e, 0xf8, 0xa1, 0x0f, 0xec, 0x9f, 0x39, 0xdb, 0xfe, 0x5a,
+	0x72, 0x4e, 0xf9, 0x84, 0xde, 0x83, 0xc1, 0xc0, 0x0a, 0xb4, 0xeb, 0x59, 0x7f, 0x4f, 0x73, 0x3d,
+	0xe4, 0x64, 0xbc, 0x3d, 0xb4, 0xb8, 0x6f, 0x94, 0xb0, 0xb8, 0x97, 0x00, 0x31, 0xe2, 0x90, 0x36,
+	0x61, 0x7e, 0x62, 0x5f, 0xdc, 0x94, 0x51, 0x9c, 0x7c, 0x0b, 0x5a, 0x82, 0x11, 0xe6, 0x63, 0x93,
+	0xf8, 0xda, 0x2d, 0xd1, 0x7b, 0xc2, 0x73, 0xb4, 0x2f, 0xe0, 0xa1, 0xab, 0x51, 0x62, 0xa1, 0x9b,
+	0x50, 0x63, 0x7e, 0x27, 0x60, 0xeb, 0xb4, 0x8d, 0x6d, 0x57, 0xd3, 0x45, 0xc7, 0x49, 0x90, 0x18,
+	0x41, 0xfc, 0xb8, 0xe2, 0xd8, 0x38, 0x20, 0x81, 0xb6, 0x28, 0x98, 0x58, 0x41, 0x0b, 0x5a, 0x86,
+	0x91, 0x4e, 0x40, 0xb6, 0xd7, 0xf6, 0xb4, 0x77, 0x7a, 0x9e, 0x03, 0x85, 0x89, 0x9e, 0x40, 0x4d,
+	0xc8, 0x68, 0x83, 0xb4, 0x29, 0x23, 0xda, 0x47, 0x3d, 0x09, 0x93, 0xe8, 0xe8, 0x05, 0x68, 0x32,
+	0x16, 0x2b, 0x9f, 0x9b, 0x27, 0xe6, 0x86, 0x6b, 0x79, 0xd4, 0x76, 0x59, 0xa0, 0xfd, 0xba, 0x67,
+	0x57, 0x5d, 0x69, 0x39, 0x2f, 0xf5, 0x05, 0x74, 0xcf, 0x76, 0x28, 0x5b, 0x13, 0x68, 0x09, 0x04,
+	0x6d, 0xa9, 0x37, 0x2f, 0x3d, 0x8f, 0x9e, 0x1f, 0x4a, 0xd5, 0x2e, 0xce, 0xf7, 0x8a, 0x65, 0x71,
+	0x45, 0x51, 0xbb, 0x23, 0x0f, 0x65, 0x41, 0x13, 0x5f, 0x8b, 0x44, 0x8f, 0x21, 0xc1, 0x5d, 0xb9,
+	0x1b, 0xf2, 0x2d, 0x5c, 0x08, 0x49, 0xe8, 0x7e, 0xb8, 0x53, 0x42, 0x9a, 0x7b, 0x82, 0xa6, 0x4b,
+	0x2b, 0xdf, 0x45, 0x62, 0x82, 0x2d, 0xed, 0x41, 0x76, 0x17, 0x6d, 0x0a, 0x78, 0xb8, 0x8b, 0x24,
+	0x16, 0xfa, 0x08, 0xa6, 0x3c, 0xf1, 0x8d, 0xc4, 0x67, 0x7b, 0x3e, 0x3d, 0xb1, 0x2d, 0xe2, 0x6b,
+	0x8f, 0xa4, 0x5f, 0x28, 0xd7, 0x80, 0x16, 0xa0, 0xfa, 0xd3, 0x2b, 0xa6, 0x78, 0xf4, 0xa7, 0xf2,
+	0x86, 0x46, 0x04, 0x10, 0x67, 0x88, 0x05, 0xda, 0xe3, 0xdc, 0x19, 0xda, 0x8f, 0xcf, 0x10, 0x0b,
+	0x38, 0x5f, 0xf6, 0xc9, 0x89, 0x2d, 0x94, 0x9f, 0xcf, 0x24, 0x5f, 0x0e, 0x9f, 0xb9, 0x8a, 0xdd,
+	0xa6, 0x1d, 0x97, 0x6d, 0x33, 0x27, 0xe0, 0x6f, 0x0e, 0xb4, 0x27, 0xbd, 0x55, 0xec, 0x34, 0x85,
+	0xb8, 0x46, 0x82, 0xc3, 0xd9, 0xfa, 0x5c, 0x5d, 0x23, 0x09, 0x01, 0xf3, 0xcf, 0x40, 0xeb, 0xc6,
+	0x85, 0x2e, 0x14, 0x6d, 0x5e, 0x81, 0xe9, 0x02, 0x7e, 0x73, 0xa1, 0x98, 0xf3, 0xaf, 0xa1, 0x1a,
+	0x4d, 0x0d, 0x3f, 0xce, 0xca, 0x8b, 0x2b, 0x34, 0x2e, 0x79, 0x2b, 0x28, 0x09, 0xd2, 0xff, 0x59,
+	0x05, 0xc6, 0x93, 0x6b, 0x88, 0x1e, 0x5d, 0xc0, 0x39, 0x26, 0xc4, 0x4b, 0xe4, 0x96, 0x89, 0x2c,
+	0x99, 0x15, 0x17, 0x3b, 0x67, 0x81, 0x1d, 0x94, 0xf0, 0xe9, 0x64, 0x28, 0xf4, 0x0f, 0x61, 0xba,
+	0x40, 0xd1, 0xe5, 0x9f, 0xeb, 0x88, 0x3b, 0x2b, 0x72, 0x0a, 0xe4, 0x83, 0xfe, 0x3f, 0x66, 0x61,
+	0xa6, 0xc8, 0xc5, 0xf3, 0x77, 0x32, 0x30, 0xf6, 0x25, 0xd4, 0xcd, 0x4e, 0xc0, 0x68, 0xbb, 0x29,
+	0x57, 0x57, 0x79, 0x28, 0xce, 0xb5, 0x01, 0x53, 0x04, 0x7c, 0x92, 0x2d, 0x72, 0xd0, 0x69, 0xa9,
+	0x6b, 0x50, 0xf2, 0x81, 0xcb, 0x36, 0x4b, 0x0a, 0x03, 0x79, 0x3d, 0x45, 0x3d, 0xe5, 0x03, 0x71,
+	0xd5, 0xfe, 0x03, 0x71, 0x70, 0xe1, 0x40, 0x5c, 0xed, 0x22, 0x81, 0xb8, 0x9b, 0x50, 0x23, 0xa7,
+	0x8c, 0xf8, 0x2e, 0x76, 0x36, 0xf7, 0x02, 0x6d, 0x5c, 0xc8, 0xaa, 0x24, 0x28, 0x34, 0x7f, 0x7f,
+	0x1d, 0x9b, 0xbf, 0x8f, 0x01, 0x8e, 0x1f, 0x05, 0x6a, 0x77, 0xa9, 0x00, 0xd2, 0x79, 0x03, 0x4c,
+	0x60, 0xa3, 0x75, 0x98, 0x8c, 0x9f, 0x9e, 0x33, 0xe6, 0x05, 0x25, 0x6e, 0x47, 0x65, 0x49, 0x12,
+	0xc1, 0xc2, 0xc9, 0x8b, 0x04, 0x0b, 0xdf, 0x87, 0x09, 0x87, 0x62, 0x6b, 0x15, 0x3b, 0xd8, 0x35,
+	0x89, 0xbf, 0xb9, 0xa7, 0x35, 0xe4, 0x5e, 0x4b, 0x43, 0xd1, 0x63, 0xd0, 0x92, 0x90, 0xa6, 0x60,
+	0x3a, 0x06, 0x76, 0x5b, 0x24, 0xd0, 0xa6, 0xc4, 0x0c, 0x75, 0x6d, 0x47, 0x1b, 0x80, 0x52, 0xa6,
+	0xa3, 0x08, 0x78, 0x69, 0xe8, 0xbc, 0x38, 0x58, 0x01, 0x41, 0x14, 0xd7, 0xfc, 0xe8, 0x9c, 0xb8,
+	0xe6, 0xf4, 0x6b, 0x8c, 0x6b, 0xce, 0xbc, 0xc1, 0xb8, 0xe6, 0xec, 0x2f, 0x11, 0xd7, 0x9c, 0x7b,
+	0xa3, 0x71, 0xcd, 0x2b, 0x25, 0xe2, 0x9a, 0xd9, 0xbb, 0x3d, 0x5a, 0x97, 0xbb, 0x3d, 0xab, 0xc9,
+	0xf8, 0xe7, 0xd5, 0x0b, 0xac, 0x43, 0x22, 0x18, 0xfa, 0xb1, 0xd4, 0xa6, 0xe7, 0xb3, 0x17, 0x28,
+	0xd2, 0x22, 0xa0, 0x69, 0x05, 0x49, 0xdd, 0x3a, 0x17, 0x41, 0xbd, 0x76, 0xf9, 0x08, 0xea, 0xc2,
+	0x6b, 0x88, 0xa0, 0xbe, 0x9d, 0x88, 0xa0, 0x3e, 0x50, 0x11, 0x54, 0x69, 0x27, 0xe8, 0xdd, 0xbe,
+	0xec, 0xc7, 0x13, 0xcf, 0x4d, 0x05, 0x53, 0x0b, 0xa2, 0x9f, 0x37, 0xde, 0x40, 0xf4, 0xf3, 0xe6,
+	0x65, 0xa3, 0x9f, 0x8b, 0xd0, 0xc0, 0x9e, 0xd8, 0x0c, 0x2c, 0x62, 0x16, 0xb7, 0xc4, 0xf7, 0xe7,
+	0xe0, 0xe8, 0x3e, 0xcc, 0x86, 0x8c, 0x39, 0x6d, 0xbc, 0x4b, 0x53, 0xa4, 0xb8, 0x31, 0x1b, 0x56,
+	0x7e, 0xe7, 0x92, 0x61, 0xe5, 0x6f, 0x60, 0x5c, 0x85, 0x96, 0xe4, 0x60, 0xdf, 0xbd, 0x60, 0x48,
+	0x27, 0x49, 0xdc, 0x35, 0x58, 0xfb, 0xde, 0xeb, 0x08, 0xd6, 0xe6, 0x02, 0xcb, 0xef, 0x5f, 0x2a,
+	0xb0, 0xfc, 0x34, 0x13, 0xcb, 0xfa, 0xa0, 0xb7, 0x3b, 0x27, 0x15, 0xbe, 0xfa, 0x08, 0x06, 0x99,
+	0x13, 0x86, 0xc0, 0xce, 0x23, 0xe3, 0x68, 0xe8, 0x47, 0xd0, 0x22, 0x93, 0xf5, 0x25, 0xb6, 0x2c,
+	0xea, 0xbe, 0x54, 0xf1, 0xb8, 0xd0, 0xfd, 0xd3, 0xfb, 0x8c, 0xcd, 0xb1, 0x84, 0xb1, 0x42, 0xdd,
+	0x30, 0x5e, 0x89, 0x3e, 0x87, 0xe1, 0x23, 0x11, 0x17, 0x5e, 0xbc, 0xd8, 0x84, 0x48, 0x2a, 0xb4,
+	0x0c, 0xb3, 0xf1, 0xd0, 0xa4, 0xc6, 0xf3, 0x52, 0xc8, 0xaa, 0x0f, 0xa5, 0x35, 0x16, 0x35, 0x4a,
+	0x63, 0x57, 0x38, 0x55, 0x94, 0x19, 0xbf, 0xd4, 0x6f, 0xe0, 0xfc, 0x4e, 0xb7, 0xc0, 0xf9, 0xbf,
+	0xae, 0xc0, 0x95, 0x2e, 0x4c, 0xae, 0xcf, 0x58, 0x76, 0x74, 0xf5, 0x79, 0x20, 0x79, 0xf5, 0x39,
+	0x75, 0x49, 0x65, 0xb0, 0xec, 0x25, 0x15, 0xfd, 0x08, 0xb4, 0x6e, 0x8c, 0xaa, 0xcf, 0xe1, 0xcd,
+	0xc1, 0x48, 0xd0, 0x39, 0x3c, 0xb4, 0x4f, 0xd5, 0xf8, 0xd4, 0x93, 0xfe, 0x1d, 0xdc, 0xf8, 0xa6,
+	0x73, 0x40, 0x7c, 0x97, 0x30, 0x12, 0x6c, 0xb8, 0x27, 0xdb, 0xf6, 0x29, 0xf1, 0x57, 0x2c, 0xec,
+	0x45, 0x0e, 0xde, 0x3e, 0xaf, 0xee, 0x59, 0x80, 0xb6, 0x28, 0xb6, 0x9a, 0x47, 0xc4, 0xb2, 0x62,
+	0xab, 0x63, 0x11, 0x1a, 0x7c, 0xfe, 0x5d, 0xf3, 0x6c, 0xff, 0xc8, 0x27, 0xc1, 0x11, 0x75, 0x2c,
+	0x65, 0x80, 0xe4, 0xe0, 0x48, 0x87, 0xa1, 0x36, 0xb5, 0xe4, 0x84, 0x4e, 0x2c, 0x4f, 0xc4, 0xd3,
+	0xc6, 0xa1, 0x86, 0x68, 0xd3, 0xff, 0x71, 0x05, 0x20, 0xf6, 0x62, 0xf7, 0x39, 0x37, 0x4b, 0x30,
+	0xc4, 0x6d, 0x8b, 0x12, 0xb6, 0x95, 0xc0, 0xe3, 0x02, 0x47, 0x0c, 0x4c, 0xde, 0x08, 0x96, 0x03,
+	0xf9, 0x47, 0x30, 0x5d, 0x10, 0x0f, 0xe8, 0x73, 0x40, 0xd2, 0xc1, 0xb3, 0xb9, 0xb5, 0x5a, 0x62,
+	0x48, 0x0a, 0x53, 0xff, 0x7f, 0x03, 0xb0, 0x20, 0x16, 0x2f, 0xe1, 0x6a, 0x10, 0xab, 0x18, 0x6e,
+	0xeb, 0x5d, 0xa8, 0x1f, 0x47, 0x2b, 0xcd, 0x15, 0x7e, 0x39, 0xa0, 0x5f, 0xc5, 0xf3, 0xda, 0x63,
+	0x23, 0x18, 0x69, 0x7a, 0xf4, 0x0c, 0x20, 0x76, 0x6b, 0xaa, 0x91, 0xbe, 0x9f, 0xf2, 0x49, 0xaa,
+	0xb6, 0x82, 0xae, 0x12, 0x94, 0xe8, 0x21, 0x0c, 0x07, 0xcc, 0xb2, 0xa9, 0x3a, 0x1f, 0x09, 0x35,
+	0xa4, 0xc9, 0xc1, 0x05, 0xd4, 0x12, 0x1f, 0x6d, 0x42, 0x2d, 0x60, 0xd8, 0x3c, 0xb6, 0x7c, 0xfb,
+	0x84, 0xf8, 0x2a, 0xbe, 0xfd, 0x41, 0x92, 0x3c, 0x6a, 0x2c, 0xe8, 0x24, 0x49, 0xcb, 0x0d, 0xed,
+	0x4e, 0x40, 0x42, 0x04, 0x63, 0x3d, 0x50, 0x16, 0xe3, 0xb9, 0x86, 0x76, 0x9a, 0x42, 0xff, 0xdb,
+	0x01, 0xb8, 0x2a, 0xde, 0x13, 0x7a, 0x94, 0xfe, 0x30, 0xfd, 0xbf, 0xcb, 0xe9, 0xff, 0xcb, 0x0a,
+	0xd4, 0xc4, 0x7b, 0xd4, 0x84, 0x7f, 0x0c, 0x23, 0xd2, 0xab, 0xaf, 0x66, 0x3a, 0x11, 0xe1, 0x49,
+	0xac, 0x52, 0x68, 0xea, 0x49, 0x54, 0xf4, 0x04, 0xaa, 0x91, 0x1c, 0x52, 0x73, 0x7a, 0x3d, 0x43,
+	0x17, 0x9d, 0xaf, 0xd0, 0xd7, 0x1e, 0x11, 0xa0, 0x55, 0x18, 0xc3, 0x6a, 0xd5, 0xd5, 0x6c, 0xbe,
+	0xdf, 0x8d, 0x38, 0xbd, 0x3b, 0x8c, 0x88, 0x4e, 0xff, 0x53, 0x80, 0xa9, 0xdc, 0xf8, 0x7e, 0xef,
+	0xdc, 0x2f, 0xca, 0xad, 0x32, 0xd4, 0x8f, 0x5b, 0x25, 0xc1, 0x13, 0x87, 0xfb, 0x90, 0xaf, 0x23,
+	0x49, 0xf9, 0xfa, 0x7a, 0x13, 0x1c, 0xb2, 0xa6, 0xd7, 0x58, 0x17, 0xd3, 0xeb, 0x8b, 0xc4, 0x3a,
+	0x4b, 0x1f, 0xcd, 0x3b, 0x85, 0x9b, 0xab, 0xdb, 0x22, 0x23, 0x03, 0xe6, 0x02, 0x12, 0x70, 0x39,
+	0x11, 0x1a, 0x8d, 0x1b, 0xa5, 0xfd, 0x36, 0x5d, 0x28, 0xd3, 0xaa, 0x46, 0xed, 0x32, 0xd9, 0x19,
+	0xe3, 0x6f, 0xc0, 0xe2, 0xa9, 0xbf, 0xe9, 0xec, 0x8c, 0x89, 0x5f, 0xc2, 0x5b, 0x30, 0xf9, 0x26,
+	0xbc, 0x05, 0x59, 0x7f, 0x4d, 0xa3, 0x6f, 0x7f, 0x8d, 0xf2, 0xec, 0x4d, 0x5d, 0xc4, 0xb3, 0x97,
+	0xb1, 0xfb, 0xd0, 0x25, 0xed, 0x3e, 0xe5, 0x06, 0x9c, 0xce, 0xa5, 0x13, 0xce, 0xf4, 0xd6, 0xe9,
+	0xf5, 0xbf, 0xa8, 0xc1, 0x4c, 0x11, 0xcf, 0x2d, 0x64, 0x87, 0x03, 0xaf, 0x81, 0x1d, 0x0e, 0x96,
+	0x60, 0x87, 0x43, 0xdd, 0xd9, 0xe1, 0xf0, 0x25, 0xd9, 0xe1, 0xc8, 0x85, 0x9d, 0xb6, 0xa3, 0x17,
+	0x59, 0xda, 0x88, 0x85, 0x8e, 0x25, 0x59, 0xe8, 0x97, 0x30, 0xee, 0x50, 0x6c, 0x05, 0x4a, 0x51,
+	0x57, 0x0c, 0x2d, 0x71, 0xe7, 0x23, 0xaf, 0xc6, 0x1b, 0x29, 0x8a, 0xdf, 0xdb, 0xc4, 0x89, 0x2c,
+	0x3b, 0x1f, 0xef, 0x9a, 0x25, 0x97, 0x63, 0x81, 0x93, 0x6f, 0x80, 0x05, 0x36, 0x2e, 0xcb, 0x02,
+	0xe3, 0xb8, 0xef, 0x54, 0xe9, 0xb8, 0xaf, 0x88, 0x67, 0x7a, 0xd4, 0x67, 0xab, 0x98, 0x99, 0x47,
+	0xdb, 0xf8, 0x74, 0xdf, 0x6e, 0x87, 0xc9, 0x06, 0x05, 0x2d, 0xe8, 0x3e, 0xcc, 0xa6, 0xa1, 0x1b,
+	0x2e, 0xf3, 0x6d, 0x22, 0xaf, 0x28, 0xd5, 0x8d, 0xe2, 0xc6, 0xb4, 0xec, 0xa9, 0x97, 0x96, 0x3d,
+	0xdd, 0xc5, 0xe0, 0x44, 0xdf, 0x62, 0xb0, 0x97, 0x9c, 0x98, 0xf9, 0x25, 0xe4, 0xc4, 0xec, 0xef,
+	0x20, 0x8b, 0x6f, 0xee, 0xf5, 0x70, 0xea, 0x2b, 0x39, 0x4e, 0xad, 0x95, 0xe0, 0xd4, 0x3f, 0xc0,
+	0x64, 0xe6, 0x6e, 0xd7, 0xeb, 0x4a, 0x3f, 0xd7, 0x1d, 0x40, 0xf9, 0x5b, 0x67, 0x7d, 0xf6, 0x7e,
+	0x13, 0x6a, 0x2a, 0xa3, 0x5f, 0x5c, 0xe8, 0x91, 0x6f, 0x49, 0x82, 0xf4, 0x7f, 0x52, 0x81, 0x6b,
+	0xe7, 0xdc, 0x61, 0x42, 0x4f, 0x53, 0x4e, 0x89, 0xc5, 0x52, 0x17, 0x9f, 0x96, 0xb6, 0x63, 0x87,
+	0xc5, 0x6d, 0x18, 0xe2, 0x4f, 0xa8, 0x0e, 0xd5, 0x95, 0xad, 0xad, 0xdd, 0xef, 0x5e, 0xae, 0xec,
+	0xfc, 0xd0, 0x78, 0x0b, 0x4d, 0x41, 0xdd, 0xd8, 0xf8, 0x6a, 0xb3, 0xb9, 0x6f, 0xfc, 0xf0, 0x72,
+	0x77, 0x67, 0xeb, 0x87, 0x46, 0x45, 0xff, 0xeb, 0x06, 0xd4, 0xe4, 0xb5, 0x86, 0xcb, 0x7c, 0xf1,
+	0x1b, 0x91, 0x94, 0x5d, 0x8c, 0x82, 0xac, 0x34, 0x1d, 0x2a, 0x90, 0xa6, 0x59, 0x9e, 0x3c, 0xdc,
+	0x85, 0x27, 0x17, 0xab, 0xfb, 0xf7, 0x61, 0x34, 0x90, 0xf7, 0xe6, 0xca, 0x64, 0x1a, 0x2a, 0x54,
+	0xf4, 0x2e, 0xd4, 0xc5, 0x5d, 0x9c, 0x26, 0x6e, 0x7b, 0xe2, 0x82, 0x36, 0x97, 0x7f, 0x15, 0x23,
+	0x0d, 0x4c, 0xf3, 0xb0, 0x6a, 0x69, 0x1e, 0x56, 0x90, 0x18, 0x00, 0xc5, 0x89, 0x01, 0x4a, 0x49,
+	0xa8, 0xf5, 0xa3, 0x24, 0x64, 0x45, 0xec, 0x78, 0xdf, 0x22, 0xd6, 0x84, 0x1b, 0xc7, 0x61, 0x3a,
+	0x0b, 0x97, 0x59, 0xc4, 0x3f, 0x11, 0x87, 0xca, 0x95, 0x1e, 0xd2, 0x95, 0x16, 0x89, 0x6a, 0x55,
+	0x74, 0x0d, 0x3b, 0xf7, 0xea, 0x01, 0x6d, 0x41, 0xc3, 0x22, 0x9e, 0x43, 0xcf, 0xda, 0xc4, 0x65,
+	0xea, 0x4e, 0xd8, 0x44, 0x49, 0x55, 0x25, 0x47, 0xd9, 0x93, 0xa5, 0x37, 0x7e, 0x09, 0x96, 0x3e,
+	0xf5, 0x26, 0x58, 0xfa, 0x23, 0xa8, 0x9a, 0xd1, 0x45, 0x52, 0xd4, 0xfb, 0x9e, 0x73, 0x84, 0x8c,
+	0x1e, 0xc0, 0xa8, 0x0a, 0x91, 0xa8, 0xf8, 0x6e, 0x42, 0x81, 0x13, 0x5c, 0x44, 0xf9, 0x93, 0xc3,
+	0x6b, 0xce, 0x0a, 0x39, 0xa1, 0x53, 0xcc, 0x94, 0xd6, 0x29, 0x94, 0xee, 0x39, 0x7b, 0x11, 0xdd,
+	0x33, 0xf6, 0xc6, 0xcc, 0xe5, 0xee, 0xdb, 0xf2, 0xe1, 0x15, 0x7a, 0x63, 0x0a, 0x14, 0x33, 0xed,
+	0x0d, 0x28, 0x66, 0x57, 0x2f, 0x9f, 0x8b, 0x98, 0x92, 0xc4, 0xf3, 0x97, 0x94, 0xc4, 0xdb, 0x50,
+	0xc7, 0x9e, 0x97, 0xb8, 0xcf, 0x7c, 0xed, 0x82, 0x11, 0xa8, 0x14, 0x35, 0x3a, 0x82, 0x5b, 0x52,
+	0x1a, 0xec, 0xf1, 0x25, 0x35, 0xa9, 0xd3, 0x74, 0x6d, 0xbe, 0x03, 0xf9, 0x77, 0x85, 0x52, 0x4b,
+	0x05, 0x60, 0xcf, 0x5b, 0xfd, 0xde, 0x9d, 0xa0, 0x43, 0xb8, 0xd9, 0x15, 0x69, 0xd3, 0x95, 0x2f,
+	0x7a, 0xbb, 0xe7, 0x8b, 0x7a, 0xf6, 0x51, 0x60, 0x26, 0x5c, 0xbf, 0x84, 0x99, 0xf0, 0x05, 0x8c,
+	0xcb, 0x73, 0x24, 0x2f, 0x64, 0xa8, 0x80, 0x6f, 0x76, 0x83, 0xae, 0x25, 0x50, 0x8c, 0x14, 0x01,
+	0x7a, 0x04, 0x57, 0x7e, 0x7a, 0x75, 0x1c, 0x70, 0x11, 0xe1, 0x9c, 0x10, 0x7f, 0xe3, 0x94, 0xf9,
+	0xd8, 0xa0, 0x94, 0xad, 0xad, 0xa8, 0x6b, 0xa4, 0xdd, 0x9a, 0xd1, 0x0a, 0x8c, 0x7a, 0xa2, 0x40,
+	0x48, 0xa0, 0x2e, 0x93, 0x96, 0x5e, 0xe3, 0x90, 0x2e, 0x54, 0x98, 0xf4, 0x9c, 0xda, 0xf6, 0x4e,
+	0x09, 0xb5, 0xed, 0xbf, 0x54, 0x00, 0xe5, 0xb9, 0x83, 0x48, 0xf3, 0x90, 0x80, 0xf0, 0xe6, 0x53,
+	0x45, 0xa5, 0x79, 0xa4, 0xa0, 0xe8, 0x5b, 0x98, 0xb5, 0x23, 0x42, 0xc6, 0xcf, 0x06, 0xf1, 0xb7,
+	0x63, 0xed, 0x28, 0x51, 0x8b, 0xa6, 0x10, 0xcd, 0x28, 0xa6, 0x16, 0x19, 0x2d, 0xaa, 0xc1, 0xc1,
+	0x41, 0xa0, 0xe2, 0x2c, 0x29, 0x98, 0xbe, 0x09, 0x53, 0x39, 0xbe, 0xd1, 0x67, 0xa4, 0xea, 0xdf,
+	0x56, 0x60, 0x32, 0xeb, 0x60, 0xe8, 0x4f, 0xd9, 0xfa, 0x10, 0x06, 0x4e, 0xee, 0x29, 0xf5, 0x2a,
+	0xb1, 0x7f, 0xa2, 0xce, 0x5f, 0xdc, 0x53, 0x0c, 0x6e, 0xe0, 0xe4, 0x9e, 0x40, 0x5e, 0x56, 0x6e,
+	0xe2, 0x42, 0xe4, 0xe5, 0x08, 0x79, 0x99, 0x7f, 0x6e, 0xae, 0x97, 0x3e, 0x3f, 0xf7, 0x3f, 0x0f,
+	0x24, 0xfb, 0x5a, 0xbe, 0xd4, 0x07, 0x7f, 0x0f, 0x53, 0x6d, 0xc2, 0xb0, 0x85, 0x19, 0x7e, 0x49,
+	0x4e, 0xcd, 0x23, 0xec, 0xaa, 0x02, 0x38, 0xb5, 0xe5, 0x0f, 0x0b, 0x3f, 0x69, 0x5b, 0x61, 0x6f,
+	0x28, 0x64, 0xf5, 0x89, 0x8d, 0x76, 0x06, 0x8e, 0x36, 0x0a, 0xa2, 0x1b, 0xef, 0x15, 0x76, 0x19,
+	0x07, 0x3a, 0x0a, 0x82, 0x1b, 0xcf, 0xd3, 0x31, 0x8a, 0x9c, 0x53, 0x3e, 0xd1, 0x8f, 0x08, 0x57,
+	0xac, 0x0b, 0xbc, 0x82, 0x10, 0x85, 0x8e, 0xe1, 0x56, 0xcf, 0xef, 0x40, 0x4f, 0xa0, 0xf6, 0x0a,
+	0x07, 0xed, 0xf2, 0x8a, 0x76, 0x12, 0x5d, 0xff, 0xf3, 0x0a, 0x5c, 0x3b, 0xe7, 0xc3, 0xfa, 0x5c,
+	0xa3, 0xcb, 0x8d, 0xe9, 0xcf, 0x06, 0x61, 0xe1, 0xbc, 0x49, 0xea, 0x73, 0x50, 0xf7, 0xe3, 0xb4,
+	0xac, 0x12, 0x59, 0xca, 0x61, 0x4e, 0xd6, 0x63, 0x80, 0x38, 0xb5, 0xa9, 0x44, 0x8a, 0x6c, 0x02,
+	0x1b, 0x3d, 0x80, 0x31, 0x46, 0x3d, 0xea, 0xd0, 0xd6, 0x59, 0x89, 0x4c, 0xd8, 0x08, 0x17, 0xad,
+	0xc3, 0xa4, 0x4a, 0xad, 0x8c, 0x64, 0x65, 0x6f, 0x37, 0x5d, 0x96, 0x04, 0x3d, 0x17, 0xd7, 0x55,
+	0x0f, 0xed, 0xd6, 0xee, 0x09, 0xf1, 0x7d, 0xdb, 0x2a, 0x9f, 0x7f, 0x9e, 0xa1, 0xd3, 0x37, 0x14,
+	0xe3, 0x4b, 0xca, 0x23, 0x74, 0x17, 0xa6, 0x83, 0xce, 0x41, 0x60, 0xfa, 0xf6, 0x01, 0xb1, 0xe2,
+	0x5c, 0xcf, 0x8a, 0xb8, 0x74, 0x58, 0xd4, 0xc4, 0xb9, 0xc0, 0x74, 0x41, 0x5a, 0x2a, 0x6a, 0x66,
+	0x0c, 0x8d, 0x4a, 0x36, 0x4f, 0xa4, 0x80, 0x68, 0x29, 0x99, 0x85, 0x2b, 0xd3, 0x39, 0xd2, 0x36,
+	0xc7, 0x66, 0x5a, 0x3f, 0x1a, 0xb8, 0x98, 0xa8, 0x4b, 0xe9, 0x46, 0x4f, 0x60, 0x0c, 0x2b, 0xf5,
+	0x59, 0x6d, 0x80, 0xde, 0x53, 0x18, 0x51, 0xcc, 0x7f, 0x01, 0x53, 0xb9, 0xb1, 0x5e, 0xe8, 0x2a,
+	0xf8, 0x9f, 0x56, 0x60, 0x2a, 0x97, 0x1f, 0xc6, 0xf7, 0xa5, 0x4f, 0x02, 0xe6, 0xdb, 0x26, 0x2b,
+	0x75, 0x0c, 0x12, 0xd8, 0x5c, 0xe5, 0xa7, 0x1e, 0x71, 0x83, 0x23, 0xfb, 0x90, 0x95, 0x38, 0x0b,
+	0x31, 0xb2, 0xfe, 0x5b, 0xa8, 0x25, 0xae, 0x0f, 0x46, 0x57, 0x3f, 0x2b, 0x89, 0xab, 0x9f, 0x61,
+	0x31, 0x85, 0x81, 0x44, 0x31, 0x85, 0x79, 0x18, 0xe3, 0x8b, 0xb3, 0x17, 0x17, 0x59, 0x88, 0x9e,
+	0xd1, 0x75, 0x00, 0x59, 0x77, 0x4e, 0xb4, 0x0e, 0x89, 0xd6, 0x04, 0x44, 0xff, 0x6f, 0x55, 0x68,
+	0xe4, 0xd8, 0x52, 0x94, 0x1c, 0x12, 0xb7, 0x84, 0xfb, 0xac, 0xc4, 0x5c, 0x74, 0xa5, 0xed, 0xb3,
+	0x92, 0x41, 0xd6, 0xc1, 0x30, 0xd8, 0xc5, 0xc1, 0xa0, 0xf4, 0xa6, 0xa1, 0x9c, 0xde, 0x34, 0x5c,
+	0xe2, 0xb2, 0xd1, 0x02, 0x54, 0x7d, 0xc2, 0x88, 0x1b, 0x95, 0x4b, 0xaa, 0x1a, 0x31, 0x20, 0x67,
+	0xac, 0x8f, 0xf6, 0x6d, 0xac, 0xaf, 0xc0, 0x44, 0x60, 0xfa, 0x58, 0xbd, 0xff, 0x04, 0x3b, 0x2a,
+	0x13, 0xfc, 0x1c, 0xdb, 0x3c, 0x43, 0x20, 0x5c, 0x5e, 0xd4, 0x65, 0xe4, 0x94, 0xed, 0x61, 0x76,
+	0xa4, 0x0a, 0x1c, 0x26, 0x41, 0xe8, 0x33, 0x18, 0x55, 0xb7, 0x2a, 0x95, 0x6f, 0xe2, 0x56, 0xd1,
+	0x2d, 0x02, 0xa5, 0xf3, 0x85, 0xf6, 0xa3, 0xa2, 0x40, 0x4f, 0x61, 0x2c, 0x08, 0x33, 0x29, 0xc7,
+	0xb3, 0x97, 0x2d, 0x93, 0xd4, 0xa9, 0x84, 0xca, 0x88, 0xe6, 0x35, 0x97, 0x22, 0xfb, 0x3b, 0x14,
+	0x25, 0x4c, 0xb9, 0xab, 0x1a, 0xa5, 0xdd, 0x55, 0xdb, 0x50, 0xe3, 0x7a, 0x4b, 0x48, 0xd8, 0x87,
+	0x17, 0x23, 0x49, 0x5f, 0x60, 0x89, 0xa1, 0x4b, 0x58, 0x62, 0x5a, 0xe8, 0xf4, 0x9b, 0x8e, 0x32,
+	0x2d, 0x95, 0xe3, 0x6f, 0x1f, 0xae, 0x78, 0x3e, 0x95, 0xc9, 0x47, 0x09, 0x06, 0x44, 0x54, 0xce,
+	0xf3, 0xf9, 0xbc, 0xa1, 0x1b, 0xa9, 0xfe, 0x1f, 0x2a, 0xb0, 0x70, 0xde, 0x3d, 0x99, 0x3e, 0x95,
+	0x9b, 0x5d, 0x98, 0x6d, 0xcb, 0xea, 0x38, 0x1b, 0xa7, 0x9e, 0xed, 0x9f, 0x45, 0xf9, 0x1c, 0x03,
+	0xbd, 0x0e, 0x6f, 0x31, 0x9d, 0xbe, 0x07, 0x5a, 0xb7, 0xa3, 0xd4, 0xa7, 0x11, 0xf0, 0xef, 0x2b,
+	0x70, 0xa5, 0xcb, 0xd9, 0x46, 0xab, 0x50, 0xc3, 0x89, 0x05, 0xad, 0x94, 0xad, 0xb6, 0x93, 0x20,
+	0x42, 0x1b, 0x09, 0x21, 0x33, 0x90, 0xbd, 0xe8, 0x94, 0x7b, 0xf1, 0x8e, 0x42, 0x0d, 0xb9, 0x43,
+	0x48, 0xaa, 0x1f, 0xc3, 0x8d, 0x1e, 0xc8, 0xfd, 0x57, 0x1e, 0x8a, 0x04, 0x63, 0x5d, 0x0a, 0x46,
+	0xfd, 0x5f, 0xd5, 0xa1, 0x96, 0xc8, 0xbc, 0x4d, 0xf6, 0xfc, 0x4e, 0xf9, 0x9e, 0xdf, 0x85, 0x3a,
+	0x36, 0x4d, 0x51, 0x8e, 0xa2, 0xf5, 0xcc, 0x76, 0x42, 0x79, 0x9c, 0x06, 0xa2, 0xdb, 0x30, 0x19,
+	0x03, 0xa8, 0xdf, 0xc6, 0x61, 0x11, 0xa4, 0x2c, 0x18, 0x6d, 0xc2, 0x54, 0x04, 0xda, 0x70, 0x4d,
+	0x6a, 0x85, 0xaa, 0xef, 0x44, 0xd2, 0x6a, 0xcc, 0xa1, 0x18, 0x79, 0x2a, 0x2e, 0xdd, 0x71, 0x87,
+	0x51, 0x99, 0x72, 0xae, 0x24, 0x5f, 0x02, 0xc2, 0x87, 0xae, 0x42, 0x21, 0x2a, 0x57, 0x55, 0x96,
+	0x7e, 0x4e, 0x03, 0xd1, 0x47, 0x30, 0x65, 0xd2, 0xb6, 0x47, 0x5d, 0xe2, 0xb2, 0xad, 0xb0, 0xf0,
+	0xb1, 0x94, 0x81, 0xf9, 0x06, 0x25, 0x7e, 0xcc, 0x8e, 0xef, 0x13, 0xd7, 0x3c, 0x13, 0xa2, 0xb0,
+	0x6e, 0x24, 0x41, 0x71, 0x8e, 0x9b, 0x28, 0xeb, 0xda, 0x69, 0x7b, 0xca, 0xf9, 0x5e, 0x22, 0xc7,
+	0x2d, 0xa4, 0x40, 0x3b, 0x30, 0x4d, 0x12, 0x45, 0xa9, 0x42, 0xaf, 0x05, 0x64, 0x3d, 0xa1, 0xf9,
+	0xca, 0x55, 0x46, 0x11, 0x21, 0x7a, 0x0a, 0x35, 0x01, 0x6e, 0x32, 0xcc, 0x02, 0x4b, 0x89, 0xc5,
+	0xf3, 0xfb, 0x49, 0x12, 0x70, 0x7d, 0x5c, 0x15, 0xa8, 0x56, 0x2e, 0x2b, 0x79, 0xe9, 0x5d, 0xd6,
+	0x02, 0x29, 0x6a, 0xe2, 0x1b, 0x22, 0x04, 0xef, 0xa9, 0x94, 0x21, 0x55, 0x1b, 0x24, 0x03, 0x8e,
+	0x23, 0x23, 0x13, 0xc9, 0xc8, 0xc8, 0x6d, 0x98, 0xb4, 0xdd, 0x34, 0x7d, 0x43, 0xd5, 0x16, 0x49,
+	0x83, 0x53, 0xf5, 0xaa, 0x51, 0xa6, 0x5e, 0xf5, 0x63, 0x6e, 0x75, 0xdb, 0x27, 0xb6, 0x43, 0x5a,
+	0xc4, 0x52, 0x8e, 0xe4, 0x73, 0x15, 0xd9, 0x18, 0x1b, 0xad, 0xc2, 0x82, 0x4f, 0xb0, 0x65, 0xbb,
+	0x24, 0x08, 0x36, 0x5d, 0x9b, 0xd9, 0xd8, 0x59, 0x27, 0x0e, 0x3e, 0x6b, 0x12, 0x93, 0xba, 0x56,
+	0xa0, 0x6a, 0x53, 0x9c, 0x8b, 0x23, 0xb3, 0x69, 0x55, 0xfb, 0x1e, 0xf1, 0x6d, 0xa1, 0x69, 0x0b,
+	0xea, 0x59, 0x41, 0xdd, 0xa5, 0x15, 0x3d, 0x81, 0xab, 0x51, 0xcb, 0x33, 0x6c, 0x3b, 0x1d, 0x9f,
+	0xc4, 0xf7, 0x8b, 0xe7, 0x04, 0x69, 0x77, 0x04, 0x7e, 0x2e, 0x02, 0x86, 0x59, 0x47, 0x64, 0x17,
+	0x88, 0x00, 0x68, 0xdd, 0x48, 0x40, 0xd2, 0xa2, 0x56, 0xbb, 0x40, 0x64, 0x28, 0x4c, 0x14, 0xbf,
+	0x2a, 0x8e, 0x6b, 0x23, 0xa6, 0x91, 0xf0, 0x28, 0x45, 0xfc, 0x31, 0x68, 0x9e, 0xf2, 0x76, 0xae,
+	0x13, 0xa6, 0xae, 0xaa, 0xab, 0xb4, 0x46, 0x59, 0xa0, 0xa0, 0x6b, 0x3b, 0xda, 0x87, 0x59, 0xb1,
+	0xf3, 0x56, 0xc2, 0xe3, 0x1e, 0x6e, 0xfe, 0x6b, 0xb9, 0xda, 0x39, 0x29, 0xb4, 0xb0, 0x26, 0x43,
+	0x21, 0x31, 0x5a, 0x86, 0x19, 0xb5, 0xef, 0x42, 0x13, 0x56, 0xee, 0x60, 0x59, 0x89, 0xae, 0xb0,
+	0x2d, 0x9f, 0xbe, 0xf8, 0xf6, 0x05, 0xd3, 0x17, 0xf3, 0x39, 0x9d, 0xd7, 0x0b, 0x73, 0x3a, 0xff,
+	0x08, 0xe6, 0x3c, 0xec, 0x13, 0x97, 0x35, 0x8f, 0x3a, 0xcc, 0xa2, 0xaf, 0xe2, 0x37, 0xde, 0xec,
+	0xf5, 0xc6, 0x2e, 0x84, 0xe8, 0x3e, 0x67, 0x20, 0x49, 0x96, 0x22, 0x6b, 0x39, 0xdf, 0x8a, 0xf4,
+	0x90, 0xa2, 0x66, 0x3e, 0x60, 0xda, 0x61, 0x8e, 0x4d, 0xfc, 0x2d, 0xda, 0x12, 0xea, 0xb5, 0x74,
+	0xc3, 0x66, 0xa0, 0xe8, 0x29, 0x54, 0x1d, 0xfb, 0x90, 0x98, 0x67, 0xa6, 0x43, 0x54, 0xe6, 0x4b,
+	0x6f, 0x79, 0x1a, 0x93, 0xe8, 0x7f, 0x32, 0x00, 0x33, 0x45, 0xab, 0xf7, 0x86, 0xca, 0xee, 0x55,
+	0x95, 0xa5, 0xb8, 0x51, 0x54, 0x76, 0xef, 0x9d, 0x6e, 0x1b, 0x2a, 0x81, 0xfa, 0x26, 0x2a, 0xef,
+	0xfd, 0x75, 0x05, 0xae, 0x76, 0x7d, 0x61, 0x74, 0x25, 0xbf, 0x12, 0x5f, 0xc9, 0x17, 0x82, 0xca,
+	0xb1, 0x89, 0x2b, 0x52, 0xe3, 0x55, 0x3e, 0x8d, 0xfa, 0xe6, 0x7c, 0x83, 0xf8, 0xd3, 0x03, 0xdf,
+	0x3e, 0xc1, 0x8c, 0x7c, 0x43, 0xce, 0xc2, 0x62, 0xdf, 0x31, 0x44, 0x6c, 0x4e, 0xbc, 0x96, 0xcc,
+	0xe4, 0x09, 0x13, 0x8e, 0x53, 0x50, 0x6e, 0x57, 0x06, 0xae, 0xad, 0x44, 0x27, 0xff, 0xc9, 0x59,
+	0x73, 0xd0, 0x39, 0xe0, 0x12, 0x76, 0xc5, 0x91, 0x55, 0xdf, 0xb4, 0x11, 0xe1, 0x98, 0xc9, 0x82,
+	0xf5, 0x3f, 0x86, 0xc9, 0x4c, 0x25, 0x8f, 0x98, 0xdb, 0x57, 0xba, 0xa6, 0x95, 0x0c, 0x97, 0x4e,
+	0x2b, 0x59, 0x83, 0x2b, 0x5d, 0x4a, 0x23, 0xf3, 0x61, 0x9b, 0x5e, 0x27, 0x74, 0x82, 0x98, 0x5e,
+	0x47, 0xd6, 0x2e, 0x6a, 0x53, 0x75, 0x0f, 0x5a, 0xd4, 0x2e, 0xe2, 0x4f, 0xfa, 0x7f, 0x1c, 0x80,
+	0x6a, 0x54, 0x6d, 0xe3, 0x12, 0xb9, 0xed, 0x0b, 0x30, 0xda, 0xb1, 0x02, 0x71, 0x6a, 0x06, 0xa2,
+	0x63, 0x16, 0x82, 0xd0, 0x2a, 0x8c, 0x77, 0x02, 0xb2, 0xc3, 0x75, 0x20, 0xe7, 0xeb, 0x57, 0xac,
+	0xb7, 0xb3, 0x4f, 0x5a, 0xcf, 0x49, 0x1a, 0xb4, 0x05, 0x53, 0x9d, 0x80, 0xec, 0xfb, 0x9d, 0x80,
+	0xbd, 0xa2, 0x3e, 0x3b, 0x3a, 0xe3, 0x1d, 0x0d, 0x95, 0xea, 0x28, 0x4f, 0x88, 0x1e, 0xc3, 0x30,
+	0xa3, 0xc7, 0xc4, 0xbd, 0x50, 0xd9, 0x76, 0x49, 0xa2, 0xff, 0x03, 0x18, 0x4f, 0xe6, 0x44, 0xa2,
+	0x05, 0xa8, 0x8a, 0x62, 0x08, 0xe2, 0xeb, 0xe5, 0x9c, 0xc7, 0x80, 0xc8, 0x93, 0x33, 0x90, 0xf0,
+	0xe4, 0x70, 0x19, 0x25, 0x7a, 0x10, 0x17, 0x57, 0xd4, 0xf6, 0x8c, 0x21, 0xfa, 0xbf, 0xa9, 0x40,
+	0xfd, 0xf5, 0xab, 0xf1, 0x3a, 0x8c, 0x87, 0xd9, 0x81, 0x7b, 0xb1, 0xba, 0x9c, 0x82, 0x45, 0xa3,
+	0x1d, 0x4c, 0xfb, 0x9d, 0xb2, 0x45, 0x6d, 0xf5, 0xff, 0x33, 0x04, 0xb3, 0x85, 0x45, 0x8f, 0xd0,
+	0xf7, 0x70, 0x55, 0x6e, 0x8a, 0x38, 0x68, 0xb9, 0x7a, 0xa6, 0xca, 0xd2, 0x95, 0x70, 0xfd, 0x74,
+	0x27, 0x46, 0x3f, 0xc0, 0xb4, 0x4b, 0x4e, 0x88, 0x7a, 0x61, 0x9f, 0x95, 0xdc, 0x8d, 0xa2, 0x3e,
+	0x44, 0x0e, 0xa2, 0xf3, 0x0a, 0x9f, 0x05, 0x99, 0xbe, 0xc7, 0x2f, 0x9a, 0x83, 0x58, 0xd0, 0x09,
+	0xda, 0x82, 0x69, 0x9f, 0xbc, 0xf2, 0x6d, 0x46, 0x56, 0x3c, 0xef, 0xf9, 0xfe, 0xfe, 0xde, 0x9e,
+	0x4f, 0x0f, 0xc2, 0x1b, 0x84, 0xe7, 0x96, 0x3d, 0x2a, 0x20, 0xe3, 0x3a, 0xb8, 0xcc, 0x80, 0x13,
+	0x1e, 0x04, 0xb5, 0x28, 0x49, 0x10, 0x32, 0x60, 0x5a, 0x3e, 0x92, 0x94, 0x2d, 0x5f, 0xb6, 0x2c,
+	0x59, 0x11, 0x31, 0x7a, 0x0e, 0x13, 0xf4, 0x20, 0x35, 0x35, 0x65, 0x2f, 0x0c, 0x64, 0xe8, 0xb8,
+	0xf8, 0x64, 0x2a, 0x71, 0x2f, 0xbc, 0xe6, 0x56, 0x42, 0x7c, 0x46, 0x24, 0xfa, 0x3f, 0xaf, 0xc0,
+	0x95, 0x2e, 0xb9, 0x2c, 0x7d, 0x4a, 0xd0, 0xa7, 0x30, 0x4e, 0x3b, 0xcc, 0xeb, 0x30, 0x55, 0x92,
+	0x6e, 0xa0, 0x44, 0x8d, 0xae, 0x04, 0xbe, 0xfe, 0x37, 0x83, 0xf0, 0xf6, 0xb9, 0xe9, 0x31, 0x7d,
+	0x8e, 0xeb, 0x63, 0x91, 0xc9, 0x76, 0xa4, 0xc6, 0x73, 0xa3, 0x30, 0x17, 0x67, 0xa5, 0xc3, 0xe2,
+	0x6a, 0xab, 0x1d, 0x76, 0x84, 0x3e, 0x8d, 0xf4, 0xd4, 0x82, 0x0c, 0xa0, 0x88, 0xac, 0xb0, 0xb6,
+	0xd1, 0x86, 0x08, 0x9d, 0x33, 0x72, 0xca, 0xbe, 0xf2, 0xb1, 0x77, 0xa4, 0x98, 0x6b, 0x71, 0x07,
+	0x6b, 0x09, 0x44, 0x23, 0x45, 0x86, 0x76, 0xe3, 0x68, 0x90, 0x64, 0xae, 0x9f, 0x94, 0xcc, 0x22,
+	0x5a, 0x52, 0x61, 0xaa, 0x6c, 0xf1, 0xbe, 0x5d, 0x18, 0x55, 0x9e, 0x14, 0x15, 0xac, 0xe9, 0xb7,
+	0x43, 0xd5, 0xcb, 0xfc, 0x06, 0xd4, 0x53, 0x2d, 0x7d, 0xba, 0x5d, 0xfe, 0x5d, 0x05, 0x66, 0x0b,
+	0x97, 0x82, 0x5b, 0xc1, 0xd8, 0xf3, 0xd6, 0x7c, 0x62, 0x11, 0x97, 0x9b, 0x45, 0x41, 0x89, 0x6e,
+	0x33, 0x14, 0x5c, 0x62, 0x63, 0xcf, 0xe6, 0xea, 0x8b, 0x92, 0xd8, 0xf2, 0x09, 0x2d, 0xc5, 0x29,
+	0xf7, 0xa6, 0x19, 0x89, 0x1d, 0xc9, 0xaf, 0x0b, 0x5a, 0xf4, 0x7f, 0xc8, 0x8f, 0x4b, 0xe1, 0xc2,
+	0xf7, 0xb9, 0x2d, 0x3f, 0x82, 0xa9, 0x00, 0xb7, 0x3d, 0x71, 0xa7, 0xe3, 0x00, 0xcb, 0x6a, 0xb0,
+	0x4a, 0x96, 0xe4, 0x1b, 0xf4, 0xdd, 0xd4, 0xeb, 0x93, 0xdb, 0xa6, 0xcf, 0x59, 0xff, 0x93, 0x01,
+	0x18, 0x4f, 0x7d, 0xc5, 0x43, 0x18, 0xb5, 0x30, 0xc3, 0x16, 0x6d, 0xe5, 0xeb, 0x2c, 0x4b, 0xc4,
+	0x75, 0xd9, 0x1c, 0x6e, 0x03, 0x85, 0x8d, 0x3e, 0xe7, 0x8a, 0x7c, 0xeb, 0x88, 0x05, 0x8c, 0x78,
+	0xf9, 0x43, 0x26, 0x49, 0xb7, 0x38, 0x42, 0x93, 0x11, 0x2f, 0xcc, 0x0f, 0x8b, 0x28, 0xd0, 0x7d,
+	0x18, 0xf9, 0xd9, 0xf6, 0x8e, 0xed, 0xb0, 0xbc, 0xef, 0x42, 0x96, 0xf6, 0x47, 0xd1, 0x1a, 0x1e,
+	0x32, 0x89, 0x8b, 0xd6, 0x8a, 0xf2, 0xec, 0x6e, 0x65, 0x49, 0xd3, 0x53, 0x96, 0x0b, 0x5f, 0xdf,
+	0x81, 0xe9, 0x82, 0x2f, 0x43, 0x1a, 0x8c, 0x62, 0x55, 0x81, 0x49, 0xaa, 0x21, 0xe1, 0xa3, 0xfe,
+	0x17, 0x15, 0x98, 0x2d, 0xfc, 0xa0, 0xee, 0x34, 0x5c, 0xd0, 0x48, 0xaf, 0xd3, 0xbe, 0x50, 0x94,
+	0xd4, 0xf5, 0xda, 0x04, 0x48, 0xfc, 0xa5, 0x0e, 0xef, 0x33, 0xb9, 0x05, 0x13, 0x10, 0xb4, 0x0c,
+	0x23, 0x22, 0x34, 0x40, 0x4a, 0xc4, 0x68, 0x15, 0xa6, 0xbe, 0x04, 0x28, 0x3f, 0x7b, 0xe7, 0x7c,
+	0xd9, 0xdf, 0x54, 0xe0, 0x4a, 0x97, 0x39, 0x43, 0x77, 0xc3, 0x82, 0x3d, 0xbd, 0xb7, 0x97, 0x2a,
+	0xe6, 0x73, 0x1f, 0x66, 0xdb, 0xf8, 0x74, 0xa7, 0xd3, 0x3e, 0x20, 0xfe, 0xee, 0xe1, 0x0a, 0x63,
+	0xbe, 0x7d, 0xd0, 0xe1, 0x82, 0x4a, 0xee, 0xef, 0xe2, 0x46, 0xf4, 0x00, 0xe6, 0x92, 0x0d, 0x09,
+	0x99, 0x2b, 0x2f, 0xd6, 0x76, 0x69, 0x45, 0x8f, 0x41, 0x4b, 0xb4, 0x6c, 0x93, 0x20, 0xc0, 0xad,
+	0xf0, 0x8f, 0xb3, 0xe4, 0x75, 0xdb, 0xae, 0xed, 0xfa, 0xff, 0x1a, 0x86, 0xba, 0x2a, 0x4e, 0x7b,
+	0xa9, 0xd3, 0xfc, 0x09, 0x8c, 0xfc, 0x84, 0x49, 0x2b, 0x92, 0x17, 0x99, 0xc3, 0x63, 0xbb, 0xad,
+	0xaf, 0x45, 0x73, 0xb8, 0x8d, 0x25, 0x72, 0x2e, 0x2a, 0x36, 0xd4, 0x77, 0x54, 0x6c, 0x1e, 0xc6,
+	0xbc, 0xb0, 0x04, 0xda, 0xb0, 0x2a, 0x18, 0x19, 0x56, 0x3e, 0xbb, 0x17, 0x07, 0xb3, 0x46, 0xb2,
+	0x81, 0xbc, 0x2e, 0x21, 0xac, 0x4f, 0xa2, 0x53, 0x39, 0xda, 0xe5, 0x7b, 0x0a, 0x8f, 0xe5, 0x0a,
+	0x00, 0xf5, 0x88, 0x6b, 0x12, 0x37, 0xe8, 0x84, 0x15, 0x9a, 0x6f, 0xe5, 0x48, 0x77, 0x23, 0x94,
+	0xf0, 0x76, 0x4a, 0x4c, 0x54, 0x22, 0x36, 0xd7, 0x2b, 0x9e, 0x55, 0xff, 0x25, 0xe2, 0x59, 0x13,
+	0xbf, 0x83, 0x6c, 0x86, 0xc9, 0x4b, 0xfe, 0x27, 0xd1, 0x7f, 0x1a, 0x90, 0x87, 0xbc, 0x60, 0x09,
+	0xc2, 0xd0, 0x6f, 0x25, 0x17, 0xfa, 0x1d, 0x28, 0x11, 0xfa, 0x7d, 0x0e, 0x55, 0x72, 0xea, 0x51,
+	0x3f, 0x91, 0xe4, 0xbb, 0x78, 0xce, 0xaa, 0x6f, 0x84, 0xb8, 0xa1, 0x34, 0x88, 0x88, 0xd3, 0x05,
+	0x7c, 0x86, 0xfb, 0x2b, 0xe0, 0x93, 0x8f, 0xbf, 0x8d, 0xf4, 0x1f, 0x7f, 0xd3, 0x0f, 0xe1, 0x66,
+	0xaf, 0x0f, 0xe0, 0x66, 0x65, 0x52, 0x1a, 0x95, 0x36, 0x2b, 0x93, 0xc2, 0xe8, 0xbf, 0x0f, 0x4a,
+	0x69, 0x94, 0x61, 0x15, 0x97, 0x5b, 0x98, 0xc8, 0x53, 0x02, 0x49, 0x4f, 0xc9, 0x67, 0x91, 0x17,
+	0x63, 0x30, 0xeb, 0xbe, 0x4a, 0x8d, 0x60, 0x5b, 0x20, 0x85, 0x47, 0x5c, 0x92, 0x08, 0xcf, 0x8d,
+	0x87, 0xdd, 0x26, 0xa3, 0x3e, 0x6e, 0x11, 0xfe, 0x4e, 0xe5, 0xf4, 0xc9, 0x82, 0x39, 0x27, 0xf5,
+	0x88, 0x1f, 0xd8, 0x01, 0x2b, 0x93, 0xd3, 0xac, 0x50, 0xd1, 0x22, 0x34, 0x02, 0xd9, 0x49, 0x5c,
+	0x64, 0x56, 0x46, 0x52, 0x72, 0x70, 0x11, 0xbc, 0x11, 0x82, 0x54, 0x5c, 0xb0, 0x54, 0x7f, 0xab,
+	0x19, 0x43, 0xd2, 0xbb, 0x69, 0xec, 0x75, 0xed, 0xa6, 0xea, 0x25, 0x76, 0xd3, 0x63, 0xb8, 0xda,
+	0x75, 0x8a, 0xd1, 0xdb, 0x00, 0x6d, 0x7c, 0xfa, 0x52, 0xd8, 0x11, 0x81, 0xaa, 0xa2, 0x58, 0x6d,
+	0xe3, 0x53, 0x21, 0x98, 0x03, 0xfd, 0x7f, 0xc7, 0x3b, 0x24, 0x25, 0xd5, 0x5f, 0xcf, 0x0e, 0xa9,
+	0x26, 0x77, 0xc8, 0x47, 0x30, 0xe5, 0x71, 0x33, 0xb9, 0xc9, 0xb0, 0xcf, 0x3a, 0x9e, 0x88, 0x47,
+	0x28, 0x29, 0x9c, 0x6f, 0x40, 0x4f, 0xe0, 0xaa, 0x63, 0x9f, 0x10, 0x11, 0x82, 0xc8, 0x51, 0xd5,
+	0x64, 0xa4, 0xa1, 0x2b, 0x02, 0x5a, 0x80, 0xea, 0x6f, 0x3b, 0xc4, 0x3f, 0x8b, 0xae, 0xd7, 0xd4,
+	0x8d, 0x18, 0xd0, 0xa7, 0x57, 0x0f, 0xe9, 0x30, 0xfe, 0x13, 0x3e, 0xc1, 0xbb, 0x1e, 0x0b, 0x9e,
+	0x13, 0xec, 0xc9, 0x3f, 0x03, 0x34, 0x52, 0x30, 0x2e, 0x32, 0xdb, 0xf8, 0xb4, 0xe9, 0x61, 0x95,
+	0x21, 0x5f, 0x37, 0xa2, 0x67, 0xf4, 0x09, 0x0c, 0x71, 0xf1, 0xda, 0x55, 0x84, 0xc9, 0x05, 0xd8,
+	0xa1, 0x56, 0x28, 0x39, 0x05, 0xfa, 0xeb, 0xfd, 0xbf, 0x55, 0xfd, 0xd7, 0x11, 0xbb, 0xce, 0xbe,
+	0x0e, 0x21, 0x18, 0x32, 0xbd, 0x4e, 0xb8, 0x49, 0xc4, 0x6f, 0xfd, 0x5f, 0x54, 0x60, 0xfa, 0x1b,
+	0x1b, 0x3b, 0xf6, 0xeb, 0x88, 0x86, 0xa3, 0x6b, 0x50, 0xe5, 0x1a, 0xe8, 0xcb, 0x43, 0xdb, 0x09,
+	0xbd, 0x6e, 0x63, 0x1c, 0xa0, 0x42, 0xb5, 0x0d, 0xe5, 0x06, 0x7e, 0x79, 0x4c, 0xce, 0x24, 0xce,
+	0xa0, 0xfa, 0x27, 0xd8, 0xc8, 0x3d, 0xcc, 0x31, 0x75, 0x07, 0x90, 0x1a, 0xd3, 0xeb, 0xf6, 0xc3,
+	0x15, 0xf9, 0xd3, 0xfe, 0xe5, 0x20, 0xcc, 0x88, 0xd7, 0xad, 0xe3, 0xe0, 0xe8, 0x80, 0x62, 0x3f,
+	0x34, 0x4d, 0xd3, 0xae, 0xc2, 0x4a, 0xd6, 0x55, 0xc8, 0xb5, 0x8e, 0x4e, 0x40, 0x7c, 0x17, 0xb7,
+	0x49, 0x6c, 0x2b, 0x26, 0x41, 0xe8, 0x5d, 0xa8, 0x7b, 0x38, 0x08, 0xbc, 0x23, 0x1f, 0x07, 0x09,
+	0x77, 0x78, 0x1a, 0x88, 0x9e, 0xc2, 0xf8, 0x89, 0x4d, 0x5e, 0xed, 0xba, 0xce, 0x99, 0xe0, 0x49,
+	0xbd, 0x35, 0xf6, 0x14, 0x3e, 0x1f, 0x67, 0xcb, 0xc7, 0x87, 0xd8, 0xc5, 0xdf, 0x1a, 0x5b, 0xe1,
+	0xdf, 0x0c, 0xc7, 0x10, 0x51, 0xc4, 0x56, 0x30, 0x0e, 0xde, 0xac, 0x2e, 0x59, 0x45, 0x00, 0x74,
+	0x5f, 0xb9, 0x3a, 0xca, 0x66, 0x40, 0x4b, 0x5f, 0xc7, 0x5d, 0x98, 0x56, 0x6f, 0xd8, 0x74, 0x55,
+	0x42, 0x21, 0xef, 0x5d, 0x26, 0x44, 0x17, 0x35, 0x71, 0xe3, 0x59, 0xbe, 0x34, 0x45, 0x20, 0x39,
+	0x48, 0x41, 0x8b, 0xfe, 0x5f, 0xc7, 0xa0, 0x26, 0x96, 0xe5, 0xb2, 0x69, 0x7b, 0xf2, 0x5e, 0xdc,
+	0x3a, 0x69, 0x53, 0xe9, 0x3a, 0x2e, 0x93, 0xb6, 0x97, 0xa5, 0x09, 0xf9, 0xe5, 0x60, 0x8e, 0x5f,
+	0x0e, 0x95, 0xe0, 0x97, 0x65, 0x73, 0xf5, 0xba, 0x94, 0x3e, 0x1f, 0xe9, 0x5e, 0xfa, 0xfc, 0xd3,
+	0xc4, 0xad, 0xb1, 0x9c, 0xd2, 0x5d, 0x70, 0xae, 0x13, 0x17, 0xc6, 0x9e, 0x40, 0xd5, 0x0a, 0x37,
+	0xbc, 0x62, 0x59, 0xd7, 0x33, 0xb4, 0x99, 0x03, 0x61, 0xc4, 0x04, 0x59, 0x8d, 0x7b, 0x32, 0xaf,
+	0x71, 0xff, 0xe1, 0x5f, 0x00, 0x7f, 0xe9, 0x7f, 0x01, 0xcc, 0x58, 0x02, 0x13, 0x97, 0xbc, 0x12,
+	0x18, 0x5d, 0x2a, 0x6b, 0x64, 0x2f, 0x95, 0xa5, 0xe4, 0xed, 0x54, 0x69, 0x79, 0xbb, 0x08, 0x13,
+	0xf1, 0x9e, 0x5e, 0xb1, 0x2c, 0x5f, 0xb2, 0x65, 0xb5, 0x6a, 0xa9, 0x16, 0xf4, 0x20, 0x36, 0x47,
+	0x73, 0x69, 0x79, 0x79, 0x59, 0x11, 0xd9, 0xa4, 0xfa, 0x3f, 0x1d, 0x83, 0x11, 0x71, 0xa6, 0x03,
+	0xf4, 0x1e, 0x0c, 0x9a, 0xae, 0xad, 0x4e, 0xff, 0x74, 0xea, 0x0f, 0xc4, 0xc3, 0xaa, 0x9c, 0xa6,
+	0x6b, 0xa3, 0xcf, 0x60, 0x5c, 0x94, 0x0a, 0x37, 0xa9, 0x4f, 0x2c, 0x37, 0xc8, 0xff, 0x5d, 0x77,
+	0xea, 0x5f, 0x93, 0x8d, 0x14, 0x32, 0xba, 0x0f, 0x63, 0x51, 0x99, 0x40, 0xa9, 0x78, 0x68, 0xb9,
+	0xd2, 0xb8, 0x51, 0x15, 0x9b, 0x10, 0x13, 0x2d, 0xc1, 0x48, 0x4b, 0x54, 0x96, 0x56, 0x46, 0xc7,
+	0x5c, 0x71, 0xbd, 0x7f, 0x43, 0x61, 0xa1, 0xc7, 0x30, 0xaa, 0x38, 0x6c, 0x69, 0xae, 0x1d, 0x12,
+	0xa0, 0x0f, 0x61, 0xb8, 0x6d, 0x9f, 0x12, 0x5f, 0x1d, 0xf9, 0xd9, 0x4c, 0xbd, 0x9d, 0xb0, 0x32,
+	0x95, 0xc0, 0x11, 0xf5, 0x56, 0x6d, 0x87, 0x86, 0xff, 0xfb, 0x33, 0x5b, 0x98, 0xca, 0x65, 0x48,
+	0x1c, 0xf4, 0x30, 0x59, 0xf2, 0xe9, 0x4a, 0xf6, 0x9f, 0x15, 0xce, 0xa9, 0xf6, 0xf4, 0x38, 0x95,
+	0xa2, 0x12, 0xfe, 0x3f, 0x50, 0xc1, 0x2d, 0xb7, 0x82, 0xbc, 0x94, 0xef, 0x60, 0x2e, 0x48, 0xc7,
+	0xc2, 0xd4, 0x7f, 0x6d, 0xa8, 0x23, 0x95, 0x74, 0xdd, 0x17, 0xc5, 0xcc, 0x8c, 0x2e, 0xe4, 0xe8,
+	0x1e, 0x8c, 0x32, 0xf5, 0x8f, 0x45, 0x13, 0x39, 0x16, 0x9f, 0x74, 0xfe, 0x18, 0x21, 0x1e, 0x9f,
+	0xad, 0x63, 0xbe, 0x15, 0x95, 0xcd, 0x3d, 0x9b, 0xd9, 0xa1, 0xe1, 0x6c, 0x09, 0x1c, 0xa4, 0xc1,
+	0xe8, 0x09, 0xb7, 0x5e, 0xa8, 0xab, 0xee, 0x17, 0x85, 0x8f, 0x42, 0x64, 0xa9, 0xbf, 0xc5, 0xcf,
+	0x1c, 0xaa, 0xf3, 0x45, 0x56, 0x86, 0x06, 0xed, 0x01, 0x8a, 0x27, 0x6a, 0x57, 0xfd, 0x1f, 0x49,
+	0xd9, 0x6b, 0xa5, 0x46, 0x01, 0x2d, 0xba, 0x0b, 0x55, 0xf9, 0xe7, 0x76, 0xfc, 0x1c, 0x4d, 0x77,
+	0x3f, 0x47, 0x63, 0x02, 0x6b, 0xcd, 0xb5, 0xd1, 0x23, 0xa8, 0x1e, 0x8b, 0x42, 0xde, 0xf6, 0xcf,
+	0xa4, 0xc4, 0x05, 0xd3, 0x18, 0x39, 0x55, 0x34, 0x7f, 0x36, 0x53, 0x34, 0xff, 0x21, 0x40, 0x9b,
+	0x04, 0xca, 0xe3, 0xaf, 0xee, 0x81, 0x74, 0x95, 0xc0, 0x09, 0x54, 0x5d, 0x83, 0xb9, 0xe2, 0xcf,
+	0xd5, 0x6f, 0xc0, 0xdb, 0xe7, 0xb2, 0x43, 0x7d, 0x0e, 0x66, 0x8a, 0xb2, 0x59, 0xf5, 0xbf, 0x0f,
+	0xf5, 0xd4, 0x9f, 0x89, 0xbe, 0xe6, 0xb2, 0x92, 0x93, 0x50, 0x4f, 0x7d, 0xce, 0xe2, 0x1d, 0x79,
+	0x41, 0x03, 0x8d, 0xc3, 0x98, 0xca, 0x8d, 0xb1, 0x1a, 0x6f, 0xf1, 0x27, 0x87, 0xb6, 0x5e, 0x52,
+	0xd7, 0x39, 0x6b, 0x54, 0x50, 0x8d, 0x0f, 0xe1, 0x90, 0xfa, 0x26, 0x69, 0x0c, 0x2c, 0x7e, 0xdd,
+	0x25, 0xb7, 0x10, 0x4d, 0x42, 0xed, 0xdb, 0x9d, 0xe6, 0xde, 0xc6, 0xda, 0xe6, 0xb3, 0xcd, 0x8d,
+	0xf5, 0xc6, 0x5b, 0x9c, 0x6c, 0x7d, 0xe3, 0xd9, 0xca, 0xb7, 0x5b, 0xfb, 0x8d, 0x0a, 0x02, 0x18,
+	0x69, 0xee, 0x1b, 0x9b, 0x6b, 0xfb, 0x8d, 0x01, 0x34, 0x0a, 0x83, 0xbb, 0xcf, 0x9e, 0x35, 0x06,
+	0x17, 0x3f, 0x28, 0xb8, 0x43, 0x89, 0xc6, 0x60, 0xe8, 0xeb, 0xe6, 0xee, 0x4e, 0xe3, 0x2d, 0xfe,
+	0x6b, 0x7f, 0xe3, 0xfb, 0xfd, 0x46, 0x65, 0x71, 0x25, 0x0c, 0x85, 0xf1, 0x7e, 0xa4, 0x9f, 0xaf,
+	0xf1, 0x16, 0xaa, 0x27, 0xbc, 0xfe, 0x72, 0x98, 0x2a, 0x1e, 0xd0, 0x18, 0xe0, 0xa3, 0x49, 0x78,
+	0x36, 0x1a, 0x83, 0xab, 0xf0, 0xe3, 0x58, 0xb8, 0xa2, 0x07, 0x23, 0x62, 0xea, 0x3e, 0xfe, 0xff,
+	0x01, 0x00, 0x00, 0xff, 0xff, 0xfb, 0xc1, 0xf2, 0xc4, 0xea, 0x84, 0x00, 0x00,
 }
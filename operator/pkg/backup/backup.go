@@ -0,0 +1,249 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup saves the Istio configuration in a cluster to a directory, and restores it from there. Backups are
+// taken before an upgrade so that operators have a rollback artifact for more than the IstioOperator CR.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// SecretsFilename is the file of a backup holding the Secrets of the Istio namespace.
+	SecretsFilename = "secrets.yaml"
+	// ConfigMapsFilename is the file of a backup holding the ConfigMaps of the Istio namespace, including the mesh
+	// config and the sidecar injector config.
+	ConfigMapsFilename = "configmaps.yaml"
+	// ConfigFilename is the file of a backup holding all the CRs of the istio.io API groups.
+	ConfigFilename = "istio-config.yaml"
+
+	istioGroupSuffix = "istio.io"
+	// serviceAccountTokenType is the type of service account token Secrets, which are recreated by Kubernetes and
+	// are not backed up.
+	serviceAccountTokenType = "kubernetes.io/service-account-token"
+	// rootCAConfigMap is published into every namespace by Kubernetes and is not backed up.
+	rootCAConfigMap = "kube-root-ca.crt"
+)
+
+// Filenames are the files of a backup, in the order they are restored.
+var Filenames = []string{SecretsFilename, ConfigMapsFilename, ConfigFilename}
+
+// serverFields are the metadata fields set by the API server, which are removed from backed up objects so that they
+// can be created again.
+var serverFields = []string{"uid", "resourceVersion", "selfLink", "creationTimestamp", "generation", "managedFields", "ownerReferences"}
+
+// Summary counts the objects in each file of a backup.
+type Summary map[string]int
+
+func (s Summary) String() string {
+	var out []string
+	for _, f := range Filenames {
+		out = append(out, fmt.Sprintf("%s: %d", f, s[f]))
+	}
+	return strings.Join(out, ", ")
+}
+
+// Create saves the Secrets and ConfigMaps of istioNamespace and all CRs of the istio.io API groups in the cluster
+// into dir, which is created if needed. The files are only readable by the current user, since they hold Secrets.
+func Create(restConfig *rest.Config, cl client.Client, istioNamespace, dir string) (Summary, error) {
+	gvks, err := IstioConfigKinds(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	secrets, err := list(cl, schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, istioNamespace)
+	if err != nil {
+		return nil, err
+	}
+	configMaps, err := list(cl, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, istioNamespace)
+	if err != nil {
+		return nil, err
+	}
+	var config object.K8sObjects
+	for _, gvk := range gvks {
+		objs, err := list(cl, gvk, "")
+		if err != nil {
+			return nil, err
+		}
+		config = append(config, objs...)
+	}
+
+	files := map[string]object.K8sObjects{
+		SecretsFilename:    filter(secrets, func(o *unstructured.Unstructured) bool { return o.Object["type"] != serviceAccountTokenType }),
+		ConfigMapsFilename: filter(configMaps, func(o *unstructured.Unstructured) bool { return o.GetName() != rootCAConfigMap }),
+		ConfigFilename:     config,
+	}
+	summary := make(Summary)
+	for _, f := range Filenames {
+		if err := write(filepath.Join(dir, f), files[f]); err != nil {
+			return nil, err
+		}
+		summary[f] = len(files[f])
+	}
+	return summary, nil
+}
+
+// Restore creates the objects saved in dir by Create, or updates them if they exist. Secrets and ConfigMaps are
+// restored before the Istio config so that the control plane finds its certificates and mesh config in place.
+// Nothing is written if dryRun is set.
+func Restore(cl client.Client, dir string, dryRun bool) (Summary, error) {
+	summary := make(Summary)
+	for _, f := range Filenames {
+		objs, err := read(filepath.Join(dir, f))
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range objs {
+			if !dryRun {
+				if err := createOrUpdate(cl, o.UnstructuredObject()); err != nil {
+					return summary, fmt.Errorf("failed to restore %s: %s", o.Hash(), err)
+				}
+			}
+			summary[f]++
+		}
+	}
+	return summary, nil
+}
+
+// IstioConfigKinds returns the kinds of the istio.io API groups served by the cluster which can be listed and
+// created, sorted for a stable backup.
+func IstioConfigKinds(restConfig *rest.Config) ([]schema.GroupVersionKind, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := dc.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("could not discover API resources: %s", err)
+	}
+	var out []schema.GroupVersionKind
+	for _, l := range lists {
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil || !strings.HasSuffix(gv.Group, istioGroupSuffix) {
+			continue
+		}
+		for _, r := range l.APIResources {
+			// Skip subresources like status.
+			if strings.Contains(r.Name, "/") || !hasVerbs(r.Verbs, "list", "create") {
+				continue
+			}
+			out = append(out, gv.WithKind(r.Kind))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].String() < out[j].String() })
+	return out, nil
+}
+
+func hasVerbs(verbs []string, want ...string) bool {
+	have := make(map[string]bool)
+	for _, v := range verbs {
+		have[v] = true
+	}
+	for _, w := range want {
+		if !have[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// list returns the objects of the given kind in namespace, or in all namespaces if namespace is empty, with the
+// fields set by the API server and the status removed.
+func list(cl client.Client, gvk schema.GroupVersionKind, namespace string) (object.K8sObjects, error) {
+	ul := &unstructured.UnstructuredList{}
+	ul.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	var opts []client.ListOption
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := cl.List(context.TODO(), ul, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %s", gvk.Kind, err)
+	}
+	var out object.K8sObjects
+	for i := range ul.Items {
+		u := ul.Items[i].DeepCopy()
+		u.SetGroupVersionKind(gvk)
+		out = append(out, object.NewK8sObject(clean(u), nil, nil))
+	}
+	return out, nil
+}
+
+// clean removes the fields set by the API server and the status from u, and returns it.
+func clean(u *unstructured.Unstructured) *unstructured.Unstructured {
+	for _, f := range serverFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+	delete(u.Object, "status")
+	return u
+}
+
+func filter(objs object.K8sObjects, keep func(o *unstructured.Unstructured) bool) object.K8sObjects {
+	var out object.K8sObjects
+	for _, o := range objs {
+		if keep(o.UnstructuredObject()) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func write(path string, objs object.K8sObjects) error {
+	y, err := objs.YAMLManifest()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(y), 0600)
+}
+
+func read(path string) (object.K8sObjects, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read backup file: %s", err)
+	}
+	return object.ParseK8sObjectsFromYAMLManifest(string(b))
+}
+
+func createOrUpdate(cl client.Client, u *unstructured.Unstructured) error {
+	err := cl.Create(context.TODO(), u.DeepCopy())
+	if !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(u.GroupVersionKind())
+	if err := cl.Get(context.TODO(), client.ObjectKey{Namespace: u.GetNamespace(), Name: u.GetName()}, live); err != nil {
+		return err
+	}
+	u = u.DeepCopy()
+	u.SetResourceVersion(live.GetResourceVersion())
+	return cl.Update(context.TODO(), u)
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestCleanWriteRead(t *testing.T) {
+	const live = `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: default
+  uid: 0c3a3b5e-0000-0000-0000-000000000000
+  resourceVersion: "1234"
+  creationTimestamp: "2020-05-01T00:00:00Z"
+  generation: 3
+  labels:
+    app: reviews
+  ownerReferences:
+  - apiVersion: v1
+    kind: ConfigMap
+    name: owner
+    uid: 1d4b4c6f-0000-0000-0000-000000000000
+spec:
+  hosts:
+  - reviews
+status:
+  validationMessages: []
+`
+	const want = `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: default
+  labels:
+    app: reviews
+spec:
+  hosts:
+  - reviews
+`
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(live), &u.Object); err != nil {
+		t.Fatal(err)
+	}
+	w := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(want), &w.Object); err != nil {
+		t.Fatal(err)
+	}
+	if got := clean(u); !reflect.DeepEqual(got.Object, w.Object) {
+		t.Errorf("got %v, want %v", got.Object, w.Object)
+	}
+
+	dir, err := ioutil.TempDir("", "backup-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ConfigFilename)
+	if err := write(path, object.K8sObjects{object.NewK8sObject(u, nil, nil)}); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("got mode %s, want -rw-------", fi.Mode().Perm())
+	}
+	objs, err := read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 || objs[0].Hash() != "VirtualService:default:reviews" {
+		t.Errorf("got %v, want VirtualService:default:reviews", objs)
+	}
+}
+
+func TestFilterAndSummary(t *testing.T) {
+	token := &unstructured.Unstructured{Object: map[string]interface{}{"type": serviceAccountTokenType}}
+	token.SetKind("Secret")
+	token.SetName("istiod-token")
+	cacerts := &unstructured.Unstructured{Object: map[string]interface{}{"type": "Opaque"}}
+	cacerts.SetKind("Secret")
+	cacerts.SetName("cacerts")
+	got := filter(object.K8sObjects{object.NewK8sObject(token, nil, nil), object.NewK8sObject(cacerts, nil, nil)},
+		func(o *unstructured.Unstructured) bool { return o.Object["type"] != serviceAccountTokenType })
+	if len(got) != 1 || got[0].Name != "cacerts" {
+		t.Errorf("got %v, want only cacerts", got)
+	}
+
+	s := Summary{SecretsFilename: 1, ConfigFilename: 4}
+	if want := "secrets.yaml: 1, configmaps.yaml: 0, istio-config.yaml: 4"; s.String() != want {
+		t.Errorf("got %q, want %q", s.String(), want)
+	}
+}
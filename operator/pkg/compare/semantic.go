@@ -0,0 +1,372 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+// ChangeType is the type of change made to an object between two manifests.
+type ChangeType string
+
+const (
+	// ChangeAdded means the object is only present in the second manifest.
+	ChangeAdded ChangeType = "Added"
+	// ChangeRemoved means the object is only present in the first manifest.
+	ChangeRemoved ChangeType = "Removed"
+	// ChangeModified means the object is present in both manifests with semantic differences.
+	ChangeModified ChangeType = "Modified"
+
+	// UnknownComponent is the component name used for objects that carry no component label.
+	UnknownComponent = "unknown"
+
+	componentLabel = name.OperatorAPINamespace + "/component"
+)
+
+var (
+	// generatedMetadataFields are metadata fields set by the API server, which are not part of the desired state.
+	generatedMetadataFields = []string{
+		"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid",
+	}
+	// generatedAnnotations are annotations set by tooling, which are not part of the desired state.
+	generatedAnnotations = []string{
+		"deployment.kubernetes.io/revision",
+		"kubectl.kubernetes.io/last-applied-configuration",
+	}
+	// podSpecPaths are the paths of the pod specs of the workload kinds, relative to the object root.
+	podSpecPaths = map[string]string{
+		"CronJob":     "spec.jobTemplate.spec.template.spec",
+		"DaemonSet":   "spec.template.spec",
+		"Deployment":  "spec.template.spec",
+		"Job":         "spec.template.spec",
+		"Pod":         "spec",
+		"ReplicaSet":  "spec.template.spec",
+		"StatefulSet": "spec.template.spec",
+	}
+	// podSpecDefaults are the values the API server fills in for absent pod spec fields, by path relative to the pod
+	// spec. A * path element matches every item of a list.
+	podSpecDefaults = map[string]interface{}{
+		"dnsPolicy":                                 "ClusterFirst",
+		"restartPolicy":                             "Always",
+		"schedulerName":                             "default-scheduler",
+		"terminationGracePeriodSeconds":             float64(30),
+		"containers.*.ports.*.protocol":             "TCP",
+		"containers.*.terminationMessagePath":       "/dev/termination-log",
+		"containers.*.terminationMessagePolicy":     "File",
+		"initContainers.*.ports.*.protocol":         "TCP",
+		"initContainers.*.terminationMessagePath":   "/dev/termination-log",
+		"initContainers.*.terminationMessagePolicy": "File",
+	}
+	// setListKeys are the keys of the lists whose items are matched by name rather than position, so reordering them
+	// is not a change. Other lists, like initContainers, which run in order, or env, whose $(VAR) references only see
+	// earlier variables, keep their order even if their items have names.
+	setListKeys = map[string]bool{
+		"containers":   true,
+		"ports":        true,
+		"volumes":      true,
+		"volumeMounts": true,
+	}
+	// kindDefaults are the values the API server fills in for absent fields outside of pod specs, by kind and path
+	// relative to the object root.
+	kindDefaults = map[string]map[string]interface{}{
+		"DaemonSet": {
+			"spec.revisionHistoryLimit": float64(10),
+		},
+		"Deployment": {
+			"spec.progressDeadlineSeconds": float64(600),
+			"spec.revisionHistoryLimit":    float64(10),
+		},
+		"Service": {
+			"spec.ports.*.protocol": "TCP",
+			"spec.sessionAffinity":  "None",
+		},
+		"StatefulSet": {
+			"spec.revisionHistoryLimit": float64(10),
+		},
+	}
+)
+
+// ObjectChange describes the change to a single object between two manifests.
+type ObjectChange struct {
+	// Component is the name of the component the object belongs to.
+	Component string `json:"component"`
+	// Object is the object hash, in kind:namespace:name format.
+	Object string `json:"object"`
+	// Change is the type of change.
+	Change ChangeType `json:"change"`
+	// Diff is a tree based diff of the object, for modified objects.
+	Diff string `json:"diff,omitempty"`
+}
+
+// ManifestChanges maps component names to the changes to objects of that component.
+type ManifestChanges map[string][]*ObjectChange
+
+// SemanticManifestDiff compares manifests a and b and returns the changed objects. Differences which don't affect
+// the desired state are ignored: the order of containers, ports, volumes and volume mounts, well known fields of workloads and services set
+// to their API server default values, null values, empty maps and lists, and metadata generated by the API server.
+func SemanticManifestDiff(a, b string) ([]*ObjectChange, error) {
+	aom, err := normalizedObjects(a)
+	if err != nil {
+		return nil, err
+	}
+	bom, err := normalizedObjects(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*ObjectChange
+	for ak, ao := range aom {
+		bo, ok := bom[ak]
+		if !ok {
+			out = append(out, &ObjectChange{Component: componentOf(ao), Object: ak, Change: ChangeRemoved})
+			continue
+		}
+		if reflect.DeepEqual(ao.tree, bo.tree) {
+			continue
+		}
+		ay, err := yaml.Marshal(ao.tree)
+		if err != nil {
+			return nil, err
+		}
+		by, err := yaml.Marshal(bo.tree)
+		if err != nil {
+			return nil, err
+		}
+		diff := YAMLCmp(string(ay), string(by))
+		if diff == "" {
+			continue
+		}
+		out = append(out, &ObjectChange{Component: componentOf(bo), Object: ak, Change: ChangeModified, Diff: diff})
+	}
+	for bk, bo := range bom {
+		if _, ok := aom[bk]; !ok {
+			out = append(out, &ObjectChange{Component: componentOf(bo), Object: bk, Change: ChangeAdded})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Component != out[j].Component {
+			return out[i].Component < out[j].Component
+		}
+		return out[i].Object < out[j].Object
+	})
+	return out, nil
+}
+
+// GroupByComponent groups changes by component name.
+func GroupByComponent(changes []*ObjectChange) ManifestChanges {
+	out := make(ManifestChanges)
+	for _, c := range changes {
+		out[c.Component] = append(out[c.Component], c)
+	}
+	return out
+}
+
+// String returns a human readable representation of the changes.
+func (mc ManifestChanges) String() string {
+	var components []string
+	for c := range mc {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+	var sb strings.Builder
+	for _, c := range components {
+		writeStringSafe(&sb, fmt.Sprintf("Component %s:\n", c))
+		for _, oc := range mc[c] {
+			writeStringSafe(&sb, fmt.Sprintf("  %s %s\n", oc.Change, oc.Object))
+			if oc.Diff != "" {
+				writeStringSafe(&sb, indent(oc.Diff, "    "))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// normalizedObject is an object tree with all semantically irrelevant differences removed.
+type normalizedObject struct {
+	component string
+	tree      map[string]interface{}
+}
+
+// normalizedObjects parses manifest and returns a map of object hashes to normalized object trees.
+func normalizedObjects(manifest string) (map[string]*normalizedObject, error) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*normalizedObject)
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		no := &normalizedObject{component: u.GetLabels()[componentLabel]}
+		// Round trip through YAML to get the same numeric types for all values.
+		y, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return nil, err
+		}
+		tree := make(map[string]interface{})
+		if err := yaml.Unmarshal(y, &tree); err != nil {
+			return nil, err
+		}
+		removeGeneratedFields(tree)
+		removeDefaultFields(tree, u.GetKind())
+		if n, ok := normalizeNode(tree, "").(map[string]interface{}); ok {
+			tree = n
+		}
+		no.tree = tree
+		out[o.Hash()] = no
+	}
+	return out, nil
+}
+
+func componentOf(no *normalizedObject) string {
+	if no.component == "" {
+		return UnknownComponent
+	}
+	return no.component
+}
+
+// removeGeneratedFields removes server generated metadata and status from the object tree.
+func removeGeneratedFields(tree map[string]interface{}) {
+	delete(tree, "status")
+	md, ok := tree["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, f := range generatedMetadataFields {
+		delete(md, f)
+	}
+	if an, ok := md["annotations"].(map[string]interface{}); ok {
+		for _, a := range generatedAnnotations {
+			delete(an, a)
+		}
+	}
+}
+
+// removeDefaultFields removes the fields of the object tree of the given kind which are set to the value the API
+// server fills in when they are absent.
+func removeDefaultFields(tree map[string]interface{}, kind string) {
+	for p, dv := range kindDefaults[kind] {
+		removeDefault(tree, strings.Split(p, "."), dv)
+	}
+	if psp, ok := podSpecPaths[kind]; ok {
+		for p, dv := range podSpecDefaults {
+			removeDefault(tree, strings.Split(psp+"."+p, "."), dv)
+		}
+	}
+}
+
+// removeDefault deletes the field at path in node if it is set to the default value dv. A * path element matches
+// every item of a list.
+func removeDefault(node interface{}, path []string, dv interface{}) {
+	if path[0] == "*" {
+		l, _ := node.([]interface{})
+		for _, v := range l {
+			removeDefault(v, path[1:], dv)
+		}
+		return
+	}
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		if v, ok := m[path[0]]; ok && reflect.DeepEqual(v, dv) {
+			delete(m, path[0])
+		}
+		return
+	}
+	removeDefault(m[path[0]], path[1:], dv)
+}
+
+// normalizeNode returns node, the value of key in its parent, with null values, empty maps and empty lists removed and
+// the items of the lists in setListKeys sorted by name. It returns nil if the node is empty after normalization.
+func normalizeNode(node interface{}, key string) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			nv := normalizeNode(v, k)
+			if nv == nil {
+				delete(n, k)
+				continue
+			}
+			n[k] = nv
+		}
+		if len(n) == 0 {
+			return nil
+		}
+		return n
+	case []interface{}:
+		var out []interface{}
+		for _, v := range n {
+			if nv := normalizeNode(v, ""); nv != nil {
+				out = append(out, nv)
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		if setListKeys[key] {
+			sortNamedItems(out)
+		}
+		return out
+	case nil:
+		return nil
+	}
+	return node
+}
+
+// sortNamedItems sorts l by the name field of its elements, if all elements are maps with a string name field, e.g.
+// container ports without names keep their order.
+func sortNamedItems(l []interface{}) {
+	names := make([]string, len(l))
+	for i, v := range l {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if names[i], ok = m["name"].(string); !ok {
+			return
+		}
+	}
+	sort.Sort(byName{names: names, items: l})
+}
+
+// byName sorts items in the order of the corresponding entries in names.
+type byName struct {
+	names []string
+	items []interface{}
+}
+
+func (b byName) Len() int           { return len(b.items) }
+func (b byName) Less(i, j int) bool { return b.names[i] < b.names[j] }
+func (b byName) Swap(i, j int) {
+	b.names[i], b.names[j] = b.names[j], b.names[i]
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+}
+
+func indent(s, prefix string) string {
+	var sb strings.Builder
+	for _, l := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		writeStringSafe(&sb, prefix+l+"\n")
+	}
+	return sb.String()
+}
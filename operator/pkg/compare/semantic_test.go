@@ -0,0 +1,307 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"testing"
+)
+
+func TestSemanticManifestDiff(t *testing.T) {
+	base := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: Pilot
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init-a
+        image: init:1.6
+      - name: init-b
+        image: init:1.6
+      containers:
+      - name: discovery
+        image: pilot:1.6
+        ports:
+        - containerPort: 8080
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: $(A)
+      - name: sidecar
+        image: proxy:1.6
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: IngressGateways
+spec:
+  type: LoadBalancer
+`
+	// deployment returns the istiod Deployment of base with the given pod spec.
+	deployment := func(podSpec string) string {
+		return `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: Pilot
+spec:
+  template:
+    spec:
+` + podSpec + `
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: IngressGateways
+spec:
+  type: LoadBalancer
+`
+	}
+	pilotModified := []*ObjectChange{{Component: "Pilot", Object: "Deployment:istio-system:istiod", Change: ChangeModified}}
+	tests := []struct {
+		desc string
+		b    string
+		want []*ObjectChange
+	}{
+		{
+			desc: "semantically equal",
+			b: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  creationTimestamp: null
+  resourceVersion: "1234"
+  labels:
+    operator.istio.io/component: Pilot
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+spec:
+  revisionHistoryLimit: 10
+  template:
+    spec:
+      restartPolicy: Always
+      initContainers:
+      - name: init-a
+        image: init:1.6
+      - name: init-b
+        image: init:1.6
+      containers:
+      - name: sidecar
+        image: proxy:1.6
+      - name: discovery
+        image: pilot:1.6
+        resources: {}
+        ports:
+        - containerPort: 8080
+          protocol: TCP
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: $(A)
+status:
+  replicas: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: IngressGateways
+spec:
+  type: LoadBalancer
+  sessionAffinity: None
+`,
+		},
+		{
+			desc: "modified, added and removed",
+			b: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: Pilot
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init-a
+        image: init:1.6
+      - name: init-b
+        image: init:1.6
+      containers:
+      - name: discovery
+        image: pilot:1.7
+        ports:
+        - containerPort: 8080
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: $(A)
+      - name: sidecar
+        image: proxy:1.6
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: istio-system
+`,
+			want: []*ObjectChange{
+				{Component: "IngressGateways", Object: "Service:istio-system:istio-ingressgateway", Change: ChangeRemoved},
+				{Component: "Pilot", Object: "Deployment:istio-system:istiod", Change: ChangeModified},
+				{Component: UnknownComponent, Object: "ConfigMap:istio-system:foo", Change: ChangeAdded},
+			},
+		},
+		{
+			desc: "reordered init containers",
+			b: deployment(`      initContainers:
+      - name: init-b
+        image: init:1.6
+      - name: init-a
+        image: init:1.6
+      containers:
+      - name: discovery
+        image: pilot:1.6
+        ports:
+        - containerPort: 8080
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: $(A)
+      - name: sidecar
+        image: proxy:1.6`),
+			want: pilotModified,
+		},
+		{
+			desc: "reordered env",
+			b: deployment(`      initContainers:
+      - name: init-a
+        image: init:1.6
+      - name: init-b
+        image: init:1.6
+      containers:
+      - name: discovery
+        image: pilot:1.6
+        ports:
+        - containerPort: 8080
+        env:
+        - name: B
+          value: $(A)
+        - name: A
+          value: a
+      - name: sidecar
+        image: proxy:1.6`),
+			want: pilotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := SemanticManifestDiff(base, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SemanticManifestDiff() got %d changes, want %d: %s", len(got), len(tt.want), GroupByComponent(got))
+			}
+			for i := range got {
+				g, w := got[i], tt.want[i]
+				if g.Component != w.Component || g.Object != w.Object || g.Change != w.Change {
+					t.Errorf("SemanticManifestDiff() change %d: got %+v, want %+v", i, g, w)
+				}
+				if g.Change == ChangeModified && g.Diff == "" {
+					t.Errorf("SemanticManifestDiff() change %d: expect diff for modified object", i)
+				}
+			}
+		})
+	}
+}
+
+func TestSemanticManifestDiffDefaultPaths(t *testing.T) {
+	a := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: istio-system
+data:
+  protocol: TCP
+  dnsPolicy: ClusterFirst
+  empty: ""
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: ServiceEntry
+metadata:
+  name: foo
+  namespace: istio-system
+spec:
+  ports:
+  - name: tcp
+    number: 9000
+    protocol: TCP
+`
+	b := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: istio-system
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: ServiceEntry
+metadata:
+  name: foo
+  namespace: istio-system
+spec:
+  ports:
+  - name: tcp
+    number: 9000
+`
+	got, err := SemanticManifestDiff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ConfigMap:istio-system:foo", "ServiceEntry:istio-system:foo"}
+	if len(got) != len(want) {
+		t.Fatalf("SemanticManifestDiff() got %d changes, want %d: %s", len(got), len(want), GroupByComponent(got))
+	}
+	for i, w := range want {
+		if got[i].Object != w || got[i].Change != ChangeModified {
+			t.Errorf("SemanticManifestDiff() change %d: got %+v, want %s modified", i, got[i], w)
+		}
+	}
+}
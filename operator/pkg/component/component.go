@@ -21,13 +21,20 @@ package component
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ghodss/yaml"
 
 	"istio.io/api/operator/v1alpha1"
 	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/ipfamily"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/multiarch"
 	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/nodeos"
 	"istio.io/istio/operator/pkg/patch"
+	"istio.io/istio/operator/pkg/platform"
+	"istio.io/istio/operator/pkg/podsecurity"
 	"istio.io/istio/operator/pkg/tpath"
 	"istio.io/istio/operator/pkg/translate"
 	"istio.io/pkg/log"
@@ -72,6 +79,38 @@ type IstioComponent interface {
 	Run() error
 	// RenderManifest returns a string with the rendered manifest for the component.
 	RenderManifest() (string, error)
+	// HelmChart returns the chart and values the manifest for the component is rendered from.
+	HelmChart() (*HelmChart, error)
+	// ChartVersion returns the version of the chart the component is rendered from, or an empty string for an addon
+	// rendered by a plugin.
+	ChartVersion() string
+	// ChartName returns the name of the chart the component is rendered from, or an empty string for an addon
+	// rendered by a plugin.
+	ChartName() string
+	// ReadinessChecks returns the custom readiness checks registered for the component with RegisterReadinessChecks.
+	ReadinessChecks() []manifest.ReadinessCheck
+}
+
+// HelmChart is the chart and values the manifest of a component is rendered from.
+type HelmChart struct {
+	// ComponentName is the name of the component.
+	ComponentName name.ComponentName
+	// AddonName is the name of the addon, for addon components.
+	AddonName string
+	// ResourceName is the name of the resources of the component.
+	ResourceName string
+	// Namespace is the namespace the chart is rendered into.
+	Namespace string
+	// Subdir is the path of the chart relative to the charts directory of the install package. It is empty for an
+	// addon with a chartPath.
+	Subdir string
+	// Path is the resolved local path of the chart of an addon with a chartPath.
+	Path string
+	// Values is the values YAML the chart is rendered with.
+	Values string
+	// Manifest is the manifest rendered from the chart and Values alone, without the K8s settings from the
+	// IstioOperatorSpec and the other changes the operator makes to the rendered manifest.
+	Manifest string
 }
 
 // CommonComponentFields is a struct common to all components.
@@ -461,6 +500,11 @@ func NewAddonComponent(addonName, resourceName string, spec *v1alpha1.ExternalCo
 
 // Run implements the IstioComponent interface.
 func (c *AddonComponent) Run() error {
+	if c.chartPath() != "" && !c.Enabled() {
+		// Third party charts may be remote and are only fetched when the addon is enabled.
+		c.started = true
+		return nil
+	}
 	return runComponent(c.CommonComponentFields)
 }
 
@@ -490,6 +534,12 @@ func (c *AddonComponent) Enabled() bool {
 	return boolValue(c.componentSpec.(*v1alpha1.ExternalComponentSpec).Enabled)
 }
 
+// chartPath returns the path or URL of a third party chart for the addon, or "" if the addon uses a chart from the
+// installation package.
+func (c *AddonComponent) chartPath() string {
+	return c.componentSpec.(*v1alpha1.ExternalComponentSpec).ChartPath
+}
+
 // runComponent performs startup tasks for the component defined by the given CommonComponentFields.
 func runComponent(c *CommonComponentFields) error {
 	r, err := createHelmRenderer(c)
@@ -514,7 +564,7 @@ func renderManifest(c IstioComponent, cf *CommonComponentFields) (string, error)
 		return disabledYAMLStr(cf.componentName, cf.resourceName), nil
 	}
 
-	mergedYAML, err := cf.Translator.TranslateHelmValues(cf.InstallSpec, cf.componentSpec, cf.componentName)
+	mergedYAML, err := chartValues(cf)
 	if err != nil {
 		return "", err
 	}
@@ -535,6 +585,40 @@ func renderManifest(c IstioComponent, cf *CommonComponentFields) (string, error)
 	if err != nil {
 		return "", err
 	}
+	// Make pods comply with restricted pod security policies. CNI is exempt, since the node agent must be
+	// privileged and runs in kube-system, which is typically excluded from these policies.
+	if restricted, openshift := podsecurity.Settings(cf.InstallSpec.Values); restricted && cf.componentName != name.CNIComponentName {
+		my, err = podsecurity.Restrict(my, &podsecurity.Options{
+			OpenShift:   openshift,
+			SCCRoleName: sccRoleName(cf),
+			Namespace:   cf.Namespace,
+		})
+		if err != nil {
+			return "", fmt.Errorf("component %s is not compatible with restricted pod security: %s", cf.componentName, err)
+		}
+	}
+	if families, policy := ipfamily.Settings(cf.InstallSpec.Values); len(families) != 0 {
+		if my, err = ipfamily.Apply(my, families, policy); err != nil {
+			return "", err
+		}
+	}
+	if enabled, _ := multiarch.Settings(cf.InstallSpec.Values); enabled {
+		if my, err = multiarch.Apply(my); err != nil {
+			return "", err
+		}
+	}
+	if my, err = nodeos.Apply(my, nodeos.Settings(cf.InstallSpec.Values)); err != nil {
+		return "", err
+	}
+	my, err = platform.Apply(my, &platform.Options{
+		Platform:       platform.Settings(cf.InstallSpec.Values),
+		IngressGateway: cf.componentName == name.IngressComponentName,
+		CNI:            cf.componentName == name.CNIComponentName,
+		SCCRoleName:    sccRoleName(cf),
+	})
+	if err != nil {
+		return "", err
+	}
 	cnOutput := string(cf.componentName)
 	if !cf.componentName.IsCoreComponent() && !cf.componentName.IsGateway() {
 		cnOutput += " " + cf.addonName
@@ -574,18 +658,127 @@ func renderManifest(c IstioComponent, cf *CommonComponentFields) (string, error)
 
 // createHelmRenderer creates a helm renderer for the component defined by c and returns a ptr to it.
 // If a helm subdir is not found in ComponentMap translations, it is assumed to be "addon/<component name>.
+// An addon with a renderer plugin is rendered by the plugin instead.
 func createHelmRenderer(c *CommonComponentFields) (helm.TemplateRenderer, error) {
-	iop := c.InstallSpec
-	cns := string(c.componentName)
+	if f, ok := addonPlugin(c); ok {
+		return f(c.addonName, c.Namespace)
+	}
+	cns, helmSubdir, chartPath, err := chartLocation(c)
+	if err != nil {
+		return nil, err
+	}
+	if chartPath != "" {
+		return helm.NewFileTemplateRenderer(chartPath, cns, c.Namespace), nil
+	}
+	return helm.NewHelmRenderer(c.InstallSpec.InstallPackagePath, helmSubdir, cns, c.Namespace)
+}
+
+// chartLocation returns the name the chart of the component defined by c is rendered with, and either the chart
+// subdir in the install package or, for an addon with a chartPath, the resolved local path of the chart.
+func chartLocation(c *CommonComponentFields) (cns, helmSubdir, chartPath string, err error) {
+	cns = string(c.componentName)
 	if c.componentName.IsAddon() {
 		// For addons, distinguish the chart path using the addon name.
 		cns = c.addonName
+		if cp := c.componentSpec.(*v1alpha1.ExternalComponentSpec).ChartPath; cp != "" {
+			dir, err := helm.ResolveChartPath(c.InstallSpec.InstallPackagePath, cp)
+			if err != nil {
+				return "", "", "", fmt.Errorf("could not resolve chart %s for addon %s: %s", cp, cns, err)
+			}
+			return cns, "", dir, nil
+		}
 	}
-	helmSubdir := addonsChartDirName + "/" + cns
+	helmSubdir = addonsChartDirName + "/" + cns
 	if cm := c.Translator.ComponentMap(cns); cm != nil {
 		helmSubdir = cm.HelmSubdir
 	}
-	return helm.NewHelmRenderer(iop.InstallPackagePath, helmSubdir, cns, c.Namespace)
+	return cns, helmSubdir, "", nil
+}
+
+// chartValues returns the values YAML the chart of the component defined by c is rendered with.
+func chartValues(c *CommonComponentFields) (string, error) {
+	values, err := c.Translator.TranslateHelmValues(c.InstallSpec, c.componentSpec, c.componentName)
+	if err != nil {
+		return "", err
+	}
+	if c.componentName.IsAddon() && c.componentSpec.(*v1alpha1.ExternalComponentSpec).ChartPath != "" {
+		return thirdPartyChartValues(values, c.addonName)
+	}
+	// Plugins get the same values as third party charts.
+	if _, ok := addonPlugin(c); ok {
+		return thirdPartyChartValues(values, c.addonName)
+	}
+	return values, nil
+}
+
+// addonPlugin returns the factory of the renderer plugin of the component defined by c and true, if it is an addon
+// rendered by a plugin.
+func addonPlugin(c *CommonComponentFields) (RendererFactory, bool) {
+	if !c.componentName.IsAddon() {
+		return nil, false
+	}
+	return pluginRenderer(c.addonName)
+}
+
+// HelmChart implements the IstioComponent interface. The component must be started and should be enabled.
+func (c *CommonComponentFields) HelmChart() (*HelmChart, error) {
+	if !c.started {
+		return nil, fmt.Errorf("component %s not started in HelmChart", c.componentName)
+	}
+	if _, ok := addonPlugin(c); ok {
+		return nil, fmt.Errorf("addon %s is rendered by a plugin and has no helm chart", c.addonName)
+	}
+	_, helmSubdir, chartPath, err := chartLocation(c)
+	if err != nil {
+		return nil, err
+	}
+	values, err := chartValues(c)
+	if err != nil {
+		return nil, err
+	}
+	m, err := c.renderer.RenderManifest(values)
+	if err != nil {
+		return nil, err
+	}
+	return &HelmChart{
+		ComponentName: c.componentName,
+		AddonName:     c.addonName,
+		ResourceName:  c.resourceName,
+		Namespace:     c.Namespace,
+		Subdir:        helmSubdir,
+		Path:          chartPath,
+		Values:        values,
+		Manifest:      m,
+	}, nil
+}
+
+// ChartVersion implements the IstioComponent interface.
+func (c *CommonComponentFields) ChartVersion() string {
+	if c.renderer == nil {
+		return ""
+	}
+	return helm.ChartVersion(c.renderer)
+}
+
+// ChartName implements the IstioComponent interface.
+func (c *CommonComponentFields) ChartName() string {
+	if c.renderer == nil {
+		return ""
+	}
+	return helm.ChartName(c.renderer)
+}
+
+// sccRoleName returns the name of the Role and RoleBinding which grant the restricted SCC to the service accounts of
+// the component defined by c.
+func sccRoleName(c *CommonComponentFields) string {
+	n := c.resourceName
+	if n == "" {
+		n = "istio-" + strings.ToLower(string(c.componentName))
+		if c.addonName != "" {
+			n = "istio-" + c.addonName
+		}
+	}
+	return n + "-scc"
 }
 
 func isCoreComponentEnabled(c *CommonComponentFields) bool {
@@ -596,6 +789,27 @@ func isCoreComponentEnabled(c *CommonComponentFields) bool {
 	return enabled
 }
 
+// thirdPartyChartValues returns the values for a third party addon chart from the merged values YAML. Like a helm
+// subchart, the chart is passed the values under the addon name, together with the global values.
+func thirdPartyChartValues(mergedYAML, addonName string) (string, error) {
+	mergedVals := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(mergedYAML), &mergedVals); err != nil {
+		return "", err
+	}
+	out := make(map[string]interface{})
+	if addonVals, ok := mergedVals[addonName].(map[string]interface{}); ok {
+		out = addonVals
+	}
+	if globalVals, ok := mergedVals["global"]; ok {
+		out["global"] = globalVals
+	}
+	y, err := yaml.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(y), nil
+}
+
 // disabledYAMLStr returns the YAML comment string that the given component is disabled.
 func disabledYAMLStr(componentName name.ComponentName, resourceName string) string {
 	fullName := string(componentName)
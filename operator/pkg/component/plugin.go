@@ -0,0 +1,143 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"istio.io/istio/operator/pkg/helm"
+)
+
+const (
+	// PluginDirEnvVar is the environment variable holding the directory exec renderer plugins are looked up in. Exec
+	// plugins are disabled if it is not set.
+	PluginDirEnvVar = "ISTIO_OPERATOR_PLUGIN_DIR"
+	// execPluginPrefix is the prefix of the file name of an exec renderer plugin, followed by the addon name.
+	execPluginPrefix = "istio-renderer-"
+	// execPluginTimeout is how long an exec renderer plugin may take to render a manifest.
+	execPluginTimeout = 30 * time.Second
+)
+
+// RendererFactory returns the renderer of the addon component addonName, installed in namespace. The renderer is
+// given the values of the addon, with the global values under "global", and must return a YAML manifest. The manifest
+// goes through the same K8s settings, overlays and platform changes as a manifest rendered from a chart.
+type RendererFactory func(addonName, namespace string) (helm.TemplateRenderer, error)
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]RendererFactory)
+)
+
+// RegisterRenderer registers factory as the renderer of the addon component addonName, in place of a chart. It is
+// meant to be called from an init function of a package linked into istioctl or the operator.
+func RegisterRenderer(addonName string, factory RendererFactory) error {
+	if addonName == "" || factory == nil {
+		return fmt.Errorf("renderer must have an addon name and a factory")
+	}
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if _, ok := renderers[addonName]; ok {
+		return fmt.Errorf("a renderer is already registered for addon %s", addonName)
+	}
+	renderers[addonName] = factory
+	return nil
+}
+
+// UnregisterRenderer removes the renderer registered for addonName, if any.
+func UnregisterRenderer(addonName string) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	delete(renderers, addonName)
+}
+
+// pluginRenderer returns the factory of the renderer plugin for addonName and true, or false if the addon is rendered
+// from a chart. A registered renderer takes precedence over an exec plugin in the directory set with PluginDirEnvVar.
+func pluginRenderer(addonName string) (RendererFactory, bool) {
+	renderersMu.RLock()
+	f, ok := renderers[addonName]
+	renderersMu.RUnlock()
+	if ok {
+		return f, true
+	}
+	dir := os.Getenv(PluginDirEnvVar)
+	if dir == "" {
+		return nil, false
+	}
+	path := filepath.Join(dir, execPluginPrefix+addonName)
+	if fi, err := os.Stat(path); err != nil || fi.IsDir() || fi.Mode()&0111 == 0 {
+		return nil, false
+	}
+	return func(addonName, namespace string) (helm.TemplateRenderer, error) {
+		return NewExecRenderer(path, addonName, namespace), nil
+	}, true
+}
+
+// ExecRenderer is a TemplateRenderer which renders an addon component by running an executable. The executable is run
+// with the values YAML on stdin and the addon name and namespace in the ISTIO_ADDON_NAME and ISTIO_NAMESPACE
+// environment variables, and must write the manifest to stdout and exit with status 0.
+type ExecRenderer struct {
+	path      string
+	addonName string
+	namespace string
+	started   bool
+}
+
+// NewExecRenderer creates an ExecRenderer for the executable at path and returns a pointer to it.
+func NewExecRenderer(path, addonName, namespace string) *ExecRenderer {
+	return &ExecRenderer{
+		path:      path,
+		addonName: addonName,
+		namespace: namespace,
+	}
+}
+
+// Run implements the TemplateRenderer interface.
+func (r *ExecRenderer) Run() error {
+	if _, err := os.Stat(r.path); err != nil {
+		return fmt.Errorf("renderer plugin for addon %s: %s", r.addonName, err)
+	}
+	r.started = true
+	return nil
+}
+
+// RenderManifest implements the TemplateRenderer interface.
+func (r *ExecRenderer) RenderManifest(values string) (string, error) {
+	if !r.started {
+		return "", fmt.Errorf("execRenderer for %s not started in RenderManifest", r.addonName)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), execPluginTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, r.path)
+	cmd.Env = append(os.Environ(), "ISTIO_ADDON_NAME="+r.addonName, "ISTIO_NAMESPACE="+r.namespace)
+	cmd.Stdin = strings.NewReader(values)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return "", fmt.Errorf("renderer plugin %s for addon %s failed: %s: %s", r.path, r.addonName, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
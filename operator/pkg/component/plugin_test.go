@@ -0,0 +1,141 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"istio.io/istio/operator/pkg/helm"
+)
+
+type fakeRenderer struct {
+	manifest string
+}
+
+func (f *fakeRenderer) Run() error {
+	return nil
+}
+
+func (f *fakeRenderer) RenderManifest(string) (string, error) {
+	return f.manifest, nil
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	factory := func(addonName, namespace string) (helm.TemplateRenderer, error) {
+		return &fakeRenderer{manifest: "kind: ConfigMap"}, nil
+	}
+	if err := RegisterRenderer("wasm-distributor", factory); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterRenderer("wasm-distributor")
+	if err := RegisterRenderer("wasm-distributor", factory); err == nil {
+		t.Error("got no error registering a renderer twice, want error")
+	}
+	if err := RegisterRenderer("", factory); err == nil {
+		t.Error("got no error registering a renderer without a name, want error")
+	}
+	if _, ok := pluginRenderer("wasm-distributor"); !ok {
+		t.Error("got no renderer for wasm-distributor, want registered renderer")
+	}
+	if _, ok := pluginRenderer("prometheus"); ok {
+		t.Error("got renderer for prometheus, want none")
+	}
+}
+
+func TestExecRenderer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "renderer-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	plugins := map[string]string{
+		"echo": "#!/bin/sh\necho \"# $ISTIO_ADDON_NAME in $ISTIO_NAMESPACE\"\ncat\n",
+		"fail": "#!/bin/sh\necho broken >&2\nexit 1\n",
+	}
+	for n, script := range plugins {
+		if err := ioutil.WriteFile(filepath.Join(dir, execPluginPrefix+n), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, execPluginPrefix+"noexec"), []byte("kind: ConfigMap"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(PluginDirEnvVar, dir)
+	defer os.Unsetenv(PluginDirEnvVar)
+
+	tests := []struct {
+		desc       string
+		addon      string
+		want       string
+		wantErr    string
+		wantPlugin bool
+	}{
+		{
+			desc:       "renders values",
+			addon:      "echo",
+			want:       "# echo in istio-system\nkind: ConfigMap\n",
+			wantPlugin: true,
+		},
+		{
+			desc:       "failure includes stderr",
+			addon:      "fail",
+			wantErr:    "broken",
+			wantPlugin: true,
+		},
+		{
+			desc:  "not executable",
+			addon: "noexec",
+		},
+		{
+			desc:  "missing",
+			addon: "grafana",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, ok := pluginRenderer(tt.addon)
+			if ok != tt.wantPlugin {
+				t.Fatalf("got plugin %v, want %v", ok, tt.wantPlugin)
+			}
+			if !ok {
+				return
+			}
+			r, err := f(tt.addon, "istio-system")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Run(); err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.RenderManifest("kind: ConfigMap\n")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
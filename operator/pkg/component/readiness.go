@@ -0,0 +1,121 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/operator/pkg/manifest"
+)
+
+// probeTimeout is how long a single probe of a readiness check may take.
+const probeTimeout = 5 * time.Second
+
+// ReadinessCheckFactory returns the readiness checks of a component, whose resources are named resourceName and
+// installed in namespace. The checks are run while waiting for the component to become ready, in addition to the
+// checks of its workloads.
+type ReadinessCheckFactory func(resourceName, namespace string) []manifest.ReadinessCheck
+
+var (
+	readinessChecksMu sync.RWMutex
+	readinessChecks   = make(map[string]ReadinessCheckFactory)
+)
+
+// RegisterReadinessChecks registers factory as the source of the custom readiness checks of the component named
+// componentName, like Pilot, or of the addon component named componentName. Like RegisterRenderer, it is meant to be
+// called from an init function of a package linked into istioctl or the operator.
+func RegisterReadinessChecks(componentName string, factory ReadinessCheckFactory) error {
+	if componentName == "" || factory == nil {
+		return fmt.Errorf("readiness checks must have a component name and a factory")
+	}
+	readinessChecksMu.Lock()
+	defer readinessChecksMu.Unlock()
+	if _, ok := readinessChecks[componentName]; ok {
+		return fmt.Errorf("readiness checks are already registered for component %s", componentName)
+	}
+	readinessChecks[componentName] = factory
+	return nil
+}
+
+// UnregisterReadinessChecks removes the readiness checks registered for componentName, if any.
+func UnregisterReadinessChecks(componentName string) {
+	readinessChecksMu.Lock()
+	defer readinessChecksMu.Unlock()
+	delete(readinessChecks, componentName)
+}
+
+// ReadinessChecks implements the IstioComponent interface.
+func (c *CommonComponentFields) ReadinessChecks() []manifest.ReadinessCheck {
+	key := string(c.componentName)
+	if c.addonName != "" {
+		key = c.addonName
+	}
+	readinessChecksMu.RLock()
+	f, ok := readinessChecks[key]
+	readinessChecksMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return f(c.resourceName, c.Namespace)
+}
+
+// HTTPReadinessCheck returns a check which passes when a GET of path on port of the Service in namespace returns a
+// 2xx status. The request goes through the service proxy of the API server, so it works wherever the cluster is
+// reachable.
+func HTTPReadinessCheck(namespace, service string, port int, path string) manifest.ReadinessCheck {
+	return manifest.ReadinessCheck{
+		Name: fmt.Sprintf("http://%s.%s:%d%s", service, namespace, port, path),
+		Check: func(ctx context.Context, cs kubernetes.Interface) (bool, string, error) {
+			ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+			_, err := cs.CoreV1().Services(namespace).ProxyGet("http", service, strconv.Itoa(port), path, nil).DoRaw(ctx)
+			if err != nil {
+				return false, err.Error(), nil
+			}
+			return true, "OK", nil
+		},
+	}
+}
+
+// GRPCHealthCheck returns a check which passes when the gRPC health service at address, e.g.
+// istiod.istio-system.svc:15010, reports service as serving. An empty service checks the server as a whole. The
+// address is dialed directly, so the check only works where it resolves and is routable, like in the operator.
+func GRPCHealthCheck(address, service string) manifest.ReadinessCheck {
+	return manifest.ReadinessCheck{
+		Name: "grpc://" + address + "/" + service,
+		Check: func(ctx context.Context, _ kubernetes.Interface) (bool, string, error) {
+			ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+			conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+			if err != nil {
+				return false, err.Error(), nil
+			}
+			defer conn.Close()
+			resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+			if err != nil {
+				return false, err.Error(), nil
+			}
+			return resp.Status == healthpb.HealthCheckResponse_SERVING, resp.Status.String(), nil
+		},
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/translate"
+)
+
+func TestRegisterReadinessChecks(t *testing.T) {
+	factory := func(resourceName, namespace string) []manifest.ReadinessCheck {
+		return []manifest.ReadinessCheck{{
+			Name: resourceName + "." + namespace,
+			Check: func(context.Context, kubernetes.Interface) (bool, string, error) {
+				return true, "OK", nil
+			},
+		}}
+	}
+	if err := RegisterReadinessChecks("wasm-distributor", factory); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterReadinessChecks("wasm-distributor")
+	if err := RegisterReadinessChecks("wasm-distributor", factory); err == nil {
+		t.Error("got no error registering readiness checks twice, want error")
+	}
+	if err := RegisterReadinessChecks("", factory); err == nil {
+		t.Error("got no error registering readiness checks without a name, want error")
+	}
+
+	opts := &Options{
+		Namespace: "istio-system",
+		Translator: &translate.Translator{
+			ComponentMaps: map[name.ComponentName]*translate.ComponentMaps{
+				name.PilotComponentName: {ResourceName: "istiod"},
+			},
+		},
+	}
+	addon := NewAddonComponent("wasm-distributor", "wasm", nil, opts)
+	checks := addon.ReadinessChecks()
+	if len(checks) != 1 || checks[0].Name != "wasm.istio-system" {
+		t.Errorf("got checks %v for wasm-distributor, want wasm.istio-system", checks)
+	}
+	if checks := NewCoreComponent(name.PilotComponentName, opts).ReadinessChecks(); len(checks) != 0 {
+		t.Errorf("got checks %v for Pilot, want none", checks)
+	}
+}
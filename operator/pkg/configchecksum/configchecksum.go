@@ -0,0 +1,221 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configchecksum annotates the pod templates of rendered workloads with a checksum of the ConfigMaps and
+// Secrets they use, so that changing only the config rolls their pods.
+package configchecksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// Annotation is the pod template annotation holding the checksum, named as in the Helm checksum/config pattern.
+	Annotation = "checksum/config"
+
+	valuesPath = "global.configChecksums"
+)
+
+// Settings reports whether config checksums are enabled in values.global.configChecksums of the given values tree,
+// which is the default.
+func Settings(values map[string]interface{}) bool {
+	v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(valuesPath))
+	if !found {
+		return true
+	}
+	enabled, ok := v.(bool)
+	return !ok || enabled
+}
+
+// Apply returns manifests with the pod templates of Deployments, DaemonSets and StatefulSets annotated with a checksum
+// of the ConfigMaps and Secrets in manifests which the pods mount or reference in their environment. Config rendered
+// by another component, like the mesh config of Pilot used by gateways, is included. Manifests without workloads that
+// use config are returned unchanged. Jobs are skipped, since their pod templates cannot be updated.
+func Apply(manifests name.ManifestMap) (name.ManifestMap, error) {
+	parsed := make(map[name.ComponentName][]object.K8sObjects)
+	configs := make(map[string]*object.K8sObject)
+	for cn, ms := range manifests {
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			parsed[cn] = append(parsed[cn], objs)
+			for _, o := range objs {
+				if o.Kind == "ConfigMap" || o.Kind == "Secret" {
+					configs[o.Hash()] = o
+				}
+			}
+		}
+	}
+	if len(configs) == 0 {
+		return manifests, nil
+	}
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		for i, m := range ms {
+			am, err := annotate(m, parsed[cn][i], configs)
+			if err != nil {
+				return nil, err
+			}
+			out[cn] = append(out[cn], am)
+		}
+	}
+	return out, nil
+}
+
+// annotate returns manifest, which holds objs, with the checksums of the workloads in objs set. manifest is returned
+// unchanged if no workload uses any of configs.
+func annotate(manifest string, objs object.K8sObjects, configs map[string]*object.K8sObject) (string, error) {
+	var out object.K8sObjects
+	changed := false
+	for _, o := range objs {
+		u := o.UnstructuredObject()
+		if templatePath := podTemplatePath(u.GetKind()); templatePath != nil {
+			spec, found, err := unstructured.NestedMap(u.Object, append(templatePath, "spec")...)
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+			if found {
+				sum, err := checksum(configs, referencedConfigs(spec, u.GetNamespace()))
+				if err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				if sum != "" {
+					u = u.DeepCopy()
+					if err := unstructured.SetNestedField(u.Object, sum, append(templatePath, "metadata", "annotations", Annotation)...); err != nil {
+						return "", fmt.Errorf("%s: %s", o.Hash(), err)
+					}
+					changed = true
+				}
+			}
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	if !changed {
+		return manifest, nil
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// podTemplatePath returns the path to the pod template of workloads of the given kind which roll their pods when the
+// template changes, or nil if kind is not one of them.
+func podTemplatePath(kind string) []string {
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet":
+		return []string{"spec", "template"}
+	}
+	return nil
+}
+
+// referencedConfigs returns the hashes of the ConfigMaps and Secrets in namespace which the pod spec mounts as
+// volumes, including projected ones, or references in the env and envFrom of its containers.
+func referencedConfigs(spec map[string]interface{}, namespace string) []string {
+	refs := make(map[string]bool)
+	add := func(kind string, m map[string]interface{}, fields ...string) {
+		if n, _, _ := unstructured.NestedString(m, fields...); n != "" {
+			refs[object.Hash(kind, namespace, n)] = true
+		}
+	}
+	volumes, _, _ := unstructured.NestedSlice(spec, "volumes")
+	for _, v := range volumes {
+		vm, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		add("ConfigMap", vm, "configMap", "name")
+		add("Secret", vm, "secret", "secretName")
+		sources, _, _ := unstructured.NestedSlice(vm, "projected", "sources")
+		for _, s := range sources {
+			if sm, ok := s.(map[string]interface{}); ok {
+				add("ConfigMap", sm, "configMap", "name")
+				add("Secret", sm, "secret", "name")
+			}
+		}
+	}
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(spec, field)
+		for _, c := range containers {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			env, _, _ := unstructured.NestedSlice(cm, "env")
+			for _, e := range env {
+				if em, ok := e.(map[string]interface{}); ok {
+					add("ConfigMap", em, "valueFrom", "configMapKeyRef", "name")
+					add("Secret", em, "valueFrom", "secretKeyRef", "name")
+				}
+			}
+			envFrom, _, _ := unstructured.NestedSlice(cm, "envFrom")
+			for _, e := range envFrom {
+				if em, ok := e.(map[string]interface{}); ok {
+					add("ConfigMap", em, "configMapRef", "name")
+					add("Secret", em, "secretRef", "name")
+				}
+			}
+		}
+	}
+	out := make([]string, 0, len(refs))
+	for r := range refs {
+		out = append(out, r)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// checksum returns the hex encoded sha256 of the data of the objects in configs with the given hashes, or an empty
+// string if none of them are in configs.
+func checksum(configs map[string]*object.K8sObject, hashes []string) (string, error) {
+	h := sha256.New()
+	found := false
+	for _, hash := range hashes {
+		o, ok := configs[hash]
+		if !ok {
+			continue
+		}
+		found = true
+		u := o.Unstructured()
+		// Encoding a map sorts its keys, so the checksum only changes with the content.
+		b, err := json.Marshal(map[string]interface{}{
+			"data":       u["data"],
+			"binaryData": u["binaryData"],
+			"stringData": u["stringData"],
+		})
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(h, "%s\n%s\n", hash, b)
+	}
+	if !found {
+		return "", nil
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configchecksum
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	pilotManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  mesh: "enableTracing: %s"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+      volumes:
+      - name: config-volume
+        configMap:
+          name: istio
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: unrelated
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        envFrom:
+        - configMapRef:
+            name: not-rendered
+`
+	gatewayManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+spec:
+  template:
+    metadata:
+      annotations:
+        sidecar.istio.io/inject: "false"
+    spec:
+      containers:
+      - name: istio-proxy
+        env:
+        - name: MESH
+          valueFrom:
+            configMapKeyRef:
+              name: istio
+              key: mesh
+`
+)
+
+func TestApply(t *testing.T) {
+	render := func(tracing string) map[string]string {
+		mm := name.ManifestMap{
+			name.PilotComponentName:   {strings.Replace(pilotManifest, "%s", tracing, 1)},
+			name.IngressComponentName: {gatewayManifest},
+		}
+		got, err := Apply(mm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sums := make(map[string]string)
+		for _, ms := range got {
+			for _, m := range ms {
+				objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, o := range objs {
+					if o.Kind != "Deployment" {
+						continue
+					}
+					annotations, _, _ := unstructured.NestedStringMap(o.Unstructured(), "spec", "template", "metadata", "annotations")
+					sums[o.Name] = annotations[Annotation]
+					if o.Name == "istio-ingressgateway" && annotations["sidecar.istio.io/inject"] != "false" {
+						t.Errorf("existing annotation of %s removed", o.Name)
+					}
+				}
+			}
+		}
+		return sums
+	}
+	first, again, changed := render("false"), render("false"), render("true")
+	if first["istiod"] == "" || first["istio-ingressgateway"] == "" {
+		t.Fatalf("got checksums %v, want istiod and istio-ingressgateway annotated", first)
+	}
+	if first["istiod"] != first["istio-ingressgateway"] {
+		t.Errorf("got different checksums %v for the same config", first)
+	}
+	if got := first["unrelated"]; got != "" {
+		t.Errorf("got checksum %s for a workload of config which is not rendered", got)
+	}
+	if first["istiod"] != again["istiod"] {
+		t.Errorf("checksums differ between renders: %v, %v", first, again)
+	}
+	if first["istiod"] == changed["istiod"] || first["istio-ingressgateway"] == changed["istio-ingressgateway"] {
+		t.Errorf("checksums did not change with the config: %v, %v", first, changed)
+	}
+}
+
+func TestSettings(t *testing.T) {
+	if !Settings(nil) {
+		t.Errorf("expected checksums to be enabled by default")
+	}
+	if Settings(map[string]interface{}{"global": map[string]interface{}{"configChecksums": false}}) {
+		t.Errorf("expected checksums to be disabled")
+	}
+}
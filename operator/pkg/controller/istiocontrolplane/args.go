@@ -15,6 +15,8 @@
 package istiocontrolplane
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +28,14 @@ type Options struct {
 	// DefaultChartPath is the relative path used added to BaseChartPath when no value is specified in
 	// IstioOperator.Spec.ChartPath
 	DefaultChartPath string
+	// DriftCheckInterval is the interval at which installed resources are compared to the manifests last applied for
+	// each IstioOperator. Drift checks are disabled if it is zero.
+	DriftCheckInterval time.Duration
+	// DriftAutoRemediate causes the controller to reconcile an IstioOperator when drift of its resources is detected.
+	DriftAutoRemediate bool
+	// RetainFields are fields of the form Kind:path whose live values are kept when objects are updated, in addition
+	// to the fields the reconciler always retains.
+	RetainFields []string
 }
 
 // ControllerOptions represents the options used by the controller
@@ -43,6 +53,16 @@ func AttachCobraFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&controllerOptions.BaseChartPath, "base-chart-path", "",
 		"The absolute path to a directory containing nested charts, e.g. /etc/istio-operator/helm.  "+
 			"This will be used as the base path for any IstioOperator instances specifying a relative ChartPath.")
+	cmd.PersistentFlags().DurationVar(&controllerOptions.DriftCheckInterval, "drift-check-interval", 0,
+		"The interval at which installed resources are checked for drift from the manifests last applied, and the "+
+			"Drifted condition of each IstioOperator is updated, e.g. 5m. Drift checks are disabled if zero.")
+	cmd.PersistentFlags().BoolVar(&controllerOptions.DriftAutoRemediate, "drift-auto-remediate", false,
+		"If true, an IstioOperator is reconciled when drift of its resources is detected.")
+	cmd.PersistentFlags().StringSliceVar(&controllerOptions.RetainFields, "retain-fields", nil,
+		"Comma separated list of fields of the form Kind:path, e.g. Service:spec.loadBalancerIP, whose values in the "+
+			"cluster are kept when resources are updated, unless the manifest sets them. Service clusterIP and "+
+			"nodePorts and webhook caBundles are always kept unless set, the replicas of Deployments scaled by a "+
+			"HorizontalPodAutoscaler are always kept.")
 	cmd.PersistentFlags().StringVar(&controllerOptions.BaseChartPath, "default-chart-path", "",
 		"A path relative to base-chart-path containing charts to be used when no ChartPath is specified by an IstioOperator resource, e.g. 1.1.0/istio")
 }
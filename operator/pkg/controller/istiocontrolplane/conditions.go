@@ -0,0 +1,80 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+// conditionsStatusField is the field under the IstioOperator status holding the conditions set by the controller.
+// Like effectiveSpec, it is not part of the typed InstallStatus.
+const conditionsStatusField = "conditions"
+
+// setStatusCondition sets the condition of the given type in the status of the IstioOperator with the given namespace
+// and name, keeping any other conditions.
+func setStatusCondition(cl client.Client, namespace, iopName, condType string, status corev1.ConditionStatus,
+	reason, message string) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(iopv1alpha1.IstioOperatorGVK)
+	if err := cl.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: iopName}, u); err != nil {
+		return err
+	}
+	conditions, _, err := unstructured.NestedSlice(u.Object, "status", conditionsStatusField)
+	if err != nil {
+		return fmt.Errorf("bad conditions in status: %s", err)
+	}
+	cond := map[string]interface{}{
+		"type":    condType,
+		"status":  string(status),
+		"reason":  reason,
+		"message": message,
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{conditionsStatusField: updateCondition(conditions, cond, metav1.Now())},
+	})
+	if err != nil {
+		return err
+	}
+	return cl.Status().Patch(context.TODO(), u, client.RawPatch(types.MergePatchType, patch))
+}
+
+// updateCondition returns conditions with cond replacing any condition of the same type. The last transition time of
+// cond is kept from the condition it replaces if the status is unchanged, and set to now otherwise.
+func updateCondition(conditions []interface{}, cond map[string]interface{}, now metav1.Time) []interface{} {
+	cond["lastTransitionTime"] = now.UTC().Format(time.RFC3339)
+	out := make([]interface{}, 0, len(conditions)+1)
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok || cm["type"] != cond["type"] {
+			out = append(out, c)
+			continue
+		}
+		if cm["status"] == cond["status"] && cm["lastTransitionTime"] != nil {
+			cond["lastTransitionTime"] = cm["lastTransitionTime"]
+		}
+	}
+	return append(out, cond)
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/pkg/log"
+)
+
+const (
+	// driftedConditionType is the type of the IstioOperator status condition reporting drift of the installed
+	// resources from the manifests last applied.
+	driftedConditionType = "Drifted"
+	// maxDriftedInCondition is the maximum number of drifted resources listed in the condition message.
+	maxDriftedInCondition = 20
+)
+
+// addDriftChecker adds a periodic drift check of all IstioOperator resources to mgr, if enabled in controllerOptions.
+func addDriftChecker(mgr manager.Manager) error {
+	if controllerOptions.DriftCheckInterval <= 0 {
+		return nil
+	}
+	log.Infof("Checking for drift of installed resources every %s", controllerOptions.DriftCheckInterval)
+	cl := mgr.GetClient()
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		ticker := time.NewTicker(controllerOptions.DriftCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return nil
+			case <-ticker.C:
+				checkAllDrift(cl)
+			}
+		}
+	}))
+}
+
+// checkAllDrift checks each IstioOperator in the watched namespace for drift, logging any errors.
+func checkAllDrift(cl client.Client) {
+	iops := &iopv1alpha1.IstioOperatorList{}
+	if err := cl.List(context.TODO(), iops); err != nil {
+		log.Errorf("drift check failed to list IstioOperators: %s", err)
+		return
+	}
+	for i := range iops.Items {
+		iop := &iops.Items[i]
+		if iop.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if err := checkDrift(cl, iop); err != nil {
+			log.Errorf("drift check of IstioOperator %s/%s failed: %s", iop.Namespace, iop.Name, err)
+		}
+	}
+}
+
+// checkDrift compares the live resources of iop to the manifests last applied for it, sets its Drifted condition and,
+// if enabled and iop is not paused, requests a reconcile to remediate any drift.
+func checkDrift(cl client.Client, iop *iopv1alpha1.IstioOperator) error {
+	namespace, iopName := iop.Namespace, iop.Name
+	manifests := LastManifests(namespace, iopName)
+	if manifests == nil {
+		var err error
+		if manifests, err = helmreconciler.ReadManifestSnapshot(cl, iopName, namespace); err != nil {
+			return err
+		}
+	}
+	if manifests == nil {
+		// Not installed yet.
+		return nil
+	}
+	drifted, err := helmreconciler.DetectDrift(cl, manifests)
+	if err != nil {
+		return err
+	}
+	if err := setDriftedCondition(cl, namespace, iopName, drifted); err != nil {
+		return err
+	}
+	if len(drifted) == 0 {
+		return nil
+	}
+	log.Warnf("IstioOperator %s/%s: %s", namespace, iopName, driftedMessage(drifted))
+	if controllerOptions.DriftAutoRemediate && !iopv1alpha1.IsPaused(iop) {
+		// Applying again is skipped for objects in the cache, which still match the manifests.
+		helmreconciler.FlushObjectCachesFor(iopName)
+		if !RequestReconcile(namespace, iopName) {
+			return fmt.Errorf("too many reconcile requests queued, drift remediation will be retried at the next check")
+		}
+	}
+	return nil
+}
+
+// setDriftedCondition sets the Drifted condition of the IstioOperator with the given namespace and name for drifted.
+func setDriftedCondition(cl client.Client, namespace, iopName string, drifted []helmreconciler.DriftedResource) error {
+	if len(drifted) == 0 {
+		return setStatusCondition(cl, namespace, iopName, driftedConditionType, corev1.ConditionFalse, "InSync",
+			"All resources match the manifests last applied.")
+	}
+	return setStatusCondition(cl, namespace, iopName, driftedConditionType, corev1.ConditionTrue, "ResourcesChanged",
+		driftedMessage(drifted))
+}
+
+// driftedMessage returns a condition message listing drifted, truncated to maxDriftedInCondition resources.
+func driftedMessage(drifted []helmreconciler.DriftedResource) string {
+	var items []string
+	for i, d := range drifted {
+		if i == maxDriftedInCondition {
+			items = append(items, fmt.Sprintf("and %d more", len(drifted)-i))
+			break
+		}
+		items = append(items, d.String())
+	}
+	return "Resources differ from the manifests last applied: " + strings.Join(items, ", ")
+}
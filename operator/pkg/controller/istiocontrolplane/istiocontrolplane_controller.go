@@ -16,8 +16,10 @@ package istiocontrolplane
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -38,11 +40,17 @@ import (
 	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
 	"istio.io/istio/operator/pkg/helmreconciler"
 	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/util/clog"
 	"istio.io/pkg/log"
 )
 
 const (
 	finalizer = "istio-finalizer.install.istio.io"
+	// pausedConditionType is the type of the IstioOperator status condition reporting whether reconciliation is paused.
+	pausedConditionType = "Paused"
+	// dryRunConditionType is the type of the IstioOperator status condition reporting whether changes are only
+	// previewed because of the dry-run annotation.
+	dryRunConditionType = "DryRun"
 	// finalizerMaxRetries defines the maximum number of attempts to remove the finalizer.
 	finalizerMaxRetries = 1
 )
@@ -102,11 +110,19 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	if err != nil {
 		return err
 	}
+	// Watch for on demand reconcile requests.
+	err = c.Watch(&source.Channel{Source: reconcileRequests}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
 	//watch for changes to Istio resources
 	err = watchIstioResources(c)
 	if err != nil {
 		return err
 	}
+	if err := addDriftChecker(mgr); err != nil {
+		return err
+	}
 	log.Info("Controller added")
 	return nil
 }
@@ -132,6 +148,8 @@ func getPredicateForIstioOperator() predicate.Funcs {
 			}
 			if !reflect.DeepEqual(oldIOP.Spec, newIOP.Spec) ||
 				oldIOP.GetDeletionTimestamp() != newIOP.GetDeletionTimestamp() ||
+				iopv1alpha1.IsPaused(oldIOP) != iopv1alpha1.IsPaused(newIOP) ||
+				iopv1alpha1.IsDryRun(oldIOP) != iopv1alpha1.IsDryRun(newIOP) ||
 				oldIOP.GetGeneration() != newIOP.GetGeneration() {
 				return true
 			}
@@ -178,6 +196,18 @@ func (r *ReconcileIstioOperator) Reconcile(request reconcile.Request) (reconcile
 		return reconcile.Result{}, err
 	}
 
+	if iopv1alpha1.IsPaused(iop) {
+		// Leave all resources, including the finalizer of a deleted CR, as they are until the annotation is removed.
+		log.Infof("Reconciliation of IstioOperator %s is paused by the %s annotation", reqNamespacedName, iopv1alpha1.PausedAnnotation)
+		return reconcile.Result{}, setStatusCondition(r.client, ns, request.Name, pausedConditionType, corev1.ConditionTrue,
+			"PausedByAnnotation", fmt.Sprintf("Reconciliation and pruning are suspended until the %s annotation is removed.",
+				iopv1alpha1.PausedAnnotation))
+	}
+	if err := setStatusCondition(r.client, ns, request.Name, pausedConditionType, corev1.ConditionFalse, "Reconciling",
+		"Reconciliation is active."); err != nil {
+		log.Errorf("failed to update the paused condition: %s", err)
+	}
+
 	deleted := iop.GetDeletionTimestamp() != nil
 	finalizers := sets.NewString(iop.GetFinalizers()...)
 	if deleted {
@@ -194,6 +224,7 @@ func (r *ReconcileIstioOperator) Reconcile(request reconcile.Request) (reconcile
 		if err := reconciler.Delete(); err != nil {
 			return reconcile.Result{}, err
 		}
+		setLastManifests(reqNamespacedName, nil)
 		finalizers.Delete(finalizer)
 		iop.SetFinalizers(finalizers.List())
 		finalizerError := r.client.Update(context.TODO(), iop)
@@ -264,10 +295,14 @@ func (r *ReconcileIstioOperator) Reconcile(request reconcile.Request) (reconcile
 		}
 		globalValues["jwtPolicy"] = string(jwtPolicy)
 	}
-	reconciler, err := helmreconciler.NewHelmReconciler(r.client, r.config, iopMerged, nil)
+	opts := &helmreconciler.Options{Log: clog.NewDefaultLogger(), RetainFields: controllerOptions.RetainFields}
+	reconciler, err := helmreconciler.NewHelmReconciler(r.client, r.config, iopMerged, opts)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if iopv1alpha1.IsDryRun(iop) {
+		return reconcile.Result{}, previewChanges(r.client, reconciler, ns, request.Name)
+	}
 	if err := reconciler.SetStatusBegin(); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -275,13 +310,56 @@ func (r *ReconcileIstioOperator) Reconcile(request reconcile.Request) (reconcile
 	if err != nil {
 		log.Errorf("reconciling err: %s", err)
 	}
+	setLastManifests(reqNamespacedName, reconciler.GetManifests().ManifestMap())
+	if err := reconciler.SaveManifestSnapshot(); err != nil {
+		log.Errorf("failed to save manifest snapshot: %s", err)
+	}
 	if err := reconciler.SetStatusComplete(status); err != nil {
 		return reconcile.Result{}, err
 	}
+	if err := clearDryRun(r.client, reconciler, ns, request.Name); err != nil {
+		log.Errorf("failed to clear the dry run status: %s", err)
+	}
 
 	return reconcile.Result{}, err
 }
 
+// previewChanges writes the changes reconciler would make into the status of the IstioOperator with the given
+// namespace and name, without applying them.
+func previewChanges(cl client.Client, reconciler *helmreconciler.HelmReconciler, namespace, iopName string) error {
+	changes, err := reconciler.PreviewChanges()
+	if err != nil {
+		log.Errorf("dry run of IstioOperator %s/%s failed: %s", namespace, iopName, err)
+		if cerr := setStatusCondition(cl, namespace, iopName, dryRunConditionType, corev1.ConditionTrue, "PreviewFailed",
+			fmt.Sprintf("Failed to preview changes: %s", err)); cerr != nil {
+			log.Errorf("failed to update the dry run condition: %s", cerr)
+		}
+		return err
+	}
+	summary := helmreconciler.ChangesSummary(changes)
+	log.Infof("Dry run of IstioOperator %s/%s: %s", namespace, iopName, summary)
+	if err := reconciler.SetStatusDryRun(true, changes); err != nil {
+		return err
+	}
+	reason := "ChangesPending"
+	if len(changes) == 0 {
+		reason = "NoChanges"
+	}
+	return setStatusCondition(cl, namespace, iopName, dryRunConditionType, corev1.ConditionTrue, reason,
+		fmt.Sprintf("Changes are previewed in status.dryRun and not applied until the %s annotation is removed: %s.",
+			iopv1alpha1.DryRunAnnotation, summary))
+}
+
+// clearDryRun removes the changes previewed by a previous dry run from the status of the IstioOperator with the given
+// namespace and name.
+func clearDryRun(cl client.Client, reconciler *helmreconciler.HelmReconciler, namespace, iopName string) error {
+	if err := reconciler.SetStatusDryRun(false, nil); err != nil {
+		return err
+	}
+	return setStatusCondition(cl, namespace, iopName, dryRunConditionType, corev1.ConditionFalse, "Applying",
+		"Changes are applied.")
+}
+
 var ownedResourcePredicates = predicate.Funcs{
 	CreateFunc: func(_ event.CreateEvent) bool {
 		// no action
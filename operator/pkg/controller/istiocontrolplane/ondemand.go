@@ -0,0 +1,76 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+const (
+	// maxQueuedReconcileRequests is the maximum number of on demand reconcile requests waiting to be picked up by
+	// the controller.
+	maxQueuedReconcileRequests = 16
+)
+
+var (
+	// reconcileRequests is a source of on demand reconcile requests, watched by the controller.
+	reconcileRequests = make(chan event.GenericEvent, maxQueuedReconcileRequests)
+
+	// lastManifests holds the manifests rendered by the last reconcile of each IstioOperator.
+	lastManifests = make(map[types.NamespacedName]name.ManifestMap)
+	// lastManifestsMu protects lastManifests.
+	lastManifestsMu sync.RWMutex
+)
+
+// RequestReconcile queues a reconcile of the IstioOperator with the given namespace and name, which is processed like
+// any other reconcile of the controller. It returns false if the request could not be queued because too many
+// requests are already waiting.
+func RequestReconcile(namespace, iopName string) bool {
+	iop := &iopv1alpha1.IstioOperator{}
+	iop.SetNamespace(namespace)
+	iop.SetName(iopName)
+	select {
+	case reconcileRequests <- event.GenericEvent{Meta: iop, Object: iop}:
+		return true
+	default:
+		return false
+	}
+}
+
+// LastManifests returns the manifests rendered by the last reconcile of the IstioOperator with the given namespace
+// and name by this controller, or nil if it was not reconciled since the controller started.
+func LastManifests(namespace, iopName string) name.ManifestMap {
+	lastManifestsMu.RLock()
+	defer lastManifestsMu.RUnlock()
+	return lastManifests[types.NamespacedName{Namespace: namespace, Name: iopName}]
+}
+
+// setLastManifests records manifests as the last rendered for the IstioOperator nn. A nil manifests removes the
+// record.
+func setLastManifests(nn types.NamespacedName, manifests name.ManifestMap) {
+	lastManifestsMu.Lock()
+	defer lastManifestsMu.Unlock()
+	if manifests == nil {
+		delete(lastManifests, nn)
+		return
+	}
+	lastManifests[nn] = manifests
+}
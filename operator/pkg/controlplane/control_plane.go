@@ -17,11 +17,17 @@ package controlplane
 import (
 	"fmt"
 	"sort"
+	"sync"
 
 	"istio.io/api/operator/v1alpha1"
 	iop "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
 	"istio.io/istio/operator/pkg/component"
+	"istio.io/istio/operator/pkg/configchecksum"
+	"istio.io/istio/operator/pkg/egress"
+	"istio.io/istio/operator/pkg/manifest"
 	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/resourcemeta"
+	"istio.io/istio/operator/pkg/scheduling"
 	"istio.io/istio/operator/pkg/translate"
 	"istio.io/istio/operator/pkg/util"
 )
@@ -30,12 +36,18 @@ import (
 type IstioOperator struct {
 	// components is a slice of components that are part of the feature.
 	components []component.IstioComponent
-	started    bool
+	// installSpec is the spec the components are rendered from.
+	installSpec *v1alpha1.IstioOperatorSpec
+	started     bool
 }
 
 // NewIstioOperator creates a new IstioOperator and returns a pointer to it.
 func NewIstioOperator(installSpec *v1alpha1.IstioOperatorSpec, translator *translate.Translator) (*IstioOperator, error) {
-	out := &IstioOperator{}
+	egress.EnableGateway(installSpec)
+	if err := scheduling.Apply(installSpec); err != nil {
+		return nil, err
+	}
+	out := &IstioOperator{installSpec: installSpec}
 	opts := &component.Options{
 		InstallSpec: installSpec,
 		Translator:  translator,
@@ -93,10 +105,22 @@ func defaultIfEmpty(val, dflt string) string {
 	return val
 }
 
-// Run starts the Istio control plane.
+// Run starts the Istio control plane. Components are started concurrently, since starting a component loads its
+// charts.
 func (i *IstioOperator) Run() error {
-	for _, c := range i.components {
-		if err := c.Run(); err != nil {
+	errs := make([]error, len(i.components))
+	var wg sync.WaitGroup
+	for idx, c := range i.components {
+		idx, c := idx, c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[idx] = c.Run()
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
 			return err
 		}
 	}
@@ -104,20 +128,118 @@ func (i *IstioOperator) Run() error {
 	return nil
 }
 
-// RenderManifest returns a manifest rendered against
+// RenderManifest returns a manifest rendered against. Components are rendered concurrently and the results are
+// collected in component order, so that the output is the same as for sequential rendering. Workloads are annotated
+// with the checksums of their config, unless disabled through values.global.configChecksums, and all objects get the
+// labels and annotations in values.global.resourceLabels and values.global.resourceAnnotations.
 func (i *IstioOperator) RenderManifest() (manifests name.ManifestMap, errsOut util.Errors) {
 	if !i.started {
 		return nil, util.NewErrs(fmt.Errorf("istioControlPlane must be Run before calling RenderManifest"))
 	}
 
+	rendered := make([]string, len(i.components))
+	errs := make([]error, len(i.components))
+	var wg sync.WaitGroup
+	for idx, c := range i.components {
+		idx, c := idx, c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rendered[idx], errs[idx] = c.RenderManifest()
+		}()
+	}
+	wg.Wait()
+
 	manifests = make(name.ManifestMap)
-	for _, c := range i.components {
-		ms, err := c.RenderManifest()
-		errsOut = util.AppendErr(errsOut, err)
-		manifests[c.ComponentName()] = append(manifests[c.ComponentName()], ms)
+	for idx, c := range i.components {
+		errsOut = util.AppendErr(errsOut, errs[idx])
+		manifests[c.ComponentName()] = append(manifests[c.ComponentName()], rendered[idx])
 	}
 	if len(errsOut) > 0 {
 		return nil, errsOut
 	}
+	// Checksums cover the config of all components, so they are added once everything is rendered.
+	if configchecksum.Settings(i.installSpec.Values) {
+		var err error
+		if manifests, err = configchecksum.Apply(manifests); err != nil {
+			return nil, util.NewErrs(fmt.Errorf("failed to add config checksums: %s", err))
+		}
+	}
+	labels, annotations := resourcemeta.Settings(i.installSpec.Values)
+	var err error
+	if manifests, err = resourcemeta.Apply(manifests, labels, annotations); err != nil {
+		return nil, util.NewErrs(fmt.Errorf("failed to add resource labels and annotations: %s", err))
+	}
 	return
 }
+
+// ChartVersions returns the versions of the charts of the enabled components by component name. Components rendered
+// by addon plugins are left out.
+func (i *IstioOperator) ChartVersions() map[name.ComponentName]string {
+	out := make(map[name.ComponentName]string)
+	for _, c := range i.components {
+		if !c.Enabled() {
+			continue
+		}
+		if v := c.ChartVersion(); v != "" {
+			out[c.ComponentName()] = v
+		}
+	}
+	return out
+}
+
+// RenderedChartVersions returns the versions of the charts of the enabled components with manifests in manifests, by
+// chart name. Components rendered by addon plugins are left out.
+func (i *IstioOperator) RenderedChartVersions(manifests name.ManifestMap) map[string]string {
+	out := make(map[string]string)
+	for _, c := range i.components {
+		if _, ok := manifests[c.ComponentName()]; !ok || !c.Enabled() {
+			continue
+		}
+		if n := c.ChartName(); n != "" {
+			out[n] = c.ChartVersion()
+		}
+	}
+	return out
+}
+
+// ReadinessChecks returns the custom readiness checks of the enabled components by component name.
+func (i *IstioOperator) ReadinessChecks() map[name.ComponentName][]manifest.ReadinessCheck {
+	out := make(map[name.ComponentName][]manifest.ReadinessCheck)
+	for _, c := range i.components {
+		if !c.Enabled() {
+			continue
+		}
+		if checks := c.ReadinessChecks(); len(checks) != 0 {
+			out[c.ComponentName()] = append(out[c.ComponentName()], checks...)
+		}
+	}
+	return out
+}
+
+// HelmCharts returns the charts and values of the enabled components, in component order, together with the
+// manifests rendered for the same components, which also include the K8s settings from the IstioOperatorSpec and
+// the other changes the operator makes to the chart output.
+func (i *IstioOperator) HelmCharts() ([]*component.HelmChart, []string, error) {
+	if !i.started {
+		return nil, nil, fmt.Errorf("istioControlPlane must be Run before calling HelmCharts")
+	}
+	var charts []*component.HelmChart
+	var manifests []string
+	for _, c := range i.components {
+		if !c.Enabled() {
+			continue
+		}
+		hc, err := c.HelmChart()
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := c.RenderManifest()
+		if err != nil {
+			return nil, nil, err
+		}
+		charts = append(charts, hc)
+		manifests = append(manifests, m)
+	}
+	return charts, manifests, nil
+}
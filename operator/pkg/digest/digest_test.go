@@ -0,0 +1,274 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+type fakeResolver struct {
+	digests  map[string]string
+	resolved []string
+}
+
+func (f *fakeResolver) Resolve(image string) (string, error) {
+	f.resolved = append(f.resolved, image)
+	d, ok := f.digests[image]
+	if !ok {
+		return "", fmt.Errorf("image %s not found", image)
+	}
+	return d, nil
+}
+
+const testManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: docker.io/istio/pilot:1.6.0
+      - name: sidecar
+        image: docker.io/istio/proxyv2:1.6.0@sha256:pinned
+`
+
+func TestPinManifests(t *testing.T) {
+	mm := name.ManifestMap{name.PilotComponentName: {testManifest}}
+	r := &fakeResolver{digests: map[string]string{"docker.io/istio/pilot:1.6.0": "sha256:1111"}}
+	lock := &Lock{Images: make(map[string]string)}
+
+	got, err := PinManifests(mm, lock, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := got[name.PilotComponentName][0]
+	if !strings.Contains(m, "image: docker.io/istio/pilot:1.6.0@sha256:1111") {
+		t.Errorf("PinManifests() expect pilot image pinned, got:\n%s", m)
+	}
+	if !strings.Contains(m, "image: docker.io/istio/proxyv2:1.6.0@sha256:pinned") {
+		t.Errorf("PinManifests() expect already pinned image unchanged, got:\n%s", m)
+	}
+	if want := map[string]string{"docker.io/istio/pilot:1.6.0": "sha256:1111"}; !reflect.DeepEqual(lock.Images, want) {
+		t.Errorf("PinManifests() got lock %v, want %v", lock.Images, want)
+	}
+
+	// A second run must reuse the lock without querying the resolver or requiring one.
+	if _, err := PinManifests(mm, lock, nil); err != nil {
+		t.Errorf("PinManifests() with lock: %s", err)
+	}
+	if len(r.resolved) != 1 {
+		t.Errorf("PinManifests() got %d resolve calls, want 1", len(r.resolved))
+	}
+	if _, err := PinManifests(mm, &Lock{Images: make(map[string]string)}, nil); err == nil {
+		t.Errorf("PinManifests() expect error for image missing from lock without resolver")
+	}
+}
+
+func TestPinInjectorImages(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio-sidecar-injector-canary
+  namespace: istio-system
+data:
+  values: |-
+    {
+      "global": {
+        "hub": "docker.io/istio",
+        "tag": "1.6.0",
+        "proxy": {
+          "image": "proxyv2"
+        },
+        "proxy_init": {
+          "image": "gcr.io/istio/proxyv2:1.6.0"
+        }
+      }
+    }
+`
+	mm := name.ManifestMap{name.PilotComponentName: {manifest}}
+	r := &fakeResolver{digests: map[string]string{
+		"docker.io/istio/proxyv2:1.6.0": "sha256:3333",
+		"gcr.io/istio/proxyv2:1.6.0":    "sha256:4444",
+	}}
+	got, err := PinManifests(mm, &Lock{Images: make(map[string]string)}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got[name.PilotComponentName][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]interface{})
+	vs, _, _ := unstructured.NestedString(objs[0].UnstructuredObject().Object, "data", "values")
+	if err := json.Unmarshal([]byte(vs), &values); err != nil {
+		t.Fatal(err)
+	}
+	for p, want := range map[string]string{
+		"global.proxy.image":      "docker.io/istio/proxyv2:1.6.0@sha256:3333",
+		"global.proxy_init.image": "gcr.io/istio/proxyv2:1.6.0@sha256:4444",
+		"global.tag":              "1.6.0",
+	} {
+		if got, _, _ := tpath.GetFromTreePath(values, util.PathFromString(p)); got != want {
+			t.Errorf("%s: got %v, want %s", p, got, want)
+		}
+	}
+}
+
+func TestLockfile(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "digest-lockfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	path := filepath.Join(tmpDir, "lock.yaml")
+	lock, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Images) != 0 {
+		t.Fatalf("ReadLockfile() of missing file got %v, want empty", lock.Images)
+	}
+	lock.Images["docker.io/istio/pilot:1.6.0"] = "sha256:1111"
+	if err := lock.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, lock) {
+		t.Errorf("ReadLockfile() got %v, want %v", got, lock)
+	}
+}
+
+func TestPinPackageManifests(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "digest-package")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	mm := name.ManifestMap{name.PilotComponentName: {testManifest}}
+
+	// A package without a lockfile is rendered unpinned.
+	got, unpinned, err := PinPackageManifests(mm, tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, mm) || len(unpinned) != 0 {
+		t.Errorf("PinPackageManifests() without lockfile got %v, %v, want unchanged", got, unpinned)
+	}
+
+	lock := &Lock{Images: map[string]string{"docker.io/istio/pilot:1.6.0": "sha256:1111"}}
+	if err := lock.Write(filepath.Join(tmpDir, PackageLockfileName)); err != nil {
+		t.Fatal(err)
+	}
+	if got := PackageLockfile(tmpDir); got != filepath.Join(tmpDir, PackageLockfileName) {
+		t.Errorf("PackageLockfile() got %q, want the packaged lockfile", got)
+	}
+	got, unpinned, err = PinPackageManifests(mm, tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := got[name.PilotComponentName][0]; !strings.Contains(m, "image: docker.io/istio/pilot:1.6.0@sha256:1111") {
+		t.Errorf("PinPackageManifests() expect pilot image pinned from the packaged lockfile, got:\n%s", m)
+	}
+	if len(unpinned) != 0 {
+		t.Errorf("PinPackageManifests() got unpinned images %v, want none", unpinned)
+	}
+
+	// Images which are not in the packaged lockfile, e.g. because of a different tag, are left unpinned.
+	overridden := strings.Replace(testManifest, "pilot:1.6.0", "pilot:1.6.1", 1)
+	got, unpinned, err = PinPackageManifests(name.ManifestMap{name.PilotComponentName: {overridden}}, tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := got[name.PilotComponentName][0]; !strings.Contains(m, "image: docker.io/istio/pilot:1.6.1\n") {
+		t.Errorf("PinPackageManifests() expect image missing from the lockfile unpinned, got:\n%s", m)
+	}
+	if want := []string{"docker.io/istio/pilot:1.6.1"}; !reflect.DeepEqual(unpinned, want) {
+		t.Errorf("PinPackageManifests() got unpinned images %v, want %v", unpinned, want)
+	}
+}
+
+func TestRegistryResolver(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.URL.Query().Get("scope") != "repository:istio/pilot:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "abc"}`))
+		case "/v2/istio/pilot/manifests/1.6.0":
+			if r.Header.Get("Authorization") != "Bearer abc" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:istio/pilot:pull"`, srv.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set(digestHeader, "sha256:2222")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := &registryResolver{client: srv.Client()}
+	host := strings.TrimPrefix(srv.URL, "https://")
+	got, err := r.Resolve(host + "/istio/pilot:1.6.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "sha256:2222" {
+		t.Errorf("Resolve() got %s, want sha256:2222", got)
+	}
+	if _, err := r.Resolve(host + "/istio/missing:1.6.0"); err == nil {
+		t.Errorf("Resolve() expect error for missing image")
+	}
+}
+
+func TestParseBearerChallenge(t *testing.T) {
+	got := parseBearerChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:a:pull,push"`)
+	want := map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry",
+		"scope":   "repository:a:pull,push",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBearerChallenge() got %v, want %v", got, want)
+	}
+}
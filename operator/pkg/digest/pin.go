@@ -0,0 +1,270 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// PackageLockfileName is the name of the digest lockfile which istioctl manifest package writes next to the charts
+	// and profiles of an installation package.
+	PackageLockfileName = "digests.lock.yaml"
+	// injectorConfigMapPrefix is the name prefix of the sidecar injector ConfigMaps, one per revision.
+	injectorConfigMapPrefix = "istio-sidecar-injector"
+)
+
+var (
+	// injectorImagePaths are the paths of the images the sidecar injector adds to pods, in its values.
+	injectorImagePaths = []string{"global.proxy.image", "global.proxy_init.image"}
+
+	// errNotPinned is returned by a Resolver to leave an image unpinned.
+	errNotPinned = errors.New("image not pinned")
+)
+
+// Lock maps tagged image references to the digests they were pinned to.
+type Lock struct {
+	// Images maps an image reference like docker.io/istio/pilot:1.6.0 to a digest like sha256:abcd...
+	Images map[string]string `json:"images"`
+}
+
+// ReadLockfile reads a Lock from path. A missing file results in an empty Lock.
+func ReadLockfile(path string) (*Lock, error) {
+	lock := &Lock{Images: make(map[string]string)}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, lock); err != nil {
+		return nil, fmt.Errorf("could not parse digest lockfile %s: %s", path, err)
+	}
+	if lock.Images == nil {
+		lock.Images = make(map[string]string)
+	}
+	return lock, nil
+}
+
+// PackageLockfile returns the path of the digest lockfile of the installation package at installPackagePath, or "" if
+// installPackagePath is empty or the package has no lockfile.
+func PackageLockfile(installPackagePath string) string {
+	if installPackagePath == "" {
+		return ""
+	}
+	path := filepath.Join(installPackagePath, PackageLockfileName)
+	if fi, err := os.Stat(path); err != nil || fi.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// PinPackageManifests pins the images in manifests to the digests in the lockfile of the installation package at
+// installPackagePath. If the package has no lockfile, manifests are returned unchanged. Images which are not in the
+// lockfile, e.g. because their hub or tag is overridden, are left unpinned and returned sorted, so that the caller can
+// warn about them.
+func PinPackageManifests(manifests name.ManifestMap, installPackagePath string) (name.ManifestMap, []string, error) {
+	path := PackageLockfile(installPackagePath)
+	if path == "" {
+		return manifests, nil, nil
+	}
+	lock, err := ReadLockfile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	missing := make(missingImages)
+	out, err := PinManifests(manifests, lock, missing)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not pin image digests from %s: %s", path, err)
+	}
+	var unpinned []string
+	for image := range missing {
+		unpinned = append(unpinned, image)
+	}
+	sort.Strings(unpinned)
+	return out, unpinned, nil
+}
+
+// missingImages is a Resolver which leaves all images unpinned and records them.
+type missingImages map[string]bool
+
+// Resolve implements Resolver.
+func (m missingImages) Resolve(image string) (string, error) {
+	m[image] = true
+	return "", errNotPinned
+}
+
+// Write writes the Lock to path.
+func (l *Lock) Write(path string) error {
+	b, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// PinManifests rewrites all tagged image references in manifests to digest references, including the proxy images
+// in the values of the sidecar injector ConfigMaps, which are injected into pods later. Digests are taken from
+// lock if present there, otherwise they are resolved with r and added to lock. If r is nil, images missing from
+// lock are an error.
+func PinManifests(manifests name.ManifestMap, lock *Lock, r Resolver) (name.ManifestMap, error) {
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		for _, m := range ms {
+			pm, err := pinManifest(m, lock, r)
+			if err != nil {
+				return nil, fmt.Errorf("component %s: %s", cn, err)
+			}
+			out[cn] = append(out[cn], pm)
+		}
+	}
+	return out, nil
+}
+
+func pinManifest(manifest string, lock *Lock, r Resolver) (string, error) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	var out object.K8sObjects
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		if err := pinImages(u.Object, lock, r); err != nil {
+			return "", err
+		}
+		if o.Kind == "ConfigMap" && strings.HasPrefix(o.Name, injectorConfigMapPrefix) {
+			if err := pinInjectorImages(u.Object, lock, r); err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// pinImages rewrites the values of all image fields in node to digest references.
+func pinImages(node interface{}, lock *Lock, r Resolver) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			if image, ok := v.(string); ok && k == "image" {
+				pinned, err := pinImage(image, lock, r)
+				if err != nil {
+					return err
+				}
+				n[k] = pinned
+				continue
+			}
+			if err := pinImages(v, lock, r); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, v := range n {
+			if err := pinImages(v, lock, r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pinInjectorImages rewrites the proxy images in the JSON values of the sidecar injector ConfigMap object cm to digest
+// references. An image which is a name rather than a full reference is expanded with the global hub and tag first, as
+// the injection template does, since the template uses full references as they are.
+func pinInjectorImages(cm map[string]interface{}, lock *Lock, r Resolver) error {
+	data, _ := cm["data"].(map[string]interface{})
+	vs, ok := data["values"].(string)
+	if !ok {
+		return nil
+	}
+	values := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(vs), &values); err != nil {
+		return fmt.Errorf("could not parse injector values: %s", err)
+	}
+	hub, _, _ := tpath.GetFromTreePath(values, util.PathFromString("global.hub"))
+	tag, _, _ := tpath.GetFromTreePath(values, util.PathFromString("global.tag"))
+	changed := false
+	for _, p := range injectorImagePaths {
+		path := util.PathFromString(p)
+		v, found, _ := tpath.GetFromTreePath(values, path)
+		image, ok := v.(string)
+		if !found || !ok || image == "" {
+			continue
+		}
+		if !strings.Contains(image, "/") {
+			image = fmt.Sprintf("%v/%s:%v", hub, image, tag)
+		}
+		pinned, err := pinImage(image, lock, r)
+		if err != nil {
+			return err
+		}
+		if err := tpath.WriteNode(values, path, pinned); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	b, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	data["values"] = string(b)
+	return nil
+}
+
+func pinImage(image string, lock *Lock, r Resolver) (string, error) {
+	if image == "" || strings.Contains(image, "@") {
+		// Already pinned.
+		return image, nil
+	}
+	digest, ok := lock.Images[image]
+	if !ok {
+		if r == nil {
+			return "", fmt.Errorf("image %s is not in the digest lockfile", image)
+		}
+		var err error
+		digest, err = r.Resolve(image)
+		if err == errNotPinned {
+			return image, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		lock.Images[image] = digest
+	}
+	return image + "@" + digest, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package digest pins container image references in rendered manifests to immutable digests.
+package digest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	digestHeader = "Docker-Content-Digest"
+	// Manifest media types accepted from the registry. Manifest lists are preferred so that pinned images remain
+	// multi-arch.
+	acceptedManifestTypes = "application/vnd.docker.distribution.manifest.list.v2+json," +
+		"application/vnd.oci.image.index.v1+json," +
+		"application/vnd.docker.distribution.manifest.v2+json," +
+		"application/vnd.oci.image.manifest.v1+json"
+	registryTimeout = 30 * time.Second
+)
+
+// Resolver resolves an image reference to a digest.
+type Resolver interface {
+	// Resolve returns the digest, e.g. sha256:abcd..., of the image with the given tagged reference.
+	Resolve(image string) (string, error)
+}
+
+// registryResolver resolves digests by querying the image registry over the Docker registry HTTP API v2, using
+// anonymous token authentication where the registry requires it.
+type registryResolver struct {
+	client *http.Client
+}
+
+// NewRegistryResolver returns a Resolver which queries image registries.
+func NewRegistryResolver() Resolver {
+	return &registryResolver{client: &http.Client{Timeout: registryTimeout}}
+}
+
+// Resolve implements Resolver.
+func (r *registryResolver) Resolve(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("bad image reference %s: %s", image, err)
+	}
+	named = reference.TagNameOnly(named)
+	tagged, ok := named.(reference.Tagged)
+	if !ok {
+		return "", fmt.Errorf("image reference %s has no tag", image)
+	}
+	host, repo := reference.Domain(named), reference.Path(named)
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repo, tagged.Tag())
+
+	resp, err := r.headManifest(manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := r.anonymousToken(resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", fmt.Errorf("could not authenticate to registry for %s: %s", image, err)
+		}
+		if resp, err = r.headManifest(manifestURL, token); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, image)
+	}
+	digest := resp.Header.Get(digestHeader)
+	if digest == "" {
+		return "", fmt.Errorf("registry returned no digest for %s", image)
+	}
+	return digest, nil
+}
+
+func (r *registryResolver) headManifest(manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", acceptedManifestTypes)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// anonymousToken requests a pull token from the token service named in a Bearer WWW-Authenticate challenge.
+func (r *registryResolver) anonymousToken(challenge string) (string, error) {
+	params := parseBearerChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+	q := url.Values{}
+	for _, k := range []string{"service", "scope"} {
+		if params[k] != "" {
+			q.Set(k, params[k])
+		}
+	}
+	resp, err := r.client.Get(realm + "?" + q.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token service returned %s", resp.Status)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	tr := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := yaml.Unmarshal(b, &tr); err != nil {
+		return "", err
+	}
+	if tr.Token != "" {
+		return tr.Token, nil
+	}
+	return tr.AccessToken, nil
+}
+
+// parseBearerChallenge parses the parameters of a header like
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:istio/pilot:pull".
+func parseBearerChallenge(challenge string) map[string]string {
+	out := make(map[string]string)
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return out
+	}
+	// Split on commas outside of quoted values, since values like scope may contain commas.
+	var kvs []string
+	inQuotes, start := false, 0
+	params := strings.TrimPrefix(challenge, "Bearer ")
+	for i, c := range params {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			kvs = append(kvs, params[start:i])
+			start = i + 1
+		}
+	}
+	kvs = append(kvs, params[start:])
+	for _, kv := range kvs {
+		kvp := strings.SplitN(strings.TrimSpace(kv), "=", 2)
+		if len(kvp) != 2 {
+			continue
+		}
+		out[kvp[0]] = strings.Trim(kvp[1], `"`)
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package egress bundles the settings of an install with egress locked down to the services in the mesh registry.
+package egress
+
+import (
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// GatewayName is the name of the default egress gateway, which is enabled when egress is locked down.
+	GatewayName = "istio-egressgateway"
+
+	valuesPath = "global.egressLockdown.enabled"
+)
+
+// Settings reports whether egress is locked down in values.global.egressLockdown.enabled of the given values tree.
+// The charts set the outbound traffic policy and render the baseline ServiceEntry from the same values.
+func Settings(values map[string]interface{}) bool {
+	v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(valuesPath))
+	if !found {
+		return false
+	}
+	enabled, _ := v.(bool)
+	return enabled
+}
+
+// EnableGateway enables the default egress gateway in iop, adding it to the egress gateway components if it is not
+// listed, if egress is locked down in the values of iop. Otherwise iop is unchanged.
+func EnableGateway(iop *v1alpha1.IstioOperatorSpec) {
+	if !Settings(iop.Values) {
+		return
+	}
+	if iop.Components == nil {
+		iop.Components = &v1alpha1.IstioComponentSetSpec{}
+	}
+	enabled := &v1alpha1.BoolValueForPB{BoolValue: types.BoolValue{Value: true}}
+	for _, gw := range iop.Components.EgressGateways {
+		if gw.Name == GatewayName {
+			gw.Enabled = enabled
+			return
+		}
+	}
+	iop.Components.EgressGateways = append(iop.Components.EgressGateways, &v1alpha1.GatewaySpec{
+		Name:    GatewayName,
+		Enabled: enabled,
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package egress
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"istio.io/api/operator/v1alpha1"
+)
+
+func TestEnableGateway(t *testing.T) {
+	lockdown := map[string]interface{}{
+		"global": map[string]interface{}{
+			"egressLockdown": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+	}
+	disabled := &v1alpha1.BoolValueForPB{BoolValue: types.BoolValue{Value: false}}
+	tests := []struct {
+		desc        string
+		values      map[string]interface{}
+		gateways    []*v1alpha1.GatewaySpec
+		wantEnabled map[string]bool
+	}{
+		{
+			desc:        "not locked down",
+			gateways:    []*v1alpha1.GatewaySpec{{Name: GatewayName}},
+			wantEnabled: map[string]bool{GatewayName: false},
+		},
+		{
+			desc:        "disabled gateway",
+			values:      lockdown,
+			gateways:    []*v1alpha1.GatewaySpec{{Name: GatewayName, Enabled: disabled}, {Name: "other"}},
+			wantEnabled: map[string]bool{GatewayName: true, "other": false},
+		},
+		{
+			desc:        "missing gateway",
+			values:      lockdown,
+			wantEnabled: map[string]bool{GatewayName: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			iop := &v1alpha1.IstioOperatorSpec{
+				Values:     tt.values,
+				Components: &v1alpha1.IstioComponentSetSpec{EgressGateways: tt.gateways},
+			}
+			EnableGateway(iop)
+			got := make(map[string]bool)
+			for _, gw := range iop.Components.EgressGateways {
+				got[gw.Name] = gw.Enabled != nil && gw.Enabled.Value
+			}
+			if len(got) != len(tt.wantEnabled) {
+				t.Fatalf("got gateways %v, want %v", got, tt.wantEnabled)
+			}
+			for gw, want := range tt.wantEnabled {
+				if got[gw] != want {
+					t.Errorf("gateway %s: got enabled %v, want %v", gw, got[gw], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSettings(t *testing.T) {
+	if Settings(nil) {
+		t.Error("got locked down for empty values, want not locked down")
+	}
+	values := map[string]interface{}{"global": map[string]interface{}{"egressLockdown": map[string]interface{}{"enabled": true}}}
+	if !Settings(values) {
+		t.Error("got not locked down, want locked down")
+	}
+}
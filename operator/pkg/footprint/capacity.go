@@ -0,0 +1,154 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodeCapacity is the CPU and memory of a node which is free for new pods.
+type nodeCapacity struct {
+	name     string
+	milliCPU int64
+	memory   int64
+}
+
+// CheckCapacity compares the requests of the workloads in r with the allocatable capacity of the schedulable nodes of
+// the cluster in cs, less the requests of the pods running on them, and returns a warning for each workload whose
+// pods cannot be scheduled and so would stay Pending. Pods of the workloads in r which are already running, e.g. of a
+// previous install being upgraded, are not counted as they are replaced.
+func CheckCapacity(cs kubernetes.Interface, r *Report) ([]string, error) {
+	nodes, err := cs.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list nodes: %s", err)
+	}
+	pods, err := cs.CoreV1().Pods(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list pods: %s", err)
+	}
+	return capacityWarnings(r, freeCapacity(nodes.Items, pods.Items, r)), nil
+}
+
+// freeCapacity returns the free capacity of the schedulable nodes, which is their allocatable capacity less the
+// requests of the pods bound to them, except for the pods of the workloads in r.
+func freeCapacity(nodes []corev1.Node, pods []corev1.Pod, r *Report) []*nodeCapacity {
+	byName := make(map[string]*nodeCapacity)
+	var out []*nodeCapacity
+	for _, n := range nodes {
+		if n.Spec.Unschedulable {
+			continue
+		}
+		nc := &nodeCapacity{
+			name:     n.Name,
+			milliCPU: n.Status.Allocatable.Cpu().MilliValue(),
+			memory:   n.Status.Allocatable.Memory().Value(),
+		}
+		byName[n.Name] = nc
+		out = append(out, nc)
+	}
+	for _, p := range pods {
+		nc := byName[p.Spec.NodeName]
+		if nc == nil || r.owns(&p) {
+			continue
+		}
+		for _, c := range p.Spec.Containers {
+			nc.milliCPU -= request(c, corev1.ResourceCPU).MilliValue()
+			nc.memory -= request(c, corev1.ResourceMemory).Value()
+		}
+	}
+	return out
+}
+
+// request returns the request of container c for resource res, or its limit if c has no request.
+func request(c corev1.Container, res corev1.ResourceName) *resource.Quantity {
+	if q, ok := c.Resources.Requests[res]; ok {
+		return &q
+	}
+	q := c.Resources.Limits[res]
+	return &q
+}
+
+// owns reports whether pod belongs to one of the workloads of r, judging by its owner, which for a Deployment is a
+// ReplicaSet named after it.
+func (r *Report) owns(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		for _, w := range r.Workloads {
+			if w.Namespace != pod.Namespace {
+				continue
+			}
+			switch {
+			case ref.Kind == "ReplicaSet" && w.Kind == "Deployment" && strings.HasPrefix(ref.Name, w.Name+"-"):
+				return true
+			case ref.Kind == w.Kind && ref.Name == w.Name:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// capacityWarnings returns the warnings for the workloads of r whose pods do not fit the free capacity of nodes. A
+// pod of a workload with replicas must fit on at least one node, all of them at their minimum replicas together must
+// fit the total free capacity, and a DaemonSet pod must fit on every node.
+func capacityWarnings(r *Report, nodes []*nodeCapacity) []string {
+	if len(nodes) == 0 {
+		return []string{"there are no schedulable nodes, all pods will stay Pending"}
+	}
+	var warnings []string
+	var freeCPU, freeMemory int64
+	for _, n := range nodes {
+		freeCPU += n.milliCPU
+		freeMemory += n.memory
+	}
+	for _, w := range r.Workloads {
+		var fits []string
+		for _, n := range nodes {
+			if w.MilliCPU <= n.milliCPU && w.Memory <= n.memory {
+				fits = append(fits, n.name)
+			}
+		}
+		switch {
+		case w.PerNode() && len(fits) != len(nodes):
+			warnings = append(warnings, fmt.Sprintf("%s/%s/%s requests %s CPU and %s memory per pod, which fits the free "+
+				"capacity of only %d of %d nodes, its pods on the other nodes will stay Pending", w.Kind, w.Namespace, w.Name,
+				cpuString(w.MilliCPU), memoryString(w.Memory), len(fits), len(nodes)))
+		case !w.PerNode() && len(fits) == 0:
+			warnings = append(warnings, fmt.Sprintf("%s/%s/%s requests %s CPU and %s memory per pod, more than is free on "+
+				"any node, its pods will stay Pending", w.Kind, w.Namespace, w.Name, cpuString(w.MilliCPU), memoryString(w.Memory)))
+		}
+		if w.PerNode() {
+			// DaemonSet pods are scheduled on every node, so take them off the capacity left for the other workloads.
+			freeCPU -= w.MilliCPU * int64(len(fits))
+			freeMemory -= w.Memory * int64(len(fits))
+		}
+	}
+	t := r.Totals()
+	if t.MinMilliCPU > freeCPU || t.MinMemory > freeMemory {
+		warnings = append(warnings, fmt.Sprintf("the workloads request %s CPU and %s memory at their minimum replicas, more "+
+			"than the %s CPU and %s memory free on %d schedulable nodes, some pods will stay Pending unless the cluster "+
+			"scales up", cpuString(t.MinMilliCPU), memoryString(t.MinMemory), cpuString(freeCPU), memoryString(freeMemory),
+			len(nodes)))
+	}
+	return warnings
+}
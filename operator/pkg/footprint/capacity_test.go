@@ -0,0 +1,122 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprint
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func node(name, cpu, memory string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+}
+
+func pod(name, nodeName, owner, cpu, memory string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "istio-system",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{{
+				Name: "c",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+	}
+}
+
+func TestCheckCapacity(t *testing.T) {
+	istiod := &Workload{Kind: "Deployment", Namespace: "istio-system", Name: "istiod", MilliCPU: 500, Memory: 2 << 30,
+		MinReplicas: 2, MaxReplicas: 5}
+	cni := &Workload{Kind: "DaemonSet", Namespace: "istio-system", Name: "istio-cni-node", MilliCPU: 100, Memory: 64 << 20,
+		MinReplicas: 1, MaxReplicas: 1}
+	tests := []struct {
+		desc      string
+		workloads []*Workload
+		objects   []runtime.Object
+		want      []string
+	}{
+		{
+			desc:      "fits, replacing the running istiod",
+			workloads: []*Workload{istiod, cni},
+			objects: []runtime.Object{
+				node("a", "2", "4Gi"),
+				node("b", "2", "4Gi"),
+				pod("istiod-5f4d-x", "a", "istiod-5f4d", "500m", "2Gi"),
+				pod("app-1", "b", "app-7c9b", "1", "1Gi"),
+			},
+		},
+		{
+			desc:      "pod too large for any node",
+			workloads: []*Workload{istiod},
+			objects: []runtime.Object{
+				node("a", "2", "4Gi"),
+				pod("app-1", "a", "app-7c9b", "1", "3Gi"),
+			},
+			want: []string{
+				"Deployment/istio-system/istiod requests 500m CPU and 2Gi memory per pod, more than is free on any node, " +
+					"its pods will stay Pending",
+				"the workloads request 1 CPU and 4Gi memory at their minimum replicas, more than the 1 CPU and 1Gi memory " +
+					"free on 1 schedulable nodes, some pods will stay Pending unless the cluster scales up",
+			},
+		},
+		{
+			desc:      "DaemonSet does not fit every node",
+			workloads: []*Workload{cni},
+			objects: []runtime.Object{
+				node("a", "2", "4Gi"),
+				node("b", "50m", "4Gi"),
+			},
+			want: []string{
+				"DaemonSet/istio-system/istio-cni-node requests 100m CPU and 64Mi memory per pod, which fits the free " +
+					"capacity of only 1 of 2 nodes, its pods on the other nodes will stay Pending",
+			},
+		},
+		{
+			desc:      "no nodes",
+			workloads: []*Workload{istiod},
+			want:      []string{"there are no schedulable nodes, all pods will stay Pending"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := CheckCapacity(fake.NewSimpleClientset(tt.objects...), &Report{Workloads: tt.workloads})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package footprint sums the CPU and memory requested by the workloads of a manifest, within the replica bounds of
+// their autoscalers, for capacity planning before an install.
+package footprint
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	hpaKind       = "HorizontalPodAutoscaler"
+	daemonSetKind = "DaemonSet"
+)
+
+// workloadKinds are the kinds of the long running workloads which are included in a report.
+var workloadKinds = map[string]bool{"Deployment": true, "StatefulSet": true, daemonSetKind: true}
+
+// Workload is the footprint of a workload in a manifest.
+type Workload struct {
+	Component name.ComponentName
+	Kind      string
+	Namespace string
+	Name      string
+	// MilliCPU and Memory, in bytes, are requested by each pod of the workload.
+	MilliCPU int64
+	Memory   int64
+	// MinReplicas and MaxReplicas are the replicas of the workload, or the bounds of its HorizontalPodAutoscaler.
+	MinReplicas int64
+	MaxReplicas int64
+}
+
+// PerNode reports whether the workload runs a pod on every node rather than a number of replicas.
+func (w *Workload) PerNode() bool {
+	return w.Kind == daemonSetKind
+}
+
+// Report is the footprint of the workloads in a manifest.
+type Report struct {
+	Workloads []*Workload
+}
+
+// Totals is the CPU and memory requested by the workloads of a report.
+type Totals struct {
+	// MinMilliCPU, MaxMilliCPU, MinMemory and MaxMemory are requested by the workloads with replicas, at the lower
+	// and upper replica bounds.
+	MinMilliCPU int64
+	MaxMilliCPU int64
+	MinMemory   int64
+	MaxMemory   int64
+	// PerNodeMilliCPU and PerNodeMemory are requested on every node by the DaemonSets.
+	PerNodeMilliCPU int64
+	PerNodeMemory   int64
+}
+
+// New returns the footprint of the Deployments, StatefulSets and DaemonSets in manifests. The sidecars injected into
+// the pods of these workloads at admission are not part of the manifests and so not counted.
+func New(manifests name.ManifestMap) (*Report, error) {
+	r := &Report{}
+	for _, c := range manifests.SortedComponentNames() {
+		var objs object.K8sObjects
+		for _, m := range manifests[c] {
+			mobjs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, mobjs...)
+		}
+		hpas := make(map[string]*unstructured.Unstructured)
+		for _, o := range objs {
+			if o.Kind == hpaKind {
+				u := o.UnstructuredObject()
+				kind, _, _ := unstructured.NestedString(u.Object, "spec", "scaleTargetRef", "kind")
+				target, _, _ := unstructured.NestedString(u.Object, "spec", "scaleTargetRef", "name")
+				hpas[hpaKey(kind, o.Namespace, target)] = u
+			}
+		}
+		for _, o := range objs {
+			if !workloadKinds[o.Kind] {
+				continue
+			}
+			w, err := newWorkload(c, o, hpas[hpaKey(o.Kind, o.Namespace, o.Name)])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+			r.Workloads = append(r.Workloads, w)
+		}
+	}
+	return r, nil
+}
+
+// hpaKey identifies the scale target of a HorizontalPodAutoscaler.
+func hpaKey(kind, namespace, name string) string {
+	return kind + ":" + namespace + ":" + name
+}
+
+// newWorkload returns the footprint of the workload o of component c, which is scaled by hpa if it is not nil.
+func newWorkload(c name.ComponentName, o *object.K8sObject, hpa *unstructured.Unstructured) (*Workload, error) {
+	u := o.UnstructuredObject()
+	w := &Workload{Component: c, Kind: o.Kind, Namespace: o.Namespace, Name: o.Name}
+	podSpec, _, err := unstructured.NestedMap(u.Object, "spec", "template", "spec")
+	if err != nil {
+		return nil, err
+	}
+	if w.MilliCPU, w.Memory, err = podRequests(podSpec); err != nil {
+		return nil, err
+	}
+
+	if w.PerNode() {
+		w.MinReplicas, w.MaxReplicas = 1, 1
+		return w, nil
+	}
+	replicas, found, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		replicas = 1
+	}
+	w.MinReplicas, w.MaxReplicas = replicas, replicas
+	if hpa != nil {
+		min, found, err := unstructured.NestedInt64(hpa.Object, "spec", "minReplicas")
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			min = 1
+		}
+		max, _, err := unstructured.NestedInt64(hpa.Object, "spec", "maxReplicas")
+		if err != nil {
+			return nil, err
+		}
+		w.MinReplicas, w.MaxReplicas = min, max
+	}
+	return w, nil
+}
+
+// podRequests returns the CPU and memory requested by a pod with podSpec, which is the larger of the sum of the
+// requests of its containers and the requests of any of its init containers, as for scheduling. A container which
+// only sets limits requests its limits.
+func podRequests(podSpec map[string]interface{}) (milliCPU, memory int64, err error) {
+	containers, _, err := unstructured.NestedSlice(podSpec, "containers")
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, c := range containers {
+		cpu, mem, err := containerRequests(c)
+		if err != nil {
+			return 0, 0, err
+		}
+		milliCPU += cpu
+		memory += mem
+	}
+	initContainers, _, err := unstructured.NestedSlice(podSpec, "initContainers")
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, c := range initContainers {
+		cpu, mem, err := containerRequests(c)
+		if err != nil {
+			return 0, 0, err
+		}
+		if cpu > milliCPU {
+			milliCPU = cpu
+		}
+		if mem > memory {
+			memory = mem
+		}
+	}
+	return milliCPU, memory, nil
+}
+
+// containerRequests returns the CPU and memory requested by container c.
+func containerRequests(c interface{}) (milliCPU, memory int64, err error) {
+	cm, ok := c.(map[string]interface{})
+	if !ok {
+		return 0, 0, nil
+	}
+	cpu, err := quantity(cm, "cpu")
+	if err != nil {
+		return 0, 0, err
+	}
+	mem, err := quantity(cm, "memory")
+	if err != nil {
+		return 0, 0, err
+	}
+	return cpu.MilliValue(), mem.Value(), nil
+}
+
+// quantity returns the request for resource res of container c, or its limit if c has no request.
+func quantity(c map[string]interface{}, res string) (resource.Quantity, error) {
+	for _, field := range []string{"requests", "limits"} {
+		v, found, _ := unstructured.NestedFieldNoCopy(c, "resources", field, res)
+		if !found || v == nil {
+			continue
+		}
+		q, err := resource.ParseQuantity(fmt.Sprint(v))
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf("container %v resources.%s.%s: %s", c["name"], field, res, err)
+		}
+		return q, nil
+	}
+	return resource.Quantity{}, nil
+}
+
+// Totals returns the CPU and memory requested by all the workloads of r.
+func (r *Report) Totals() *Totals {
+	t := &Totals{}
+	for _, w := range r.Workloads {
+		if w.PerNode() {
+			t.PerNodeMilliCPU += w.MilliCPU
+			t.PerNodeMemory += w.Memory
+			continue
+		}
+		t.MinMilliCPU += w.MilliCPU * w.MinReplicas
+		t.MaxMilliCPU += w.MilliCPU * w.MaxReplicas
+		t.MinMemory += w.Memory * w.MinReplicas
+		t.MaxMemory += w.Memory * w.MaxReplicas
+	}
+	return t
+}
+
+// Write writes r as a table of the workloads and their totals to out.
+func (r *Report) Write(out io.Writer) error {
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMPONENT\tWORKLOAD\tREPLICAS\tCPU/POD\tMEMORY/POD\tCPU\tMEMORY")
+	for _, w := range r.Workloads {
+		if w.PerNode() {
+			fmt.Fprintf(tw, "%s\t%s/%s/%s\tper node\t%s\t%s\t%s/node\t%s/node\n", w.Component, w.Kind, w.Namespace, w.Name,
+				cpuString(w.MilliCPU), memoryString(w.Memory), cpuString(w.MilliCPU), memoryString(w.Memory))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s/%s/%s\t%s\t%s\t%s\t%s\t%s\n", w.Component, w.Kind, w.Namespace, w.Name,
+			rangeString(fmt.Sprint(w.MinReplicas), fmt.Sprint(w.MaxReplicas)), cpuString(w.MilliCPU), memoryString(w.Memory),
+			rangeString(cpuString(w.MilliCPU*w.MinReplicas), cpuString(w.MilliCPU*w.MaxReplicas)),
+			rangeString(memoryString(w.Memory*w.MinReplicas), memoryString(w.Memory*w.MaxReplicas)))
+	}
+	t := r.Totals()
+	fmt.Fprintf(tw, "TOTAL\t\t\t\t\t%s\t%s\n", rangeString(cpuString(t.MinMilliCPU), cpuString(t.MaxMilliCPU)),
+		rangeString(memoryString(t.MinMemory), memoryString(t.MaxMemory)))
+	if t.PerNodeMilliCPU != 0 || t.PerNodeMemory != 0 {
+		fmt.Fprintf(tw, "TOTAL PER NODE\t\t\t\t\t%s\t%s\n", cpuString(t.PerNodeMilliCPU), memoryString(t.PerNodeMemory))
+	}
+	return tw.Flush()
+}
+
+// rangeString returns min-max, or min if they are the same.
+func rangeString(min, max string) string {
+	if min == max {
+		return min
+	}
+	return min + "-" + max
+}
+
+func cpuString(milliCPU int64) string {
+	return resource.NewMilliQuantity(milliCPU, resource.DecimalSI).String()
+}
+
+func memoryString(memory int64) string {
+	return resource.NewQuantity(memory, resource.BinarySI).String()
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprint
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+const testManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        resources:
+          requests:
+            memory: 1Gi
+      containers:
+      - name: discovery
+        resources:
+          requests:
+            cpu: 500m
+            memory: 256Mi
+      - name: helper
+        resources:
+          limits:
+            cpu: 100m
+            memory: 128Mi
+---
+apiVersion: autoscaling/v2beta1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  minReplicas: 2
+  maxReplicas: 5
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: istiod
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: istio-cni-node
+  namespace: kube-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: install-cni
+        resources:
+          requests:
+            cpu: 100m
+            memory: 64Mi
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+`
+
+func TestNew(t *testing.T) {
+	r, err := New(name.ManifestMap{name.PilotComponentName: {testManifest}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantWorkloads := []*Workload{
+		{
+			Component: name.PilotComponentName, Kind: "Deployment", Namespace: "istio-system", Name: "istiod",
+			MilliCPU: 600, Memory: 1 << 30, MinReplicas: 2, MaxReplicas: 5,
+		},
+		{
+			Component: name.PilotComponentName, Kind: "DaemonSet", Namespace: "kube-system", Name: "istio-cni-node",
+			MilliCPU: 100, Memory: 64 << 20, MinReplicas: 1, MaxReplicas: 1,
+		},
+	}
+	if !reflect.DeepEqual(r.Workloads, wantWorkloads) {
+		t.Errorf("got workloads %v, want %v", r.Workloads, wantWorkloads)
+	}
+	wantTotals := &Totals{
+		MinMilliCPU:     1200,
+		MaxMilliCPU:     3000,
+		MinMemory:       2 << 30,
+		MaxMemory:       5 << 30,
+		PerNodeMilliCPU: 100,
+		PerNodeMemory:   64 << 20,
+	}
+	if got := r.Totals(); !reflect.DeepEqual(got, wantTotals) {
+		t.Errorf("got totals %+v, want %+v", got, wantTotals)
+	}
+}
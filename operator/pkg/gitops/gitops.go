@@ -0,0 +1,182 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitops orders generated manifests into the stages GitOps controllers like ArgoCD and Flux must apply them
+// in: CRDs first, then the control plane, then the webhooks served by it and finally the gateways.
+package gitops
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// ArgoCD orders objects with ArgoCD sync-wave annotations.
+	ArgoCD = "argocd"
+	// Flux orders objects with a Flux Kustomization per stage, each depending on the previous stage.
+	Flux = "flux"
+
+	// SyncWaveAnnotation is the ArgoCD annotation with the sync wave of an object.
+	SyncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+
+	// FluxKustomizationsFilename is the name of the file holding the Flux Kustomizations of all stages.
+	FluxKustomizationsFilename = "kustomizations.yaml"
+
+	fluxKustomizationAPIVersion = "kustomize.toolkit.fluxcd.io/v1beta2"
+	fluxNamespace               = "flux-system"
+	fluxSource                  = "flux-system"
+	fluxInterval                = "10m"
+)
+
+var (
+	// Stages are the names of the stages objects are applied in, in order.
+	Stages = []string{"crds", "control-plane", "webhooks", "gateways"}
+
+	// Modes are the valid GitOps modes.
+	Modes = map[string]bool{ArgoCD: true, Flux: true}
+)
+
+const (
+	crdsStage = iota
+	controlPlaneStage
+	webhooksStage
+	gatewaysStage
+)
+
+// fluxKustomization is a Flux Kustomization, with only the fields set by this package.
+type fluxKustomization struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Metadata   map[string]string     `json:"metadata"`
+	Spec       fluxKustomizationSpec `json:"spec"`
+}
+
+type fluxKustomizationSpec struct {
+	Interval  string              `json:"interval"`
+	Path      string              `json:"path"`
+	Prune     bool                `json:"prune"`
+	Wait      bool                `json:"wait"`
+	SourceRef map[string]string   `json:"sourceRef"`
+	DependsOn []map[string]string `json:"dependsOn,omitempty"`
+}
+
+// Stage returns the index in Stages of the stage the object o of component cn is applied in.
+func Stage(cn name.ComponentName, o *object.K8sObject) int {
+	switch {
+	case o.Kind == "CustomResourceDefinition" || o.Kind == "Namespace":
+		return crdsStage
+	case o.Kind == "MutatingWebhookConfiguration" || o.Kind == "ValidatingWebhookConfiguration":
+		return webhooksStage
+	case cn.IsGateway():
+		return gatewaysStage
+	}
+	return controlPlaneStage
+}
+
+// Split returns the objects in manifests grouped by stage, indexed like Stages. Objects are ordered by component
+// name within a stage.
+func Split(manifests name.ManifestMap) ([]object.K8sObjects, error) {
+	out := make([]object.K8sObjects, len(Stages))
+	for _, cn := range manifests.SortedComponentNames() {
+		for _, m := range manifests[cn] {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range objs {
+				s := Stage(cn, o)
+				out[s] = append(out[s], o)
+			}
+		}
+	}
+	return out, nil
+}
+
+// AnnotateSyncWaves returns manifests with the ArgoCD sync-wave annotation set on every object to the index of its
+// stage.
+func AnnotateSyncWaves(manifests name.ManifestMap) (name.ManifestMap, error) {
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			var annotated object.K8sObjects
+			for _, o := range objs {
+				u := o.UnstructuredObject().DeepCopy()
+				annotations := u.GetAnnotations()
+				if annotations == nil {
+					annotations = make(map[string]string)
+				}
+				annotations[SyncWaveAnnotation] = strconv.Itoa(Stage(cn, o))
+				u.SetAnnotations(annotations)
+				annotated = append(annotated, object.NewK8sObject(u, nil, nil))
+			}
+			ym, err := annotated.YAMLManifest()
+			if err != nil {
+				return nil, err
+			}
+			out[cn] = append(out[cn], strings.TrimSuffix(ym, object.YAMLSeparator))
+		}
+	}
+	return out, nil
+}
+
+// StageDir returns the name of the directory the objects of stage s are written to.
+func StageDir(s int) string {
+	return fmt.Sprintf("%d-%s", s, Stages[s])
+}
+
+// FluxKustomizations returns a manifest of Flux Kustomizations, one for each non empty stage in stages, which apply
+// the stage directories under repoPath of the flux-system GitRepository and depend on the Kustomization of the
+// previous stage.
+func FluxKustomizations(stages []object.K8sObjects, repoPath string) (string, error) {
+	var out []string
+	prev := ""
+	for s, objs := range stages {
+		if len(objs) == 0 {
+			continue
+		}
+		k := &fluxKustomization{
+			APIVersion: fluxKustomizationAPIVersion,
+			Kind:       "Kustomization",
+			Metadata:   map[string]string{"name": "istio-" + Stages[s], "namespace": fluxNamespace},
+			Spec: fluxKustomizationSpec{
+				Interval:  fluxInterval,
+				Path:      "./" + path.Join(repoPath, StageDir(s)),
+				Prune:     true,
+				Wait:      true,
+				SourceRef: map[string]string{"kind": "GitRepository", "name": fluxSource},
+			},
+		}
+		if prev != "" {
+			k.Spec.DependsOn = []map[string]string{{"name": prev}}
+		}
+		prev = k.Metadata["name"]
+		b, err := yaml.Marshal(k)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, string(b))
+	}
+	return strings.Join(out, object.YAMLSeparator), nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+var manifests = name.ManifestMap{
+	name.IstioBaseComponentName: {`
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+`},
+	name.PilotComponentName: {`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: istio-sidecar-injector
+`},
+	name.IngressComponentName: {`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  annotations:
+    foo: bar
+`},
+}
+
+func TestSplit(t *testing.T) {
+	stages, err := Split(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]string
+	for _, objs := range stages {
+		var hashes []string
+		for _, o := range objs {
+			hashes = append(hashes, o.Hash())
+		}
+		got = append(got, hashes)
+	}
+	want := [][]string{
+		{"CustomResourceDefinition::gateways.networking.istio.io"},
+		{"Deployment:istio-system:istiod"},
+		{"MutatingWebhookConfiguration::istio-sidecar-injector"},
+		{"Deployment:istio-system:istio-ingressgateway"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAnnotateSyncWaves(t *testing.T) {
+	got, err := AnnotateSyncWaves(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"CustomResourceDefinition::gateways.networking.istio.io": "0",
+		"Deployment:istio-system:istiod":                         "1",
+		"MutatingWebhookConfiguration::istio-sidecar-injector":   "2",
+		"Deployment:istio-system:istio-ingressgateway":           "3",
+	}
+	for _, ms := range got {
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, o := range objs {
+				annotations := o.UnstructuredObject().GetAnnotations()
+				if annotations[SyncWaveAnnotation] != want[o.Hash()] {
+					t.Errorf("%s: got sync wave %q, want %q", o.Hash(), annotations[SyncWaveAnnotation], want[o.Hash()])
+				}
+				delete(want, o.Hash())
+				if o.Name == "istio-ingressgateway" && annotations["foo"] != "bar" {
+					t.Errorf("%s: existing annotations were not kept, got %v", o.Hash(), annotations)
+				}
+			}
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("objects missing from the annotated manifests: %v", want)
+	}
+}
+
+func TestFluxKustomizations(t *testing.T) {
+	stages, err := Split(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The control plane stage is skipped when empty, so the webhooks stage depends on the CRDs stage.
+	stages[controlPlaneStage] = nil
+	got, err := FluxKustomizations(stages, "clusters/prod/istio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		name, path, dependsOn string
+	}{
+		{"istio-crds", "./clusters/prod/istio/0-crds", ""},
+		{"istio-webhooks", "./clusters/prod/istio/2-webhooks", "istio-crds"},
+		{"istio-gateways", "./clusters/prod/istio/3-gateways", "istio-webhooks"},
+	}
+	if len(objs) != len(want) {
+		t.Fatalf("got %d Kustomizations, want %d:\n%s", len(objs), len(want), got)
+	}
+	for i, o := range objs {
+		spec := o.Unstructured()["spec"].(map[string]interface{})
+		dependsOn := ""
+		if d, ok := spec["dependsOn"].([]interface{}); ok {
+			dependsOn = d[0].(map[string]interface{})["name"].(string)
+		}
+		if o.Name != want[i].name || spec["path"] != want[i].path || dependsOn != want[i].dependsOn {
+			t.Errorf("got Kustomization %s with path %v depending on %q, want %v", o.Name, spec["path"], dependsOn, want[i])
+		}
+	}
+	if !strings.Contains(got, fluxKustomizationAPIVersion) {
+		t.Errorf("got Kustomizations without apiVersion %s:\n%s", fluxKustomizationAPIVersion, got)
+	}
+}
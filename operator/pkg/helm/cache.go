@@ -0,0 +1,126 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const (
+	// maxCachedCharts is the maximum number of charts in chartCache. The least recently used chart is evicted when
+	// another one is loaded, so that a long running process rendering from many installation packages does not hold on
+	// to all their charts.
+	maxCachedCharts = 64
+)
+
+var (
+	// chartCache holds charts which were already loaded, so that rendering the same charts repeatedly in one process
+	// does not read and parse the chart files each time. The cached charts are never handed out, only copies of them,
+	// since renderers may use their charts concurrently.
+	chartCache = make(map[string]*cachedChart)
+	// chartCacheClock is incremented on every use of chartCache, to order the charts by when they were last used.
+	chartCacheClock uint64
+	// chartCacheMu protects chartCache and chartCacheClock.
+	chartCacheMu sync.Mutex
+)
+
+// cachedChart is a loaded chart in chartCache.
+type cachedChart struct {
+	// fingerprint identifies the state of the chart files when the chart was loaded.
+	fingerprint string
+	chart       *chart.Chart
+	// lastUsed is the value of chartCacheClock when the chart was last loaded or read from the cache.
+	lastUsed uint64
+}
+
+// loadChartCached returns a copy of the chart cached under key if it was loaded when the chart files had the given
+// fingerprint. Otherwise it loads the chart with load and caches it, evicting the least recently used chart if the
+// cache is full.
+func loadChartCached(key, fingerprint string, load func() (*chart.Chart, error)) (*chart.Chart, error) {
+	chartCacheMu.Lock()
+	cc := chartCache[key]
+	if cc != nil && cc.fingerprint == fingerprint {
+		chartCacheClock++
+		cc.lastUsed = chartCacheClock
+		chartCacheMu.Unlock()
+		scope.Debugf("Using cached chart for %s", key)
+		return proto.Clone(cc.chart).(*chart.Chart), nil
+	}
+	chartCacheMu.Unlock()
+
+	chrt, err := load()
+	if err != nil {
+		return nil, err
+	}
+	chartCacheMu.Lock()
+	defer chartCacheMu.Unlock()
+	chartCacheClock++
+	chartCache[key] = &cachedChart{fingerprint: fingerprint, chart: chrt, lastUsed: chartCacheClock}
+	for len(chartCache) > maxCachedCharts {
+		evictLeastRecentlyUsedChart()
+	}
+	return proto.Clone(chrt).(*chart.Chart), nil
+}
+
+// evictLeastRecentlyUsedChart removes the chart which was used least recently from chartCache. chartCacheMu must be
+// held.
+func evictLeastRecentlyUsedChart() {
+	var oldest string
+	for key, cc := range chartCache {
+		if oldest == "" || cc.lastUsed < chartCache[oldest].lastUsed {
+			oldest = key
+		}
+	}
+	delete(chartCache, oldest)
+}
+
+// FlushChartCache removes all charts from the cache.
+func FlushChartCache() {
+	chartCacheMu.Lock()
+	defer chartCacheMu.Unlock()
+	chartCache = make(map[string]*cachedChart)
+}
+
+// filesFingerprint returns a fingerprint of the names, sizes and modification times of path and, if path is a
+// directory, all the files under it.
+func filesFingerprint(path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	// Walk does not follow a symlink at the root.
+	root, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	err = filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(h, "%s:%d:%d\n", p, info.Size(), info.ModTime().UnixNano())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum64()), nil
+}
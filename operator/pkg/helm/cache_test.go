@@ -0,0 +1,133 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestLoadChartCachedCopies(t *testing.T) {
+	defer FlushChartCache()
+	loads := 0
+	load := func() (*chart.Chart, error) {
+		loads++
+		return &chart.Chart{Metadata: &chart.Metadata{Name: "copied"}, Values: &chart.Config{Raw: "a: 1\n"}}, nil
+	}
+	first, err := loadChartCached("copied", "", load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Changes to a chart must not leak into the cache or other users of the chart.
+	first.Values.Raw = "a: 2\n"
+	second, err := loadChartCached("copied", "", load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := second.Values.Raw, "a: 1\n"; got != want {
+		t.Errorf("got cached values %q, want %q", got, want)
+	}
+	if loads != 1 {
+		t.Errorf("got %d loads, want 1", loads)
+	}
+}
+
+func TestLoadChartCachedEviction(t *testing.T) {
+	defer FlushChartCache()
+	FlushChartCache()
+	load := func(name string) func() (*chart.Chart, error) {
+		return func() (*chart.Chart, error) {
+			return &chart.Chart{Metadata: &chart.Metadata{Name: name}}, nil
+		}
+	}
+	for i := 0; i <= maxCachedCharts; i++ {
+		key := fmt.Sprintf("chart-%d", i)
+		if _, err := loadChartCached(key, "", load(key)); err != nil {
+			t.Fatal(err)
+		}
+		// chart-0 stays in use, so chart-1 is the least recently used chart when the cache overflows.
+		if _, err := loadChartCached("chart-0", "", load("chart-0")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := len(chartCache); got != maxCachedCharts {
+		t.Errorf("got %d cached charts, want %d", got, maxCachedCharts)
+	}
+	if chartCache["chart-0"] == nil {
+		t.Errorf("got chart-0 evicted, want it cached")
+	}
+	if chartCache["chart-1"] != nil {
+		t.Errorf("got chart-1 cached, want it evicted")
+	}
+}
+
+// TestFileTemplateRendererConcurrent renders the same cached chart from many renderers at once, each of which then
+// changes its own chart. Run with -race.
+func TestFileTemplateRendererConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chart-concurrent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer FlushChartCache()
+	if err := os.MkdirAll(filepath.Join(dir, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"Chart.yaml":          "name: concurrent\nversion: 1.0.0\n",
+		"values.yaml":         "name: default\n",
+		"templates/cm.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Values.name }}\n",
+		"templates/NOTES.txt": "notes\n",
+	}
+	for path, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const renderers = 16
+	got := make([]string, renderers)
+	errs := make([]error, renderers)
+	var wg sync.WaitGroup
+	for i := 0; i < renderers; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := NewFileTemplateRenderer(dir, "concurrent", "istio-system")
+			if errs[i] = h.Run(); errs[i] != nil {
+				return
+			}
+			got[i], errs[i] = h.RenderManifest(fmt.Sprintf("name: cm-%d\n", i))
+			h.chart.Values.Raw = "name: changed\n"
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < renderers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("renderer %d: %s", i, errs[i])
+		}
+		want := fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm-%d\n%s", i, YAMLSeparator)
+		if got[i] != want {
+			t.Errorf("renderer %d: got:\n%s\nwant:\n%s", i, got[i], want)
+		}
+	}
+}
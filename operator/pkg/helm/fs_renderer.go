@@ -60,11 +60,25 @@ func (h *FileTemplateRenderer) RenderManifest(values string) (string, error) {
 	return renderChart(h.namespace, values, h.chart)
 }
 
-// loadChart implements the TemplateRenderer interface.
+// ChartVersion returns the version of the loaded chart.
+func (h *FileTemplateRenderer) ChartVersion() string {
+	return h.chart.GetMetadata().GetVersion()
+}
+
+// ChartName returns the name of the loaded chart.
+func (h *FileTemplateRenderer) ChartName() string {
+	return h.chart.GetMetadata().GetName()
+}
+
+// loadChart implements the TemplateRenderer interface. The chart is reloaded only if the chart files have changed
+// since it was last loaded.
 func (h *FileTemplateRenderer) loadChart() error {
-	var err error
-	if h.chart, err = chartutil.Load(h.helmChartDirPath); err != nil {
+	fingerprint, err := filesFingerprint(h.helmChartDirPath)
+	if err != nil {
 		return err
 	}
-	return nil
+	h.chart, err = loadChartCached(h.helmChartDirPath, fingerprint, func() (*chart.Chart, error) {
+		return chartutil.Load(h.helmChartDirPath)
+	})
+	return err
 }
@@ -16,6 +16,10 @@ package helm
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -157,3 +161,47 @@ keywords:
 		})
 	}
 }
+
+func TestFileTemplateRendererChartCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chart-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer FlushChartCache()
+
+	writeChart := func(message string) {
+		if err := os.MkdirAll(filepath.Join(dir, "templates"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: cached\nversion: 1.0.0\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cm := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + message + "\n"
+		if err := ioutil.WriteFile(filepath.Join(dir, "templates", "cm.yaml"), []byte(cm), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func() string {
+		h := NewFileTemplateRenderer(dir, "cached", "istio-system")
+		if err := h.Run(); err != nil {
+			t.Fatal(err)
+		}
+		out, err := h.RenderManifest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	writeChart("first")
+	first := render()
+	if got := render(); got != first {
+		t.Errorf("second render from cache: got:\n%s\nwant:\n%s", got, first)
+	}
+	// The file size changes, so the change is detected even with coarse modification times.
+	writeChart("second-version")
+	if got := render(); !strings.Contains(got, "second-version") {
+		t.Errorf("render after chart change: got:\n%s\nwant updated chart", got)
+	}
+}
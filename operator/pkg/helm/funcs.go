@@ -0,0 +1,53 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"text/template"
+	"time"
+)
+
+var (
+	// renderTime is the time charts are rendered at, for .Release.Time and now. It is fixed so that the same inputs
+	// always render to the same output.
+	renderTime = time.Unix(0, 0).UTC()
+)
+
+// seededFuncs returns replacements for the template functions which return non-secret random values or the current
+// time. uuidv4 draws from a source seeded with the given inputs, so that rendering a chart with the same values always
+// produces the same output. The functions which generate random strings, like randAlphaNum, are left to draw from a
+// cryptographically secure source, since charts use them for secrets such as the Kiali signing key.
+func seededFuncs(inputs ...string) template.FuncMap {
+	h := sha256.New()
+	for _, in := range inputs {
+		// Separate inputs so that moving characters between them changes the seed.
+		_, _ = fmt.Fprintf(h, "%d:%s", len(in), in)
+	}
+	r := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(h.Sum(nil)))))
+	return template.FuncMap{
+		"uuidv4": func() string {
+			b := make([]byte, 16)
+			_, _ = r.Read(b)
+			b[6] = b[6]&0x0f | 0x40
+			b[8] = b[8]&0x3f | 0x80
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		},
+		"now": func() time.Time { return renderTime },
+	}
+}
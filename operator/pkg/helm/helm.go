@@ -59,6 +59,24 @@ type TemplateRenderer interface {
 	RenderManifest(values string) (string, error)
 }
 
+// ChartVersion returns the version of the chart r renders, or an empty string if r is not started or does not render a
+// helm chart, like an addon plugin.
+func ChartVersion(r TemplateRenderer) string {
+	if cv, ok := r.(interface{ ChartVersion() string }); ok {
+		return cv.ChartVersion()
+	}
+	return ""
+}
+
+// ChartName returns the name of the chart r renders, or an empty string if r is not started or does not render a helm
+// chart, like an addon plugin.
+func ChartName(r TemplateRenderer) string {
+	if cn, ok := r.(interface{ ChartName() string }); ok {
+		return cn.ChartName()
+	}
+	return ""
+}
+
 // NewHelmRenderer creates a new helm renderer with the given parameters and returns an interface to it.
 // The format of helmBaseDir and profile strings determines the type of helm renderer returned (compiled-in, file,
 // HTTP etc.)
@@ -101,12 +119,13 @@ func ReadProfileYAML(profile string) (string, error) {
 	return globalValues, nil
 }
 
-// renderChart renders the given chart with the given values and returns the resulting YAML manifest string.
+// renderChart renders the given chart with the given values and returns the resulting YAML manifest string. The output
+// only depends on the chart, namespace and values, unless the chart generates random strings, see seededFuncs.
 func renderChart(namespace, values string, chrt *chart.Chart) (string, error) {
 	config := &chart.Config{Raw: values, Values: map[string]*chart.Value{}}
 	options := chartutil.ReleaseOptions{
 		Name:      "istio",
-		Time:      timeconv.Now(),
+		Time:      timeconv.Timestamp(renderTime),
 		Namespace: namespace,
 	}
 
@@ -115,7 +134,11 @@ func renderChart(namespace, values string, chrt *chart.Chart) (string, error) {
 		return "", err
 	}
 
-	files, err := engine.New().Render(chrt, vals)
+	e := engine.New()
+	for name, f := range seededFuncs(chrt.GetMetadata().GetName(), namespace, values) {
+		e.FuncMap[name] = f
+	}
+	files, err := e.Render(chrt, vals)
 	if err != nil {
 		return "", err
 	}
@@ -203,6 +226,36 @@ func readFile(path string) (string, error) {
 
 // GetAddonNamesFromCharts scans the charts directory for addon-components
 func GetAddonNamesFromCharts(chartsRootDir string, capitalize bool) (addonChartNames []string, err error) {
+	metadatas, err := loadChartMetadatas(chartsRootDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, metadata := range metadatas {
+		if addonName := getAddonName(metadata); addonName != nil {
+			addonChartNames = append(addonChartNames, *addonName)
+		}
+	}
+	// sort for consistent results
+	sort.Strings(addonChartNames)
+	// check for duplicates
+	seen := make(map[string]bool)
+	for i, name := range addonChartNames {
+		if capitalize {
+			name = strings.ToUpper(name[:1]) + name[1:]
+			addonChartNames[i] = name
+		}
+		if seen[name] {
+			return nil, errors.New("Duplicate AddonComponent defined: " + name)
+		}
+		seen[name] = true
+	}
+	return addonChartNames, nil
+}
+
+// loadChartMetadatas returns the metadata of all charts under chartsRootDir, or the compiled in charts if
+// chartsRootDir is empty. Charts with unreadable metadata in the filesystem are skipped.
+func loadChartMetadatas(chartsRootDir string) ([]*chart.Metadata, error) {
+	var out []*chart.Metadata
 	if chartsRootDir == "" {
 		// VFS
 		fnames, err := vfs.GetFilesRecursive(ChartsSubdirName)
@@ -226,53 +279,37 @@ func GetAddonNamesFromCharts(chartsRootDir string, capitalize bool) (addonChartN
 				chart, err := chartutil.LoadFiles(bfs)
 				if err != nil {
 					return nil, err
-				} else if addonName := getAddonName(chart.Metadata); addonName != nil {
-					addonChartNames = append(addonChartNames, *addonName)
 				}
+				out = append(out, chart.Metadata)
 			}
 		}
-	} else {
-		// filesystem
-		var chartFilenames []string
-		err = filepath.Walk(chartsRootDir, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-			if info.IsDir() {
-				if ok, err := chartutil.IsChartDir(path); ok && err == nil {
-					chartFilenames = append(chartFilenames, filepath.Join(path, chartutil.ChartfileName))
-				}
-			}
-			return nil
-		})
+		return out, nil
+	}
+
+	// filesystem
+	var chartFilenames []string
+	err := filepath.Walk(chartsRootDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			return nil, err
+			return err
 		}
-		for _, filename := range chartFilenames {
-			metadata, err := chartutil.LoadChartfile(filename)
-			if err != nil {
-				continue
-			}
-			if addonName := getAddonName(metadata); addonName != nil {
-				addonChartNames = append(addonChartNames, *addonName)
+		if info.IsDir() {
+			if ok, err := chartutil.IsChartDir(path); ok && err == nil {
+				chartFilenames = append(chartFilenames, filepath.Join(path, chartutil.ChartfileName))
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	// sort for consistent results
-	sort.Strings(addonChartNames)
-	// check for duplicates
-	seen := make(map[string]bool)
-	for i, name := range addonChartNames {
-		if capitalize {
-			name = strings.ToUpper(name[:1]) + name[1:]
-			addonChartNames[i] = name
-		}
-		if seen[name] {
-			return nil, errors.New("Duplicate AddonComponent defined: " + name)
+	for _, filename := range chartFilenames {
+		metadata, err := chartutil.LoadChartfile(filename)
+		if err != nil {
+			continue
 		}
-		seen[name] = true
+		out = append(out, metadata)
 	}
-	return addonChartNames, nil
+	return out, nil
 }
 
 func getAddonName(metadata *chart.Metadata) *string {
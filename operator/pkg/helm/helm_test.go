@@ -16,7 +16,10 @@ package helm
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
 func TestGetAddonNamesFromCharts(t *testing.T) {
@@ -68,3 +71,63 @@ func TestGetAddonNamesFromCharts(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderChartDeterministic(t *testing.T) {
+	chrt := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "test"},
+		Templates: []*chart.Template{{
+			Name: "templates/configmap.yaml",
+			Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  namespace: {{ .Release.Namespace }}
+data:
+  key: {{ randAlphaNum 10 | quote }}
+  id: {{ uuidv4 | quote }}
+  time: {{ now | date "2006-01-02" | quote }}
+  release: {{ .Release.Time.Seconds | quote }}
+  value: {{ .Values.value | quote }}
+`),
+		}},
+	}
+	first, err := renderChart("istio-system", "value: a", chrt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		got, err := renderChart("istio-system", "value: a", chrt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withoutLine(got, "key") != withoutLine(first, "key") {
+			t.Fatalf("render %d differs, got:\n%s\nwant:\n%s", i, got, first)
+		}
+		// Random strings may be secrets, so they must not be derived from the values.
+		if fieldLine(got, "key") == fieldLine(first, "key") {
+			t.Errorf("render %d: got the same random string %s, want a new one", i, fieldLine(got, "key"))
+		}
+	}
+	other, err := renderChart("istio-system", "value: b", chrt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fieldLine(other, "id") == fieldLine(first, "id") {
+		t.Errorf("expected different values to seed different UUIDs, got %s for both", fieldLine(first, "id"))
+	}
+}
+
+// fieldLine returns the line of the given data field in the rendered ConfigMap.
+func fieldLine(manifest, field string) string {
+	for _, l := range strings.Split(manifest, "\n") {
+		if strings.HasPrefix(l, "  "+field+":") {
+			return l
+		}
+	}
+	return ""
+}
+
+// withoutLine returns manifest without the line of the given data field.
+func withoutLine(manifest, field string) string {
+	return strings.Replace(manifest, fieldLine(manifest, field)+"\n", "", 1)
+}
@@ -0,0 +1,232 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mholt/archiver"
+
+	"istio.io/istio/operator/pkg/vfs"
+)
+
+// installPackageSubdirs are the subdirectories of an install package which are exported to an offline package.
+var installPackageSubdirs = []string{ChartsSubdirName, profilesRoot}
+
+// IsInstallPackageArchive reports whether path is a local installation package tar, as created by
+// istioctl manifest package.
+func IsInstallPackageArchive(path string) bool {
+	if !strings.HasSuffix(path, ".tar.gz") && !strings.HasSuffix(path, ".tgz") {
+		return false
+	}
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir()
+}
+
+// ExportInstallPackage copies the charts and profiles from installPackagePath into destDir. If installPackagePath is
+// empty, the compiled in charts and profiles are exported.
+func ExportInstallPackage(installPackagePath, destDir string) error {
+	for _, subdir := range installPackageSubdirs {
+		if installPackagePath == "" {
+			if err := exportVFSDir(subdir, destDir); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyDir(filepath.Join(installPackagePath, subdir), filepath.Join(destDir, subdir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportChart copies the chart of a single component into destDir. chartPath is the local path of a third party
+// chart. If it is empty, the chart at helmSubdir in the charts directory of installPackagePath is exported, or the
+// compiled in chart if installPackagePath is also empty.
+func ExportChart(installPackagePath, helmSubdir, chartPath, destDir string) error {
+	switch {
+	case chartPath != "":
+		return copyDir(chartPath, destDir)
+	case installPackagePath != "":
+		return copyDir(filepath.Join(installPackagePath, ChartsSubdirName, helmSubdir), destDir)
+	}
+	dir := filepath.Join(ChartsSubdirName, helmSubdir)
+	fnames, err := vfs.GetFilesRecursive(dir)
+	if err != nil {
+		return err
+	}
+	for _, fname := range fnames {
+		b, err := vfs.ReadFile(fname)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, fname)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(destDir, rel), b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExtractInstallPackage extracts the installation package tar at archivePath into a directory under destDirRoot
+// named after the SHA-256 digest of the tar, and returns the path of the top level directory in the tar. A package
+// which was already extracted is reused, so repeated runs with the same package do not leave a new copy behind each
+// time. If destDirRoot is "", the default installation package directory is used.
+func ExtractInstallPackage(archivePath, destDirRoot string) (string, error) {
+	if destDirRoot == "" {
+		destDirRoot = filepath.Join(os.TempDir(), InstallationDirectory)
+	}
+	if err := os.MkdirAll(destDirRoot, os.ModePerm); err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(archivePath)
+	if err != nil {
+		return "", err
+	}
+	base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(archivePath), ".tgz"), ".tar.gz")
+	dir := filepath.Join(destDirRoot, fmt.Sprintf("%s-%x", base, sha256.Sum256(b)))
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		// Extract into a temporary directory first and rename it, so that an interrupted extraction is never reused.
+		tmpDir, err := ioutil.TempDir(destDirRoot, base)
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmpDir)
+		targz := archiver.TarGz{Tar: &archiver.Tar{OverwriteExisting: true}}
+		if err := targz.Unarchive(archivePath, tmpDir); err != nil {
+			return "", fmt.Errorf("could not extract install package %s: %s", archivePath, err)
+		}
+		if err := os.Rename(tmpDir, dir); err != nil {
+			// The package may have been extracted concurrently.
+			if _, serr := os.Stat(dir); serr != nil {
+				return "", err
+			}
+		}
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(fis) != 1 || !fis[0].IsDir() {
+		return "", fmt.Errorf("install package %s must contain a single top level directory", archivePath)
+	}
+	return filepath.Join(dir, fis[0].Name()), nil
+}
+
+// ChartsDigest returns the SHA-256 digest of the relative paths and contents of all the chart files in
+// installPackagePath, or of the compiled in charts if installPackagePath is empty. Two installs rendered from charts
+// with the same digest used identical charts.
+func ChartsDigest(installPackagePath string) (string, error) {
+	files := make(map[string][]byte)
+	if installPackagePath == "" {
+		fnames, err := vfs.GetFilesRecursive(ChartsSubdirName)
+		if err != nil {
+			return "", err
+		}
+		for _, fname := range fnames {
+			b, err := vfs.ReadFile(fname)
+			if err != nil {
+				return "", err
+			}
+			files[strings.TrimPrefix(fname, ChartsSubdirName+"/")] = b
+		}
+	} else {
+		root := filepath.Join(installPackagePath, ChartsSubdirName)
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.Mode().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(rel)] = b
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	var names []string
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, n := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00", n, len(files[n]))
+		h.Write(files[n])
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// exportVFSDir writes all the compiled in files under dir to the same relative paths under destDir.
+func exportVFSDir(dir, destDir string) error {
+	fnames, err := vfs.GetFilesRecursive(dir)
+	if err != nil {
+		return err
+	}
+	for _, fname := range fnames {
+		b, err := vfs.ReadFile(fname)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(destDir, fname), b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDir recursively copies all the regular files in srcDir to destDir.
+func copyDir(srcDir, destDir string) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(destDir, rel), b)
+	})
+}
+
+func writeFile(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mholt/archiver"
+)
+
+func TestInstallPackageRoundTrip(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "install-package")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	files := map[string]string{
+		"charts/base/Chart.yaml":          "name: base\nversion: 1.1.0\n",
+		"charts/base/templates/crds.yaml": "kind: CustomResourceDefinition\n",
+		"profiles/default.yaml":           "spec: {}\n",
+	}
+	srcDir := filepath.Join(tmp, "src")
+	for path, content := range files {
+		if err := writeFile(filepath.Join(srcDir, path), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Files outside charts and profiles are not exported.
+	if err := writeFile(filepath.Join(srcDir, "README.md"), []byte("readme")); err != nil {
+		t.Fatal(err)
+	}
+
+	pkgDir := filepath.Join(tmp, "pkg", "istio-1.6.0")
+	if err := ExportInstallPackage(srcDir, filepath.Join(pkgDir, OperatorSubdirFilePath)); err != nil {
+		t.Fatal(err)
+	}
+	archivePath := filepath.Join(tmp, "istio-1.6.0.tar.gz")
+	targz := archiver.TarGz{Tar: &archiver.Tar{}}
+	if err := targz.Archive([]string{pkgDir}, archivePath); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsInstallPackageArchive(archivePath) {
+		t.Fatalf("IsInstallPackageArchive(%s): got false, want true", archivePath)
+	}
+	if IsInstallPackageArchive(srcDir) {
+		t.Fatalf("IsInstallPackageArchive(%s): got true, want false", srcDir)
+	}
+
+	dir, err := ExtractInstallPackage(archivePath, filepath.Join(tmp, "extract"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := filepath.Base(dir), "istio-1.6.0"; got != want {
+		t.Errorf("got top level dir %s, want %s", got, want)
+	}
+	for path, want := range files {
+		got, err := ioutil.ReadFile(filepath.Join(dir, OperatorSubdirFilePath, path))
+		if err != nil {
+			t.Errorf("%s: %s", path, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s: got %q, want %q", path, got, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, OperatorSubdirFilePath, "README.md")); !os.IsNotExist(err) {
+		t.Errorf("README.md: got err %v, want not exist", err)
+	}
+
+	// Extracting the same package again reuses the extracted directory.
+	again, err := ExtractInstallPackage(archivePath, filepath.Join(tmp, "extract"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != dir {
+		t.Errorf("got dir %s on the second extraction, want %s", again, dir)
+	}
+	fis, err := ioutil.ReadDir(filepath.Join(tmp, "extract"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fis) != 1 {
+		t.Errorf("got %d extracted packages, want 1", len(fis))
+	}
+}
+
+func TestExportChart(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "export-chart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	srcDir := filepath.Join(tmp, "src")
+	for _, path := range []string{"charts/gateways/istio-ingress/Chart.yaml", "charts/gateways/istio-egress/Chart.yaml"} {
+		if err := writeFile(filepath.Join(srcDir, path), []byte("name: "+filepath.Base(filepath.Dir(path))+"\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	destDir := filepath.Join(tmp, "dest")
+	if err := ExportChart(srcDir, "gateways/istio-ingress", "", destDir); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(destDir, "Chart.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: istio-ingress\n"; string(got) != want {
+		t.Errorf("got Chart.yaml %q, want %q", got, want)
+	}
+	// Only the chart at helmSubdir is exported.
+	fis, err := ioutil.ReadDir(destDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fis) != 1 {
+		t.Errorf("got %d exported files, want 1", len(fis))
+	}
+}
+
+func TestChartsDigest(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "charts-digest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	srcDir := filepath.Join(tmp, "src")
+	for path, content := range map[string]string{
+		"charts/base/Chart.yaml":          "name: base\nversion: 1.1.0\n",
+		"charts/base/templates/crds.yaml": "kind: CustomResourceDefinition\n",
+		"profiles/default.yaml":           "spec: {}\n",
+	} {
+		if err := writeFile(filepath.Join(srcDir, path), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exportDir := filepath.Join(tmp, "export")
+	if err := ExportInstallPackage(srcDir, exportDir); err != nil {
+		t.Fatal(err)
+	}
+	src, err := ChartsDigest(srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := ChartsDigest(exportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src != exported {
+		t.Errorf("got digest %s for the exported charts, want the digest of the source charts %s", exported, src)
+	}
+
+	if err := writeFile(filepath.Join(exportDir, ChartsSubdirName, "base", "Chart.yaml"), []byte("name: base\n")); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := ChartsDigest(exportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed == src {
+		t.Errorf("got unchanged digest %s after changing a chart", changed)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/mholt/archiver"
 
 	"istio.io/istio/operator/pkg/httprequest"
+	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/version"
 )
 
@@ -117,6 +118,28 @@ func DownloadTo(srcURL, dest string) (string, error) {
 	return destFile, nil
 }
 
+// ResolveChartPath returns a local path to the chart at chartPath, which is either a chart directory or archive
+// in the local filesystem, or a URL to a chart archive. Chart archives at URLs are downloaded into the installation
+// package cache dir. Relative local paths are relative to installPackagePath, if set.
+func ResolveChartPath(installPackagePath, chartPath string) (string, error) {
+	isURL, err := util.IsHTTPURL(chartPath)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case isURL:
+		destDir := filepath.Join(os.TempDir(), InstallationDirectory, ChartsSubdirName)
+		if err := os.MkdirAll(destDir, os.ModePerm); err != nil {
+			return "", err
+		}
+		return DownloadTo(chartPath, destDir)
+	case filepath.IsAbs(chartPath) || installPackagePath == "":
+		return chartPath, nil
+	default:
+		return filepath.Join(installPackagePath, chartPath), nil
+	}
+}
+
 // URLToDirname, given an input URL pointing to an Istio release tar, returns the subdirectory name that the tar would
 // be extracted to and the version in the URL. The input URLs are expected to have the form
 // https://.../istio-{version}-{platform}[optional suffix].tar.gz.
@@ -92,6 +92,16 @@ func (h *VFSRenderer) RenderManifest(values string) (string, error) {
 	return renderChart(h.namespace, values, h.chart)
 }
 
+// ChartVersion returns the version of the loaded chart.
+func (h *VFSRenderer) ChartVersion() string {
+	return h.chart.GetMetadata().GetVersion()
+}
+
+// ChartName returns the name of the loaded chart.
+func (h *VFSRenderer) ChartName() string {
+	return h.chart.GetMetadata().GetName()
+}
+
 // LoadValuesVFS loads the compiled in file corresponding to the given profile name.
 func LoadValuesVFS(profileName string) (string, error) {
 	path := filepath.Join(profilesRoot, BuiltinProfileToFilename(profileName))
@@ -109,16 +119,24 @@ func IsBuiltinProfileName(name string) bool {
 
 // loadChart implements the TemplateRenderer interface.
 func (h *VFSRenderer) loadChart() error {
+	var err error
+	// Compiled in charts never change, so no fingerprint is needed.
+	h.chart, err = loadChartCached("vfs:"+h.helmChartDirPath, "", h.loadChartFiles)
+	return err
+}
+
+// loadChartFiles loads the chart for h from the compiled in files.
+func (h *VFSRenderer) loadChartFiles() (*chart.Chart, error) {
 	prefix := h.helmChartDirPath
 	fnames, err := vfs.GetFilesRecursive(prefix)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var bfs []*chartutil.BufferedFile
 	for _, fname := range fnames {
 		b, err := vfs.ReadFile(fname)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		// Helm expects unix / separator, but on windows this will be \
 		name := strings.ReplaceAll(stripPrefix(fname, prefix), string(filepath.Separator), "/")
@@ -130,8 +148,7 @@ func (h *VFSRenderer) loadChart() error {
 		scope.Debugf("Chart loaded: %s", bf.Name)
 	}
 
-	h.chart, err = chartutil.LoadFiles(bfs)
-	return err
+	return chartutil.LoadFiles(bfs)
 }
 
 func BuiltinProfileToFilename(name string) string {
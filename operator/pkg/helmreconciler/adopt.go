@@ -0,0 +1,214 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// helmReleaseNameAnnotation and helmReleaseNamespaceAnnotation are set by Helm 3 on the objects of a release.
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	// helmManagedByLabel is set to Helm by Helm 3 on the objects of a release.
+	helmManagedByLabel = "app.kubernetes.io/managed-by"
+	// helmReleaseSecretType is the type of the Secrets in which Helm 3 stores each revision of a release.
+	helmReleaseSecretType = "helm.sh/release.v1"
+)
+
+// clusterScopedKinds are the kinds of cluster scoped objects in Istio Helm releases.
+var clusterScopedKinds = map[string]bool{
+	"CustomResourceDefinition":       true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"MutatingWebhookConfiguration":   true,
+	"ValidatingWebhookConfiguration": true,
+	"MeshPolicy":                     true,
+	"Namespace":                      true,
+	"PodSecurityPolicy":              true,
+}
+
+// HelmRelease is a Helm 3 release whose objects are adopted by the operator.
+type HelmRelease struct {
+	// Name is the name of the release.
+	Name string
+	// Namespace is the namespace of the release.
+	Namespace string
+	// Version is the latest revision of the release.
+	Version int
+	// Objects are the objects in the manifest of the latest revision.
+	Objects object.K8sObjects
+	// records are the Secrets holding all revisions of the release.
+	records []corev1.Secret
+}
+
+// helmReleaseRecord is the part of the Helm 3 release record used for adoption.
+type helmReleaseRecord struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Manifest  string `json:"manifest"`
+}
+
+// ReadHelmRelease reads the Helm 3 release with the given name from the release Secrets in namespace. Releases
+// installed with Helm 2 must be converted with the helm-2to3 plugin first.
+func ReadHelmRelease(c client.Client, releaseName, namespace string) (*HelmRelease, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(context.TODO(), secrets, client.InNamespace(namespace),
+		client.MatchingLabels{"owner": "helm", "name": releaseName}); err != nil {
+		return nil, fmt.Errorf("could not list the records of Helm release %s/%s: %s", namespace, releaseName, err)
+	}
+	hr := &HelmRelease{Name: releaseName, Namespace: namespace}
+	var latest *helmReleaseRecord
+	for _, s := range secrets.Items {
+		if s.Type != helmReleaseSecretType {
+			continue
+		}
+		hr.records = append(hr.records, s)
+		if v, _ := strconv.Atoi(s.Labels["version"]); latest != nil && v <= latest.Version {
+			continue
+		}
+		r, err := decodeHelmRelease(s.Data["release"])
+		if err != nil {
+			return nil, fmt.Errorf("could not decode Helm release record %s/%s: %s", s.Namespace, s.Name, err)
+		}
+		latest = r
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no Helm release %s found in namespace %s, Helm 2 releases must be converted with "+
+			"the helm-2to3 plugin before they can be adopted", releaseName, namespace)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(latest.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the manifest of Helm release %s/%s: %s", namespace, releaseName, err)
+	}
+	hr.Version, hr.Objects = latest.Version, objs
+	return hr, nil
+}
+
+// decodeHelmRelease decodes a Helm 3 release record, which is gzipped JSON encoded with base64.
+func decodeHelmRelease(data []byte) (*helmReleaseRecord, error) {
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	// Helm may store records uncompressed, which are detected by the missing gzip magic number.
+	if len(b) > 2 && b[0] == 0x1f && b[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		if b, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	rec := &helmReleaseRecord{}
+	if err := json.Unmarshal(b, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// AdoptHelmRelease labels the live objects of hr as owned by the operator and removes the Helm release metadata
+// from them. The following reconcile then updates the objects which are still rendered and prunes the rest. The release
+// records are kept until DeleteHelmReleaseRecords is called, so that Helm can still roll back if reconciling fails.
+func (h *HelmReconciler) AdoptHelmRelease(hr *HelmRelease) error {
+	var adopted int
+	for _, o := range hr.Objects {
+		obj := o.UnstructuredObject()
+		namespace := obj.GetNamespace()
+		if namespace == "" && !clusterScopedKinds[obj.GetKind()] {
+			namespace = hr.Namespace
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		err := h.client.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: obj.GetName()}, live)
+		switch {
+		case kerrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return fmt.Errorf("could not get %s of Helm release %s: %s", o.Hash(), hr.Name, err)
+		}
+		if !adoptObject(live, h.pruningDetails.GetOwnerLabels(), hr.Name) {
+			continue
+		}
+		if h.opts.DryRun {
+			h.opts.Log.LogAndPrintf("Not adopting %s because of dry run.", o.Hash())
+			continue
+		}
+		if err := h.client.Update(context.TODO(), live); err != nil {
+			return fmt.Errorf("could not adopt %s of Helm release %s: %s", o.Hash(), hr.Name, err)
+		}
+		adopted++
+	}
+	h.opts.Log.LogAndPrintf("Adopted %d objects of Helm release %s/%s revision %d.", adopted, hr.Namespace, hr.Name, hr.Version)
+	return nil
+}
+
+// adoptObject sets the operator owner labels on obj and removes the metadata of Helm release releaseName from it. It
+// returns false if obj belongs to a different release.
+func adoptObject(obj *unstructured.Unstructured, ownerLabels map[string]string, releaseName string) bool {
+	annotations := obj.GetAnnotations()
+	if r, ok := annotations[helmReleaseNameAnnotation]; ok && r != releaseName {
+		return false
+	}
+	delete(annotations, helmReleaseNameAnnotation)
+	delete(annotations, helmReleaseNamespaceAnnotation)
+	obj.SetAnnotations(annotations)
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if labels[helmManagedByLabel] == "Helm" {
+		delete(labels, helmManagedByLabel)
+	}
+	for k, v := range ownerLabels {
+		labels[k] = v
+	}
+	labels[operatorLabelStr] = operatorReconcileStr
+	obj.SetLabels(labels)
+	return true
+}
+
+// DeleteHelmReleaseRecords deletes the records of hr, after which Helm no longer lists the release.
+func (h *HelmReconciler) DeleteHelmReleaseRecords(hr *HelmRelease) error {
+	for i := range hr.records {
+		s := &hr.records[i]
+		if h.opts.DryRun {
+			h.opts.Log.LogAndPrintf("Not deleting Helm release record %s/%s because of dry run.", s.Namespace, s.Name)
+			continue
+		}
+		if err := h.client.Delete(context.TODO(), s); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete Helm release record %s/%s: %s", s.Namespace, s.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDecodeHelmRelease(t *testing.T) {
+	record := `{"name":"istio","namespace":"istio-system","version":3,"manifest":"apiVersion: v1\nkind: Service\n"}`
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	if _, err := w.Write([]byte(record)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := &helmReleaseRecord{Name: "istio", Namespace: "istio-system", Version: 3, Manifest: "apiVersion: v1\nkind: Service\n"}
+	for desc, data := range map[string][]byte{"gzipped": gz.Bytes(), "uncompressed": []byte(record)} {
+		t.Run(desc, func(t *testing.T) {
+			got, err := decodeHelmRelease([]byte(base64.StdEncoding.EncodeToString(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestAdoptObject(t *testing.T) {
+	ownerLabels := map[string]string{OwnerNameKey: "installed-state"}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetLabels(map[string]string{helmManagedByLabel: "Helm", "app": "istiod"})
+	obj.SetAnnotations(map[string]string{
+		helmReleaseNameAnnotation:      "istio",
+		helmReleaseNamespaceAnnotation: "istio-system",
+	})
+	if !adoptObject(obj, ownerLabels, "istio") {
+		t.Fatal("got not adopted, want adopted")
+	}
+	wantLabels := map[string]string{"app": "istiod", OwnerNameKey: "installed-state", operatorLabelStr: operatorReconcileStr}
+	if got := obj.GetLabels(); !reflect.DeepEqual(got, wantLabels) {
+		t.Errorf("got labels %v, want %v", got, wantLabels)
+	}
+	if got := obj.GetAnnotations(); len(got) != 0 {
+		t.Errorf("got annotations %v, want none", got)
+	}
+
+	other := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	other.SetAnnotations(map[string]string{helmReleaseNameAnnotation: "other"})
+	if adoptObject(other, ownerLabels, "istio") {
+		t.Error("got object of another release adopted, want not adopted")
+	}
+}
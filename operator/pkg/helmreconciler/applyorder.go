@@ -0,0 +1,50 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"fmt"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/object"
+)
+
+// ApplyOrderForIOP returns defaults with the override set through the apply-order annotation of iop applied, see
+// object.ApplyOrder.Override. The annotation is a comma separated list of kinds, for example
+// "apiextensions.k8s.io/CustomResourceDefinition,example.com/Widget,*" to apply the Widgets of a user added chart
+// right after the CRDs, which may define them, and everything else in the default order.
+func ApplyOrderForIOP(defaults object.ApplyOrder, iop *valuesv1alpha1.IstioOperator) (object.ApplyOrder, error) {
+	var a string
+	if iop != nil {
+		a = iop.GetAnnotations()[valuesv1alpha1.ApplyOrderAnnotation]
+	}
+	if a == "" {
+		return defaults, nil
+	}
+	override, err := object.ParseApplyOrder(a)
+	if err != nil {
+		return nil, fmt.Errorf("bad %s annotation: %s", valuesv1alpha1.ApplyOrderAnnotation, err)
+	}
+	return defaults.Override(override), nil
+}
+
+// applyOrder returns the apply order for the custom resource instance.
+func (h *HelmReconciler) applyOrder() (object.ApplyOrder, error) {
+	order := h.opts.ApplyOrder
+	if order == nil {
+		order = object.DefaultApplyOrderKinds
+	}
+	return ApplyOrderForIOP(order, h.iop)
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestApplyOrderForIOP(t *testing.T) {
+	defaults := object.ApplyOrder{"apiextensions.k8s.io/CustomResourceDefinition", object.AnyKind, "Service"}
+	tests := []struct {
+		desc       string
+		annotation string
+		want       object.ApplyOrder
+		wantErr    bool
+	}{
+		{
+			desc: "no annotation",
+			want: defaults,
+		},
+		{
+			desc:       "override",
+			annotation: "apiextensions.k8s.io/CustomResourceDefinition,example.com/Widget,*",
+			want:       object.ApplyOrder{"apiextensions.k8s.io/CustomResourceDefinition", "example.com/Widget", object.AnyKind, "Service"},
+		},
+		{
+			desc:       "bad annotation",
+			annotation: "example.com/",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			iop := &valuesv1alpha1.IstioOperator{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+			if tt.annotation != "" {
+				iop.SetAnnotations(map[string]string{valuesv1alpha1.ApplyOrderAnnotation: tt.annotation})
+			}
+			got, err := ApplyOrderForIOP(defaults, iop)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
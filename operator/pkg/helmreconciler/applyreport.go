@@ -0,0 +1,210 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+// lastApplyStatusField is the field under the IstioOperator status holding the ApplyReport of the last reconcile.
+// Like effectiveSpec, it is not part of the typed InstallStatus.
+const lastApplyStatusField = "lastApply"
+
+// ObjectAction is what a reconcile did to an object, named like the output of kubectl apply.
+type ObjectAction string
+
+const (
+	// ObjectCreated is the action on an object which did not exist.
+	ObjectCreated ObjectAction = "created"
+	// ObjectConfigured is the action on an object which was changed.
+	ObjectConfigured ObjectAction = "configured"
+	// ObjectUnchanged is the action on an object which already was as rendered.
+	ObjectUnchanged ObjectAction = "unchanged"
+	// ObjectSkipped is the action on an object which was left unchanged because of field conflicts, see ConflictsSkip.
+	ObjectSkipped ObjectAction = "skipped"
+	// ObjectPruned is the action on an object which was deleted because it is no longer rendered.
+	ObjectPruned ObjectAction = "pruned"
+)
+
+// AppliedObject is an object which a reconcile acted on.
+type AppliedObject struct {
+	// Component is the component the object belongs to.
+	Component string       `json:"component"`
+	Action    ObjectAction `json:"action"`
+	Group     string       `json:"group,omitempty"`
+	Kind      string       `json:"kind"`
+	Namespace string       `json:"namespace,omitempty"`
+	Name      string       `json:"name"`
+}
+
+// String implements fmt.Stringer, e.g. "deployment.apps/istiod configured" like kubectl apply.
+func (o AppliedObject) String() string {
+	kind := strings.ToLower(o.Kind)
+	if o.Group != "" {
+		kind += "." + o.Group
+	}
+	return fmt.Sprintf("%s/%s %s", kind, o.Name, o.Action)
+}
+
+// ComponentActions counts the actions on the objects of a component.
+type ComponentActions struct {
+	Component  string `json:"component"`
+	Created    int    `json:"created"`
+	Configured int    `json:"configured"`
+	Unchanged  int    `json:"unchanged"`
+	Skipped    int    `json:"skipped,omitempty"`
+	Pruned     int    `json:"pruned"`
+}
+
+// String implements fmt.Stringer.
+func (c ComponentActions) String() string {
+	s := fmt.Sprintf("%d created, %d configured, %d unchanged", c.Created, c.Configured, c.Unchanged)
+	if c.Skipped != 0 {
+		s += fmt.Sprintf(", %d skipped", c.Skipped)
+	}
+	return s + fmt.Sprintf(", %d pruned", c.Pruned)
+}
+
+// ApplyReport is what the last reconcile did to the objects of each component.
+type ApplyReport struct {
+	// Components are the counts of the actions of each component, sorted by component.
+	Components []ComponentActions `json:"components,omitempty"`
+	// Objects are the objects acted on, sorted by component, kind, namespace and name. In the IstioOperator status,
+	// only the first maxChangesInStatus objects are listed.
+	Objects []AppliedObject `json:"objects,omitempty"`
+	// Omitted is the number of objects not listed in Objects.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// recordAction records that the reconcile did action to obj of component, and logs it if Options.Verbose is set.
+// Pruned objects are not logged again, since Prune logs them. Objects which belong to no component, like the
+// installed-state CR, are not recorded. It is safe for concurrent use.
+func (h *HelmReconciler) recordAction(component string, obj *unstructured.Unstructured, action ObjectAction) {
+	if component == "" {
+		return
+	}
+	gvk := obj.GroupVersionKind()
+	ao := AppliedObject{
+		Component: component,
+		Action:    action,
+		Group:     gvk.Group,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+	h.appliedObjectsMu.Lock()
+	h.appliedObjects = append(h.appliedObjects, ao)
+	h.appliedObjectsMu.Unlock()
+	if h.opts.Verbose && action != ObjectPruned {
+		h.opts.Log.LogAndPrintf("  %s", ao)
+	}
+}
+
+// updateAction returns the action of an update which changed the resourceVersion of an object from before to after.
+// after is empty if nothing was written.
+func updateAction(before, after string) ObjectAction {
+	if after == "" || after == before {
+		return ObjectUnchanged
+	}
+	return ObjectConfigured
+}
+
+// ApplyReport returns what the last reconcile did to the objects of each component.
+func (h *HelmReconciler) ApplyReport() *ApplyReport {
+	h.appliedObjectsMu.Lock()
+	objects := append([]AppliedObject(nil), h.appliedObjects...)
+	h.appliedObjectsMu.Unlock()
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	r := &ApplyReport{Objects: objects}
+	for _, o := range objects {
+		if len(r.Components) == 0 || r.Components[len(r.Components)-1].Component != o.Component {
+			r.Components = append(r.Components, ComponentActions{Component: o.Component})
+		}
+		c := &r.Components[len(r.Components)-1]
+		switch o.Action {
+		case ObjectCreated:
+			c.Created++
+		case ObjectConfigured:
+			c.Configured++
+		case ObjectUnchanged:
+			c.Unchanged++
+		case ObjectSkipped:
+			c.Skipped++
+		case ObjectPruned:
+			c.Pruned++
+		}
+	}
+	return r
+}
+
+// applyReportStatus returns ApplyReport for status.lastApply, listing at most maxChangesInStatus objects to keep the CR
+// small.
+func (h *HelmReconciler) applyReportStatus() *ApplyReport {
+	r := h.ApplyReport()
+	if len(r.Objects) > maxChangesInStatus {
+		r.Omitted = len(r.Objects) - maxChangesInStatus
+		r.Objects = r.Objects[:maxChangesInStatus]
+	}
+	return r
+}
+
+// ReadApplyReport returns the ApplyReport recorded in the status of the IstioOperator CR with the given name and
+// namespace. It returns nil if the CR does not exist or has no report.
+func ReadApplyReport(cl client.Reader, name, namespace string) (*ApplyReport, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(valuesv1alpha1.IstioOperatorGVK)
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, u); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get IstioOperator %s/%s: %s", namespace, name, err)
+	}
+	v, ok, err := unstructured.NestedFieldNoCopy(u.Object, "status", lastApplyStatusField)
+	if err != nil || !ok {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	r := &ApplyReport{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("bad %s in IstioOperator %s/%s: %s", lastApplyStatusField, namespace, name, err)
+	}
+	return r, nil
+}
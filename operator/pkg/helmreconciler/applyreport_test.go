@@ -0,0 +1,79 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplyReport(t *testing.T) {
+	newObject := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+	h := &HelmReconciler{opts: &Options{}}
+	h.recordAction("Pilot", newObject("apps/v1", "Deployment", "istio-system", "istiod"), ObjectConfigured)
+	h.recordAction("Pilot", newObject("v1", "Service", "istio-system", "istiod"), ObjectUnchanged)
+	h.recordAction("IngressGateways", newObject("v1", "Service", "istio-system", "istio-ingressgateway"), ObjectCreated)
+	h.recordAction("Pilot", newObject("v1", "ConfigMap", "istio-system", "istio-old"), ObjectPruned)
+	h.recordAction("", newObject("install.istio.io/v1alpha1", "IstioOperator", "istio-system", "installed-state"), ObjectConfigured)
+
+	got := h.ApplyReport()
+	wantComponents := []ComponentActions{
+		{Component: "IngressGateways", Created: 1},
+		{Component: "Pilot", Configured: 1, Unchanged: 1, Pruned: 1},
+	}
+	if !reflect.DeepEqual(got.Components, wantComponents) {
+		t.Errorf("got components %+v, want %+v", got.Components, wantComponents)
+	}
+	var lines []string
+	for _, o := range got.Objects {
+		lines = append(lines, o.String())
+	}
+	wantLines := []string{
+		"service/istio-ingressgateway created",
+		"configmap/istio-old pruned",
+		"deployment.apps/istiod configured",
+		"service/istiod unchanged",
+	}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("got objects %v, want %v", lines, wantLines)
+	}
+	if got, want := wantComponents[1].String(), "0 created, 1 configured, 1 unchanged, 1 pruned"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUpdateAction(t *testing.T) {
+	for _, tt := range []struct {
+		before, after string
+		want          ObjectAction
+	}{
+		{"10", "11", ObjectConfigured},
+		{"10", "10", ObjectUnchanged},
+		{"10", "", ObjectUnchanged},
+	} {
+		if got := updateAction(tt.before, tt.after); got != tt.want {
+			t.Errorf("updateAction(%q, %q) got %s, want %s", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
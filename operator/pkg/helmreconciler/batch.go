@@ -0,0 +1,75 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"sync"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// defaultApplyBatchSize is how many objects of a component are applied concurrently, if Options.ApplyBatchSize
+	// is not set.
+	defaultApplyBatchSize = 20
+)
+
+// applyBatches splits objs, which are sorted as in order, into batches of at most size objects which are at the same
+// position in order, so that the objects of a batch can be applied concurrently while objects of kinds which are
+// applied earlier, like CRDs, are still applied before the objects which depend on them.
+func applyBatches(objs object.K8sObjects, order object.ApplyOrder, size int) []object.K8sObjects {
+	if size < 1 {
+		size = 1
+	}
+	var out []object.K8sObjects
+	var batch object.K8sObjects
+	for _, o := range objs {
+		if len(batch) == size || len(batch) != 0 && order.Score(batch[0]) != order.Score(o) {
+			out = append(out, batch)
+			batch = nil
+		}
+		batch = append(batch, o)
+	}
+	if len(batch) != 0 {
+		out = append(out, batch)
+	}
+	return out
+}
+
+// applyBatch applies the objects of batch of the given component concurrently and returns the error for each object,
+// nil if it was applied. Only the objects of one batch are in flight at a time, which bounds the concurrent API calls
+// and the memory they use regardless of the size of the manifest.
+func (h *HelmReconciler) applyBatch(component string, batch object.K8sObjects) []error {
+	errs := make([]error, len(batch))
+	var wg sync.WaitGroup
+	for i, o := range batch {
+		i, o := i, o
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = h.ProcessObject(component, o.UnstructuredObject())
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// applyBatchSize returns the number of objects which are applied concurrently.
+func (h *HelmReconciler) applyBatchSize() int {
+	if h.opts.ApplyBatchSize > 0 {
+		return h.opts.ApplyBatchSize
+	}
+	return defaultApplyBatchSize
+}
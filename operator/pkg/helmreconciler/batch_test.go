@@ -0,0 +1,105 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/helm/pkg/manifest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+func TestApplyBatches(t *testing.T) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(testManifest(2, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs.SortApplyOrder()
+	var got [][]string
+	for _, b := range applyBatches(objs, object.DefaultApplyOrderKinds, 3) {
+		var kinds []string
+		for _, o := range b {
+			kinds = append(kinds, o.Kind)
+		}
+		got = append(got, kinds)
+	}
+	want := [][]string{
+		{"ConfigMap", "ConfigMap"},
+		{"Service", "Service", "Service"},
+		{"Service", "Service"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got batches %v, want %v", got, want)
+	}
+}
+
+// testManifest returns a manifest with the given numbers of ConfigMaps and Services.
+func testManifest(configMaps, services int) string {
+	var docs []string
+	for i := 0; i < configMaps; i++ {
+		docs = append(docs, fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm-%d\n  namespace: istio-system\n"+
+			"data:\n  key: value\n", i))
+	}
+	for i := 0; i < services; i++ {
+		docs = append(docs, fmt.Sprintf("apiVersion: v1\nkind: Service\nmetadata:\n  name: svc-%d\n  namespace: istio-system\n"+
+			"spec:\n  ports:\n  - port: 80\n", i))
+	}
+	return strings.Join(docs, object.YAMLSeparator)
+}
+
+func benchmarkProcessManifest(b *testing.B, objects, batchSize int) {
+	m := manifest.Manifest{Name: "IngressGateways", Content: testManifest(objects/2, objects-objects/2)}
+	iop := &valuesv1alpha1.IstioOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: "bench", Namespace: "istio-system"},
+		Spec:       &v1alpha1.IstioOperatorSpec{},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		FlushObjectCaches()
+		h, err := NewHelmReconciler(fake.NewFakeClientWithScheme(scheme.Scheme), nil, iop,
+			&Options{Log: clog.NewConsoleLogger(false, ioutil.Discard, ioutil.Discard), ApplyBatchSize: batchSize})
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+		if _, err := h.ProcessManifest([]manifest.Manifest{m}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProcessManifest1000Serial(b *testing.B) {
+	benchmarkProcessManifest(b, 1000, 1)
+}
+
+func BenchmarkProcessManifest1000Batched(b *testing.B) {
+	benchmarkProcessManifest(b, 1000, defaultApplyBatchSize)
+}
+
+func BenchmarkProcessManifest5000Batched(b *testing.B) {
+	benchmarkProcessManifest(b, 5000, defaultApplyBatchSize)
+}
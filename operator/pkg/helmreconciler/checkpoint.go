@@ -0,0 +1,100 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/helm/pkg/manifest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+// checkpointsStatusField is the field under the IstioOperator status holding a map of component name to the checksum
+// of the manifests that were last installed successfully for that component. Like effectiveSpec, it is not part of
+// the typed InstallStatus.
+const checkpointsStatusField = "checkpoints"
+
+// SetStatusCheckpoints records a checkpoint on the IstioOperator instance for each component that is HEALTHY in
+// status. Checkpoints of components that are not HEALTHY are removed. Checkpoints of components which are not targets
+// are kept, since they were not applied.
+func (h *HelmReconciler) SetStatusCheckpoints(status *v1alpha1.InstallStatus) error {
+	if status == nil {
+		return nil
+	}
+	checkpoints := make(map[string]interface{})
+	for c, m := range toChartManifestsMap(h.manifests) {
+		if !h.isTarget(c) {
+			continue
+		}
+		// A null value deletes the key in a merge patch.
+		checkpoints[c] = nil
+		if vs := status.ComponentStatus[c]; vs != nil && vs.Status == v1alpha1.InstallStatus_HEALTHY {
+			checkpoints[c] = manifestsChecksum(m)
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{checkpointsStatusField: checkpoints},
+	})
+	if err != nil {
+		return err
+	}
+
+	iop := &valuesv1alpha1.IstioOperator{}
+	namespacedName := types.NamespacedName{
+		Name:      h.iop.Name,
+		Namespace: h.iop.Namespace,
+	}
+	if err := h.GetClient().Get(context.TODO(), namespacedName, iop); err != nil {
+		return fmt.Errorf("failed to get IstioOperator before updating checkpoints due to %v", err)
+	}
+	return h.GetClient().Status().Patch(context.TODO(), iop, client.RawPatch(types.MergePatchType, patch))
+}
+
+// ReadCheckpoints returns the checkpoints recorded by SetStatusCheckpoints on the IstioOperator CR with the given name
+// and namespace, for use as Options.Checkpoints. It returns nil if the CR does not exist.
+func ReadCheckpoints(cl client.Client, name, namespace string) (map[string]string, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(valuesv1alpha1.IstioOperatorGVK)
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, u); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get IstioOperator %s/%s: %s", namespace, name, err)
+	}
+	checkpoints, _, err := unstructured.NestedStringMap(u.Object, "status", checkpointsStatusField)
+	if err != nil {
+		return nil, fmt.Errorf("bad checkpoints in IstioOperator %s/%s: %s", namespace, name, err)
+	}
+	return checkpoints, nil
+}
+
+// manifestsChecksum returns a checksum over the contents of the given manifests.
+func manifestsChecksum(manifests []manifest.Manifest) string {
+	h := sha256.New()
+	for _, m := range manifests {
+		_, _ = h.Write([]byte(m.Content))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
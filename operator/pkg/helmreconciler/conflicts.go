@@ -0,0 +1,153 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util"
+)
+
+// ResourceConflict is a rendered cluster scoped resource which exists in the cluster, owned by the IstioOperator CR of
+// another control plane, with different content.
+type ResourceConflict struct {
+	// Component is the component the resource is rendered for.
+	Component name.ComponentName
+	// Resource is the object Hash() of the form Kind:Namespace:Name.
+	Resource string
+	// Owner is the name of the IstioOperator CR owning the live resource.
+	Owner string
+	// Field is the path of the first field whose rendered value differs from the live one.
+	Field string
+}
+
+// String implements fmt.Stringer.
+func (c ResourceConflict) String() string {
+	return fmt.Sprintf("%s of component %s is owned by IstioOperator %s and differs at %s", c.Resource, c.Component, c.Owner, c.Field)
+}
+
+// CheckConflicts renders the charts of h and returns an error listing the resources which applying them would take
+// over from another control plane: the istiod resources of another revision, see revisionConflicts, and the cluster
+// scoped resources, like ClusterRoles, webhook configurations and CRDs, owned by the CR of another revision with
+// different content, see ClusterResourceConflicts. Without the check, the last control plane applied silently
+// overwrites the shared resources of the others.
+func (h *HelmReconciler) CheckConflicts() error {
+	if _, err := h.RenderCharts(); err != nil {
+		return err
+	}
+	errs, err := revisionConflicts(h.client, h.manifests, h.iop.Spec.GetRevision())
+	if err != nil {
+		return err
+	}
+	conflicts, err := ClusterResourceConflicts(h.client, h.manifests, h.iop.Name)
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		errs = util.AppendErr(errs, fmt.Errorf("%s", c))
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("the manifests conflict with the installed control plane: %s", errs.ToError())
+	}
+	return nil
+}
+
+// ClusterResourceConflicts returns the cluster scoped resources in manifests which exist in the cluster, owned by an
+// IstioOperator CR other than owner, and whose live content differs from the rendered one, sorted by component and
+// resource. Resources which are not owned by any CR, or are protected, are not conflicts.
+func ClusterResourceConflicts(cl client.Client, manifests name.ManifestMap, owner string) ([]ResourceConflict, error) {
+	clusterKinds := make(map[string]bool)
+	for _, gvk := range append(nonNamespacedResources, crdResources...) {
+		clusterKinds[gvk.Kind] = true
+	}
+	var out []ResourceConflict
+	for c, ms := range manifests {
+		objs, err := object.ParseK8sObjectsFromYAMLManifest(strings.Join(ms, helm.YAMLSeparator))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest for component %s: %s", c, err)
+		}
+		for _, obj := range objs {
+			if !clusterKinds[obj.Kind] {
+				continue
+			}
+			live := &unstructured.Unstructured{}
+			live.SetGroupVersionKind(obj.GroupVersionKind())
+			if err := cl.Get(context.TODO(), types.NamespacedName{Name: obj.Name}, live); err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get %s: %s", obj.Hash(), err)
+			}
+			if rc, ok := resourceConflict(obj, live, owner); ok {
+				rc.Component = c
+				out = append(out, rc)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Component != out[j].Component {
+			return out[i].Component < out[j].Component
+		}
+		return out[i].Resource < out[j].Resource
+	})
+	return out, nil
+}
+
+// resourceConflict returns the conflict of rendered with live, and true if live is owned by a CR other than owner and
+// differs from rendered.
+func resourceConflict(rendered *object.K8sObject, live *unstructured.Unstructured, owner string) (ResourceConflict, bool) {
+	liveOwner := live.GetLabels()[OwnerNameKey]
+	if liveOwner == "" || liveOwner == owner || isProtected(live) {
+		return ResourceConflict{}, false
+	}
+	field, ok := driftedField(withoutPatchedFields(rendered), live.Object)
+	if !ok {
+		return ResourceConflict{}, false
+	}
+	return ResourceConflict{Resource: rendered.Hash(), Owner: liveOwner, Field: field}, true
+}
+
+// withoutPatchedFields returns the content of rendered without the fields istiod patches at runtime: the webhook
+// caBundles and the failurePolicy of the config validation webhooks, which fail open until istiod is ready.
+func withoutPatchedFields(rendered *object.K8sObject) map[string]interface{} {
+	u := rendered.UnstructuredObject().DeepCopy()
+	if !webhookKinds[u.GetKind()] {
+		return u.Object
+	}
+	webhooks, _, _ := unstructured.NestedSlice(u.Object, "webhooks")
+	for _, wh := range webhooks {
+		whm, ok := wh.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		unstructured.RemoveNestedField(whm, "clientConfig", "caBundle")
+		if u.GetKind() == "ValidatingWebhookConfiguration" {
+			unstructured.RemoveNestedField(whm, "failurePolicy")
+		}
+	}
+	_ = unstructured.SetNestedSlice(u.Object, webhooks, "webhooks")
+	return u.Object
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestResourceConflict(t *testing.T) {
+	rendered, err := object.ParseYAMLToK8sObject([]byte(`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istiod-istio-system
+rules:
+- apiGroups: ["networking.istio.io"]
+  resources: ["*"]
+  verbs: ["get", "watch", "list"]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := func(owner string, verbs ...interface{}) *unstructured.Unstructured {
+		u := rendered.UnstructuredObject().DeepCopy()
+		if owner != "" {
+			u.SetLabels(map[string]string{OwnerNameKey: owner})
+		}
+		rules, _, _ := unstructured.NestedSlice(u.Object, "rules")
+		rules[0].(map[string]interface{})["verbs"] = verbs
+		_ = unstructured.SetNestedSlice(u.Object, rules, "rules")
+		return u
+	}
+	tests := []struct {
+		desc      string
+		live      *unstructured.Unstructured
+		wantField string
+	}{
+		{
+			desc: "not owned",
+			live: live("", "get"),
+		},
+		{
+			desc: "owned by the same CR",
+			live: live("installed-state", "get"),
+		},
+		{
+			desc: "same content",
+			live: live("installed-state-canary", "get", "watch", "list"),
+		},
+		{
+			desc:      "different content",
+			live:      live("installed-state-canary", "get"),
+			wantField: "rules[0].verbs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, ok := resourceConflict(rendered, tt.live, "installed-state")
+			if ok != (tt.wantField != "") {
+				t.Fatalf("got conflict %v, want conflict: %v", ok, tt.wantField != "")
+			}
+			if got.Field != tt.wantField {
+				t.Errorf("got field %s, want %s", got.Field, tt.wantField)
+			}
+			if ok && got.Owner != "installed-state-canary" {
+				t.Errorf("got owner %s, want installed-state-canary", got.Owner)
+			}
+		})
+	}
+}
+
+func TestWithoutPatchedFields(t *testing.T) {
+	rendered, err := object.ParseYAMLToK8sObject([]byte(`
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: istiod-istio-system
+webhooks:
+- name: validation.istio.io
+  clientConfig:
+    caBundle: ""
+    service:
+      name: istiod
+  failurePolicy: Ignore
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := withoutPatchedFields(rendered)
+	webhooks, _, _ := unstructured.NestedSlice(got, "webhooks")
+	wh := webhooks[0].(map[string]interface{})
+	if _, found, _ := unstructured.NestedFieldNoCopy(wh, "clientConfig", "caBundle"); found {
+		t.Errorf("got caBundle, want it removed")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(wh, "failurePolicy"); found {
+		t.Errorf("got failurePolicy, want it removed")
+	}
+	if n, _, _ := unstructured.NestedString(wh, "clientConfig", "service", "name"); n != "istiod" {
+		t.Errorf("got service %q, want istiod", n)
+	}
+	webhooks, _, _ = unstructured.NestedSlice(rendered.Unstructured(), "webhooks")
+	if _, found, _ := unstructured.NestedFieldNoCopy(webhooks[0].(map[string]interface{}), "failurePolicy"); !found {
+		t.Errorf("the rendered object was modified")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+const (
+	// injectorWebhookName is the name of the injector MutatingWebhookConfiguration of the control plane installed
+	// without a revision, which injects the namespaces labeled istio-injection=enabled.
+	injectorWebhookName = "istio-sidecar-injector"
+	// injectionLabel is the namespace label selecting injection by the default revision.
+	injectionLabel = "istio-injection"
+)
+
+// DefaultRevisionChange is the change of the webhook configurations which makes a revision the default revision.
+type DefaultRevisionChange struct {
+	// Previous is the revision the default injector webhook pointed to, or empty if there was none.
+	Previous string
+	// Injector is the default injector webhook configuration pointing to the new default revision.
+	Injector *admissionv1beta1.MutatingWebhookConfiguration
+	// Validation is the config validation webhook configuration pointing to the new default revision, or nil if there
+	// is none.
+	Validation *admissionv1beta1.ValidatingWebhookConfiguration
+}
+
+// SetDefaultRevision makes revision the default revision of the control plane in istioNamespace. The default injector
+// webhook, which injects the namespaces labeled istio-injection=enabled, is replaced by a copy of the injector webhook
+// of revision with the namespace selector of the default one, and the config validation webhook is pointed at the
+// istiod of revision. Namespaces labeled istio.io/rev are not affected.
+//
+// The webhooks are updated with optimistic concurrency and, if the validation webhook cannot be updated, the injector
+// webhook is restored, so that either both or neither point to revision. The default injector webhook is labeled as a
+// resource of revision, but is not owned by its installed-state CR, so that reinstalling revision does not prune it
+// and removing revision removes it. If dryRun is set, nothing is changed.
+func SetDefaultRevision(cl client.Client, istioNamespace, revision string, dryRun bool) (*DefaultRevisionChange, error) {
+	if revision == "" || revision == defaultRevision {
+		return nil, fmt.Errorf("the control plane installed without a revision is made the default again by reinstalling it")
+	}
+	revInjector := &admissionv1beta1.MutatingWebhookConfiguration{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: injectorName(istioNamespace, revision)}, revInjector); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("no injector webhook of revision %s found in namespace %s, is the revision installed?",
+				revision, istioNamespace)
+		}
+		return nil, err
+	}
+	current := &admissionv1beta1.MutatingWebhookConfiguration{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: injectorName(istioNamespace, "")}, current); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+		current = nil
+	}
+	change := &DefaultRevisionChange{Injector: defaultInjector(revInjector, current, istioNamespace, revision)}
+	if current != nil {
+		change.Previous = revisionOf(current.Labels)
+	}
+	validation := &admissionv1beta1.ValidatingWebhookConfiguration{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: "istiod-" + istioNamespace}, validation); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		change.Validation = validationForRevision(validation, istioNamespace, revision)
+	}
+	if dryRun {
+		return change, nil
+	}
+
+	var err error
+	if current == nil {
+		err = cl.Create(context.TODO(), change.Injector)
+	} else {
+		err = cl.Update(context.TODO(), change.Injector)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the default injector webhook: %s", err)
+	}
+	if change.Validation == nil {
+		return change, nil
+	}
+	if err := cl.Update(context.TODO(), change.Validation); err != nil {
+		if rerr := restoreInjector(cl, change.Injector, current); rerr != nil {
+			return nil, fmt.Errorf("failed to update the validation webhook: %s, and to restore the default injector "+
+				"webhook: %s", err, rerr)
+		}
+		return nil, fmt.Errorf("failed to update the validation webhook, the default injector webhook is unchanged: %s", err)
+	}
+	return change, nil
+}
+
+// restoreInjector restores the default injector webhook updated to injector to previous, or deletes it if previous is
+// nil.
+func restoreInjector(cl client.Client, injector, previous *admissionv1beta1.MutatingWebhookConfiguration) error {
+	if previous == nil {
+		return cl.Delete(context.TODO(), injector)
+	}
+	restored := previous.DeepCopy()
+	restored.ResourceVersion = injector.ResourceVersion
+	return cl.Update(context.TODO(), restored)
+}
+
+// injectorName returns the name of the injector webhook of revision in istioNamespace, as in the istio-discovery
+// chart.
+func injectorName(istioNamespace, revision string) string {
+	n := injectorWebhookName
+	if revision != "" {
+		n += "-" + revision
+	}
+	if istioNamespace != "istio-system" {
+		n += "-" + istioNamespace
+	}
+	return n
+}
+
+// defaultInjector returns the default injector webhook for revision, a copy of revInjector, the injector webhook of
+// revision, named and selecting namespaces like current, the default injector webhook, if it is not nil. Without a
+// current one, the webhooks select the namespaces labeled istio-injection=enabled.
+func defaultInjector(revInjector, current *admissionv1beta1.MutatingWebhookConfiguration, istioNamespace,
+	revision string) *admissionv1beta1.MutatingWebhookConfiguration {
+	out := &admissionv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   injectorName(istioNamespace, ""),
+			Labels: defaultInjectorLabels(revInjector.Labels, revision),
+		},
+	}
+	selectors := make(map[string]*metav1.LabelSelector)
+	if current != nil {
+		out.ResourceVersion = current.ResourceVersion
+		for _, w := range current.Webhooks {
+			selectors[w.Name] = w.NamespaceSelector
+		}
+	}
+	for _, w := range revInjector.Webhooks {
+		w := *w.DeepCopy()
+		if s, ok := selectors[w.Name]; ok {
+			w.NamespaceSelector = s.DeepCopy()
+		} else {
+			w.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{injectionLabel: "enabled"}}
+		}
+		out.Webhooks = append(out.Webhooks, w)
+	}
+	return out
+}
+
+// defaultInjectorLabels returns labels, the labels of the injector webhook of revision, without the labels which
+// make the installed-state CR of revision own and prune the default injector webhook.
+func defaultInjectorLabels(labels map[string]string, revision string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range labels {
+		switch k {
+		case OwnerNameKey, OwnerKindKey, OwnerGroupKey, owningResourceKey, operatorLabelStr:
+			continue
+		}
+		out[k] = v
+	}
+	out[model.RevisionLabel] = revision
+	out[istioComponentLabelStr] = RevisionComponentLabel(revision)
+	return out
+}
+
+// validationForRevision returns a copy of validation, the config validation webhook configuration, whose webhooks
+// served by istiod in istioNamespace are served by the istiod of revision instead.
+func validationForRevision(validation *admissionv1beta1.ValidatingWebhookConfiguration, istioNamespace,
+	revision string) *admissionv1beta1.ValidatingWebhookConfiguration {
+	out := validation.DeepCopy()
+	for i := range out.Webhooks {
+		s := out.Webhooks[i].ClientConfig.Service
+		if s != nil && s.Namespace == istioNamespace && strings.HasPrefix(s.Name, "istiod") {
+			s.Name = "istiod-" + revision
+		}
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInjectorName(t *testing.T) {
+	tests := []struct {
+		namespace, revision, want string
+	}{
+		{namespace: "istio-system", want: "istio-sidecar-injector"},
+		{namespace: "istio-system", revision: "canary", want: "istio-sidecar-injector-canary"},
+		{namespace: "istio-control", revision: "canary", want: "istio-sidecar-injector-canary-istio-control"},
+	}
+	for _, tt := range tests {
+		if got := injectorName(tt.namespace, tt.revision); got != tt.want {
+			t.Errorf("injectorName(%s, %s): got %s, want %s", tt.namespace, tt.revision, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultInjector(t *testing.T) {
+	revSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"istio.io/rev": "canary"}}
+	revInjector := &admissionv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "istio-sidecar-injector-canary",
+			Labels: map[string]string{
+				"app":                  "sidecar-injector",
+				"istio.io/rev":         "canary",
+				OwnerNameKey:           "installed-state-canary",
+				owningResourceKey:      "installed-state-canary",
+				operatorLabelStr:       operatorReconcileStr,
+				istioComponentLabelStr: "Pilot-canary",
+				OwnerGroupKey:          "install.istio.io",
+			},
+		},
+		Webhooks: []admissionv1beta1.MutatingWebhook{{
+			Name:              "sidecar-injector.istio.io",
+			ClientConfig:      admissionv1beta1.WebhookClientConfig{Service: &admissionv1beta1.ServiceReference{Name: "istiod-canary"}},
+			NamespaceSelector: revSelector,
+		}},
+	}
+	wantLabels := map[string]string{
+		"app":                  "sidecar-injector",
+		"istio.io/rev":         "canary",
+		istioComponentLabelStr: "Pilot-canary",
+	}
+	enabled := &metav1.LabelSelector{MatchLabels: map[string]string{"istio-injection": "enabled"}}
+
+	got := defaultInjector(revInjector, nil, "istio-system", "canary")
+	if got.Name != "istio-sidecar-injector" {
+		t.Errorf("got name %s, want istio-sidecar-injector", got.Name)
+	}
+	if !reflect.DeepEqual(got.Labels, wantLabels) {
+		t.Errorf("got labels %v, want %v", got.Labels, wantLabels)
+	}
+	if len(got.Webhooks) != 1 || got.Webhooks[0].ClientConfig.Service.Name != "istiod-canary" {
+		t.Fatalf("got webhooks %v, want the webhook of istiod-canary", got.Webhooks)
+	}
+	if !reflect.DeepEqual(got.Webhooks[0].NamespaceSelector, enabled) {
+		t.Errorf("got selector %v, want %v", got.Webhooks[0].NamespaceSelector, enabled)
+	}
+	if !reflect.DeepEqual(revInjector.Webhooks[0].NamespaceSelector, revSelector) {
+		t.Errorf("the webhook of the revision was modified")
+	}
+
+	byDefault := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "istio-injection", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"disabled"}},
+	}}
+	current := &admissionv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector", ResourceVersion: "42"},
+		Webhooks:   []admissionv1beta1.MutatingWebhook{{Name: "sidecar-injector.istio.io", NamespaceSelector: byDefault}},
+	}
+	got = defaultInjector(revInjector, current, "istio-system", "canary")
+	if got.ResourceVersion != "42" {
+		t.Errorf("got resource version %s, want 42", got.ResourceVersion)
+	}
+	if !reflect.DeepEqual(got.Webhooks[0].NamespaceSelector, byDefault) {
+		t.Errorf("got selector %v, want %v", got.Webhooks[0].NamespaceSelector, byDefault)
+	}
+}
+
+func TestValidationForRevision(t *testing.T) {
+	validation := &admissionv1beta1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionv1beta1.ValidatingWebhook{
+			{
+				Name:         "validation.istio.io",
+				ClientConfig: admissionv1beta1.WebhookClientConfig{Service: &admissionv1beta1.ServiceReference{Name: "istiod", Namespace: "istio-system"}},
+			},
+			{
+				Name:         "other.example.com",
+				ClientConfig: admissionv1beta1.WebhookClientConfig{Service: &admissionv1beta1.ServiceReference{Name: "other", Namespace: "istio-system"}},
+			},
+		},
+	}
+	got := validationForRevision(validation, "istio-system", "canary")
+	if n := got.Webhooks[0].ClientConfig.Service.Name; n != "istiod-canary" {
+		t.Errorf("got service %s, want istiod-canary", n)
+	}
+	if n := got.Webhooks[1].ClientConfig.Service.Name; n != "other" {
+		t.Errorf("got service %s, want other", n)
+	}
+	if n := validation.Webhooks[0].ClientConfig.Service.Name; n != "istiod" {
+		t.Errorf("the input was modified, got service %s", n)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// defaultDeletionTimeout is how long to wait for pruned objects to be removed, if Options.DeletionTimeout is
+	// not set.
+	defaultDeletionTimeout = 2 * time.Minute
+	// deletionPollInterval is how often pruned objects are checked for removal.
+	deletionPollInterval = 2 * time.Second
+)
+
+// waitForDeletion waits until all the given deleted objects are gone from the cluster. Objects which are still
+// present after the deletion timeout, typically because finalizers hold them in a terminating state, are listed in the
+// returned error together with what holds them and how to release them.
+func (h *HelmReconciler) waitForDeletion(objs []*unstructured.Unstructured) error {
+	timeout := h.opts.DeletionTimeout
+	if timeout == 0 {
+		timeout = defaultDeletionTimeout
+	}
+	remaining := objs
+	err := wait.PollImmediate(deletionPollInterval, timeout, func() (bool, error) {
+		var left []*unstructured.Unstructured
+		for _, o := range remaining {
+			live := &unstructured.Unstructured{}
+			live.SetGroupVersionKind(o.GroupVersionKind())
+			err := h.client.Get(context.TODO(), client.ObjectKey{Namespace: o.GetNamespace(), Name: o.GetName()}, live)
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			left = append(left, live)
+		}
+		remaining = left
+		return len(remaining) == 0, nil
+	})
+	if err != wait.ErrWaitTimeout {
+		return err
+	}
+	var msgs []string
+	for _, o := range remaining {
+		msgs = append(msgs, stuckDeletionMessage(o))
+	}
+	return fmt.Errorf("timed out after %s waiting for pruned objects to be removed:\n%s", timeout, strings.Join(msgs, "\n"))
+}
+
+// stuckDeletionMessage returns a description of why the deleted object o still exists and how to release it.
+func stuckDeletionMessage(o *unstructured.Unstructured) string {
+	oh := object.NewK8sObject(o, nil, nil).Hash()
+	if o.GetDeletionTimestamp() == nil {
+		return fmt.Sprintf("- %s is not terminating yet.", oh)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "- %s is stuck terminating", oh)
+	if f := o.GetFinalizers(); len(f) != 0 {
+		fmt.Fprintf(&sb, " with finalizers %s", strings.Join(f, ", "))
+	}
+	sb.WriteString(".")
+	for _, c := range trueConditions(o) {
+		fmt.Fprintf(&sb, "\n  %s", c)
+	}
+
+	nsFlag := ""
+	if o.GetNamespace() != "" {
+		nsFlag = " -n " + o.GetNamespace()
+	}
+	switch o.GetKind() {
+	case "Namespace":
+		fmt.Fprintf(&sb, "\n  Find the resources left in the namespace with: kubectl api-resources --verbs=list --namespaced "+
+			"-o name | xargs -n 1 kubectl get --show-kind --ignore-not-found -n %s", o.GetName())
+		sb.WriteString("\n  and remove them, or the finalizers blocking them, before the namespace can be removed.")
+	case "CustomResourceDefinition":
+		plural, _, _ := unstructured.NestedString(o.Object, "spec", "names", "plural")
+		fmt.Fprintf(&sb, "\n  Find the remaining custom resources with: kubectl get %s --all-namespaces", plural)
+		sb.WriteString("\n  and remove them, or their finalizers if their controller is gone, before the CRD can be removed.")
+	default:
+		if len(o.GetFinalizers()) != 0 {
+			fmt.Fprintf(&sb, "\n  If the controller owning the finalizers is gone, release the object with: kubectl patch %s %s%s "+
+				`--type=merge -p '{"metadata":{"finalizers":null}}'`, strings.ToLower(o.GetKind()), o.GetName(), nsFlag)
+		}
+	}
+	return sb.String()
+}
+
+// trueConditions returns the status conditions of o which are true, formatted as type (reason): message.
+func trueConditions(o *unstructured.Unstructured) []string {
+	conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+	var out []string
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok || cm["status"] != "True" {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%v (%v): %v", cm["type"], cm["reason"], cm["message"]))
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestStuckDeletionMessage(t *testing.T) {
+	tests := []struct {
+		desc string
+		obj  string
+		want []string
+	}{
+		{
+			desc: "not terminating",
+			obj: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+`,
+			want: []string{"ConfigMap:istio-system:istio is not terminating yet."},
+		},
+		{
+			desc: "finalizers",
+			obj: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  deletionTimestamp: "2020-05-01T00:00:00Z"
+  finalizers:
+  - service.kubernetes.io/load-balancer-cleanup
+`,
+			want: []string{
+				"Service:istio-system:istio-ingressgateway is stuck terminating with finalizers service.kubernetes.io/load-balancer-cleanup.",
+				`kubectl patch service istio-ingressgateway -n istio-system --type=merge -p '{"metadata":{"finalizers":null}}'`,
+			},
+		},
+		{
+			desc: "namespace",
+			obj: `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: istio-system
+  deletionTimestamp: "2020-05-01T00:00:00Z"
+status:
+  phase: Terminating
+  conditions:
+  - type: NamespaceDeletionDiscoveryFailure
+    status: "False"
+    reason: ResourcesDiscovered
+  - type: NamespaceContentRemaining
+    status: "True"
+    reason: SomeResourcesRemain
+    message: 'Some resources are remaining: gateways.networking.istio.io has 1 resource instances'
+`,
+			want: []string{
+				"Namespace::istio-system is stuck terminating.",
+				"NamespaceContentRemaining (SomeResourcesRemain): Some resources are remaining: gateways.networking.istio.io has 1 resource instances",
+				"kubectl get --show-kind --ignore-not-found -n istio-system",
+			},
+		},
+		{
+			desc: "CRD",
+			obj: `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+  deletionTimestamp: "2020-05-01T00:00:00Z"
+  finalizers:
+  - customresourcecleanup.apiextensions.k8s.io
+spec:
+  names:
+    plural: gateways
+`,
+			want: []string{
+				"with finalizers customresourcecleanup.apiextensions.k8s.io.",
+				"kubectl get gateways --all-namespaces",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			o := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(tt.obj), &o.Object); err != nil {
+				t.Fatal(err)
+			}
+			got := stuckDeletionMessage(o)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got message:\n%s\nwant it to contain:\n%s", got, want)
+				}
+			}
+			if strings.Contains(got, "NamespaceDeletionDiscoveryFailure") {
+				t.Errorf("got message with a false condition:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestDeletionSteps(t *testing.T) {
+	kinds := func(steps [][]schema.GroupVersionKind) [][]string {
+		var out [][]string
+		for _, gvks := range steps {
+			var ks []string
+			for _, gvk := range gvks {
+				ks = append(ks, gvk.Kind)
+			}
+			out = append(out, ks)
+		}
+		return out
+	}
+	namespaced := []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}}
+	cluster := []schema.GroupVersionKind{
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingWebhookConfiguration"},
+		{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingWebhookConfiguration"},
+	}
+
+	got := kinds(deletionSteps(namespaced, cluster, false))
+	want := [][]string{{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}, {"Deployment"}, {"ClusterRole"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got steps %v, want %v", got, want)
+	}
+	got = kinds(deletionSteps(namespaced, cluster, true))
+	want = append(want, []string{"CustomResourceDefinition"})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got steps with CRDs %v, want %v", got, want)
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright 2019 Istio Authors
+// Copyright 2020 Istio Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,9 +15,129 @@
 package helmreconciler
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
 	"istio.io/istio/operator/pkg/name"
 )
 
+// ComponentDependencies maps each component to the components it depends on. A component is only applied once all
+// of its dependencies have been applied and their resources are ready.
+type ComponentDependencies map[name.ComponentName][]name.ComponentName
+
+// DefaultDependencies returns the dependencies of the built in components: istiod waits for the base chart and
+// all other components, including addons, wait for istiod.
+func DefaultDependencies() ComponentDependencies {
+	deps := ComponentDependencies{
+		name.PilotComponentName: {name.IstioBaseComponentName},
+	}
+	for _, c := range []name.ComponentName{
+		name.PolicyComponentName,
+		name.TelemetryComponentName,
+		name.CNIComponentName,
+		name.IngressComponentName,
+		name.EgressComponentName,
+		name.AddonComponentName,
+	} {
+		deps[c] = []name.ComponentName{name.PilotComponentName}
+	}
+	return deps
+}
+
+// DependenciesForIOP returns defaults with the dependencies declared through the depends-on annotation of iop added.
+// The annotation is a JSON map of component names to the components they depend on, for example
+// {"AddonComponents": ["IngressGateways"]} to install user added charts after the ingress gateways.
+func DependenciesForIOP(defaults ComponentDependencies, iop *valuesv1alpha1.IstioOperator) (ComponentDependencies, error) {
+	out := make(ComponentDependencies)
+	for c, d := range defaults {
+		out[c] = append([]name.ComponentName{}, d...)
+	}
+	var a string
+	if iop != nil {
+		a = iop.GetAnnotations()[valuesv1alpha1.DependsOnAnnotation]
+	}
+	if a == "" {
+		return out, out.Validate()
+	}
+	declared := make(map[name.ComponentName][]name.ComponentName)
+	if err := json.Unmarshal([]byte(a), &declared); err != nil {
+		return nil, fmt.Errorf("bad %s annotation: %s", valuesv1alpha1.DependsOnAnnotation, err)
+	}
+	for c, d := range declared {
+		for _, dc := range d {
+			if !containsComponent(out[c], dc) {
+				out[c] = append(out[c], dc)
+			}
+		}
+	}
+	return out, out.Validate()
+}
+
+// Validate returns an error if d has a component which depends on itself, directly or through other components.
+func (d ComponentDependencies) Validate() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[name.ComponentName]int)
+	var visit func(c name.ComponentName, path []name.ComponentName) error
+	visit = func(c name.ComponentName, path []name.ComponentName) error {
+		switch state[c] {
+		case visiting:
+			return fmt.Errorf("component dependency cycle: %v", append(path, c))
+		case visited:
+			return nil
+		}
+		state[c] = visiting
+		for _, dc := range d[c] {
+			if err := visit(dc, append(path, c)); err != nil {
+				return err
+			}
+		}
+		state[c] = visited
+		return nil
+	}
+	for _, c := range d.components() {
+		if err := visit(c, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dependents returns a map of each component to the components which depend on it.
+func (d ComponentDependencies) Dependents() ComponentNameToListMap {
+	out := make(ComponentNameToListMap)
+	for _, c := range d.components() {
+		for _, dc := range d[c] {
+			out[dc] = append(out[dc], c)
+		}
+	}
+	return out
+}
+
+// components returns the components which have dependencies, sorted by name.
+func (d ComponentDependencies) components() []name.ComponentName {
+	var out []name.ComponentName
+	for c := range d {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func containsComponent(cs []name.ComponentName, c name.ComponentName) bool {
+	for _, cc := range cs {
+		if cc == c {
+			return true
+		}
+	}
+	return false
+}
+
 func InsertChildrenRecursive(componentName name.ComponentName, tree ComponentTree, children ComponentNameToListMap) {
 	tree[componentName] = make(ComponentTree)
 	for _, child := range children[componentName] {
@@ -0,0 +1,101 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestDependenciesForIOP(t *testing.T) {
+	tests := []struct {
+		desc       string
+		annotation string
+		want       map[name.ComponentName][]name.ComponentName
+		wantErr    string
+	}{
+		{
+			desc: "defaults",
+			want: map[name.ComponentName][]name.ComponentName{
+				name.PilotComponentName: {name.IstioBaseComponentName},
+				name.AddonComponentName: {name.PilotComponentName},
+			},
+		},
+		{
+			desc:       "declared",
+			annotation: `{"AddonComponents": ["IngressGateways", "EgressGateways"], "IngressGateways": ["Pilot"]}`,
+			want: map[name.ComponentName][]name.ComponentName{
+				name.AddonComponentName:   {name.PilotComponentName, name.IngressComponentName, name.EgressComponentName},
+				name.IngressComponentName: {name.PilotComponentName},
+			},
+		},
+		{
+			desc:       "cycle",
+			annotation: `{"Base": ["AddonComponents"]}`,
+			wantErr:    "component dependency cycle: [AddonComponents Pilot Base AddonComponents]",
+		},
+		{
+			desc:       "bad annotation",
+			annotation: `AddonComponents: IngressGateways`,
+			wantErr:    "bad install.istio.io/depends-on annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			iop := &valuesv1alpha1.IstioOperator{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+			if tt.annotation != "" {
+				iop.Annotations[valuesv1alpha1.DependsOnAnnotation] = tt.annotation
+			}
+			got, err := DependenciesForIOP(DefaultDependencies(), iop)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for c, want := range tt.want {
+				if !reflect.DeepEqual(got[c], want) {
+					t.Errorf("%s: got %v, want %v", c, got[c], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	deps := ComponentDependencies{
+		name.PilotComponentName:   {name.IstioBaseComponentName},
+		name.AddonComponentName:   {name.PilotComponentName, name.IngressComponentName},
+		name.IngressComponentName: {name.PilotComponentName},
+	}
+	got := deps.Dependents()
+	want := ComponentNameToListMap{
+		name.IstioBaseComponentName: {name.PilotComponentName},
+		name.PilotComponentName:     {name.AddonComponentName, name.IngressComponentName},
+		name.IngressComponentName:   {name.AddonComponentName},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
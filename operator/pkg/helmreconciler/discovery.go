@@ -0,0 +1,136 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// defaultDiscoveryTimeout is how long to wait for applied CRDs to be established and for objects of unknown kinds
+	// to be accepted, if Options.DiscoveryTimeout is not set.
+	defaultDiscoveryTimeout = time.Minute
+	// discoveryPollInterval is how often applied CRDs are checked and objects of unknown kinds are applied again.
+	discoveryPollInterval = 2 * time.Second
+)
+
+// applyBatchAndDiscover applies batch like applyBatch. The CRDs of the batch are waited for until they are
+// established, so that objects of their kinds in later batches are served, and objects whose kind the API server, or
+// the cached discovery results of the client, do not know yet are applied again until their kind is discovered or
+// the discovery timeout passes. This happens when the CRD of a kind is part of the same manifest and was applied just
+// before, or is served by an aggregated API server which is still starting.
+func (h *HelmReconciler) applyBatchAndDiscover(component string, batch object.K8sObjects) []error {
+	errs := h.applyBatch(component, batch)
+	if h.opts.DryRun {
+		return errs
+	}
+	var crds object.K8sObjects
+	for i, o := range batch {
+		if o.Kind == "CustomResourceDefinition" && errs[i] == nil {
+			crds = append(crds, o)
+		}
+	}
+	if len(crds) != 0 {
+		if err := h.waitForEstablished(crds); err != nil {
+			// The objects of their kinds are still retried below.
+			scope.Warnf("%s", err)
+		}
+	}
+
+	var pending []int
+	for i, err := range errs {
+		if meta.IsNoMatchError(err) {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return errs
+	}
+	scope.Infof("Waiting for discovery of the kinds of %d resources of component %s.", len(pending), component)
+	_ = wait.Poll(discoveryPollInterval, h.discoveryTimeout(), func() (bool, error) {
+		var left []int
+		for _, i := range pending {
+			errs[i] = h.ProcessObject(component, batch[i].UnstructuredObject())
+			if meta.IsNoMatchError(errs[i]) {
+				left = append(left, i)
+			}
+		}
+		pending = left
+		return len(pending) == 0, nil
+	})
+	for _, i := range pending {
+		errs[i] = fmt.Errorf("kind %s of %s is not served by the cluster after %s: %s", batch[i].GroupVersionKind(),
+			batch[i].Hash(), h.discoveryTimeout(), errs[i])
+	}
+	return errs
+}
+
+// waitForEstablished waits until the given applied CRDs have the Established condition, which means that the API
+// server serves their kinds.
+func (h *HelmReconciler) waitForEstablished(crds object.K8sObjects) error {
+	remaining := crds
+	err := wait.PollImmediate(discoveryPollInterval, h.discoveryTimeout(), func() (bool, error) {
+		var left object.K8sObjects
+		for _, crd := range remaining {
+			live := &unstructured.Unstructured{}
+			live.SetGroupVersionKind(crd.GroupVersionKind())
+			if err := h.client.Get(context.TODO(), client.ObjectKey{Name: crd.Name}, live); err != nil {
+				return false, err
+			}
+			if !isEstablished(live) {
+				left = append(left, crd)
+			}
+		}
+		remaining = left
+		return len(remaining) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		var names []string
+		for _, crd := range remaining {
+			names = append(names, crd.Name)
+		}
+		return fmt.Errorf("CRDs %v are not established after %s", names, h.discoveryTimeout())
+	}
+	return err
+}
+
+// isEstablished reports whether crd has the Established condition with status True.
+func isEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if ok && cm["type"] == "Established" && cm["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// discoveryTimeout returns how long to wait for the kinds of applied CRDs to be served.
+func (h *HelmReconciler) discoveryTimeout() time.Duration {
+	if h.opts.DiscoveryTimeout > 0 {
+		return h.opts.DiscoveryTimeout
+	}
+	return defaultDiscoveryTimeout
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsEstablished(t *testing.T) {
+	tests := []struct {
+		desc       string
+		conditions []interface{}
+		want       bool
+	}{
+		{
+			desc: "no status",
+		},
+		{
+			desc: "established",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+				map[string]interface{}{"type": "Established", "status": "True"},
+			},
+			want: true,
+		},
+		{
+			desc: "names accepted only",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+			},
+		},
+		{
+			desc: "not established",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Established", "status": "False"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			crd := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tt.conditions != nil {
+				crd.Object["status"] = map[string]interface{}{"conditions": tt.conditions}
+			}
+			if got := isEstablished(crd); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
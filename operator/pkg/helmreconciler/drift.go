@@ -0,0 +1,170 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+// DriftedResource describes an object in the cluster which differs from the manifest it was last applied from.
+type DriftedResource struct {
+	// Component is the component the object belongs to.
+	Component name.ComponentName
+	// Hash is the object Hash() of the form Kind:Namespace:Name.
+	Hash string
+	// Reason is a short description of how the object differs.
+	Reason string
+}
+
+func (d DriftedResource) String() string {
+	return fmt.Sprintf("%s (%s)", d.Hash, d.Reason)
+}
+
+// DetectDrift compares the objects in manifests with the live objects in the cluster and returns those which are
+// missing or have a field with a value other than the rendered one, sorted by component and object. Fields which are
+// not set in the manifests, like status or defaults filled in by the API server, are not compared.
+func DetectDrift(cl client.Client, manifests name.ManifestMap) ([]DriftedResource, error) {
+	var out []DriftedResource
+	for c, ms := range manifests {
+		objs, err := object.ParseK8sObjectsFromYAMLManifest(strings.Join(ms, helm.YAMLSeparator))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest for component %s: %s", c, err)
+		}
+		for _, obj := range objs {
+			live := &unstructured.Unstructured{}
+			live.SetGroupVersionKind(obj.GroupVersionKind())
+			nn := types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}
+			if err := cl.Get(context.TODO(), nn, live); err != nil {
+				if kerrors.IsNotFound(err) {
+					out = append(out, DriftedResource{Component: c, Hash: obj.Hash(), Reason: "missing"})
+					continue
+				}
+				return nil, fmt.Errorf("failed to get %s: %s", obj.Hash(), err)
+			}
+			if isProtected(live) {
+				// Changes to protected objects are deliberate.
+				continue
+			}
+			if path, ok := driftedField(obj.Unstructured(), live.Object); ok {
+				out = append(out, DriftedResource{Component: c, Hash: obj.Hash(), Reason: "changed " + path})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Component != out[j].Component {
+			return out[i].Component < out[j].Component
+		}
+		return out[i].Hash < out[j].Hash
+	})
+	return out, nil
+}
+
+// driftedField returns the path of the first field set in rendered whose value in live differs, and true if there is
+// one. Server managed metadata and status are ignored.
+func driftedField(rendered, live map[string]interface{}) (string, bool) {
+	keys := make([]string, 0, len(rendered))
+	for k := range rendered {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		switch k {
+		case "status":
+			continue
+		case "metadata":
+			rm, _ := rendered[k].(map[string]interface{})
+			lm, _ := live[k].(map[string]interface{})
+			for _, mk := range []string{"labels", "annotations"} {
+				if p, ok := subsetDiff(rm[mk], lm[mk], "metadata."+mk); ok {
+					return p, true
+				}
+			}
+			continue
+		}
+		if p, ok := subsetDiff(rendered[k], live[k], k); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// subsetDiff returns the path of the first value in want which is not matched in got, and true if there is one. Maps in
+// got may hold extra keys, lists must have the same length.
+func subsetDiff(want, got interface{}, path string) (string, bool) {
+	switch w := want.(type) {
+	case nil:
+		return "", false
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return path, len(w) != 0
+		}
+		keys := make([]string, 0, len(w))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p, ok := subsetDiff(w[k], g[k], path+"."+k); ok {
+				return p, true
+			}
+		}
+		return "", false
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return path, len(w) != 0 || len(g) != 0
+		}
+		for i := range w {
+			if p, ok := subsetDiff(w[i], g[i], fmt.Sprintf("%s[%d]", path, i)); ok {
+				return p, true
+			}
+		}
+		return "", false
+	default:
+		// Compare scalars by their string form since numbers decoded from YAML and from the API server may have
+		// different types.
+		if got == nil || fmt.Sprint(want) != fmt.Sprint(got) {
+			return path, true
+		}
+		return "", false
+	}
+}
+
+// FlushObjectCachesFor flushes the K8s object caches for the IstioOperator CR with the given name, so that the next
+// reconcile applies all of its objects again.
+func FlushObjectCachesFor(iopName string) {
+	objectCachesMu.Lock()
+	defer objectCachesMu.Unlock()
+	prefix := iopName + "-"
+	for k := range objectCaches {
+		// Component names have no dashes, which keeps revisioned CRs like installed-state-canary separate.
+		if strings.HasPrefix(k, prefix) && !strings.Contains(strings.TrimPrefix(k, prefix), "-") {
+			delete(objectCaches, k)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"testing"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestDriftedField(t *testing.T) {
+	rendered := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    app: istiod
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: istio/pilot:1.6.0
+`
+	tests := []struct {
+		desc     string
+		live     string
+		wantPath string
+	}{
+		{
+			desc: "in sync with server fields",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  uid: 1234
+  labels:
+    app: istiod
+    operator.istio.io/component: Pilot
+spec:
+  replicas: 1
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: istio/pilot:1.6.0
+        imagePullPolicy: IfNotPresent
+status:
+  replicas: 1
+`,
+		},
+		{
+			desc: "changed scalar",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    app: istiod
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: istio/pilot:1.6.0
+`,
+			wantPath: "spec.replicas",
+		},
+		{
+			desc: "changed list item",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    app: istiod
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: my/pilot:dev
+`,
+			wantPath: "spec.template.spec.containers[0].image",
+		},
+		{
+			desc: "removed label",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: istio/pilot:1.6.0
+`,
+			wantPath: "metadata.labels",
+		},
+	}
+	r, err := object.ParseYAMLToK8sObject([]byte(rendered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			l, err := object.ParseYAMLToK8sObject([]byte(tt.live))
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotPath, gotDrift := driftedField(r.Unstructured(), l.Unstructured())
+			if gotPath != tt.wantPath || gotDrift != (tt.wantPath != "") {
+				t.Errorf("got path %q, drifted %v, want path %q", gotPath, gotDrift, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// dryRunStatusField is the field under the IstioOperator status holding the changes a reconcile would make while
+	// the CR has the dry-run annotation. Like effectiveSpec, it is not part of the typed InstallStatus.
+	dryRunStatusField = "dryRun"
+	// maxChangesInStatus is the maximum number of changes listed in status.dryRun, to keep the CR small.
+	maxChangesInStatus = 100
+)
+
+// ChangeAction is what a reconcile would do to a resource.
+type ChangeAction string
+
+const (
+	// ChangeCreate is the action on a rendered resource which does not exist in the cluster.
+	ChangeCreate ChangeAction = "create"
+	// ChangeUpdate is the action on a rendered resource which differs from the live one.
+	ChangeUpdate ChangeAction = "update"
+	// ChangePrune is the action on a live resource owned by the CR which is no longer rendered.
+	ChangePrune ChangeAction = "prune"
+)
+
+// ResourceChange is a change a reconcile would make to a resource.
+type ResourceChange struct {
+	// Component is the component the resource belongs to.
+	Component string `json:"component,omitempty"`
+	// Action is what would be done to the resource.
+	Action ChangeAction `json:"action"`
+	// Resource is the object Hash() of the form Kind:Namespace:Name.
+	Resource string `json:"resource"`
+	// Field is the path of the first differing field of an update.
+	Field string `json:"field,omitempty"`
+}
+
+// String implements fmt.Stringer.
+func (c ResourceChange) String() string {
+	if c.Field != "" {
+		return fmt.Sprintf("%s %s (%s)", c.Action, c.Resource, c.Field)
+	}
+	return fmt.Sprintf("%s %s", c.Action, c.Resource)
+}
+
+// DryRunStatus is the content of status.dryRun.
+type DryRunStatus struct {
+	// Summary counts the changes by action.
+	Summary string `json:"summary"`
+	// Changes lists the changes, truncated to maxChangesInStatus.
+	Changes []ResourceChange `json:"changes,omitempty"`
+	// Omitted is the number of changes not listed in Changes.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// PreviewChanges renders the charts of h and returns the resources a reconcile would create, update or prune, sorted
+// by action, component and resource. Nothing is applied or deleted.
+func (h *HelmReconciler) PreviewChanges() ([]ResourceChange, error) {
+	manifestMap, err := h.RenderCharts()
+	if err != nil {
+		return nil, err
+	}
+	drifted, err := DetectDrift(h.client, h.manifests)
+	if err != nil {
+		return nil, err
+	}
+	var out []ResourceChange
+	for _, d := range drifted {
+		rc := ResourceChange{Component: string(d.Component), Action: ChangeCreate, Resource: d.Hash}
+		if d.Reason != "missing" {
+			rc.Action = ChangeUpdate
+			rc.Field = strings.TrimPrefix(d.Reason, "changed ")
+		}
+		out = append(out, rc)
+	}
+	// Like Reconcile, only prune when all resource types, including CRDs, are managed.
+	if h.needUpdateAndPrune && (h.opts.CRDs == "" || h.opts.CRDs == manifest.IncludeCRDs) {
+		pruned, err := h.pruneCandidates(allObjectHashes(manifestMap))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, pruned...)
+	}
+	sortChanges(out)
+	return out, nil
+}
+
+// pruneCandidates returns the live resources owned by the CR of h which are not in excluded and would be pruned.
+func (h *HelmReconciler) pruneCandidates(excluded map[string]bool) ([]ResourceChange, error) {
+	namespacedResources, clusterResources := h.pruningDetails.GetResourceTypes()
+	ownerLabels := h.pruningDetails.GetOwnerLabels()
+	var out []ResourceChange
+	for _, gvk := range append(namespacedResources, clusterResources...) {
+		objects := &unstructured.UnstructuredList{}
+		objects.SetGroupVersionKind(gvk)
+		err := h.client.List(context.TODO(), objects, client.MatchingLabels(ownerLabels), client.InNamespace(h.iop.Namespace))
+		if err != nil {
+			// Same as Prune, types which are not served by the cluster are skipped.
+			scope.Warnf("retrieving resources to prune type %s: %s not found", gvk.String(), err)
+			continue
+		}
+		for i := range objects.Items {
+			o := &objects.Items[i]
+			oh := object.NewK8sObject(o, nil, nil).Hash()
+			if excluded[oh] || isProtected(o) {
+				continue
+			}
+			out = append(out, ResourceChange{
+				Component: componentFromLabel(o.GetLabels()[istioComponentLabelStr]),
+				Action:    ChangePrune,
+				Resource:  oh,
+			})
+		}
+	}
+	return out, nil
+}
+
+// sortChanges sorts changes by action, component and resource.
+func sortChanges(changes []ResourceChange) {
+	order := map[ChangeAction]int{ChangeCreate: 0, ChangeUpdate: 1, ChangePrune: 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Action != b.Action {
+			return order[a.Action] < order[b.Action]
+		}
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Resource < b.Resource
+	})
+}
+
+// ChangesSummary returns a one line summary of changes, like "2 to create, 1 to update, 0 to prune".
+func ChangesSummary(changes []ResourceChange) string {
+	counts := make(map[ChangeAction]int)
+	for _, c := range changes {
+		counts[c.Action]++
+	}
+	return fmt.Sprintf("%d to create, %d to update, %d to prune", counts[ChangeCreate], counts[ChangeUpdate], counts[ChangePrune])
+}
+
+// newDryRunStatus returns the DryRunStatus for changes.
+func newDryRunStatus(changes []ResourceChange) *DryRunStatus {
+	s := &DryRunStatus{Summary: ChangesSummary(changes), Changes: changes}
+	if len(changes) > maxChangesInStatus {
+		s.Changes = changes[:maxChangesInStatus]
+		s.Omitted = len(changes) - maxChangesInStatus
+	}
+	return s
+}
+
+// SetStatusDryRun writes changes into status.dryRun of the IstioOperator of h. If dryRun is false, the field is removed
+// instead.
+func (h *HelmReconciler) SetStatusDryRun(dryRun bool, changes []ResourceChange) error {
+	var v interface{}
+	// A null value deletes the field in a merge patch.
+	if dryRun {
+		v = newDryRunStatus(changes)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{dryRunStatusField: v},
+	})
+	if err != nil {
+		return err
+	}
+	iop := &valuesv1alpha1.IstioOperator{}
+	if err := h.GetClient().Get(context.TODO(), types.NamespacedName{Name: h.iop.Name, Namespace: h.iop.Namespace}, iop); err != nil {
+		return fmt.Errorf("failed to get IstioOperator %s/%s: %s", h.iop.Namespace, h.iop.Name, err)
+	}
+	return h.GetClient().Status().Patch(context.TODO(), iop, client.RawPatch(types.MergePatchType, patch))
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSortChanges(t *testing.T) {
+	changes := []ResourceChange{
+		{Component: "Pilot", Action: ChangePrune, Resource: "Service:istio-system:istio-pilot"},
+		{Component: "Pilot", Action: ChangeUpdate, Resource: "Deployment:istio-system:istiod", Field: "spec.replicas"},
+		{Component: "Pilot", Action: ChangeCreate, Resource: "Service:istio-system:istiod"},
+		{Component: "Base", Action: ChangeCreate, Resource: "ServiceAccount:istio-system:istio-reader"},
+	}
+	sortChanges(changes)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"create ServiceAccount:istio-system:istio-reader",
+		"create Service:istio-system:istiod",
+		"update Deployment:istio-system:istiod (spec.replicas)",
+		"prune Service:istio-system:istio-pilot",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := ChangesSummary(changes), "2 to create, 1 to update, 1 to prune"; got != want {
+		t.Errorf("ChangesSummary() got %q, want %q", got, want)
+	}
+}
+
+func TestNewDryRunStatus(t *testing.T) {
+	if got := newDryRunStatus(nil); got.Summary != "0 to create, 0 to update, 0 to prune" || len(got.Changes) != 0 || got.Omitted != 0 {
+		t.Errorf("got %+v for no changes", got)
+	}
+	var changes []ResourceChange
+	for i := 0; i < maxChangesInStatus+3; i++ {
+		changes = append(changes, ResourceChange{Action: ChangeCreate, Resource: fmt.Sprintf("ConfigMap:istio-system:cm%d", i)})
+	}
+	got := newDryRunStatus(changes)
+	if len(got.Changes) != maxChangesInStatus || got.Omitted != 3 {
+		t.Errorf("got %d changes and %d omitted, want %d and 3", len(got.Changes), got.Omitted, maxChangesInStatus)
+	}
+	if want := fmt.Sprintf("%d to create, 0 to update, 0 to prune", maxChangesInStatus+3); got.Summary != want {
+		t.Errorf("got summary %q, want %q", got.Summary, want)
+	}
+}
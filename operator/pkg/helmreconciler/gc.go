@@ -0,0 +1,100 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/translate"
+)
+
+const (
+	// istiodAppLabel is the app label value of istiod Deployments.
+	istiodAppLabel = "istiod"
+	// revisionLabel is the label with the control plane revision of istiod Deployments.
+	revisionLabel = "istio.io/rev"
+	// defaultRevision is the revision label value of a control plane installed without a revision.
+	defaultRevision = "default"
+)
+
+// StaleInstalledStates returns the installed-state IstioOperator CRs in namespace, i.e. the CRs whose names start with
+// prefix, which install a control plane whose revision has no istiod Deployment left in the cluster.
+func StaleInstalledStates(cl client.Client, namespace, prefix string) ([]*valuesv1alpha1.IstioOperator, error) {
+	iops := &valuesv1alpha1.IstioOperatorList{}
+	if err := cl.List(context.TODO(), iops, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list IstioOperator CRs in namespace %s: %s", namespace, err)
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := cl.List(context.TODO(), deployments, client.MatchingLabels{"app": istiodAppLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list istiod Deployments: %s", err)
+	}
+	revisions := make(map[string]bool)
+	for _, d := range deployments.Items {
+		rev := d.Labels[revisionLabel]
+		if rev == "" {
+			rev = defaultRevision
+		}
+		revisions[rev] = true
+	}
+	return staleInstalledStates(iops.Items, revisions, prefix), nil
+}
+
+// staleInstalledStates returns the CRs in iops whose names start with prefix, which enable pilot and whose revision
+// is not in revisions.
+func staleInstalledStates(iops []valuesv1alpha1.IstioOperator, revisions map[string]bool, prefix string) []*valuesv1alpha1.IstioOperator {
+	var out []*valuesv1alpha1.IstioOperator
+	for i := range iops {
+		iop := &iops[i]
+		if !strings.HasPrefix(iop.Name, prefix) || iop.Spec == nil {
+			continue
+		}
+		// CRs which do not install istiod, e.g. for gateways only, have no control plane to check against.
+		if enabled, err := translate.IsComponentEnabledInSpec(name.PilotComponentName, iop.Spec); err != nil || !enabled {
+			continue
+		}
+		rev := iop.Spec.Revision
+		if rev == "" {
+			rev = defaultRevision
+		}
+		if !revisions[rev] {
+			out = append(out, iop)
+		}
+	}
+	return out
+}
+
+// DeleteInstalledState deletes the installed-state IstioOperator CR iop. Its finalizers are removed first, so that an
+// operator controller watching it does not tear down the resources it once installed, which may be shared with other
+// revisions. The manifest snapshot owned by the CR is garbage collected with it.
+func DeleteInstalledState(cl client.Client, iop *valuesv1alpha1.IstioOperator) error {
+	if len(iop.GetFinalizers()) != 0 {
+		iop.SetFinalizers(nil)
+		if err := cl.Update(context.TODO(), iop); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove the finalizers of IstioOperator %s/%s: %s", iop.Namespace, iop.Name, err)
+		}
+	}
+	if err := cl.Delete(context.TODO(), iop); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete IstioOperator %s/%s: %s", iop.Namespace, iop.Name, err)
+	}
+	return nil
+}
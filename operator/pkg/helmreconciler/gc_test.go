@@ -0,0 +1,79 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/validate"
+)
+
+func TestStaleInstalledStates(t *testing.T) {
+	crs := map[string]string{
+		"installed-state": `
+spec:
+  components:
+    pilot:
+      enabled: true
+`,
+		"installed-state-canary": `
+spec:
+  revision: canary
+  components:
+    pilot:
+      enabled: true
+`,
+		"installed-state-old": `
+spec:
+  revision: old
+  components:
+    pilot:
+      enabled: true
+`,
+		"installed-state-gateways": `
+spec:
+  revision: gateways
+  components:
+    pilot:
+      enabled: false
+`,
+		"user-iop": `
+spec:
+  revision: user
+  components:
+    pilot:
+      enabled: true
+`,
+	}
+	var iops []valuesv1alpha1.IstioOperator
+	for _, n := range []string{"installed-state", "installed-state-canary", "installed-state-old", "installed-state-gateways", "user-iop"} {
+		iop, err := validate.UnmarshalIOP(crs[n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		iop.Name = n
+		iops = append(iops, *iop)
+	}
+
+	var got []string
+	for _, iop := range staleInstalledStates(iops, map[string]bool{defaultRevision: true, "canary": true}, "installed-state") {
+		got = append(got, iop.Name)
+	}
+	if want := []string{"installed-state-old"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got stale CRs %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+// ComponentHealth is the live health of a component installed by an IstioOperator CR.
+type ComponentHealth struct {
+	// Component is the component name.
+	Component string `json:"component"`
+	// Status is the status of the component recorded in the CR status, or empty if there is none.
+	Status string `json:"status,omitempty"`
+	// Error is the error recorded for the component in the CR status.
+	Error string `json:"error,omitempty"`
+	// Workloads are the Deployments and DaemonSets of the component.
+	Workloads []WorkloadHealth `json:"workloads,omitempty"`
+	// Webhooks are the webhook configurations of the component.
+	Webhooks []WebhookHealth `json:"webhooks,omitempty"`
+}
+
+// WorkloadHealth is the replica readiness of a Deployment or DaemonSet.
+type WorkloadHealth struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Version is the Istio version label of the workload, or the image tag of its first container.
+	Version string `json:"version,omitempty"`
+	Ready   int32  `json:"ready"`
+	Desired int32  `json:"desired"`
+}
+
+// WebhookHealth is the state of a mutating or validating webhook configuration.
+type WebhookHealth struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Webhooks is the number of webhooks in the configuration.
+	Webhooks int `json:"webhooks"`
+	// CABundles is the number of webhooks whose caBundle is set. Istiod patches it in once it is serving, so until
+	// then the API server cannot call the webhook.
+	CABundles int `json:"caBundles"`
+}
+
+// Versions returns the distinct versions of the workloads of c.
+func (c *ComponentHealth) Versions() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, w := range c.Workloads {
+		if w.Version != "" && !seen[w.Version] {
+			seen[w.Version] = true
+			out = append(out, w.Version)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Ready returns the number of ready and desired replicas of all the workloads of c.
+func (c *ComponentHealth) Ready() (ready, desired int32) {
+	for _, w := range c.Workloads {
+		ready += w.Ready
+		desired += w.Desired
+	}
+	return ready, desired
+}
+
+// GetComponentHealth returns the health of each component installed by iop, combining the status recorded in iop
+// with the live workloads and webhook configurations labeled as owned by it. Components are sorted by name.
+func GetComponentHealth(cl client.Reader, iop *valuesv1alpha1.IstioOperator) ([]*ComponentHealth, error) {
+	owned := client.MatchingLabels{owningResourceKey: iop.Name}
+	deployments := &appsv1.DeploymentList{}
+	if err := cl.List(context.TODO(), deployments, owned); err != nil {
+		return nil, fmt.Errorf("failed to list the Deployments of IstioOperator %s: %s", iop.Name, err)
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := cl.List(context.TODO(), daemonSets, owned); err != nil {
+		return nil, fmt.Errorf("failed to list the DaemonSets of IstioOperator %s: %s", iop.Name, err)
+	}
+	mutating := &admissionv1beta1.MutatingWebhookConfigurationList{}
+	if err := cl.List(context.TODO(), mutating, owned); err != nil {
+		return nil, fmt.Errorf("failed to list the MutatingWebhookConfigurations of IstioOperator %s: %s", iop.Name, err)
+	}
+	validating := &admissionv1beta1.ValidatingWebhookConfigurationList{}
+	if err := cl.List(context.TODO(), validating, owned); err != nil {
+		return nil, fmt.Errorf("failed to list the ValidatingWebhookConfigurations of IstioOperator %s: %s", iop.Name, err)
+	}
+	return componentHealth(iop, deployments.Items, daemonSets.Items, mutating.Items, validating.Items), nil
+}
+
+func componentHealth(iop *valuesv1alpha1.IstioOperator, deployments []appsv1.Deployment, daemonSets []appsv1.DaemonSet,
+	mutating []admissionv1beta1.MutatingWebhookConfiguration, validating []admissionv1beta1.ValidatingWebhookConfiguration) []*ComponentHealth {
+	components := make(map[string]*ComponentHealth)
+	get := func(labels map[string]string) *ComponentHealth {
+		c := componentFromLabel(labels[istioComponentLabelStr])
+		if components[c] == nil {
+			components[c] = &ComponentHealth{Component: c}
+		}
+		return components[c]
+	}
+
+	if iop.Status != nil {
+		for c, vs := range iop.Status.ComponentStatus {
+			if vs == nil {
+				continue
+			}
+			components[c] = &ComponentHealth{Component: c, Status: vs.Status.String(), Error: vs.Error}
+		}
+	}
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		c := get(d.Labels)
+		c.Workloads = append(c.Workloads, WorkloadHealth{
+			Kind:      "Deployment",
+			Namespace: d.Namespace,
+			Name:      d.Name,
+			Version:   workloadVersion(d.Labels, d.Spec.Template.Spec),
+			Ready:     d.Status.ReadyReplicas,
+			Desired:   desired,
+		})
+	}
+	for _, ds := range daemonSets {
+		c := get(ds.Labels)
+		c.Workloads = append(c.Workloads, WorkloadHealth{
+			Kind:      "DaemonSet",
+			Namespace: ds.Namespace,
+			Name:      ds.Name,
+			Version:   workloadVersion(ds.Labels, ds.Spec.Template.Spec),
+			Ready:     ds.Status.NumberReady,
+			Desired:   ds.Status.DesiredNumberScheduled,
+		})
+	}
+	for _, wh := range mutating {
+		h := WebhookHealth{Kind: "MutatingWebhookConfiguration", Name: wh.Name, Webhooks: len(wh.Webhooks)}
+		for _, w := range wh.Webhooks {
+			if len(w.ClientConfig.CABundle) != 0 {
+				h.CABundles++
+			}
+		}
+		c := get(wh.Labels)
+		c.Webhooks = append(c.Webhooks, h)
+	}
+	for _, wh := range validating {
+		h := WebhookHealth{Kind: "ValidatingWebhookConfiguration", Name: wh.Name, Webhooks: len(wh.Webhooks)}
+		for _, w := range wh.Webhooks {
+			if len(w.ClientConfig.CABundle) != 0 {
+				h.CABundles++
+			}
+		}
+		c := get(wh.Labels)
+		c.Webhooks = append(c.Webhooks, h)
+	}
+
+	var out []*ComponentHealth
+	for _, c := range components {
+		sort.Slice(c.Workloads, func(i, j int) bool { return c.Workloads[i].Name < c.Workloads[j].Name })
+		sort.Slice(c.Webhooks, func(i, j int) bool { return c.Webhooks[i].Name < c.Webhooks[j].Name })
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Component < out[j].Component })
+	return out
+}
+
+// componentFromLabel returns the component name of a component label value, which for Pilot also holds the
+// revision, see applyLabelsAndAnnotations.
+func componentFromLabel(label string) string {
+	if strings.HasPrefix(label, string(name.PilotComponentName)+"-") {
+		return string(name.PilotComponentName)
+	}
+	return label
+}
+
+// workloadVersion returns the Istio version label, or the image tag of the first container of spec.
+func workloadVersion(labels map[string]string, spec corev1.PodSpec) string {
+	if v := labels[istioVersionLabelStr]; v != "" {
+		return v
+	}
+	if len(spec.Containers) == 0 {
+		return ""
+	}
+	image := spec.Containers[0].Image
+	if i := strings.LastIndex(image, ":"); i >= 0 && !strings.Contains(image[i:], "/") {
+		return image[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+func TestComponentHealth(t *testing.T) {
+	iop := &valuesv1alpha1.IstioOperator{
+		Status: &v1alpha1.InstallStatus{
+			ComponentStatus: map[string]*v1alpha1.InstallStatus_VersionStatus{
+				"Pilot":           {Status: v1alpha1.InstallStatus_HEALTHY},
+				"IngressGateways": {Status: v1alpha1.InstallStatus_ERROR, Error: "timeout"},
+				"Base":            {Status: v1alpha1.InstallStatus_HEALTHY},
+			},
+		},
+	}
+	two := int32(2)
+	deployments := []appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "istiod", Labels: map[string]string{
+				istioComponentLabelStr: "Pilot-default",
+				istioVersionLabelStr:   "1.6.0",
+			}},
+			Spec:   appsv1.DeploymentSpec{Replicas: &two},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "istio-ingressgateway", Labels: map[string]string{
+				istioComponentLabelStr: "IngressGateways",
+			}},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Image: "localhost:5000/istio/proxyv2:1.5.4"}},
+			}}},
+		},
+	}
+	daemonSets := []appsv1.DaemonSet{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "istio-cni-node", Labels: map[string]string{
+			istioComponentLabelStr: "Cni",
+			istioVersionLabelStr:   "1.6.0",
+		}},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 1},
+	}}
+	mutating := []admissionv1beta1.MutatingWebhookConfiguration{{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector", Labels: map[string]string{istioComponentLabelStr: "Pilot-default"}},
+		Webhooks: []admissionv1beta1.MutatingWebhook{
+			{ClientConfig: admissionv1beta1.WebhookClientConfig{CABundle: []byte("ca")}},
+		},
+	}}
+	validating := []admissionv1beta1.ValidatingWebhookConfiguration{{
+		ObjectMeta: metav1.ObjectMeta{Name: "istiod-istio-system", Labels: map[string]string{istioComponentLabelStr: "Pilot-default"}},
+		Webhooks:   []admissionv1beta1.ValidatingWebhook{{}},
+	}}
+
+	want := []*ComponentHealth{
+		{Component: "Base", Status: "HEALTHY"},
+		{
+			Component: "Cni",
+			Workloads: []WorkloadHealth{{Kind: "DaemonSet", Namespace: "kube-system", Name: "istio-cni-node", Version: "1.6.0", Ready: 1, Desired: 3}},
+		},
+		{
+			Component: "IngressGateways",
+			Status:    "ERROR",
+			Error:     "timeout",
+			Workloads: []WorkloadHealth{{Kind: "Deployment", Namespace: "istio-system", Name: "istio-ingressgateway", Version: "1.5.4", Desired: 1}},
+		},
+		{
+			Component: "Pilot",
+			Status:    "HEALTHY",
+			Workloads: []WorkloadHealth{{Kind: "Deployment", Namespace: "istio-system", Name: "istiod", Version: "1.6.0", Ready: 2, Desired: 2}},
+			Webhooks: []WebhookHealth{
+				{Kind: "MutatingWebhookConfiguration", Name: "istio-sidecar-injector", Webhooks: 1, CABundles: 1},
+				{Kind: "ValidatingWebhookConfiguration", Name: "istiod-istio-system", Webhooks: 1},
+			},
+		},
+	}
+	got := componentHealth(iop, deployments, daemonSets, mutating, validating)
+	if !reflect.DeepEqual(got, want) {
+		for i := range got {
+			t.Logf("got %+v", *got[i])
+		}
+		t.Fatalf("component health mismatch")
+	}
+	if ready, desired := got[3].Ready(); ready != 2 || desired != 2 {
+		t.Errorf("Pilot: got %d/%d ready, want 2/2", ready, desired)
+	}
+	if got, want := got[2].Versions(), []string{"1.5.4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IngressGateways: got versions %v, want %v", got, want)
+	}
+}
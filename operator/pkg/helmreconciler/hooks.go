@@ -0,0 +1,309 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// HookAnnotation is the Helm annotation which marks a rendered object as a hook, to be run at the given
+	// comma separated lifecycle points instead of being applied with the other objects of its component.
+	HookAnnotation = "helm.sh/hook"
+	// HookWeightAnnotation orders hooks which run at the same lifecycle point, lowest weight first.
+	HookWeightAnnotation = "helm.sh/hook-weight"
+	// HookDeletePolicyAnnotation is a comma separated list of hook delete policies.
+	HookDeletePolicyAnnotation = "helm.sh/hook-delete-policy"
+
+	// Lifecycle points at which hooks are run.
+	PreInstallHook  = "pre-install"
+	PostInstallHook = "post-install"
+	PreUpgradeHook  = "pre-upgrade"
+	PostUpgradeHook = "post-upgrade"
+
+	// Hook delete policies. A hook without a delete policy is deleted before it is created again.
+	beforeHookCreationPolicy = "before-hook-creation"
+	hookSucceededPolicy      = "hook-succeeded"
+	hookFailedPolicy         = "hook-failed"
+
+	// defaultHookTimeout is how long to wait for a hook to complete, if Options.HookTimeout is not set.
+	defaultHookTimeout = 5 * time.Minute
+	// hookPollInterval is how often running hooks are checked for completion.
+	hookPollInterval = 2 * time.Second
+)
+
+var hookPoints = map[string]bool{
+	PreInstallHook:  true,
+	PostInstallHook: true,
+	PreUpgradeHook:  true,
+	PostUpgradeHook: true,
+}
+
+// hook is a rendered object which is run at some lifecycle points of an install.
+type hook struct {
+	// component is the name of the component which rendered the hook.
+	component string
+	// points are the lifecycle points the hook runs at.
+	points map[string]bool
+	// weight orders hooks which run at the same point.
+	weight int
+	// deletePolicies are the delete policies of the hook.
+	deletePolicies map[string]bool
+	obj            *object.K8sObject
+}
+
+// splitHooks removes the objects with install or upgrade hook annotations from manifests and returns them as hooks,
+// sorted by weight and then by name. Manifests which have no hooks are returned unchanged. Other Helm hooks, like
+// test hooks, are left in place.
+func splitHooks(manifests ChartManifestsMap) (ChartManifestsMap, []*hook, error) {
+	out := make(ChartManifestsMap)
+	var hooks []*hook
+	for c, ms := range manifests {
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m.Content)
+			if err != nil {
+				return nil, nil, err
+			}
+			var rest object.K8sObjects
+			var found bool
+			for _, o := range objs {
+				h, err := newHook(c, o)
+				if err != nil {
+					return nil, nil, err
+				}
+				if h == nil {
+					rest = append(rest, o)
+					continue
+				}
+				hooks = append(hooks, h)
+				found = true
+			}
+			if found {
+				if m.Content, err = rest.YAMLManifest(); err != nil {
+					return nil, nil, err
+				}
+			}
+			out[c] = append(out[c], m)
+		}
+	}
+	sort.SliceStable(hooks, func(i, j int) bool {
+		if hooks[i].weight != hooks[j].weight {
+			return hooks[i].weight < hooks[j].weight
+		}
+		return hooks[i].obj.Hash() < hooks[j].obj.Hash()
+	})
+	return out, hooks, nil
+}
+
+// newHook returns the hook for o, or nil if o is not an install or upgrade hook.
+func newHook(component string, o *object.K8sObject) (*hook, error) {
+	a := o.UnstructuredObject().GetAnnotations()
+	points := make(map[string]bool)
+	for _, p := range splitList(a[HookAnnotation]) {
+		if hookPoints[p] {
+			points[p] = true
+		}
+	}
+	if len(points) == 0 {
+		return nil, nil
+	}
+	h := &hook{component: component, points: points, deletePolicies: make(map[string]bool), obj: o}
+	if w := a[HookWeightAnnotation]; w != "" {
+		var err error
+		if h.weight, err = strconv.Atoi(w); err != nil {
+			return nil, fmt.Errorf("bad %s annotation on %s: %s", HookWeightAnnotation, o.Hash(), err)
+		}
+	}
+	for _, p := range splitList(a[HookDeletePolicyAnnotation]) {
+		h.deletePolicies[p] = true
+	}
+	if len(h.deletePolicies) == 0 {
+		h.deletePolicies[beforeHookCreationPolicy] = true
+	}
+	return h, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// isUpgrade reports whether the custom resource instance was installed before, that is whether any of the
+// Deployments it owns exist.
+func (h *HelmReconciler) isUpgrade(ctx context.Context) (bool, error) {
+	objects := &unstructured.UnstructuredList{}
+	objects.SetAPIVersion("apps/v1")
+	objects.SetKind("DeploymentList")
+	err := h.client.List(ctx, objects, client.MatchingLabels(h.pruningDetails.GetOwnerLabels()), client.InNamespace(h.iop.Namespace))
+	if err != nil {
+		return false, err
+	}
+	return len(objects.Items) != 0, nil
+}
+
+// runHooks runs the hooks for the given lifecycle point in order. Each hook must complete before the next one
+// starts, and the first hook which fails stops the run.
+func (h *HelmReconciler) runHooks(ctx context.Context, hooks []*hook, point string) error {
+	for _, hk := range hooks {
+		if !hk.points[point] {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		oh := hk.obj.Hash()
+		if h.opts.DryRun {
+			h.opts.Log.LogAndPrintf("Not running %s hook %s of component %s because of dry run.", point, oh, hk.component)
+			continue
+		}
+		h.opts.Log.LogAndPrintf("Running %s hook %s of component %s...", point, oh, hk.component)
+		err := h.runHook(ctx, hk)
+		policy := hookSucceededPolicy
+		if err != nil {
+			policy = hookFailedPolicy
+		}
+		if hk.deletePolicies[policy] {
+			if derr := h.deleteHook(ctx, hk); derr != nil {
+				scope.Warnf("failed to delete hook %s: %s", oh, derr)
+			}
+		}
+		if err != nil {
+			h.opts.Log.LogAndPrintf("✘ %s hook %s failed: %s", point, oh, err)
+			return fmt.Errorf("%s hook %s of component %s failed: %s", point, oh, hk.component, err)
+		}
+		h.opts.Log.LogAndPrintf("✔ %s hook %s completed.", point, oh)
+	}
+	return nil
+}
+
+// runHook creates the hook object and waits for it to complete.
+func (h *HelmReconciler) runHook(ctx context.Context, hk *hook) error {
+	if hk.deletePolicies[beforeHookCreationPolicy] {
+		if err := h.deleteHook(ctx, hk); err != nil {
+			return err
+		}
+	}
+	u := hk.obj.UnstructuredObject().DeepCopy()
+	if err := h.client.Create(ctx, u); err != nil {
+		return err
+	}
+	timeout := h.opts.HookTimeout
+	if timeout == 0 {
+		timeout = defaultHookTimeout
+	}
+	pctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var failure error
+	err := wait.PollImmediateUntil(hookPollInterval, func() (bool, error) {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(u.GroupVersionKind())
+		if err := h.client.Get(ctx, client.ObjectKey{Namespace: u.GetNamespace(), Name: u.GetName()}, live); err != nil {
+			return false, err
+		}
+		done, err := hookDone(live)
+		failure = err
+		return done, nil
+	}, pctx.Done())
+	if err == wait.ErrWaitTimeout {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("timed out after %s waiting for completion", timeout)
+	}
+	if err != nil {
+		return err
+	}
+	return failure
+}
+
+// deleteHook deletes the hook object, if it exists, and waits until it is gone.
+func (h *HelmReconciler) deleteHook(ctx context.Context, hk *hook) error {
+	u := hk.obj.UnstructuredObject().DeepCopy()
+	err := h.client.Delete(ctx, u, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return h.waitForDeletion([]*unstructured.Unstructured{u})
+}
+
+// hookDone reports whether the live hook object o has completed, and returns an error if it failed. Jobs complete
+// when they have a Complete or Failed condition and Pods when they are in the Succeeded or Failed phase. Objects of
+// other kinds complete once they are created.
+func hookDone(o *unstructured.Unstructured) (bool, error) {
+	switch o.GetKind() {
+	case "Job":
+		conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+		for _, c := range conditions {
+			cm, ok := c.(map[string]interface{})
+			if !ok || cm["status"] != "True" {
+				continue
+			}
+			switch cm["type"] {
+			case "Complete":
+				return true, nil
+			case "Failed":
+				return true, fmt.Errorf("job failed: %v", cm["message"])
+			}
+		}
+		return false, nil
+	case "Pod":
+		phase, _, _ := unstructured.NestedString(o.Object, "status", "phase")
+		switch phase {
+		case "Succeeded":
+			return true, nil
+		case "Failed":
+			return true, fmt.Errorf("pod failed")
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// preHook returns the lifecycle point to run hooks at before an install or upgrade.
+func preHook(upgrade bool) string {
+	if upgrade {
+		return PreUpgradeHook
+	}
+	return PreInstallHook
+}
+
+// postHook returns the lifecycle point to run hooks at after an install or upgrade.
+func postHook(upgrade bool) string {
+	if upgrade {
+		return PostUpgradeHook
+	}
+	return PostInstallHook
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/helm/pkg/manifest"
+)
+
+func TestSplitHooks(t *testing.T) {
+	const configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+`
+	const hooks = `apiVersion: batch/v1
+kind: Job
+metadata:
+  annotations:
+    helm.sh/hook: post-install,post-upgrade
+    helm.sh/hook-weight: "5"
+  name: migrate
+  namespace: istio-system
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-delete-policy: hook-succeeded
+  name: check
+  namespace: istio-system
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  annotations:
+    helm.sh/hook: test-success
+  name: test
+  namespace: istio-system
+`
+	manifests := ChartManifestsMap{
+		"Base":  {{Name: "Base", Content: configMap}},
+		"Pilot": {{Name: "Pilot", Content: configMap + "---\n" + hooks}},
+	}
+	got, hks, err := splitHooks(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["Base"][0].Content != configMap {
+		t.Errorf("manifest without hooks changed: %s", got["Base"][0].Content)
+	}
+	pilot := got["Pilot"][0].Content
+	if strings.Contains(pilot, "kind: Job") || !strings.Contains(pilot, "kind: ConfigMap") || !strings.Contains(pilot, "name: test") {
+		t.Errorf("got Pilot manifest %s, want the ConfigMap and the test Pod", pilot)
+	}
+
+	var gotHooks []string
+	for _, h := range hks {
+		gotHooks = append(gotHooks, h.obj.Hash())
+	}
+	wantHooks := []string{"Job:istio-system:check", "Job:istio-system:migrate"}
+	if !reflect.DeepEqual(gotHooks, wantHooks) {
+		t.Errorf("got hooks %v, want %v", gotHooks, wantHooks)
+	}
+	if !hks[0].points[PreUpgradeHook] || !hks[0].deletePolicies[hookSucceededPolicy] || hks[0].deletePolicies[beforeHookCreationPolicy] {
+		t.Errorf("got hook %+v, want pre-upgrade hook deleted on success", hks[0])
+	}
+	if !hks[1].points[PostInstallHook] || !hks[1].points[PostUpgradeHook] || hks[1].weight != 5 || !hks[1].deletePolicies[beforeHookCreationPolicy] {
+		t.Errorf("got hook %+v, want post-install and post-upgrade hook with weight 5", hks[1])
+	}
+}
+
+func TestSplitHooksBadWeight(t *testing.T) {
+	manifests := ChartManifestsMap{"Pilot": {manifest.Manifest{Name: "Pilot", Content: `apiVersion: batch/v1
+kind: Job
+metadata:
+  annotations:
+    helm.sh/hook: pre-install
+    helm.sh/hook-weight: first
+  name: check
+`}}}
+	if _, _, err := splitHooks(manifests); err == nil || !strings.Contains(err.Error(), HookWeightAnnotation) {
+		t.Errorf("got error %v, want bad weight error", err)
+	}
+}
+
+func TestHookDone(t *testing.T) {
+	tests := []struct {
+		desc     string
+		obj      string
+		wantDone bool
+		wantErr  bool
+	}{
+		{
+			desc: "job running",
+			obj: `
+kind: Job
+status:
+  active: 1
+`,
+		},
+		{
+			desc: "job complete",
+			obj: `
+kind: Job
+status:
+  conditions:
+  - type: Complete
+    status: "True"
+`,
+			wantDone: true,
+		},
+		{
+			desc: "job failed",
+			obj: `
+kind: Job
+status:
+  conditions:
+  - type: Failed
+    status: "True"
+    message: BackoffLimitExceeded
+`,
+			wantDone: true,
+			wantErr:  true,
+		},
+		{
+			desc: "pod succeeded",
+			obj: `
+kind: Pod
+status:
+  phase: Succeeded
+`,
+			wantDone: true,
+		},
+		{
+			desc: "pod running",
+			obj: `
+kind: Pod
+status:
+  phase: Running
+`,
+		},
+		{
+			desc:     "other kind",
+			obj:      "kind: ConfigMap",
+			wantDone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(tt.obj), &u.Object); err != nil {
+				t.Fatal(err)
+			}
+			done, err := hookDone(u)
+			if done != tt.wantDone || (err != nil) != tt.wantErr {
+				t.Errorf("got %v, %v, want done %v, error %v", done, err, tt.wantDone, tt.wantErr)
+			}
+		})
+	}
+}
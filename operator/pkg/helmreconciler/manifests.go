@@ -0,0 +1,97 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"istio.io/istio/operator/pkg/manifest"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+// ComponentManifest is what RenderCharts rendered for a component.
+type ComponentManifest struct {
+	// Component is the name of the component.
+	Component name.ComponentName
+	// ChartVersion is the version of the chart the component is rendered from. It is empty for addons rendered by
+	// plugins.
+	ChartVersion string
+	// Manifests are the rendered manifests, one for each instance of the component, like each ingress gateway.
+	Manifests []string
+	// Objects are the objects of Manifests.
+	Objects object.K8sObjects
+	// ReadinessChecks are the custom readiness checks registered for the component, which are run when waiting for
+	// its objects to become ready.
+	ReadinessChecks []manifest.ReadinessCheck
+}
+
+// ComponentManifests are the manifests rendered for all components, sorted by component name.
+type ComponentManifests []*ComponentManifest
+
+// newComponentManifests returns the ComponentManifests of manifests, with the chart versions in versions.
+func newComponentManifests(manifests name.ManifestMap, versions map[name.ComponentName]string) (ComponentManifests, error) {
+	var out ComponentManifests
+	for _, cn := range manifests.SortedComponentNames() {
+		cm := &ComponentManifest{Component: cn, ChartVersion: versions[cn], Manifests: manifests[cn]}
+		for _, m := range manifests[cn] {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			cm.Objects = append(cm.Objects, objs...)
+		}
+		out = append(out, cm)
+	}
+	return out, nil
+}
+
+// ManifestMap returns the manifests of cms by component name.
+func (cms ComponentManifests) ManifestMap() name.ManifestMap {
+	if cms == nil {
+		return nil
+	}
+	out := make(name.ManifestMap)
+	for _, cm := range cms {
+		out[cm.Component] = cm.Manifests
+	}
+	return out
+}
+
+// Objects returns the objects of all components of cms, in component order.
+func (cms ComponentManifests) Objects() object.K8sObjects {
+	var out object.K8sObjects
+	for _, cm := range cms {
+		out = append(out, cm.Objects...)
+	}
+	return out
+}
+
+// ReadinessChecks returns the custom readiness checks of all components of cms, in component order.
+func (cms ComponentManifests) ReadinessChecks() []manifest.ReadinessCheck {
+	var out []manifest.ReadinessCheck
+	for _, cm := range cms {
+		out = append(out, cm.ReadinessChecks...)
+	}
+	return out
+}
+
+// componentReadinessChecks returns the custom readiness checks of component cn in cms.
+func (cms ComponentManifests) componentReadinessChecks(cn name.ComponentName) []manifest.ReadinessCheck {
+	for _, cm := range cms {
+		if cm.Component == cn {
+			return cm.ReadinessChecks
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestNewComponentManifests(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.PilotComponentName: {`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istiod
+  namespace: istio-system
+`},
+		name.IngressComponentName: {`apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+`, `apiVersion: v1
+kind: Service
+metadata:
+  name: ilb-gateway
+  namespace: istio-system
+`},
+		name.CNIComponentName: nil,
+	}
+	versions := map[name.ComponentName]string{name.PilotComponentName: "1.1.0", name.IngressComponentName: "1.2.0"}
+
+	got, err := newComponentManifests(manifests, versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotComponents []name.ComponentName
+	for _, cm := range got {
+		gotComponents = append(gotComponents, cm.Component)
+	}
+	wantComponents := []name.ComponentName{name.CNIComponentName, name.IngressComponentName, name.PilotComponentName}
+	if !reflect.DeepEqual(gotComponents, wantComponents) {
+		t.Errorf("got components %v, want %v", gotComponents, wantComponents)
+	}
+	ingress := got[1]
+	if ingress.ChartVersion != "1.2.0" || len(ingress.Objects) != 2 || ingress.Objects[1].Name != "ilb-gateway" {
+		t.Errorf("got ingress %+v, want chart 1.2.0 with both gateway Services", ingress)
+	}
+	if got[0].ChartVersion != "" || len(got[0].Objects) != 0 {
+		t.Errorf("got CNI %+v, want no chart version or objects", got[0])
+	}
+	if n := len(got.Objects()); n != 3 {
+		t.Errorf("got %d objects, want 3", n)
+	}
+	if !reflect.DeepEqual(got.ManifestMap(), manifests) {
+		t.Errorf("got manifest map %v, want %v", got.ManifestMap(), manifests)
+	}
+
+	if _, err := newComponentManifests(name.ManifestMap{name.PilotComponentName: {"kind: [\n"}}, nil); err == nil {
+		t.Error("got no error for a bad manifest, want error")
+	}
+}
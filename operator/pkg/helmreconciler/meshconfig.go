@@ -0,0 +1,130 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// meshConfigMapName is the name of the ConfigMap holding the mesh config of the default revision.
+	meshConfigMapName = "istio"
+	// meshConfigKey is the key of the mesh config in the istio ConfigMap.
+	meshConfigKey = "mesh"
+)
+
+// meshConfigConflict is a mesh config field which the live istio ConfigMap and the manifest set to different values.
+type meshConfigConflict struct {
+	// Path is the dot separated path of the field in the mesh config.
+	Path string
+	// Live and Rendered are the values of the field in the cluster and in the manifest, which wins.
+	Live     interface{}
+	Rendered interface{}
+}
+
+func (c meshConfigConflict) String() string {
+	return fmt.Sprintf("%s: %v in the cluster, %v in the manifest", c.Path, c.Live, c.Rendered)
+}
+
+// mergesMeshConfig reports whether the mesh config is merged into the live one rather than replacing it.
+func (h *HelmReconciler) mergesMeshConfig() bool {
+	return h.opts.MergeMeshConfig || valuesv1alpha1.MergesMeshConfig(h.iop)
+}
+
+// mergeLiveMeshConfig merges the mesh config of desired, if it is the istio ConfigMap of the revision, over the mesh
+// config of live before desired is applied over live, so that the fields set by other tools are kept. Fields
+// set to different values in both are logged as conflicts. The value of desired wins.
+func (h *HelmReconciler) mergeLiveMeshConfig(live, desired *unstructured.Unstructured) error {
+	if !h.mergesMeshConfig() || !isMeshConfigMap(desired, h.iop.Spec.Revision) {
+		return nil
+	}
+	liveMesh, _, _ := unstructured.NestedString(live.Object, "data", meshConfigKey)
+	desiredMesh, found, _ := unstructured.NestedString(desired.Object, "data", meshConfigKey)
+	if !found || strings.TrimSpace(liveMesh) == "" {
+		return nil
+	}
+	merged, conflicts, err := mergeMeshConfig(liveMesh, desiredMesh)
+	if err != nil {
+		return fmt.Errorf("could not merge the mesh config of ConfigMap %s/%s: %s", desired.GetNamespace(), desired.GetName(), err)
+	}
+	for _, c := range conflicts {
+		h.opts.Log.LogAndPrintf("Mesh config conflict in ConfigMap %s/%s, keeping the manifest value of %s",
+			desired.GetNamespace(), desired.GetName(), c)
+	}
+	return unstructured.SetNestedField(desired.Object, merged, "data", meshConfigKey)
+}
+
+// isMeshConfigMap reports whether obj is the istio ConfigMap of revision.
+func isMeshConfigMap(obj *unstructured.Unstructured, revision string) bool {
+	name := meshConfigMapName
+	if revision != "" {
+		name += "-" + revision
+	}
+	return obj.GetKind() == "ConfigMap" && obj.GetName() == name
+}
+
+// mergeMeshConfig overlays the rendered mesh config YAML over the live one and returns the result, along with the
+// fields set to different values in both, sorted by path.
+func mergeMeshConfig(live, rendered string) (string, []meshConfigConflict, error) {
+	lt, rt := make(map[string]interface{}), make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(live), &lt); err != nil {
+		return "", nil, fmt.Errorf("live mesh config: %s", err)
+	}
+	if err := yaml.Unmarshal([]byte(rendered), &rt); err != nil {
+		return "", nil, fmt.Errorf("rendered mesh config: %s", err)
+	}
+	conflicts := meshConfigConflicts(lt, rt, "")
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Path < conflicts[j].Path
+	})
+	merged, err := util.OverlayYAML(live, rendered)
+	if err != nil {
+		return "", nil, err
+	}
+	return merged, conflicts, nil
+}
+
+// meshConfigConflicts returns the leaf fields under path which are set in both live and rendered to different values.
+func meshConfigConflicts(live, rendered map[string]interface{}, path string) []meshConfigConflict {
+	var out []meshConfigConflict
+	for k, rv := range rendered {
+		lv, ok := live[k]
+		if !ok {
+			continue
+		}
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		lm, lok := lv.(map[string]interface{})
+		rm, rok := rv.(map[string]interface{})
+		switch {
+		case lok && rok:
+			out = append(out, meshConfigConflicts(lm, rm, p)...)
+		case !reflect.DeepEqual(lv, rv):
+			out = append(out, meshConfigConflict{Path: p, Live: lv, Rendered: rv})
+		}
+	}
+	return out
+}
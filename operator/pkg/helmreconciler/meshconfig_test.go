@@ -0,0 +1,95 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMergeMeshConfig(t *testing.T) {
+	tests := []struct {
+		desc          string
+		live          string
+		rendered      string
+		want          string
+		wantConflicts []meshConfigConflict
+	}{
+		{
+			desc:     "keeps live fields",
+			live:     "accessLogFile: /dev/stdout\ndefaultConfig:\n  tracing:\n    sampling: 10\n",
+			rendered: "enableTracing: true\ndefaultConfig:\n  discoveryAddress: istiod:15012\n",
+			want: "accessLogFile: /dev/stdout\nenableTracing: true\ndefaultConfig:\n  discoveryAddress: istiod:15012\n" +
+				"  tracing:\n    sampling: 10\n",
+		},
+		{
+			desc:     "conflicts",
+			live:     "enableTracing: false\ndefaultConfig:\n  concurrency: 4\n  drainDuration: 45s\n",
+			rendered: "enableTracing: true\ndefaultConfig:\n  concurrency: 2\n  drainDuration: 45s\n",
+			want:     "enableTracing: true\ndefaultConfig:\n  concurrency: 2\n  drainDuration: 45s\n",
+			wantConflicts: []meshConfigConflict{
+				{Path: "defaultConfig.concurrency", Live: float64(4), Rendered: float64(2)},
+				{Path: "enableTracing", Live: false, Rendered: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, conflicts, err := mergeMeshConfig(tt.live, tt.rendered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotTree, wantTree := make(map[string]interface{}), make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(got), &gotTree); err != nil {
+				t.Fatal(err)
+			}
+			if err := yaml.Unmarshal([]byte(tt.want), &wantTree); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotTree, wantTree) {
+				t.Errorf("mergeMeshConfig() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(conflicts, tt.wantConflicts) {
+				t.Errorf("mergeMeshConfig() got conflicts %v, want %v", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestIsMeshConfigMap(t *testing.T) {
+	tests := []struct {
+		kind     string
+		name     string
+		revision string
+		want     bool
+	}{
+		{kind: "ConfigMap", name: "istio", want: true},
+		{kind: "ConfigMap", name: "istio-canary", revision: "canary", want: true},
+		{kind: "ConfigMap", name: "istio", revision: "canary", want: false},
+		{kind: "ConfigMap", name: "istio-sidecar-injector", want: false},
+		{kind: "Service", name: "istio", want: false},
+	}
+	for _, tt := range tests {
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(tt.kind)
+		obj.SetName(tt.name)
+		if got := isMeshConfigMap(obj, tt.revision); got != tt.want {
+			t.Errorf("isMeshConfigMap(%s %s, %q) = %v, want %v", tt.kind, tt.name, tt.revision, got, tt.want)
+		}
+	}
+}
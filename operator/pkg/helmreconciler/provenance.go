@@ -0,0 +1,113 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/helm"
+	binversion "istio.io/istio/operator/version"
+	buildversion "istio.io/pkg/version"
+)
+
+// Provenance records which binary, charts and profile an installed-state IstioOperator CR was last applied with, so
+// that it can be told which version manages a revision when several people install into the same cluster.
+type Provenance struct {
+	// Version is the version of the istioctl or operator binary.
+	Version string `json:"version,omitempty"`
+	// GitRevision is the git revision the binary was built from.
+	GitRevision string `json:"gitRevision,omitempty"`
+	// ChartDigest is the digest of the charts the manifests were rendered from, see helm.ChartsDigest.
+	ChartDigest string `json:"chartDigest,omitempty"`
+	// Profile is the profile the IstioOperator was applied with.
+	Profile string `json:"profile,omitempty"`
+	// Time is the time of the apply.
+	Time time.Time `json:"time,omitempty"`
+}
+
+// NewProvenance returns the Provenance of applying iops with this binary now.
+func NewProvenance(iops *v1alpha1.IstioOperatorSpec) (*Provenance, error) {
+	digest, err := helm.ChartsDigest(iops.InstallPackagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the digest of the charts in %q: %s", iops.InstallPackagePath, err)
+	}
+	profile := iops.Profile
+	if profile == "" {
+		profile = "default"
+	}
+	return &Provenance{
+		Version:     binversion.OperatorVersionString,
+		GitRevision: buildversion.Info.GitRevision,
+		ChartDigest: digest,
+		Profile:     profile,
+		Time:        time.Now().UTC(),
+	}, nil
+}
+
+// Annotate sets the annotations recording p on obj.
+func (p *Provenance) Annotate(obj metav1.Object) {
+	a := obj.GetAnnotations()
+	if a == nil {
+		a = make(map[string]string)
+	}
+	a[valuesv1alpha1.InstalledVersionAnnotation] = p.Version
+	a[valuesv1alpha1.InstalledGitRevisionAnnotation] = p.GitRevision
+	a[valuesv1alpha1.ChartDigestAnnotation] = p.ChartDigest
+	a[valuesv1alpha1.InstalledProfileAnnotation] = p.Profile
+	a[valuesv1alpha1.InstalledAtAnnotation] = p.Time.Format(time.RFC3339)
+	obj.SetAnnotations(a)
+}
+
+// ReadProvenance returns the Provenance recorded in the annotations of obj. Fields which are not recorded, e.g.
+// because obj was applied by an older binary, are left empty.
+func ReadProvenance(obj metav1.Object) *Provenance {
+	a := obj.GetAnnotations()
+	p := &Provenance{
+		Version:     a[valuesv1alpha1.InstalledVersionAnnotation],
+		GitRevision: a[valuesv1alpha1.InstalledGitRevisionAnnotation],
+		ChartDigest: a[valuesv1alpha1.ChartDigestAnnotation],
+		Profile:     a[valuesv1alpha1.InstalledProfileAnnotation],
+	}
+	if t, err := time.Parse(time.RFC3339, a[valuesv1alpha1.InstalledAtAnnotation]); err == nil {
+		p.Time = t
+	}
+	return p
+}
+
+// InstalledStates returns the installed-state IstioOperator CRs in namespace, i.e. the CRs whose names start with
+// prefix, sorted by name.
+func InstalledStates(cl client.Reader, namespace, prefix string) ([]*valuesv1alpha1.IstioOperator, error) {
+	iops := &valuesv1alpha1.IstioOperatorList{}
+	if err := cl.List(context.TODO(), iops, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list IstioOperator CRs in namespace %s: %s", namespace, err)
+	}
+	var out []*valuesv1alpha1.IstioOperator
+	for i := range iops.Items {
+		if strings.HasPrefix(iops.Items[i].Name, prefix) {
+			out = append(out, &iops.Items[i])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
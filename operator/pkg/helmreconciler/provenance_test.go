@@ -0,0 +1,48 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+func TestProvenanceRoundTrip(t *testing.T) {
+	want := &Provenance{
+		Version:     "1.6.0",
+		GitRevision: "abc123",
+		ChartDigest: "sha256:0123",
+		Profile:     "demo",
+		Time:        time.Date(2020, 5, 1, 12, 30, 0, 0, time.UTC),
+	}
+	iop := &valuesv1alpha1.IstioOperator{}
+	iop.SetAnnotations(map[string]string{valuesv1alpha1.PausedAnnotation: "true"})
+	want.Annotate(iop)
+	if got := ReadProvenance(iop); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if !valuesv1alpha1.IsPaused(iop) {
+		t.Error("Annotate removed the existing annotations")
+	}
+}
+
+func TestReadProvenanceUnrecorded(t *testing.T) {
+	if got, want := ReadProvenance(&valuesv1alpha1.IstioOperator{}), (&Provenance{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
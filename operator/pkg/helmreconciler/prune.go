@@ -96,6 +96,18 @@ var (
 		// Cannot currently prune CRDs because this will also wipe out user config.
 		// {Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
 	}
+
+	// crdResources are only deleted on request when an IstioOperator CR is deleted, since this also deletes all Istio
+	// config.
+	crdResources = []schema.GroupVersionKind{
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
+	}
+
+	// webhookKinds are the kinds of the webhook configurations, which are deleted before anything else on teardown.
+	webhookKinds = map[string]bool{
+		"MutatingWebhookConfiguration":   true,
+		"ValidatingWebhookConfiguration": true,
+	}
 )
 
 // NewPruningDetails creates a new PruningDetails object specific to the instance.
@@ -124,8 +136,29 @@ func (h *HelmReconciler) Prune(excluded map[string]bool, all bool) error {
 	return err
 }
 
+// deletionSteps returns the resource types to delete on teardown, in groups which are deleted one after the other:
+// first the webhook configurations, so that pods are no longer injected and config is no longer validated by a
+// control plane which is going away, then the namespaced resources including the workloads, then the remaining
+// cluster scoped resources and finally, if deleteCRDs is set, the CRDs.
+func deletionSteps(namespaced, cluster []schema.GroupVersionKind, deleteCRDs bool) [][]schema.GroupVersionKind {
+	var webhooks, others []schema.GroupVersionKind
+	for _, gvk := range cluster {
+		if webhookKinds[gvk.Kind] {
+			webhooks = append(webhooks, gvk)
+		} else {
+			others = append(others, gvk)
+		}
+	}
+	steps := [][]schema.GroupVersionKind{webhooks, namespaced, others}
+	if deleteCRDs {
+		steps = append(steps, crdResources)
+	}
+	return steps
+}
+
 func (h *HelmReconciler) PruneUnlistedResources(gvks []schema.GroupVersionKind, excluded map[string]bool, all bool, namespace string) error {
 	allErrors := []error{}
+	var deleted []*unstructured.Unstructured
 	ownerLabels := h.pruningDetails.GetOwnerLabels()
 	for _, gvk := range gvks {
 		objects := &unstructured.UnstructuredList{}
@@ -141,6 +174,10 @@ func (h *HelmReconciler) PruneUnlistedResources(gvks []schema.GroupVersionKind,
 			if excluded[oh] && !all {
 				continue
 			}
+			if isProtected(&o) {
+				h.opts.Log.LogAndPrintf("Not pruning object %s because it has the %s annotation.", oh, v1alpha1.IgnoreAnnotation)
+				continue
+			}
 			if h.opts.DryRun {
 				h.opts.Log.LogAndPrintf("Not pruning object %s because of dry run.", oh)
 				continue
@@ -149,10 +186,19 @@ func (h *HelmReconciler) PruneUnlistedResources(gvks []schema.GroupVersionKind,
 			err = h.client.Delete(context.TODO(), &o, client.PropagationPolicy(metav1.DeletePropagationBackground))
 			if err != nil {
 				allErrors = append(allErrors, err)
+				continue
 			}
+			deleted = append(deleted, o.DeepCopy())
 			h.opts.Log.LogAndPrintf("Pruned object %s.", oh)
+			h.recordAction(componentFromLabel(o.GetLabels()[istioComponentLabelStr]), &o, ObjectPruned)
 
 		}
 	}
+	// Objects with finalizers linger after deletion, so only report success once they are gone.
+	if len(deleted) != 0 {
+		if err := h.waitForDeletion(deleted); err != nil {
+			allErrors = append(allErrors, err)
+		}
+	}
 	return utilerrors.NewAggregate(allErrors)
 }
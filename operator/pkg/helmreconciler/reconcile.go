@@ -16,10 +16,14 @@ package helmreconciler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/ghodss/yaml"
+	"go.opencensus.io/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -31,46 +35,22 @@ import (
 	"istio.io/istio/operator/pkg/manifest"
 	"istio.io/istio/operator/pkg/name"
 	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/policy"
+	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/util/clog"
+	"istio.io/istio/operator/pkg/validate"
 )
 
-type componentNameToListMap map[name.ComponentName][]name.ComponentName
-type componentTree map[name.ComponentName]interface{}
-
 const (
 	// Time to wait for internal dependencies before proceeding to installing the next component.
 	internalDepTimeout = 10 * time.Minute
+	// effectiveSpecStatusField is the field under the IstioOperator status holding the spec the reconciler acted on.
+	// It is not part of the typed InstallStatus.
+	effectiveSpecStatusField = "effectiveSpec"
+	// iopCRDName is the name of the IstioOperator CRD.
+	iopCRDName = "istiooperators.install.istio.io"
 )
 
-var (
-	componentDependencies = componentNameToListMap{
-		name.PilotComponentName: {
-			name.PolicyComponentName,
-			name.TelemetryComponentName,
-			name.CNIComponentName,
-			name.IngressComponentName,
-			name.EgressComponentName,
-			name.AddonComponentName,
-		},
-		name.IstioBaseComponentName: {
-			name.PilotComponentName,
-		},
-	}
-
-	installTree      = make(componentTree)
-	dependencyWaitCh = make(map[name.ComponentName]chan struct{})
-)
-
-func init() {
-	buildInstallTree()
-	for _, parent := range componentDependencies {
-		for _, child := range parent {
-			dependencyWaitCh[child] = make(chan struct{}, 1)
-		}
-	}
-
-}
-
 // HelmReconciler reconciles resources rendered by a set of helm charts.
 type HelmReconciler struct {
 	client             client.Client
@@ -80,8 +60,18 @@ type HelmReconciler struct {
 	pruningDetails     PruningDetails
 	opts               *Options
 	needUpdateAndPrune bool
+	// retainFields are the fields of live objects kept on update.
+	retainFields []retainField
 	// copy of the last generated manifests.
 	manifests name.ManifestMap
+	// componentManifests are the last generated manifests with the component metadata.
+	componentManifests ComponentManifests
+	// resourceErrors are the resources which failed to apply in the last reconcile.
+	resourceErrors   []ResourceError
+	resourceErrorsMu sync.Mutex
+	// appliedObjects are the objects the last reconcile acted on, see ApplyReport.
+	appliedObjects   []AppliedObject
+	appliedObjectsMu sync.Mutex
 }
 
 // Options are options for HelmReconciler.
@@ -90,6 +80,58 @@ type Options struct {
 	DryRun bool
 	// Log is a console logger for user visible CLI output.
 	Log clog.Logger
+	// Verbose logs what is done to each object, like kubectl apply, e.g. deployment.apps/istiod configured.
+	Verbose bool
+	// Checkpoints maps component names to checksums of manifests from a previous install, as returned by
+	// ReadCheckpoints. Components with matching rendered manifests are not applied again.
+	Checkpoints map[string]string
+	// Targets, if set, are the only components which are applied. The other components are left as they are in the
+	// cluster, see UntargetedChanges, and nothing is pruned.
+	Targets map[name.ComponentName]bool
+	// RetainFields are fields of the form Kind:path, e.g. Service:spec.loadBalancerIP, whose live values are kept when
+	// objects are updated unless the manifest sets them, in addition to runtime managed fields like Service clusterIP
+	// and nodePorts.
+	RetainFields []string
+	// SchemaValidator, if set, validates the rendered manifests before anything is applied. No changes are made to
+	// the cluster if any object is invalid.
+	SchemaValidator *validate.SchemaValidator
+	// PolicyChecker, if set, checks the rendered manifests against user policies before anything is applied.
+	PolicyChecker *policy.Checker
+	// CRDs is one of the manifest CRD modes, selecting whether CRDs are applied with all other objects, which is the
+	// default, or only CRDs or all objects except CRDs are applied. Nothing is pruned unless all objects are applied.
+	CRDs string
+	// Namespaced selects namespaced mode, in which the cluster scoped resources, like CRDs, ClusterRoles and webhook
+	// configurations, are neither applied nor pruned, since they are provisioned by a cluster admin.
+	Namespaced bool
+	// DeletionTimeout is how long to wait for pruned objects to be removed from the cluster. Defaults to 2 minutes.
+	DeletionTimeout time.Duration
+	// Dependencies is the order in which components are applied. Defaults to DefaultDependencies. Dependencies
+	// declared through the depends-on annotation of the IstioOperator CR are added to these.
+	Dependencies ComponentDependencies
+	// HookTimeout is how long to wait for each install and upgrade hook to complete. Defaults to 5 minutes.
+	HookTimeout time.Duration
+	// ReconcilePolicies are the retries and failure actions of components. Defaults to DefaultReconcilePolicies.
+	// Policies set through the reconcile-policy annotation of the IstioOperator CR override these.
+	ReconcilePolicies ReconcilePolicies
+	// MergeMeshConfig merges the rendered mesh config into the one in the live istio ConfigMap, keeping the fields
+	// set by other tools, rather than replacing it. It is also set through the merge-mesh-config annotation of the
+	// IstioOperator CR.
+	MergeMeshConfig bool
+	// ApplyOrder is the order in which the objects of each component are applied, and deleted in reverse. Defaults to
+	// object.DefaultApplyOrderKinds. It is overridden through the apply-order annotation of the IstioOperator CR.
+	ApplyOrder object.ApplyOrder
+	// ApplyBatchSize is how many objects of a component are applied concurrently, in batches of objects at the same
+	// position in the apply order. Defaults to 20. Set it to 1 to apply one object at a time.
+	ApplyBatchSize int
+	// ServerSideApply applies objects with server-side apply as FieldManager rather than updating them, so that fields
+	// owned by other field managers, like the replicas of an HPA, are reported as conflicts instead of overwritten.
+	ServerSideApply bool
+	// Conflicts is one of the ConflictPolicies, selecting whether objects with field conflicts under server-side apply
+	// fail, which is the default, are left unchanged or have the fields taken over.
+	Conflicts string
+	// DiscoveryTimeout is how long to wait for applied CRDs to be established, and for objects whose kinds are not
+	// yet served to be accepted, before they fail with no matches for kind. Defaults to 1 minute.
+	DiscoveryTimeout time.Duration
 }
 
 var defaultOptions = &Options{Log: clog.NewDefaultLogger()}
@@ -107,40 +149,139 @@ func NewHelmReconciler(client client.Client, restConfig *rest.Config, iop *value
 	if err != nil {
 		return nil, err
 	}
+	retainFields, err := parseRetainFields(opts.RetainFields)
+	if err != nil {
+		return nil, err
+	}
+	pruningDetails := NewIstioPruningDetails(iop)
+	if opts.Namespaced {
+		pruningDetails = &SimplePruningDetails{
+			OwnerLabels:         pruningDetails.GetOwnerLabels(),
+			NamespacedResources: namespacedResources,
+		}
+	}
 	return &HelmReconciler{
 		client:             client,
 		restConfig:         restConfig,
 		clientSet:          cs,
 		iop:                iop,
-		pruningDetails:     NewIstioPruningDetails(iop),
+		pruningDetails:     pruningDetails,
 		opts:               opts,
 		needUpdateAndPrune: true,
+		retainFields:       append(defaultRetainFields[:len(defaultRetainFields):len(defaultRetainFields)], retainFields...),
 	}, nil
 }
 
 // Reconcile reconciles the associated resources.
 func (h *HelmReconciler) Reconcile() (*v1alpha1.InstallStatus, error) {
+	return h.ReconcileContext(context.Background())
+}
+
+// ReconcileContext is like Reconcile, but stops starting new components once ctx is done. Components that were not
+// installed because of this are reported with ERROR status and ctx.Err() is returned along with the partial status.
+// Pruning is skipped in that case.
+func (h *HelmReconciler) ReconcileContext(ctx context.Context) (status *v1alpha1.InstallStatus, err error) {
+	ctx, span := h.startReconcileSpan(ctx)
+	defer func() { endSpan(span, err) }()
+	h.resourceErrorsMu.Lock()
+	h.resourceErrors = nil
+	h.resourceErrorsMu.Unlock()
+	h.appliedObjectsMu.Lock()
+	h.appliedObjects = nil
+	h.appliedObjectsMu.Unlock()
+
+	_, renderSpan := startSpan(ctx, "render")
 	manifestMap, err := h.RenderCharts()
+	endSpan(renderSpan, err)
+	if err != nil {
+		return nil, err
+	}
+	if valuesv1alpha1.IsUserGateway(h.iop) {
+		if err := checkGatewayControlPlane(h.client, h.iop); err != nil {
+			return nil, err
+		}
+	}
+	if h.opts.SchemaValidator != nil {
+		if errs := h.opts.SchemaValidator.ValidateManifests(h.manifests); len(errs) != 0 {
+			return nil, fmt.Errorf("rendered manifests failed schema validation:\n%s", errs)
+		}
+	}
+	if h.opts.PolicyChecker != nil {
+		if errs := h.opts.PolicyChecker.CheckManifests(h.manifests); len(errs) != 0 {
+			return nil, fmt.Errorf("rendered manifests violate policies:\n%s", errs)
+		}
+	}
+
+	manifestMap, hooks, err := splitHooks(manifestMap)
 	if err != nil {
 		return nil, err
 	}
+	var upgrade bool
+	if len(hooks) != 0 {
+		if upgrade, err = h.isUpgrade(ctx); err != nil {
+			return nil, err
+		}
+		if err := h.runHooks(ctx, hooks, preHook(upgrade)); err != nil {
+			return nil, err
+		}
+	}
 
-	status := h.processRecursive(manifestMap)
+	status = h.processRecursive(ctx, manifestMap)
+	if ctx.Err() != nil {
+		return status, ctx.Err()
+	}
+	// Like Helm, post hooks only run if everything was installed.
+	if status.Status != v1alpha1.InstallStatus_ERROR {
+		if err := h.runHooks(ctx, hooks, postHook(upgrade)); err != nil {
+			status.Status = v1alpha1.InstallStatus_ERROR
+			return status, err
+		}
+	}
 
 	// Delete any resources not in the manifest but managed by operator.
-	if h.needUpdateAndPrune {
+	if h.needUpdateAndPrune && (h.opts.CRDs == "" || h.opts.CRDs == manifest.IncludeCRDs) && len(h.opts.Targets) == 0 {
+		_, pruneSpan := startSpan(ctx, "prune")
 		err = h.Prune(allObjectHashes(manifestMap), false)
+		endSpan(pruneSpan, err)
 	}
 
 	return status, err
 }
 
-// processRecursive processes the given manifests in an order of dependencies defined in h. Dependencies are a tree,
-// where a child must wait for the parent to complete before starting.
-func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha1.InstallStatus {
+// processRecursive processes the given manifests in the order of the component dependencies of h, where a component
+// must wait for all of its dependencies to complete before starting. Dependencies on components which are not in
+// manifests are ignored. Components which fail are retried as set in their reconcile policy. Once ctx is done, or a
+// component whose policy is to abort has failed, components that have not started yet are skipped and marked as ERROR.
+func (h *HelmReconciler) processRecursive(ctx context.Context, manifests ChartManifestsMap) *v1alpha1.InstallStatus {
 	componentStatus := make(map[string]*v1alpha1.InstallStatus_VersionStatus)
+	deps, err := h.dependencies()
+	var policies ReconcilePolicies
+	if err == nil {
+		policies, err = h.reconcilePolicies()
+	}
+	if err != nil {
+		for c := range manifests {
+			setStatus(componentStatus, c, v1alpha1.InstallStatus_ERROR, err)
+		}
+		return &v1alpha1.InstallStatus{
+			Status:          v1alpha1.InstallStatus_ERROR,
+			ComponentStatus: componentStatus,
+		}
+	}
+	dependents := deps.Dependents()
+	// done is closed once the component has been processed.
+	done := make(map[name.ComponentName]chan struct{})
+	for c := range manifests {
+		done[name.ComponentName(c)] = make(chan struct{})
+	}
+
+	// abort cancels the components which have not started yet once a component whose policy is to abort has failed.
+	ctx, abort := context.WithCancel(ctx)
+	defer abort()
+	// abortedBy is the component which caused the abort.
+	var abortedBy string
 
-	// mu protects the shared InstallStatus componentStatus across goroutines
+	// mu protects the shared InstallStatus componentStatus and abortedBy across goroutines
 	var mu sync.Mutex
 	// wg waits for all manifest processing goroutines to finish
 	var wg sync.WaitGroup
@@ -152,10 +293,12 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha1
 			var processedObjs object.K8sObjects
 			defer wg.Done()
 			cn := name.ComponentName(c)
-			if s := dependencyWaitCh[cn]; s != nil {
-				scope.Infof("%s is waiting on dependency...", c)
-				<-s
-				scope.Infof("Dependency for %s has completed, proceeding.", c)
+			for _, dc := range deps[cn] {
+				if s := done[dc]; s != nil {
+					scope.Infof("%s is waiting on dependency %s...", c, dc)
+					<-s
+					scope.Infof("Dependency %s for %s has completed, proceeding.", dc, c)
+				}
 			}
 
 			// Possible paths for status are RECONCILING -> {NONE, ERROR, HEALTHY}. NONE means component has no resources.
@@ -167,10 +310,39 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha1
 			status := v1alpha1.InstallStatus_NONE
 			var err error
 			if len(m) != 0 {
-				if processedObjs, err = h.ProcessManifest(m); err != nil {
+				rp := policies.Get(cn)
+				if ctx.Err() != nil {
+					mu.Lock()
+					if abortedBy != "" {
+						err = fmt.Errorf("not installed: component %s failed", abortedBy)
+					} else {
+						err = fmt.Errorf("not installed: %s", ctx.Err())
+					}
+					mu.Unlock()
 					status = v1alpha1.InstallStatus_ERROR
-				} else if len(processedObjs) != 0 {
+				} else if !h.isTarget(c) {
+					h.opts.Log.LogAndPrintf("- Skipping component %s, it is not a target.", c)
+					status = v1alpha1.InstallStatus_HEALTHY
+				} else if h.opts.Checkpoints[c] == manifestsChecksum(m) {
+					h.opts.Log.LogAndPrintf("- Skipping component %s, it is unchanged since it was last installed.", c)
 					status = v1alpha1.InstallStatus_HEALTHY
+				} else {
+					_, applySpan := startSpan(ctx, "apply", trace.StringAttribute("component", c))
+					if processedObjs, err = h.processManifestWithRetries(ctx, c, m, rp); err != nil {
+						status = v1alpha1.InstallStatus_ERROR
+					} else if len(processedObjs) != 0 {
+						status = v1alpha1.InstallStatus_HEALTHY
+					}
+					endSpan(applySpan, err)
+					if err != nil && rp.FailureAction == FailureActionAbort {
+						mu.Lock()
+						if abortedBy == "" {
+							abortedBy = c
+							h.opts.Log.LogAndPrintf("✘ Component %s failed, skipping the components which have not started.", c)
+						}
+						mu.Unlock()
+						abort()
+					}
 				}
 			}
 
@@ -180,17 +352,21 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha1
 
 			// If we are depending on a component, we may depend on it actually running (eg Deployment is ready)
 			// For example, for the validation webhook to become ready, so we should wait for it always.
-			if err == nil && len(componentDependencies[cn]) > 0 {
-				if err := manifest.WaitForResources(processedObjs, h.clientSet, internalDepTimeout, h.opts.DryRun, h.opts.Log); err != nil {
+			if err == nil && len(dependents[cn]) > 0 {
+				waitCtx, waitSpan := startSpan(ctx, "wait", trace.StringAttribute("component", c))
+				err := manifest.WaitForResourcesContext(waitCtx, processedObjs, h.clientSet, internalDepTimeout, h.opts.DryRun, h.opts.Log,
+					h.componentManifests.componentReadinessChecks(cn)...)
+				if err != nil {
 					scope.Errorf("Failed to wait for resource: %v", err)
 				}
+				endSpan(waitSpan, err)
 			}
 
 			// Signal all the components that depend on us.
-			for _, ch := range componentDependencies[cn] {
+			for _, ch := range dependents[cn] {
 				scope.Infof("Unblocking dependency %s.", ch)
-				dependencyWaitCh[ch] <- struct{}{}
 			}
+			close(done[cn])
 		}()
 	}
 	wg.Wait()
@@ -203,10 +379,45 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha1
 	return out
 }
 
-// Delete resources associated with the custom resource instance
+// reconcilePolicies returns the reconcile policies for the custom resource instance.
+func (h *HelmReconciler) reconcilePolicies() (ReconcilePolicies, error) {
+	policies := h.opts.ReconcilePolicies
+	if policies == nil {
+		policies = DefaultReconcilePolicies()
+	}
+	return ReconcilePoliciesForIOP(policies, h.iop)
+}
+
+// dependencies returns the component dependencies for the custom resource instance.
+func (h *HelmReconciler) dependencies() (ComponentDependencies, error) {
+	deps := h.opts.Dependencies
+	if deps == nil {
+		deps = DefaultDependencies()
+	}
+	return DependenciesForIOP(deps, h.iop)
+}
+
+// Delete resources associated with the custom resource instance, in the order given by deletionSteps and, within
+// each step, the reverse apply order. Each step waits for its resources to be removed before the next one starts.
+// CRDs are only deleted if the custom resource has the delete-crds annotation.
 func (h *HelmReconciler) Delete() error {
 	h.needUpdateAndPrune = true
-	return h.Prune(nil, true)
+	defer FlushObjectCaches()
+	namespacedResources, clusterResources := h.pruningDetails.GetResourceTypes()
+	order, err := h.applyOrder()
+	if err != nil {
+		return err
+	}
+	// The IstioOperator CRD is kept, since its removal would wait for the deletion of the custom resource, which in
+	// turn waits for this teardown.
+	excluded := map[string]bool{object.Hash("CustomResourceDefinition", "", iopCRDName): true}
+	steps := deletionSteps(order.DeletionOrder(namespacedResources), order.DeletionOrder(clusterResources), valuesv1alpha1.DeletesCRDs(h.iop))
+	for _, gvks := range steps {
+		if err := h.PruneUnlistedResources(gvks, excluded, false, h.iop.Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetStatusBegin updates the status field on the IstioOperator instance before reconciling.
@@ -234,21 +445,96 @@ func (h *HelmReconciler) SetStatusBegin() error {
 		}
 		isop.Status.Status = v1alpha1.InstallStatus_RECONCILING
 	}
-	return h.GetClient().Status().Update(context.TODO(), isop)
+	return h.updateStatus(isop.Status, nil)
 }
 
-// SetStatusComplete updates the status field on the IstioOperator instance based on the resulting err parameter.
+// SetStatusComplete updates the status field on the IstioOperator instance based on the resulting err parameter,
+// along with the resources which failed to apply, the actions on the applied objects and the effective spec, in a
+// single status update.
 func (h *HelmReconciler) SetStatusComplete(status *v1alpha1.InstallStatus) error {
-	iop := &valuesv1alpha1.IstioOperator{}
+	effectiveSpec, err := h.effectiveSpecStatus()
+	if err != nil {
+		return err
+	}
+	return h.updateStatus(status, map[string]interface{}{
+		resourceErrorsStatusField: h.resourceErrorsStatus(),
+		lastApplyStatusField:      h.applyReportStatus(),
+		effectiveSpecStatusField:  effectiveSpec,
+	})
+}
+
+// effectiveSpecStatus returns the spec the reconciler acted on, i.e. the CR spec merged with its profile and
+// defaults, for status.effectiveSpec, or nil if there is none.
+func (h *HelmReconciler) effectiveSpecStatus() (interface{}, error) {
+	if h.iop.Spec == nil {
+		return nil, nil
+	}
+	specYAML, err := util.MarshalWithJSONPB(h.iop.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal effective IstioOperator spec: %s", err)
+	}
+	spec := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(specYAML), &spec); err != nil {
+		return nil, fmt.Errorf("failed to marshal effective IstioOperator spec: %s", err)
+	}
+	return spec, nil
+}
+
+// updateStatus writes status into the status of the IstioOperator instance together with the fields in extra, which
+// are not part of the typed InstallStatus, in a single update. A nil value in extra deletes the field. The other
+// fields which are not part of InstallStatus, like the checkpoints and conditions, are kept, which a typed update
+// would drop.
+func (h *HelmReconciler) updateStatus(status *v1alpha1.InstallStatus, extra map[string]interface{}) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(valuesv1alpha1.IstioOperatorGVK)
 	namespacedName := types.NamespacedName{
 		Name:      h.iop.Name,
 		Namespace: h.iop.Namespace,
 	}
-	if err := h.GetClient().Get(context.TODO(), namespacedName, iop); err != nil {
+	if err := h.GetClient().Get(context.TODO(), namespacedName, u); err != nil {
 		return fmt.Errorf("failed to get IstioOperator before updating status due to %v", err)
 	}
-	iop.Status = status
-	return h.GetClient().Status().Update(context.TODO(), iop)
+	st, _, err := unstructured.NestedMap(u.Object, "status")
+	if err != nil {
+		return fmt.Errorf("bad status in IstioOperator %s/%s: %s", h.iop.Namespace, h.iop.Name, err)
+	}
+	if st == nil {
+		st = make(map[string]interface{})
+	}
+	for _, f := range valuesv1alpha1.InstallStatusFields {
+		delete(st, f)
+	}
+	if status != nil {
+		var typed map[string]interface{}
+		if err := toStatusValue(status, &typed); err != nil {
+			return err
+		}
+		for k, v := range typed {
+			st[k] = v
+		}
+	}
+	for k, v := range extra {
+		if v == nil {
+			delete(st, k)
+			continue
+		}
+		var sv interface{}
+		if err := toStatusValue(v, &sv); err != nil {
+			return err
+		}
+		st[k] = sv
+	}
+	u.Object["status"] = st
+	return h.GetClient().Status().Update(context.TODO(), u)
+}
+
+// toStatusValue sets out to v converted through JSON to the plain maps, slices and values of an unstructured object.
+func toStatusValue(v, out interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal IstioOperator status: %s", err)
+	}
+	return json.Unmarshal(b, out)
 }
 
 // setStatus sets the status for the component with the given name, which is a key in the given map.
@@ -312,15 +598,3 @@ func allObjectHashes(cmm ChartManifestsMap) map[string]bool {
 func (h *HelmReconciler) GetClient() client.Client {
 	return h.client
 }
-
-func buildInstallTree() {
-	// Starting with root, recursively insert each first level child into each node.
-	insertChildrenRecursive(name.IstioBaseComponentName, installTree, componentDependencies)
-}
-
-func insertChildrenRecursive(componentName name.ComponentName, tree componentTree, children componentNameToListMap) {
-	tree[componentName] = make(componentTree)
-	for _, child := range children[componentName] {
-		insertChildrenRecursive(child, tree[componentName].(componentTree), children)
-	}
-}
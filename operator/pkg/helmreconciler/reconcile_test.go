@@ -0,0 +1,95 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+func TestEffectiveSpecSurvivesReconcile(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := valuesv1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	iop := &valuesv1alpha1.IstioOperator{
+		Kind:       "IstioOperator",
+		ApiVersion: "install.istio.io/v1alpha1",
+		ObjectMeta: metav1.ObjectMeta{Name: "installed-state", Namespace: "istio-system"},
+		Spec:       &v1alpha1.IstioOperatorSpec{Hub: "docker.io/istio"},
+	}
+	cl := fake.NewFakeClientWithScheme(s, iop.DeepCopyObject())
+	h, err := NewHelmReconciler(cl, nil, iop, &Options{Log: clog.NewConsoleLogger(false, ioutil.Discard, ioutil.Discard)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	healthy := &v1alpha1.InstallStatus{
+		Status: v1alpha1.InstallStatus_HEALTHY,
+		ComponentStatus: map[string]*v1alpha1.InstallStatus_VersionStatus{
+			"Pilot": {Status: v1alpha1.InstallStatus_HEALTHY},
+		},
+	}
+
+	check := func(step string, wantStatus v1alpha1.InstallStatus_Status) {
+		t.Helper()
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(valuesv1alpha1.IstioOperatorGVK)
+		if err := cl.Get(context.TODO(), types.NamespacedName{Name: iop.Name, Namespace: iop.Namespace}, u); err != nil {
+			t.Fatal(err)
+		}
+		if got, _, _ := unstructured.NestedString(u.Object, "status", effectiveSpecStatusField, "hub"); got != "docker.io/istio" {
+			t.Errorf("%s: got effective spec hub %q, want docker.io/istio", step, got)
+		}
+		if _, ok, _ := unstructured.NestedFieldNoCopy(u.Object, "status", lastApplyStatusField); !ok {
+			t.Errorf("%s: got no apply report in status", step)
+		}
+		got := &valuesv1alpha1.IstioOperator{}
+		if err := cl.Get(context.TODO(), types.NamespacedName{Name: iop.Name, Namespace: iop.Namespace}, got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Status == nil || got.Status.Status != wantStatus {
+			t.Errorf("%s: got status %v, want %s", step, got.Status, wantStatus)
+			return
+		}
+		if cs := got.Status.ComponentStatus["Pilot"]; cs == nil || cs.Status != wantStatus {
+			t.Errorf("%s: got Pilot status %v, want %s", step, cs, wantStatus)
+		}
+	}
+
+	if err := h.SetStatusComplete(healthy); err != nil {
+		t.Fatal(err)
+	}
+	check("first reconcile", v1alpha1.InstallStatus_HEALTHY)
+	// The status update at the start of the next reconcile must keep the fields which are not part of InstallStatus.
+	if err := h.SetStatusBegin(); err != nil {
+		t.Fatal(err)
+	}
+	check("next reconcile begins", v1alpha1.InstallStatus_RECONCILING)
+	if err := h.SetStatusComplete(healthy); err != nil {
+		t.Fatal(err)
+	}
+	check("next reconcile completes", v1alpha1.InstallStatus_HEALTHY)
+}
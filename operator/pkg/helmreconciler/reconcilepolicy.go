@@ -0,0 +1,162 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/helm/pkg/manifest"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+// FailureAction is what happens to the rest of a reconcile when a component fails to apply.
+type FailureAction string
+
+const (
+	// FailureActionAbort skips all components which have not started yet, so that a broken core component fails the
+	// reconcile fast.
+	FailureActionAbort FailureAction = "abort"
+	// FailureActionContinue applies the remaining components regardless, so that an optional component does not block
+	// the others.
+	FailureActionContinue FailureAction = "continue"
+
+	// defaultRetryBackoff is the wait before the first retry of a component with retries.
+	defaultRetryBackoff = 5 * time.Second
+)
+
+// ReconcilePolicy controls how the resources of a component are applied.
+type ReconcilePolicy struct {
+	// Retries is how many more times applying the component is attempted after it fails.
+	Retries int
+	// Backoff is the wait before the first retry, which doubles for each further retry. Defaults to 5 seconds.
+	Backoff time.Duration
+	// FailureAction is what happens to the remaining components if the component still fails after its retries.
+	FailureAction FailureAction
+}
+
+// reconcilePolicyJSON is the ReconcilePolicy of a component in the reconcile-policy annotation. Unset fields keep
+// their default.
+type reconcilePolicyJSON struct {
+	Retries       *int   `json:"retries,omitempty"`
+	Backoff       string `json:"backoff,omitempty"`
+	FailureAction string `json:"failureAction,omitempty"`
+}
+
+// ReconcilePolicies maps components to their reconcile policies. Components without a policy are applied once and
+// the reconcile continues if they fail.
+type ReconcilePolicies map[name.ComponentName]ReconcilePolicy
+
+// DefaultReconcilePolicies returns the reconcile policies of the built in components: nothing is retried, and a
+// failure of the base chart or istiod aborts the reconcile, since all other components depend on them.
+func DefaultReconcilePolicies() ReconcilePolicies {
+	return ReconcilePolicies{
+		name.IstioBaseComponentName: {FailureAction: FailureActionAbort},
+		name.PilotComponentName:     {FailureAction: FailureActionAbort},
+	}
+}
+
+// Get returns the policy of component c.
+func (p ReconcilePolicies) Get(c name.ComponentName) ReconcilePolicy {
+	rp, ok := p[c]
+	if !ok {
+		return ReconcilePolicy{FailureAction: FailureActionContinue}
+	}
+	return rp
+}
+
+// ReconcilePoliciesForIOP returns defaults with the policies set through the reconcile-policy annotation of iop
+// applied. The annotation is a JSON map of component names to policies, for example
+// {"AddonComponents": {"retries": 3, "backoff": "10s", "failureAction": "continue"}}. Fields which are not set keep the
+// value in defaults.
+func ReconcilePoliciesForIOP(defaults ReconcilePolicies, iop *valuesv1alpha1.IstioOperator) (ReconcilePolicies, error) {
+	out := make(ReconcilePolicies)
+	for c, rp := range defaults {
+		out[c] = rp
+	}
+	var a string
+	if iop != nil {
+		a = iop.GetAnnotations()[valuesv1alpha1.ReconcilePolicyAnnotation]
+	}
+	if a == "" {
+		return out, nil
+	}
+	declared := make(map[name.ComponentName]reconcilePolicyJSON)
+	if err := json.Unmarshal([]byte(a), &declared); err != nil {
+		return nil, fmt.Errorf("bad %s annotation: %s", valuesv1alpha1.ReconcilePolicyAnnotation, err)
+	}
+	for c, d := range declared {
+		rp := out.Get(c)
+		if d.Retries != nil {
+			if *d.Retries < 0 {
+				return nil, fmt.Errorf("bad %s annotation: negative retries for %s", valuesv1alpha1.ReconcilePolicyAnnotation, c)
+			}
+			rp.Retries = *d.Retries
+		}
+		if d.Backoff != "" {
+			b, err := time.ParseDuration(d.Backoff)
+			if err != nil || b < 0 {
+				return nil, fmt.Errorf("bad %s annotation: bad backoff %q for %s", valuesv1alpha1.ReconcilePolicyAnnotation, d.Backoff, c)
+			}
+			rp.Backoff = b
+		}
+		switch FailureAction(d.FailureAction) {
+		case "":
+		case FailureActionAbort, FailureActionContinue:
+			rp.FailureAction = FailureAction(d.FailureAction)
+		default:
+			return nil, fmt.Errorf("bad %s annotation: failureAction for %s must be %s or %s, got %q",
+				valuesv1alpha1.ReconcilePolicyAnnotation, c, FailureActionAbort, FailureActionContinue, d.FailureAction)
+		}
+		out[c] = rp
+	}
+	return out, nil
+}
+
+// retryBackoff returns the wait before retry number attempt, counting from 1, of a component with policy rp.
+func (rp ReconcilePolicy) retryBackoff(attempt int) time.Duration {
+	b := rp.Backoff
+	if b == 0 {
+		b = defaultRetryBackoff
+	}
+	for i := 1; i < attempt; i++ {
+		b *= 2
+	}
+	return b
+}
+
+// processManifestWithRetries processes the manifests m of component c, retrying up to rp.Retries times with
+// exponential backoff while it fails and ctx is not done.
+func (h *HelmReconciler) processManifestWithRetries(ctx context.Context, c string, m []manifest.Manifest,
+	rp ReconcilePolicy) (object.K8sObjects, error) {
+	processedObjs, err := h.ProcessManifest(m)
+	for attempt := 1; err != nil && attempt <= rp.Retries; attempt++ {
+		backoff := rp.retryBackoff(attempt)
+		h.opts.Log.LogAndPrintf("Retrying component %s in %s (%d/%d)...", c, backoff, attempt, rp.Retries)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return processedObjs, err
+		}
+		h.clearResourceErrors(c)
+		processedObjs, err = h.ProcessManifest(m)
+	}
+	return processedObjs, err
+}
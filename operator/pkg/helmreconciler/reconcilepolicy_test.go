@@ -0,0 +1,110 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestReconcilePoliciesForIOP(t *testing.T) {
+	tests := []struct {
+		desc       string
+		annotation string
+		want       map[name.ComponentName]ReconcilePolicy
+		wantErr    string
+	}{
+		{
+			desc: "defaults",
+			want: map[name.ComponentName]ReconcilePolicy{
+				name.IstioBaseComponentName: {FailureAction: FailureActionAbort},
+				name.PilotComponentName:     {FailureAction: FailureActionAbort},
+				name.AddonComponentName:     {FailureAction: FailureActionContinue},
+			},
+		},
+		{
+			desc:       "declared",
+			annotation: `{"AddonComponents": {"retries": 3, "backoff": "10s"}, "Pilot": {"retries": 1}, "IngressGateways": {"failureAction": "abort"}}`,
+			want: map[name.ComponentName]ReconcilePolicy{
+				name.AddonComponentName:   {Retries: 3, Backoff: 10 * time.Second, FailureAction: FailureActionContinue},
+				name.PilotComponentName:   {Retries: 1, FailureAction: FailureActionAbort},
+				name.IngressComponentName: {FailureAction: FailureActionAbort},
+				name.EgressComponentName:  {FailureAction: FailureActionContinue},
+			},
+		},
+		{
+			desc:       "bad failure action",
+			annotation: `{"AddonComponents": {"failureAction": "ignore"}}`,
+			wantErr:    "failureAction for AddonComponents must be abort or continue",
+		},
+		{
+			desc:       "bad backoff",
+			annotation: `{"AddonComponents": {"backoff": "soon"}}`,
+			wantErr:    `bad backoff "soon" for AddonComponents`,
+		},
+		{
+			desc:       "negative retries",
+			annotation: `{"AddonComponents": {"retries": -1}}`,
+			wantErr:    "negative retries for AddonComponents",
+		},
+		{
+			desc:       "bad annotation",
+			annotation: `AddonComponents: {retries: 3}`,
+			wantErr:    "bad install.istio.io/reconcile-policy annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			iop := &valuesv1alpha1.IstioOperator{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+			if tt.annotation != "" {
+				iop.Annotations[valuesv1alpha1.ReconcilePolicyAnnotation] = tt.annotation
+			}
+			got, err := ReconcilePoliciesForIOP(DefaultReconcilePolicies(), iop)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for c, want := range tt.want {
+				if got := got.Get(c); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: got %+v, want %+v", c, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	rp := ReconcilePolicy{Backoff: time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second} {
+		if got := rp.retryBackoff(attempt); got != want {
+			t.Errorf("attempt %d: got %s, want %s", attempt, got, want)
+		}
+	}
+	if got := (ReconcilePolicy{}).retryBackoff(1); got != defaultRetryBackoff {
+		t.Errorf("default: got %s, want %s", got, defaultRetryBackoff)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	jsonpatch "github.com/evanphx/json-patch"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -35,12 +36,16 @@ import (
 	"istio.io/istio/operator/pkg/apis/istio"
 	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
 	"istio.io/istio/operator/pkg/controlplane"
+	"istio.io/istio/operator/pkg/digest"
 	"istio.io/istio/operator/pkg/helm"
+	opmanifest "istio.io/istio/operator/pkg/manifest"
 	"istio.io/istio/operator/pkg/name"
 	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/sizing"
 	"istio.io/istio/operator/pkg/tpath"
 	"istio.io/istio/operator/pkg/translate"
 	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/util/clog"
 	"istio.io/istio/operator/pkg/validate"
 	binversion "istio.io/istio/operator/version"
 	"istio.io/istio/pilot/pkg/model"
@@ -107,14 +112,42 @@ func (h *HelmReconciler) RenderCharts() (ChartManifestsMap, error) {
 	if errs != nil {
 		err = errs.ToError()
 	}
+	if err == nil {
+		manifests, err = opmanifest.FilterCRDs(manifests, h.opts.CRDs)
+	}
+	if err == nil && h.opts.Namespaced {
+		manifests, err = opmanifest.FilterNamespaced(manifests)
+	}
+	if err == nil {
+		// Images rendered from an installation package with a digest lockfile are pinned to the packaged digests.
+		var unpinned []string
+		manifests, unpinned, err = digest.PinPackageManifests(manifests, iopSpec.InstallPackagePath)
+		if len(unpinned) != 0 {
+			h.opts.Log.LogAndPrintf("! Not pinning images which are not in the digest lockfile of the installation package: %s",
+				strings.Join(unpinned, ", "))
+		}
+	}
+	if err == nil && valuesv1alpha1.IsUserGateway(h.iop) {
+		manifests = gatewayManifests(manifests)
+	}
 
 	h.manifests = manifests
+	h.componentManifests = nil
+	if err == nil {
+		h.componentManifests, err = newComponentManifests(manifests, cp.ChartVersions())
+	}
+	checks := cp.ReadinessChecks()
+	for _, cm := range h.componentManifests {
+		cm.ReadinessChecks = checks[cm.Component]
+	}
 
 	return toChartManifestsMap(manifests), err
 }
 
-func (h *HelmReconciler) GetManifests() name.ManifestMap {
-	return h.manifests
+// GetManifests returns the manifests generated by the last RenderCharts for each component, together with the
+// objects of each component and the version of the chart it is rendered from.
+func (h *HelmReconciler) GetManifests() ComponentManifests {
+	return h.componentManifests
 }
 
 // MergeIOPSWithProfile overlays the values in iop on top of the defaults for the profile given by iop.profile and
@@ -159,6 +192,11 @@ func MergeIOPSWithProfile(iop *valuesv1alpha1.IstioOperator) (*v1alpha1.IstioOpe
 		return nil, err
 	}
 
+	mergedYAML, err = sizing.Overlay(mergedYAML, overlayYAML)
+	if err != nil {
+		return nil, err
+	}
+
 	mergedYAML, err = translate.OverlayValuesEnablement(mergedYAML, overlayYAML, "")
 	if err != nil {
 		return nil, err
@@ -175,6 +213,10 @@ func MergeIOPSWithProfile(iop *valuesv1alpha1.IstioOperator) (*v1alpha1.IstioOpe
 // ProcessManifest apply the manifest to create or update resources, returns the number of objects processed
 func (h *HelmReconciler) ProcessManifest(manifests []manifest.Manifest) (object.K8sObjects, error) {
 	var processedObjects object.K8sObjects
+	order, err := h.applyOrder()
+	if err != nil {
+		return nil, err
+	}
 	for _, manifest := range manifests {
 		var errs util.Errors
 		objAccessor, err := meta.Accessor(h.iop)
@@ -220,6 +262,7 @@ func (h *HelmReconciler) ProcessManifest(manifests []manifest.Manifest) (object.
 			if co, ok := objectCache.cache[oh]; ok && obj.Equal(co) {
 				// Object is in the cache and unchanged.
 				deployedObjects++
+				h.recordAction(manifest.Name, obj.UnstructuredObject(), ObjectUnchanged)
 				continue
 			}
 			changedObjects = append(changedObjects, obj)
@@ -229,27 +272,41 @@ func (h *HelmReconciler) ProcessManifest(manifests []manifest.Manifest) (object.
 		var bar *pb.ProgressBar
 		if len(changedObjectKeys) > 0 {
 			h.opts.Log.LogAndPrintf("Processing resources for component %s...", manifest.Name)
-			bar = progressBar(len(changedObjectKeys))
+			// The objects are listed instead of a progress bar when verbose, and quiet output has neither.
+			if !h.opts.Verbose && !clog.Quiet(h.opts.Log) {
+				bar = progressBar(len(changedObjectKeys))
+			}
 			scope.Infof("The following objects differ between generated manifest and cache: \n - %s", strings.Join(changedObjectKeys, "\n - "))
 		} else {
 			scope.Infof("Generated manifest objects are the same as cached for component %s.", manifest.Name)
 		}
 
-		// For each changed object, write it to the API server.
+		// Write the changed objects to the API server in batches, which are applied concurrently.
+		changedObjects.Sort(order.Score)
 		for _, obj := range changedObjects {
-			obju := obj.UnstructuredObject()
-			if err := applyLabelsAndAnnotations(obju, manifest.Name, h.iop.Spec.Revision, crName); err != nil {
+			if err := applyLabelsAndAnnotations(obj.UnstructuredObject(), manifest.Name, h.iop.Spec.Revision, crName); err != nil {
 				return nil, err
 			}
-			if err := h.ProcessObject(manifest.Name, obj.UnstructuredObject()); err != nil {
-				scope.Error(err.Error())
-				errs = util.AppendErr(errs, err)
-				continue
+		}
+		applied := 0
+		for _, batch := range applyBatches(changedObjects, order, h.applyBatchSize()) {
+			for i, err := range h.applyBatchAndDiscover(manifest.Name, batch) {
+				obj := batch[i]
+				if err != nil {
+					scope.Error(err.Error())
+					errs = util.AppendErr(errs, err)
+					h.recordResourceError(manifest.Name, obj.UnstructuredObject(), err)
+					continue
+				}
+				if bar != nil {
+					bar.Increment()
+				}
+				processedObjects = append(processedObjects, obj)
+				// Update the cache with the latest object.
+				objectCache.cache[obj.Hash()] = obj
 			}
-			bar.Increment()
-			processedObjects = append(processedObjects, obj)
-			// Update the cache with the latest object.
-			objectCache.cache[obj.Hash()] = obj
+			applied += len(batch)
+			scope.Infof("Applied %d/%d changed resources of component %s.", applied, len(changedObjects), manifest.Name)
 		}
 		if bar != nil {
 			bar.Finish()
@@ -310,6 +367,8 @@ func applyLabelsAndAnnotations(obj runtime.Object, componentName, revision, crNa
 }
 
 // ProcessObject creates or updates an object in the API server depending on whether it already exists.
+// Updates remove the fields which were removed from the rendered object since it was last applied, see threeWayMerge,
+// or are server-side applies if Options.ServerSideApply is set.
 // It mutates obj.
 func (h *HelmReconciler) ProcessObject(chartName string, obj *unstructured.Unstructured) error {
 	if obj.GetKind() == "List" {
@@ -347,17 +406,62 @@ func (h *HelmReconciler) ProcessObject(chartName string, obj *unstructured.Unstr
 	switch {
 	case apierrors.IsNotFound(err):
 		scope.Infof("creating resource: %s", objectStr)
-		return h.client.Create(context.TODO(), obj)
+		if h.opts.ServerSideApply {
+			err = h.serverSideApply(obj, objectStr)
+		} else {
+			err = h.client.Create(context.TODO(), obj)
+		}
+		switch err {
+		case nil:
+			h.recordAction(chartName, obj, ObjectCreated)
+		case errConflictsSkipped:
+			h.recordAction(chartName, obj, ObjectSkipped)
+			return nil
+		}
+		return err
 	case err == nil:
+		if isProtected(receiver) {
+			h.opts.Log.LogAndPrintf("Not updating %s because it has the %s annotation.", objectStr, valuesv1alpha1.IgnoreAnnotation)
+			h.recordAction(chartName, obj, ObjectUnchanged)
+			return nil
+		}
+		resourceVersion := receiver.GetResourceVersion()
 		scope.Infof("updating resource: %s", objectStr)
-		if err := applyOverlay(receiver, obj); err != nil {
+		if err := h.retainLiveFields(receiver, obj); err != nil {
 			return err
 		}
-		return h.client.Update(context.TODO(), receiver)
+		if err := h.mergeLiveMeshConfig(receiver, obj); err != nil {
+			return err
+		}
+		if h.opts.ServerSideApply {
+			err := h.serverSideApply(obj, objectStr)
+			if err == errConflictsSkipped {
+				h.recordAction(chartName, obj, ObjectSkipped)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			h.recordAction(chartName, obj, updateAction(resourceVersion, obj.GetResourceVersion()))
+			return nil
+		}
+		if err := threeWayMerge(receiver, obj); err != nil {
+			return err
+		}
+		if err := h.client.Update(context.TODO(), receiver); err != nil {
+			return err
+		}
+		h.recordAction(chartName, receiver, updateAction(resourceVersion, receiver.GetResourceVersion()))
+		return nil
 	}
 	return err
 }
 
+// isProtected reports whether obj has the IgnoreAnnotation, which excludes it from updates and pruning.
+func isProtected(obj metav1.Object) bool {
+	return strings.EqualFold(obj.GetAnnotations()[valuesv1alpha1.IgnoreAnnotation], "true")
+}
+
 // applyOverlay applies an overlay using JSON patch strategy over the current Object in place.
 func applyOverlay(current, overlay runtime.Object) error {
 	cj, err := runtime.Encode(unstructured.UnstructuredJSONScheme, current)
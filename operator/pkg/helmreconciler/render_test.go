@@ -0,0 +1,159 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+func TestProtectedObjects(t *testing.T) {
+	iop := &valuesv1alpha1.IstioOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: "installed-state", Namespace: "istio-system"},
+		Spec:       &v1alpha1.IstioOperatorSpec{},
+	}
+	liveConfigMap := func(name string, protected bool, labels map[string]string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "istio-system", Labels: labels},
+			Data:       map[string]string{"key": "live"},
+		}
+		if protected {
+			cm.Annotations = map[string]string{valuesv1alpha1.IgnoreAnnotation: "true"}
+		}
+		return cm
+	}
+	ownerLabels := NewIstioPruningDetails(iop).GetOwnerLabels()
+	// Each step starts from the same live objects in a new client.
+	setup := func(t *testing.T) (*HelmReconciler, func(name string) (string, bool)) {
+		cl := fake.NewFakeClientWithScheme(scheme.Scheme,
+			liveConfigMap("protected", true, ownerLabels),
+			liveConfigMap("unprotected", false, ownerLabels),
+			liveConfigMap("protected-stale", true, ownerLabels),
+			liveConfigMap("unprotected-stale", false, ownerLabels),
+		)
+		h, err := NewHelmReconciler(cl, nil, iop, &Options{Log: clog.NewConsoleLogger(false, ioutil.Discard, ioutil.Discard)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := func(name string) (string, bool) {
+			cm := &corev1.ConfigMap{}
+			if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "istio-system"}, cm); err != nil {
+				if apierrors.IsNotFound(err) {
+					return "", false
+				}
+				t.Fatal(err)
+			}
+			return cm.Data["key"], true
+		}
+		return h, data
+	}
+	rendered := func(name string) string {
+		return fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n  namespace: istio-system\ndata:\n  key: rendered\n", name)
+	}
+
+	t.Run("drift", func(t *testing.T) {
+		h, _ := setup(t)
+		manifests := name.ManifestMap{name.PilotComponentName: {rendered("protected"), rendered("unprotected")}}
+		drifted, err := DetectDrift(h.GetClient(), manifests)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, d := range drifted {
+			got = append(got, d.Hash)
+		}
+		if want := []string{"ConfigMap:istio-system:unprotected"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got drifted objects %v, want %v", got, want)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		h, data := setup(t)
+		for _, n := range []string{"protected", "unprotected"} {
+			obj, err := object.ParseYAMLToK8sObject([]byte(rendered(n)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.ProcessObject(string(name.PilotComponentName), obj.UnstructuredObject()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got, _ := data("protected"); got != "live" {
+			t.Errorf("got protected data %q, want it not updated", got)
+		}
+		if got, _ := data("unprotected"); got != "rendered" {
+			t.Errorf("got unprotected data %q, want it updated", got)
+		}
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		h, data := setup(t)
+		excluded := map[string]bool{
+			"ConfigMap:istio-system:protected":   true,
+			"ConfigMap:istio-system:unprotected": true,
+		}
+		// The fake client only lists unstructured objects by their list kind.
+		gvks := []schema.GroupVersionKind{{Version: "v1", Kind: "ConfigMapList"}}
+		if err := h.PruneUnlistedResources(gvks, excluded, false, "istio-system"); err != nil {
+			t.Fatal(err)
+		}
+		for n, want := range map[string]bool{
+			"protected":         true,
+			"unprotected":       true,
+			"protected-stale":   true,
+			"unprotected-stale": false,
+		} {
+			if _, got := data(n); got != want {
+				t.Errorf("%s: got exists %v, want %v", n, got, want)
+			}
+		}
+	})
+}
+
+func TestIsProtected(t *testing.T) {
+	for _, tt := range []struct {
+		annotations map[string]string
+		want        bool
+	}{
+		{want: false},
+		{annotations: map[string]string{valuesv1alpha1.IgnoreAnnotation: "true"}, want: true},
+		{annotations: map[string]string{valuesv1alpha1.IgnoreAnnotation: "True"}, want: true},
+		{annotations: map[string]string{valuesv1alpha1.IgnoreAnnotation: "false"}, want: false},
+	} {
+		u := &unstructured.Unstructured{}
+		u.SetAnnotations(tt.annotations)
+		if got := isProtected(u); got != tt.want {
+			t.Errorf("isProtected(%v): got %v, want %v", tt.annotations, got, tt.want)
+		}
+	}
+}
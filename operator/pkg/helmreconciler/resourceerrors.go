@@ -0,0 +1,166 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+)
+
+// resourceErrorsStatusField is the field under the IstioOperator status holding the list of resources which failed
+// to apply in the last reconcile, as ResourceErrors. Like effectiveSpec, it is not part of the typed InstallStatus.
+const resourceErrorsStatusField = "resourceErrors"
+
+// ResourceError is a resource which failed to apply, with the error returned by the API server.
+type ResourceError struct {
+	// Component is the component the resource belongs to.
+	Component string `json:"component"`
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Reason is the machine readable reason of an API error, e.g. Forbidden or Invalid, or empty for other errors.
+	Reason string `json:"reason,omitempty"`
+	// Code is the HTTP status code of an API error, or 0 for other errors.
+	Code int32 `json:"code,omitempty"`
+	// Message is the error message.
+	Message string `json:"message"`
+}
+
+// String implements fmt.Stringer.
+func (e ResourceError) String() string {
+	gk := e.Kind
+	if e.Group != "" {
+		gk = e.Group + "/" + e.Kind
+	}
+	s := fmt.Sprintf("%s %s", gk, e.Name)
+	if e.Namespace != "" {
+		s = fmt.Sprintf("%s %s/%s", gk, e.Namespace, e.Name)
+	}
+	if e.Reason != "" {
+		s += " (" + e.Reason + ")"
+	}
+	return s + ": " + e.Message
+}
+
+// newResourceError returns the ResourceError of obj of component failing to apply with err.
+func newResourceError(component string, obj *unstructured.Unstructured, err error) ResourceError {
+	gvk := obj.GroupVersionKind()
+	re := ResourceError{
+		Component: component,
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Message:   err.Error(),
+	}
+	if status, ok := err.(kerrors.APIStatus); ok {
+		re.Reason = string(status.Status().Reason)
+		re.Code = status.Status().Code
+	}
+	return re
+}
+
+// recordResourceError records that obj of component failed to apply with err. It is safe for concurrent use.
+func (h *HelmReconciler) recordResourceError(component string, obj *unstructured.Unstructured, err error) {
+	h.resourceErrorsMu.Lock()
+	defer h.resourceErrorsMu.Unlock()
+	h.resourceErrors = append(h.resourceErrors, newResourceError(component, obj, err))
+}
+
+// clearResourceErrors removes the recorded errors of the resources of component, before it is applied again.
+func (h *HelmReconciler) clearResourceErrors(component string) {
+	h.resourceErrorsMu.Lock()
+	defer h.resourceErrorsMu.Unlock()
+	var kept []ResourceError
+	for _, re := range h.resourceErrors {
+		if re.Component != component {
+			kept = append(kept, re)
+		}
+	}
+	h.resourceErrors = kept
+}
+
+// ResourceErrors returns the resources which failed to apply in the last reconcile, sorted by component, kind,
+// namespace and name.
+func (h *HelmReconciler) ResourceErrors() []ResourceError {
+	h.resourceErrorsMu.Lock()
+	defer h.resourceErrorsMu.Unlock()
+	out := append([]ResourceError(nil), h.resourceErrors...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+// resourceErrorsStatus returns ResourceErrors for status.resourceErrors, or nil to remove the field if there are none.
+func (h *HelmReconciler) resourceErrorsStatus() interface{} {
+	if re := h.ResourceErrors(); len(re) != 0 {
+		return re
+	}
+	return nil
+}
+
+// ReadResourceErrors returns the resource errors recorded in the status of the IstioOperator CR with the given name
+// and namespace. It returns nil if the CR does not exist.
+func ReadResourceErrors(cl client.Reader, name, namespace string) ([]ResourceError, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(valuesv1alpha1.IstioOperatorGVK)
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, u); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get IstioOperator %s/%s: %s", namespace, name, err)
+	}
+	return resourceErrorsFromStatus(u)
+}
+
+// resourceErrorsFromStatus returns the resource errors in the status of the IstioOperator u.
+func resourceErrorsFromStatus(u *unstructured.Unstructured) ([]ResourceError, error) {
+	v, ok, err := unstructured.NestedFieldNoCopy(u.Object, "status", resourceErrorsStatusField)
+	if err != nil || !ok {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out []ResourceError
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("bad %s in IstioOperator %s/%s: %s", resourceErrorsStatusField, u.GetNamespace(), u.GetName(), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourceErrors(t *testing.T) {
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("istio-system")
+	deployment.SetName("istiod")
+	webhook := &unstructured.Unstructured{}
+	webhook.SetAPIVersion("admissionregistration.k8s.io/v1beta1")
+	webhook.SetKind("MutatingWebhookConfiguration")
+	webhook.SetName("istio-sidecar-injector")
+	service := &unstructured.Unstructured{}
+	service.SetAPIVersion("v1")
+	service.SetKind("Service")
+	service.SetNamespace("istio-system")
+	service.SetName("istio-ingressgateway")
+
+	h := &HelmReconciler{}
+	forbidden := kerrors.NewForbidden(schema.GroupResource{Group: "admissionregistration.k8s.io", Resource: "mutatingwebhookconfigurations"},
+		"istio-sidecar-injector", fmt.Errorf("no RBAC policy matched"))
+	h.recordResourceError("Pilot", webhook, forbidden)
+	h.recordResourceError("Pilot", deployment, fmt.Errorf("connection refused"))
+	invalid := kerrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "istio-ingressgateway", nil)
+	h.recordResourceError("IngressGateways", service, invalid)
+
+	got := h.ResourceErrors()
+	want := []ResourceError{
+		{
+			Component: "IngressGateways",
+			Version:   "v1",
+			Kind:      "Service",
+			Namespace: "istio-system",
+			Name:      "istio-ingressgateway",
+			Reason:    "Invalid",
+			Code:      422,
+			Message:   invalid.Error(),
+		},
+		{
+			Component: "Pilot",
+			Group:     "apps",
+			Version:   "v1",
+			Kind:      "Deployment",
+			Namespace: "istio-system",
+			Name:      "istiod",
+			Message:   "connection refused",
+		},
+		{
+			Component: "Pilot",
+			Group:     "admissionregistration.k8s.io",
+			Version:   "v1beta1",
+			Kind:      "MutatingWebhookConfiguration",
+			Name:      "istio-sidecar-injector",
+			Reason:    "Forbidden",
+			Code:      403,
+			Message:   forbidden.Error(),
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+	if got, want := got[1].String(), "apps/Deployment istio-system/istiod: connection refused"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// The errors round trip through the untyped status field.
+	status, err := unstructuredWithResourceErrors(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := resourceErrorsFromStatus(status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, want) {
+		t.Errorf("round trip: got %+v\nwant %+v", read, want)
+	}
+}
+
+// unstructuredWithResourceErrors returns an IstioOperator with errs in its status, decoded like one read from the
+// API server.
+func unstructuredWithResourceErrors(errs []ResourceError) (*unstructured.Unstructured, error) {
+	b, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "install.istio.io/v1alpha1",
+		"kind":       "IstioOperator",
+		"status":     map[string]interface{}{resourceErrorsStatusField: errs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	return u, u.UnmarshalJSON(b)
+}
@@ -0,0 +1,191 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// listWildcard is a path element matching every item of a list.
+	listWildcard = "[*]"
+)
+
+// retainField is a field of live objects of a kind which is kept when the object is updated, because it is managed
+// in the cluster rather than by the manifest. The live value is only kept if the manifest leaves the field unset or
+// empty, so that a value set explicitly in the manifest still takes effect.
+type retainField struct {
+	kind string
+	path []string
+	// onlyWithHPA retains the field only if a HorizontalPodAutoscaler targets the object. The HPA then owns the field,
+	// so the live value is kept even if the manifest sets it.
+	onlyWithHPA bool
+}
+
+var (
+	// defaultRetainFields are always retained.
+	defaultRetainFields = []retainField{
+		{kind: "Service", path: []string{"spec", "clusterIP"}},
+		{kind: "Service", path: []string{"spec", "ports", listWildcard, "nodePort"}},
+		{kind: "Service", path: []string{"spec", "healthCheckNodePort"}},
+		{kind: "Deployment", path: []string{"spec", "replicas"}, onlyWithHPA: true},
+		{kind: "MutatingWebhookConfiguration", path: []string{"webhooks", listWildcard, "clientConfig", "caBundle"}},
+		{kind: "ValidatingWebhookConfiguration", path: []string{"webhooks", listWildcard, "clientConfig", "caBundle"}},
+	}
+
+	hpaGVK = schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "HorizontalPodAutoscaler"}
+)
+
+// parseRetainFields parses fields of the form Kind:path, where path is a dot separated path of the field e.g.
+// Service:spec.loadBalancerIP or Service:spec.ports.[*].nodePort.
+func parseRetainFields(fields []string) ([]retainField, error) {
+	var out []retainField
+	for _, f := range fields {
+		kv := strings.SplitN(f, ":", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("bad retain field %q, must have the form Kind:path", f)
+		}
+		out = append(out, retainField{kind: kv[0], path: strings.Split(kv[1], ".")})
+	}
+	return out, nil
+}
+
+// retainLiveFields copies the values of the retained fields of live, if set, into desired before desired is applied
+// over live.
+func (h *HelmReconciler) retainLiveFields(live, desired *unstructured.Unstructured) error {
+	for _, rf := range h.retainFields {
+		if rf.kind != live.GetKind() {
+			continue
+		}
+		if rf.onlyWithHPA {
+			targeted, err := h.hasHPA(live)
+			if err != nil {
+				return err
+			}
+			if !targeted {
+				continue
+			}
+		}
+		retainPath(live.Object, desired.Object, rf.path, rf.onlyWithHPA)
+	}
+	return nil
+}
+
+// hasHPA reports whether a HorizontalPodAutoscaler in the namespace of obj targets obj.
+func (h *HelmReconciler) hasHPA(obj *unstructured.Unstructured) (bool, error) {
+	hpas := &unstructured.UnstructuredList{}
+	hpas.SetGroupVersionKind(hpaGVK)
+	if err := h.client.List(context.TODO(), hpas, client.InNamespace(obj.GetNamespace())); err != nil {
+		return false, fmt.Errorf("failed to list HorizontalPodAutoscalers: %s", err)
+	}
+	for _, hpa := range hpas.Items {
+		kind, _, _ := unstructured.NestedString(hpa.Object, "spec", "scaleTargetRef", "kind")
+		name, _, _ := unstructured.NestedString(hpa.Object, "spec", "scaleTargetRef", "name")
+		if kind == obj.GetKind() && name == obj.GetName() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// retainPath sets the value at path in desired to the one in live, if live has one and desired has none or an empty
+// one, or always if overwrite is set. Items of lists at a listWildcard element are matched by name if they have one,
+// otherwise by index.
+func retainPath(live, desired interface{}, path []string, overwrite bool) {
+	if len(path) == 0 {
+		return
+	}
+	if path[0] == listWildcard {
+		ll, ok := live.([]interface{})
+		if !ok {
+			return
+		}
+		dl, ok := desired.([]interface{})
+		if !ok {
+			return
+		}
+		for i, d := range dl {
+			if l := matchingListItem(ll, d, i); l != nil {
+				retainPath(l, d, path[1:], overwrite)
+			}
+		}
+		return
+	}
+	lm, ok := live.(map[string]interface{})
+	if !ok {
+		return
+	}
+	dm, ok := desired.(map[string]interface{})
+	if !ok {
+		return
+	}
+	lv, ok := lm[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		if dv, ok := dm[path[0]]; ok && !overwrite && !isEmptyValue(dv) {
+			return
+		}
+		dm[path[0]] = lv
+		return
+	}
+	dv, ok := dm[path[0]]
+	if !ok {
+		return
+	}
+	retainPath(lv, dv, path[1:], overwrite)
+}
+
+// isEmptyValue reports whether v, a value of an unstructured object, is null, an empty string or zero.
+func isEmptyValue(v interface{}) bool {
+	switch vv := v.(type) {
+	case nil:
+		return true
+	case string:
+		return vv == ""
+	case int64:
+		return vv == 0
+	case float64:
+		return vv == 0
+	case int:
+		return vv == 0
+	}
+	return false
+}
+
+// matchingListItem returns the item of live matching item d at index i of the desired list, or nil if there is none.
+func matchingListItem(live []interface{}, d interface{}, i int) interface{} {
+	if dm, ok := d.(map[string]interface{}); ok {
+		if name, ok := dm["name"]; ok {
+			for _, l := range live {
+				if lm, ok := l.(map[string]interface{}); ok && lm["name"] == name {
+					return l
+				}
+			}
+			return nil
+		}
+	}
+	if i < len(live) {
+		return live[i]
+	}
+	return nil
+}
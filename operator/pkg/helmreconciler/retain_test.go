@@ -0,0 +1,174 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestRetainPath(t *testing.T) {
+	live := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+spec:
+  clusterIP: 10.0.0.10
+  ports:
+  - name: status-port
+    port: 15020
+    nodePort: 31000
+  - name: http2
+    port: 80
+    nodePort: 32000
+`
+	desired := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+spec:
+  ports:
+  - name: http2
+    port: 80
+  - name: https
+    port: 443
+  - name: status-port
+    port: 15021
+`
+	want := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+spec:
+  clusterIP: 10.0.0.10
+  ports:
+  - name: http2
+    port: 80
+    nodePort: 32000
+  - name: https
+    port: 443
+  - name: status-port
+    port: 15021
+    nodePort: 31000
+`
+	l, err := object.ParseYAMLToK8sObject([]byte(live))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := object.ParseYAMLToK8sObject([]byte(desired))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := object.ParseYAMLToK8sObject([]byte(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rf := range defaultRetainFields {
+		if rf.kind == "Service" {
+			retainPath(l.Unstructured(), d.Unstructured(), rf.path, rf.onlyWithHPA)
+		}
+	}
+	if got := d.Unstructured(); !reflect.DeepEqual(got, w.Unstructured()) {
+		t.Errorf("got:\n%v\nwant:\n%v", got, w.Unstructured())
+	}
+}
+
+func TestRetainPathExplicitValues(t *testing.T) {
+	parse := func(y string) map[string]interface{} {
+		o, err := object.ParseYAMLToK8sObject([]byte(y))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return o.Unstructured()
+	}
+	tests := []struct {
+		desc      string
+		live      string
+		desired   string
+		path      []string
+		overwrite bool
+		want      string
+	}{
+		{
+			desc:    "explicit nodePort",
+			live:    "kind: Service\nspec:\n  ports:\n  - name: http2\n    nodePort: 32000\n",
+			desired: "kind: Service\nspec:\n  ports:\n  - name: http2\n    nodePort: 31380\n",
+			path:    []string{"spec", "ports", listWildcard, "nodePort"},
+			want:    "kind: Service\nspec:\n  ports:\n  - name: http2\n    nodePort: 31380\n",
+		},
+		{
+			desc:    "explicit healthCheckNodePort",
+			live:    "kind: Service\nspec:\n  healthCheckNodePort: 30000\n",
+			desired: "kind: Service\nspec:\n  healthCheckNodePort: 30100\n",
+			path:    []string{"spec", "healthCheckNodePort"},
+			want:    "kind: Service\nspec:\n  healthCheckNodePort: 30100\n",
+		},
+		{
+			desc:    "explicit caBundle",
+			live:    "kind: MutatingWebhookConfiguration\nwebhooks:\n- name: a\n  clientConfig:\n    caBundle: live\n",
+			desired: "kind: MutatingWebhookConfiguration\nwebhooks:\n- name: a\n  clientConfig:\n    caBundle: chart\n",
+			path:    []string{"webhooks", listWildcard, "clientConfig", "caBundle"},
+			want:    "kind: MutatingWebhookConfiguration\nwebhooks:\n- name: a\n  clientConfig:\n    caBundle: chart\n",
+		},
+		{
+			desc:    "empty caBundle",
+			live:    "kind: MutatingWebhookConfiguration\nwebhooks:\n- name: a\n  clientConfig:\n    caBundle: live\n",
+			desired: "kind: MutatingWebhookConfiguration\nwebhooks:\n- name: a\n  clientConfig:\n    caBundle: \"\"\n",
+			path:    []string{"webhooks", listWildcard, "clientConfig", "caBundle"},
+			want:    "kind: MutatingWebhookConfiguration\nwebhooks:\n- name: a\n  clientConfig:\n    caBundle: live\n",
+		},
+		{
+			desc:      "replicas owned by an HPA",
+			live:      "kind: Deployment\nspec:\n  replicas: 5\n",
+			desired:   "kind: Deployment\nspec:\n  replicas: 1\n",
+			path:      []string{"spec", "replicas"},
+			overwrite: true,
+			want:      "kind: Deployment\nspec:\n  replicas: 5\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			d := parse(tt.desired)
+			retainPath(parse(tt.live), d, tt.path, tt.overwrite)
+			if want := parse(tt.want); !reflect.DeepEqual(d, want) {
+				t.Errorf("got:\n%v\nwant:\n%v", d, want)
+			}
+		})
+	}
+}
+
+func TestParseRetainFields(t *testing.T) {
+	got, err := parseRetainFields([]string{"Service:spec.loadBalancerIP", "Service:spec.ports.[*].nodePort"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []retainField{
+		{kind: "Service", path: []string{"spec", "loadBalancerIP"}},
+		{kind: "Service", path: []string{"spec", "ports", listWildcard, "nodePort"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	for _, bad := range []string{"Service", ":spec.clusterIP", "Service:"} {
+		if _, err := parseRetainFields([]string{bad}); err == nil {
+			t.Errorf("%s: expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// RevisionComponentLabel returns the component label value of the istiod resources of a control plane revision, as
+// set by applyLabelsAndAnnotations. An empty revision is the default revision.
+func RevisionComponentLabel(revision string) string {
+	if revision == "" {
+		revision = defaultRevision
+	}
+	return string(name.PilotComponentName) + "-" + revision
+}
+
+// DeleteControlPlaneRevision deletes the istiod resources of the given control plane revision, i.e. the resources
+// labeled as its Pilot component, in all namespaces. Resources shared by all revisions, like the CRDs or the gateways,
+// are left in place. As on teardown, the webhook configurations are deleted first. If dryRun is set nothing is deleted.
+// It returns the hashes of the deleted objects.
+func DeleteControlPlaneRevision(cl client.Client, revision string, dryRun bool) ([]string, error) {
+	var deleted []string
+	labels := client.MatchingLabels{istioComponentLabelStr: RevisionComponentLabel(revision)}
+	var gvks []schema.GroupVersionKind
+	order := object.DefaultApplyOrderKinds
+	for _, step := range deletionSteps(order.DeletionOrder(namespacedResources), order.DeletionOrder(nonNamespacedResources), false) {
+		gvks = append(gvks, step...)
+	}
+	for _, gvk := range gvks {
+		objects := &unstructured.UnstructuredList{}
+		objects.SetGroupVersionKind(gvk)
+		if err := cl.List(context.TODO(), objects, labels); err != nil {
+			// Kinds whose CRDs are not installed cannot be listed.
+			scope.Warnf("retrieving resources of revision %s of type %s: %s", revision, gvk.String(), err)
+			continue
+		}
+		for i := range objects.Items {
+			o := &objects.Items[i]
+			oh := object.NewK8sObject(o, nil, nil).Hash()
+			if !dryRun {
+				err := cl.Delete(context.TODO(), o, client.PropagationPolicy(metav1.DeletePropagationBackground))
+				if err != nil && !kerrors.IsNotFound(err) {
+					return deleted, fmt.Errorf("failed to delete %s: %s", oh, err)
+				}
+			}
+			deleted = append(deleted, oh)
+		}
+	}
+	return deleted, nil
+}
+
+// revisionConflicts returns an error for each istiod resource in manifests of the given control plane revision which
+// is not named after the revision, or which already exists in the cluster as a resource of another revision, including
+// the control plane installed without one. Installing the revision would otherwise take over the resources of a
+// running control plane rather than install next to it.
+func revisionConflicts(cl client.Client, manifests name.ManifestMap, revision string) (util.Errors, error) {
+	if revision == "" {
+		return nil, nil
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(strings.Join(manifests[name.PilotComponentName], helm.YAMLSeparator))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest for component %s: %s", name.PilotComponentName, err)
+	}
+	errs := unsuffixedResources(objs, revision)
+	for _, obj := range objs {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := cl.Get(context.TODO(), types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, live); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %s", obj.Hash(), err)
+		}
+		if owner := revisionOf(live.GetLabels()); owner != revision {
+			errs = util.AppendErr(errs, fmt.Errorf("%s already exists for revision %s", obj.Hash(), owner))
+		}
+	}
+	return errs, nil
+}
+
+// unsuffixedResources returns an error for each of objs whose name does not contain the revision suffix.
+func unsuffixedResources(objs object.K8sObjects, revision string) (errs util.Errors) {
+	for _, obj := range objs {
+		if !strings.Contains(obj.Name, "-"+revision) {
+			errs = util.AppendErr(errs, fmt.Errorf("%s is not named after revision %s", obj.Hash(), revision))
+		}
+	}
+	return errs
+}
+
+// revisionOf returns the revision of a resource with the given labels. Resources without a revision label belong to
+// the control plane installed without a revision.
+func revisionOf(labels map[string]string) string {
+	if rev := labels[model.RevisionLabel]; rev != "" {
+		return rev
+	}
+	return defaultRevision
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"testing"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestUnsuffixedResources(t *testing.T) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod-canary
+  namespace: istio-system
+---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: istio-sidecar-injector-canary-istio-control
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := unsuffixedResources(objs, "canary")
+	if len(errs) != 1 {
+		t.Fatalf("got errors %v, want one", errs)
+	}
+	if got, want := errs[0].Error(), "ConfigMap:istio-system:istio is not named after revision canary"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRevisionOf(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		want   string
+	}{
+		{labels: nil, want: "default"},
+		{labels: map[string]string{"app": "istiod"}, want: "default"},
+		{labels: map[string]string{"istio.io/rev": "default"}, want: "default"},
+		{labels: map[string]string{"istio.io/rev": "canary"}, want: "canary"},
+	}
+	for _, tt := range tests {
+		if got := revisionOf(tt.labels); got != tt.want {
+			t.Errorf("revisionOf(%v): got %s, want %s", tt.labels, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FieldManager is the field manager of the fields applied with server-side apply.
+	FieldManager = "istio-operator"
+
+	// ConflictsAbort fails objects with fields owned by other field managers. It is the default.
+	ConflictsAbort = "abort"
+	// ConflictsSkip leaves objects with fields owned by other field managers unchanged.
+	ConflictsSkip = "skip"
+	// ConflictsForce takes over the fields owned by other field managers.
+	ConflictsForce = "force"
+)
+
+// errConflictsSkipped is returned by serverSideApply if an object was left unchanged because of ConflictsSkip.
+var errConflictsSkipped = errors.New("object skipped because of field conflicts")
+
+// ConflictPolicies are the valid values of Options.Conflicts.
+var ConflictPolicies = map[string]bool{ConflictsAbort: true, ConflictsSkip: true, ConflictsForce: true}
+
+// ValidateConflictPolicy returns an error if p is not one of the ConflictPolicies. An empty p is ConflictsAbort.
+func ValidateConflictPolicy(p string) error {
+	if p != "" && !ConflictPolicies[p] {
+		return fmt.Errorf("unknown conflict policy %s, must be one of %s, %s or %s", p, ConflictsAbort, ConflictsSkip, ConflictsForce)
+	}
+	return nil
+}
+
+// FieldConflict is a field of an applied object whose value is owned by another field manager.
+type FieldConflict struct {
+	// Manager is the other field manager, e.g. kube-controller-manager for an HPA, or kubectl.
+	Manager string
+	// Field is the path of the field, e.g. .spec.replicas.
+	Field string
+}
+
+// ConflictError is the error of an object which could not be applied because of field conflicts.
+type ConflictError struct {
+	// Object is the object, of the form Kind/Namespace/Name.
+	Object    string
+	Conflicts []FieldConflict
+}
+
+// Error implements error.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s has fields owned by other field managers: %s. Use --force-conflicts to take them over, "+
+		"or --conflicts=skip to leave the object unchanged", e.Object, e.fields())
+}
+
+// fields returns the conflicting fields with their managers, e.g. ".spec.replicas (kube-controller-manager)".
+func (e *ConflictError) fields() string {
+	var fields []string
+	for _, c := range e.Conflicts {
+		fields = append(fields, fmt.Sprintf("%s (%s)", c.Field, c.Manager))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// fieldConflicts returns the field conflicts reported by a failed server-side apply, and false if err is not a field
+// conflict.
+func fieldConflicts(err error) ([]FieldConflict, bool) {
+	if !kerrors.IsConflict(err) {
+		return nil, false
+	}
+	status, ok := err.(kerrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil, false
+	}
+	var out []FieldConflict
+	for _, c := range status.Status().Details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		// The message is of the form: conflict with "kubectl" using apps/v1
+		manager := c.Message
+		if i := strings.Index(manager, `"`); i >= 0 {
+			if j := strings.Index(manager[i+1:], `"`); j >= 0 {
+				manager = manager[i+1 : i+1+j]
+			}
+		}
+		out = append(out, FieldConflict{Manager: manager, Field: c.Field})
+	}
+	return out, len(out) != 0
+}
+
+// serverSideApply applies obj with server-side apply as FieldManager, so that the API server merges it with the fields
+// of other field managers and removes the fields which are no longer applied. Field conflicts are handled as set in
+// Options.Conflicts: the object fails with a ConflictError, is left unchanged with errConflictsSkipped or the fields
+// are taken over.
+func (h *HelmReconciler) serverSideApply(obj *unstructured.Unstructured, objectStr string) error {
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if h.opts.Conflicts == ConflictsForce {
+		opts = append(opts, client.ForceOwnership)
+	}
+	// An applied configuration must not set these.
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	err := h.client.Patch(context.TODO(), obj, client.Apply, opts...)
+	conflicts, ok := fieldConflicts(err)
+	if !ok {
+		return err
+	}
+	ce := &ConflictError{Object: objectStr, Conflicts: conflicts}
+	if h.opts.Conflicts == ConflictsSkip {
+		h.opts.Log.LogAndPrintf("Not updating %s because it has fields owned by other field managers: %s", objectStr, ce.fields())
+		return errConflictsSkipped
+	}
+	return ce
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+func TestFieldConflicts(t *testing.T) {
+	tests := []struct {
+		desc   string
+		err    error
+		want   []FieldConflict
+		wantOK bool
+	}{
+		{
+			desc: "nil",
+		},
+		{
+			desc: "other error",
+			err:  fmt.Errorf("connection refused"),
+		},
+		{
+			desc: "update conflict",
+			err:  kerrors.NewConflict(schema.GroupResource{Resource: "deployments"}, "istiod", fmt.Errorf("object was modified")),
+		},
+		{
+			desc: "field conflicts",
+			err: kerrors.NewApplyConflict([]metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kube-controller-manager" using apps/v1`, Field: ".spec.replicas"},
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl" using apps/v1`,
+					Field:   ".spec.template.spec.containers[name=\"discovery\"].image",
+				},
+			}, "Apply failed with 2 conflicts"),
+			want: []FieldConflict{
+				{Manager: "kube-controller-manager", Field: ".spec.replicas"},
+				{Manager: "kubectl", Field: ".spec.template.spec.containers[name=\"discovery\"].image"},
+			},
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, ok := fieldConflicts(tt.err)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConflictError(t *testing.T) {
+	e := &ConflictError{
+		Object:    "Deployment/istio-system/istiod",
+		Conflicts: []FieldConflict{{Manager: "kube-controller-manager", Field: ".spec.replicas"}},
+	}
+	want := "Deployment/istio-system/istiod has fields owned by other field managers: .spec.replicas (kube-controller-manager). " +
+		"Use --force-conflicts to take them over, or --conflicts=skip to leave the object unchanged"
+	if got := e.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if err := ValidateConflictPolicy("overwrite"); err == nil {
+		t.Errorf("got no error for an unknown conflict policy")
+	}
+}
+
+// conflictClient fails every server-side apply with a field conflict on .data.key.
+type conflictClient struct {
+	client.Client
+}
+
+func (c conflictClient) Patch(_ context.Context, _ runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return kerrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl" using v1`, Field: ".data.key"},
+	}, "Apply failed with 1 conflict")
+}
+
+func TestServerSideApplyConflicts(t *testing.T) {
+	iop := &valuesv1alpha1.IstioOperator{
+		ObjectMeta: metav1.ObjectMeta{Name: "installed-state", Namespace: "istio-system"},
+		Spec:       &v1alpha1.IstioOperatorSpec{},
+	}
+	live := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "istio-system"},
+		Data:       map[string]string{"key": "live"},
+	}
+	tests := []struct {
+		desc      string
+		conflicts string
+		object    string
+		wantErr   bool
+		want      []string
+	}{
+		{
+			desc:      "skip update",
+			conflicts: ConflictsSkip,
+			object:    "live",
+			want:      []string{"configmap/live skipped"},
+		},
+		{
+			desc:      "skip create",
+			conflicts: ConflictsSkip,
+			object:    "new",
+			want:      []string{"configmap/new skipped"},
+		},
+		{
+			desc:    "abort update",
+			object:  "live",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cl := conflictClient{fake.NewFakeClientWithScheme(scheme.Scheme, live.DeepCopy())}
+			h, err := NewHelmReconciler(cl, nil, iop, &Options{
+				ServerSideApply: true,
+				Conflicts:       tt.conflicts,
+				Log:             clog.NewConsoleLogger(false, ioutil.Discard, ioutil.Discard),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			obj, err := object.ParseYAMLToK8sObject([]byte(fmt.Sprintf(
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n  namespace: istio-system\ndata:\n  key: rendered\n", tt.object)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.ProcessObject(string(name.PilotComponentName), obj.UnstructuredObject())
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*ConflictError); tt.wantErr && !ok {
+				t.Errorf("got error %T, want *ConflictError", err)
+			}
+			report := h.ApplyReport()
+			var got []string
+			for _, o := range report.Objects {
+				got = append(got, o.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got objects %v, want %v", got, tt.want)
+			}
+			if len(tt.want) != 0 {
+				if got, want := report.Components[0].String(), "0 created, 0 configured, 0 unchanged, 1 skipped, 0 pruned"; got != want {
+					t.Errorf("got %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
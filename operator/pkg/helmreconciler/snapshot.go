@@ -0,0 +1,147 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/helm"
+	"istio.io/istio/operator/pkg/name"
+)
+
+const (
+	// manifestSnapshotSuffix is appended to the name of an IstioOperator CR to give the name of the ConfigMap holding
+	// the manifests last applied for it.
+	manifestSnapshotSuffix = "-manifests"
+	// manifestSnapshotKeySuffix is appended to a component name to give its key in the snapshot ConfigMap binaryData.
+	manifestSnapshotKeySuffix = ".yaml.gz"
+)
+
+// ManifestSnapshotName returns the name of the ConfigMap holding the manifest snapshot for the IstioOperator CR with
+// the given name.
+func ManifestSnapshotName(iopName string) string {
+	return iopName + manifestSnapshotSuffix
+}
+
+// SaveManifestSnapshot stores the manifests rendered in the last reconcile in a ConfigMap alongside the IstioOperator
+// CR, one gzip compressed entry per component, replacing any earlier snapshot. The ConfigMap is owned by the CR and
+// is garbage collected with it.
+func (h *HelmReconciler) SaveManifestSnapshot() error {
+	if h.opts.DryRun {
+		return nil
+	}
+	iop := &valuesv1alpha1.IstioOperator{}
+	if err := h.client.Get(context.TODO(), types.NamespacedName{Name: h.iop.Name, Namespace: h.iop.Namespace}, iop); err != nil {
+		return fmt.Errorf("failed to get IstioOperator before saving manifest snapshot: %s", err)
+	}
+	data := make(map[string][]byte)
+	for c, ms := range h.manifests {
+		b, err := compressManifest(strings.Join(ms, helm.YAMLSeparator))
+		if err != nil {
+			return fmt.Errorf("failed to compress manifest for component %s: %s", c, err)
+		}
+		data[string(c)+manifestSnapshotKeySuffix] = b
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManifestSnapshotName(h.iop.Name),
+			Namespace: h.iop.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(context.TODO(), h.client, cm, func() error {
+		cm.BinaryData = data
+		cm.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: valuesv1alpha1.IstioOperatorGVK.GroupVersion().String(),
+			Kind:       valuesv1alpha1.IstioOperatorGVK.Kind,
+			Name:       iop.Name,
+			UID:        iop.UID,
+		}}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save manifest snapshot %s/%s: %s", cm.Namespace, cm.Name, err)
+	}
+	return nil
+}
+
+// ReadManifestSnapshot returns the manifests saved by SaveManifestSnapshot for the IstioOperator CR with the given
+// name and namespace. It returns nil if there is no snapshot.
+func ReadManifestSnapshot(cl client.Client, iopName, namespace string) (name.ManifestMap, error) {
+	cm := &corev1.ConfigMap{}
+	nn := types.NamespacedName{Name: ManifestSnapshotName(iopName), Namespace: namespace}
+	if err := cl.Get(context.TODO(), nn, cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get manifest snapshot %s: %s", nn, err)
+	}
+	return manifestsFromSnapshotData(cm.BinaryData)
+}
+
+// manifestsFromSnapshotData decodes the binaryData of a snapshot ConfigMap into a ManifestMap.
+func manifestsFromSnapshotData(data map[string][]byte) (name.ManifestMap, error) {
+	out := make(name.ManifestMap)
+	for k, v := range data {
+		if !strings.HasSuffix(k, manifestSnapshotKeySuffix) {
+			continue
+		}
+		c := strings.TrimSuffix(k, manifestSnapshotKeySuffix)
+		m, err := decompressManifest(v)
+		if err != nil {
+			return nil, fmt.Errorf("bad manifest for component %s in snapshot: %s", c, err)
+		}
+		out[name.ComponentName(c)] = []string{m}
+	}
+	return out, nil
+}
+
+func compressManifest(m string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(m)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressManifest(b []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	out, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"sort"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+// isTarget reports whether the component c is applied, which is the case for all components unless Options.Targets
+// is set.
+func (h *HelmReconciler) isTarget(c string) bool {
+	return len(h.opts.Targets) == 0 || h.opts.Targets[name.ComponentName(c)]
+}
+
+// UntargetedChanges returns the sorted names of the components which are not in Options.Targets but whose rendered
+// manifests differ from the ones last installed successfully, as recorded in checkpoints by SetStatusCheckpoints.
+// Components which are no longer rendered but have a checkpoint are included too, since pruning them is skipped. A
+// partial apply of the targets leaves these components out of date.
+func (h *HelmReconciler) UntargetedChanges(checkpoints map[string]string) []string {
+	if len(h.opts.Targets) == 0 {
+		return nil
+	}
+	var changed []string
+	rendered := toChartManifestsMap(h.manifests)
+	for c, m := range rendered {
+		if !h.isTarget(c) && checkpoints[c] != manifestsChecksum(m) {
+			changed = append(changed, c)
+		}
+	}
+	for c := range checkpoints {
+		if _, ok := rendered[c]; !ok && !h.isTarget(c) {
+			changed = append(changed, c)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
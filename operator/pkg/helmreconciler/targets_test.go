@@ -0,0 +1,77 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestUntargetedChanges(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.IstioBaseComponentName: {"base"},
+		name.PilotComponentName:     {"pilot"},
+		name.IngressComponentName:   {"ingress"},
+	}
+	installed := make(map[string]string)
+	for c, m := range toChartManifestsMap(manifests) {
+		installed[c] = manifestsChecksum(m)
+	}
+	tests := []struct {
+		desc        string
+		targets     map[name.ComponentName]bool
+		checkpoints map[string]string
+		want        []string
+	}{
+		{
+			desc:        "no targets",
+			checkpoints: map[string]string{},
+		},
+		{
+			desc:        "unchanged",
+			targets:     map[name.ComponentName]bool{name.IngressComponentName: true},
+			checkpoints: installed,
+		},
+		{
+			desc:    "changed",
+			targets: map[name.ComponentName]bool{name.IngressComponentName: true},
+			checkpoints: map[string]string{
+				string(name.IstioBaseComponentName): installed[string(name.IstioBaseComponentName)],
+				string(name.PilotComponentName):     "old",
+				string(name.IngressComponentName):   "old",
+			},
+			want: []string{string(name.PilotComponentName)},
+		},
+		{
+			desc:    "not installed and removed",
+			targets: map[name.ComponentName]bool{name.IngressComponentName: true},
+			checkpoints: map[string]string{
+				string(name.PilotComponentName):  installed[string(name.PilotComponentName)],
+				string(name.EgressComponentName): "old",
+			},
+			want: []string{string(name.IstioBaseComponentName), string(name.EgressComponentName)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			h := &HelmReconciler{manifests: manifests, opts: &Options{Targets: tt.targets}}
+			if got := h.UntargetedChanges(tt.checkpoints); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	util2 "k8s.io/kubectl/pkg/util"
+)
+
+// threeWayMerge updates live in place to desired with the same three-way merge as kubectl apply. Fields set in
+// desired are set, and fields which were set in the last applied configuration recorded on live but are no longer in
+// desired are removed. Fields set by neither, like those defaulted by the API server or set by other controllers, are
+// kept. Built-in kinds are merged with a strategic merge patch, others, like custom resources, with a JSON merge
+// patch. desired must have the last applied configuration annotation of the rendered object, which is recorded on live
+// for the next merge, see util2.CreateApplyAnnotation. If live has none, because it was created by an older version or
+// by another tool, desired is overlaid without removing fields.
+func threeWayMerge(live, desired *unstructured.Unstructured) error {
+	original, err := util2.GetOriginalConfiguration(live)
+	if err != nil {
+		return err
+	}
+	if len(original) == 0 {
+		return applyOverlay(live, desired)
+	}
+	current, err := runtime.Encode(unstructured.UnstructuredJSONScheme, live)
+	if err != nil {
+		return err
+	}
+	modified, err := runtime.Encode(unstructured.UnstructuredJSONScheme, desired)
+	if err != nil {
+		return err
+	}
+
+	var merged []byte
+	versioned, err := scheme.Scheme.New(live.GroupVersionKind())
+	switch {
+	case runtime.IsNotRegisteredError(err):
+		patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current)
+		if err != nil {
+			return err
+		}
+		if merged, err = jsonpatch.MergePatch(current, patch); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		lookupPatchMeta, err := strategicpatch.NewPatchMetaFromStruct(versioned)
+		if err != nil {
+			return err
+		}
+		patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, lookupPatchMeta, true)
+		if err != nil {
+			return err
+		}
+		if merged, err = strategicpatch.StrategicMergePatchUsingLookupPatchMeta(current, patch, lookupPatchMeta); err != nil {
+			return err
+		}
+	}
+	return runtime.DecodeInto(unstructured.UnstructuredJSONScheme, merged, live)
+}
@@ -0,0 +1,223 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	util2 "k8s.io/kubectl/pkg/util"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestThreeWayMerge(t *testing.T) {
+	tests := []struct {
+		desc    string
+		applied string
+		live    string
+		desired string
+		path    []string
+		want    interface{}
+	}{
+		{
+			desc: "removed key",
+			applied: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  a: "1"
+  b: "2"
+`,
+			live: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  a: "1"
+  b: "2"
+  other: "3"
+`,
+			desired: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  a: "10"
+`,
+			path: []string{"data"},
+			want: map[string]interface{}{"a": "10", "other": "3"},
+		},
+		{
+			desc: "removed container env",
+			applied: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "2"
+`,
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: discovery
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "2"
+`,
+			desired: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        env:
+        - name: A
+          value: "1"
+`,
+			path: []string{"spec", "template", "spec", "containers"},
+			want: []interface{}{map[string]interface{}{
+				"name": "discovery",
+				"env":  []interface{}{map[string]interface{}{"name": "A", "value": "1"}},
+			}},
+		},
+		{
+			desc: "custom resource",
+			applied: `
+apiVersion: networking.istio.io/v1alpha3
+kind: EnvoyFilter
+metadata:
+  name: stats
+  namespace: istio-system
+spec:
+  a: 1
+  b: 2
+`,
+			live: `
+apiVersion: networking.istio.io/v1alpha3
+kind: EnvoyFilter
+metadata:
+  name: stats
+  namespace: istio-system
+spec:
+  a: 1
+  b: 2
+`,
+			desired: `
+apiVersion: networking.istio.io/v1alpha3
+kind: EnvoyFilter
+metadata:
+  name: stats
+  namespace: istio-system
+spec:
+  a: 1
+`,
+			path: []string{"spec"},
+			want: map[string]interface{}{"a": int64(1)},
+		},
+		{
+			desc: "no last applied configuration",
+			live: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  a: "1"
+  b: "2"
+`,
+			desired: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  a: "10"
+`,
+			path: []string{"data"},
+			want: map[string]interface{}{"a": "10", "b": "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			live := mustParse(t, tt.live)
+			if tt.applied != "" {
+				applied := mustParse(t, tt.applied)
+				if err := util2.CreateApplyAnnotation(applied, unstructured.UnstructuredJSONScheme); err != nil {
+					t.Fatal(err)
+				}
+				live.SetAnnotations(applied.GetAnnotations())
+			}
+			desired := mustParse(t, tt.desired)
+			if err := util2.CreateApplyAnnotation(desired, unstructured.UnstructuredJSONScheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := threeWayMerge(live, desired); err != nil {
+				t.Fatal(err)
+			}
+			got, _, _ := unstructured.NestedFieldNoCopy(live.Object, tt.path...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(live.GetAnnotations(), desired.GetAnnotations()) {
+				t.Errorf("got annotations %v, want %v", live.GetAnnotations(), desired.GetAnnotations())
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, y string) *unstructured.Unstructured {
+	t.Helper()
+	o, err := object.ParseYAMLToK8sObject([]byte(y))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o.UnstructuredObject()
+}
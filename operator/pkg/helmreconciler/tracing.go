@@ -0,0 +1,64 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+
+	"istio.io/istio/operator/pkg/util/clog"
+)
+
+const (
+	// spanPrefix is the prefix of the names of all spans created by HelmReconciler.
+	spanPrefix = "istio.io/operator/"
+	// traceIDLogField is the log field holding the ID of the reconcile trace.
+	traceIDLogField = "traceID"
+)
+
+// startReconcileSpan starts the root span of a reconcile of h and returns it with its context. Console log messages
+// written by h for the rest of the reconcile carry the trace ID, so that they can be matched with the trace.
+func (h *HelmReconciler) startReconcileSpan(ctx context.Context) (context.Context, *trace.Span) {
+	ctx, span := trace.StartSpan(ctx, spanPrefix+"reconcile")
+	span.AddAttributes(
+		trace.StringAttribute("iop.name", h.iop.Name),
+		trace.StringAttribute("iop.namespace", h.iop.Namespace),
+		trace.StringAttribute("iop.revision", h.iop.Spec.GetRevision()),
+	)
+	traceID := span.SpanContext().TraceID.String()
+	scope.Infof("Reconciling IstioOperator %s/%s, trace ID %s", h.iop.Namespace, h.iop.Name, traceID)
+
+	// Copy the options, they may be shared with other reconcilers.
+	opts := *h.opts
+	opts.Log = clog.WithField(opts.Log, traceIDLogField, traceID)
+	h.opts = &opts
+	return ctx, span
+}
+
+// startSpan starts a span with the given name for a phase of a reconcile, as a child of the span in ctx.
+func startSpan(ctx context.Context, name string, attributes ...trace.Attribute) (context.Context, *trace.Span) {
+	ctx, span := trace.StartSpan(ctx, spanPrefix+name)
+	span.AddAttributes(attributes...)
+	return ctx, span
+}
+
+// endSpan ends span, recording err as its status if it is not nil.
+func endSpan(span *trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	span.End()
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+// defaultIstioNamespace is the namespace of the control plane if values.global.istioNamespace is not set.
+const defaultIstioNamespace = "istio-system"
+
+// gatewayComponents are the components a user gateway CR installs.
+var gatewayComponents = map[name.ComponentName]bool{
+	name.IngressComponentName: true,
+	name.EgressComponentName:  true,
+}
+
+// gatewayManifests returns the manifests of the gateway components in manifests. The other components make up the
+// control plane, which a user gateway CR leaves to the CR of its revision.
+func gatewayManifests(manifests name.ManifestMap) name.ManifestMap {
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		if gatewayComponents[cn] {
+			out[cn] = ms
+		}
+	}
+	return out
+}
+
+// checkGatewayControlPlane returns an error if the istiod Service of the control plane revision which the user gateway
+// CR iop is installed against does not exist, since its gateways get their config and certificates from it.
+func checkGatewayControlPlane(cl client.Client, iop *valuesv1alpha1.IstioOperator) error {
+	svcName := "istiod"
+	if rev := iop.Spec.Revision; rev != "" {
+		svcName += "-" + rev
+	}
+	ns := istioNamespace(iop)
+	if err := cl.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: svcName}, &corev1.Service{}); err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("user gateway %s needs control plane revision %s, but Service %s/%s does not exist",
+				iop.Name, revisionOrDefault(iop.Spec.Revision), ns, svcName)
+		}
+		return fmt.Errorf("failed to get control plane Service %s/%s: %s", ns, svcName, err)
+	}
+	return nil
+}
+
+// istioNamespace returns the namespace of the control plane iop is installed against.
+func istioNamespace(iop *valuesv1alpha1.IstioOperator) string {
+	if global, ok := iop.Spec.Values["global"].(map[string]interface{}); ok {
+		if ns, ok := global["istioNamespace"].(string); ok && ns != "" {
+			return ns
+		}
+	}
+	return defaultIstioNamespace
+}
+
+func revisionOrDefault(revision string) string {
+	if revision == "" {
+		return defaultRevision
+	}
+	return revision
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestGatewayManifests(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.IstioBaseComponentName: {"base"},
+		name.PilotComponentName:     {"istiod"},
+		name.IngressComponentName:   {"ingress"},
+		name.EgressComponentName:    {"egress"},
+	}
+	want := name.ManifestMap{
+		name.IngressComponentName: {"ingress"},
+		name.EgressComponentName:  {"egress"},
+	}
+	if got := gatewayManifests(manifests); !reflect.DeepEqual(got, want) {
+		t.Errorf("gatewayManifests() = %v, want %v", got, want)
+	}
+}
+
+func TestIstioNamespace(t *testing.T) {
+	tests := []struct {
+		desc   string
+		values map[string]interface{}
+		want   string
+	}{
+		{
+			desc: "default",
+			want: defaultIstioNamespace,
+		},
+		{
+			desc:   "empty",
+			values: map[string]interface{}{"global": map[string]interface{}{"istioNamespace": ""}},
+			want:   defaultIstioNamespace,
+		},
+		{
+			desc:   "set",
+			values: map[string]interface{}{"global": map[string]interface{}{"istioNamespace": "istio-control"}},
+			want:   "istio-control",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			iop := &valuesv1alpha1.IstioOperator{Spec: &v1alpha1.IstioOperatorSpec{Values: tt.values}}
+			if got := istioNamespace(iop); got != tt.want {
+				t.Errorf("istioNamespace() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
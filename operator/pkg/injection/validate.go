@@ -0,0 +1,188 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package injection validates the sidecar injection templates of rendered manifests.
+package injection
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/kube/inject"
+)
+
+const (
+	injectorConfigMapName = "istio-sidecar-injector"
+	meshConfigMapName     = "istio"
+	// sidecarTemplateName names the default sidecar template in errors, the other templates are named in the config.
+	sidecarTemplateName = "sidecar"
+)
+
+// ValidateTemplates injects a sample pod with every template in the sidecar injector ConfigMap of revision
+// in manifests and validates the injected pod, so that a broken template fails generate instead of the injection of
+// every pod in the cluster. Nothing is checked if the manifests have no injector ConfigMap.
+func ValidateTemplates(manifests name.ManifestMap, revision string) error {
+	suffix := ""
+	if revision != "" {
+		suffix = "-" + revision
+	}
+	configMaps, err := manifestConfigMaps(manifests, injectorConfigMapName+suffix, meshConfigMapName+suffix)
+	if err != nil {
+		return err
+	}
+	injector, ok := configMaps[injectorConfigMapName+suffix]
+	if !ok {
+		return nil
+	}
+	cfg := &inject.Config{}
+	if err := yaml.Unmarshal([]byte(injector["config"]), cfg); err != nil {
+		return fmt.Errorf("could not parse the config of ConfigMap %s: %s", injectorConfigMapName+suffix, err)
+	}
+	mc, err := mesh.ApplyMeshConfigDefaults(configMaps[meshConfigMapName+suffix]["mesh"])
+	if err != nil {
+		return fmt.Errorf("could not parse the mesh config of ConfigMap %s: %s", meshConfigMapName+suffix, err)
+	}
+
+	templates := map[string]string{sidecarTemplateName: cfg.Template}
+	for tn, t := range cfg.Templates {
+		templates[tn] = t
+	}
+	var names []string
+	for tn := range templates {
+		names = append(names, tn)
+	}
+	sort.Strings(names)
+	var errs util.Errors
+	for _, tn := range names {
+		if err := validateInjectionTemplate(templates[tn], injector["values"], revision, mc); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("injection template %s: %s", tn, err))
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("injection templates failed validation:\n%s", errs)
+	}
+	return nil
+}
+
+// validateInjectionTemplate injects a sample pod with template and returns an error if the injected pod is invalid.
+func validateInjectionTemplate(template, values, revision string, mc *meshconfig.MeshConfig) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("template is empty")
+	}
+	out, err := inject.IntoObject(template, values, revision, mc, samplePod())
+	if err != nil {
+		return err
+	}
+	pod, ok := out.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("injection returned %T, want a Pod", out)
+	}
+	return validatePod(pod).ToError()
+}
+
+// samplePod returns the pod which the injection templates are validated with.
+func samplePod() *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "injection-sample", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "app:latest",
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			}},
+		},
+	}
+}
+
+// validatePod checks the fields of pod which the API server requires and which an injection template sets, i.e. the
+// names and images of the containers, their ports and the volumes they mount.
+func validatePod(pod *corev1.Pod) util.Errors {
+	var errs util.Errors
+	volumes := make(map[string]bool)
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == "" {
+			errs = util.AppendErr(errs, fmt.Errorf("volume without a name"))
+		} else if volumes[v.Name] {
+			errs = util.AppendErr(errs, fmt.Errorf("duplicate volume %s", v.Name))
+		}
+		volumes[v.Name] = true
+	}
+	containers := make(map[string]bool)
+	for _, c := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		switch {
+		case c.Name == "":
+			errs = util.AppendErr(errs, fmt.Errorf("container without a name"))
+		case containers[c.Name]:
+			errs = util.AppendErr(errs, fmt.Errorf("duplicate container %s", c.Name))
+		}
+		containers[c.Name] = true
+		if c.Image == "" {
+			errs = util.AppendErr(errs, fmt.Errorf("container %s has no image", c.Name))
+		}
+		for _, p := range c.Ports {
+			if p.ContainerPort < 1 || p.ContainerPort > 65535 {
+				errs = util.AppendErr(errs, fmt.Errorf("container %s has invalid port %d", c.Name, p.ContainerPort))
+			}
+		}
+		for _, m := range c.VolumeMounts {
+			if !volumes[m.Name] {
+				errs = util.AppendErr(errs, fmt.Errorf("container %s mounts unknown volume %s", c.Name, m.Name))
+			}
+			if m.MountPath == "" {
+				errs = util.AppendErr(errs, fmt.Errorf("container %s mounts volume %s without a path", c.Name, m.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// manifestConfigMaps returns the data of the ConfigMaps in manifests with the given names.
+func manifestConfigMaps(manifests name.ManifestMap, names ...string) (map[string]map[string]string, error) {
+	wanted := make(map[string]bool)
+	for _, n := range names {
+		wanted[n] = true
+	}
+	out := make(map[string]map[string]string)
+	for _, ms := range manifests {
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range objs {
+				if o.Kind != "ConfigMap" || !wanted[o.Name] {
+					continue
+				}
+				data, _, err := unstructured.NestedStringMap(o.UnstructuredObject().Object, "data")
+				if err != nil {
+					return nil, fmt.Errorf("ConfigMap %s: %s", o.Name, err)
+				}
+				out[o.Name] = data
+			}
+		}
+	}
+	return out, nil
+}
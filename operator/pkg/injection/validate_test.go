@@ -0,0 +1,115 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package injection
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/pkg/kube/inject"
+)
+
+func TestValidateInjectionTemplates(t *testing.T) {
+	tests := []struct {
+		desc      string
+		cfg       inject.Config
+		wantErr   string
+		noConfigs bool
+	}{
+		{
+			desc: "valid",
+			cfg: inject.Config{
+				Template:  "containers:\n- name: istio-proxy\n  image: proxyv2\n",
+				Templates: map[string]string{"gateway": "containers:\n- name: istio-proxy\n  image: proxyv2\n"},
+			},
+		},
+		{
+			desc:    "template syntax error",
+			cfg:     inject.Config{Template: "containers:\n- name: {{ .Values.global.hub\n"},
+			wantErr: "injection template sidecar",
+		},
+		{
+			desc: "named template without image",
+			cfg: inject.Config{
+				Template:  "containers:\n- name: istio-proxy\n  image: proxyv2\n",
+				Templates: map[string]string{"gateway": "containers:\n- name: istio-proxy\n"},
+			},
+			wantErr: "injection template gateway: container istio-proxy has no image",
+		},
+		{
+			desc:    "unknown volume",
+			cfg:     inject.Config{Template: "containers:\n- name: istio-proxy\n  image: proxyv2\n  volumeMounts:\n  - name: certs\n    mountPath: /etc/certs\n"},
+			wantErr: "container istio-proxy mounts unknown volume certs",
+		},
+		{
+			desc:      "no injector",
+			noConfigs: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			manifests := name.ManifestMap{}
+			if !tt.noConfigs {
+				manifests[name.PilotComponentName] = []string{injectorConfigMap(t, &tt.cfg)}
+			}
+			err := ValidateTemplates(manifests, "")
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ValidateTemplates() got error %s, want none", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ValidateTemplates() got error %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePod(t *testing.T) {
+	pod := samplePod()
+	pod.Spec.Containers = append(pod.Spec.Containers,
+		corev1.Container{Name: "app", Image: "app", Ports: []corev1.ContainerPort{{ContainerPort: 70000}}},
+		corev1.Container{Image: "sidecar"})
+	pod.Spec.Volumes = []corev1.Volume{{Name: "data"}, {Name: "data"}}
+	want := []string{
+		"duplicate volume data",
+		"duplicate container app",
+		"container app has invalid port 70000",
+		"container without a name",
+	}
+	if got := validatePod(pod).String(); got != strings.Join(want, ", ") {
+		t.Errorf("validatePod() got:\n%s\nwant:\n%s", got, strings.Join(want, ", "))
+	}
+}
+
+func injectorConfigMap(t *testing.T, cfg *inject.Config) string {
+	t.Helper()
+	c, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": injectorConfigMapName, "namespace": "istio-system"},
+		"data":       map[string]interface{}{"config": string(c), "values": "{}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(cm)
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipfamily configures rendered Services for single-stack IPv6 and dual-stack clusters.
+package ipfamily
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// IPv4 is the IPv4 family.
+	IPv4 = "IPv4"
+	// IPv6 is the IPv6 family.
+	IPv6 = "IPv6"
+
+	// SingleStack services have one IP of the primary family.
+	SingleStack = "SingleStack"
+	// PreferDualStack services have IPs of both families if the cluster supports it.
+	PreferDualStack = "PreferDualStack"
+	// RequireDualStack services must have IPs of both families.
+	RequireDualStack = "RequireDualStack"
+
+	familiesValuesPath = "global.ipFamilies"
+	policyValuesPath   = "global.ipFamilyPolicy"
+)
+
+var (
+	// Policies are the valid IP family policies.
+	Policies = map[string]bool{SingleStack: true, PreferDualStack: true, RequireDualStack: true}
+)
+
+// Settings returns the IP families and IP family policy from values.global.ipFamilies and values.global.ipFamilyPolicy
+// of the given values tree. families is nil if no families are set.
+func Settings(values map[string]interface{}) (families []string, policy string) {
+	if v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(familiesValuesPath)); found {
+		if l, ok := v.([]interface{}); ok {
+			for _, f := range l {
+				families = append(families, fmt.Sprint(f))
+			}
+		}
+	}
+	if v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(policyValuesPath)); found {
+		policy, _ = v.(string)
+	}
+	return families, policy
+}
+
+// Apply returns manifest with spec.ipFamilies and spec.ipFamilyPolicy set on all Services except ExternalName ones.
+// If policy is empty, it defaults to SingleStack for one family and PreferDualStack for two.
+func Apply(manifest string, families []string, policy string) (string, error) {
+	if len(families) == 0 {
+		return manifest, nil
+	}
+	if policy == "" {
+		policy = SingleStack
+		if len(families) > 1 {
+			policy = PreferDualStack
+		}
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	var out object.K8sObjects
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		if t, _, _ := unstructured.NestedString(u.Object, "spec", "type"); o.Kind == "Service" && t != "ExternalName" {
+			if err := unstructured.SetNestedStringSlice(u.Object, families, "spec", "ipFamilies"); err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+			if err := unstructured.SetNestedField(u.Object, policy, "spec", "ipFamilyPolicy"); err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// CheckCluster returns an error if the pod CIDRs of the cluster nodes do not include all of families. Nodes without
+// pod CIDRs are ignored, since CIDR allocation may be done outside of Kubernetes.
+func CheckCluster(cs kubernetes.Interface, families []string) error {
+	if len(families) == 0 || (len(families) == 1 && families[0] == IPv4) {
+		return nil
+	}
+	nodes, err := cs.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("could not list nodes to check IP families: %s", err)
+	}
+	for _, n := range nodes.Items {
+		cidrs := n.Spec.PodCIDRs
+		if len(cidrs) == 0 && n.Spec.PodCIDR != "" {
+			cidrs = []string{n.Spec.PodCIDR}
+		}
+		if len(cidrs) == 0 {
+			continue
+		}
+		have := make(map[string]bool)
+		for _, c := range cidrs {
+			if f := cidrFamily(c); f != "" {
+				have[f] = true
+			}
+		}
+		for _, f := range families {
+			if !have[f] {
+				return fmt.Errorf("node %s has no %s pod CIDR (pod CIDRs: %s), the cluster does not support IP families %s",
+					n.Name, f, strings.Join(cidrs, ","), strings.Join(families, ","))
+			}
+		}
+	}
+	return nil
+}
+
+func cidrFamily(cidr string) string {
+	ip, _, err := net.ParseCIDR(cidr)
+	switch {
+	case err != nil:
+		return ""
+	case ip.To4() != nil:
+		return IPv4
+	default:
+		return IPv6
+	}
+}
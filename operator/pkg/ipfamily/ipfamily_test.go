@@ -0,0 +1,122 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfamily
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestApply(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  ports:
+  - port: 15012
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: external
+  namespace: istio-system
+spec:
+  type: ExternalName
+  externalName: example.com
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+`
+	got, err := Apply(manifest, []string{IPv6, IPv4}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPolicies := []string{PreferDualStack, "", ""}
+	for i, o := range objs {
+		policy, _, _ := unstructured.NestedString(o.Unstructured(), "spec", "ipFamilyPolicy")
+		if policy != wantPolicies[i] {
+			t.Errorf("%s: got ipFamilyPolicy %q, want %q", o.Hash(), policy, wantPolicies[i])
+		}
+	}
+	families, _, _ := unstructured.NestedStringSlice(objs[0].Unstructured(), "spec", "ipFamilies")
+	if want := []string{IPv6, IPv4}; !reflect.DeepEqual(families, want) {
+		t.Errorf("got ipFamilies %v, want %v", families, want)
+	}
+}
+
+func TestCheckCluster(t *testing.T) {
+	node := func(name string, cidrs ...string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: corev1.NodeSpec{PodCIDRs: cidrs}}
+	}
+	tests := []struct {
+		desc     string
+		nodes    []*corev1.Node
+		families []string
+		wantErr  bool
+	}{
+		{
+			desc:     "dual-stack",
+			nodes:    []*corev1.Node{node("a", "10.244.0.0/24", "fd00:10:244::/64")},
+			families: []string{IPv4, IPv6},
+		},
+		{
+			desc:     "IPv6 only",
+			nodes:    []*corev1.Node{node("a", "fd00:10:244::/64")},
+			families: []string{IPv6},
+		},
+		{
+			desc:     "IPv4 only cluster",
+			nodes:    []*corev1.Node{node("a", "10.244.0.0/24"), node("b", "10.244.1.0/24")},
+			families: []string{IPv4, IPv6},
+			wantErr:  true,
+		},
+		{
+			desc:     "no pod CIDRs",
+			nodes:    []*corev1.Node{node("a")},
+			families: []string{IPv6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cs := fake.NewSimpleClientset()
+			for _, n := range tt.nodes {
+				if _, err := cs.CoreV1().Nodes().Create(context.TODO(), n, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := CheckCluster(cs, tt.families); (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
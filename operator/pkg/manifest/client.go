@@ -26,6 +26,9 @@ import (
 	"istio.io/istio/operator/pkg/version"
 )
 
+// proxyContainerName is the name of the injected sidecar proxy container.
+const proxyContainerName = "istio-proxy"
+
 // Client is a helper wrapper around the Kube RESTClient for istioctl -> Pilot/Envoy/Mesh related things
 type Client struct {
 	Config *rest.Config
@@ -47,6 +50,7 @@ func (cv ComponentVersion) String() string {
 // ExecClient is an interface for remote execution
 type ExecClient interface {
 	GetIstioVersions(namespace string) ([]ComponentVersion, error)
+	GetProxyVersions() ([]ComponentVersion, error)
 	GetPods(namespace string, params map[string]string) (*v1.PodList, error)
 	PodsForSelector(namespace, labelSelector string) (*v1.PodList, error)
 	ConfigMapForSelector(namespace, labelSelector string) (*v1.ConfigMapList, error)
@@ -119,6 +123,42 @@ func (client *Client) GetIstioVersions(namespace string) ([]ComponentVersion, er
 	return res, errs.ToError()
 }
 
+// GetProxyVersions gets the version of the sidecar proxy of each running pod in the cluster. Gateway pods, which are
+// Istio components, are not included.
+func (client *Client) GetProxyVersions() ([]ComponentVersion, error) {
+	pods, err := client.GetPods("", map[string]string{
+		"fieldSelector": "status.phase=Running",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve pods, error: %v", err)
+	}
+
+	var errs util.Errors
+	var res []ComponentVersion
+	for _, pod := range pods.Items {
+		if _, ok := pod.Labels["istio"]; ok {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name != proxyContainerName {
+				continue
+			}
+			tag, err := parseTag(c.Image)
+			if err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			v, err := version.TagToVersionString(tag)
+			if err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("unable to convert tag %s into version in pod %s/%s", tag, pod.Namespace, pod.Name))
+				continue
+			}
+			res = append(res, ComponentVersion{Component: "sidecar", Version: v, Pod: pod})
+		}
+	}
+	return res, errs.ToError()
+}
+
 func parseTag(image string) (string, error) {
 	ref, err := reference.Parse(image)
 	if err != nil {
@@ -0,0 +1,74 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+// SelectableComponentNames are the names of the components which FilterComponents selects from.
+var SelectableComponentNames = append(append([]name.ComponentName{}, name.AllCoreComponentNames...),
+	name.IngressComponentName, name.EgressComponentName, name.AddonComponentName)
+
+// FilterComponents returns manifests with only the given components, which are matched case insensitively against
+// SelectableComponentNames. All manifests are returned if components is empty. It is an error to select an unknown
+// component. A selected component which is not enabled is left out.
+func FilterComponents(manifests name.ManifestMap, components []string) (name.ManifestMap, error) {
+	if len(components) == 0 {
+		return manifests, nil
+	}
+	selected, err := SelectComponents(components)
+	if err != nil {
+		return nil, err
+	}
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		if selected[cn] {
+			out[cn] = ms
+		}
+	}
+	return out, nil
+}
+
+// SelectComponents returns the set of SelectableComponentNames matching components case insensitively. It is an error
+// to select an unknown component.
+func SelectComponents(components []string) (map[name.ComponentName]bool, error) {
+	selected := make(map[name.ComponentName]bool)
+	for _, c := range components {
+		cn, ok := selectableComponentName(strings.TrimSpace(c))
+		if !ok {
+			var valid []string
+			for _, n := range SelectableComponentNames {
+				valid = append(valid, string(n))
+			}
+			return nil, fmt.Errorf("unknown component %s, must be one of %s", c, strings.Join(valid, ", "))
+		}
+		selected[cn] = true
+	}
+	return selected, nil
+}
+
+// selectableComponentName returns the name in SelectableComponentNames which equals c ignoring case.
+func selectableComponentName(c string) (name.ComponentName, bool) {
+	for _, n := range SelectableComponentNames {
+		if strings.EqualFold(string(n), c) {
+			return n, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestFilterComponents(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.IstioBaseComponentName: {"base"},
+		name.PilotComponentName:     {"pilot"},
+		name.IngressComponentName:   {"ingress-1", "ingress-2"},
+		name.CNIComponentName:       {""},
+	}
+	tests := []struct {
+		desc       string
+		components []string
+		want       name.ManifestMap
+		wantErr    bool
+	}{
+		{
+			desc: "all",
+			want: manifests,
+		},
+		{
+			desc:       "selected",
+			components: []string{"Pilot", "IngressGateways"},
+			want: name.ManifestMap{
+				name.PilotComponentName:   {"pilot"},
+				name.IngressComponentName: {"ingress-1", "ingress-2"},
+			},
+		},
+		{
+			desc:       "case insensitive",
+			components: []string{"cni", " base"},
+			want: name.ManifestMap{
+				name.IstioBaseComponentName: {"base"},
+				name.CNIComponentName:       {""},
+			},
+		},
+		{
+			desc:       "not rendered",
+			components: []string{"EgressGateways"},
+			want:       name.ManifestMap{},
+		},
+		{
+			desc:       "unknown",
+			components: []string{"Pilot", "Galley"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := FilterComponents(manifests, tt.components)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
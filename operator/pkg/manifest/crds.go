@@ -0,0 +1,89 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// IncludeCRDs generates and applies CRDs together with all other objects.
+	IncludeCRDs = "include"
+	// OnlyCRDs generates and applies only CRDs.
+	OnlyCRDs = "only"
+	// SkipCRDs generates and applies everything but CRDs.
+	SkipCRDs = "skip"
+
+	crdKind = "CustomResourceDefinition"
+)
+
+var (
+	// CRDModes are the valid modes for including CRDs.
+	CRDModes = map[string]bool{IncludeCRDs: true, OnlyCRDs: true, SkipCRDs: true}
+)
+
+// ValidateCRDMode returns an error if mode is not empty and not one of CRDModes.
+func ValidateCRDMode(mode string) error {
+	if mode != "" && !CRDModes[mode] {
+		return fmt.Errorf("unknown CRD mode %s, must be one of %s, %s or %s", mode, IncludeCRDs, OnlyCRDs, SkipCRDs)
+	}
+	return nil
+}
+
+// FilterCRDs returns manifests with only the CRDs if mode is OnlyCRDs, or with all objects except CRDs if mode is
+// SkipCRDs. For any other mode, manifests are returned unchanged. Components left without objects are kept with no
+// manifests, since the install order waits on them.
+func FilterCRDs(manifests name.ManifestMap, mode string) (name.ManifestMap, error) {
+	if mode != OnlyCRDs && mode != SkipCRDs {
+		return manifests, nil
+	}
+	return FilterObjects(manifests, func(o *object.K8sObject) bool {
+		return (o.Kind == crdKind) == (mode == OnlyCRDs)
+	})
+}
+
+// FilterObjects returns manifests with only the objects selected by keep, e.g. a Predicate parsed with
+// object.ParseFilter. Components left without objects are kept with no manifests.
+func FilterObjects(manifests name.ManifestMap, keep object.Predicate) (name.ManifestMap, error) {
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		out[cn] = nil
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			var kept object.K8sObjects
+			for _, o := range objs {
+				if keep(o) {
+					kept = append(kept, o)
+				}
+			}
+			if len(kept) == 0 {
+				continue
+			}
+			ym, err := kept.YAMLManifest()
+			if err != nil {
+				return nil, err
+			}
+			out[cn] = append(out[cn], strings.TrimSuffix(ym, object.YAMLSeparator))
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestFilterCRDs(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.IstioBaseComponentName: {`
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istiod-istio-system
+`},
+		name.PilotComponentName: {`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+`},
+	}
+	tests := []struct {
+		mode string
+		want map[name.ComponentName][]string
+	}{
+		{
+			mode: IncludeCRDs,
+			want: map[name.ComponentName][]string{
+				name.IstioBaseComponentName: {"CustomResourceDefinition::gateways.networking.istio.io", "ClusterRole::istiod-istio-system"},
+				name.PilotComponentName:     {"Deployment:istio-system:istiod"},
+			},
+		},
+		{
+			mode: OnlyCRDs,
+			want: map[name.ComponentName][]string{
+				name.IstioBaseComponentName: {"CustomResourceDefinition::gateways.networking.istio.io"},
+				name.PilotComponentName:     nil,
+			},
+		},
+		{
+			mode: SkipCRDs,
+			want: map[name.ComponentName][]string{
+				name.IstioBaseComponentName: {"ClusterRole::istiod-istio-system"},
+				name.PilotComponentName:     {"Deployment:istio-system:istiod"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			mm, err := FilterCRDs(manifests, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[name.ComponentName][]string)
+			for cn, ms := range mm {
+				got[cn] = nil
+				for _, m := range ms {
+					objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, o := range objs {
+						got[cn] = append(got[cn], o.Hash())
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := ValidateCRDMode("all"); err == nil {
+		t.Error("ValidateCRDMode(all): got no error, want error")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// daemonSetReadyPercent is the percentage of the eligible nodes of a DaemonSet which must run a ready pod of it for
+// the DaemonSet to be ready.
+var daemonSetReadyPercent = 100
+
+// SetDaemonSetReadyThreshold sets the percentage of the eligible nodes of a DaemonSet which must run a ready pod of
+// it for WaitForResources to consider the DaemonSet ready. The default is 100.
+func SetDaemonSetReadyThreshold(percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("the DaemonSet ready threshold must be a percentage between 0 and 100, got %d", percent)
+	}
+	daemonSetReadyPercent = percent
+	return nil
+}
+
+// daemonSet is the readiness of a DaemonSet.
+type daemonSet struct {
+	daemonSet *appsv1.DaemonSet
+	// eligible is the number of nodes the pods of the DaemonSet can run and become ready on, and ready the number of
+	// these which run a ready pod of it.
+	eligible int
+	ready    int
+}
+
+// newDaemonSet returns the readiness of ds with the given pods on nodes. Pods on nodes which are not eligible, like
+// cordoned nodes or nodes with taints the pods do not tolerate, are not counted.
+func newDaemonSet(ds *appsv1.DaemonSet, pods []v1.Pod, nodes []v1.Node) daemonSet {
+	d := daemonSet{daemonSet: ds}
+	eligible := make(map[string]bool)
+	for i := range nodes {
+		if isEligibleNode(&nodes[i], &ds.Spec.Template.Spec) {
+			eligible[nodes[i].Name] = true
+		}
+	}
+	d.eligible = len(eligible)
+	readyNodes := make(map[string]bool)
+	for i := range pods {
+		if eligible[pods[i].Spec.NodeName] && isPodReady(&pods[i]) {
+			readyNodes[pods[i].Spec.NodeName] = true
+		}
+	}
+	d.ready = len(readyNodes)
+	return d
+}
+
+// isReady reports whether at least daemonSetReadyPercent of the eligible nodes of d run a ready pod.
+func (d daemonSet) isReady() bool {
+	return d.ready*100 >= daemonSetReadyPercent*d.eligible
+}
+
+// isEligibleNode reports whether a pod with podSpec can run and become ready on node: the node is ready and not
+// cordoned, has the labels of the node selector, and has no NoSchedule or NoExecute taints the pod does not tolerate.
+func isEligibleNode(node *v1.Node, podSpec *v1.PodSpec) bool {
+	if node.Spec.Unschedulable || !isNodeReady(node) {
+		return false
+	}
+	if !labels.SelectorFromSet(podSpec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for j := range podSpec.Tolerations {
+			if podSpec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+func isNodeReady(node *v1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func daemonSetsReady(daemonSets []daemonSet) (bool, []string) {
+	var notReady []string
+	for _, d := range daemonSets {
+		if !d.isReady() {
+			notReady = append(notReady, "DaemonSet/"+d.daemonSet.Namespace+"/"+d.daemonSet.Name)
+		}
+	}
+	return len(notReady) == 0, notReady
+}
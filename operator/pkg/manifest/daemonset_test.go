@@ -0,0 +1,105 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name string, unschedulable bool, taints ...v1.Taint) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1.NodeSpec{Unschedulable: unschedulable, Taints: taints},
+		Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}},
+	}
+}
+
+func testPod(nodeName string, ready bool) v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return v1.Pod{
+		Spec:   v1.PodSpec{NodeName: nodeName},
+		Status: v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}}},
+	}
+}
+
+func TestDaemonSetReadiness(t *testing.T) {
+	gpuTaint := v1.Taint{Key: "nvidia.com/gpu", Effect: v1.TaintEffectNoSchedule}
+	nodes := []v1.Node{
+		testNode("a", false),
+		testNode("b", false),
+		testNode("cordoned", true),
+		testNode("gpu", false, gpuTaint),
+		testNode("prefer", false, v1.Taint{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}),
+	}
+	tests := []struct {
+		desc         string
+		tolerations  []v1.Toleration
+		pods         []v1.Pod
+		percent      int
+		wantEligible int
+		wantReady    int
+		want         bool
+	}{
+		{
+			desc:         "pending pods on cordoned and tainted nodes are ignored",
+			pods:         []v1.Pod{testPod("a", true), testPod("b", true), testPod("prefer", true), testPod("cordoned", false), testPod("gpu", false)},
+			percent:      100,
+			wantEligible: 3,
+			wantReady:    3,
+			want:         true,
+		},
+		{
+			desc:         "tolerated taint",
+			tolerations:  []v1.Toleration{{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists}},
+			pods:         []v1.Pod{testPod("a", true), testPod("b", true), testPod("prefer", true), testPod("gpu", false)},
+			percent:      100,
+			wantEligible: 4,
+			wantReady:    3,
+			want:         false,
+		},
+		{
+			desc:         "threshold",
+			pods:         []v1.Pod{testPod("a", true), testPod("b", true), testPod("prefer", false)},
+			percent:      60,
+			wantEligible: 3,
+			wantReady:    2,
+			want:         true,
+		},
+	}
+	defer func() { daemonSetReadyPercent = 100 }()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := SetDaemonSetReadyThreshold(tt.percent); err != nil {
+				t.Fatal(err)
+			}
+			ds := &appsv1.DaemonSet{}
+			ds.Spec.Template.Spec.Tolerations = tt.tolerations
+			d := newDaemonSet(ds, tt.pods, nodes)
+			if d.eligible != tt.wantEligible || d.ready != tt.wantReady {
+				t.Errorf("got %d/%d ready, want %d/%d", d.ready, d.eligible, tt.wantReady, tt.wantEligible)
+			}
+			if got := d.isReady(); got != tt.want {
+				t.Errorf("got ready %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	// discoveryCacheTTL is how long the discovery results cached on disk are used before they are fetched again, the
+	// same as kubectl.
+	discoveryCacheTTL = 10 * time.Minute
+)
+
+var (
+	// discoveryCacheDir is the directory the discovery results are cached in between runs. They are only cached in
+	// memory if it is empty.
+	discoveryCacheDir string
+	cachedDiscovery   discovery.CachedDiscoveryInterface
+	restMapper        *retryingMapper
+)
+
+// SetDiscoveryCacheDir sets the directory the discovery results of the cluster are cached in between runs, for
+// example ~/.kube/cache/discovery/<host> as kubectl does. An empty dir caches them in memory for the current run
+// only. It must be called before InitK8SRestClient.
+func SetDiscoveryCacheDir(dir string) {
+	discoveryCacheDir = dir
+	cachedDiscovery, restMapper = nil, nil
+}
+
+// CachedDiscoveryClient returns the discovery client of the cluster last set up by InitK8SRestClient. Its results
+// are shared by all components applied within a run, so that the API groups and resources of a cluster are only
+// fetched once instead of once per component.
+func CachedDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	if k8sRESTConfig == nil {
+		return nil, fmt.Errorf("the k8s client is not initialized")
+	}
+	if cachedDiscovery != nil {
+		return cachedDiscovery, nil
+	}
+	dc, err := newCachedDiscoveryClient(k8sRESTConfig, discoveryCacheDir)
+	if err != nil {
+		return nil, err
+	}
+	cachedDiscovery = dc
+	return cachedDiscovery, nil
+}
+
+// RESTMapper returns a RESTMapper backed by CachedDiscoveryClient. A kind the cached results have no mapping for,
+// such as one whose CRD was applied after they were fetched, causes the results to be fetched again once.
+func RESTMapper() (meta.RESTMapper, error) {
+	if restMapper != nil {
+		return restMapper, nil
+	}
+	dc, err := CachedDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	restMapper = &retryingMapper{DeferredDiscoveryRESTMapper: restmapper.NewDeferredDiscoveryRESTMapper(dc)}
+	return restMapper, nil
+}
+
+func newCachedDiscoveryClient(config *rest.Config, cacheDir string) (discovery.CachedDiscoveryInterface, error) {
+	if cacheDir == "" {
+		dc, err := discovery.NewDiscoveryClientForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return memory.NewMemCacheClient(dc), nil
+	}
+	return disk.NewCachedDiscoveryClientForConfig(config, cacheDir, "", discoveryCacheTTL)
+}
+
+// resetDiscoveryCache drops the cached discovery results, which belong to the cluster of the previous REST config.
+func resetDiscoveryCache() {
+	cachedDiscovery, restMapper = nil, nil
+}
+
+// retryingMapper is a DeferredDiscoveryRESTMapper which fetches the discovery results again when they have no
+// mapping for a kind. The DeferredDiscoveryRESTMapper only does so for results which are not fresh, and the results
+// cached in memory are always fresh, so the kinds of CRDs applied during a run would otherwise never be found.
+type retryingMapper struct {
+	*restmapper.DeferredDiscoveryRESTMapper
+}
+
+// RESTMapping implements meta.RESTMapper.
+func (m *retryingMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mapping, err := m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		return m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	}
+	return mapping, err
+}
+
+// RESTMappings implements meta.RESTMapper.
+func (m *retryingMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	mappings, err := m.DeferredDiscoveryRESTMapper.RESTMappings(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		return m.DeferredDiscoveryRESTMapper.RESTMappings(gk, versions...)
+	}
+	return mappings, err
+}
+
+// KindFor implements meta.RESTMapper.
+func (m *retryingMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	gvk, err := m.DeferredDiscoveryRESTMapper.KindFor(resource)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		return m.DeferredDiscoveryRESTMapper.KindFor(resource)
+	}
+	return gvk, err
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/restmapper"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRetryingMapper(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "services", Kind: "Service", Namespaced: true}},
+	}}
+	m := &retryingMapper{DeferredDiscoveryRESTMapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))}
+
+	gk := schema.GroupKind{Group: "networking.istio.io", Kind: "Gateway"}
+	if _, err := m.RESTMapping(gk, "v1alpha3"); err == nil {
+		t.Fatalf("got mapping for %s before its CRD was applied, want error", gk)
+	}
+	if _, err := m.RESTMapping(schema.GroupKind{Kind: "Service"}, "v1"); err != nil {
+		t.Fatalf("got error %s for Service, want mapping", err)
+	}
+
+	// As if the CRD was applied after the discovery results were cached.
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: "networking.istio.io/v1alpha3",
+		APIResources: []metav1.APIResource{{Name: "gateways", Kind: "Gateway", Namespaced: true}},
+	})
+	got, err := m.RESTMapping(gk, "v1alpha3")
+	if err != nil {
+		t.Fatalf("got error %s after the CRD was applied, want mapping", err)
+	}
+	if want := "gateways"; got.Resource.Resource != want {
+		t.Errorf("got resource %s, want %s", got.Resource.Resource, want)
+	}
+}
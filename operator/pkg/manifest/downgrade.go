@@ -0,0 +1,139 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"istio.io/istio/operator/pkg/version"
+)
+
+// IsDowngrade reports whether targetVersion is older than currentVersion. Only the major, minor and patch versions
+// are compared.
+func IsDowngrade(currentVersion, targetVersion string) (bool, error) {
+	cur, err := version.NewVersionFromString(currentVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the current version %s: %s", currentVersion, err)
+	}
+	tar, err := version.NewVersionFromString(targetVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the target version %s: %s", targetVersion, err)
+	}
+	switch {
+	case tar.Major != cur.Major:
+		return tar.Major < cur.Major, nil
+	case tar.Minor != cur.Minor:
+		return tar.Minor < cur.Minor, nil
+	default:
+		return tar.Patch < cur.Patch, nil
+	}
+}
+
+// CheckDowngrade returns an error if a downgrade from currentVersion to targetVersion crosses more than
+// MaxMinorVersionSkew minor versions, since the configuration of older releases is not kept around for longer.
+func CheckDowngrade(currentVersion, targetVersion string) error {
+	cur, err := version.NewVersionFromString(currentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse the current version %s: %s", currentVersion, err)
+	}
+	tar, err := version.NewVersionFromString(targetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse the target version %s: %s", targetVersion, err)
+	}
+	if tooFarBehind(tar, cur) {
+		return fmt.Errorf("%s cannot be downgraded to %s directly, downgrade at most %d minor version(s) at a time",
+			currentVersion, targetVersion, MaxMinorVersionSkew)
+	}
+	return nil
+}
+
+// FieldsRequiringNewerVersion scans the IstioOperatorSpec YAML installed, which is in use in the cluster, for the
+// fields which a downgrade would lose. A field counts as used if installed sets it to a value other than the one in
+// currentDefaults, the profile defaults of the installed version, and it is lost if it is not known to
+// targetDefaults, the profile defaults of the target version. A field is known if targetDefaults holds it, or holds
+// one of its parents as a leaf, e.g. an empty map for labels. The returned paths are sorted.
+func FieldsRequiringNewerVersion(installed, currentDefaults, targetDefaults string) ([]string, error) {
+	trees := make([]map[string]interface{}, 3)
+	for i, y := range []string{installed, currentDefaults, targetDefaults} {
+		if err := yaml.Unmarshal([]byte(y), &trees[i]); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal IstioOperatorSpec: %s", err)
+		}
+	}
+	inUse, current, target := trees[0], trees[1], trees[2]
+
+	var out []string
+	walkLeaves(inUse, nil, func(path []string, value interface{}) {
+		if value == nil {
+			return
+		}
+		if cv, ok := nodeAt(current, path); ok && reflect.DeepEqual(cv, value) {
+			return
+		}
+		if !knownPath(target, path) {
+			out = append(out, strings.Join(path, "."))
+		}
+	})
+	sort.Strings(out)
+	return out, nil
+}
+
+// walkLeaves calls f with the path and value of every leaf of tree. Lists and empty maps are leaves.
+func walkLeaves(tree map[string]interface{}, path []string, f func(path []string, value interface{})) {
+	for k, v := range tree {
+		p := append(append([]string{}, path...), k)
+		if m, ok := v.(map[string]interface{}); ok && len(m) != 0 {
+			walkLeaves(m, p, f)
+			continue
+		}
+		f(p, v)
+	}
+}
+
+// nodeAt returns the node of tree at path.
+func nodeAt(tree map[string]interface{}, path []string) (interface{}, bool) {
+	var node interface{} = tree
+	for _, k := range path {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// knownPath reports whether tree holds path, or a leaf at one of its parents.
+func knownPath(tree map[string]interface{}, path []string) bool {
+	node := tree
+	for _, k := range path {
+		v, ok := node[k]
+		if !ok {
+			return false
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return true
+		}
+		node = m
+	}
+	return true
+}
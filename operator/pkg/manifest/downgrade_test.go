@@ -0,0 +1,142 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsDowngrade(t *testing.T) {
+	tests := []struct {
+		current string
+		target  string
+		want    bool
+	}{
+		{current: "1.6.0", target: "1.5.4", want: true},
+		{current: "1.6.2", target: "1.6.1", want: true},
+		{current: "1.6.0", target: "1.6.0"},
+		{current: "1.5.9", target: "1.6.0"},
+		{current: "2.0.0", target: "1.9.0", want: true},
+	}
+	for _, tt := range tests {
+		got, err := IsDowngrade(tt.current, tt.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("IsDowngrade(%s, %s): got %v, want %v", tt.current, tt.target, got, tt.want)
+		}
+	}
+	if _, err := IsDowngrade("1.6.0", "latest"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestCheckDowngrade(t *testing.T) {
+	if err := CheckDowngrade("1.6.2", "1.5.0"); err != nil {
+		t.Errorf("one minor version: %v", err)
+	}
+	if err := CheckDowngrade("1.7.0", "1.5.4"); err == nil {
+		t.Error("two minor versions: expected an error")
+	}
+}
+
+func TestFieldsRequiringNewerVersion(t *testing.T) {
+	currentDefaults := `
+hub: docker.io/istio
+components:
+  pilot:
+    enabled: true
+    k8s:
+      nodeSelector: {}
+  newComponent:
+    enabled: false
+values:
+  global:
+    proxy:
+      image: proxyv2
+    newFeature:
+      enabled: false
+`
+	targetDefaults := `
+hub: docker.io/istio
+components:
+  pilot:
+    enabled: true
+    k8s:
+      nodeSelector: {}
+values:
+  global:
+    proxy:
+      image: proxyv2
+`
+	tests := []struct {
+		desc      string
+		installed string
+		want      []string
+	}{
+		{
+			desc:      "defaults only",
+			installed: currentDefaults,
+		},
+		{
+			desc: "known fields changed",
+			installed: `
+hub: example.com/istio
+components:
+  pilot:
+    enabled: false
+values:
+  global:
+    proxy:
+      image: proxy
+`,
+		},
+		{
+			desc: "new fields set",
+			installed: `
+hub: example.com/istio
+components:
+  pilot:
+    enabled: true
+    k8s:
+      nodeSelector:
+        pool: istio
+  newComponent:
+    enabled: true
+values:
+  global:
+    proxy:
+      image: proxyv2
+      newProxyOption: 2
+    newFeature:
+      enabled: false
+`,
+			want: []string{"components.newComponent.enabled", "values.global.proxy.newProxyOption"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := FieldsRequiringNewerVersion(tt.installed, currentDefaults, targetDefaults)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/ghodss/yaml"
@@ -34,8 +35,6 @@ import (
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -44,7 +43,6 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp" // for GCP auth
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	kubectlutil "k8s.io/kubectl/pkg/util/deployment"
 	"k8s.io/utils/pointer"
 
 	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
@@ -470,62 +468,9 @@ func buildComponentApplyOutput(stdout string, stderr string, objects object.K8sO
 	}
 }
 
-func istioCustomResources(group string) bool {
-	switch group {
-	case "config.istio.io",
-		"rbac.istio.io",
-		"security.istio.io",
-		"authentication.istio.io",
-		"networking.istio.io":
-		return true
-	}
-	return false
-}
-
-// DefaultObjectOrder is default sorting function used to sort k8s objects.
+// DefaultObjectOrder is default sorting function used to sort k8s objects, object.DefaultApplyOrder.
 func DefaultObjectOrder() func(o *object.K8sObject) int {
-	return func(o *object.K8sObject) int {
-		gk := o.Group + "/" + o.Kind
-		switch {
-		// Create CRDs asap - both because they are slow and because we will likely create instances of them soon
-		case gk == "apiextensions.k8s.io/CustomResourceDefinition":
-			return -1000
-
-			// We need to create ServiceAccounts, Roles before we bind them with a RoleBinding
-		case gk == "/ServiceAccount" || gk == "rbac.authorization.k8s.io/ClusterRole":
-			return 1
-		case gk == "rbac.authorization.k8s.io/ClusterRoleBinding":
-			return 2
-
-			// validatingwebhookconfiguration is configured to FAIL-OPEN in the default install. For the
-			// re-install case we want to apply the validatingwebhookconfiguration first to reset any
-			// orphaned validatingwebhookconfiguration that is FAIL-CLOSE.
-		case gk == "admissionregistration.k8s.io/ValidatingWebhookConfiguration":
-			return 3
-
-		case istioCustomResources(o.Group):
-			return 4
-
-			// Pods might need configmap or secrets - avoid backoff by creating them first
-		case gk == "/ConfigMap" || gk == "/Secrets":
-			return 100
-
-			// Create the pods after we've created other things they might be waiting for
-		case gk == "extensions/Deployment" || gk == "app/Deployment":
-			return 1000
-
-			// Autoscalers typically act on a deployment
-		case gk == "autoscaling/HorizontalPodAutoscaler":
-			return 1001
-
-			// Create services late - after pods have been started
-		case gk == "/Service":
-			return 10000
-
-		default:
-			return 1000
-		}
-	}
+	return object.DefaultApplyOrder
 }
 
 func CRDKindObjects(objects object.K8sObjects) object.K8sObjects {
@@ -639,52 +584,71 @@ func waitForCRDs(objects object.K8sObjects, stdout string, dryRun bool) error {
 	return nil
 }
 
-// WaitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
-func WaitForResources(objects object.K8sObjects, cs kubernetes.Interface, waitTimeout time.Duration, dryRun bool, l clog.Logger) error {
+// WaitForResources polls or watches, as set by SetWaitStrategy, the current status of all pods, PVCs, and Services
+// until all are ready or a timeout is reached. A table of the resources and their readiness is printed each time
+// it changes. StatefulSets must complete their rollout, Jobs their completions and PersistentVolumeClaims must be
+// bound, and a Job which fails stops the wait. A DaemonSet is ready when its pods are ready on the share of its
+// eligible nodes set by SetDaemonSetReadyThreshold, leaving out cordoned nodes and nodes with taints the pods do not
+// tolerate. The custom checks must pass too, and an error from one of them stops the wait.
+func WaitForResources(objects object.K8sObjects, cs kubernetes.Interface, waitTimeout time.Duration, dryRun bool, l clog.Logger,
+	checks ...ReadinessCheck) error {
+	return WaitForResourcesContext(context2.Background(), objects, cs, waitTimeout, dryRun, l, checks...)
+}
+
+// WaitForResourcesContext is like WaitForResources but also stops waiting when ctx is done.
+func WaitForResourcesContext(ctx context2.Context, objects object.K8sObjects, cs kubernetes.Interface, waitTimeout time.Duration,
+	dryRun bool, l clog.Logger, checks ...ReadinessCheck) error {
 	if dryRun {
 		l.LogAndPrint("Not waiting for resources ready in dry run mode.")
 		return nil
 	}
 
 	var notReady []string
+	var lastTable string
 
-	errPoll := wait.Poll(2*time.Second, waitTimeout, func() (bool, error) {
+	ctx, cancel := context2.WithTimeout(ctx, waitTimeout)
+	defer cancel()
+	checkReady := func(r resourceReader) (bool, error) {
 		pods := []v1.Pod{}
 		deployments := []deployment{}
 		namespaces := []v1.Namespace{}
+		daemonSets := []daemonSet{}
+		var nodes []v1.Node
+		// others are the StatefulSets, Jobs and PersistentVolumeClaims, whose readiness is read from their status, and
+		// the custom readiness checks.
+		var others []resourceReadiness
 
 		for _, o := range objects {
 			kind := o.GroupVersionKind().Kind
 			switch kind {
 			case "Namespace":
-				namespace, err := cs.CoreV1().Namespaces().Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				namespace, err := r.namespace(o.Name)
 				if err != nil {
 					return false, err
 				}
 				namespaces = append(namespaces, *namespace)
 			case "Pod":
-				pod, err := cs.CoreV1().Pods(o.Namespace).Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				pod, err := r.pod(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
 				pods = append(pods, *pod)
 			case "ReplicationController":
-				rc, err := cs.CoreV1().ReplicationControllers(o.Namespace).Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				rc, err := r.replicationController(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
-				list, err := getPods(cs, rc.Namespace, rc.Spec.Selector)
+				list, err := r.pods(rc.Namespace, rc.Spec.Selector)
 				if err != nil {
 					return false, err
 				}
 				pods = append(pods, list...)
 			case "Deployment":
-				currentDeployment, err := cs.AppsV1().Deployments(o.Namespace).Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				currentDeployment, err := r.deployment(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
-				_, _, newReplicaSet, err := kubectlutil.GetAllReplicaSets(currentDeployment, cs.AppsV1())
+				newReplicaSet, err := r.newReplicaSet(currentDeployment)
 				if err != nil || newReplicaSet == nil {
 					return false, err
 				}
@@ -694,31 +658,51 @@ func WaitForResources(objects object.K8sObjects, cs kubernetes.Interface, waitTi
 				}
 				deployments = append(deployments, newDeployment)
 			case "DaemonSet":
-				ds, err := cs.AppsV1().DaemonSets(o.Namespace).Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				ds, err := r.daemonSet(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
-				list, err := getPods(cs, ds.Namespace, ds.Spec.Selector.MatchLabels)
+				list, err := r.pods(ds.Namespace, ds.Spec.Selector.MatchLabels)
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				if nodes == nil {
+					var err error
+					if nodes, err = r.nodes(); err != nil {
+						return false, err
+					}
+				}
+				daemonSets = append(daemonSets, newDaemonSet(ds, list, nodes))
 			case "StatefulSet":
-				sts, err := cs.AppsV1().StatefulSets(o.Namespace).Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				sts, err := r.statefulSet(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
-				list, err := getPods(cs, sts.Namespace, sts.Spec.Selector.MatchLabels)
+				state, ready := statefulSetState(sts)
+				others = append(others, resourceReadiness{kind: kind, namespace: sts.Namespace, name: sts.Name, state: state, ready: ready})
+			case "Job":
+				job, err := r.job(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				state, ready, err := jobState(job)
+				if err != nil {
+					return false, err
+				}
+				others = append(others, resourceReadiness{kind: kind, namespace: job.Namespace, name: job.Name, state: state, ready: ready})
+			case "PersistentVolumeClaim":
+				pvc, err := r.persistentVolumeClaim(o.Namespace, o.Name)
+				if err != nil {
+					return false, err
+				}
+				others = append(others, resourceReadiness{kind: kind, namespace: pvc.Namespace, name: pvc.Name,
+					state: string(pvc.Status.Phase), ready: isPVCBound(pvc)})
 			case "ReplicaSet":
-				rs, err := cs.AppsV1().ReplicaSets(o.Namespace).Get(context2.TODO(), o.Name, metav1.GetOptions{})
+				rs, err := r.replicaSet(o.Namespace, o.Name)
 				if err != nil {
 					return false, err
 				}
-				list, err := getPods(cs, rs.Namespace, rs.Spec.Selector.MatchLabels)
+				list, err := r.pods(rs.Namespace, rs.Spec.Selector.MatchLabels)
 				if err != nil {
 					return false, err
 				}
@@ -728,13 +712,30 @@ func WaitForResources(objects object.K8sObjects, cs kubernetes.Interface, waitTi
 		dr, dnr := deploymentsReady(deployments)
 		nsr, nnr := namespacesReady(namespaces)
 		pr, pnr := podsReady(pods)
-		isReady := dr && nsr && pr
-		if !isReady {
-			l.LogAndPrint("  Waiting for resources to become ready...")
+		dsr, dsnr := daemonSetsReady(daemonSets)
+		checkRows, err := runReadinessChecks(ctx, cs, checks)
+		if err != nil {
+			return false, err
+		}
+		others = append(others, checkRows...)
+		otr, otnr := othersReady(others)
+		isReady := dr && nsr && pr && dsr && otr
+		if rows := append(readinessRows(namespaces, deployments, daemonSets, pods), others...); len(rows) != 0 {
+			if table := readinessTable(rows); table != lastTable {
+				l.LogAndPrint(table)
+				lastTable = table
+			}
 		}
-		notReady = append(append(nnr, dnr...), pnr...)
+		notReady = append(append(append(append(nnr, dnr...), dsnr...), otnr...), pnr...)
 		return isReady, nil
-	})
+	}
+	var errPoll error
+	if waitStrategy == WaitWatch {
+		errPoll = watchUntil(ctx, objects, cs, checkReady)
+	} else {
+		r := clientReader{cs: cs}
+		errPoll = wait.PollUntil(2*time.Second, func() (bool, error) { return checkReady(r) }, ctx.Done())
+	}
 
 	if errPoll != nil {
 		msg := fmt.Sprintf("resources not ready after %v: %v\n%s", waitTimeout, errPoll, strings.Join(notReady, "\n"))
@@ -743,14 +744,6 @@ func WaitForResources(objects object.K8sObjects, cs kubernetes.Interface, waitTi
 	return nil
 }
 
-func getPods(client kubernetes.Interface, namespace string, selector map[string]string) ([]v1.Pod, error) {
-	list, err := client.CoreV1().Pods(namespace).List(context2.TODO(), metav1.ListOptions{
-		FieldSelector: fields.Everything().String(),
-		LabelSelector: labels.Set(selector).AsSelector().String(),
-	})
-	return list.Items, err
-}
-
 func namespacesReady(namespaces []v1.Namespace) (bool, []string) {
 	var notReady []string
 	for _, namespace := range namespaces {
@@ -771,6 +764,16 @@ func podsReady(pods []v1.Pod) (bool, []string) {
 	return len(notReady) == 0, notReady
 }
 
+func othersReady(others []resourceReadiness) (bool, []string) {
+	var notReady []string
+	for _, r := range others {
+		if !r.ready {
+			notReady = append(notReady, r.kind+"/"+r.namespace+"/"+r.name)
+		}
+	}
+	return len(notReady) == 0, notReady
+}
+
 func isNamespaceReady(namespace *v1.Namespace) bool {
 	return namespace.Status.Phase == v1.NamespaceActive
 }
@@ -797,6 +800,86 @@ func deploymentsReady(deployments []deployment) (bool, []string) {
 	return len(notReady) == 0, notReady
 }
 
+// resourceReadiness is a row of the table printed while waiting for resources to become ready.
+type resourceReadiness struct {
+	kind      string
+	namespace string
+	name      string
+	// state is a short description of the readiness, e.g. ready/desired replicas for a Deployment.
+	state string
+	ready bool
+}
+
+func readinessRows(namespaces []v1.Namespace, deployments []deployment, daemonSets []daemonSet, pods []v1.Pod) []resourceReadiness {
+	var out []resourceReadiness
+	for _, ns := range namespaces {
+		out = append(out, resourceReadiness{
+			kind:  "Namespace",
+			name:  ns.Name,
+			state: string(ns.Status.Phase),
+			ready: isNamespaceReady(&ns),
+		})
+	}
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.deployment.Spec.Replicas != nil {
+			desired = *d.deployment.Spec.Replicas
+		}
+		out = append(out, resourceReadiness{
+			kind:      "Deployment",
+			namespace: d.deployment.Namespace,
+			name:      d.deployment.Name,
+			state:     fmt.Sprintf("%d/%d", d.replicaSets.Status.ReadyReplicas, desired),
+			ready:     d.replicaSets.Status.ReadyReplicas >= desired,
+		})
+	}
+	for _, d := range daemonSets {
+		out = append(out, resourceReadiness{
+			kind:      "DaemonSet",
+			namespace: d.daemonSet.Namespace,
+			name:      d.daemonSet.Name,
+			state:     fmt.Sprintf("%d/%d", d.ready, d.eligible),
+			ready:     d.isReady(),
+		})
+	}
+	for _, p := range pods {
+		r := resourceReadiness{
+			kind:      "Pod",
+			namespace: p.Namespace,
+			name:      p.Name,
+			state:     string(p.Status.Phase),
+			ready:     isPodReady(&p),
+		}
+		if r.ready {
+			r.state = "Ready"
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// readinessTable returns a tabular listing of rows, with the resources that are not yet ready first.
+func readinessTable(rows []resourceReadiness) string {
+	sorted := make([]resourceReadiness, len(rows))
+	copy(sorted, rows)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !sorted[i].ready && sorted[j].ready
+	})
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  KIND\tNAMESPACE\tNAME\tSTATE\tREADY")
+	for _, r := range sorted {
+		ready := "✘"
+		if r.ready {
+			ready = "✔"
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", r.kind, r.namespace, r.name, r.state, ready)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
 func buildInstallTree() {
 	// Starting with root, recursively insert each first level child into each node.
 	insertChildrenRecursive(name.IstioBaseComponentName, installTree, componentDependencies)
@@ -831,6 +914,7 @@ func InitK8SRestClient(kubeconfig, context string) (*rest.Config, *kubernetes.Cl
 		return k8sRESTConfig, k8sClientset, nil
 	}
 	currentKubeconfig, currentContext = kubeconfig, context
+	resetDiscoveryCache()
 
 	k8sRESTConfig, err = defaultRestConfig(kubeconfig, context)
 	if err != nil {
@@ -61,3 +61,19 @@ customresourcedefinition.apiextensions.k8s.io/attributemanifests.config.istio.io
 		})
 	}
 }
+
+func TestReadinessTable(t *testing.T) {
+	rows := []resourceReadiness{
+		{kind: "Namespace", name: "istio-system", state: "Active", ready: true},
+		{kind: "Deployment", namespace: "istio-system", name: "istiod", state: "0/1"},
+		{kind: "Pod", namespace: "istio-system", name: "istiod-abc", state: "Ready", ready: true},
+	}
+	want := `  KIND        NAMESPACE     NAME          STATE   READY
+  Deployment  istio-system  istiod        0/1     ✘
+  Namespace                 istio-system  Active  ✔
+  Pod         istio-system  istiod-abc    Ready   ✔
+`
+	if got := readinessTable(rows); got != want {
+		t.Errorf("readinessTable: got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+var (
+	// serverMetadataFields are the metadata fields which are populated by the API server and show up as removed in
+	// kubectl diff if present in a manifest.
+	serverMetadataFields = []string{"creationTimestamp", "resourceVersion", "uid", "generation", "selfLink", "managedFields"}
+	// webhookKinds are the kinds whose webhooks have a caBundle patched in by istiod.
+	webhookKinds = map[string]bool{"MutatingWebhookConfiguration": true, "ValidatingWebhookConfiguration": true}
+)
+
+// ForKubectlDiff returns manifests with the fields which are populated by the API server or by Istio after
+// installation removed, and with the objects of each manifest sorted by group, kind, namespace and name, so that
+// kubectl diff against an installed cluster only shows the changes the manifests would make.
+func ForKubectlDiff(manifests name.ManifestMap) (name.ManifestMap, error) {
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		out[cn] = nil
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			var stripped object.K8sObjects
+			for _, o := range objs {
+				u := o.UnstructuredObject()
+				stripServerFields(u)
+				// Rebuild the object to drop the YAML cached from parsing.
+				stripped = append(stripped, object.NewK8sObject(u, nil, nil))
+			}
+			sortForDiff(stripped)
+			ym, err := stripped.YAMLManifest()
+			if err != nil {
+				return nil, err
+			}
+			out[cn] = append(out[cn], strings.TrimSuffix(ym, object.YAMLSeparator))
+		}
+	}
+	return out, nil
+}
+
+// stripServerFields removes the server populated metadata and the status of u, as well as empty webhook caBundles,
+// which istiod fills in.
+func stripServerFields(u *unstructured.Unstructured) {
+	for _, f := range serverMetadataFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+	if !webhookKinds[u.GetKind()] {
+		return
+	}
+	webhooks, ok := u.Object["webhooks"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, wh := range webhooks {
+		whm, ok := wh.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ca, _, _ := unstructured.NestedString(whm, "clientConfig", "caBundle"); ca == "" {
+			unstructured.RemoveNestedField(whm, "clientConfig", "caBundle")
+		}
+	}
+}
+
+// sortForDiff sorts objs by group, kind, namespace and name.
+func sortForDiff(objs object.K8sObjects) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestForKubectlDiff(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.PilotComponentName: {`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istiod
+  namespace: istio-system
+---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: istio-sidecar-injector
+  creationTimestamp: null
+webhooks:
+- name: sidecar-injector.istio.io
+  clientConfig:
+    caBundle: ""
+    service:
+      name: istiod
+      namespace: istio-system
+- name: other.istio.io
+  clientConfig:
+    caBundle: Y2E=
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istiod-istio-system
+  creationTimestamp: null
+  resourceVersion: "1234"
+status: {}
+`},
+	}
+	got, err := ForKubectlDiff(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got[name.PilotComponentName]) != 1 {
+		t.Fatalf("got %d manifests, want 1", len(got[name.PilotComponentName]))
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got[name.PilotComponentName][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes []string
+	for _, o := range objs {
+		hashes = append(hashes, o.Hash())
+	}
+	wantHashes := []string{
+		"ServiceAccount:istio-system:istiod",
+		"MutatingWebhookConfiguration::istio-sidecar-injector",
+		"ClusterRole::istiod-istio-system",
+	}
+	if !reflect.DeepEqual(hashes, wantHashes) {
+		t.Errorf("got objects %v, want %v", hashes, wantHashes)
+	}
+	for _, o := range objs {
+		u := o.Unstructured()
+		for _, f := range serverMetadataFields {
+			if _, ok, _ := unstructured.NestedFieldNoCopy(u, "metadata", f); ok {
+				t.Errorf("%s: metadata.%s not removed", o.Hash(), f)
+			}
+		}
+		if _, ok := u["status"]; ok {
+			t.Errorf("%s: status not removed", o.Hash())
+		}
+	}
+	webhooks := objs[1].Unstructured()["webhooks"].([]interface{})
+	if _, ok, _ := unstructured.NestedString(webhooks[0].(map[string]interface{}), "clientConfig", "caBundle"); ok {
+		t.Errorf("empty caBundle not removed")
+	}
+	if ca, _, _ := unstructured.NestedString(webhooks[1].(map[string]interface{}), "clientConfig", "caBundle"); ca != "Y2E=" {
+		t.Errorf("got caBundle %q, want it kept", ca)
+	}
+	again, err := ForKubectlDiff(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[name.PilotComponentName][0] != got[name.PilotComponentName][0] {
+		t.Errorf("not idempotent, got:\n%s", again[name.PilotComponentName][0])
+	}
+}
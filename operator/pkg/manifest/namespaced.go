@@ -0,0 +1,173 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util"
+)
+
+var (
+	// clusterScopedKinds are the kinds of the cluster scoped resources rendered by the charts, which are left out in
+	// namespaced mode.
+	clusterScopedKinds = map[string]bool{
+		crdKind:                          true,
+		"ClusterRole":                    true,
+		"ClusterRoleBinding":             true,
+		"MutatingWebhookConfiguration":   true,
+		"ValidatingWebhookConfiguration": true,
+		"MeshPolicy":                     true,
+		"Namespace":                      true,
+		"PodSecurityPolicy":              true,
+		"PriorityClass":                  true,
+		"StorageClass":                   true,
+	}
+
+	// namespacedVerbs are the verbs the reconciler uses on the resources it applies and prunes.
+	namespacedVerbs = []string{"get", "list", "create", "update", "delete"}
+)
+
+// FilterNamespaced returns manifests without the cluster scoped resources, for namespaced mode, in which the CRDs,
+// ClusterRoles and webhook configurations are provisioned by a cluster admin and an app team installs a revision
+// with permissions in its own namespace only. Components left without objects are kept with no manifests.
+func FilterNamespaced(manifests name.ManifestMap) (name.ManifestMap, error) {
+	return FilterObjects(manifests, func(o *object.K8sObject) bool {
+		return !clusterScopedKinds[o.Kind]
+	})
+}
+
+// CheckNamespacedPermissions returns an error listing the permissions on the resources in manifests, and on the
+// IstioOperator CRs in crNamespace, which the user of cs lacks to apply and prune them. Objects without a namespace
+// are checked in crNamespace.
+func CheckNamespacedPermissions(cs kubernetes.Interface, manifests name.ManifestMap, crNamespace string) error {
+	var mapper meta.RESTMapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(cs.Discovery()))
+	if cs == kubernetes.Interface(k8sClientset) {
+		// Share the discovery results of the clients set up by InitK8SRestClient.
+		m, err := RESTMapper()
+		if err != nil {
+			return err
+		}
+		mapper = m
+	}
+	attrs, err := requiredPermissions(manifests, mapper, crNamespace)
+	if err != nil {
+		return err
+	}
+	missing := make(map[string][]string)
+	var keys []string
+	for _, a := range attrs {
+		a := a
+		sar := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &a},
+		}
+		resp, err := cs.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), sar, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to check permission to %s %s: %s", a.Verb, a.Resource, err)
+		}
+		if resp.Status.Allowed {
+			continue
+		}
+		k := fmt.Sprintf("%s in namespace %s", qualifiedResource(a), a.Namespace)
+		if missing[k] == nil {
+			keys = append(keys, k)
+		}
+		missing[k] = append(missing[k], a.Verb)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	var errs util.Errors
+	for _, k := range keys {
+		errs = util.AppendErr(errs, fmt.Errorf("cannot %s %s", strings.Join(missing[k], ", "), k))
+	}
+	return fmt.Errorf("missing permissions for a namespaced install: %s", errs.ToError())
+}
+
+// requiredPermissions returns the resource attributes of the namespacedVerbs on each resource type in manifests, and
+// of get, create and update on IstioOperator CRs, by namespace, sorted. Resource types are looked up with mapper.
+func requiredPermissions(manifests name.ManifestMap, mapper meta.RESTMapper, crNamespace string) ([]authorizationv1.ResourceAttributes, error) {
+	seen := make(map[authorizationv1.ResourceAttributes]bool)
+	var out []authorizationv1.ResourceAttributes
+	add := func(group, resource, namespace string, verbs []string) {
+		for _, v := range verbs {
+			a := authorizationv1.ResourceAttributes{Namespace: namespace, Verb: v, Group: group, Resource: resource}
+			if !seen[a] {
+				seen[a] = true
+				out = append(out, a)
+			}
+		}
+	}
+	for cn, ms := range manifests {
+		objs, err := object.ParseK8sObjectsFromYAMLManifest(strings.Join(ms, object.YAMLSeparator))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest for component %s: %s", cn, err)
+		}
+		for _, o := range objs {
+			gvk := o.GroupVersionKind()
+			m, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				return nil, fmt.Errorf("unknown resource type of %s: %s", o.Hash(), err)
+			}
+			namespace := o.Namespace
+			if namespace == "" {
+				namespace = crNamespace
+			}
+			add(gvk.Group, m.Resource.Resource, namespace, namespacedVerbs)
+		}
+	}
+	add("install.istio.io", "istiooperators", crNamespace, []string{"get", "create", "update"})
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if qa, qb := qualifiedResource(a), qualifiedResource(b); qa != qb {
+			return qa < qb
+		}
+		return verbIndex(a.Verb) < verbIndex(b.Verb)
+	})
+	return out, nil
+}
+
+// qualifiedResource returns the resource of a in the form resource.group, like kubectl auth can-i.
+func qualifiedResource(a authorizationv1.ResourceAttributes) string {
+	if a.Group == "" {
+		return a.Resource
+	}
+	return a.Resource + "." + a.Group
+}
+
+// verbIndex orders the verbs in error messages as in namespacedVerbs.
+func verbIndex(verb string) int {
+	for i, v := range namespacedVerbs {
+		if v == verb {
+			return i
+		}
+	}
+	return len(namespacedVerbs)
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+var namespacedTestManifests = name.ManifestMap{
+	name.IstioBaseComponentName: {`
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istiod-istio-system
+`},
+	name.PilotComponentName: {`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod-canary
+  namespace: team-a
+---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: istio-sidecar-injector-canary-team-a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio-canary
+`},
+}
+
+func TestFilterNamespaced(t *testing.T) {
+	got, err := FilterNamespaced(namespacedTestManifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms := got[name.IstioBaseComponentName]; len(ms) != 0 {
+		t.Errorf("got base manifests %v, want none", ms)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got[name.PilotComponentName][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes []string
+	for _, o := range objs {
+		hashes = append(hashes, o.Hash())
+	}
+	if want := []string{"Deployment:team-a:istiod-canary", "ConfigMap::istio-canary"}; !reflect.DeepEqual(hashes, want) {
+		t.Errorf("got %v, want %v", hashes, want)
+	}
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	manifests, err := FilterNamespaced(namespacedTestManifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	got, err := requiredPermissions(manifests, mapper, "team-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []authorizationv1.ResourceAttributes
+	for _, r := range []struct{ group, resource string }{{"", "configmaps"}, {"apps", "deployments"}} {
+		for _, v := range namespacedVerbs {
+			want = append(want, authorizationv1.ResourceAttributes{Namespace: "team-a", Verb: v, Group: r.group, Resource: r.resource})
+		}
+	}
+	for _, v := range []string{"get", "create", "update"} {
+		want = append(want, authorizationv1.ResourceAttributes{Namespace: "team-a", Verb: v, Group: "install.istio.io", Resource: "istiooperators"})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+
+	if _, err := requiredPermissions(namespacedTestManifests, mapper, "team-a"); err == nil {
+		t.Errorf("got no error for resource types unknown to the mapper, want error")
+	}
+}
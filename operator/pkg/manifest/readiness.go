@@ -0,0 +1,45 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// ReadinessCheck is a custom check of the readiness of a component, which WaitForResources runs along with the checks
+// of the workloads of the component, e.g. to probe an endpoint of one of its Services.
+type ReadinessCheck struct {
+	// Name identifies the check in the readiness table, e.g. the Service and port it probes.
+	Name string
+	// Check reports whether the check passes, with a short description of the state. Failures which may go away, like
+	// a refused connection while the component starts, should be reported as not ready rather than as an error, which
+	// stops the wait.
+	Check func(ctx context.Context, cs kubernetes.Interface) (ready bool, state string, err error)
+}
+
+// runReadinessChecks runs checks and returns a readiness row for each of them.
+func runReadinessChecks(ctx context.Context, cs kubernetes.Interface, checks []ReadinessCheck) ([]resourceReadiness, error) {
+	var out []resourceReadiness
+	for _, c := range checks {
+		ready, state, err := c.Check(ctx, cs)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resourceReadiness{kind: "Check", name: c.Name, state: state, ready: ready})
+	}
+	return out, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/version"
+)
+
+// MaxMinorVersionSkew is the number of minor versions by which the control plane can be upgraded at once, and by which
+// proxies can be behind the control plane.
+const MaxMinorVersionSkew = 1
+
+// CheckVersionSkew returns an error for each control plane component or proxy which is outside the supported version
+// skew for a control plane at targetVersion: control plane components must not be more than MaxMinorVersionSkew
+// minor versions behind the target, and proxies must neither be newer than the target nor more than
+// MaxMinorVersionSkew minor versions behind it. Downgrades are not checked here.
+func CheckVersionSkew(targetVersion string, controlPlane, proxies []ComponentVersion) util.Errors {
+	target, err := version.NewVersionFromString(targetVersion)
+	if err != nil {
+		return util.NewErrs(fmt.Errorf("failed to parse the target version %s: %s", targetVersion, err))
+	}
+	var errs util.Errors
+	for _, cv := range controlPlane {
+		v, err := version.NewVersionFromString(cv.Version)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("failed to parse the version of %s: %s", cv, err))
+			continue
+		}
+		if tooFarBehind(v, target) {
+			errs = util.AppendErr(errs, fmt.Errorf("%s cannot be upgraded to %s directly, upgrade at most %d minor version(s) at a time",
+				cv, targetVersion, MaxMinorVersionSkew))
+		}
+	}
+
+	// Summarize proxies by version, since there may be many of them.
+	behind := make(map[string][]string)
+	ahead := make(map[string][]string)
+	for _, cv := range proxies {
+		v, err := version.NewVersionFromString(cv.Version)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("failed to parse the version of %s: %s", cv, err))
+			continue
+		}
+		pod := cv.Pod.Namespace + "/" + cv.Pod.Name
+		switch {
+		case tooFarBehind(v, target):
+			behind[cv.Version] = append(behind[cv.Version], pod)
+		case v.Major > target.Major || (v.Major == target.Major && v.Minor > target.Minor):
+			ahead[cv.Version] = append(ahead[cv.Version], pod)
+		}
+	}
+	for _, ver := range sortedKeys(behind) {
+		errs = util.AppendErr(errs, fmt.Errorf("%d proxies at version %s would be more than %d minor version(s) behind the control plane "+
+			"at %s, upgrade them first: %s", len(behind[ver]), ver, MaxMinorVersionSkew, targetVersion, podList(behind[ver])))
+	}
+	for _, ver := range sortedKeys(ahead) {
+		errs = util.AppendErr(errs, fmt.Errorf("%d proxies at version %s would be newer than the control plane at %s: %s",
+			len(ahead[ver]), ver, targetVersion, podList(ahead[ver])))
+	}
+	return errs
+}
+
+// tooFarBehind reports whether v is more than MaxMinorVersionSkew minor versions behind target.
+func tooFarBehind(v, target *version.Version) bool {
+	return v.Major < target.Major || (v.Major == target.Major && int(target.Minor)-int(v.Minor) > MaxMinorVersionSkew)
+}
+
+func sortedKeys(m map[string][]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// podList returns a comma separated list of the first few pods.
+func podList(pods []string) string {
+	const maxPods = 5
+	sort.Strings(pods)
+	if len(pods) <= maxPods {
+		return strings.Join(pods, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(pods[:maxPods], ", "), len(pods)-maxPods)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func componentVersion(component, version, namespace, name string) ComponentVersion {
+	return ComponentVersion{
+		Component: component,
+		Version:   version,
+		Pod:       v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+	}
+}
+
+func TestCheckVersionSkew(t *testing.T) {
+	tests := []struct {
+		desc         string
+		target       string
+		controlPlane []ComponentVersion
+		proxies      []ComponentVersion
+		want         []string
+	}{
+		{
+			desc:         "one minor version",
+			target:       "1.6.0",
+			controlPlane: []ComponentVersion{componentVersion("pilot", "1.5.2", "istio-system", "istiod-1")},
+			proxies: []ComponentVersion{
+				componentVersion("sidecar", "1.5.2", "default", "a"),
+				componentVersion("sidecar", "1.6.0", "default", "b"),
+			},
+		},
+		{
+			desc:   "control plane jumps two minor versions",
+			target: "1.7.0",
+			controlPlane: []ComponentVersion{
+				componentVersion("pilot", "1.5.2", "istio-system", "istiod-1"),
+				componentVersion("ingressgateway", "1.6.1", "istio-system", "gw-1"),
+			},
+			want: []string{"pilot pod - istiod-1 - version: 1.5.2 cannot be upgraded to 1.7.0 directly"},
+		},
+		{
+			desc:   "old and new proxies",
+			target: "1.7.0",
+			proxies: []ComponentVersion{
+				componentVersion("sidecar", "1.5.2", "default", "b"),
+				componentVersion("sidecar", "1.5.2", "default", "a"),
+				componentVersion("sidecar", "1.6.0", "default", "c"),
+				componentVersion("sidecar", "1.8.0", "test", "d"),
+			},
+			want: []string{
+				"2 proxies at version 1.5.2 would be more than 1 minor version(s) behind the control plane at 1.7.0, upgrade them first: default/a, default/b",
+				"1 proxies at version 1.8.0 would be newer than the control plane at 1.7.0: test/d",
+			},
+		},
+		{
+			desc:   "bad target",
+			target: "latest",
+			want:   []string{"failed to parse the target version latest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := CheckVersionSkew(tt.target, tt.controlPlane, tt.proxies)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got errors %v, want %v", errs, tt.want)
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(errs[i].Error(), w) {
+					t.Errorf("got error %q, want %q", errs[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestPodList(t *testing.T) {
+	got := podList([]string{"ns/g", "ns/f", "ns/e", "ns/d", "ns/c", "ns/b", "ns/a"})
+	if want := "ns/a, ns/b, ns/c, ns/d, ns/e and 2 more"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	kubectlutil "k8s.io/kubectl/pkg/util/deployment"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// WaitPoll reads the resources from the API server every 2 seconds while waiting for them to become ready.
+	WaitPoll = "poll"
+	// WaitWatch watches the resources and checks their readiness as soon as they change. It is the default.
+	WaitWatch = "watch"
+
+	// watchResync is how often the readiness is checked again without any change to the watched resources, for the
+	// custom readiness checks and in case a watch event is missed.
+	watchResync = 10 * time.Second
+	// watchMinInterval is the minimum time between two readiness checks, so that bursts of changes, like the pods of a
+	// rollout starting, are checked together.
+	watchMinInterval = 500 * time.Millisecond
+)
+
+// waitStrategy is how WaitForResources reads the resources, one of WaitPoll or WaitWatch.
+var waitStrategy = WaitWatch
+
+// SetWaitStrategy sets how WaitForResources reads the resources, one of WaitPoll or WaitWatch. Watching reads the
+// resources from informer caches, which is less load on the API server and notices readiness within a second, while
+// polling needs no watch permissions.
+func SetWaitStrategy(s string) error {
+	if s != WaitPoll && s != WaitWatch {
+		return fmt.Errorf("unknown wait strategy %s, must be %s or %s", s, WaitPoll, WaitWatch)
+	}
+	waitStrategy = s
+	return nil
+}
+
+// resourceReader reads the resources whose readiness WaitForResources checks.
+type resourceReader interface {
+	namespace(name string) (*v1.Namespace, error)
+	pod(namespace, name string) (*v1.Pod, error)
+	pods(namespace string, selector map[string]string) ([]v1.Pod, error)
+	replicationController(namespace, name string) (*v1.ReplicationController, error)
+	deployment(namespace, name string) (*appsv1.Deployment, error)
+	// newReplicaSet returns the ReplicaSet of the current revision of d, or nil if it is not created yet.
+	newReplicaSet(d *appsv1.Deployment) (*appsv1.ReplicaSet, error)
+	replicaSet(namespace, name string) (*appsv1.ReplicaSet, error)
+	daemonSet(namespace, name string) (*appsv1.DaemonSet, error)
+	nodes() ([]v1.Node, error)
+	statefulSet(namespace, name string) (*appsv1.StatefulSet, error)
+	job(namespace, name string) (*batchv1.Job, error)
+	persistentVolumeClaim(namespace, name string) (*v1.PersistentVolumeClaim, error)
+}
+
+// clientReader reads the resources from the API server.
+type clientReader struct {
+	cs kubernetes.Interface
+}
+
+func (r clientReader) namespace(name string) (*v1.Namespace, error) {
+	return r.cs.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) pod(namespace, name string) (*v1.Pod, error) {
+	return r.cs.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) pods(namespace string, selector map[string]string) ([]v1.Pod, error) {
+	list, err := r.cs.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.Set(selector).AsSelector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r clientReader) replicationController(namespace, name string) (*v1.ReplicationController, error) {
+	return r.cs.CoreV1().ReplicationControllers(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) deployment(namespace, name string) (*appsv1.Deployment, error) {
+	return r.cs.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) newReplicaSet(d *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	_, _, rs, err := kubectlutil.GetAllReplicaSets(d, r.cs.AppsV1())
+	return rs, err
+}
+
+func (r clientReader) replicaSet(namespace, name string) (*appsv1.ReplicaSet, error) {
+	return r.cs.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) daemonSet(namespace, name string) (*appsv1.DaemonSet, error) {
+	return r.cs.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) nodes() ([]v1.Node, error) {
+	list, err := r.cs.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r clientReader) statefulSet(namespace, name string) (*appsv1.StatefulSet, error) {
+	return r.cs.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) job(namespace, name string) (*batchv1.Job, error) {
+	return r.cs.BatchV1().Jobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (r clientReader) persistentVolumeClaim(namespace, name string) (*v1.PersistentVolumeClaim, error) {
+	return r.cs.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// informerReader reads the resources from the caches of shared informers. Namespaced resources are only watched in
+// the namespaces of the waited for objects.
+type informerReader struct {
+	cluster    informers.SharedInformerFactory
+	namespaced map[string]informers.SharedInformerFactory
+	informers  []cache.SharedIndexInformer
+}
+
+// newInformerReader returns an informerReader with informers for the resources whose readiness is checked for
+// objects, which are not started yet.
+func newInformerReader(cs kubernetes.Interface, objects object.K8sObjects) *informerReader {
+	r := &informerReader{
+		cluster:    informers.NewSharedInformerFactory(cs, watchResync),
+		namespaced: make(map[string]informers.SharedInformerFactory),
+	}
+	for _, o := range objects {
+		kind := o.GroupVersionKind().Kind
+		if kind == "Namespace" {
+			r.add(r.cluster.Core().V1().Namespaces().Informer())
+			continue
+		}
+		ns := r.factory(cs, o.Namespace)
+		switch kind {
+		case "Pod":
+			r.add(ns.Core().V1().Pods().Informer())
+		case "ReplicationController":
+			r.add(ns.Core().V1().ReplicationControllers().Informer(), ns.Core().V1().Pods().Informer())
+		case "Deployment":
+			r.add(ns.Apps().V1().Deployments().Informer(), ns.Apps().V1().ReplicaSets().Informer())
+		case "DaemonSet":
+			r.add(ns.Apps().V1().DaemonSets().Informer(), ns.Core().V1().Pods().Informer(), r.cluster.Core().V1().Nodes().Informer())
+		case "StatefulSet":
+			r.add(ns.Apps().V1().StatefulSets().Informer())
+		case "Job":
+			r.add(ns.Batch().V1().Jobs().Informer())
+		case "PersistentVolumeClaim":
+			r.add(ns.Core().V1().PersistentVolumeClaims().Informer())
+		case "ReplicaSet":
+			r.add(ns.Apps().V1().ReplicaSets().Informer(), ns.Core().V1().Pods().Informer())
+		}
+	}
+	return r
+}
+
+// factory returns the informer factory for namespace, creating it with cs if needed.
+func (r *informerReader) factory(cs kubernetes.Interface, namespace string) informers.SharedInformerFactory {
+	f, ok := r.namespaced[namespace]
+	if !ok {
+		f = informers.NewSharedInformerFactoryWithOptions(cs, watchResync, informers.WithNamespace(namespace))
+		r.namespaced[namespace] = f
+	}
+	return f
+}
+
+// add adds the given informers, which are shared by their factory, unless they were added before.
+func (r *informerReader) add(infs ...cache.SharedIndexInformer) {
+	for _, inf := range infs {
+		found := false
+		for _, i := range r.informers {
+			if i == inf {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.informers = append(r.informers, inf)
+		}
+	}
+}
+
+// start starts the informers and waits until their caches are synced. It calls changed on every event after that.
+func (r *informerReader) start(stop <-chan struct{}, changed func()) error {
+	for _, inf := range r.informers {
+		inf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { changed() },
+			UpdateFunc: func(interface{}, interface{}) { changed() },
+			DeleteFunc: func(interface{}) { changed() },
+		})
+	}
+	r.cluster.Start(stop)
+	for _, f := range r.namespaced {
+		f.Start(stop)
+	}
+	var synced []cache.InformerSynced
+	for _, inf := range r.informers {
+		synced = append(synced, inf.HasSynced)
+	}
+	if !cache.WaitForCacheSync(stop, synced...) {
+		return fmt.Errorf("failed to sync the caches of the watched resources")
+	}
+	return nil
+}
+
+func (r *informerReader) namespace(name string) (*v1.Namespace, error) {
+	return r.cluster.Core().V1().Namespaces().Lister().Get(name)
+}
+
+func (r *informerReader) pod(namespace, name string) (*v1.Pod, error) {
+	return r.namespaced[namespace].Core().V1().Pods().Lister().Pods(namespace).Get(name)
+}
+
+func (r *informerReader) pods(namespace string, selector map[string]string) ([]v1.Pod, error) {
+	list, err := r.namespaced[namespace].Core().V1().Pods().Lister().Pods(namespace).List(labels.Set(selector).AsSelector())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]v1.Pod, 0, len(list))
+	for _, p := range list {
+		out = append(out, *p)
+	}
+	return out, nil
+}
+
+func (r *informerReader) replicationController(namespace, name string) (*v1.ReplicationController, error) {
+	return r.namespaced[namespace].Core().V1().ReplicationControllers().Lister().ReplicationControllers(namespace).Get(name)
+}
+
+func (r *informerReader) deployment(namespace, name string) (*appsv1.Deployment, error) {
+	return r.namespaced[namespace].Apps().V1().Deployments().Lister().Deployments(namespace).Get(name)
+}
+
+// newReplicaSet returns the ReplicaSet controlled by d with the same revision, which the deployment controller sets
+// on both.
+func (r *informerReader) newReplicaSet(d *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	revision, ok := d.Annotations[kubectlutil.RevisionAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	list, err := r.namespaced[d.Namespace].Apps().V1().ReplicaSets().Lister().ReplicaSets(d.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range list {
+		if metav1.IsControlledBy(rs, d) && rs.Annotations[kubectlutil.RevisionAnnotation] == revision {
+			return rs, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *informerReader) replicaSet(namespace, name string) (*appsv1.ReplicaSet, error) {
+	return r.namespaced[namespace].Apps().V1().ReplicaSets().Lister().ReplicaSets(namespace).Get(name)
+}
+
+func (r *informerReader) daemonSet(namespace, name string) (*appsv1.DaemonSet, error) {
+	return r.namespaced[namespace].Apps().V1().DaemonSets().Lister().DaemonSets(namespace).Get(name)
+}
+
+func (r *informerReader) nodes() ([]v1.Node, error) {
+	list, err := r.cluster.Core().V1().Nodes().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]v1.Node, 0, len(list))
+	for _, n := range list {
+		out = append(out, *n)
+	}
+	return out, nil
+}
+
+func (r *informerReader) statefulSet(namespace, name string) (*appsv1.StatefulSet, error) {
+	return r.namespaced[namespace].Apps().V1().StatefulSets().Lister().StatefulSets(namespace).Get(name)
+}
+
+func (r *informerReader) job(namespace, name string) (*batchv1.Job, error) {
+	return r.namespaced[namespace].Batch().V1().Jobs().Lister().Jobs(namespace).Get(name)
+}
+
+func (r *informerReader) persistentVolumeClaim(namespace, name string) (*v1.PersistentVolumeClaim, error) {
+	return r.namespaced[namespace].Core().V1().PersistentVolumeClaims().Lister().PersistentVolumeClaims(namespace).Get(name)
+}
+
+// watchUntil checks condition with an informerReader for objects each time the watched resources change, and at
+// least every watchResync, until it returns true or an error. It returns wait.ErrWaitTimeout when ctx is done first,
+// like wait.PollUntil. The informers run until ctx is done, so ctx must be canceled once the wait is over.
+func watchUntil(ctx context.Context, objects object.K8sObjects, cs kubernetes.Interface, condition func(resourceReader) (bool, error)) error {
+	r := newInformerReader(cs, objects)
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	if err := r.start(ctx.Done(), notify); err != nil {
+		if ctx.Err() != nil {
+			return wait.ErrWaitTimeout
+		}
+		return err
+	}
+	resync := time.NewTicker(watchResync)
+	defer resync.Stop()
+	for {
+		ready, err := condition(r)
+		if err != nil || ready {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return wait.ErrWaitTimeout
+		case <-time.After(watchMinInterval):
+		}
+		select {
+		case <-ctx.Done():
+			return wait.ErrWaitTimeout
+		case <-changed:
+		case <-resync.C:
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	kubectlutil "k8s.io/kubectl/pkg/util/deployment"
+	"k8s.io/utils/pointer"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestWatchUntil(t *testing.T) {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "istiod",
+			Namespace:   "istio-system",
+			UID:         "istiod-uid",
+			Annotations: map[string]string{kubectlutil.RevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+	}
+	replicaSet := func(name, revision string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "istio-system",
+			Annotations:     map[string]string{kubectlutil.RevisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+		}}
+	}
+	old, current := replicaSet("istiod-1", "1"), replicaSet("istiod-2", "2")
+	old.Status.ReadyReplicas = 1
+	cs := fake.NewSimpleClientset(d, old, current)
+	o, err := object.ParseYAMLToK8sObject([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := object.K8sObjects{o}
+
+	condition := func(r resourceReader) (bool, error) {
+		live, err := r.deployment("istio-system", "istiod")
+		if err != nil {
+			return false, err
+		}
+		rs, err := r.newReplicaSet(live)
+		if err != nil || rs == nil {
+			return false, err
+		}
+		if rs.Name != "istiod-2" {
+			t.Errorf("got new ReplicaSet %s, want istiod-2", rs.Name)
+		}
+		return rs.Status.ReadyReplicas >= *live.Spec.Replicas, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	if err := watchUntil(ctx, objects, cs, condition); err != wait.ErrWaitTimeout {
+		t.Errorf("got %v before the new ReplicaSet is ready, want %v", err, wait.ErrWaitTimeout)
+	}
+	cancel()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		ready := current.DeepCopy()
+		ready.Status.ReadyReplicas = 1
+		if _, err := cs.AppsV1().ReplicaSets("istio-system").Update(context.TODO(), ready, metav1.UpdateOptions{}); err != nil {
+			t.Error(err)
+		}
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := watchUntil(ctx, objects, cs, condition); err != nil {
+		t.Fatalf("got %v once the new ReplicaSet is ready", err)
+	}
+	if elapsed := time.Since(start); elapsed >= watchResync {
+		t.Errorf("readiness noticed after %v, want a watch event before the resync after %v", elapsed, watchResync)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// statefulSetState returns the ready/desired replicas of sts and whether its rollout is complete: the controller has
+// seen the latest spec, the desired replicas are ready and, for a rolling update without a partition, all of them run
+// the update revision.
+func statefulSetState(sts *appsv1.StatefulSet) (string, bool) {
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+	state := fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, desired)
+	if sts.Status.ObservedGeneration < sts.Generation || sts.Status.ReadyReplicas < desired {
+		return state, false
+	}
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return state, true
+	}
+	partition := int32(0)
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		partition = *ru.Partition
+	}
+	if sts.Status.UpdatedReplicas < desired-partition {
+		return state + " updating", false
+	}
+	if partition == 0 && sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return state + " updating", false
+	}
+	return state, true
+}
+
+// jobState returns the succeeded/desired completions of job and whether it is complete. It returns an error if the
+// job failed, as it will not complete without being recreated.
+func jobState(job *batchv1.Job) (string, bool, error) {
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	state := fmt.Sprintf("%d/%d", job.Status.Succeeded, completions)
+	for _, c := range job.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return state, true, nil
+		case batchv1.JobFailed:
+			return state, false, fmt.Errorf("job %s/%s failed: %s", job.Namespace, job.Name, c.Message)
+		}
+	}
+	return state, job.Status.Succeeded >= completions, nil
+}
+
+// isPVCBound reports whether pvc is bound to a volume.
+func isPVCBound(pvc *v1.PersistentVolumeClaim) bool {
+	return pvc.Status.Phase == v1.ClaimBound
+}
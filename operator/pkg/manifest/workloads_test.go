@@ -0,0 +1,125 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestStatefulSetState(t *testing.T) {
+	tests := []struct {
+		desc      string
+		spec      appsv1.StatefulSetSpec
+		status    appsv1.StatefulSetStatus
+		wantState string
+		want      bool
+	}{
+		{
+			desc:      "not ready",
+			spec:      appsv1.StatefulSetSpec{Replicas: pointer.Int32Ptr(2)},
+			status:    appsv1.StatefulSetStatus{ReadyReplicas: 1},
+			wantState: "1/2",
+		},
+		{
+			desc: "rolling update in progress",
+			spec: appsv1.StatefulSetSpec{
+				Replicas:       pointer.Int32Ptr(2),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			},
+			status:    appsv1.StatefulSetStatus{ReadyReplicas: 2, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+			wantState: "2/2 updating",
+		},
+		{
+			desc: "rolled out",
+			spec: appsv1.StatefulSetSpec{
+				Replicas:       pointer.Int32Ptr(2),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			},
+			status:    appsv1.StatefulSetStatus{ReadyReplicas: 2, UpdatedReplicas: 2, CurrentRevision: "b", UpdateRevision: "b"},
+			wantState: "2/2",
+			want:      true,
+		},
+		{
+			desc: "partitioned",
+			spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Int32Ptr(3),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: pointer.Int32Ptr(2)},
+				},
+			},
+			status:    appsv1.StatefulSetStatus{ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+			wantState: "3/3",
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			state, ready := statefulSetState(&appsv1.StatefulSet{Spec: tt.spec, Status: tt.status})
+			if state != tt.wantState || ready != tt.want {
+				t.Errorf("got %s %v, want %s %v", state, ready, tt.wantState, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobState(t *testing.T) {
+	tests := []struct {
+		desc      string
+		job       batchv1.Job
+		wantState string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			desc:      "running",
+			job:       batchv1.Job{Spec: batchv1.JobSpec{Completions: pointer.Int32Ptr(2)}, Status: batchv1.JobStatus{Succeeded: 1}},
+			wantState: "1/2",
+		},
+		{
+			desc: "complete",
+			job: batchv1.Job{Status: batchv1.JobStatus{
+				Succeeded:  1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}},
+			}},
+			wantState: "1/1",
+			want:      true,
+		},
+		{
+			desc: "failed",
+			job: batchv1.Job{Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Message: "BackoffLimitExceeded"}},
+			}},
+			wantState: "0/1",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			state, ready, err := jobState(&tt.job)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if state != tt.wantState || ready != tt.want {
+				t.Errorf("got %s %v, want %s %v", state, ready, tt.wantState, tt.want)
+			}
+		})
+	}
+}
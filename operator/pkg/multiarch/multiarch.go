@@ -0,0 +1,191 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multiarch renders K8s objects to run on clusters with both amd64 and arm64 nodes.
+package multiarch
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// ArchLabel is the well known node label for the node architecture.
+	ArchLabel = "kubernetes.io/arch"
+	// BetaArchLabel is the deprecated node label for the node architecture, used by the chart affinity templates.
+	BetaArchLabel = "beta.kubernetes.io/arch"
+
+	enabledValuesPath = "global.multiArch.enabled"
+	hubValuesPath     = "global.multiArch.hub"
+)
+
+var (
+	// Arches are the architectures which pods are scheduled on.
+	Arches = []string{"amd64", "arm64"}
+)
+
+// Settings returns whether multi-arch scheduling is enabled and the multi-arch image hub from
+// values.global.multiArch of the given values tree.
+func Settings(values map[string]interface{}) (enabled bool, hub string) {
+	if v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(enabledValuesPath)); found {
+		enabled, _ = v.(bool)
+	}
+	if v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(hubValuesPath)); found {
+		hub, _ = v.(string)
+	}
+	return enabled, hub
+}
+
+// Apply returns manifest with the pod templates of all objects changed to schedule on amd64 and arm64 nodes.
+// Required node affinity terms which restrict the architecture are extended to include both, terms which don't are
+// restricted to both, and pods tolerate the kubernetes.io/arch=arm64:NoSchedule taint.
+func Apply(manifest string) (string, error) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	var out object.K8sObjects
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		if podSpecPath := podSpecPath(u.GetKind()); podSpecPath != nil {
+			spec, found, err := unstructured.NestedMap(u.Object, podSpecPath...)
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+			if found {
+				if err := setNodeAffinity(spec); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				if err := setToleration(spec); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				if err := unstructured.SetNestedMap(u.Object, spec, podSpecPath...); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+			}
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// podSpecPath returns the path to the pod spec of objects of the given kind, or nil if kind has none.
+func podSpecPath(kind string) []string {
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "Job":
+		return []string{"spec", "template", "spec"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	}
+	return nil
+}
+
+func setNodeAffinity(spec map[string]interface{}) error {
+	path := []string{"affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms"}
+	terms, _, err := unstructured.NestedSlice(spec, path...)
+	if err != nil {
+		return err
+	}
+	if len(terms) == 0 {
+		terms = []interface{}{map[string]interface{}{}}
+	}
+
+	restricted := false
+	for _, t := range terms {
+		tm, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		exprs, _ := tm["matchExpressions"].([]interface{})
+		for _, e := range exprs {
+			em, ok := e.(map[string]interface{})
+			if !ok || !isArchExpression(em) {
+				continue
+			}
+			restricted = true
+			em["values"] = addArches(em["values"])
+		}
+	}
+	if !restricted {
+		for _, t := range terms {
+			tm, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			exprs, _ := tm["matchExpressions"].([]interface{})
+			tm["matchExpressions"] = append(exprs, map[string]interface{}{
+				"key":      ArchLabel,
+				"operator": "In",
+				"values":   addArches(nil),
+			})
+		}
+	}
+	return unstructured.SetNestedSlice(spec, terms, path...)
+}
+
+func isArchExpression(expr map[string]interface{}) bool {
+	key, _ := expr["key"].(string)
+	op, _ := expr["operator"].(string)
+	return (key == ArchLabel || key == BetaArchLabel) && op == "In"
+}
+
+// addArches returns values, which is a node selector requirement values list, with any missing Arches appended.
+func addArches(values interface{}) []interface{} {
+	out, _ := values.([]interface{})
+	have := make(map[string]bool)
+	for _, v := range out {
+		have[fmt.Sprint(v)] = true
+	}
+	for _, a := range Arches {
+		if !have[a] {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func setToleration(spec map[string]interface{}) error {
+	tolerations, _, err := unstructured.NestedSlice(spec, "tolerations")
+	if err != nil {
+		return err
+	}
+	for _, t := range tolerations {
+		tm, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := tm["key"].(string)
+		// A toleration with an empty key and the Exists operator tolerates all taints.
+		if ((key == "" || key == ArchLabel) && tm["operator"] == "Exists") || (key == ArchLabel && tm["value"] == "arm64") {
+			return nil
+		}
+	}
+	tolerations = append(tolerations, map[string]interface{}{
+		"key":      ArchLabel,
+		"operator": "Equal",
+		"value":    "arm64",
+		"effect":   "NoSchedule",
+	})
+	return unstructured.SetNestedSlice(spec, tolerations, "tolerations")
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiarch
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestApply(t *testing.T) {
+	manifest := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+            - matchExpressions:
+              - key: beta.kubernetes.io/arch
+                operator: In
+                values:
+                - amd64
+                - s390x
+      containers:
+      - name: istio-proxy
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      tolerations:
+      - key: kubernetes.io/arch
+        operator: Exists
+      containers:
+      - name: discovery
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+`
+	got, err := Apply(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc            string
+		wantExpressions []interface{}
+		wantTolerations int
+	}{
+		{
+			desc: "extend arch expression",
+			wantExpressions: []interface{}{
+				map[string]interface{}{"key": BetaArchLabel, "operator": "In", "values": []interface{}{"amd64", "s390x", "arm64"}},
+			},
+			wantTolerations: 1,
+		},
+		{
+			desc: "add arch expression",
+			wantExpressions: []interface{}{
+				map[string]interface{}{"key": ArchLabel, "operator": "In", "values": []interface{}{"amd64", "arm64"}},
+			},
+			wantTolerations: 1,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			spec := objs[i].Unstructured()
+			terms, _, _ := unstructured.NestedSlice(spec, "spec", "template", "spec", "affinity", "nodeAffinity",
+				"requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+			if len(terms) != 1 {
+				t.Fatalf("got %d nodeSelectorTerms, want 1", len(terms))
+			}
+			if got := terms[0].(map[string]interface{})["matchExpressions"]; !reflect.DeepEqual(got, tt.wantExpressions) {
+				t.Errorf("got matchExpressions %v, want %v", got, tt.wantExpressions)
+			}
+			tolerations, _, _ := unstructured.NestedSlice(spec, "spec", "template", "spec", "tolerations")
+			if len(tolerations) != tt.wantTolerations {
+				t.Errorf("got %d tolerations, want %d", len(tolerations), tt.wantTolerations)
+			}
+		})
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(objs[2].Unstructured(), "spec"); found {
+		t.Errorf("Service was changed: %v", objs[2].Unstructured())
+	}
+}
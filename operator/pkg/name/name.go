@@ -16,7 +16,9 @@ package name
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -111,12 +113,34 @@ func init() {
 type ManifestMap map[ComponentName][]string
 
 func (mm ManifestMap) String() string {
-	out := ""
-	for _, ms := range mm {
-		for _, m := range ms {
-			out += m + helm.YAMLSeparator
+	var sb strings.Builder
+	_, _ = mm.WriteTo(&sb)
+	return sb.String()
+}
+
+// WriteTo writes the manifests in mm to w, each followed by a YAML separator, ordered by component name. It
+// implements io.WriterTo and avoids building the whole output in memory.
+func (mm ManifestMap) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for _, cn := range mm.SortedComponentNames() {
+		for _, m := range mm[cn] {
+			n, err := io.WriteString(w, m+helm.YAMLSeparator)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
 		}
 	}
+	return total, nil
+}
+
+// SortedComponentNames returns the component names in mm in sorted order.
+func (mm ManifestMap) SortedComponentNames() []ComponentName {
+	out := make([]ComponentName, 0, len(mm))
+	for cn := range mm {
+		out = append(out, cn)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
 	return out
 }
 
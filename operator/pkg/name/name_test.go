@@ -126,3 +126,14 @@ a:
 		})
 	}
 }
+
+func TestManifestMapString(t *testing.T) {
+	mm := ManifestMap{
+		PilotComponentName:     {"kind: Deployment"},
+		IstioBaseComponentName: {"kind: Namespace", "kind: ServiceAccount"},
+	}
+	want := "kind: Namespace\n---\nkind: ServiceAccount\n---\nkind: Deployment\n---\n"
+	if got := mm.String(); got != want {
+		t.Errorf("String: got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodeos renders K8s objects to run on the right nodes of clusters with both Linux and Windows nodes.
+package nodeos
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// OSLabel is the well known node label for the node operating system.
+	OSLabel = "kubernetes.io/os"
+	// BetaOSLabel is the deprecated node label for the node operating system.
+	BetaOSLabel = "beta.kubernetes.io/os"
+
+	// Linux is the linux operating system.
+	Linux = "linux"
+	// Windows is the windows operating system.
+	Windows = "windows"
+
+	valuesPath = "global.nodeOS"
+)
+
+var (
+	// OperatingSystems are the valid node operating systems.
+	OperatingSystems = map[string]bool{Linux: true, Windows: true}
+)
+
+// Settings returns the node operating systems in values.global.nodeOS of the given values tree, or nil if none are set.
+func Settings(values map[string]interface{}) []string {
+	v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(valuesPath))
+	if !found {
+		return nil
+	}
+	l, _ := v.([]interface{})
+	var out []string
+	for _, os := range l {
+		out = append(out, fmt.Sprint(os))
+	}
+	return out
+}
+
+// Apply returns manifest with the pod templates of all objects restricted to nodes of the given operating systems.
+// If operatingSystems is empty, only DaemonSets are restricted, to linux nodes, since they would otherwise also be
+// scheduled on Windows nodes which cannot run them. manifest is returned unchanged if it has no object to restrict.
+func Apply(manifest string, operatingSystems []string) (string, error) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	changed := false
+	var out object.K8sObjects
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		oses := operatingSystems
+		if len(oses) == 0 && u.GetKind() == "DaemonSet" {
+			oses = []string{Linux}
+		}
+		if podSpecPath := podSpecPath(u.GetKind()); podSpecPath != nil && len(oses) != 0 {
+			spec, found, err := unstructured.NestedMap(u.Object, podSpecPath...)
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+			if found {
+				if err := selectOS(spec, oses); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				if err := unstructured.SetNestedMap(u.Object, spec, podSpecPath...); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				changed = true
+			}
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	if !changed {
+		return manifest, nil
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// podSpecPath returns the path to the pod spec of objects of the given kind, or nil if kind has none.
+func podSpecPath(kind string) []string {
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "Job":
+		return []string{"spec", "template", "spec"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	}
+	return nil
+}
+
+// selectOS replaces any operating system node selector in the pod spec with one for oses. A single operating system
+// is selected with nodeSelector, several with a required node affinity expression, which is added to every term.
+func selectOS(spec map[string]interface{}, oses []string) error {
+	nodeSelector, _, err := unstructured.NestedStringMap(spec, "nodeSelector")
+	if err != nil {
+		return err
+	}
+	if nodeSelector == nil {
+		nodeSelector = make(map[string]string)
+	}
+	delete(nodeSelector, BetaOSLabel)
+	delete(nodeSelector, OSLabel)
+	if len(oses) == 1 {
+		nodeSelector[OSLabel] = oses[0]
+		return unstructured.SetNestedStringMap(spec, nodeSelector, "nodeSelector")
+	}
+	if len(nodeSelector) == 0 {
+		unstructured.RemoveNestedField(spec, "nodeSelector")
+	} else if err := unstructured.SetNestedStringMap(spec, nodeSelector, "nodeSelector"); err != nil {
+		return err
+	}
+
+	path := []string{"affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms"}
+	terms, _, err := unstructured.NestedSlice(spec, path...)
+	if err != nil {
+		return err
+	}
+	if len(terms) == 0 {
+		terms = []interface{}{map[string]interface{}{}}
+	}
+	values := make([]interface{}, 0, len(oses))
+	for _, os := range oses {
+		values = append(values, os)
+	}
+	for _, t := range terms {
+		tm, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		exprs, _ := tm["matchExpressions"].([]interface{})
+		var kept []interface{}
+		for _, e := range exprs {
+			if em, ok := e.(map[string]interface{}); ok && (em["key"] == OSLabel || em["key"] == BetaOSLabel) {
+				continue
+			}
+			kept = append(kept, e)
+		}
+		tm["matchExpressions"] = append(kept, map[string]interface{}{
+			"key":      OSLabel,
+			"operator": "In",
+			"values":   values,
+		})
+	}
+	return unstructured.SetNestedSlice(spec, terms, path...)
+}
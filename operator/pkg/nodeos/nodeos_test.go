@@ -0,0 +1,119 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeos
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+const manifest = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: istio-cni-node
+  namespace: kube-system
+spec:
+  template:
+    spec:
+      nodeSelector:
+        beta.kubernetes.io/os: linux
+      containers:
+      - name: install-cni
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+`
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		desc             string
+		operatingSystems []string
+		wantSelectors    []map[string]string
+		wantAffinity     []bool
+	}{
+		{
+			desc:          "default",
+			wantSelectors: []map[string]string{{OSLabel: Linux}, nil},
+			wantAffinity:  []bool{false, false},
+		},
+		{
+			desc:             "linux",
+			operatingSystems: []string{Linux},
+			wantSelectors:    []map[string]string{{OSLabel: Linux}, {OSLabel: Linux}},
+			wantAffinity:     []bool{false, false},
+		},
+		{
+			desc:             "linux and windows",
+			operatingSystems: []string{Linux, Windows},
+			wantSelectors:    []map[string]string{nil, nil},
+			wantAffinity:     []bool{true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Apply(manifest, tt.operatingSystems)
+			if err != nil {
+				t.Fatal(err)
+			}
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, o := range objs {
+				selector, _, _ := unstructured.NestedStringMap(o.Unstructured(), "spec", "template", "spec", "nodeSelector")
+				if !reflect.DeepEqual(selector, tt.wantSelectors[i]) {
+					t.Errorf("%s: got nodeSelector %v, want %v", o.Hash(), selector, tt.wantSelectors[i])
+				}
+				_, affinity, _ := unstructured.NestedSlice(o.Unstructured(), "spec", "template", "spec", "affinity",
+					"nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+				if affinity != tt.wantAffinity[i] {
+					t.Errorf("%s: got node affinity %v, want %v", o.Hash(), affinity, tt.wantAffinity[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApplyUnchanged(t *testing.T) {
+	const unrestricted = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: coredns
+data:
+  Corefile: |
+    .:53 {
+    }
+`
+	got, err := Apply(unrestricted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != unrestricted {
+		t.Errorf("got manifest:\n%s\nwant it unchanged:\n%s", got, unrestricted)
+	}
+}
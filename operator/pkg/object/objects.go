@@ -15,6 +15,16 @@
 /*
 Package manifest provides functions for going between in-memory k8s objects (unstructured.Unstructured) and their JSON
 or YAML representations.
+
+K8sObjects parsed from generated manifests can be filtered with Predicates, like ByGroupKind and ByLabels, grouped by
+the component they belong to with GroupByComponent, sorted in the order in which they are applied with SortApplyOrder
+and serialized with YAMLManifest or WriteYAMLManifest:
+
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	...
+	deployments := objs.Filter(object.ByGroupKind(schema.GroupKind{Group: "apps", Kind: "Deployment"}))
+	deployments.SortApplyOrder()
+	err = deployments.WriteYAMLManifest(os.Stdout)
 */
 package object
 
@@ -22,7 +32,10 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -39,6 +52,9 @@ import (
 const (
 	// YAMLSeparator is a separator for multi-document YAML files.
 	YAMLSeparator = "\n---\n"
+	// MaxYAMLDocumentSize is the size limit of each document of a parsed YAML manifest, above the request size limit
+	// of the API server, which bounds the memory used for a malformed or hostile manifest.
+	MaxYAMLDocumentSize = 8 * 1024 * 1024
 )
 
 // K8sObject is an in-memory representation of a k8s object, used for moving between different representations
@@ -114,15 +130,22 @@ func ParseJSONToK8sObject(json []byte) (*K8sObject, error) {
 
 // ParseYAMLToK8sObject parses YAML to an Object.
 func ParseYAMLToK8sObject(yaml []byte) (*K8sObject, error) {
+	out, err := decodeYAML(yaml)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding object %v: %v", string(yaml), err)
+	}
+	return NewK8sObject(out, nil, yaml), nil
+}
+
+func decodeYAML(yaml []byte) (*unstructured.Unstructured, error) {
 	r := bytes.NewReader(yaml)
 	decoder := k8syaml.NewYAMLOrJSONDecoder(r, 1024)
 
 	out := &unstructured.Unstructured{}
-	err := decoder.Decode(out)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding object %v: %v", string(yaml), err)
+	if err := decoder.Decode(out); err != nil {
+		return nil, err
 	}
-	return NewK8sObject(out, nil, yaml), nil
+	return out, nil
 }
 
 // UnstructuredObject exposes the raw object, primarily for testing
@@ -243,61 +266,134 @@ func ParseK8sObjectsFromYAMLManifest(manifest string) (K8sObjects, error) {
 // ParseK8sObjectsFromYAMLManifest returns a K8sObjects representation of manifest. Continues parsing when a bad object
 // is found if failOnError is set to false.
 func ParseK8sObjectsFromYAMLManifestFailOption(manifest string, failOnError bool) (K8sObjects, error) {
-	var b bytes.Buffer
+	return ParseK8sObjectsFromYAMLReader(strings.NewReader(manifest), failOnError)
+}
 
-	var yamls []string
-	scanner := bufio.NewScanner(strings.NewReader(manifest))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "---") {
-			// yaml separator
-			yamls = append(yamls, b.String())
-			b.Reset()
-		} else {
-			if _, err := b.WriteString(line); err != nil {
-				return nil, err
-			}
-			if _, err := b.WriteString("\n"); err != nil {
-				return nil, err
-			}
-		}
+// ParseK8sObjectsFromYAMLReader returns a K8sObjects representation of the multi-document YAML manifest read from r.
+// Each document is parsed as soon as it has been read, so the whole manifest is never held in memory. Continues
+// parsing when a bad object is found if failOnError is set to false.
+func ParseK8sObjectsFromYAMLReader(r io.Reader, failOnError bool) (K8sObjects, error) {
+	var objects K8sObjects
+	err := StreamK8sObjectsFromYAML(r, failOnError, func(o *K8sObject) error {
+		objects = append(objects, o)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	yamls = append(yamls, b.String())
+	return objects, nil
+}
 
-	var objects K8sObjects
+// StreamK8sObjectsFromYAML parses the multi-document YAML manifest read from r and calls f with each object as soon
+// as its document has been read, so that neither the manifest nor its objects need to be held in memory. Documents
+// which are empty, null or only hold comments, like the ones of disabled chart templates, are skipped. Documents
+// larger than MaxYAMLDocumentSize are rejected, and parse errors report the line in the manifest they occur at.
+// Continues parsing when a bad object is found if failOnError is set to false. Parsing stops at the first error
+// returned by f, which is returned.
+func StreamK8sObjectsFromYAML(r io.Reader, failOnError bool, f func(*K8sObject) error) error {
+	var b bytes.Buffer
+	// line is the current line of the manifest and docLine the first line of the current document, both from 1.
+	line, docLine := 0, 1
 
-	for _, yaml := range yamls {
-		yaml = removeNonYAMLLines(yaml)
+	parse := func() error {
+		defer b.Reset()
+		doc := b.String()
+		yaml := removeNonYAMLLines(doc)
 		if yaml == "" {
-			continue
+			return nil
 		}
-		o, err := ParseYAMLToK8sObject([]byte(yaml))
+		u, err := decodeYAML([]byte(yaml))
 		if err != nil {
-			e := fmt.Errorf("failed to parse YAML to a k8s object: %s", err)
+			if isNullYAML(yaml) {
+				return nil
+			}
+			e := fmt.Errorf("failed to parse YAML to a k8s object in the document at line %d: %s", docLine, manifestLines(err, doc, docLine))
 			if failOnError {
-				return nil, e
+				return e
 			}
-			log.Error(err.Error())
+			log.Error(e.Error())
+			return nil
+		}
+		if len(u.Object) == 0 {
+			return nil
+		}
+		return f(NewK8sObject(u, nil, []byte(yaml)))
+	}
+
+	scanner := bufio.NewScanner(r)
+	// Lines may be as long as a document, e.g. for files embedded in ConfigMaps.
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), MaxYAMLDocumentSize)
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.HasPrefix(text, "---") {
+			// yaml separator
+			if err := parse(); err != nil {
+				return err
+			}
+			docLine = line + 1
 			continue
 		}
+		if b.Len()+len(text)+1 > MaxYAMLDocumentSize {
+			return fmt.Errorf("the YAML document at line %d is larger than the limit of %d bytes", docLine, MaxYAMLDocumentSize)
+		}
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		if err == bufio.ErrTooLong {
+			return fmt.Errorf("line %d of the YAML manifest is longer than the limit of %d bytes", line+1, MaxYAMLDocumentSize)
+		}
+		return err
+	}
+	return parse()
+}
 
-		objects = append(objects, o)
+// lineRegexp matches the line numbers in the errors of the YAML parser, which count from the start of the document.
+var lineRegexp = regexp.MustCompile(`yaml: line (\d+)`)
+
+// manifestLines returns the message of the error err parsing the document doc, which starts at line docLine of the
+// manifest, with the line numbers in the document replaced by the line numbers in the manifest.
+func manifestLines(err error, doc string, docLine int) string {
+	return lineRegexp.ReplaceAllStringFunc(err.Error(), func(m string) string {
+		n, _ := strconv.Atoi(strings.TrimPrefix(m, "yaml: line "))
+		return fmt.Sprintf("yaml: line %d", docLine+docLineOf(doc, n)-1)
+	})
+}
+
+// docLineOf returns the line of doc which is line n of removeNonYAMLLines(doc), both from 1.
+func docLineOf(doc string, n int) int {
+	kept := 0
+	for i, l := range strings.Split(doc, "\n") {
+		if strings.HasPrefix(l, "#") || kept == 0 && strings.TrimSpace(l) == "" {
+			continue
+		}
+		if kept++; kept == n {
+			return i + 1
+		}
 	}
+	return n
+}
 
-	return objects, nil
+// isNullYAML reports whether the YAML document yaml is null, e.g. because it only holds indented comments or an
+// explicit null.
+func isNullYAML(yml string) bool {
+	var v interface{}
+	return yaml.Unmarshal([]byte(yml), &v) == nil && v == nil
 }
 
 func removeNonYAMLLines(yms string) string {
-	out := ""
+	var out strings.Builder
 	for _, s := range strings.Split(yms, "\n") {
 		if strings.HasPrefix(s, "#") {
 			continue
 		}
-		out += s + "\n"
+		out.WriteString(s)
+		out.WriteString("\n")
 	}
 
 	// helm charts sometimes emits blank objects with just a "disabled" comment.
-	return strings.TrimSpace(out)
+	return strings.TrimSpace(out.String())
 }
 
 // JSONManifest returns a JSON representation of K8sObjects os.
@@ -327,26 +423,9 @@ func (os K8sObjects) JSONManifest() (string, error) {
 // YAMLManifest returns a YAML representation of K8sObjects os.
 func (os K8sObjects) YAMLManifest() (string, error) {
 	var b bytes.Buffer
-
-	for i, item := range os {
-		if i != 0 {
-			if _, err := b.WriteString("\n\n"); err != nil {
-				return "", err
-			}
-		}
-		ym, err := item.YAML()
-		if err != nil {
-			return "", fmt.Errorf("error building yaml: %v", err)
-		}
-		if _, err := b.Write(ym); err != nil {
-			return "", err
-		}
-		if _, err := b.Write([]byte(YAMLSeparator)); err != nil {
-			return "", err
-		}
-
+	if err := os.WriteYAMLManifest(&b); err != nil {
+		return "", err
 	}
-
 	return b.String(), nil
 }
 
@@ -391,6 +470,40 @@ func (os K8sObjects) ToNameKindMap() map[string]*K8sObject {
 	return ret
 }
 
+// ContainerImages returns the sorted, de-duplicated values of all image fields in os, e.g. container images.
+func (os K8sObjects) ContainerImages() []string {
+	seen := make(map[string]bool)
+	for _, o := range os {
+		collectImages(o.object.Object, seen)
+	}
+	var out []string
+	for image := range seen {
+		out = append(out, image)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// collectImages adds the values of all non-empty image fields in node to images.
+func collectImages(node interface{}, images map[string]bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			if image, ok := v.(string); ok && k == "image" {
+				if image != "" {
+					images[image] = true
+				}
+				continue
+			}
+			collectImages(v, images)
+		}
+	case []interface{}:
+		for _, v := range n {
+			collectImages(v, images)
+		}
+	}
+}
+
 // Valid checks returns true if Kind and Name of K8sObject are both not empty.
 func (o *K8sObject) Valid() bool {
 	if o.Kind == "" || o.Name == "" {
@@ -15,6 +15,9 @@
 package object
 
 import (
+	"bufio"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -494,3 +497,74 @@ spec:
 		})
 	}
 }
+
+func TestStreamK8sObjectsFromYAML(t *testing.T) {
+	cm := func(name string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n"
+	}
+	tests := []struct {
+		desc      string
+		manifest  string
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			desc: "null and comment documents",
+			manifest: "---\n# Source: disabled.yaml\n---\nnull\n---\n~\n---\n  # indented comment\n---\n" + cm("a") +
+				"---\n\n" + cm("b"),
+			wantNames: []string{"a", "b"},
+		},
+		{
+			desc:     "error line",
+			manifest: cm("a") + "---\n# Source: bad.yaml\n\napiVersion: v1\nkind: ConfigMap\nmetadata:\n\tname: b\n",
+			wantErr: "failed to parse YAML to a k8s object in the document at line 6: error converting YAML to JSON: yaml: line 11: " +
+				"found character that cannot start any token",
+		},
+		{
+			desc:     "document too large",
+			manifest: cm("a") + "---\n" + cm("b") + "data:\n" + strings.Repeat("  big: "+strings.Repeat("x", 1024*1024)+"\n", 9),
+			wantErr:  "the YAML document at line 6 is larger than the limit of 8388608 bytes",
+		},
+		{
+			desc:     "line too long",
+			manifest: cm("a") + "---\n" + cm("b") + "data:\n  big: " + strings.Repeat("x", MaxYAMLDocumentSize) + "\n",
+			wantErr:  "line 11 of the YAML manifest is longer than the limit of 8388608 bytes",
+		},
+		{
+			desc:      "long lines",
+			manifest:  cm("a") + "data:\n  big: " + strings.Repeat("x", 2*bufio.MaxScanTokenSize) + "\n",
+			wantNames: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var gotNames []string
+			err := StreamK8sObjectsFromYAML(strings.NewReader(tt.manifest), true, func(o *K8sObject) error {
+				gotNames = append(gotNames, o.Name)
+				return nil
+			})
+			if gotErr := fmt.Sprint(err); err != nil || tt.wantErr != "" {
+				if !strings.HasPrefix(gotErr, tt.wantErr) || tt.wantErr == "" {
+					t.Fatalf("got error %q, want %q", gotErr, tt.wantErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("got objects %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestStreamK8sObjectsFromYAMLStops(t *testing.T) {
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n"
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err := StreamK8sObjectsFromYAML(strings.NewReader(manifest), true, func(*K8sObject) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("got error %v after %d objects, want %v after 1", err, calls, stop)
+	}
+}
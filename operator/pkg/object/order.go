@@ -0,0 +1,151 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package object
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// AnyKind is the ApplyOrder entry for all kinds which are not listed otherwise.
+const AnyKind = "*"
+
+// ApplyOrder is the order in which objects are applied, as a list of kinds, and deleted, in reverse. Each entry is
+// either the Kind of a core group kind, like Namespace, a group/Kind, all kinds of a group as group/*, or AnyKind.
+// Objects of kinds which match no entry are applied last.
+type ApplyOrder []string
+
+// DefaultApplyOrderKinds is the order in which objects are applied by default: namespaces and CRDs first, since
+// everything else may be created in them or be an instance of them, then service accounts and RBAC before anything
+// which runs with them, webhook configurations and Istio config, then ConfigMaps and Secrets before the workloads
+// which use them, autoscalers after the workloads they scale and Services last, once their pods have been started.
+var DefaultApplyOrderKinds = ApplyOrder{
+	"Namespace",
+	"apiextensions.k8s.io/CustomResourceDefinition",
+	"ServiceAccount",
+	"rbac.authorization.k8s.io/ClusterRole",
+	"rbac.authorization.k8s.io/ClusterRoleBinding",
+	"rbac.authorization.k8s.io/Role",
+	"rbac.authorization.k8s.io/RoleBinding",
+	// validatingwebhookconfiguration is configured to FAIL-OPEN in the default install. For the
+	// re-install case we want to apply the validatingwebhookconfiguration first to reset any
+	// orphaned validatingwebhookconfiguration that is FAIL-CLOSE.
+	"admissionregistration.k8s.io/ValidatingWebhookConfiguration",
+	"config.istio.io/*",
+	"rbac.istio.io/*",
+	"security.istio.io/*",
+	"authentication.istio.io/*",
+	"networking.istio.io/*",
+	"ConfigMap",
+	"Secret",
+	AnyKind,
+	"autoscaling/HorizontalPodAutoscaler",
+	"Service",
+}
+
+// DefaultApplyOrder is the score of o for Sort which orders objects as in DefaultApplyOrderKinds.
+func DefaultApplyOrder(o *K8sObject) int {
+	return DefaultApplyOrderKinds.Score(o)
+}
+
+// ParseApplyOrder parses a comma separated list of ApplyOrder entries, e.g.
+// "apiextensions.k8s.io/CustomResourceDefinition,example.com/Widget,*".
+func ParseApplyOrder(s string) (ApplyOrder, error) {
+	var out ApplyOrder
+	seen := make(map[string]bool)
+	for _, e := range strings.Split(s, ",") {
+		e = strings.TrimSpace(e)
+		if e != AnyKind {
+			parts := strings.Split(e, "/")
+			if len(parts) > 2 || parts[len(parts)-1] == "" || len(parts) == 2 && parts[0] == "" {
+				return nil, fmt.Errorf("bad apply order entry %q, must be a Kind, group/Kind, group/* or *", e)
+			}
+		}
+		if seen[e] {
+			return nil, fmt.Errorf("apply order entry %s is listed more than once", e)
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// Override returns override, with its AnyKind entry, if any, replaced by the entries of ao which are not in override.
+// An override of "example.com/Widget,*" thus applies Widgets first and everything else in the order of ao, while an
+// override without AnyKind replaces ao. ao is returned if override is empty.
+func (ao ApplyOrder) Override(override ApplyOrder) ApplyOrder {
+	if len(override) == 0 {
+		return ao
+	}
+	listed := make(map[string]bool)
+	for _, e := range override {
+		listed[e] = true
+	}
+	var out ApplyOrder
+	for _, e := range override {
+		if e != AnyKind {
+			out = append(out, e)
+			continue
+		}
+		for _, d := range ao {
+			if d == AnyKind || !listed[d] {
+				out = append(out, d)
+			}
+		}
+	}
+	return out
+}
+
+// Score returns the score of o for Sort which orders objects as in ao.
+func (ao ApplyOrder) Score(o *K8sObject) int {
+	return ao.ScoreGroupKind(o.Group, o.Kind)
+}
+
+// ScoreGroupKind returns the position in ao of the given group kind: the position of its group/Kind entry if it is
+// listed, or else of its group/* entry, or else of the AnyKind entry, or else len(ao).
+func (ao ApplyOrder) ScoreGroupKind(group, kind string) int {
+	exact, inGroup := kind, group+"/"+AnyKind
+	if group != "" {
+		exact = group + "/" + kind
+	}
+	groupScore, anyScore := -1, len(ao)
+	for i, e := range ao {
+		switch e {
+		case exact:
+			return i
+		case inGroup:
+			groupScore = i
+		case AnyKind:
+			anyScore = i
+		}
+	}
+	if groupScore >= 0 {
+		return groupScore
+	}
+	return anyScore
+}
+
+// DeletionOrder returns gvks in the order in which they are deleted, which is the reverse of ao. Kinds with the same
+// position in ao keep their order in gvks.
+func (ao ApplyOrder) DeletionOrder(gvks []schema.GroupVersionKind) []schema.GroupVersionKind {
+	out := append([]schema.GroupVersionKind{}, gvks...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return ao.ScoreGroupKind(out[i].Group, out[i].Kind) > ao.ScoreGroupKind(out[j].Group, out[j].Kind)
+	})
+	return out
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package object
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseApplyOrder(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		want    ApplyOrder
+		wantErr bool
+	}{
+		{
+			desc: "kinds",
+			in:   "Namespace, example.com/Widget,example.com/*,*",
+			want: ApplyOrder{"Namespace", "example.com/Widget", "example.com/*", AnyKind},
+		},
+		{
+			desc:    "empty entry",
+			in:      "Namespace,,Service",
+			wantErr: true,
+		},
+		{
+			desc:    "no group",
+			in:      "/Widget",
+			wantErr: true,
+		},
+		{
+			desc:    "too many slashes",
+			in:      "example.com/v1/Widget",
+			wantErr: true,
+		},
+		{
+			desc:    "duplicate",
+			in:      "Service,Service",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseApplyOrder(tt.in)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrderOverride(t *testing.T) {
+	defaults := ApplyOrder{"Namespace", "apiextensions.k8s.io/CustomResourceDefinition", AnyKind, "Service"}
+	tests := []struct {
+		desc     string
+		override ApplyOrder
+		want     ApplyOrder
+	}{
+		{
+			desc: "none",
+			want: defaults,
+		},
+		{
+			desc:     "insert after CRDs",
+			override: ApplyOrder{"apiextensions.k8s.io/CustomResourceDefinition", "example.com/Widget", AnyKind},
+			want:     ApplyOrder{"apiextensions.k8s.io/CustomResourceDefinition", "example.com/Widget", "Namespace", AnyKind, "Service"},
+		},
+		{
+			desc:     "replace",
+			override: ApplyOrder{"Service", "Namespace"},
+			want:     ApplyOrder{"Service", "Namespace"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := defaults.Override(tt.override); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrderScore(t *testing.T) {
+	ao := ApplyOrder{"Namespace", "example.com/*", "example.com/Widget", AnyKind, "Service"}
+	tests := []struct {
+		group, kind string
+		want        int
+	}{
+		{kind: "Namespace", want: 0},
+		{group: "example.com", kind: "Gadget", want: 1},
+		{group: "example.com", kind: "Widget", want: 2},
+		{group: "apps", kind: "Deployment", want: 3},
+		{kind: "Service", want: 4},
+		{group: "example.org", kind: "Service", want: 3},
+	}
+	for _, tt := range tests {
+		if got := ao.ScoreGroupKind(tt.group, tt.kind); got != tt.want {
+			t.Errorf("%s/%s: got %d, want %d", tt.group, tt.kind, got, tt.want)
+		}
+	}
+	if got, want := (ApplyOrder{"Namespace"}).ScoreGroupKind("", "Service"), 1; got != want {
+		t.Errorf("unlisted kind: got %d, want %d", got, want)
+	}
+}
+
+func TestDeletionOrder(t *testing.T) {
+	gvks := []schema.GroupVersionKind{
+		{Version: "v1", Kind: "ServiceAccount"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Version: "v1", Kind: "Service"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		{Version: "v1", Kind: "ConfigMap"},
+	}
+	var got []string
+	for _, gvk := range DefaultApplyOrderKinds.DeletionOrder(gvks) {
+		got = append(got, gvk.Kind)
+	}
+	want := []string{"Service", "Deployment", "DaemonSet", "ConfigMap", "ServiceAccount"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if gvks[0].Kind != "ServiceAccount" {
+		t.Error("DeletionOrder changed its input")
+	}
+}
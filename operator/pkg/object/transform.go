@@ -0,0 +1,224 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package object
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// ComponentLabel is the label with the Istio component which an installed object belongs to.
+	ComponentLabel = "operator.istio.io/component"
+)
+
+// Predicate selects K8sObjects, e.g. for Filter.
+type Predicate func(o *K8sObject) bool
+
+// ByGroupKind returns a Predicate selecting objects of any of the given group kinds. A group kind with the Kind "*"
+// selects all kinds in its group.
+func ByGroupKind(gks ...schema.GroupKind) Predicate {
+	return func(o *K8sObject) bool {
+		for _, gk := range gks {
+			if gk.Group == o.Group && (gk.Kind == "*" || gk.Kind == o.Kind) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByGroupVersionKind returns a Predicate selecting objects of any of the given group version kinds.
+func ByGroupVersionKind(gvks ...schema.GroupVersionKind) Predicate {
+	return func(o *K8sObject) bool {
+		gvk := o.GroupVersionKind()
+		for _, want := range gvks {
+			if gvk == want {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByLabels returns a Predicate selecting objects whose labels match selector, e.g. one parsed with labels.Parse.
+func ByLabels(selector labels.Selector) Predicate {
+	return func(o *K8sObject) bool {
+		return selector.Matches(labels.Set(o.object.GetLabels()))
+	}
+}
+
+// ByComponent returns a Predicate selecting objects with the ComponentLabel of any of the given components.
+func ByComponent(components ...string) Predicate {
+	return func(o *K8sObject) bool {
+		c := o.object.GetLabels()[ComponentLabel]
+		for _, want := range components {
+			if c == want {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate selecting the objects which p does not select.
+func Not(p Predicate) Predicate {
+	return func(o *K8sObject) bool {
+		return !p(o)
+	}
+}
+
+// Or returns a Predicate selecting the objects which any of ps selects.
+func Or(ps ...Predicate) Predicate {
+	return func(o *K8sObject) bool {
+		for _, p := range ps {
+			if p(o) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// filterFields are the fields of an object which ParseFilter terms match against.
+var filterFields = map[string]func(o *K8sObject) string{
+	"kind":       func(o *K8sObject) string { return o.Kind },
+	"name":       func(o *K8sObject) string { return o.Name },
+	"namespace":  func(o *K8sObject) string { return o.Namespace },
+	"group":      func(o *K8sObject) string { return o.Group },
+	"apiVersion": func(o *K8sObject) string { return o.object.GetAPIVersion() },
+}
+
+// ParseFilter returns a Predicate for a filter expression of comma separated terms, like
+// kind=Deployment,namespace=istio-system, selecting the objects which match all terms. A term is field=value or
+// field!=value, where the field is one of kind, name, namespace, group or apiVersion and the value may be a shell
+// pattern like istio-*. Cluster scoped objects have an empty namespace.
+func ParseFilter(expr string) (Predicate, error) {
+	var ps []Predicate
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		negate := false
+		kv := strings.SplitN(term, "!=", 2)
+		if len(kv) == 2 {
+			negate = true
+		} else {
+			kv = strings.SplitN(term, "=", 2)
+		}
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("bad filter term %q, must be field=value or field!=value", term)
+		}
+		field, pattern := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		get, ok := filterFields[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter field %q in %q, must be one of apiVersion, group, kind, name or namespace",
+				field, term)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("bad filter pattern %q in %q: %s", pattern, term, err)
+		}
+		p := Predicate(func(o *K8sObject) bool {
+			matched, _ := path.Match(pattern, get(o))
+			return matched
+		})
+		if negate {
+			p = Not(p)
+		}
+		ps = append(ps, p)
+	}
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("empty filter %q", expr)
+	}
+	return func(o *K8sObject) bool {
+		for _, p := range ps {
+			if !p(o) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// Filter returns the objects in os selected by all of the given predicates, in the order of os. os is unchanged.
+func (os K8sObjects) Filter(predicates ...Predicate) K8sObjects {
+	var out K8sObjects
+	for _, o := range os {
+		keep := true
+		for _, p := range predicates {
+			keep = keep && p(o)
+		}
+		if keep {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// GroupByComponent returns os grouped by the value of their ComponentLabel, in the order of os within each group.
+// Objects without the label, like the ones of manifests which have not been installed, are grouped under an empty
+// component.
+func (os K8sObjects) GroupByComponent() map[string]K8sObjects {
+	out := make(map[string]K8sObjects)
+	for _, o := range os {
+		c := o.object.GetLabels()[ComponentLabel]
+		out[c] = append(out[c], o)
+	}
+	return out
+}
+
+// SortedComponents returns the components of a GroupByComponent result in lexical order.
+func SortedComponents(groups map[string]K8sObjects) []string {
+	var out []string
+	for c := range groups {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SortApplyOrder sorts os in the order in which they are applied by default, see DefaultApplyOrderKinds.
+func (os K8sObjects) SortApplyOrder() {
+	os.Sort(DefaultApplyOrder)
+}
+
+// WriteYAMLManifest writes the YAML manifest of os to w, one object at a time, in the format of YAMLManifest.
+func (os K8sObjects) WriteYAMLManifest(w io.Writer) error {
+	for i, o := range os {
+		if i != 0 {
+			if _, err := io.WriteString(w, "\n\n"); err != nil {
+				return err
+			}
+		}
+		y, err := o.YAML()
+		if err != nil {
+			return fmt.Errorf("error building yaml: %v", err)
+		}
+		if _, err := w.Write(y); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, YAMLSeparator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package object
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const transformManifest = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    operator.istio.io/component: Pilot
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    app: istiod
+    operator.istio.io/component: Pilot
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  labels:
+    app: istio-ingressgateway
+    operator.istio.io/component: IngressGateways
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+`
+
+func hashes(objs K8sObjects) []string {
+	var out []string
+	for _, o := range objs {
+		out = append(out, o.Hash())
+	}
+	return out
+}
+
+func TestFilter(t *testing.T) {
+	objs, err := ParseK8sObjectsFromYAMLManifest(transformManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployments := ByGroupKind(schema.GroupKind{Group: "apps", Kind: "Deployment"})
+	tests := []struct {
+		desc       string
+		predicates []Predicate
+		want       []string
+	}{
+		{
+			desc: "all",
+			want: hashes(objs),
+		},
+		{
+			desc:       "group kind",
+			predicates: []Predicate{deployments},
+			want:       []string{"Deployment:istio-system:istiod", "Deployment:istio-system:istio-ingressgateway"},
+		},
+		{
+			desc:       "group wildcard",
+			predicates: []Predicate{ByGroupKind(schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "*"})},
+			want:       []string{"CustomResourceDefinition::gateways.networking.istio.io"},
+		},
+		{
+			desc:       "group version kind",
+			predicates: []Predicate{ByGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Service"})},
+			want:       []string{"Service:istio-system:istiod"},
+		},
+		{
+			desc:       "labels and kind",
+			predicates: []Predicate{deployments, ByLabels(labels.SelectorFromSet(labels.Set{"app": "istiod"}))},
+			want:       []string{"Deployment:istio-system:istiod"},
+		},
+		{
+			desc:       "not component",
+			predicates: []Predicate{Not(ByComponent("Pilot"))},
+			want:       []string{"Deployment:istio-system:istio-ingressgateway", "CustomResourceDefinition::gateways.networking.istio.io"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := hashes(objs.Filter(tt.predicates...)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	objs, err := ParseK8sObjectsFromYAMLManifest(transformManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc    string
+		expr    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "kind and namespace",
+			expr: "kind=Deployment,namespace=istio-system",
+			want: []string{"Deployment:istio-system:istiod", "Deployment:istio-system:istio-ingressgateway"},
+		},
+		{
+			desc: "pattern",
+			expr: "name=istio-*",
+			want: []string{"Deployment:istio-system:istio-ingressgateway"},
+		},
+		{
+			desc: "negated",
+			expr: " kind != Deployment , apiVersion=v1",
+			want: []string{"Service:istio-system:istiod"},
+		},
+		{
+			desc: "cluster scoped",
+			expr: "namespace=,group=apiextensions.k8s.io",
+			want: []string{"CustomResourceDefinition::gateways.networking.istio.io"},
+		},
+		{
+			desc:    "unknown field",
+			expr:    "label=app",
+			wantErr: true,
+		},
+		{
+			desc:    "no value",
+			expr:    "kind",
+			wantErr: true,
+		},
+		{
+			desc:    "bad pattern",
+			expr:    "name=[",
+			wantErr: true,
+		},
+		{
+			desc:    "empty",
+			expr:    ",",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p, err := ParseFilter(tt.expr)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := hashes(objs.Filter(p)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupByComponent(t *testing.T) {
+	objs, err := ParseK8sObjectsFromYAMLManifest(transformManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := objs.GroupByComponent()
+	if got, want := SortedComponents(groups), []string{"", "IngressGateways", "Pilot"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got components %v, want %v", got, want)
+	}
+	if got, want := hashes(groups["Pilot"]), []string{"Service:istio-system:istiod", "Deployment:istio-system:istiod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got Pilot objects %v, want %v", got, want)
+	}
+}
+
+func TestSortApplyOrder(t *testing.T) {
+	objs, err := ParseK8sObjectsFromYAMLManifest(transformManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs.SortApplyOrder()
+	want := []string{
+		"CustomResourceDefinition::gateways.networking.istio.io",
+		"Deployment:istio-system:istio-ingressgateway",
+		"Deployment:istio-system:istiod",
+		"Service:istio-system:istiod",
+	}
+	if got := hashes(objs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	var b strings.Builder
+	if err := objs.Filter(ByComponent("Pilot")).WriteYAMLManifest(&b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseK8sObjectsFromYAMLManifest(b.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hashes(got), want[2:]) {
+		t.Errorf("got serialized %v, want %v", hashes(got), want[2:])
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"fmt"
+)
+
+const (
+	// patchDirectiveKey is the key of a list item holding a merge directive.
+	patchDirectiveKey = "$patch"
+	// patchDelete as a merge directive of a list item deletes the matching base list item.
+	patchDelete = "delete"
+	// patchReplace as a merge directive of an item without other keys replaces the whole base list.
+	patchReplace = "replace"
+)
+
+// ListMergeKeys maps list field names to the keys used to match the items of a list patch with those of the base
+// list. The first key an item has is used. Lists of fields not listed here are replaced by list patches. Entries may be
+// added for lists of custom resources.
+var ListMergeKeys = map[string][]string{
+	"containers":       {"name"},
+	"initContainers":   {"name"},
+	"env":              {"name"},
+	"ports":            {"name", "containerPort", "port"},
+	"volumes":          {"name"},
+	"volumeMounts":     {"mountPath"},
+	"imagePullSecrets": {"name"},
+	"hostAliases":      {"ip"},
+}
+
+// listNode returns the list held by node, which may be a pointer to the list.
+func listNode(node interface{}) ([]interface{}, bool) {
+	if p, ok := node.(*interface{}); ok {
+		node = *p
+	}
+	l, ok := node.([]interface{})
+	return l, ok
+}
+
+// mergeLists returns the result of merging the overlay list into the base list of the given field.
+func mergeLists(base, overlay []interface{}, field string) []interface{} {
+	var items []interface{}
+	replace := false
+	for _, o := range overlay {
+		if om, ok := toStringKeyMap(o); ok && len(om) == 1 && om[patchDirectiveKey] == patchReplace {
+			replace = true
+			continue
+		}
+		items = append(items, o)
+	}
+	keys := ListMergeKeys[field]
+	if replace || len(keys) == 0 {
+		return items
+	}
+
+	out := append([]interface{}{}, base...)
+	for _, o := range items {
+		om, ok := toStringKeyMap(o)
+		if !ok {
+			out = append(out, o)
+			continue
+		}
+		idx := matchingItem(out, om, keys)
+		switch {
+		case om[patchDirectiveKey] == patchDelete:
+			if idx >= 0 {
+				out = append(out[:idx], out[idx+1:]...)
+			}
+		case idx >= 0:
+			bm, _ := toStringKeyMap(out[idx])
+			out[idx] = mergeMaps(bm, om)
+		default:
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// matchingItem returns the index of the item in list with the same value as item for the first of keys which item
+// has, or -1 if there is none.
+func matchingItem(list []interface{}, item map[string]interface{}, keys []string) int {
+	for _, k := range keys {
+		v, ok := item[k]
+		if !ok {
+			continue
+		}
+		for i, l := range list {
+			if lm, ok := toStringKeyMap(l); ok && lm[k] != nil && fmt.Sprint(lm[k]) == fmt.Sprint(v) {
+				return i
+			}
+		}
+		return -1
+	}
+	return -1
+}
+
+// mergeMaps returns base with the values of overlay merged into it. Nested maps are merged and nested lists are merged
+// according to their merge keys.
+func mergeMaps(base, overlay map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(base)+len(overlay))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, ov := range overlay {
+		bv, ok := out[k]
+		if !ok {
+			out[k] = ov
+			continue
+		}
+		if om, ok := toStringKeyMap(ov); ok {
+			if bm, ok := toStringKeyMap(bv); ok {
+				out[k] = mergeMaps(bm, om)
+				continue
+			}
+		}
+		if ol, ok := ov.([]interface{}); ok {
+			if bl, ok := bv.([]interface{}); ok {
+				out[k] = mergeLists(bl, ol, k)
+				continue
+			}
+		}
+		out[k] = ov
+	}
+	return out
+}
+
+// toStringKeyMap returns a copy of v with string keys if it is a map, which may be unmarshaled by either JSON or YAML.
+func toStringKeyMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			out[fmt.Sprint(k)] = v
+		}
+		return out, true
+	}
+	return nil, false
+}
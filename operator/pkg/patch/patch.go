@@ -76,6 +76,28 @@ ADD
   value:
     new_attr: v3
 
+MERGE LISTS
+
+A list value written to a path selecting a list replaces the list, unless the list field has merge keys in
+ListMergeKeys, like env, ports and volumes. Items of such lists are merged with the item of the base list which has
+the same value for the first merge key the item has, or added if there is none. Like in a strategic merge patch, an
+item with $patch: delete deletes the matching item, and an item $patch: replace replaces the whole list.
+
+1. Add or update the env var FOO of container n1, keeping the other env vars
+
+  path: spec.template.spec.containers.[name:n1].env
+  value:
+  - name: FOO
+    value: bar
+
+2. Replace all volumes
+
+  path: spec.template.spec.volumes
+  value:
+  - $patch: replace
+  - name: config
+    emptyDir: {}
+
 *NOTES*
 - Due to loss of string quoting during unmarshaling, keys and values should not be string quoted, even if they appear
 that way in the object being patched.
@@ -193,12 +215,19 @@ func applyPatches(base *object.K8sObject, patches []*v1alpha1.K8SObjectOverlay_P
 			continue
 		}
 		scope.Debugf("applying path=%s, value=%s\n", p.Path, p.Value)
-		inc, _, err := tpath.GetPathContext(bo, util.PathFromString(p.Path), false)
+		path := util.PathFromString(p.Path)
+		inc, _, err := tpath.GetPathContext(bo, path, false)
 		if err != nil {
 			errs = util.AppendErr(errs, err)
 			continue
 		}
-		errs = util.AppendErr(errs, tpath.WritePathContext(inc, p.Value, false))
+		value := p.Value
+		if bl, ok := listNode(inc.Node); ok {
+			if ol, ok := value.([]interface{}); ok {
+				value = mergeLists(bl, ol, path[len(path)-1])
+			}
+		}
+		errs = util.AppendErr(errs, tpath.WritePathContext(inc, value, false))
 	}
 	oy, err := yaml.Marshal(bo)
 	if err != nil {
@@ -492,3 +492,165 @@ func errToString(err error) string {
 	}
 	return err.Error()
 }
+
+func TestPatchYAMLManifestMergeLists(t *testing.T) {
+	base := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: citadel
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: b
+      volumes:
+      - name: certs
+        secret:
+          secretName: certs
+`
+	tests := []struct {
+		desc  string
+		path  string
+		value string
+		want  string
+	}{
+		{
+			desc: "MergeByKey",
+			path: `spec.template.spec.containers.[name:citadel].env`,
+			value: `
+      - name: B
+        value: b2
+      - name: C
+        value: c`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: citadel
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: b2
+        - name: C
+          value: c
+      volumes:
+      - name: certs
+        secret:
+          secretName: certs
+`,
+		},
+		{
+			desc: "DeleteByKey",
+			path: `spec.template.spec.containers.[name:citadel].env`,
+			value: `
+      - name: A
+        $patch: delete`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: citadel
+        env:
+        - name: B
+          value: b
+      volumes:
+      - name: certs
+        secret:
+          secretName: certs
+`,
+		},
+		{
+			desc: "MergeNested",
+			path: `spec.template.spec.containers`,
+			value: `
+      - name: citadel
+        env:
+        - name: A
+          value: a2`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: citadel
+        env:
+        - name: A
+          value: a2
+        - name: B
+          value: b
+      volumes:
+      - name: certs
+        secret:
+          secretName: certs
+`,
+		},
+		{
+			desc: "Replace",
+			path: `spec.template.spec.volumes`,
+			value: `
+      - $patch: replace
+      - name: config
+        emptyDir: {}`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: citadel
+        env:
+        - name: A
+          value: a
+        - name: B
+          value: b
+      volumes:
+      - name: config
+        emptyDir: {}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rc := &v1alpha1.KubernetesResourcesSpec{}
+			if err := util.UnmarshalWithJSONPB(makeOverlayHeader(tt.path, tt.value), rc, false); err != nil {
+				t.Fatalf("unmarshalWithJSONPB(%s): got error %s", tt.desc, err)
+			}
+			got, err := YAMLManifestPatch(base, "istio-system", rc.Overlays)
+			if err != nil {
+				t.Fatalf("YAMLManifestPatch(%s): got error %s", tt.desc, err)
+			}
+			if want := tt.want; !util.IsYAMLEqual(got, want) {
+				t.Errorf("YAMLManifestPatch(%s): got:\n%s\n\nwant:\n%s\nDiff:\n%s\n", tt.desc, got, want, util.YAMLDiff(got, want))
+			}
+		})
+	}
+}
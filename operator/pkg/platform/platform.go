@@ -0,0 +1,268 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package platform detects the Kubernetes platform of a cluster and provides the platform specific adjustments to
+// Istio installs on GKE, EKS, AKS and OpenShift.
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/podsecurity"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// GKE is Google Kubernetes Engine.
+	GKE = "gke"
+	// EKS is Amazon Elastic Kubernetes Service.
+	EKS = "eks"
+	// AKS is Azure Kubernetes Service.
+	AKS = "aks"
+	// OpenShift is Red Hat OpenShift.
+	OpenShift = "openshift"
+
+	// ValuesPath is the values path of the platform.
+	ValuesPath = "global.platform"
+
+	// aksEnforcerAnnotation opts webhook configurations out of the AKS admissions enforcer.
+	aksEnforcerAnnotation = "admissions.enforcer/disabled"
+	// openshiftNodeLabel is set on all OpenShift nodes, including those running on the clouds in Platforms.
+	openshiftNodeLabel = "node.openshift.io/os_id"
+	// webhookPort is the istiod port which the API server calls the injection and validation webhooks on.
+	webhookPort = 15017
+)
+
+var (
+	// Platforms are the supported platforms.
+	Platforms = map[string]bool{GKE: true, EKS: true, AKS: true, OpenShift: true}
+
+	// providerIDPrefixes map the node spec.providerID prefix of the cloud provider to the platform.
+	providerIDPrefixes = map[string]string{
+		"gce://":   GKE,
+		"aws://":   EKS,
+		"azure://": AKS,
+	}
+	// nodeLabels are node labels which are set on the nodes of the managed node pools of each platform.
+	nodeLabels = map[string]string{
+		"cloud.google.com/gke-nodepool": GKE,
+		"eks.amazonaws.com/nodegroup":   EKS,
+		"kubernetes.azure.com/cluster":  AKS,
+	}
+
+	// defaultValues are the values which are set for each platform, unless already set by the user.
+	defaultValues = map[string]map[string]interface{}{
+		EKS: {
+			// The AWS VPC CNI and other node agents in kube-system must not be redirected by the Istio CNI plugin.
+			"cni.excludeNamespaces": []interface{}{"istio-system", "kube-system"},
+			// The instance metadata service provides IAM credentials and is reached directly by the SDKs.
+			"global.proxy.excludeIPRanges": "169.254.169.254/32",
+		},
+		OpenShift: {
+			// The Istio CNI plugin runs as a Multus delegate rather than chained to the default OpenShift SDN plugin,
+			// so it is installed into the Multus directories.
+			"cni.cniBinDir":         "/var/lib/cni/bin",
+			"cni.cniConfDir":        "/etc/cni/multus/net.d",
+			"cni.cniConfFileName":   "istio-cni.conf",
+			"cni.chained":           false,
+			"cni.excludeNamespaces": []interface{}{"istio-system", "kube-system"},
+		},
+	}
+)
+
+// Settings returns the platform in values.global.platform of the given values tree, or "" if it is not set.
+func Settings(values map[string]interface{}) string {
+	v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(ValuesPath))
+	if !found {
+		return ""
+	}
+	p, _ := v.(string)
+	return p
+}
+
+// Detect returns the platform of the cluster from the provider ID and labels of its nodes, or "" if the platform is
+// not one of Platforms.
+func Detect(cs kubernetes.Interface) (string, error) {
+	nodes, err := cs.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("could not list nodes to detect the platform: %s", err)
+	}
+	for i := range nodes.Items {
+		if p := nodePlatform(&nodes.Items[i]); p != "" {
+			return p, nil
+		}
+	}
+	return "", nil
+}
+
+func nodePlatform(n *corev1.Node) string {
+	if _, ok := n.Labels[openshiftNodeLabel]; ok {
+		return OpenShift
+	}
+	for prefix, p := range providerIDPrefixes {
+		if strings.HasPrefix(n.Spec.ProviderID, prefix) {
+			return p
+		}
+	}
+	for label, p := range nodeLabels {
+		if _, ok := n.Labels[label]; ok {
+			return p
+		}
+	}
+	return ""
+}
+
+// DefaultValues returns the values paths and values which are set for platform if they are not set already.
+func DefaultValues(platform string) map[string]interface{} {
+	return defaultValues[platform]
+}
+
+// Options are options for Apply.
+type Options struct {
+	// Platform is the platform the manifest is rendered for.
+	Platform string
+	// IngressGateway is set for manifests of ingress gateways, which are exposed with Routes on OpenShift.
+	IngressGateway bool
+	// CNI is set for the manifest of the CNI node agent, which is granted the privileged SCC on OpenShift.
+	CNI bool
+	// SCCRoleName is the name of the Role and RoleBinding which grant the privileged SCC.
+	SCCRoleName string
+}
+
+// Apply returns manifest with the platform specific changes to rendered objects. On AKS, webhook configurations are
+// opted out of the admissions enforcer, which otherwise adds a namespaceSelector to them that the operator then
+// keeps reverting. On OpenShift, ingress gateway Services are exposed with Routes and the CNI service account is
+// granted the privileged SCC.
+func Apply(manifest string, opts *Options) (string, error) {
+	if opts.Platform != AKS && opts.Platform != OpenShift {
+		return manifest, nil
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	var out object.K8sObjects
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		switch {
+		case opts.Platform == AKS && (o.Kind == "MutatingWebhookConfiguration" || o.Kind == "ValidatingWebhookConfiguration"):
+			annotations := u.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[aksEnforcerAnnotation] = "true"
+			u.SetAnnotations(annotations)
+		case opts.Platform == OpenShift && opts.IngressGateway && o.Kind == "Service":
+			out = append(out, gatewayRoutes(u)...)
+		case opts.Platform == OpenShift && opts.CNI && o.Kind == "DaemonSet":
+			sa, _, _ := unstructured.NestedString(u.Object, "spec", "template", "spec", "serviceAccountName")
+			if sa == "" {
+				sa = "default"
+			}
+			out = append(out, podsecurity.SCCRBAC(opts.SCCRoleName, u.GetNamespace(), podsecurity.PrivilegedSCC, []string{sa})...)
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// gatewayRoutes returns OpenShift Routes for the HTTP and HTTPS ports of the gateway Service svc. HTTPS is passed
+// through to the gateway, which terminates TLS itself.
+func gatewayRoutes(svc *unstructured.Unstructured) object.K8sObjects {
+	ports, _, _ := unstructured.NestedSlice(svc.Object, "spec", "ports")
+	var out object.K8sObjects
+	for _, p := range ports {
+		pm, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		portName, _ := pm["name"].(string)
+		var routeName string
+		var tls map[string]interface{}
+		switch {
+		case portName == "http2" || portName == "http":
+			routeName = svc.GetName()
+		case portName == "https":
+			routeName = svc.GetName() + "-https"
+			tls = map[string]interface{}{"termination": "passthrough"}
+		default:
+			continue
+		}
+		spec := map[string]interface{}{
+			"to": map[string]interface{}{
+				"kind":   "Service",
+				"name":   svc.GetName(),
+				"weight": int64(100),
+			},
+			"port":           map[string]interface{}{"targetPort": portName},
+			"wildcardPolicy": "None",
+		}
+		if tls != nil {
+			spec["tls"] = tls
+		}
+		route := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "route.openshift.io/v1",
+			"kind":       "Route",
+			"metadata": map[string]interface{}{
+				"name":      routeName,
+				"namespace": svc.GetNamespace(),
+			},
+			"spec": spec,
+		}}
+		route.SetLabels(svc.GetLabels())
+		out = append(out, object.NewK8sObject(route, nil, nil))
+	}
+	return out
+}
+
+// Check returns warnings about cluster configuration which the install depends on and which must be fixed outside
+// of Istio on platform. On GKE, private clusters block the API server from calling the istiod webhooks unless a
+// firewall rule allows it.
+func Check(cs kubernetes.Interface, platform string) ([]string, error) {
+	if platform != GKE {
+		return nil, nil
+	}
+	nodes, err := cs.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list nodes to check the platform: %s", err)
+	}
+	if len(nodes.Items) == 0 {
+		return nil, nil
+	}
+	for _, n := range nodes.Items {
+		for _, a := range n.Status.Addresses {
+			if a.Type == corev1.NodeExternalIP {
+				return nil, nil
+			}
+		}
+	}
+	return []string{fmt.Sprintf("The cluster nodes have no external IPs, which indicates a private GKE cluster. The "+
+		"control plane can only call the sidecar injector and validation webhooks if a firewall rule allows ingress "+
+		"from the control plane CIDR to the nodes on TCP port %d, e.g.\n"+
+		"  gcloud compute firewall-rules create istio-webhooks --network <network> --source-ranges <master CIDR> "+
+		"--target-tags <node tag> --allow tcp:%d", webhookPort, webhookPort)}, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		desc string
+		node *corev1.Node
+		want string
+	}{
+		{
+			desc: "gke provider ID",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n"}, Spec: corev1.NodeSpec{ProviderID: "gce://project/zone/n"}},
+			want: GKE,
+		},
+		{
+			desc: "eks label",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Labels: map[string]string{"eks.amazonaws.com/nodegroup": "ng"}}},
+			want: EKS,
+		},
+		{
+			desc: "unknown",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n"}, Spec: corev1.NodeSpec{ProviderID: "kind://docker/kind/n"}},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Detect(fake.NewSimpleClientset(tt.node))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	manifest := `
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: istio-sidecar-injector
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+`
+	got, err := Apply(manifest, &Options{Platform: AKS})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := objs[0].UnstructuredObject().GetAnnotations()[aksEnforcerAnnotation]; a != "true" {
+		t.Errorf("%s: got annotation %q, want true", objs[0].Hash(), a)
+	}
+	if a := objs[1].UnstructuredObject().GetAnnotations(); len(a) != 0 {
+		t.Errorf("%s: got annotations %v, want none", objs[1].Hash(), a)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	private := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "private"}, Status: corev1.NodeStatus{
+		Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}}}}
+	public := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "public"}, Status: corev1.NodeStatus{
+		Addresses: []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "203.0.113.2"}}}}
+
+	if warnings, err := Check(fake.NewSimpleClientset(private), GKE); err != nil || len(warnings) != 1 {
+		t.Errorf("private cluster: got warnings %v, err %v, want one warning", warnings, err)
+	}
+	if warnings, err := Check(fake.NewSimpleClientset(public), GKE); err != nil || len(warnings) != 0 {
+		t.Errorf("public cluster: got warnings %v, err %v, want none", warnings, err)
+	}
+}
+
+func TestApplyOpenShift(t *testing.T) {
+	gateway := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  labels:
+    istio: ingressgateway
+spec:
+  ports:
+  - name: status-port
+    port: 15020
+  - name: http2
+    port: 80
+  - name: https
+    port: 443
+`
+	got, err := Apply(gateway, &Options{Platform: OpenShift, IngressGateway: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var routes []string
+	for _, o := range objs {
+		if o.Kind == "Route" {
+			routes = append(routes, o.Name)
+		}
+	}
+	if want := []string{"istio-ingressgateway", "istio-ingressgateway-https"}; !reflect.DeepEqual(routes, want) {
+		t.Errorf("got Routes %v, want %v", routes, want)
+	}
+
+	cni := `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: istio-cni-node
+  namespace: kube-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-cni
+`
+	got, err = Apply(cni, &Options{Platform: OpenShift, CNI: true, SCCRoleName: "istio-cni-scc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objs, err = object.ParseK8sObjectsFromYAMLManifest(got); err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, o := range objs {
+		kinds = append(kinds, o.Kind)
+	}
+	if want := []string{"Role", "RoleBinding", "DaemonSet"}; !reflect.DeepEqual(kinds, want) {
+		t.Errorf("got kinds %v, want %v", kinds, want)
+	}
+}
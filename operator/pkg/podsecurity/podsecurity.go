@@ -0,0 +1,262 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podsecurity renders K8s objects to comply with restricted pod security policies, i.e. the restricted
+// PodSecurity profile, restrictive PSPs and the OpenShift restricted SCC.
+package podsecurity
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// SeccompPodAnnotation sets the seccomp profile of all containers in a pod.
+	SeccompPodAnnotation = "seccomp.security.alpha.kubernetes.io/pod"
+	// SeccompRuntimeDefault is the container runtime default seccomp profile.
+	SeccompRuntimeDefault = "runtime/default"
+	// RestrictedSCC is the name of the OpenShift SCC which the service accounts of components are granted.
+	RestrictedSCC = "restricted"
+	// PrivilegedSCC is the name of the OpenShift SCC for node agents which must run privileged.
+	PrivilegedSCC = "privileged"
+
+	restrictedValuesPath = "global.podSecurity.restricted"
+	openshiftValuesPath  = "global.podSecurity.openshift"
+)
+
+var (
+	// allowedCapabilities are the only capabilities containers may add under the restricted profile.
+	allowedCapabilities = map[string]bool{"NET_BIND_SERVICE": true}
+	// uidFields are the securityContext fields which are assigned by the SCC on OpenShift.
+	uidFields = []string{"runAsUser", "runAsGroup", "fsGroup"}
+)
+
+// Options are options for Restrict.
+type Options struct {
+	// OpenShift removes fixed user and group IDs and generates a Role and RoleBinding which grant the restricted SCC
+	// to the service accounts used by the objects.
+	OpenShift bool
+	// SCCRoleName is the name of the generated Role and RoleBinding.
+	SCCRoleName string
+	// Namespace is the namespace of the generated Role and RoleBinding.
+	Namespace string
+}
+
+// Settings returns whether restricted pod security and OpenShift SCC rendering are enabled in the given values
+// tree, under values.global.podSecurity.
+func Settings(values map[string]interface{}) (restricted, openshift bool) {
+	return boolValue(values, restrictedValuesPath), boolValue(values, openshiftValuesPath)
+}
+
+func boolValue(values map[string]interface{}, path string) bool {
+	v, found, err := tpath.GetFromTreePath(values, util.PathFromString(path))
+	if err != nil || !found {
+		return false
+	}
+	b, ok := v.(bool)
+	return ok && b
+}
+
+// Restrict returns manifest with the pod templates of all objects changed to run as non root with the runtime
+// default seccomp profile, no privilege escalation and all capabilities dropped. It returns an error for any
+// container which needs to be privileged, run as root or add capabilities other than NET_BIND_SERVICE, since no
+// setting can make these compliant.
+func Restrict(manifest string, opts *Options) (string, error) {
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	var out object.K8sObjects
+	serviceAccounts := make(map[string]bool)
+	var serviceAccountNames []string
+	for _, o := range objs {
+		u := o.UnstructuredObject().DeepCopy()
+		if podSpecPath := podTemplatePath(u.GetKind()); podSpecPath != nil {
+			pod, found, err := unstructured.NestedMap(u.Object, podSpecPath...)
+			if err != nil {
+				return "", fmt.Errorf("%s: %s", o.Hash(), err)
+			}
+			if found {
+				if err := restrictPodTemplate(pod, opts.OpenShift); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				if err := unstructured.SetNestedMap(u.Object, pod, podSpecPath...); err != nil {
+					return "", fmt.Errorf("%s: %s", o.Hash(), err)
+				}
+				sa, _, _ := unstructured.NestedString(pod, "spec", "serviceAccountName")
+				if sa == "" {
+					sa = "default"
+				}
+				if !serviceAccounts[sa] {
+					serviceAccounts[sa] = true
+					serviceAccountNames = append(serviceAccountNames, sa)
+				}
+			}
+		}
+		out = append(out, object.NewK8sObject(u, nil, nil))
+	}
+	if opts.OpenShift && len(serviceAccountNames) != 0 {
+		out = append(out, SCCRBAC(opts.SCCRoleName, opts.Namespace, RestrictedSCC, serviceAccountNames)...)
+	}
+	ym, err := out.YAMLManifest()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ym, object.YAMLSeparator), nil
+}
+
+// podTemplatePath returns the path to the pod template of objects of the given kind, or nil if kind has none.
+func podTemplatePath(kind string) []string {
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "Job":
+		return []string{"spec", "template"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template"}
+	}
+	return nil
+}
+
+func restrictPodTemplate(pod map[string]interface{}, openshift bool) error {
+	if err := unstructured.SetNestedField(pod, SeccompRuntimeDefault, "metadata", "annotations", SeccompPodAnnotation); err != nil {
+		return err
+	}
+	psc, _, err := unstructured.NestedMap(pod, "spec", "securityContext")
+	if err != nil {
+		return err
+	}
+	if psc == nil {
+		psc = make(map[string]interface{})
+	}
+	if uid, ok := psc["runAsUser"].(int64); ok && uid == 0 {
+		return fmt.Errorf("pod runs as root")
+	}
+	psc["runAsNonRoot"] = true
+	if openshift {
+		for _, f := range uidFields {
+			delete(psc, f)
+		}
+	}
+	if err := unstructured.SetNestedMap(pod, psc, "spec", "securityContext"); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, err := unstructured.NestedSlice(pod, "spec", field)
+		if err != nil {
+			return err
+		}
+		for _, c := range containers {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if err := restrictContainer(cm, openshift); err != nil {
+				return fmt.Errorf("container %v: %s", cm["name"], err)
+			}
+		}
+		if len(containers) != 0 {
+			if err := unstructured.SetNestedSlice(pod, containers, "spec", field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func restrictContainer(container map[string]interface{}, openshift bool) error {
+	sc, _, err := unstructured.NestedMap(container, "securityContext")
+	if err != nil {
+		return err
+	}
+	if sc == nil {
+		sc = make(map[string]interface{})
+	}
+	if privileged, ok := sc["privileged"].(bool); ok && privileged {
+		return fmt.Errorf("privileged containers are not allowed")
+	}
+	if uid, ok := sc["runAsUser"].(int64); ok && uid == 0 {
+		return fmt.Errorf("containers may not run as root")
+	}
+	added, _, err := unstructured.NestedStringSlice(sc, "capabilities", "add")
+	if err != nil {
+		return err
+	}
+	for _, c := range added {
+		if !allowedCapabilities[c] {
+			return fmt.Errorf("capability %s is not allowed", c)
+		}
+	}
+	sc["privileged"] = false
+	sc["allowPrivilegeEscalation"] = false
+	sc["runAsNonRoot"] = true
+	if openshift {
+		for _, f := range uidFields {
+			delete(sc, f)
+		}
+	}
+	if err := unstructured.SetNestedStringSlice(sc, []string{"ALL"}, "capabilities", "drop"); err != nil {
+		return err
+	}
+	container["securityContext"] = sc
+	return nil
+}
+
+// SCCRBAC returns a Role granting use of the OpenShift SCC scc and a RoleBinding of it to serviceAccounts.
+func SCCRBAC(roleName, namespace, scc string, serviceAccounts []string) object.K8sObjects {
+	role := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "Role",
+		"metadata": map[string]interface{}{
+			"name":      roleName,
+			"namespace": namespace,
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"apiGroups":     []interface{}{"security.openshift.io"},
+				"resources":     []interface{}{"securitycontextconstraints"},
+				"resourceNames": []interface{}{scc},
+				"verbs":         []interface{}{"use"},
+			},
+		},
+	}}
+	var subjects []interface{}
+	for _, sa := range serviceAccounts {
+		subjects = append(subjects, map[string]interface{}{
+			"kind":      "ServiceAccount",
+			"name":      sa,
+			"namespace": namespace,
+		})
+	}
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "RoleBinding",
+		"metadata": map[string]interface{}{
+			"name":      roleName,
+			"namespace": namespace,
+		},
+		"roleRef": map[string]interface{}{
+			"apiGroup": "rbac.authorization.k8s.io",
+			"kind":     "Role",
+			"name":     roleName,
+		},
+		"subjects": subjects,
+	}}
+	return object.K8sObjects{object.NewK8sObject(role, nil, nil), object.NewK8sObject(binding, nil, nil)}
+}
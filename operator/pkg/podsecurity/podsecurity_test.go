@@ -0,0 +1,138 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const testDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istiod-service-account
+      securityContext:
+        fsGroup: 1337
+      containers:
+      - name: discovery
+        image: docker.io/istio/pilot:1.6.0
+        securityContext:
+          runAsUser: 1337
+          capabilities:
+            drop:
+            - NET_RAW
+`
+
+func TestRestrict(t *testing.T) {
+	tests := []struct {
+		desc      string
+		openshift bool
+		want      map[string]interface{}
+		wantKinds []string
+	}{
+		{
+			desc: "restricted",
+			want: map[string]interface{}{
+				"spec.template.metadata.annotations.seccomp\\.security\\.alpha\\.kubernetes\\.io/pod": SeccompRuntimeDefault,
+				"spec.template.spec.securityContext.runAsNonRoot":                                     true,
+				"spec.template.spec.securityContext.fsGroup":                                          int64(1337),
+				"spec.template.spec.containers.0.securityContext.runAsUser":                           int64(1337),
+				"spec.template.spec.containers.0.securityContext.allowPrivilegeEscalation":            false,
+				"spec.template.spec.containers.0.securityContext.capabilities.drop":                   []interface{}{"ALL"},
+			},
+			wantKinds: []string{"Deployment"},
+		},
+		{
+			desc:      "openshift",
+			openshift: true,
+			want: map[string]interface{}{
+				"spec.template.spec.securityContext.fsGroup":                nil,
+				"spec.template.spec.containers.0.securityContext.runAsUser": nil,
+			},
+			wantKinds: []string{"Deployment", "Role", "RoleBinding"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Restrict(testDeployment, &Options{OpenShift: tt.openshift, SCCRoleName: "istiod-scc", Namespace: "istio-system"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var kinds []string
+			for _, o := range objs {
+				kinds = append(kinds, o.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tt.wantKinds) {
+				t.Errorf("got kinds %v, want %v", kinds, tt.wantKinds)
+			}
+			for path, want := range tt.want {
+				v, found, err := tpath.GetFromTreePath(objs[0].Unstructured(), util.PathFromString(path))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !found {
+					v = nil
+				}
+				if !reflect.DeepEqual(v, want) {
+					t.Errorf("%s: got %v, want %v", path, v, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRestrictErrors(t *testing.T) {
+	tests := []struct {
+		desc            string
+		securityContext string
+	}{
+		{desc: "privileged", securityContext: "privileged: true"},
+		{desc: "root", securityContext: "runAsUser: 0"},
+		{desc: "NET_ADMIN", securityContext: "capabilities: {add: [NET_ADMIN]}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: test
+spec:
+  template:
+    spec:
+      containers:
+      - name: test
+        securityContext: {` + tt.securityContext + `}
+`
+			if _, err := Restrict(m, &Options{}); err == nil {
+				t.Error("got no error, want error")
+			}
+		})
+	}
+}
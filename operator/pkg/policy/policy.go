@@ -0,0 +1,190 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy evaluates rendered K8s objects against user supplied Rego policies.
+//
+// Policies are OPA Rego modules in the istio.install package which add a message to the deny set for every violation,
+// with the object under evaluation as input, e.g.
+//
+//   package istio.install
+//
+//   deny[msg] {
+//     input.kind == "Deployment"
+//     c := input.spec.template.spec.containers[_]
+//     c.securityContext.privileged
+//     msg := sprintf("container %s must not be privileged", [c.name])
+//   }
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// DenyQuery is the query evaluated for each object. It must return a set of violation messages.
+	DenyQuery = "data.istio.install.deny"
+	// ConfigMapSourcePrefix prefixes a policy source of the form configmap:<namespace>/<name>. Every key of the
+	// ConfigMap ending in .rego is a policy module.
+	ConfigMapSourcePrefix = "configmap:"
+
+	regoFileSuffix = ".rego"
+)
+
+// Checker checks K8s objects against a set of compiled policies.
+type Checker struct {
+	compiler *ast.Compiler
+}
+
+// NewChecker compiles the given policy modules, which map a module file name to its Rego source.
+func NewChecker(modules map[string]string) (*Checker, error) {
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no policies found")
+	}
+	parsed := make(map[string]*ast.Module)
+	for filename, src := range modules {
+		m, err := ast.ParseModule(filename, src)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse policy %s: %s", filename, err)
+		}
+		parsed[filename] = m
+	}
+	compiler := ast.NewCompiler()
+	if compiler.Compile(parsed); compiler.Failed() {
+		return nil, fmt.Errorf("could not compile policies: %s", compiler.Errors)
+	}
+	return &Checker{compiler: compiler}, nil
+}
+
+// ReadModules reads the policy modules at source, which is either a directory of .rego files or a ConfigMap in the
+// form configmap:<namespace>/<name>. cs is only used for ConfigMap sources.
+func ReadModules(source string, cs kubernetes.Interface) (map[string]string, error) {
+	if !strings.HasPrefix(source, ConfigMapSourcePrefix) {
+		return readModulesFromDir(source)
+	}
+	nn := strings.Split(strings.TrimPrefix(source, ConfigMapSourcePrefix), "/")
+	if len(nn) != 2 || nn[0] == "" || nn[1] == "" {
+		return nil, fmt.Errorf("policy source %s must have the form %s<namespace>/<name>", source, ConfigMapSourcePrefix)
+	}
+	if cs == nil {
+		return nil, fmt.Errorf("policy source %s needs a cluster", source)
+	}
+	cm, err := cs.CoreV1().ConfigMaps(nn[0]).Get(context.TODO(), nn[1], metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not read policy ConfigMap %s/%s: %s", nn[0], nn[1], err)
+	}
+	modules := make(map[string]string)
+	for k, v := range cm.Data {
+		if strings.HasSuffix(k, regoFileSuffix) {
+			modules[k] = v
+		}
+	}
+	return modules, nil
+}
+
+func readModulesFromDir(dir string) (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+regoFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	modules := make(map[string]string)
+	for _, p := range paths {
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		modules[p] = string(b)
+	}
+	return modules, nil
+}
+
+// CheckManifests evaluates every object in manifests and returns an error for each violation, identified by
+// component and object hash.
+func (c *Checker) CheckManifests(manifests name.ManifestMap) util.Errors {
+	var components []string
+	for cn := range manifests {
+		components = append(components, string(cn))
+	}
+	sort.Strings(components)
+
+	var errs util.Errors
+	for _, cn := range components {
+		for _, m := range manifests[name.ComponentName(cn)] {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: %s", cn, err))
+				continue
+			}
+			for _, o := range objs {
+				violations, err := c.Check(o)
+				if err != nil {
+					errs = util.AppendErr(errs, fmt.Errorf("%s %s: %s", cn, o.Hash(), err))
+				}
+				for _, v := range violations {
+					errs = util.AppendErr(errs, fmt.Errorf("%s %s: %s", cn, o.Hash(), v))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// Check evaluates DenyQuery for o and returns the sorted violation messages.
+func (c *Checker) Check(o *object.K8sObject) ([]string, error) {
+	j, err := o.JSON()
+	if err != nil {
+		return nil, err
+	}
+	var input interface{}
+	if err := json.Unmarshal(j, &input); err != nil {
+		return nil, err
+	}
+	rs, err := rego.New(
+		rego.Compiler(c.compiler),
+		rego.Query(DenyQuery),
+		rego.Input(input),
+	).Eval(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate policies: %s", err)
+	}
+
+	var out []string
+	for _, r := range rs {
+		for _, e := range r.Expressions {
+			msgs, ok := e.Value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s must be a set of messages, got %T", DenyQuery, e.Value)
+			}
+			for _, m := range msgs {
+				out = append(out, fmt.Sprint(m))
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+const testPolicy = `
+package istio.install
+
+deny[msg] {
+  input.kind == "Deployment"
+  c := input.spec.template.spec.containers[_]
+  c.securityContext.privileged
+  msg := sprintf("container %s must not be privileged", [c.name])
+}
+
+deny[msg] {
+  input.kind == "Deployment"
+  c := input.spec.template.spec.containers[_]
+  not startswith(c.image, "docker.io/istio/")
+  msg := sprintf("image %s is not from an allowed registry", [c.image])
+}
+`
+
+func TestCheckManifests(t *testing.T) {
+	c, err := NewChecker(map[string]string{"test.rego": testPolicy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc string
+		yaml string
+		want []string
+	}{
+		{
+			desc: "allowed",
+			yaml: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: docker.io/istio/pilot:1.6.0
+`,
+		},
+		{
+			desc: "violations",
+			yaml: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: example.com/pilot:1.6.0
+        securityContext:
+          privileged: true
+`,
+			want: []string{
+				"Pilot Deployment:istio-system:istiod: container discovery must not be privileged",
+				"Pilot Deployment:istio-system:istiod: image example.com/pilot:1.6.0 is not from an allowed registry",
+			},
+		},
+		{
+			desc: "other kind",
+			yaml: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, err := range c.CheckManifests(name.ManifestMap{name.PilotComponentName: {tt.yaml}}) {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCheckerErrors(t *testing.T) {
+	if _, err := NewChecker(nil); err == nil {
+		t.Error("NewChecker(nil): got no error, want error")
+	}
+	if _, err := NewChecker(map[string]string{"bad.rego": "package istio.install\ndeny[msg] {"}); err == nil {
+		t.Error("NewChecker(bad.rego): got no error, want error")
+	}
+}
+
+func TestReadModulesConfigMapSource(t *testing.T) {
+	for _, source := range []string{"configmap:", "configmap:istio-system", "configmap:/policies"} {
+		if _, err := ReadModules(source, nil); err == nil {
+			t.Errorf("ReadModules(%s): got no error, want error", source)
+		}
+	}
+}
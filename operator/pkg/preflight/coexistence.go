@@ -0,0 +1,143 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+const (
+	// istioInjectorWebhookSuffix is the suffix of the names of the sidecar injection webhooks of all Istio versions.
+	istioInjectorWebhookSuffix = "sidecar-injector.istio.io"
+	// operatorManagedLabel is set on all resources installed by the operator or istioctl.
+	operatorManagedLabel = name.OperatorAPINamespace + "/managed"
+	// helmReleaseNameAnnotation is set by Helm 3 on the objects of a release.
+	helmReleaseNameAnnotation = "meta.helm.sh/release-name"
+)
+
+// meshFingerprint identifies another service mesh by the API group of its CRDs and the name prefix of its injection
+// webhook configuration.
+type meshFingerprint struct {
+	mesh          string
+	group         string
+	webhookPrefix string
+	// initContainer is the init container which the mesh injects to redirect the pod traffic with iptables.
+	initContainer string
+}
+
+var otherMeshes = []meshFingerprint{
+	{mesh: "Linkerd", group: "linkerd.io", webhookPrefix: "linkerd-proxy-injector", initContainer: "linkerd-init"},
+	{mesh: "Consul Connect", group: "consul.hashicorp.com", webhookPrefix: "consul-connect-injector",
+		initContainer: "consul-connect-inject-init"},
+	{mesh: "Kuma", group: "kuma.io", webhookPrefix: "kuma-admission-mutating-webhook", initContainer: "kuma-init"},
+	{mesh: "AWS App Mesh", group: "appmesh.k8s.aws", webhookPrefix: "appmesh-inject", initContainer: "proxyinit"},
+	{mesh: "Open Service Mesh", group: "openservicemesh.io", webhookPrefix: "osm-webhook", initContainer: "osm-init"},
+}
+
+// DetectConflicts returns the other service meshes and the Istio Helm releases in the cluster at restConfig, which
+// conflict with the control plane about to be installed, with the reason and how to resolve each conflict.
+func DetectConflicts(restConfig *rest.Config) ([]string, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	groupList, err := dc.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("could not discover API groups: %s", err)
+	}
+	var groups []string
+	for _, g := range groupList.Groups {
+		groups = append(groups, g.Name)
+	}
+	cl, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	webhooks := &admissionv1beta1.MutatingWebhookConfigurationList{}
+	if err := cl.List(context.TODO(), webhooks); err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %s", err)
+	}
+	return meshConflicts(groups, webhooks.Items), nil
+}
+
+// meshConflicts returns the conflicts with the meshes fingerprinted by the given API groups and mutating webhook
+// configurations. Pods selected by the injectors of two meshes get two init containers which both own the iptables
+// rules redirecting the pod traffic, and two Istio injectors inject two sidecars.
+func meshConflicts(groups []string, webhooks []admissionv1beta1.MutatingWebhookConfiguration) []string {
+	installed := make(map[string]bool)
+	for _, g := range groups {
+		installed[g] = true
+	}
+	var out []string
+	for _, m := range otherMeshes {
+		var evidence []string
+		if installed[m.group] {
+			evidence = append(evidence, "API group "+m.group)
+		}
+		for _, wh := range webhooks {
+			if strings.HasPrefix(wh.Name, m.webhookPrefix) {
+				evidence = append(evidence, "MutatingWebhookConfiguration "+wh.Name)
+			}
+		}
+		if len(evidence) == 0 {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s is installed (%s): pods injected by both meshes get the %s and istio-init "+
+			"init containers, which both redirect the pod traffic with iptables. Exclude the namespaces of each mesh from "+
+			"the injection of the other, or uninstall %s", m.mesh, strings.Join(evidence, ", "), m.initContainer, m.mesh))
+	}
+	for _, wh := range webhooks {
+		if release := helmRelease(&wh); release != "" {
+			out = append(out, fmt.Sprintf("Istio Helm release %s is installed (MutatingWebhookConfiguration %s): its "+
+				"injector also adds istio-init and the sidecar to the pods selected by the new control plane. Adopt the "+
+				"release with --adopt-helm-release, or uninstall it", release, wh.Name))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// helmRelease returns the name of the Helm release which installed the Istio injection webhook configuration wh, or
+// an empty string if wh is not an Istio injector or was installed by the operator or istioctl.
+func helmRelease(wh *admissionv1beta1.MutatingWebhookConfiguration) string {
+	if _, ok := wh.Labels[operatorManagedLabel]; ok {
+		return ""
+	}
+	istio := false
+	for _, w := range wh.Webhooks {
+		istio = istio || strings.HasSuffix(w.Name, istioInjectorWebhookSuffix)
+	}
+	if !istio {
+		return ""
+	}
+	if release := wh.Annotations[helmReleaseNameAnnotation]; release != "" {
+		return release
+	}
+	// Helm 2 charts label their objects with the release and the heritage Tiller.
+	if heritage := wh.Labels["heritage"]; heritage == "Tiller" || heritage == "Helm" {
+		return wh.Labels["release"]
+	}
+	return ""
+}
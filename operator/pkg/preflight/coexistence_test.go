@@ -0,0 +1,76 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"reflect"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMeshConflicts(t *testing.T) {
+	injector := func(name string, labels, annotations map[string]string) admissionv1beta1.MutatingWebhookConfiguration {
+		return admissionv1beta1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			Webhooks:   []admissionv1beta1.MutatingWebhook{{Name: "sidecar-injector.istio.io"}},
+		}
+	}
+	linkerd := "Linkerd is installed (API group linkerd.io, MutatingWebhookConfiguration linkerd-proxy-injector-webhook-config): " +
+		"pods injected by both meshes get the linkerd-init and istio-init init containers, which both redirect the pod " +
+		"traffic with iptables. Exclude the namespaces of each mesh from the injection of the other, or uninstall Linkerd"
+	helmRelease := func(release, webhook string) string {
+		return "Istio Helm release " + release + " is installed (MutatingWebhookConfiguration " + webhook + "): its " +
+			"injector also adds istio-init and the sidecar to the pods selected by the new control plane. Adopt the " +
+			"release with --adopt-helm-release, or uninstall it"
+	}
+	tests := []struct {
+		desc     string
+		groups   []string
+		webhooks []admissionv1beta1.MutatingWebhookConfiguration
+		want     []string
+	}{
+		{
+			desc:     "istio only",
+			groups:   []string{"networking.istio.io", "apps"},
+			webhooks: []admissionv1beta1.MutatingWebhookConfiguration{injector("istio-sidecar-injector", map[string]string{operatorManagedLabel: "Reconcile"}, nil)},
+		},
+		{
+			desc:   "linkerd",
+			groups: []string{"linkerd.io"},
+			webhooks: []admissionv1beta1.MutatingWebhookConfiguration{{
+				ObjectMeta: metav1.ObjectMeta{Name: "linkerd-proxy-injector-webhook-config"},
+			}},
+			want: []string{linkerd},
+		},
+		{
+			desc: "helm releases",
+			webhooks: []admissionv1beta1.MutatingWebhookConfiguration{
+				injector("istio-sidecar-injector", map[string]string{"heritage": "Tiller", "release": "istio"}, nil),
+				injector("istio-sidecar-injector-1-6", nil, map[string]string{helmReleaseNameAnnotation: "istiod-1-6"}),
+				injector("istio-sidecar-injector-manual", nil, nil),
+			},
+			want: []string{helmRelease("istio", "istio-sidecar-injector"), helmRelease("istiod-1-6", "istio-sidecar-injector-1-6")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := meshConflicts(tt.groups, tt.webhooks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
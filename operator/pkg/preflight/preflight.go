@@ -0,0 +1,184 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preflight checks the existing mesh config in a cluster before the control plane is installed or upgraded,
+// using the Istio config analyzers and the API kinds known to this version, and detects other meshes and Istio Helm
+// releases which conflict with the control plane.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/galley/pkg/config/analysis/local"
+	cfgKube "istio.io/istio/galley/pkg/config/source/kube"
+	"istio.io/istio/operator/pkg/backup"
+	"istio.io/istio/pkg/config/resource"
+	configschema "istio.io/istio/pkg/config/schema"
+)
+
+const (
+	// AnalyzerErrors is the fail-on condition which fails an upgrade or apply if the preflight analysis finds errors.
+	AnalyzerErrors = "analyzer-errors"
+	// MeshConflicts is the fail-on condition which fails an upgrade or apply if another mesh or an Istio Helm release
+	// is installed in the cluster.
+	MeshConflicts = "mesh-conflicts"
+
+	// analysisTimeout is how long the analyzers may take to read the config from the cluster.
+	analysisTimeout = 30 * time.Second
+)
+
+var (
+	// FailOnConditions are the valid fail-on conditions.
+	FailOnConditions = []string{AnalyzerErrors, MeshConflicts}
+
+	// Analyzers returns the config analyzers which Analyze runs. It is set by the binaries which include the
+	// preflight, to analyzers.AllCombined, since the analyzers import the injector, whose tests import the operator
+	// commands. The config analysis is skipped if it is nil.
+	Analyzers func() *analysis.CombinedAnalyzer
+)
+
+// Result holds the findings of a preflight analysis.
+type Result struct {
+	// Messages are the messages of the config analyzers.
+	Messages diag.Messages
+	// RemovedAPIs are the existing resources of istio.io kinds which this version no longer supports.
+	RemovedAPIs []string
+	// MeshConflicts are the other meshes and Istio Helm releases in the cluster, with how to resolve each conflict.
+	MeshConflicts []string
+}
+
+// HasErrors reports whether the analyzers found errors or there are resources of kinds which are no longer supported.
+func (r *Result) HasErrors() bool {
+	if len(r.RemovedAPIs) != 0 {
+		return true
+	}
+	for _, m := range r.Messages {
+		if m.Type.Level() == diag.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Fails reports whether the result fails any of the given fail-on conditions.
+func (r *Result) Fails(failOn []string) bool {
+	for _, c := range failOn {
+		if c == AnalyzerErrors && r.HasErrors() || c == MeshConflicts && len(r.MeshConflicts) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns one line per finding, or an empty string if there are none.
+func (r *Result) String() string {
+	var out []string
+	for _, m := range r.Messages {
+		out = append(out, m.String())
+	}
+	for _, a := range r.RemovedAPIs {
+		out = append(out, fmt.Sprintf("%s [RemovedAPI] %s is of a kind which is not supported by this version of Istio", diag.Error, a))
+	}
+	for _, c := range r.MeshConflicts {
+		out = append(out, fmt.Sprintf("%s [MeshConflict] %s", diag.Error, c))
+	}
+	return strings.Join(out, "\n")
+}
+
+// ValidateFailOn returns an error if any of the given fail-on conditions is not one of FailOnConditions.
+func ValidateFailOn(conditions []string) error {
+	for _, c := range conditions {
+		if c != AnalyzerErrors && c != MeshConflicts {
+			return fmt.Errorf("unknown --fail-on condition %q, must be one of %s", c, strings.Join(FailOnConditions, ", "))
+		}
+	}
+	return nil
+}
+
+// Analyze runs the config analyzers of this version against the mesh config in the cluster at restConfig, with the
+// mesh config read from istioNamespace, and lists the resources of istio.io kinds which this version does not know,
+// since these break once the control plane is upgraded. It also detects the other meshes and Istio Helm releases in
+// the cluster.
+func Analyze(restConfig *rest.Config, istioNamespace string) (*Result, error) {
+	result := &Result{}
+	if Analyzers != nil {
+		sa := local.NewSourceAnalyzer(configschema.MustGet(), Analyzers(),
+			resource.Namespace(""), resource.Namespace(istioNamespace), nil, true, analysisTimeout)
+		sa.AddRunningKubeSource(cfgKube.NewInterfaces(restConfig))
+		ar, err := sa.Analyze(make(chan struct{}))
+		if err != nil {
+			return nil, fmt.Errorf("config analysis failed: %s", err)
+		}
+		result.Messages = ar.Messages
+	}
+
+	gvks, err := backup.IstioConfigKinds(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	for _, gvk := range unknownKinds(gvks) {
+		ul := &unstructured.UnstructuredList{}
+		ul.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := cl.List(context.TODO(), ul); err != nil {
+			return nil, fmt.Errorf("failed to list %s: %s", gvk.Kind, err)
+		}
+		for _, u := range ul.Items {
+			result.RemovedAPIs = append(result.RemovedAPIs, fmt.Sprintf("%s %s", gvk.Kind, resourceName(&u)))
+		}
+	}
+	sort.Strings(result.RemovedAPIs)
+	if result.MeshConflicts, err = DetectConflicts(restConfig); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// unknownKinds returns the kinds in gvks whose group and kind are not in the config schemas of this version. The
+// install.istio.io group is not part of the config schemas and is always known.
+func unknownKinds(gvks []schema.GroupVersionKind) []schema.GroupVersionKind {
+	known := make(map[schema.GroupKind]bool)
+	for _, s := range configschema.MustGet().KubeCollections().All() {
+		known[schema.GroupKind{Group: s.Resource().Group(), Kind: s.Resource().Kind()}] = true
+	}
+	var out []schema.GroupVersionKind
+	for _, gvk := range gvks {
+		if gvk.Group == "install.istio.io" || known[gvk.GroupKind()] {
+			continue
+		}
+		out = append(out, gvk)
+	}
+	return out
+}
+
+func resourceName(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return u.GetName()
+	}
+	return u.GetName() + "." + u.GetNamespace()
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+)
+
+func TestResult(t *testing.T) {
+	warning := diag.NewMessage(diag.NewMessageType(diag.Warning, "IST9998", "deprecated field %s"), nil, "foo")
+	failure := diag.NewMessage(diag.NewMessageType(diag.Error, "IST9999", "broken %s"), nil, "bar")
+	tests := []struct {
+		desc       string
+		result     *Result
+		wantErrors bool
+		wantString string
+	}{
+		{
+			desc:   "empty",
+			result: &Result{},
+		},
+		{
+			desc:       "warnings",
+			result:     &Result{Messages: diag.Messages{warning}},
+			wantString: "Warn [IST9998] deprecated field foo",
+		},
+		{
+			desc:       "analyzer errors",
+			result:     &Result{Messages: diag.Messages{warning, failure}},
+			wantErrors: true,
+			wantString: "Warn [IST9998] deprecated field foo\nError [IST9999] broken bar",
+		},
+		{
+			desc:       "removed APIs",
+			result:     &Result{RemovedAPIs: []string{"RbacConfig default"}},
+			wantErrors: true,
+			wantString: "Error [RemovedAPI] RbacConfig default is of a kind which is not supported by this version of Istio",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := tt.result.HasErrors(); got != tt.wantErrors {
+				t.Errorf("HasErrors: got %v, want %v", got, tt.wantErrors)
+			}
+			if got := tt.result.Fails([]string{AnalyzerErrors}); got != tt.wantErrors {
+				t.Errorf("Fails: got %v, want %v", got, tt.wantErrors)
+			}
+			if tt.result.Fails(nil) {
+				t.Error("Fails without conditions: got true, want false")
+			}
+			if got := tt.result.String(); got != tt.wantString {
+				t.Errorf("String: got %q, want %q", got, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestMeshConflictsResult(t *testing.T) {
+	r := &Result{MeshConflicts: []string{"Linkerd is installed"}}
+	if r.HasErrors() || r.Fails([]string{AnalyzerErrors}) {
+		t.Error("got analyzer errors for a mesh conflict")
+	}
+	if !r.Fails([]string{AnalyzerErrors, MeshConflicts}) {
+		t.Errorf("Fails: got false, want true for %s", MeshConflicts)
+	}
+	if got, want := r.String(), "Error [MeshConflict] Linkerd is installed"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+}
+
+func TestValidateFailOn(t *testing.T) {
+	if err := ValidateFailOn([]string{AnalyzerErrors, MeshConflicts}); err != nil {
+		t.Errorf("got %v, want no error", err)
+	}
+	if err := ValidateFailOn([]string{"warnings"}); err == nil {
+		t.Error("got no error for an unknown condition")
+	}
+}
+
+func TestUnknownKinds(t *testing.T) {
+	gvks := []schema.GroupVersionKind{
+		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "VirtualService"},
+		{Group: "install.istio.io", Version: "v1alpha1", Kind: "IstioOperator"},
+		{Group: "example.istio.io", Version: "v1", Kind: "Retired"},
+	}
+	want := []schema.GroupVersionKind{{Group: "example.istio.io", Version: "v1", Kind: "Retired"}}
+	if got := unknownKinds(gvks); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
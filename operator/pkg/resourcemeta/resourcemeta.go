@@ -0,0 +1,140 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcemeta adds the labels and annotations of an install to the metadata of every rendered object, e.g. for
+// cost allocation, ownership or admission policies.
+package resourcemeta
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	labelsPath      = "global.resourceLabels"
+	annotationsPath = "global.resourceAnnotations"
+)
+
+// Settings returns the labels and annotations in values.global.resourceLabels and values.global.resourceAnnotations of
+// the given values tree. Values which are not strings, like numbers set with --set, are converted to strings.
+func Settings(values map[string]interface{}) (labels, annotations map[string]string) {
+	return stringMap(values, labelsPath), stringMap(values, annotationsPath)
+}
+
+// stringMap returns the map at path in values, with its values converted to strings, or nil if there is none.
+func stringMap(values map[string]interface{}, path string) map[string]string {
+	v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(path))
+	if !found {
+		return nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = fmt.Sprint(v)
+	}
+	return out
+}
+
+// Validate returns an error listing the labels which are not valid K8s label keys and values, and the annotations
+// which do not have valid keys.
+func Validate(labels, annotations map[string]string) error {
+	var errs util.Errors
+	for _, k := range sortedKeys(labels) {
+		for _, msg := range validation.IsQualifiedName(k) {
+			errs = util.AppendErr(errs, fmt.Errorf("bad resource label key %q: %s", k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(labels[k]) {
+			errs = util.AppendErr(errs, fmt.Errorf("bad value %q of resource label %s: %s", labels[k], k, msg))
+		}
+	}
+	for _, k := range sortedKeys(annotations) {
+		for _, msg := range validation.IsQualifiedName(strings.ToLower(k)) {
+			errs = util.AppendErr(errs, fmt.Errorf("bad resource annotation key %q: %s", k, msg))
+		}
+	}
+	return errs.ToError()
+}
+
+// Apply returns manifests with labels and annotations added to the metadata of all objects. Labels and annotations
+// which an object already has, like the ones the charts set for selectors, are kept. manifests is returned unchanged if
+// there are no labels or annotations.
+func Apply(manifests name.ManifestMap, labels, annotations map[string]string) (name.ManifestMap, error) {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return manifests, nil
+	}
+	if err := Validate(labels, annotations); err != nil {
+		return nil, err
+	}
+	out := make(name.ManifestMap)
+	for cn, ms := range manifests {
+		for _, m := range ms {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			if len(objs) == 0 {
+				out[cn] = append(out[cn], m)
+				continue
+			}
+			var labeled object.K8sObjects
+			for _, o := range objs {
+				u := o.UnstructuredObject().DeepCopy()
+				u.SetLabels(merge(u.GetLabels(), labels))
+				u.SetAnnotations(merge(u.GetAnnotations(), annotations))
+				labeled = append(labeled, object.NewK8sObject(u, nil, nil))
+			}
+			ym, err := labeled.YAMLManifest()
+			if err != nil {
+				return nil, err
+			}
+			out[cn] = append(out[cn], strings.TrimSuffix(ym, object.YAMLSeparator))
+		}
+	}
+	return out, nil
+}
+
+// merge returns existing with the entries of add whose keys it does not have.
+func merge(existing, add map[string]string) map[string]string {
+	if len(add) == 0 {
+		return existing
+	}
+	out := make(map[string]string, len(existing)+len(add))
+	for k, v := range add {
+		out[k] = v
+	}
+	for k, v := range existing {
+		out[k] = v
+	}
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
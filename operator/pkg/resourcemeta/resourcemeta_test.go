@@ -0,0 +1,108 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcemeta
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+func TestSettings(t *testing.T) {
+	values := map[string]interface{}{
+		"global": map[string]interface{}{
+			"resourceLabels":      map[string]interface{}{"team": "mesh", "cost-center": 123},
+			"resourceAnnotations": map[string]interface{}{},
+		},
+	}
+	labels, annotations := Settings(values)
+	if want := map[string]string{"team": "mesh", "cost-center": "123"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("got labels %v, want %v", labels, want)
+	}
+	if annotations != nil {
+		t.Errorf("got annotations %v, want none", annotations)
+	}
+}
+
+func TestApply(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.PilotComponentName: {`apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+  labels:
+    app: istiod
+`},
+		name.CNIComponentName: {""},
+	}
+	tests := []struct {
+		desc            string
+		labels          map[string]string
+		annotations     map[string]string
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+		wantErr         bool
+	}{
+		{
+			desc:       "none",
+			wantLabels: map[string]string{"app": "istiod"},
+		},
+		{
+			desc:            "added",
+			labels:          map[string]string{"team": "mesh", "app": "other"},
+			annotations:     map[string]string{"example.com/owner": "mesh-team"},
+			wantLabels:      map[string]string{"app": "istiod", "team": "mesh"},
+			wantAnnotations: map[string]string{"example.com/owner": "mesh-team"},
+		},
+		{
+			desc:    "bad label value",
+			labels:  map[string]string{"team": "mesh team"},
+			wantErr: true,
+		},
+		{
+			desc:        "bad annotation key",
+			annotations: map[string]string{"-owner": "mesh"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Apply(manifests, tt.labels, tt.annotations)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(got[name.PilotComponentName][0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			u := objs[0].UnstructuredObject()
+			if !reflect.DeepEqual(u.GetLabels(), tt.wantLabels) {
+				t.Errorf("got labels %v, want %v", u.GetLabels(), tt.wantLabels)
+			}
+			if !reflect.DeepEqual(u.GetAnnotations(), tt.wantAnnotations) {
+				t.Errorf("got annotations %v, want %v", u.GetAnnotations(), tt.wantAnnotations)
+			}
+			if want := []string{""}; !reflect.DeepEqual(got[name.CNIComponentName], want) {
+				t.Errorf("got CNI manifests %q, want %q", got[name.CNIComponentName], want)
+			}
+		})
+	}
+}
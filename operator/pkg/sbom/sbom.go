@@ -0,0 +1,197 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sbom generates software bills of materials for rendered Istio installation manifests.
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+const (
+	// BOMFormat is the format of the generated documents.
+	BOMFormat = "CycloneDX"
+	// SpecVersion is the CycloneDX specification version of the generated documents.
+	SpecVersion = "1.3"
+
+	componentTypeApplication = "application"
+	componentTypeContainer   = "container"
+	componentTypeFile        = "file"
+
+	hashAlgSHA256 = "SHA-256"
+	digestPrefix  = "sha256:"
+
+	profileProperty = "istio:profile"
+	chartProperty   = "istio:helm-chart"
+)
+
+// BOM is a CycloneDX bill of materials document.
+type BOM struct {
+	BOMFormat   string       `json:"bomFormat"`
+	SpecVersion string       `json:"specVersion"`
+	Version     int          `json:"version"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Components  []*Component `json:"components,omitempty"`
+}
+
+// Metadata describes how and when the BOM was generated.
+type Metadata struct {
+	Timestamp  string      `json:"timestamp,omitempty"`
+	Tools      []*Tool     `json:"tools,omitempty"`
+	Properties []*Property `json:"properties,omitempty"`
+}
+
+// Tool is a tool used to generate the BOM.
+type Tool struct {
+	Vendor  string `json:"vendor,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Component is a component included in the BOM.
+type Component struct {
+	Type       string      `json:"type"`
+	Name       string      `json:"name"`
+	Version    string      `json:"version,omitempty"`
+	Hashes     []*Hash     `json:"hashes,omitempty"`
+	Properties []*Property `json:"properties,omitempty"`
+}
+
+// Hash is a hash of a component.
+type Hash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// Property is a name value pair.
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Options are the inputs to the BOM.
+type Options struct {
+	// ToolName and ToolVersion identify the binary which generated the manifests.
+	ToolName    string
+	ToolVersion string
+	// Profile is the installation profile the manifests were generated from.
+	Profile string
+	// ChartVersions maps helm chart names to chart versions.
+	ChartVersions map[string]string
+	// Manifests are the rendered manifests, which are scanned for container images.
+	Manifests name.ManifestMap
+	// InputFiles are the paths of the user supplied IstioOperator files.
+	InputFiles []string
+}
+
+// New returns a BOM for the given options.
+func New(opts *Options) (*BOM, error) {
+	bom := &BOM{
+		BOMFormat:   BOMFormat,
+		SpecVersion: SpecVersion,
+		Version:     1,
+		Metadata: &Metadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools:     []*Tool{{Vendor: "Istio", Name: opts.ToolName, Version: opts.ToolVersion}},
+		},
+	}
+	if opts.Profile != "" {
+		bom.Metadata.Properties = append(bom.Metadata.Properties, &Property{Name: profileProperty, Value: opts.Profile})
+	}
+
+	var charts []string
+	for c := range opts.ChartVersions {
+		charts = append(charts, c)
+	}
+	sort.Strings(charts)
+	for _, c := range charts {
+		bom.Components = append(bom.Components, &Component{
+			Type:       componentTypeApplication,
+			Name:       c,
+			Version:    opts.ChartVersions[c],
+			Properties: []*Property{{Name: chartProperty, Value: "true"}},
+		})
+	}
+
+	images, err := manifestImages(opts.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		bom.Components = append(bom.Components, imageComponent(image))
+	}
+
+	for _, f := range opts.InputFiles {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("could not read input file %s: %s", f, err)
+		}
+		sum := sha256.Sum256(b)
+		bom.Components = append(bom.Components, &Component{
+			Type:   componentTypeFile,
+			Name:   f,
+			Hashes: []*Hash{{Alg: hashAlgSHA256, Content: hex.EncodeToString(sum[:])}},
+		})
+	}
+	return bom, nil
+}
+
+// JSON returns the BOM as an indented JSON document.
+func (b *BOM) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// manifestImages returns the sorted, de-duplicated list of container images referenced in the manifests.
+func manifestImages(manifests name.ManifestMap) ([]string, error) {
+	var objs object.K8sObjects
+	for _, ms := range manifests {
+		for _, m := range ms {
+			mobjs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, mobjs...)
+		}
+	}
+	return objs.ContainerImages(), nil
+}
+
+// imageComponent returns a component for an image reference of the form repo[:tag][@sha256:digest].
+func imageComponent(image string) *Component {
+	c := &Component{Type: componentTypeContainer}
+	ref := image
+	if i := strings.Index(ref, "@"); i >= 0 {
+		if digest := ref[i+1:]; strings.HasPrefix(digest, digestPrefix) {
+			c.Hashes = []*Hash{{Alg: hashAlgSHA256, Content: strings.TrimPrefix(digest, digestPrefix)}}
+		}
+		ref = ref[:i]
+	}
+	// A colon after the last slash separates the tag, any other colon is part of a registry host:port.
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		c.Name, c.Version = ref[:i], ref[i+1:]
+	} else {
+		c.Name = ref
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestImageComponent(t *testing.T) {
+	tests := []struct {
+		image string
+		want  *Component
+	}{
+		{
+			image: "docker.io/istio/pilot:1.6.0",
+			want:  &Component{Type: componentTypeContainer, Name: "docker.io/istio/pilot", Version: "1.6.0"},
+		},
+		{
+			image: "localhost:5000/istio/proxyv2",
+			want:  &Component{Type: componentTypeContainer, Name: "localhost:5000/istio/proxyv2"},
+		},
+		{
+			image: "gcr.io/istio/pilot:1.6.0@sha256:abcd",
+			want: &Component{
+				Type:    componentTypeContainer,
+				Name:    "gcr.io/istio/pilot",
+				Version: "1.6.0",
+				Hashes:  []*Hash{{Alg: hashAlgSHA256, Content: "abcd"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := imageComponent(tt.image); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("imageComponent(%s) got %+v, want %+v", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.PilotComponentName: {`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: docker.io/istio/proxyv2:1.6.0
+      containers:
+      - name: discovery
+        image: docker.io/istio/pilot:1.6.0
+`},
+		name.IngressComponentName: {`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: istio-proxy
+        image: docker.io/istio/proxyv2:1.6.0
+`},
+	}
+	bom, err := New(&Options{
+		ToolName:      "istioctl",
+		ToolVersion:   "1.6.0",
+		Profile:       "demo",
+		ChartVersions: map[string]string{"istio-discovery": "1.6.0", "base": "1.6.0"},
+		Manifests:     manifests,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range bom.Components {
+		got = append(got, c.Type+":"+c.Name)
+	}
+	want := []string{
+		"application:base",
+		"application:istio-discovery",
+		"container:docker.io/istio/pilot",
+		"container:docker.io/istio/proxyv2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New() got components %v, want %v", got, want)
+	}
+	if len(bom.Metadata.Properties) != 1 || bom.Metadata.Properties[0].Value != "demo" {
+		t.Errorf("New() got metadata properties %+v, want profile demo", bom.Metadata.Properties)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scheduling applies the default scheduling constraints of an install, like the node selector and tolerations
+// of a dedicated node pool, to the k8s settings of all components which do not set their own.
+package scheduling
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	valuesPath = "global.defaultPodScheduling"
+)
+
+var (
+	// fields are the k8s settings of a component, and the fields of values.global.defaultPodScheduling, which are
+	// applied.
+	fields = []string{"nodeSelector", "tolerations", "affinity"}
+	// components are the components whose pods get the default scheduling constraints, besides the gateways and
+	// addons. Base has no pods and CNI must run on every node.
+	components = []string{"pilot", "policy", "telemetry"}
+)
+
+// Settings returns the non empty fields of values.global.defaultPodScheduling in the given values tree, by k8s
+// setting name.
+func Settings(values map[string]interface{}) map[string]interface{} {
+	v, found, _ := tpath.GetFromTreePath(values, util.PathFromString(valuesPath))
+	if !found {
+		return nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := make(map[string]interface{})
+	for _, f := range fields {
+		switch fv := m[f].(type) {
+		case map[string]interface{}:
+			if len(fv) != 0 {
+				out[f] = fv
+			}
+		case []interface{}:
+			if len(fv) != 0 {
+				out[f] = fv
+			}
+		}
+	}
+	return out
+}
+
+// Apply sets the k8s nodeSelector, tolerations and affinity of the components in iop to the defaults in
+// values.global.defaultPodScheduling, for each of the settings a component does not set itself. The components which
+// are not listed in iop, and CNI, are left unchanged. iop is unchanged if there are no defaults.
+func Apply(iop *v1alpha1.IstioOperatorSpec) error {
+	defaults := Settings(iop.Values)
+	if len(defaults) == 0 {
+		return nil
+	}
+	y, err := util.MarshalWithJSONPB(iop)
+	if err != nil {
+		return err
+	}
+	tree := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(y), &tree); err != nil {
+		return err
+	}
+	var specs []map[string]interface{}
+	if cs, ok := tree["components"].(map[string]interface{}); ok {
+		for _, c := range components {
+			if spec, ok := cs[c].(map[string]interface{}); ok {
+				specs = append(specs, spec)
+			}
+		}
+		for _, g := range []string{"ingressGateways", "egressGateways"} {
+			gws, _ := cs[g].([]interface{})
+			for _, gw := range gws {
+				if spec, ok := gw.(map[string]interface{}); ok {
+					specs = append(specs, spec)
+				}
+			}
+		}
+	}
+	if addons, ok := tree["addonComponents"].(map[string]interface{}); ok {
+		for _, a := range addons {
+			if spec, ok := a.(map[string]interface{}); ok {
+				specs = append(specs, spec)
+			}
+		}
+	}
+	for _, spec := range specs {
+		k8s, _ := spec["k8s"].(map[string]interface{})
+		if k8s == nil {
+			k8s = make(map[string]interface{})
+			spec["k8s"] = k8s
+		}
+		for f, v := range defaults {
+			if _, ok := k8s[f]; !ok {
+				k8s[f] = v
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	applied := &v1alpha1.IstioOperatorSpec{}
+	if err := util.UnmarshalWithJSONPB(string(out), applied, false); err != nil {
+		return fmt.Errorf("could not apply %s: %s", valuesPath, err)
+	}
+	*iop = *applied
+	return nil
+}
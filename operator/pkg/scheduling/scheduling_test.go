@@ -0,0 +1,124 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduling
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/operator/pkg/util"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		desc string
+		iop  string
+		want map[string]string
+	}{
+		{
+			desc: "no defaults",
+			iop: `
+components:
+  pilot:
+    enabled: true
+`,
+			want: map[string]string{"pilot": ""},
+		},
+		{
+			desc: "defaults with override",
+			iop: `
+components:
+  pilot:
+    enabled: true
+    k8s:
+      nodeSelector:
+        pool: control
+  ingressGateways:
+  - name: istio-ingressgateway
+    enabled: true
+  cni:
+    enabled: true
+values:
+  global:
+    defaultPodScheduling:
+      nodeSelector:
+        pool: infra
+      tolerations:
+      - key: dedicated
+        operator: Equal
+        value: infra
+        effect: NoSchedule
+`,
+			want: map[string]string{
+				"pilot": `nodeSelector:
+  pool: control
+tolerations:
+- effect: NoSchedule
+  key: dedicated
+  operator: Equal
+  value: infra
+`,
+				"ingress": `nodeSelector:
+  pool: infra
+tolerations:
+- effect: NoSchedule
+  key: dedicated
+  operator: Equal
+  value: infra
+`,
+				"cni": "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			iop := &v1alpha1.IstioOperatorSpec{}
+			if err := util.UnmarshalWithJSONPB(tt.iop, iop, false); err != nil {
+				t.Fatal(err)
+			}
+			if err := Apply(iop); err != nil {
+				t.Fatal(err)
+			}
+			y, err := util.MarshalWithJSONPB(iop)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(y), &tree); err != nil {
+				t.Fatal(err)
+			}
+			cs := tree["components"].(map[string]interface{})
+			specs := map[string]interface{}{"pilot": cs["pilot"], "cni": cs["cni"]}
+			if gws, ok := cs["ingressGateways"].([]interface{}); ok {
+				specs["ingress"] = gws[0]
+			}
+			got := make(map[string]string)
+			for c := range tt.want {
+				spec, _ := specs[c].(map[string]interface{})
+				if k8s, ok := spec["k8s"]; ok {
+					got[c] = util.ToYAML(k8s)
+				} else {
+					got[c] = ""
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema validates and completes IstioOperator paths, such as those used with --set, against the
+// IstioOperatorSpec, Values and MeshConfig types.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/api/operator/v1alpha1"
+	valuesv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// maxEnumValue is the largest enum value that is checked for a name when listing enum values.
+	maxEnumValue = 64
+)
+
+var (
+	rootType = reflect.TypeOf(v1alpha1.IstioOperatorSpec{})
+	// freeformTypes are untyped in IstioOperatorSpec and are given a schema here. Paths under any other untyped
+	// field, e.g. unvalidatedValues, are not checked.
+	freeformTypes = map[string]reflect.Type{
+		"values":     reflect.TypeOf(valuesv1alpha1.Values{}),
+		"meshConfig": reflect.TypeOf(meshconfig.MeshConfig{}),
+	}
+	// wellKnownFreeformTypes are protobuf well known types which can hold arbitrary values.
+	wellKnownFreeformTypes = map[string]bool{
+		"Struct":    true,
+		"Value":     true,
+		"ListValue": true,
+		"Any":       true,
+	}
+)
+
+// ValidSetPath returns an error if path is not a path in the IstioOperatorSpec schema, or if value is not a valid
+// value for an enum at path.
+func ValidSetPath(path, value string) error {
+	t, err := typeAt(util.PathFromString(path))
+	if err != nil {
+		return err
+	}
+	if t == nil || value == "" || !isEnum(t) {
+		return nil
+	}
+	for _, v := range enumValues(t) {
+		if v == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("path %s: %s is not one of %s", path, value, strings.Join(enumValues(t), ", "))
+}
+
+// CompleteSetPath returns the completions for a partial --set flag value. A partial path like components.pi is
+// completed to the matching child paths like components.pilot, and a partial path=value for bool or enum paths is
+// completed to the allowed values.
+func CompleteSetPath(toComplete string) []string {
+	if kv := strings.SplitN(toComplete, "=", 2); len(kv) == 2 {
+		t, err := typeAt(util.PathFromString(kv[0]))
+		if err != nil || t == nil {
+			return nil
+		}
+		var out []string
+		for _, v := range leafValues(t) {
+			if strings.HasPrefix(v, kv[1]) {
+				out = append(out, kv[0]+"="+v)
+			}
+		}
+		return out
+	}
+
+	parent, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, util.PathSeparator); i >= 0 {
+		parent, partial = toComplete[:i], toComplete[i+1:]
+	}
+	t := rootType
+	if parent != "" {
+		var err error
+		if t, err = typeAt(util.PathFromString(parent)); err != nil || t == nil {
+			return nil
+		}
+	}
+	var out []string
+	for _, c := range childNames(t) {
+		if !strings.HasPrefix(c, partial) {
+			continue
+		}
+		if parent != "" {
+			c = parent + util.PathSeparator + c
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// typeAt returns the type at path in the IstioOperatorSpec schema, or nil if path is below an untyped field where
+// any path is allowed.
+func typeAt(path util.Path) (reflect.Type, error) {
+	t := rootType
+	for i, pe := range path {
+		t = deref(t)
+		switch {
+		case isFreeform(t):
+			return nil, nil
+		case isLeaf(t):
+			return nil, fmt.Errorf("path %s: %s has no child %s", path, path[:i], pe)
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			if st, ok := freeformTypes[pe]; ok && t == rootType {
+				t = st
+				continue
+			}
+			f, ok := fieldByName(t, pe)
+			if !ok {
+				if hasOneof(t) {
+					return nil, nil
+				}
+				return nil, fmt.Errorf("path %s: unknown field %s", path, pe)
+			}
+			t = f.Type
+		case reflect.Slice:
+			if !util.IsNPathElement(pe) && !util.IsKVPathElement(pe) && !util.IsVPathElement(pe) {
+				return nil, fmt.Errorf("path %s: expect a list selector like [0] or [name:value] at %s, got %s", path, path[:i], pe)
+			}
+			t = t.Elem()
+		case reflect.Map:
+			t = t.Elem()
+		}
+	}
+	t = deref(t)
+	if isFreeform(t) {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// childNames returns the sorted names of the fields of t.
+func childNames(t reflect.Type) []string {
+	t = deref(t)
+	if t.Kind() != reflect.Struct || isLeaf(t) || isFreeform(t) {
+		return nil
+	}
+	var out []string
+	for i := 0; i < t.NumField(); i++ {
+		if n := fieldName(t.Field(i)); n != "" {
+			out = append(out, n)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// leafValues returns the allowed values for a leaf type t, if these are enumerable.
+func leafValues(t reflect.Type) []string {
+	switch {
+	case isEnum(t):
+		return enumValues(t)
+	case t.Kind() == reflect.Bool || (t.Kind() == reflect.Struct && strings.Contains(t.Name(), "BoolValue")):
+		return []string{"true", "false"}
+	}
+	return nil
+}
+
+// fieldByName returns the field of struct type t with the JSON or proto name pe.
+func fieldByName(t reflect.Type, pe string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if fieldName(f) == "" {
+			continue
+		}
+		for _, n := range fieldNames(f) {
+			if n == pe {
+				return f, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldName returns the preferred name in paths for field f. This is the JSON name used by jsonpb, or "" if f is
+// not a proto field.
+func fieldName(f reflect.StructField) string {
+	ns := fieldNames(f)
+	if len(ns) == 0 {
+		return ""
+	}
+	return ns[0]
+}
+
+// fieldNames returns the names that f can be referenced with, the preferred name first.
+func fieldNames(f reflect.StructField) []string {
+	if strings.HasPrefix(f.Name, "XXX_") || f.Tag.Get("protobuf_oneof") != "" {
+		return nil
+	}
+	var jsonName, origName string
+	for _, tv := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		switch {
+		case strings.HasPrefix(tv, "json="):
+			jsonName = strings.TrimPrefix(tv, "json=")
+		case strings.HasPrefix(tv, "name="):
+			origName = strings.TrimPrefix(tv, "name=")
+		}
+	}
+	var out []string
+	for _, n := range []string{jsonName, origName, strings.Split(f.Tag.Get("json"), ",")[0]} {
+		if n != "" && n != "-" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func hasOneof(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("protobuf_oneof") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isFreeform reports whether t can hold arbitrary values, so that any path under it is valid.
+func isFreeform(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Map:
+		return t.Elem().Kind() == reflect.Interface
+	case reflect.Struct:
+		return isWellKnownType(t) && wellKnownFreeformTypes[t.Name()]
+	}
+	return false
+}
+
+// isLeaf reports whether t is a scalar or wrapped scalar type without child paths.
+func isLeaf(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return (isWellKnownType(t) && !wellKnownFreeformTypes[t.Name()]) || strings.HasSuffix(t.Name(), "ForPB")
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	case reflect.Map, reflect.Interface, reflect.Ptr:
+		return false
+	}
+	return true
+}
+
+func isWellKnownType(t reflect.Type) bool {
+	return strings.HasPrefix(t.PkgPath(), "github.com/gogo/protobuf/types") ||
+		strings.HasPrefix(t.PkgPath(), "github.com/golang/protobuf/ptypes")
+}
+
+func isEnum(t reflect.Type) bool {
+	_, ok := reflect.Zero(t).Interface().(fmt.Stringer)
+	return t.Kind() == reflect.Int32 && ok
+}
+
+// enumValues returns the names of the values of the proto enum type t.
+func enumValues(t reflect.Type) []string {
+	var out []string
+	for i := 0; i <= maxEnumValue; i++ {
+		v := reflect.New(t).Elem()
+		v.SetInt(int64(i))
+		if s := v.Interface().(fmt.Stringer).String(); s != strconv.Itoa(i) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidSetPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		value   string
+		wantErr bool
+	}{
+		{path: "profile", value: "demo"},
+		{path: "components.pilot.enabled", value: "true"},
+		{path: "components.pilot.k8s.replicaCount", value: "2"},
+		{path: "components.ingressGateways.[0].enabled", value: "true"},
+		{path: "components.ingressGateways.[name:istio-ingressgateway].k8s.hpaSpec.maxReplicas", value: "3"},
+		{path: "addonComponents.grafana.enabled", value: "true"},
+		{path: "values.global.proxy.includeIPRanges", value: "10.0.0.0/8"},
+		{path: "values.gateways.istio-egressgateway.enabled", value: "true"},
+		{path: "values.sidecarInjectorWebhook.injectedAnnotations.container\\.apparmor", value: "runtime/default"},
+		{path: "meshConfig.defaultConfig.proxyMetadata.DNS_AGENT", value: "DNS-TLS"},
+		{path: "meshConfig.outboundTrafficPolicy.mode", value: "REGISTRY_ONLY"},
+		{path: "unvalidatedValues.anything.at.all", value: "x"},
+		{path: "components.pilot.enable", value: "true", wantErr: true},
+		{path: "components.pilot.enabled.foo", value: "true", wantErr: true},
+		{path: "components.ingressGateways.enabled", value: "true", wantErr: true},
+		{path: "values.global.notAField", value: "true", wantErr: true},
+		{path: "meshConfig.outboundTrafficPolicy.mode", value: "ALLOW_SOME", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := ValidSetPath(tt.path, tt.value)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("ValidSetPath(%s, %s): got error %v, want error: %v", tt.path, tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompleteSetPath(t *testing.T) {
+	tests := []struct {
+		toComplete string
+		want       []string
+	}{
+		{
+			toComplete: "componen",
+			want:       []string{"components"},
+		},
+		{
+			toComplete: "components.pil",
+			want:       []string{"components.pilot"},
+		},
+		{
+			toComplete: "components.pilot.enabled=t",
+			want:       []string{"components.pilot.enabled=true"},
+		},
+		{
+			toComplete: "meshConfig.outboundTrafficPolicy.mode=",
+			want:       []string{"meshConfig.outboundTrafficPolicy.mode=REGISTRY_ONLY", "meshConfig.outboundTrafficPolicy.mode=ALLOW_ANY"},
+		},
+		{
+			toComplete: "values.gateways.istio-i",
+			want:       []string{"values.gateways.istio-ingressgateway"},
+		},
+		{
+			toComplete: "components.notAComponent.",
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.toComplete, func(t *testing.T) {
+			if got := CompleteSetPath(tt.toComplete); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompleteSetPath(%s): got %v, want %v", tt.toComplete, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sizing scales the replicas, autoscaling bounds and resources of the control plane and gateways of an install
+// coherently to one of a few sizing presets, selected with values.global.sizing.
+package sizing
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/util"
+)
+
+const (
+	// Small fits development and test clusters, with a single replica of each component.
+	Small = "small"
+	// Medium matches the defaults of the default profile.
+	Medium = "medium"
+	// Large fits production meshes, with at least two replicas of each component.
+	Large = "large"
+	// Custom applies no preset, leaving the sizing to the profile and overlays.
+	Custom = "custom"
+
+	valuesPath = "spec.values.global.sizing"
+	gatewayKey = "gateway"
+)
+
+var (
+	// Presets are the valid values of values.global.sizing.
+	Presets = map[string]bool{Small: true, Medium: true, Large: true, Custom: true}
+
+	// components are the components which are sized, besides the gateways.
+	components = []string{"pilot", "policy", "telemetry"}
+
+	// presets map the presets to the sizing of each component, with gatewayKey for all the gateways.
+	presets = map[string]map[string]componentSizing{
+		Small: {
+			"pilot":     {replicas: 1, minReplicas: 1, maxReplicas: 1, resources: resources("250m", "512Mi", "", "")},
+			"policy":    {replicas: 1, minReplicas: 1, maxReplicas: 1, resources: resources("100m", "128Mi", "", "")},
+			"telemetry": {replicas: 1, minReplicas: 1, maxReplicas: 1, resources: resources("500m", "512Mi", "2000m", "2G")},
+			gatewayKey:  {replicas: 1, minReplicas: 1, maxReplicas: 1, resources: resources("50m", "64Mi", "1000m", "512Mi")},
+		},
+		Medium: {
+			"pilot":     {replicas: 1, minReplicas: 1, maxReplicas: 5, resources: resources("500m", "2048Mi", "", "")},
+			"policy":    {replicas: 1, minReplicas: 1, maxReplicas: 5, resources: resources("100m", "128Mi", "", "")},
+			"telemetry": {replicas: 1, minReplicas: 1, maxReplicas: 5, resources: resources("1000m", "1G", "4800m", "4G")},
+			gatewayKey:  {replicas: 1, minReplicas: 1, maxReplicas: 5, resources: resources("100m", "128Mi", "2000m", "1024Mi")},
+		},
+		Large: {
+			"pilot":     {replicas: 2, minReplicas: 2, maxReplicas: 10, resources: resources("1000m", "4Gi", "", "")},
+			"policy":    {replicas: 2, minReplicas: 2, maxReplicas: 10, resources: resources("500m", "512Mi", "", "")},
+			"telemetry": {replicas: 2, minReplicas: 2, maxReplicas: 10, resources: resources("2000m", "2G", "4800m", "4G")},
+			gatewayKey:  {replicas: 2, minReplicas: 2, maxReplicas: 10, resources: resources("500m", "512Mi", "4000m", "2Gi")},
+		},
+	}
+)
+
+// componentSizing is the sizing of a component in a preset.
+type componentSizing struct {
+	replicas    int
+	minReplicas int
+	maxReplicas int
+	resources   map[string]interface{}
+}
+
+// resources returns k8s resources with the given requests and, if set, limits.
+func resources(cpu, memory, cpuLimit, memoryLimit string) map[string]interface{} {
+	out := map[string]interface{}{
+		"requests": map[string]interface{}{"cpu": cpu, "memory": memory},
+	}
+	if cpuLimit != "" {
+		out["limits"] = map[string]interface{}{"cpu": cpuLimit, "memory": memoryLimit}
+	}
+	return out
+}
+
+// Settings returns the sizing preset in values.global.sizing of the given IstioOperator tree, or "" if it is not set.
+func Settings(tree map[string]interface{}) string {
+	v, found, _ := tpath.GetFromTreePath(tree, util.PathFromString(valuesPath))
+	if !found {
+		return ""
+	}
+	s, _ := v.(string)
+	return s
+}
+
+// Overlay returns iopYAML, an IstioOperator which has userOverlayYAML merged over its profile, with the k8s
+// replicaCount, resources and hpaSpec bounds of pilot, policy, telemetry and the gateways set to the sizing preset in
+// values.global.sizing. Each of these settings which userOverlayYAML sets for a component takes precedence over the
+// preset, and the bounds are only set on components with an hpaSpec. iopYAML is returned unchanged if the sizing is
+// custom or not set.
+func Overlay(iopYAML, userOverlayYAML string) (string, error) {
+	tree := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(iopYAML), &tree); err != nil {
+		return "", err
+	}
+	size := Settings(tree)
+	if size == "" || size == Custom {
+		return iopYAML, nil
+	}
+	preset, ok := presets[size]
+	if !ok {
+		return "", fmt.Errorf("values.global.sizing: unknown sizing %s, must be one of small, medium, large or custom", size)
+	}
+	user := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(userOverlayYAML), &user); err != nil {
+		return "", err
+	}
+
+	cs := componentsOf(tree)
+	userCS := componentsOf(user)
+	for _, c := range components {
+		if spec, ok := cs[c].(map[string]interface{}); ok {
+			userSpec, _ := userCS[c].(map[string]interface{})
+			apply(spec, userSpec, preset[c])
+		}
+	}
+	for _, g := range []string{"ingressGateways", "egressGateways"} {
+		userGateways, _ := userCS[g].([]interface{})
+		gws, _ := cs[g].([]interface{})
+		for _, gw := range gws {
+			if spec, ok := gw.(map[string]interface{}); ok {
+				apply(spec, gatewayNamed(userGateways, spec["name"]), preset[gatewayKey])
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// apply sets the k8s settings of the component spec to s, except for those set in the k8s settings of userSpec.
+func apply(spec, userSpec map[string]interface{}, s componentSizing) {
+	userK8s, _ := userSpec["k8s"].(map[string]interface{})
+	k8s, _ := spec["k8s"].(map[string]interface{})
+	if k8s == nil {
+		k8s = make(map[string]interface{})
+		spec["k8s"] = k8s
+	}
+	if _, ok := userK8s["replicaCount"]; !ok {
+		k8s["replicaCount"] = s.replicas
+	}
+	if _, ok := userK8s["resources"]; !ok {
+		k8s["resources"] = s.resources
+	}
+	// The bounds are only set together, so that they cannot cross, and only on an existing hpaSpec, which carries the
+	// scale target and metrics.
+	if hpa, ok := k8s["hpaSpec"].(map[string]interface{}); ok {
+		if _, ok := userK8s["hpaSpec"]; !ok {
+			hpa["minReplicas"] = s.minReplicas
+			hpa["maxReplicas"] = s.maxReplicas
+		}
+	}
+}
+
+// componentsOf returns spec.components of the given IstioOperator tree, or nil if there is none.
+func componentsOf(tree map[string]interface{}) map[string]interface{} {
+	v, _, _ := tpath.GetFromTreePath(tree, util.PathFromString("spec.components"))
+	cs, _ := v.(map[string]interface{})
+	return cs
+}
+
+// gatewayNamed returns the spec of the gateway with the given name in gateways, or nil if there is none.
+func gatewayNamed(gateways []interface{}, name interface{}) map[string]interface{} {
+	for _, gw := range gateways {
+		if spec, ok := gw.(map[string]interface{}); ok && spec["name"] == name {
+			return spec
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sizing
+
+import (
+	"testing"
+
+	"istio.io/istio/operator/pkg/util"
+)
+
+func TestOverlay(t *testing.T) {
+	tests := []struct {
+		desc    string
+		iop     string
+		user    string
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "custom",
+			iop: `
+spec:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 3
+  values:
+    global:
+      sizing: custom
+`,
+			want: `
+spec:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 3
+  values:
+    global:
+      sizing: custom
+`,
+		},
+		{
+			desc: "large with user settings",
+			iop: `
+spec:
+  components:
+    pilot:
+      k8s:
+        hpaSpec:
+          minReplicas: 1
+          maxReplicas: 5
+          scaleTargetRef:
+            name: istiod
+    ingressGateways:
+    - name: istio-ingressgateway
+      k8s:
+        resources:
+          requests:
+            cpu: 10m
+  values:
+    global:
+      sizing: large
+`,
+			user: `
+spec:
+  components:
+    ingressGateways:
+    - name: istio-ingressgateway
+      k8s:
+        resources:
+          requests:
+            cpu: 10m
+  values:
+    global:
+      sizing: large
+`,
+			want: `
+spec:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 2
+        hpaSpec:
+          minReplicas: 2
+          maxReplicas: 10
+          scaleTargetRef:
+            name: istiod
+        resources:
+          requests:
+            cpu: 1000m
+            memory: 4Gi
+    ingressGateways:
+    - name: istio-ingressgateway
+      k8s:
+        replicaCount: 2
+        resources:
+          requests:
+            cpu: 10m
+  values:
+    global:
+      sizing: large
+`,
+		},
+		{
+			desc: "unknown",
+			iop: `
+spec:
+  values:
+    global:
+      sizing: huge
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Overlay(tt.iop, tt.user)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !util.IsYAMLEqual(got, tt.want) {
+				t.Errorf("got:\n%s\nwant:\n%s\ndiff:\n%s", got, tt.want, util.YAMLDiff(got, tt.want))
+			}
+		})
+	}
+}
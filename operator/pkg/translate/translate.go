@@ -29,8 +29,10 @@ import (
 
 	"istio.io/api/operator/v1alpha1"
 	iopv1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/multiarch"
 	"istio.io/istio/operator/pkg/name"
 	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/platform"
 	"istio.io/istio/operator/pkg/tpath"
 	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/version"
@@ -47,6 +49,14 @@ const (
 	HelmValuesHubSubpath = "hub"
 	// HelmValuesTagSubpath is the subpath from the component root to the tag parameter.
 	HelmValuesTagSubpath = "tag"
+	// ImageVariantValuesPath is the values path of the image variant, which suffixes the tags of all Istio images.
+	ImageVariantValuesPath = "global.imageVariant"
+	// InjectTemplatesAnnotation is the pod annotation naming the injection template of a gateway, which sets its
+	// injectionTemplate value so that its chart renders a Deployment for injection.
+	InjectTemplatesAnnotation = "inject.istio.io/templates"
+	// defaultArchWeight is the node affinity scheduling weight of the architectures of multi-arch installs, which
+	// is the same as the default weight of the other architectures in values.global.arch.
+	defaultArchWeight = 2
 	// TranslateConfigFolder is the folder where we store translation configurations
 	TranslateConfigFolder = "translateConfig"
 	// TranslateConfigPrefix is the prefix of IstioOperator's translation configuration file
@@ -261,6 +271,15 @@ func (t *Translator) TranslateHelmValues(iop *v1alpha1.IstioOperatorSpec, compon
 	if err != nil {
 		return "", err
 	}
+	if err := applyPlatformDefaults(mergedVals); err != nil {
+		return "", err
+	}
+	if err := applyMultiArch(mergedVals); err != nil {
+		return "", err
+	}
+	if err := t.applyImageVariant(mergedVals); err != nil {
+		return "", err
+	}
 
 	mergedYAML, err := yaml.Marshal(mergedVals)
 	if err != nil {
@@ -275,8 +294,99 @@ func (t *Translator) TranslateHelmValues(iop *v1alpha1.IstioOperatorSpec, compon
 	return string(mergedYAML), err
 }
 
+// applyImageVariant suffixes the global tag, the component tags and the tags of full image references in values
+// with -<variant> for the variant at values.global.imageVariant. Tags which already have the suffix are unchanged.
+// Third party addon images, which have their own tags deeper in the values tree, are not affected.
+func (t *Translator) applyImageVariant(values map[string]interface{}) error {
+	v, found, err := tpath.GetFromTreePath(values, util.PathFromString(ImageVariantValuesPath))
+	if err != nil {
+		return err
+	}
+	variant, _ := v.(string)
+	if !found || variant == "" {
+		return nil
+	}
+	suffix := "-" + variant
+
+	tagPaths := []string{"global." + HelmValuesTagSubpath}
+	imagePaths := []string{"global.proxy.image", "global.proxy_init.image"}
+	for cn, c := range t.ComponentMaps {
+		if c.ToHelmValuesTreeRoot != "" && (cn.IsCoreComponent() || cn.IsGateway()) {
+			tagPaths = append(tagPaths, c.ToHelmValuesTreeRoot+"."+HelmValuesTagSubpath)
+			imagePaths = append(imagePaths, c.ToHelmValuesTreeRoot+".image")
+		}
+	}
+	for _, p := range tagPaths {
+		path := util.PathFromString(p)
+		tag, found, _ := tpath.GetFromTreePath(values, path)
+		if !found || tag == nil || fmt.Sprint(tag) == "" {
+			continue
+		}
+		if err := tpath.WriteNode(values, path, addTagSuffix(fmt.Sprint(tag), suffix)); err != nil {
+			return err
+		}
+	}
+	for _, p := range imagePaths {
+		path := util.PathFromString(p)
+		image, found, _ := tpath.GetFromTreePath(values, path)
+		ref, ok := image.(string)
+		// Only full references have a tag, other images are names which are combined with the hub and tag.
+		if !found || !ok || strings.Contains(ref, "@") || strings.LastIndex(ref, ":") <= strings.LastIndex(ref, "/") {
+			continue
+		}
+		if err := tpath.WriteNode(values, path, addTagSuffix(ref, suffix)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPlatformDefaults sets the defaults for the platform at values.global.platform in values, for paths which have no
+// value or an empty one.
+func applyPlatformDefaults(values map[string]interface{}) error {
+	for p, v := range platform.DefaultValues(platform.Settings(values)) {
+		path := util.PathFromString(p)
+		if cur, found, _ := tpath.GetFromTreePath(values, path); found && !util.IsValueNilOrDefault(cur) {
+			continue
+		}
+		if err := tpath.WriteNode(values, path, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMultiArch sets the scheduling weight of arm64 nodes in values.global.arch, which the chart affinity templates
+// use, and replaces the global hub with the multi-arch hub if values.global.multiArch is enabled.
+func applyMultiArch(values map[string]interface{}) error {
+	enabled, hub := multiarch.Settings(values)
+	if !enabled {
+		return nil
+	}
+	for _, arch := range multiarch.Arches {
+		path := util.PathFromString("global.arch." + arch)
+		if w, found, _ := tpath.GetFromTreePath(values, path); found && fmt.Sprint(w) != "0" {
+			continue
+		}
+		if err := tpath.WriteNode(values, path, defaultArchWeight); err != nil {
+			return err
+		}
+	}
+	if hub == "" {
+		return nil
+	}
+	return tpath.WriteNode(values, util.PathFromString("global."+HelmValuesHubSubpath), hub)
+}
+
+func addTagSuffix(tag, suffix string) string {
+	if strings.HasSuffix(tag, suffix) {
+		return tag
+	}
+	return tag + suffix
+}
+
 // applyGatewayTranslations writes gateway name gwName at the appropriate values path in iop and maps k8s.service.ports
-// to values. It returns the resulting YAML tree.
+// and the inject.istio.io/templates pod annotation to values. It returns the resulting YAML tree.
 func applyGatewayTranslations(iop []byte, componentName name.ComponentName, componentSpec interface{}) ([]byte, error) {
 	if !componentName.IsGateway() {
 		return iop, nil
@@ -305,6 +415,13 @@ func applyGatewayTranslations(iop []byte, componentName name.ComponentName, comp
 			setYAMLNodeByMapPath(iopt, util.PathFromString("gateways.istio-egressgateway.ports"), k8s.Service.Ports)
 		}
 	}
+	if tmpl, ok := k8s.GetPodAnnotations()[InjectTemplatesAnnotation]; ok {
+		gwValues := "gateways.istio-ingressgateway"
+		if componentName == name.EgressComponentName {
+			gwValues = "gateways.istio-egressgateway"
+		}
+		setYAMLNodeByMapPath(iopt, util.PathFromString(gwValues+".injectionTemplate"), tmpl)
+	}
 	return yaml.Marshal(iopt)
 }
 
@@ -17,9 +17,11 @@ package translate
 import (
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/kr/pretty"
 
 	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/operator/pkg/name"
 	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/operator/pkg/version"
 )
@@ -253,3 +255,101 @@ func TestNewTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyImageVariant(t *testing.T) {
+	tr, err := NewTranslator(version.NewMinorVersion(1, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := `
+global:
+  imageVariant: distroless
+  tag: 1.6.0
+  proxy:
+    image: docker.io/istio/proxyv2:1.6.0
+  proxy_init:
+    image: proxyv2
+pilot:
+  tag: 1.6.0-distroless
+gateways:
+  istio-ingressgateway:
+    tag: 1.6.1
+kiali:
+  tag: v1.18
+`
+	want := `
+global:
+  imageVariant: distroless
+  tag: 1.6.0-distroless
+  proxy:
+    image: docker.io/istio/proxyv2:1.6.0-distroless
+  proxy_init:
+    image: proxyv2
+pilot:
+  tag: 1.6.0-distroless
+gateways:
+  istio-ingressgateway:
+    tag: 1.6.1-distroless
+kiali:
+  tag: v1.18
+`
+	vals := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(values), &vals); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.applyImageVariant(vals); err != nil {
+		t.Fatal(err)
+	}
+	if got := util.ToYAML(vals); !util.IsYAMLEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestApplyGatewayTranslations(t *testing.T) {
+	values := `
+gateways:
+  istio-ingressgateway:
+    name: istio-ingressgateway
+`
+	tests := []struct {
+		desc string
+		spec *v1alpha1.GatewaySpec
+		want string
+	}{
+		{
+			desc: "no injection template",
+			spec: &v1alpha1.GatewaySpec{Name: "ingress-a"},
+			want: `
+gateways:
+  istio-ingressgateway:
+    name: ingress-a
+`,
+		},
+		{
+			desc: "injection template annotation",
+			spec: &v1alpha1.GatewaySpec{
+				Name: "ingress-b",
+				K8S: &v1alpha1.KubernetesResourcesSpec{
+					PodAnnotations: map[string]string{InjectTemplatesAnnotation: "gateway"},
+				},
+			},
+			want: `
+gateways:
+  istio-ingressgateway:
+    name: ingress-b
+    injectionTemplate: gateway
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := applyGatewayTranslations([]byte(values), name.IngressComponentName, tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !util.IsYAMLEqual(string(got), tt.want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
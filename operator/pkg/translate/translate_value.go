@@ -16,6 +16,7 @@ package translate
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -71,6 +72,14 @@ var (
 		name.CNIComponentName:                true,
 	}
 
+	// envValueFromSources lists the k8s EnvVarSource fields which may be used in a values.yaml env valueFrom.
+	envValueFromSources = map[string]bool{
+		"configMapKeyRef":  true,
+		"fieldRef":         true,
+		"resourceFieldRef": true,
+		"secretKeyRef":     true,
+	}
+
 	gatewayPathMapping = map[string]name.ComponentName{
 		"gateways.istio-ingressgateway": name.IngressComponentName,
 		"gateways.istio-egressgateway":  name.EgressComponentName,
@@ -487,19 +496,31 @@ func setOutputAndClean(valPath, outPath string, outVal interface{}, valueTree, c
 	return nil
 }
 
-// translateEnv translates env value from helm values.yaml tree.
+// translateEnv translates env value from helm values.yaml tree. Each entry is either a plain value or a map with a
+// single valueFrom key, which is passed through as a k8s EnvVarSource.
 func translateEnv(outPath string, value interface{}, cpSpecTree map[string]interface{}) error {
 	envMap, ok := value.(map[string]interface{})
 	if !ok {
 		return fmt.Errorf("expect env node type to be map[string]interface{} but got: %T", value)
 	}
-	outEnv := make([]map[string]interface{}, len(envMap))
-	cnt := 0
-	for k, v := range envMap {
-		outEnv[cnt] = make(map[string]interface{})
-		outEnv[cnt]["name"] = k
-		outEnv[cnt]["value"] = fmt.Sprintf("%v", v)
-		cnt++
+	keys := make([]string, 0, len(envMap))
+	for k := range envMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	outEnv := make([]map[string]interface{}, 0, len(envMap))
+	for _, k := range keys {
+		env := map[string]interface{}{"name": k}
+		if vm, ok := envMap[k].(map[string]interface{}); ok {
+			vf, err := getEnvValueFrom(k, vm)
+			if err != nil {
+				return err
+			}
+			env["valueFrom"] = vf
+		} else {
+			env["value"] = fmt.Sprintf("%v", envMap[k])
+		}
+		outEnv = append(outEnv, env)
 	}
 	scope.Debugf("path has value in helm Value.yaml tree, mapping to output path %s", outPath)
 	if err := tpath.WriteNode(cpSpecTree, util.ToYAMLPath(outPath), outEnv); err != nil {
@@ -508,6 +529,27 @@ func translateEnv(outPath string, value interface{}, cpSpecTree map[string]inter
 	return nil
 }
 
+// getEnvValueFrom returns the valueFrom node of env variable name from its values.yaml map value, checking that it
+// references exactly one supported source.
+func getEnvValueFrom(name string, value map[string]interface{}) (map[string]interface{}, error) {
+	if len(value) != 1 {
+		return nil, fmt.Errorf("env %s: expect a single valueFrom key, got %d keys", name, len(value))
+	}
+	vf, ok := value["valueFrom"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("env %s: expect valueFrom map, got %v", name, value)
+	}
+	if len(vf) != 1 {
+		return nil, fmt.Errorf("env %s: valueFrom must have exactly one source, got %d", name, len(vf))
+	}
+	for src := range vf {
+		if !envValueFromSources[src] {
+			return nil, fmt.Errorf("env %s: unsupported valueFrom source %s", name, src)
+		}
+	}
+	return vf, nil
+}
+
 // translateK8sTree is internal method for translating K8s configurations from value.yaml tree.
 func (t *ReverseTranslator) translateK8sTree(valueTree map[string]interface{},
 	cpSpecTree map[string]interface{}, mapping map[string]*Translation) error {
@@ -317,3 +317,112 @@ func TestNewReverseTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateEnv(t *testing.T) {
+	tests := []struct {
+		desc    string
+		envYAML string
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "plain values sorted by name",
+			envYAML: `
+ZZZ: 1
+AAA: foo
+`,
+			want: `
+env:
+- name: AAA
+  value: foo
+- name: ZZZ
+  value: "1"
+`,
+		},
+		{
+			desc: "valueFrom sources",
+			envYAML: `
+FOO: bar
+POD_NAME:
+  valueFrom:
+    fieldRef:
+      fieldPath: metadata.name
+TOKEN:
+  valueFrom:
+    secretKeyRef:
+      name: my-secret
+      key: token
+LEVEL:
+  valueFrom:
+    configMapKeyRef:
+      name: my-config
+      key: level
+`,
+			want: `
+env:
+- name: FOO
+  value: bar
+- name: LEVEL
+  valueFrom:
+    configMapKeyRef:
+      name: my-config
+      key: level
+- name: POD_NAME
+  valueFrom:
+    fieldRef:
+      fieldPath: metadata.name
+- name: TOKEN
+  valueFrom:
+    secretKeyRef:
+      name: my-secret
+      key: token
+`,
+		},
+		{
+			desc: "unsupported valueFrom source",
+			envYAML: `
+FOO:
+  valueFrom:
+    vaultRef:
+      name: foo
+`,
+			wantErr: true,
+		},
+		{
+			desc: "multiple valueFrom sources",
+			envYAML: `
+FOO:
+  valueFrom:
+    fieldRef:
+      fieldPath: metadata.name
+    secretKeyRef:
+      name: foo
+      key: bar
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			envMap := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(tt.envYAML), &envMap); err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]interface{})
+			err := translateEnv("env", envMap, got)
+			if gotErr, wantErr := err != nil, tt.wantErr; gotErr != wantErr {
+				t.Fatalf("translateEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotYAML, err := yaml.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !util.IsYAMLEqual(string(gotYAML), tt.want) {
+				t.Errorf("translateEnv() got:\n%s\nwant:\n%s\ndiff:\n%s", gotYAML, tt.want, util.YAMLDiff(string(gotYAML), tt.want))
+			}
+		})
+	}
+}
@@ -15,9 +15,14 @@
 package clog
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
 
 	"istio.io/pkg/log"
 )
@@ -84,72 +89,161 @@ func (l *DefaultLogger) Print(s string) {
 func (l *DefaultLogger) PrintErr(s string) {
 }
 
-//ConsoleLogger is the struct used for mesh command
+// Level is the severity of a message logged by a ConsoleLogger.
+type Level string
+
+const (
+	// InfoLevel is the level of messages logged with LogAndPrint and LogAndPrintf.
+	InfoLevel Level = "info"
+	// ErrorLevel is the level of messages logged with LogAndError and LogAndErrorf.
+	ErrorLevel Level = "error"
+	// FatalLevel is the level of messages logged with LogAndFatal and LogAndFatalf.
+	FatalLevel Level = "fatal"
+)
+
+// Format is the output format of messages logged by a ConsoleLogger.
+type Format string
+
+const (
+	// TextFormat writes each message as a line of plain text.
+	TextFormat Format = "text"
+	// JSONFormat writes each message as a JSON object on a single line, with the time, level, message and any fields
+	// added with WithField.
+	JSONFormat Format = "json"
+)
+
+// ParseFormat returns the Format with the given name. An empty name is TextFormat.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case "":
+		return TextFormat, nil
+	case TextFormat, JSONFormat:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown log format %q, must be one of %s, %s", s, TextFormat, JSONFormat)
+}
+
+const (
+	ansiGreen = "\x1b[32m"
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+var (
+	// coloredGlyphs colors the status glyphs of messages written to a terminal.
+	coloredGlyphs = strings.NewReplacer("✔", ansiGreen+"✔"+ansiReset, "✘", ansiRed+"✘"+ansiReset)
+	// plainGlyphs replaces the status glyphs of messages with ASCII, for logs stored in systems which mangle unicode.
+	plainGlyphs = strings.NewReplacer("✔", "[OK]", "✘", "[X]")
+)
+
+// ConsoleLogger is the struct used for mesh command
 type ConsoleLogger struct {
 	logToStdErr bool
 	stdOut      io.Writer
 	stdErr      io.Writer
+	format      Format
+	// quiet drops the messages at InfoLevel.
+	quiet bool
+	// color colors the status glyphs of the messages in TextFormat written to stdOut and stdErr if they are terminals,
+	// as set in terminalOut and terminalErr.
+	color       bool
+	terminalOut bool
+	terminalErr bool
+	// plain replaces the status glyphs with ASCII and disables colors.
+	plain bool
+	// fields are added to every message in JSONFormat.
+	fields map[string]string
 }
 
 // NewConsoleLogger creates a new logger and returns a pointer to it.
-// stdOut and stdErr can be used to capture output for testing.
+// stdOut and stdErr can be used to capture output for testing. Messages written to a terminal are colored unless the
+// NO_COLOR environment variable is set.
 func NewConsoleLogger(logToStdErr bool, stdOut, stdErr io.Writer) *ConsoleLogger {
 	return &ConsoleLogger{
 		logToStdErr: logToStdErr,
 		stdOut:      stdOut,
 		stdErr:      stdErr,
+		format:      TextFormat,
+		color:       os.Getenv("NO_COLOR") == "",
+		terminalOut: isTerminal(stdOut),
+		terminalErr: isTerminal(stdErr),
 	}
 }
 
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// SetFormat sets the format of messages logged by l. Output written with Print and PrintErr, like manifests, is not
+// affected.
+func (l *ConsoleLogger) SetFormat(format Format) {
+	l.format = format
+}
+
+// SetQuiet sets whether l drops the messages at InfoLevel, like progress messages, keeping only errors. Output
+// written with Print and PrintErr, like manifests and JSON results, is not affected.
+func (l *ConsoleLogger) SetQuiet(quiet bool) {
+	l.quiet = quiet
+}
+
+// Quiet reports whether l drops the messages at InfoLevel.
+func (l *ConsoleLogger) Quiet() bool {
+	return l.quiet
+}
+
+// SetColor sets whether l colors the ✔ and ✘ status glyphs of its messages. Colors are only used in TextFormat, for
+// output which is a terminal.
+func (l *ConsoleLogger) SetColor(color bool) {
+	l.color = color
+}
+
+// SetPlain sets whether l replaces the ✔ and ✘ status glyphs of its messages with [OK] and [X], without colors.
+func (l *ConsoleLogger) SetPlain(plain bool) {
+	l.plain = plain
+}
+
+// WithField returns a copy of l which adds the given key and value to every message in JSONFormat.
+func (l *ConsoleLogger) WithField(key, value string) *ConsoleLogger {
+	out := *l
+	out.fields = make(map[string]string, len(l.fields)+1)
+	for k, v := range l.fields {
+		out.fields[k] = v
+	}
+	out.fields[key] = value
+	return &out
+}
+
 func (l *ConsoleLogger) LogAndPrint(v ...interface{}) {
 	if len(v) == 0 {
 		return
 	}
-	s := fmt.Sprint(v...)
-	if !l.logToStdErr {
-		l.Print(s + "\n")
-	} else {
-		log.Infof(s)
-	}
+	l.logMessage(InfoLevel, fmt.Sprint(v...))
 }
 
 func (l *ConsoleLogger) LogAndError(v ...interface{}) {
 	if len(v) == 0 {
 		return
 	}
-	s := fmt.Sprint(v...)
-	if !l.logToStdErr {
-		l.PrintErr(s + "\n")
-	} else {
-		log.Infof(s)
-	}
+	l.logMessage(ErrorLevel, fmt.Sprint(v...))
 }
 
 func (l *ConsoleLogger) LogAndFatal(a ...interface{}) {
-	l.LogAndError(a...)
+	l.logMessage(FatalLevel, fmt.Sprint(a...))
 	os.Exit(-1)
 }
 
 func (l *ConsoleLogger) LogAndPrintf(format string, a ...interface{}) {
-	s := fmt.Sprintf(format, a...)
-	if !l.logToStdErr {
-		l.Print(s + "\n")
-	} else {
-		log.Infof(s)
-	}
+	l.logMessage(InfoLevel, fmt.Sprintf(format, a...))
 }
 
 func (l *ConsoleLogger) LogAndErrorf(format string, a ...interface{}) {
-	s := fmt.Sprintf(format, a...)
-	if !l.logToStdErr {
-		l.PrintErr(s + "\n")
-	} else {
-		log.Infof(s)
-	}
+	l.logMessage(ErrorLevel, fmt.Sprintf(format, a...))
 }
 
 func (l *ConsoleLogger) LogAndFatalf(format string, a ...interface{}) {
-	l.LogAndErrorf(format, a...)
+	l.logMessage(FatalLevel, fmt.Sprintf(format, a...))
 	os.Exit(-1)
 }
 
@@ -160,3 +254,81 @@ func (l *ConsoleLogger) Print(s string) {
 func (l *ConsoleLogger) PrintErr(s string) {
 	_, _ = l.stdErr.Write([]byte(s))
 }
+
+// logMessage writes s at the given level to the istio log if logToStdErr is set, and otherwise to stdOut for
+// InfoLevel or stdErr for higher levels, in the format of l. Messages at InfoLevel are dropped if l is quiet.
+func (l *ConsoleLogger) logMessage(level Level, s string) {
+	if l.quiet && level == InfoLevel {
+		return
+	}
+	if l.plain {
+		s = plainGlyphs.Replace(s)
+	}
+	if l.logToStdErr {
+		if level == InfoLevel {
+			log.Infof(s)
+		} else {
+			log.Errorf(s)
+		}
+		return
+	}
+	if l.format == JSONFormat {
+		s = l.jsonMessage(level, s)
+	} else if l.color && !l.plain && (level == InfoLevel && l.terminalOut || level != InfoLevel && l.terminalErr) {
+		s = coloredGlyphs.Replace(s)
+	}
+	if level == InfoLevel {
+		l.Print(s + "\n")
+	} else {
+		l.PrintErr(s + "\n")
+	}
+}
+
+// jsonMessage returns s at the given level as a JSON object.
+func (l *ConsoleLogger) jsonMessage(level Level, s string) string {
+	entry := make(map[string]string, len(l.fields)+3)
+	for k, v := range l.fields {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = string(level)
+	entry["msg"] = s
+	b, err := json.Marshal(entry)
+	if err != nil {
+		// Marshaling a map of strings can't fail.
+		return s
+	}
+	return string(b)
+}
+
+// WithField returns a copy of l which adds the given key and value to its messages if l supports fields, like a
+// ConsoleLogger in JSONFormat. Otherwise l is returned.
+func WithField(l Logger, key, value string) Logger {
+	if cl, ok := l.(*ConsoleLogger); ok {
+		return cl.WithField(key, value)
+	}
+	return l
+}
+
+// Quiet reports whether l is a ConsoleLogger which drops the messages at InfoLevel, in which case other console
+// output, like progress bars, should be left out too.
+func Quiet(l Logger) bool {
+	cl, ok := l.(*ConsoleLogger)
+	return ok && cl.Quiet()
+}
+
+// printWriter is an io.Writer which writes to the Print output of a Logger.
+type printWriter struct {
+	l Logger
+}
+
+// NewPrintWriter returns an io.Writer which writes to the Print output of l, for streaming large outputs like
+// manifests without first building them as a string.
+func NewPrintWriter(l Logger) io.Writer {
+	return &printWriter{l: l}
+}
+
+func (w *printWriter) Write(p []byte) (int, error) {
+	w.l.Print(string(p))
+	return len(p), nil
+}
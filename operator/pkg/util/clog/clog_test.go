@@ -0,0 +1,132 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestConsoleLoggerJSONFormat(t *testing.T) {
+	var stdOut, stdErr bytes.Buffer
+	l := NewConsoleLogger(false, &stdOut, &stdErr)
+	l.SetFormat(JSONFormat)
+	l = l.WithField("reconcileID", "abc")
+
+	l.LogAndPrintf("installing %s", "Pilot")
+	l.LogAndError("failed")
+	l.Print("raw\n")
+
+	var entry map[string]string
+	if err := json.Unmarshal(bytes.TrimSuffix(stdErr.Bytes(), []byte("\n")), &entry); err != nil {
+		t.Fatalf("stderr is not a JSON object: %s\n%s", err, stdErr.String())
+	}
+	if entry["level"] != "error" || entry["msg"] != "failed" || entry["reconcileID"] != "abc" || entry["time"] == "" {
+		t.Errorf("unexpected error entry: %v", entry)
+	}
+
+	lines := bytes.Split(stdOut.Bytes(), []byte("\n"))
+	if len(lines) != 3 || string(lines[1]) != "raw" {
+		t.Fatalf("unexpected stdout:\n%s", stdOut.String())
+	}
+	entry = nil
+	if err := json.Unmarshal(lines[0], &entry); err != nil {
+		t.Fatalf("stdout line is not a JSON object: %s\n%s", err, lines[0])
+	}
+	if entry["level"] != "info" || entry["msg"] != "installing Pilot" {
+		t.Errorf("unexpected info entry: %v", entry)
+	}
+}
+
+func TestConsoleLoggerQuiet(t *testing.T) {
+	var stdOut, stdErr bytes.Buffer
+	l := NewConsoleLogger(false, &stdOut, &stdErr)
+	l.SetQuiet(true)
+
+	l.LogAndPrint("Waiting for resources to become ready...")
+	l.LogAndPrintf("installing %s", "Pilot")
+	l.LogAndErrorf("failed %s", "Pilot")
+	l.Print("{\"status\": \"HEALTHY\"}\n")
+
+	if got, want := stdOut.String(), "{\"status\": \"HEALTHY\"}\n"; got != want {
+		t.Errorf("got stdout %q, want %q", got, want)
+	}
+	if got, want := stdErr.String(), "failed Pilot\n"; got != want {
+		t.Errorf("got stderr %q, want %q", got, want)
+	}
+	if !Quiet(l) || Quiet(NewDefaultLogger()) {
+		t.Errorf("Quiet() got %v for the quiet ConsoleLogger and %v for the DefaultLogger", Quiet(l), Quiet(NewDefaultLogger()))
+	}
+}
+
+func TestConsoleLoggerGlyphs(t *testing.T) {
+	tests := []struct {
+		desc     string
+		terminal bool
+		noColor  bool
+		plain    bool
+		want     string
+	}{
+		{
+			desc: "not a terminal",
+			want: "✔ Istiod installed\n✘ Ingress gateways encountered an error\n",
+		},
+		{
+			desc:     "terminal",
+			terminal: true,
+			want:     "\x1b[32m✔\x1b[0m Istiod installed\n\x1b[31m✘\x1b[0m Ingress gateways encountered an error\n",
+		},
+		{
+			desc:     "terminal with colors disabled",
+			terminal: true,
+			noColor:  true,
+			want:     "✔ Istiod installed\n✘ Ingress gateways encountered an error\n",
+		},
+		{
+			desc:     "plain",
+			terminal: true,
+			plain:    true,
+			want:     "[OK] Istiod installed\n[X] Ingress gateways encountered an error\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdOut bytes.Buffer
+			l := NewConsoleLogger(false, &stdOut, &stdOut)
+			l.terminalOut, l.terminalErr = tt.terminal, tt.terminal
+			l.SetColor(!tt.noColor)
+			l.SetPlain(tt.plain)
+
+			l.LogAndPrint("✔ Istiod installed")
+			l.LogAndError("✘ Ingress gateways encountered an error")
+
+			if got := stdOut.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": TextFormat, "text": TextFormat, "json": JSONFormat} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q): got %v, %v, want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml): expected error")
+	}
+}
@@ -19,18 +19,24 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"istio.io/pkg/log"
 
 	"istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/platform"
+	"istio.io/istio/operator/pkg/sizing"
 	"istio.io/istio/operator/pkg/util"
 )
 
 var (
 	scope = log.RegisterScope("validation", "API validation", 0)
 
+	// imageVariants are the published Istio image variants, selected with values.global.imageVariant.
+	imageVariants = map[string]bool{"distroless": true, "debug": true, "fips": true}
+
 	// alphaNumericRegexp defines the alpha numeric atom, typically a
 	// component of names. This only allows lower case characters and digits.
 	alphaNumericRegexp = match(`[a-z0-9]+`)
@@ -84,6 +90,26 @@ var (
 
 	// ObjectNameRegexp is a legal name for a k8s object.
 	ObjectNameRegexp = match(`[a-z0-9.-]{1,254}`)
+
+	// RevisionRegexp is a legal control plane revision, a DNS label.
+	RevisionRegexp = match(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`)
+
+	// hostRegexp matches DNS names, which are domains without a port.
+	hostRegexp = anchored(domainComponentRegexp, optional(repeated(literal(`.`), domainComponentRegexp)))
+
+	// mtlsModes are the modes of the mesh-wide PeerAuthentication, selected with values.global.mtls.mode.
+	mtlsModes = map[string]bool{"STRICT": true, "PERMISSIVE": true, "DISABLE": true}
+
+	// builtinInjectionTemplates are the named injection templates of the istiod chart.
+	builtinInjectionTemplates = map[string]bool{"gateway": true}
+)
+
+const (
+	// defaultRevision is the revision label value of the control plane installed without a revision.
+	defaultRevision = "default"
+	// maxRevisionLength keeps istiod-<revision>, the name of the istiod Service, within the 63 characters of a
+	// DNS label.
+	maxRevisionLength = 63 - len("istiod-")
 )
 
 // validateWithRegex checks whether the given value matches the regexp r.
@@ -133,6 +159,103 @@ func validatePortNumberString(path util.Path, val interface{}) util.Errors {
 	return validatePortNumber(path, intV)
 }
 
+// validateImageVariant checks that val is one of the published Istio image variants.
+func validateImageVariant(path util.Path, val interface{}) util.Errors {
+	scope.Debugf("validateImageVariant %v:", val)
+	if !util.IsString(val) {
+		return util.NewErrs(fmt.Errorf("validateImageVariant(%s) bad type %T, want string", path, val))
+	}
+	if v := val.(string); v != "" && !imageVariants[v] {
+		return util.NewErrs(fmt.Errorf("%s: unknown image variant %s, must be one of distroless, debug or fips", path, v))
+	}
+	return nil
+}
+
+// validatePlatform checks that val is one of the platforms with platform specific defaults.
+func validatePlatform(path util.Path, val interface{}) util.Errors {
+	scope.Debugf("validatePlatform %v:", val)
+	if !util.IsString(val) {
+		return util.NewErrs(fmt.Errorf("validatePlatform(%s) bad type %T, want string", path, val))
+	}
+	if v := val.(string); v != "" && !platform.Platforms[v] {
+		return util.NewErrs(fmt.Errorf("%s: unknown platform %s, must be one of gke, eks, aks or openshift", path, v))
+	}
+	return nil
+}
+
+// validateMTLSMode checks that val is one of the mTLS modes of a PeerAuthentication.
+func validateMTLSMode(path util.Path, val interface{}) util.Errors {
+	scope.Debugf("validateMTLSMode %v:", val)
+	if !util.IsString(val) {
+		return util.NewErrs(fmt.Errorf("validateMTLSMode(%s) bad type %T, want string", path, val))
+	}
+	if v := val.(string); v != "" && !mtlsModes[v] {
+		return util.NewErrs(fmt.Errorf("%s: unknown mTLS mode %s, must be one of STRICT, PERMISSIVE or DISABLE", path, v))
+	}
+	return nil
+}
+
+// validateSizing checks that val is one of the sizing presets.
+func validateSizing(path util.Path, val interface{}) util.Errors {
+	scope.Debugf("validateSizing %v:", val)
+	if !util.IsString(val) {
+		return util.NewErrs(fmt.Errorf("validateSizing(%s) bad type %T, want string", path, val))
+	}
+	if v := val.(string); v != "" && !sizing.Presets[v] {
+		return util.NewErrs(fmt.Errorf("%s: unknown sizing %s, must be one of small, medium, large or custom", path, v))
+	}
+	return nil
+}
+
+// validateEgressHosts checks that val is a list of DNS names, optionally with a leading wildcard label, which the
+// baseline ServiceEntry of a locked down install allows egress traffic to.
+func validateEgressHosts(path util.Path, val interface{}) (errs util.Errors) {
+	scope.Debugf("validateEgressHosts %v:", val)
+	if val == nil {
+		return nil
+	}
+	hosts, ok := val.([]interface{})
+	if !ok {
+		return util.NewErrs(fmt.Errorf("validateEgressHosts(%s) bad type %T, want list", path, val))
+	}
+	for i, h := range hosts {
+		host, _ := h.(string)
+		domain := strings.TrimPrefix(host, "*.")
+		if !hostRegexp.MatchString(domain) {
+			errs = util.AppendErr(errs, fmt.Errorf("%s[%d]: invalid host %v, must be a DNS name like example.com or *.example.com", path, i, h))
+		}
+	}
+	return errs
+}
+
+// validateInjectionTemplates checks that val maps DNS label names, which pods select the templates with, to
+// non-empty templates.
+func validateInjectionTemplates(path util.Path, val interface{}) util.Errors {
+	scope.Debugf("validateInjectionTemplates %v:", val)
+	if val == nil {
+		return nil
+	}
+	templates, ok := val.(map[string]interface{})
+	if !ok {
+		return util.NewErrs(fmt.Errorf("validateInjectionTemplates(%s) bad type %T, want map", path, val))
+	}
+	var names []string
+	for n := range templates {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var errs util.Errors
+	for _, n := range names {
+		if !anchored(RevisionRegexp).MatchString(n) {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: invalid template name %s, must be a DNS label", path, n))
+		}
+		if t, ok := templates[n].(string); !ok || strings.TrimSpace(t) == "" {
+			errs = util.AppendErr(errs, fmt.Errorf("%s.%s: template must be a non-empty string", path, n))
+		}
+	}
+	return errs
+}
+
 // validatePortNumber checks whether val is an integer representing a valid port number.
 func validatePortNumber(path util.Path, val interface{}) util.Errors {
 	return validateIntRange(path, val, 0, 65535)
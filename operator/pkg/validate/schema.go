@@ -0,0 +1,111 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubectl/pkg/util/openapi"
+	openapivalidation "k8s.io/kubectl/pkg/util/openapi/validation"
+
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+	"istio.io/istio/operator/pkg/util"
+)
+
+// SchemaValidator validates rendered K8s objects against Kubernetes OpenAPI schemas. Objects with kinds that are not
+// in the schemas, like CRs of CRDs which are not installed yet, are not validated.
+type SchemaValidator struct {
+	schema *openapivalidation.SchemaValidation
+}
+
+// NewClusterSchemaValidator returns a SchemaValidator using the OpenAPI schemas served by the cluster at restConfig.
+func NewClusterSchemaValidator(restConfig *rest.Config) (*SchemaValidator, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := openapi.NewOpenAPIGetter(dc).Get()
+	if err != nil {
+		return nil, fmt.Errorf("could not get OpenAPI schemas from the cluster: %s", err)
+	}
+	return &SchemaValidator{schema: openapivalidation.NewSchemaValidation(resources)}, nil
+}
+
+// NewFileSchemaValidator returns a SchemaValidator using the OpenAPI v2 document at path, in JSON or YAML format, as
+// served by the API server at /openapi/v2. It is used to validate manifests without access to a cluster.
+func NewFileSchemaValidator(path string) (*SchemaValidator, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(path, b)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse OpenAPI document %s: %s", path, err)
+	}
+	doc, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse OpenAPI document %s: %s", path, err)
+	}
+	resources, err := openapi.NewOpenAPIData(doc)
+	if err != nil {
+		return nil, fmt.Errorf("could not read OpenAPI schemas from %s: %s", path, err)
+	}
+	return &SchemaValidator{schema: openapivalidation.NewSchemaValidation(resources)}, nil
+}
+
+// ValidateManifests validates every object in manifests and returns an error for each invalid one, identified by
+// component and object hash.
+func (v *SchemaValidator) ValidateManifests(manifests name.ManifestMap) util.Errors {
+	var components []string
+	for c := range manifests {
+		components = append(components, string(c))
+	}
+	sort.Strings(components)
+
+	var errs util.Errors
+	for _, c := range components {
+		for _, m := range manifests[name.ComponentName(c)] {
+			objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+			if err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: %s", c, err))
+				continue
+			}
+			errs = util.AppendErrs(errs, v.validateObjects(c, objs))
+		}
+	}
+	return errs
+}
+
+func (v *SchemaValidator) validateObjects(component string, objs object.K8sObjects) util.Errors {
+	var errs util.Errors
+	for _, o := range objs {
+		y, err := o.YAML()
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s %s: %s", component, o.Hash(), err))
+			continue
+		}
+		if err := v.schema.ValidateBytes(y); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s %s: %s", component, o.Hash(), err))
+		}
+	}
+	return errs
+}
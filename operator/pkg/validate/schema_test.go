@@ -0,0 +1,103 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"istio.io/istio/operator/pkg/name"
+)
+
+func TestSchemaValidatorValidateManifests(t *testing.T) {
+	v, err := NewFileSchemaValidator("testdata/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			desc: "valid",
+			yaml: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+spec:
+  type: LoadBalancer
+  ports:
+  - name: http2
+    port: 80
+`,
+		},
+		{
+			desc: "unknown field",
+			yaml: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+spec:
+  ports:
+  - name: http2
+    prot: 80
+`,
+			wantErr: "prot",
+		},
+		{
+			desc: "wrong type",
+			yaml: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+spec:
+  ports:
+  - name: http2
+    port: http
+`,
+			wantErr: "port",
+		},
+		{
+			desc: "kind without schema",
+			yaml: `
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: ingressgateway
+  namespace: istio-system
+spec:
+  anything: goes
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := v.ValidateManifests(name.ManifestMap{name.IngressComponentName: {tt.yaml}})
+			switch {
+			case tt.wantErr == "" && len(errs) != 0:
+				t.Errorf("got errors %s, want none", errs)
+			case tt.wantErr != "" && !strings.Contains(errs.String(), tt.wantErr):
+				t.Errorf("got errors %q, want error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,13 +17,20 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/ghodss/yaml"
 
 	"istio.io/api/operator/v1alpha1"
 
 	operator_v1alpha1 "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/ipfamily"
 	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/nodeos"
+	"istio.io/istio/operator/pkg/podsecurity"
+	"istio.io/istio/operator/pkg/tpath"
+	"istio.io/istio/operator/pkg/translate"
 	"istio.io/istio/operator/pkg/util"
 	"istio.io/istio/pkg/config/mesh"
 )
@@ -37,6 +44,7 @@ var (
 		"MeshConfig":                         validateMeshConfig,
 		"Hub":                                validateHub,
 		"Tag":                                validateTag,
+		"Revision":                           validateRevision,
 		"AddonComponents":                    validateAddonComponents,
 		"Components.IngressGateways[*].Name": validateGatewayName,
 		"Components.EgressGateways[*].Name":  validateGatewayName,
@@ -63,9 +71,127 @@ func CheckIstioOperatorSpec(is *v1alpha1.IstioOperatorSpec, checkRequiredFields
 		return util.Errors{}
 	}
 
+	errs = util.AppendErrs(errs, validatePodSecurity(is))
+	errs = util.AppendErrs(errs, validateIPFamilies(is))
+	errs = util.AppendErrs(errs, validateNodeOS(is))
+	errs = util.AppendErrs(errs, validateInjectionTemplateRefs(is))
 	return util.AppendErrs(errs, Validate(DefaultValidations, is, nil, checkRequiredFields))
 }
 
+// validatePodSecurity checks that settings which make Istio pods need NET_ADMIN or privileged containers are not
+// enabled together with values.global.podSecurity.restricted.
+func validatePodSecurity(is *v1alpha1.IstioOperatorSpec) (errs util.Errors) {
+	if restricted, _ := podsecurity.Settings(is.Values); !restricted {
+		return nil
+	}
+	cniEnabled, err := translate.IsComponentEnabledInSpec(name.CNIComponentName, is)
+	if err != nil {
+		return util.NewErrs(err)
+	}
+	if v, found, _ := tpath.GetFromTreePath(is.Values, util.PathFromString("istio_cni.enabled")); found && v == true {
+		cniEnabled = true
+	}
+	if !cniEnabled {
+		errs = util.AppendErr(errs, fmt.Errorf("values.global.podSecurity.restricted requires components.cni.enabled, "+
+			"since sidecar traffic redirection needs NET_ADMIN otherwise"))
+	}
+	for _, p := range []string{"global.proxy.privileged", "global.proxy.enableCoreDump"} {
+		if v, found, _ := tpath.GetFromTreePath(is.Values, util.PathFromString(p)); found && v == true {
+			errs = util.AppendErr(errs, fmt.Errorf("values.%s needs privileged containers and cannot be used with "+
+				"values.global.podSecurity.restricted", p))
+		}
+	}
+	return errs
+}
+
+// validateIPFamilies checks values.global.ipFamilies and values.global.ipFamilyPolicy.
+func validateIPFamilies(is *v1alpha1.IstioOperatorSpec) (errs util.Errors) {
+	families, policy := ipfamily.Settings(is.Values)
+	seen := make(map[string]bool)
+	for _, f := range families {
+		if f != ipfamily.IPv4 && f != ipfamily.IPv6 {
+			errs = util.AppendErr(errs, fmt.Errorf("values.global.ipFamilies: unknown IP family %s, must be IPv4 or IPv6", f))
+		}
+		if seen[f] {
+			errs = util.AppendErr(errs, fmt.Errorf("values.global.ipFamilies: IP family %s is listed more than once", f))
+		}
+		seen[f] = true
+	}
+	if policy == "" {
+		return errs
+	}
+	switch {
+	case !ipfamily.Policies[policy]:
+		errs = util.AppendErr(errs, fmt.Errorf("values.global.ipFamilyPolicy: unknown policy %s, must be one of %s, %s or %s",
+			policy, ipfamily.SingleStack, ipfamily.PreferDualStack, ipfamily.RequireDualStack))
+	case policy == ipfamily.SingleStack && len(families) > 1:
+		errs = util.AppendErr(errs, fmt.Errorf("values.global.ipFamilyPolicy %s needs exactly one IP family, got %s",
+			policy, strings.Join(families, ",")))
+	case policy == ipfamily.RequireDualStack && len(families) == 1:
+		errs = util.AppendErr(errs, fmt.Errorf("values.global.ipFamilyPolicy %s needs two IP families, got %s",
+			policy, strings.Join(families, ",")))
+	}
+	return errs
+}
+
+// validateNodeOS checks values.global.nodeOS.
+func validateNodeOS(is *v1alpha1.IstioOperatorSpec) (errs util.Errors) {
+	for _, os := range nodeos.Settings(is.Values) {
+		if !nodeos.OperatingSystems[os] {
+			errs = util.AppendErr(errs, fmt.Errorf("values.global.nodeOS: unknown operating system %s, must be %s or %s",
+				os, nodeos.Linux, nodeos.Windows))
+		}
+	}
+	return errs
+}
+
+// validateInjectionTemplateRefs checks that the injection templates gateways are rendered for, through
+// values.gateways.*.injectionTemplate or the inject.istio.io/templates pod annotation, are built in or defined in
+// values.sidecarInjectorWebhook.templates.
+func validateInjectionTemplateRefs(is *v1alpha1.IstioOperatorSpec) (errs util.Errors) {
+	known := make(map[string]bool)
+	for n := range builtinInjectionTemplates {
+		known[n] = true
+	}
+	if templates, found, _ := tpath.GetFromTreePath(is.Values, util.PathFromString("sidecarInjectorWebhook.templates")); found {
+		if tm, ok := templates.(map[string]interface{}); ok {
+			for n := range tm {
+				known[n] = true
+			}
+		}
+	}
+	refs := make(map[string]string)
+	for _, gw := range []string{"istio-ingressgateway", "istio-egressgateway"} {
+		p := "gateways." + gw + ".injectionTemplate"
+		if v, found, _ := tpath.GetFromTreePath(is.Values, util.PathFromString(p)); found && v != nil && v != "" {
+			refs["values."+p] = fmt.Sprint(v)
+		}
+	}
+	gateways := append(append([]*v1alpha1.GatewaySpec{}, is.GetComponents().GetIngressGateways()...), is.GetComponents().GetEgressGateways()...)
+	for _, gw := range gateways {
+		if v, ok := gw.GetK8S().GetPodAnnotations()[translate.InjectTemplatesAnnotation]; ok {
+			refs[fmt.Sprintf("gateway %s pod annotation %s", gw.GetName(), translate.InjectTemplatesAnnotation)] = v
+		}
+	}
+	for _, k := range sortedKeys(refs) {
+		if !known[refs[k]] {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: unknown injection template %s, must be built in or defined in "+
+				"values.sidecarInjectorWebhook.templates", k, refs[k]))
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
 // Validate function below is used by third party for integrations and has to be public
 
 // Validate validates the values of the tree using the supplied Func.
@@ -181,6 +307,27 @@ func validateTag(path util.Path, val interface{}) util.Errors {
 	return validateWithRegex(path, val, TagRegexp)
 }
 
+// validateRevision checks that the revision can be used as a label value and as the suffix of the istiod resource
+// names. The revision default is the label value of the control plane installed without a revision.
+func validateRevision(path util.Path, val interface{}) util.Errors {
+	rev, ok := val.(string)
+	if !ok {
+		return util.NewErrs(fmt.Errorf("validateRevision(%s) bad type %T, want string", path, val))
+	}
+	switch {
+	case rev == "":
+		return nil
+	case rev == defaultRevision:
+		return util.NewErrs(fmt.Errorf("%s: revision %s is reserved for the control plane installed without a revision", path, rev))
+	case len(rev) > maxRevisionLength:
+		return util.NewErrs(fmt.Errorf("%s: revision %s is longer than %d characters", path, rev, maxRevisionLength))
+	case len(RevisionRegexp.FindString(rev)) != len(rev):
+		return util.NewErrs(fmt.Errorf("%s: invalid revision %s, must consist of lower case alphanumeric characters or '-', "+
+			"and start and end with an alphanumeric character, e.g. 1-7-0", path, rev))
+	}
+	return nil
+}
+
 func validateAddonComponents(path util.Path, val interface{}) util.Errors {
 	valMap, ok := val.(map[string]*v1alpha1.ExternalComponentSpec)
 	if !ok {
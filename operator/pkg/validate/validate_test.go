@@ -123,6 +123,27 @@ hub: docker.io:tag/istio
 `,
 			wantErrs: makeErrors([]string{`invalid value Hub: docker.io:tag/istio`}),
 		},
+		{
+			desc: "GoodRevision",
+			yamlStr: `
+revision: 1-7-0
+`,
+		},
+		{
+			desc: "BadRevision",
+			yamlStr: `
+revision: "1.7"
+`,
+			wantErrs: makeErrors([]string{`Revision: invalid revision 1.7, must consist of lower case alphanumeric characters ` +
+				`or '-', and start and end with an alphanumeric character, e.g. 1-7-0`}),
+		},
+		{
+			desc: "DefaultRevision",
+			yamlStr: `
+revision: default
+`,
+			wantErrs: makeErrors([]string{`Revision: revision default is reserved for the control plane installed without a revision`}),
+		},
 		{
 			desc: "BadAddonComponentName",
 			yamlStr: `
@@ -189,6 +210,105 @@ meshConfig:
     discoveryAddress: istiod:15012
 `,
 		},
+		{
+			desc: "Restricted pod security with CNI",
+			yamlStr: `
+components:
+  cni:
+    enabled: true
+values:
+  global:
+    podSecurity:
+      restricted: true
+`,
+		},
+		{
+			desc: "Restricted pod security without CNI",
+			yamlStr: `
+values:
+  global:
+    proxy:
+      enableCoreDump: true
+    podSecurity:
+      restricted: true
+`,
+			wantErrs: makeErrors([]string{"values.global.podSecurity.restricted requires components.cni.enabled, " +
+				"since sidecar traffic redirection needs NET_ADMIN otherwise",
+				"values.global.proxy.enableCoreDump needs privileged containers and cannot be used with " +
+					"values.global.podSecurity.restricted"}),
+		},
+		{
+			desc: "Dual-stack IP families",
+			yamlStr: `
+values:
+  global:
+    ipFamilies: [IPv6, IPv4]
+    ipFamilyPolicy: RequireDualStack
+`,
+		},
+		{
+			desc: "Bad IP families",
+			yamlStr: `
+values:
+  global:
+    ipFamilies: [IPv6, IPv5]
+    ipFamilyPolicy: SingleStack
+`,
+			wantErrs: makeErrors([]string{"values.global.ipFamilies: unknown IP family IPv5, must be IPv4 or IPv6",
+				"values.global.ipFamilyPolicy SingleStack needs exactly one IP family, got IPv6,IPv5"}),
+		},
+		{
+			desc: "Bad node OS",
+			yamlStr: `
+values:
+  global:
+    nodeOS: [linux, darwin]
+`,
+			wantErrs: makeErrors([]string{"values.global.nodeOS: unknown operating system darwin, must be linux or windows"}),
+		},
+		{
+			desc: "Custom injection templates",
+			yamlStr: `
+components:
+  ingressGateways:
+  - name: istio-ingressgateway
+    enabled: true
+    k8s:
+      podAnnotations:
+        inject.istio.io/templates: custom
+values:
+  gateways:
+    istio-egressgateway:
+      injectionTemplate: gateway
+  sidecarInjectorWebhook:
+    templates:
+      custom: |
+        containers:
+        - name: istio-proxy
+`,
+		},
+		{
+			desc: "Unknown injection templates",
+			yamlStr: `
+components:
+  ingressGateways:
+  - name: istio-ingressgateway
+    enabled: true
+    k8s:
+      podAnnotations:
+        inject.istio.io/templates: missing
+values:
+  gateways:
+    istio-egressgateway:
+      injectionTemplate: other
+`,
+			wantErrs: makeErrors([]string{
+				"gateway istio-ingressgateway pod annotation inject.istio.io/templates: unknown injection template missing, " +
+					"must be built in or defined in values.sidecarInjectorWebhook.templates",
+				"values.gateways.istio-egressgateway.injectionTemplate: unknown injection template other, " +
+					"must be built in or defined in values.sidecarInjectorWebhook.templates",
+			}),
+		},
 	}
 	if err := name.ScanBundledAddonComponents("../../cmd/mesh/testdata/manifest-generate/data-snapshot"); err != nil {
 		t.Fatal(err)
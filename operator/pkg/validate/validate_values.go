@@ -28,10 +28,16 @@ import (
 var (
 	// DefaultValuesValidations maps a data path to a validation function.
 	DefaultValuesValidations = map[string]ValidatorFunc{
-		"global.proxy.includeIPRanges":     validateIPRangesOrStar,
-		"global.proxy.excludeIPRanges":     validateIPRangesOrStar,
-		"global.proxy.includeInboundPorts": validateStringList(validatePortNumberString),
-		"global.proxy.excludeInboundPorts": validateStringList(validatePortNumberString),
+		"global.proxy.includeIPRanges":       validateIPRangesOrStar,
+		"global.proxy.excludeIPRanges":       validateIPRangesOrStar,
+		"global.proxy.includeInboundPorts":   validateStringList(validatePortNumberString),
+		"global.proxy.excludeInboundPorts":   validateStringList(validatePortNumberString),
+		"global.imageVariant":                validateImageVariant,
+		"global.platform":                    validatePlatform,
+		"global.mtls.mode":                   validateMTLSMode,
+		"global.sizing":                      validateSizing,
+		"global.egressLockdown.allowedHosts": validateEgressHosts,
+		"sidecarInjectorWebhook.templates":   validateInjectionTemplates,
 	}
 )
 
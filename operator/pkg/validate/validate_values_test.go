@@ -84,6 +84,17 @@ cni:
   cniConfDir: "/var/run/multus/cni/net.d"
 `,
 		},
+		{
+			desc: "GatewayHubTag",
+			yamlStr: `
+gateways:
+  istio-ingressgateway:
+    hub: gcr.io/istio-release
+    tag: 1.6.0
+  istio-egressgateway:
+    tag: 1.6.0
+`,
+		},
 
 		{
 			desc: "BadIPRange",
@@ -135,6 +146,88 @@ global:
 `,
 			wantErrs: makeErrors([]string{`global.proxy.excludeInboundPorts : strconv.ParseInt: parsing "222x": invalid syntax`}),
 		},
+		{
+			desc: "MTLSMode",
+			yamlStr: `
+global:
+  mtls:
+    mode: STRICT
+`,
+		},
+		{
+			desc: "BadMTLSMode",
+			yamlStr: `
+global:
+  mtls:
+    mode: strict
+`,
+			wantErrs: makeErrors([]string{`global.mtls.mode: unknown mTLS mode strict, must be one of STRICT, PERMISSIVE or DISABLE`}),
+		},
+		{
+			desc: "Sizing",
+			yamlStr: `
+global:
+  sizing: large
+`,
+		},
+		{
+			desc: "BadSizing",
+			yamlStr: `
+global:
+  sizing: huge
+`,
+			wantErrs: makeErrors([]string{`global.sizing: unknown sizing huge, must be one of small, medium, large or custom`}),
+		},
+		{
+			desc: "EgressLockdownHosts",
+			yamlStr: `
+global:
+  egressLockdown:
+    enabled: true
+    allowedHosts:
+    - "*.googleapis.com"
+    - github.com
+`,
+		},
+		{
+			desc: "BadEgressLockdownHosts",
+			yamlStr: `
+global:
+  egressLockdown:
+    allowedHosts:
+    - "*"
+    - bad_host.com
+`,
+			wantErrs: makeErrors([]string{
+				`global.egressLockdown.allowedHosts[0]: invalid host *, must be a DNS name like example.com or *.example.com`,
+				`global.egressLockdown.allowedHosts[1]: invalid host bad_host.com, must be a DNS name like example.com or *.example.com`,
+			}),
+		},
+		{
+			desc: "InjectionTemplates",
+			yamlStr: `
+sidecarInjectorWebhook:
+  templates:
+    debug: |
+      containers:
+      - name: istio-proxy
+`,
+		},
+		{
+			desc: "BadInjectionTemplates",
+			yamlStr: `
+sidecarInjectorWebhook:
+  templates:
+    Debug: |
+      containers:
+      - name: istio-proxy
+    empty: ""
+`,
+			wantErrs: makeErrors([]string{
+				`sidecarInjectorWebhook.templates: invalid template name Debug, must be a DNS label`,
+				`sidecarInjectorWebhook.templates.empty: template must be a non-empty string`,
+			}),
+		},
 		{
 			desc: "unknown field",
 			yamlStr: `
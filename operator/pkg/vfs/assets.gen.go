@@ -10,6 +10,7 @@
 // profiles/demo.yaml
 // profiles/empty.yaml
 // profiles/minimal.yaml
+// profiles/openshift.yaml
 // profiles/preview.yaml
 // profiles/remote.yaml
 // translateConfig/names-1.5.yaml
@@ -1149,6 +1150,42 @@ func profilesMinimalYaml() (*asset, error) {
 	return a, nil
 }
 
+var _profilesOpenshiftYaml = []byte(`# The openshift profile configures Istio for OpenShift. The CNI plugin is installed into the Multus directories and
+# replaces the privileged istio-init container, all components run under the restricted SCC and ingress gateways are
+# exposed with Routes.
+# Application namespaces need a NetworkAttachmentDefinition named istio-cni for the injected pods to attach the plugin.
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+spec:
+  components:
+    cni:
+      enabled: true
+  values:
+    global:
+      platform: openshift
+      podSecurity:
+        restricted: true
+        openshift: true
+    sidecarInjectorWebhook:
+      injectedAnnotations:
+        k8s.v1.cni.cncf.io/networks: istio-cni
+`)
+
+func profilesOpenshiftYamlBytes() ([]byte, error) {
+	return _profilesOpenshiftYaml, nil
+}
+
+func profilesOpenshiftYaml() (*asset, error) {
+	bytes, err := profilesOpenshiftYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "profiles/openshift.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _profilesPreviewYaml = []byte(`# The preview profile contains features that are experimental.
 # This is intended to explore new features coming to Istio.
 # Stability, security, and performance are not guaranteed - use at your own risk.
@@ -2286,6 +2323,7 @@ var _bindata = map[string]func() (*asset, error){
 	"profiles/demo.yaml":                                            profilesDemoYaml,
 	"profiles/empty.yaml":                                           profilesEmptyYaml,
 	"profiles/minimal.yaml":                                         profilesMinimalYaml,
+	"profiles/openshift.yaml":                                       profilesOpenshiftYaml,
 	"profiles/preview.yaml":                                         profilesPreviewYaml,
 	"profiles/remote.yaml":                                          profilesRemoteYaml,
 	"translateConfig/names-1.5.yaml":                                translateconfigNames15Yaml,
@@ -2358,12 +2396,13 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 	}},
 	"profiles": &bintree{nil, map[string]*bintree{
-		"default.yaml": &bintree{profilesDefaultYaml, map[string]*bintree{}},
-		"demo.yaml":    &bintree{profilesDemoYaml, map[string]*bintree{}},
-		"empty.yaml":   &bintree{profilesEmptyYaml, map[string]*bintree{}},
-		"minimal.yaml": &bintree{profilesMinimalYaml, map[string]*bintree{}},
-		"preview.yaml": &bintree{profilesPreviewYaml, map[string]*bintree{}},
-		"remote.yaml":  &bintree{profilesRemoteYaml, map[string]*bintree{}},
+		"default.yaml":   &bintree{profilesDefaultYaml, map[string]*bintree{}},
+		"demo.yaml":      &bintree{profilesDemoYaml, map[string]*bintree{}},
+		"empty.yaml":     &bintree{profilesEmptyYaml, map[string]*bintree{}},
+		"minimal.yaml":   &bintree{profilesMinimalYaml, map[string]*bintree{}},
+		"openshift.yaml": &bintree{profilesOpenshiftYaml, map[string]*bintree{}},
+		"preview.yaml":   &bintree{profilesPreviewYaml, map[string]*bintree{}},
+		"remote.yaml":    &bintree{profilesRemoteYaml, map[string]*bintree{}},
 	}},
 	"translateConfig": &bintree{nil, map[string]*bintree{
 		"names-1.5.yaml":                  &bintree{translateconfigNames15Yaml, map[string]*bintree{}},
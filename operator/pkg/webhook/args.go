@@ -0,0 +1,45 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Options represents the details used to configure the admission webhook server.
+type Options struct {
+	// Enabled determines whether the IstioOperator validation and conversion webhooks are served.
+	Enabled bool
+	// Port is the port the webhook server listens on.
+	Port int
+	// CertDir is the directory containing tls.crt and tls.key for the webhook server.
+	CertDir string
+}
+
+var webhookOptions = &Options{
+	Port:    9443,
+	CertDir: "/etc/istio-operator/webhook-certs",
+}
+
+// AttachCobraFlags attaches the set of flags used to configure the admission webhook server to the given Cobra
+// command.
+func AttachCobraFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&webhookOptions.Enabled, "webhook-enabled", webhookOptions.Enabled,
+		"If set, the operator serves the validating admission and CRD conversion webhooks for IstioOperator resources.")
+	cmd.PersistentFlags().IntVar(&webhookOptions.Port, "webhook-port", webhookOptions.Port,
+		"The port the admission webhook server listens on.")
+	cmd.PersistentFlags().StringVar(&webhookOptions.CertDir, "webhook-cert-dir", webhookOptions.CertDir,
+		"The directory containing the tls.crt and tls.key files used by the admission webhook server.")
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"istio.io/istio/operator/pkg/apis/istio/conversion"
+	"istio.io/pkg/log"
+)
+
+// iopConverter is an http.Handler serving ConversionReview requests for the IstioOperator CRD.
+type iopConverter struct{}
+
+// ServeHTTP implements http.Handler.
+func (c *iopConverter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &apiextensionsv1beta1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		log.Errorf("failed to decode ConversionReview: %v", err)
+		http.Error(w, "bad ConversionReview", http.StatusBadRequest)
+		return
+	}
+	review.Response = convertReview(review.Request)
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Errorf("failed to write ConversionReview response: %s", err)
+	}
+}
+
+// convertReview converts all objects in req to the desired API version.
+func convertReview(req *apiextensionsv1beta1.ConversionRequest) *apiextensionsv1beta1.ConversionResponse {
+	resp := &apiextensionsv1beta1.ConversionResponse{UID: req.UID}
+	gv, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return conversionFailed(resp, err)
+	}
+	if gv.Group != conversion.Group {
+		return conversionFailed(resp, fmt.Errorf("bad desired API version %s, expect %s/<version>", req.DesiredAPIVersion, conversion.Group))
+	}
+	for _, raw := range req.Objects {
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(raw.Raw, &obj); err != nil {
+			return conversionFailed(resp, err)
+		}
+		if err := conversion.Convert(obj, gv.Version); err != nil {
+			return conversionFailed(resp, err)
+		}
+		out, err := json.Marshal(obj)
+		if err != nil {
+			return conversionFailed(resp, err)
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: out})
+	}
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+func conversionFailed(resp *apiextensionsv1beta1.ConversionResponse, err error) *apiextensionsv1beta1.ConversionResponse {
+	log.Errorf("IstioOperator conversion failed: %s", err)
+	resp.ConvertedObjects = nil
+	resp.Result = metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: fmt.Sprintf("IstioOperator conversion failed: %s", err),
+	}
+	return resp
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestIOPConverterServeHTTP(t *testing.T) {
+	iop := `{"apiVersion":"install.istio.io/v1alpha1","kind":"IstioOperator","metadata":{"name":"iop"},"spec":{}}`
+	tests := []struct {
+		desc              string
+		body              string
+		desiredAPIVersion string
+		wantCode          int
+		wantStatus        string
+		wantAPIVersion    string
+	}{
+		{
+			desc:              "convert",
+			desiredAPIVersion: "install.istio.io/v1alpha2",
+			wantCode:          http.StatusOK,
+			wantStatus:        metav1.StatusSuccess,
+			wantAPIVersion:    "install.istio.io/v1alpha2",
+		},
+		{
+			desc:              "other group",
+			desiredAPIVersion: "example.com/v1alpha2",
+			wantCode:          http.StatusOK,
+			wantStatus:        metav1.StatusFailure,
+		},
+		{
+			desc:              "unknown version",
+			desiredAPIVersion: "install.istio.io/v9",
+			wantCode:          http.StatusOK,
+			wantStatus:        metav1.StatusFailure,
+		},
+		{
+			desc:     "bad review",
+			body:     "{",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			body := tt.body
+			if body == "" {
+				b, err := json.Marshal(&apiextensionsv1beta1.ConversionReview{
+					Request: &apiextensionsv1beta1.ConversionRequest{
+						UID:               "uid",
+						DesiredAPIVersion: tt.desiredAPIVersion,
+						Objects:           []runtime.RawExtension{{Raw: []byte(iop)}},
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = string(b)
+			}
+			w := httptest.NewRecorder()
+			(&iopConverter{}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewBufferString(body)))
+			if w.Code != tt.wantCode {
+				t.Fatalf("got status code %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			review := &apiextensionsv1beta1.ConversionReview{}
+			if err := json.NewDecoder(w.Body).Decode(review); err != nil {
+				t.Fatal(err)
+			}
+			if review.Response == nil {
+				t.Fatal("got no response")
+			}
+			if got := review.Response.UID; got != "uid" {
+				t.Errorf("got UID %s, want uid", got)
+			}
+			if got := review.Response.Result.Status; got != tt.wantStatus {
+				t.Fatalf("got result %s (%s), want %s", got, review.Response.Result.Message, tt.wantStatus)
+			}
+			if tt.wantAPIVersion == "" {
+				if len(review.Response.ConvertedObjects) != 0 {
+					t.Errorf("got %d converted objects for a failed conversion, want none", len(review.Response.ConvertedObjects))
+				}
+				return
+			}
+			if len(review.Response.ConvertedObjects) != 1 {
+				t.Fatalf("got %d converted objects, want 1", len(review.Response.ConvertedObjects))
+			}
+			if got := string(review.Response.ConvertedObjects[0].Raw); !strings.Contains(got, `"apiVersion":"`+tt.wantAPIVersion+`"`) {
+				t.Errorf("got converted object %s, want apiVersion %s", got, tt.wantAPIVersion)
+			}
+		})
+	}
+}
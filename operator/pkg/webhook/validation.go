@@ -0,0 +1,128 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/helmreconciler"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/translate"
+	"istio.io/istio/operator/pkg/util"
+	"istio.io/istio/operator/pkg/validate"
+	"istio.io/pkg/log"
+)
+
+// iopValidator is an admission.Handler which rejects IstioOperator resources that fail validation.
+type iopValidator struct {
+	// client is used to list the existing IstioOperator resources.
+	client client.Client
+}
+
+// newIOPValidator creates an iopValidator which lists the existing IstioOperator resources with c.
+func newIOPValidator(c client.Client) *iopValidator {
+	return &iopValidator{client: c}
+}
+
+// Handle implements admission.Handler.
+func (v *iopValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1beta1.Delete {
+		return admission.Allowed("")
+	}
+	iop, err := validate.UnmarshalIOP(string(req.Object.Raw))
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if v.client == nil {
+		return admission.Errored(http.StatusInternalServerError, errors.New("IstioOperator validator has no client"))
+	}
+	iopList := &v1alpha1.IstioOperatorList{}
+	if err := v.client.List(ctx, iopList); err != nil {
+		log.Errorf("failed to list IstioOperator resources: %s", err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if errs := validateIstioOperator(req.Namespace, req.Name, iop, iopList.Items); len(errs) != 0 {
+		log.Infof("rejecting IstioOperator %s/%s: %s", req.Namespace, req.Name, errs)
+		return admission.Denied(errs.Error())
+	}
+	return admission.Allowed("")
+}
+
+// validateIstioOperator runs schema and semantic checks on the IstioOperator with the given namespace and name.
+// existing is the list of IstioOperator resources already in the cluster, which may include a previous version of
+// iop itself. Only one CR may install the control plane of each revision. User gateway CRs, installed-state CRs and
+// CRs which only install gateways share the revision of a control plane.
+func validateIstioOperator(namespace, name string, iop *v1alpha1.IstioOperator, existing []v1alpha1.IstioOperator) util.Errors {
+	var errs util.Errors
+	if err := validate.ValidIOP(iop); err != nil {
+		errs = util.AppendErr(errs, err)
+	}
+	if iop.Spec == nil || v1alpha1.IsUserGateway(iop) || v1alpha1.IsInstalledState(name) {
+		return errs
+	}
+	installs, err := installsControlPlane(iop)
+	if err != nil {
+		return util.AppendErr(errs, err)
+	}
+	if !installs {
+		return errs
+	}
+	for i := range existing {
+		e := &existing[i]
+		if e.Namespace == namespace && e.Name == name {
+			continue
+		}
+		if e.Spec == nil || e.Spec.Revision != iop.Spec.Revision || v1alpha1.IsUserGateway(e) || v1alpha1.IsInstalledState(e.Name) {
+			continue
+		}
+		installs, err := installsControlPlane(e)
+		if err != nil {
+			// An existing CR which cannot be merged with its profile is assumed to install its control plane.
+			log.Warnf("failed to merge IstioOperator %s/%s with its profile: %s", e.Namespace, e.Name, err)
+		} else if !installs {
+			continue
+		}
+		errs = util.AppendErr(errs, fmt.Errorf("revision %q is already used by IstioOperator %s/%s",
+			iop.Spec.Revision, e.Namespace, e.Name))
+	}
+	return errs
+}
+
+// installsControlPlane reports whether iop, merged with its profile, enables any of the core components, rather
+// than only gateways or addons.
+func installsControlPlane(iop *v1alpha1.IstioOperator) (bool, error) {
+	spec, err := helmreconciler.MergeIOPSWithProfile(iop)
+	if err != nil {
+		return false, err
+	}
+	for _, cn := range name.AllCoreComponentNames {
+		enabled, err := translate.IsComponentEnabledInSpec(cn, spec)
+		if err != nil {
+			return false, err
+		}
+		if enabled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,278 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"istio.io/istio/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/istio/operator/pkg/validate"
+)
+
+func TestValidateIstioOperator(t *testing.T) {
+	existing := []v1alpha1.IstioOperator{
+		*mustIOP(t, `
+metadata:
+  namespace: istio-system
+  name: canary
+spec:
+  revision: canary
+`),
+		*mustIOP(t, `
+metadata:
+  namespace: istio-system
+  name: stable
+spec:
+  revision: ""
+`),
+		*mustIOP(t, `
+metadata:
+  namespace: istio-system
+  name: gateways
+spec:
+  profile: empty
+  revision: gateways
+  components:
+    ingressGateways:
+    - name: istio-ingressgateway
+      enabled: true
+`),
+	}
+	tests := []struct {
+		desc      string
+		namespace string
+		name      string
+		iopYAML   string
+		wantErr   bool
+	}{
+		{
+			desc:      "new revision",
+			namespace: "istio-system",
+			name:      "other",
+			iopYAML: `
+spec:
+  revision: other
+`,
+		},
+		{
+			desc:      "update of existing CR",
+			namespace: "istio-system",
+			name:      "canary",
+			iopYAML: `
+spec:
+  revision: canary
+`,
+		},
+		{
+			desc:      "conflicting revision",
+			namespace: "istio-system",
+			name:      "canary-copy",
+			iopYAML: `
+spec:
+  revision: canary
+`,
+			wantErr: true,
+		},
+		{
+			desc:      "conflicting default revision",
+			namespace: "istio-system",
+			name:      "default",
+			iopYAML: `
+spec:
+  profile: default
+`,
+			wantErr: true,
+		},
+		{
+			desc:      "user gateway on the revision of a control plane",
+			namespace: "apps",
+			name:      "app-gateway",
+			iopYAML: `
+metadata:
+  annotations:
+    install.istio.io/user-gateway: "true"
+spec:
+  revision: canary
+  components:
+    ingressGateways:
+    - name: app-ingressgateway
+      enabled: true
+`,
+		},
+		{
+			desc:      "installed-state CR",
+			namespace: "istio-system",
+			name:      "installed-state-canary",
+			iopYAML: `
+spec:
+  revision: canary
+`,
+		},
+		{
+			desc:      "gateways only CR on the revision of a control plane",
+			namespace: "istio-system",
+			name:      "canary-gateways",
+			iopYAML: `
+spec:
+  profile: empty
+  revision: canary
+  components:
+    ingressGateways:
+    - name: istio-ingressgateway
+      enabled: true
+`,
+		},
+		{
+			desc:      "control plane on the revision of a gateways only CR",
+			namespace: "istio-system",
+			name:      "gateways-control-plane",
+			iopYAML: `
+spec:
+  revision: gateways
+`,
+		},
+		{
+			desc:      "invalid spec",
+			namespace: "istio-system",
+			name:      "bad",
+			iopYAML: `
+spec:
+  revision: bad
+  hub: "docker.io/istio bad"
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := validateIstioOperator(tt.namespace, tt.name, mustIOP(t, tt.iopYAML), existing)
+			if gotErr := len(errs) != 0; gotErr != tt.wantErr {
+				t.Errorf("validateIstioOperator() got errors: %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIOPValidatorHandle(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewFakeClientWithScheme(s, mustIOP(t, `
+metadata:
+  namespace: istio-system
+  name: canary
+spec:
+  revision: canary
+`))
+	tests := []struct {
+		desc      string
+		validator *iopValidator
+		operation admissionv1beta1.Operation
+		iopYAML   string
+		wantAllow bool
+		wantCode  int32
+	}{
+		{
+			desc:      "allowed",
+			validator: newIOPValidator(c),
+			operation: admissionv1beta1.Create,
+			iopYAML: `
+spec:
+  revision: other
+`,
+			wantAllow: true,
+			wantCode:  http.StatusOK,
+		},
+		{
+			desc:      "denied",
+			validator: newIOPValidator(c),
+			operation: admissionv1beta1.Create,
+			iopYAML: `
+spec:
+  revision: canary
+`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			desc:      "user gateway allowed",
+			validator: newIOPValidator(c),
+			operation: admissionv1beta1.Create,
+			iopYAML: `
+metadata:
+  annotations:
+    install.istio.io/user-gateway: "true"
+spec:
+  revision: canary
+`,
+			wantAllow: true,
+			wantCode:  http.StatusOK,
+		},
+		{
+			desc:      "not an IstioOperator",
+			validator: newIOPValidator(c),
+			operation: admissionv1beta1.Update,
+			iopYAML:   `spec: [`,
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			desc:      "delete",
+			validator: &iopValidator{},
+			operation: admissionv1beta1.Delete,
+			wantAllow: true,
+			wantCode:  http.StatusOK,
+		},
+		{
+			desc:      "no client",
+			validator: &iopValidator{},
+			operation: admissionv1beta1.Create,
+			iopYAML: `
+spec:
+  revision: other
+`,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			req := admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+				Operation: tt.operation,
+				Namespace: "istio-system",
+				Name:      "new",
+				Object:    runtime.RawExtension{Raw: []byte(tt.iopYAML)},
+			}}
+			resp := tt.validator.Handle(context.Background(), req)
+			if resp.Allowed != tt.wantAllow || resp.Result.Code != tt.wantCode {
+				t.Errorf("Handle() got allowed %v, code %d (%s), want allowed %v, code %d", resp.Allowed, resp.Result.Code,
+					resp.Result.Message, tt.wantAllow, tt.wantCode)
+			}
+		})
+	}
+}
+
+func mustIOP(t *testing.T, iopYAML string) *v1alpha1.IstioOperator {
+	iop, err := validate.UnmarshalIOP(iopYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return iop
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"istio.io/pkg/log"
+)
+
+const (
+	// ValidatePath is the path the IstioOperator validating webhook is served on.
+	ValidatePath = "/validate-istiooperator"
+	// ConvertPath is the path the IstioOperator CRD conversion webhook is served on.
+	ConvertPath = "/convert-istiooperator"
+)
+
+// AddToManager registers the operator admission and conversion webhooks with the manager webhook server, if enabled.
+func AddToManager(m manager.Manager) error {
+	if !webhookOptions.Enabled {
+		log.Info("IstioOperator validation webhook is disabled")
+		return nil
+	}
+	srv := m.GetWebhookServer()
+	srv.Port = webhookOptions.Port
+	srv.CertDir = webhookOptions.CertDir
+	srv.Register(ValidatePath, &webhook.Admission{Handler: newIOPValidator(m.GetClient())})
+	srv.Register(ConvertPath, &iopConverter{})
+	log.Infof("IstioOperator webhooks registered on port %d, paths %s, %s", webhookOptions.Port, ValidatePath, ConvertPath)
+	return nil
+}
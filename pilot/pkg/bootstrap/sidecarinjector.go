@@ -139,7 +139,7 @@ func (s *Server) patchCertLoop(client kubernetes.Interface, stopCh <-chan struct
 
 				if oldConfig.ResourceVersion != newConfig.ResourceVersion {
 					for i, w := range newConfig.Webhooks {
-						if w.Name == webhookName && !bytes.Equal(newConfig.Webhooks[i].ClientConfig.CABundle, caCertPem) {
+						if util.IsWebhookEntry(w.Name, webhookName) && !bytes.Equal(newConfig.Webhooks[i].ClientConfig.CABundle, caCertPem) {
 							log.Infof("Detected a change in CABundle, patching MutatingWebhookConfiguration again")
 							shouldPatch <- struct{}{}
 							break
@@ -89,6 +89,7 @@ var (
 		annotation.SidecarTrafficExcludeOutboundPorts.Name:        ValidateExcludeOutboundPorts,
 		annotation.SidecarTrafficKubevirtInterfaces.Name:          alwaysValidFunc,
 		annotation.PrometheusMergeMetrics.Name:                    validateBool,
+		InjectTemplatesAnnotation:                                 alwaysValidFunc,
 	}
 )
 
@@ -128,6 +129,10 @@ const (
 const (
 	// ProxyContainerName is used by e2e integration tests for fetching logs
 	ProxyContainerName = "istio-proxy"
+
+	// InjectTemplatesAnnotation is a pod annotation naming the template in Config.Templates the pod is injected with,
+	// instead of the sidecar template.
+	InjectTemplatesAnnotation = "inject.istio.io/templates"
 )
 
 // SidecarInjectionSpec collects all container types and volumes for
@@ -166,6 +171,11 @@ type Config struct {
 	// expansion over the `SidecarTemplateData`.
 	Template string `json:"template"`
 
+	// Templates are additional named templates, which pods select with the inject.istio.io/templates annotation.
+	// A container in the pod with the same name as an injected container, e.g. the istio-proxy container of a gateway
+	// with image "auto", is merged with the injected container rather than added to.
+	Templates map[string]string `json:"templates"`
+
 	// NeverInjectSelector: Refuses the injection on pods whose labels match this selector.
 	// It's an array of label selectors, that will be OR'ed, meaning we will iterate
 	// over it and stop at the first match
@@ -182,6 +192,20 @@ type Config struct {
 	InjectedAnnotations map[string]string `json:"injectedAnnotations"`
 }
 
+// TemplateFor returns the template the pod with metadata is injected with, which is the template named in its
+// inject.istio.io/templates annotation or else the sidecar template.
+func (c *Config) TemplateFor(metadata *metav1.ObjectMeta) (string, error) {
+	name, ok := metadata.GetAnnotations()[InjectTemplatesAnnotation]
+	if !ok {
+		return c.Template, nil
+	}
+	t, ok := c.Templates[name]
+	if !ok {
+		return "", fmt.Errorf("pod requests unknown injection template %q", name)
+	}
+	return t, nil
+}
+
 func validateCIDRList(cidrs string) error {
 	if len(cidrs) > 0 {
 		for _, cidr := range strings.Split(cidrs, ",") {
@@ -306,6 +330,14 @@ func injectRequired(ignored []string, config *Config, podSpec *corev1.PodSpec, m
 		return false
 	}
 
+	// Skip injection into pods which select Windows or other non Linux nodes,
+	// since the proxy and init containers are only built for Linux.
+	for _, label := range []string{"kubernetes.io/os", "beta.kubernetes.io/os"} {
+		if nodeOS, ok := podSpec.NodeSelector[label]; ok && nodeOS != "linux" {
+			return false
+		}
+	}
+
 	// skip special kubernetes system namespaces
 	for _, namespace := range ignored {
 		if metadata.Namespace == namespace {
@@ -419,6 +419,110 @@ func addContainer(target, added []corev1.Container, basePath string) (patch []rf
 	return patch
 }
 
+// mergeContainers merges the added containers into the containers of the same name in target, which are not
+// removed, e.g. the istio-proxy container of a gateway injected with the gateway template. It returns the patch and
+// the added containers which have no container to merge into.
+func mergeContainers(target, added []corev1.Container, removed []string, basePath string) (patch []rfc6902PatchOperation,
+	rest []corev1.Container) {
+	// The remaining containers, in the order they have once the removed containers are removed.
+	var remaining []corev1.Container
+	for _, c := range target {
+		if !containsString(removed, c.Name) {
+			remaining = append(remaining, c)
+		}
+	}
+	for _, add := range added {
+		i := -1
+		for j, c := range remaining {
+			if c.Name == add.Name {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			rest = append(rest, add)
+			continue
+		}
+		patch = append(patch, rfc6902PatchOperation{
+			Op:    "replace",
+			Path:  fmt.Sprintf("%s/%d", basePath, i),
+			Value: mergeContainer(remaining[i], add),
+		})
+	}
+	return patch, rest
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeContainer returns the injected container merged with the container of the same name in the pod. The ports,
+// env vars and volume mounts of both are kept, and the args, resources, probes and security context of the pod's
+// container take precedence if set. The image is always the injected one.
+func mergeContainer(existing, injected corev1.Container) corev1.Container {
+	out := injected
+	if len(existing.Args) != 0 {
+		out.Args = existing.Args
+	}
+	if len(existing.Resources.Limits) != 0 || len(existing.Resources.Requests) != 0 {
+		out.Resources = existing.Resources
+	}
+	if existing.ReadinessProbe != nil {
+		out.ReadinessProbe = existing.ReadinessProbe
+	}
+	if existing.LivenessProbe != nil {
+		out.LivenessProbe = existing.LivenessProbe
+	}
+	if existing.SecurityContext != nil {
+		out.SecurityContext = existing.SecurityContext
+	}
+
+	out.Ports = append([]corev1.ContainerPort{}, existing.Ports...)
+	for _, p := range injected.Ports {
+		found := false
+		for _, ep := range existing.Ports {
+			if ep.ContainerPort == p.ContainerPort && ep.Protocol == p.Protocol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out.Ports = append(out.Ports, p)
+		}
+	}
+
+	out.Env = nil
+	envIndex := make(map[string]int)
+	for _, e := range append(append([]corev1.EnvVar{}, injected.Env...), existing.Env...) {
+		if i, ok := envIndex[e.Name]; ok {
+			out.Env[i] = e
+			continue
+		}
+		envIndex[e.Name] = len(out.Env)
+		out.Env = append(out.Env, e)
+	}
+
+	out.VolumeMounts = append([]corev1.VolumeMount{}, injected.VolumeMounts...)
+	for _, m := range existing.VolumeMounts {
+		found := false
+		for _, im := range injected.VolumeMounts {
+			if im.MountPath == m.MountPath {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out.VolumeMounts = append(out.VolumeMounts, m)
+		}
+	}
+	return out
+}
+
 func addSecurityContext(target *corev1.PodSecurityContext, basePath string) (patch []rfc6902PatchOperation) {
 	patch = append(patch, rfc6902PatchOperation{
 		Op:    "add",
@@ -595,7 +699,9 @@ func createPatch(pod *corev1.Pod, prevStatus *SidecarInjectionStatus, revision s
 	}
 
 	patch = append(patch, addContainer(pod.Spec.InitContainers, sic.InitContainers, "/spec/initContainers")...)
-	patch = append(patch, addContainer(pod.Spec.Containers, sic.Containers, "/spec/containers")...)
+	merged, added := mergeContainers(pod.Spec.Containers, sic.Containers, prevStatus.Containers, "/spec/containers")
+	patch = append(patch, merged...)
+	patch = append(patch, addContainer(pod.Spec.Containers, added, "/spec/containers")...)
 	patch = append(patch, addVolume(pod.Spec.Volumes, sic.Volumes, "/spec/volumes")...)
 	patch = append(patch, addImagePullSecrets(pod.Spec.ImagePullSecrets, sic.ImagePullSecrets, "/spec/imagePullSecrets")...)
 
@@ -804,7 +910,17 @@ func (wh *Webhook) inject(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionRespons
 		deployMeta.Name = pod.Name
 	}
 
-	spec, iStatus, err := InjectionData(wh.Config.Template, wh.valuesConfig, wh.sidecarTemplateVersion, typeMetadata, deployMeta, &pod.Spec, &pod.ObjectMeta, wh.meshConfig.DefaultConfig, wh.meshConfig) // nolint: lll
+	tmpl, err := wh.Config.TemplateFor(&pod.ObjectMeta)
+	if err != nil {
+		handleError(fmt.Sprintf("Injection template: err=%v\n", err))
+		return toAdmissionResponse(err)
+	}
+	version := wh.sidecarTemplateVersion
+	if tmpl != wh.Config.Template {
+		version = sidecarTemplateVersionHash(tmpl)
+	}
+
+	spec, iStatus, err := InjectionData(tmpl, wh.valuesConfig, version, typeMetadata, deployMeta, &pod.Spec, &pod.ObjectMeta, wh.meshConfig.DefaultConfig, wh.meshConfig) // nolint: lll
 	if err != nil {
 		handleError(fmt.Sprintf("Injection data: err=%v spec=%v\n", err, iStatus))
 		return toAdmissionResponse(err)
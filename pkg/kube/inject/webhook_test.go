@@ -24,6 +24,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -134,6 +135,9 @@ func TestInjectRequired(t *testing.T) {
 	podSpecHostNetwork := &corev1.PodSpec{
 		HostNetwork: true,
 	}
+	podSpecWindows := &corev1.PodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+	}
 	cases := []struct {
 		config  *Config
 		podSpec *corev1.PodSpec
@@ -246,6 +250,18 @@ func TestInjectRequired(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			config: &Config{
+				Policy: InjectionPolicyEnabled,
+			},
+			podSpec: podSpecWindows,
+			meta: &metav1.ObjectMeta{
+				Name:        "windows-node-selector",
+				Namespace:   "test-namespace",
+				Annotations: map[string]string{annotation.SidecarInject.Name: "true"},
+			},
+			want: false,
+		},
 		{
 			config: &Config{
 				Policy: "wrong_policy",
@@ -896,7 +912,7 @@ func loadInjectionConfigMap(t testing.TB, settings string) (template *Config, va
 		t.Fatal(err)
 	}
 	l := clog.NewConsoleLogger(true, os.Stdout, os.Stderr)
-	manifests, _, err := operatormesh.GenManifests(nil, oy, false, nil, l)
+	manifests, _, err := operatormesh.GenManifests(nil, oy, nil, false, nil, l)
 	if err != nil {
 		t.Fatalf("failed to generate manifests: %v", err)
 	}
@@ -1603,3 +1619,101 @@ func defaultInstallPackageDir() string {
 	}
 	return filepath.Join(wd, "../../../operator/cmd/mesh/testdata/manifest-generate/data-snapshot")
 }
+
+func TestTemplateFor(t *testing.T) {
+	config := &Config{
+		Template:  "sidecar",
+		Templates: map[string]string{"gateway": "gateway"},
+	}
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name: "default",
+			want: "sidecar",
+		},
+		{
+			name:        "named",
+			annotations: map[string]string{InjectTemplatesAnnotation: "gateway"},
+			want:        "gateway",
+		},
+		{
+			name:        "unknown",
+			annotations: map[string]string{InjectTemplatesAnnotation: "egress"},
+			wantErr:     true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := config.TemplateFor(&metav1.ObjectMeta{Annotations: c.annotations})
+			if (err != nil) != c.wantErr {
+				t.Fatalf("got error %v, want error %v", err, c.wantErr)
+			}
+			if got != c.want {
+				t.Errorf("got template %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMergeContainers(t *testing.T) {
+	target := []corev1.Container{
+		{Name: "istio-init"},
+		{
+			Name:  "istio-proxy",
+			Image: "auto",
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080}, {ContainerPort: 15090, Name: "http-envoy-prom"}},
+			Env:   []corev1.EnvVar{{Name: "ISTIO_META_NETWORK", Value: "network-a"}},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "config-volume", MountPath: "/etc/istio/config"},
+				{Name: "podinfo", MountPath: "/etc/istio/pod"},
+			},
+		},
+	}
+	added := []corev1.Container{
+		{
+			Name:  "istio-proxy",
+			Image: "docker.io/istio/proxyv2:latest",
+			Args:  []string{"proxy", "router"},
+			Ports: []corev1.ContainerPort{{ContainerPort: 15090, Name: "http-envoy-prom"}},
+			Env: []corev1.EnvVar{
+				{Name: "ISTIO_META_NETWORK", Value: "network-b"},
+				{Name: "POD_NAME"},
+			},
+			VolumeMounts: []corev1.VolumeMount{{Name: "istio-podinfo", MountPath: "/etc/istio/pod"}},
+		},
+		{Name: "other"},
+	}
+
+	patch, rest := mergeContainers(target, added, []string{"istio-init"}, "/spec/containers")
+	if len(rest) != 1 || rest[0].Name != "other" {
+		t.Fatalf("got remaining containers %v, want other", rest)
+	}
+	if len(patch) != 1 || patch[0].Op != "replace" || patch[0].Path != "/spec/containers/0" {
+		t.Fatalf("got patch %v, want replace of /spec/containers/0", patch)
+	}
+	got := patch[0].Value.(corev1.Container)
+	if got.Image != "docker.io/istio/proxyv2:latest" {
+		t.Errorf("got image %s, want injected image", got.Image)
+	}
+	if len(got.Args) != 2 {
+		t.Errorf("got args %v, want injected args", got.Args)
+	}
+	if len(got.Ports) != 2 {
+		t.Errorf("got ports %v, want 8080 and 15090", got.Ports)
+	}
+	wantEnv := []corev1.EnvVar{{Name: "ISTIO_META_NETWORK", Value: "network-a"}, {Name: "POD_NAME"}}
+	if !reflect.DeepEqual(got.Env, wantEnv) {
+		t.Errorf("got env %v, want %v", got.Env, wantEnv)
+	}
+	var mounts []string
+	for _, m := range got.VolumeMounts {
+		mounts = append(mounts, m.Name)
+	}
+	if want := []string{"istio-podinfo", "config-volume"}; !reflect.DeepEqual(mounts, want) {
+		t.Errorf("got volume mounts %v, want %v", mounts, want)
+	}
+}
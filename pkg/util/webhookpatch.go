@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"k8s.io/api/admissionregistration/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,7 +28,13 @@ import (
 	admissionregistrationv1beta1client "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
 )
 
-// PatchMutatingWebhookConfig patches a CA bundle into the specified webhook config.
+// IsWebhookEntry returns whether the webhook entry name is webhookName or a variant of it, e.g.
+// gateway.sidecar-injector.istio.io for sidecar-injector.istio.io.
+func IsWebhookEntry(name, webhookName string) bool {
+	return name == webhookName || strings.HasSuffix(name, "."+webhookName)
+}
+
+// PatchMutatingWebhookConfig patches a CA bundle into the specified webhook config and its variants.
 func PatchMutatingWebhookConfig(client admissionregistrationv1beta1client.MutatingWebhookConfigurationInterface,
 	webhookConfigName, webhookName string, caBundle []byte) error {
 	config, err := client.Get(context.TODO(), webhookConfigName, metav1.GetOptions{})
@@ -40,10 +47,9 @@ func PatchMutatingWebhookConfig(client admissionregistrationv1beta1client.Mutati
 	}
 	found := false
 	for i, w := range config.Webhooks {
-		if w.Name == webhookName {
+		if IsWebhookEntry(w.Name, webhookName) {
 			config.Webhooks[i].ClientConfig.CABundle = caBundle
 			found = true
-			break
 		}
 	}
 	if !found {
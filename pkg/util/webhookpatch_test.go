@@ -81,6 +81,32 @@ func TestMutatingWebhookPatch(t *testing.T) {
 			[]byte("fake CA"),
 			"",
 		},
+		{
+			"SuccessfullyPatchedVariants",
+			admissionregistrationv1beta1.MutatingWebhookConfigurationList{
+				Items: []admissionregistrationv1beta1.MutatingWebhookConfiguration{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "config1",
+						},
+						Webhooks: []admissionregistrationv1beta1.MutatingWebhook{
+							{
+								Name:         "webhook1",
+								ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{},
+							},
+							{
+								Name:         "gateway.webhook1",
+								ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{},
+							},
+						},
+					},
+				},
+			},
+			"config1",
+			"webhook1",
+			[]byte("fake CA"),
+			"",
+		},
 	}
 	for _, tc := range ts {
 		t.Run(tc.name, func(t *testing.T) {
@@ -101,8 +127,13 @@ func TestMutatingWebhookPatch(t *testing.T) {
 				if err != nil {
 					t.Fatalf("Fail to parse the patch: %s", err.Error())
 				}
-				if !bytes.Equal(config.Webhooks[0].ClientConfig.CABundle, tc.pemData) {
-					t.Fatalf("Incorrect CA bundle: expect %s got %s", tc.pemData, config.Webhooks[0].ClientConfig.CABundle)
+				if len(config.Webhooks) != len(tc.configs.Items[0].Webhooks) {
+					t.Fatalf("Incorrect number of patched webhooks: expect %d got %d", len(tc.configs.Items[0].Webhooks), len(config.Webhooks))
+				}
+				for _, w := range config.Webhooks {
+					if !bytes.Equal(w.ClientConfig.CABundle, tc.pemData) {
+						t.Fatalf("Incorrect CA bundle of %s: expect %s got %s", w.Name, tc.pemData, w.ClientConfig.CABundle)
+					}
 				}
 			}
 		})